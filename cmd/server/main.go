@@ -3,38 +3,57 @@ package main
 
 import (
 	"context"
-	"log"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 
 	// --- Importações Internas Padronizadas ---
 
+	"github.com/luiszkm/masterCostrutora/internal/domain/notificacoes"
 	"github.com/luiszkm/masterCostrutora/internal/events"
 	"github.com/luiszkm/masterCostrutora/internal/handler/http/router"
 	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
 	"github.com/luiszkm/masterCostrutora/internal/platform/bus"
+	"github.com/luiszkm/masterCostrutora/internal/platform/stream"
 	"github.com/luiszkm/masterCostrutora/pkg/auth"
+	"github.com/luiszkm/masterCostrutora/pkg/cache"
 	"github.com/luiszkm/masterCostrutora/pkg/logging"
+	"github.com/luiszkm/masterCostrutora/pkg/metrics"
+	"github.com/luiszkm/masterCostrutora/pkg/notificacao"
 	"github.com/luiszkm/masterCostrutora/pkg/security"
+	"github.com/luiszkm/masterCostrutora/pkg/tracing"
 
 	// Usaremos um único nome 'postgres' para o pacote de repositório para clareza
 
+	alertas_handler "github.com/luiszkm/masterCostrutora/internal/handler/http/alertas"
+	clientes_handler "github.com/luiszkm/masterCostrutora/internal/handler/http/clientes"
 	dashboard_handler "github.com/luiszkm/masterCostrutora/internal/handler/http/dashboard"
+	eventos_handler "github.com/luiszkm/masterCostrutora/internal/handler/http/eventos"
 	financeiro_handler "github.com/luiszkm/masterCostrutora/internal/handler/http/financeiro"
 	identidade_handler "github.com/luiszkm/masterCostrutora/internal/handler/http/identidade"
+	integracoes_handler "github.com/luiszkm/masterCostrutora/internal/handler/http/integracoes"
+	notificacoes_handler "github.com/luiszkm/masterCostrutora/internal/handler/http/notificacoes"
 	obras_handler "github.com/luiszkm/masterCostrutora/internal/handler/http/obras"
 	pessoal_handler "github.com/luiszkm/masterCostrutora/internal/handler/http/pessoal"
+	portal_handler "github.com/luiszkm/masterCostrutora/internal/handler/http/portal"
 	suprimentos_handler "github.com/luiszkm/masterCostrutora/internal/handler/http/suprimentos"
 
+	alertas_service "github.com/luiszkm/masterCostrutora/internal/service/alertas"
+	clientes_service "github.com/luiszkm/masterCostrutora/internal/service/clientes"
 	dashboard_service "github.com/luiszkm/masterCostrutora/internal/service/dashboard"
 	financeiro_service "github.com/luiszkm/masterCostrutora/internal/service/financeiro"
 	identidade_service "github.com/luiszkm/masterCostrutora/internal/service/identidade"
+	integracoes_service "github.com/luiszkm/masterCostrutora/internal/service/integracoes"
+	notificacoes_service "github.com/luiszkm/masterCostrutora/internal/service/notificacoes"
 	obras_service "github.com/luiszkm/masterCostrutora/internal/service/obras"
 	pessoal_service "github.com/luiszkm/masterCostrutora/internal/service/pessoal"
+	portal_service "github.com/luiszkm/masterCostrutora/internal/service/portal"
 	suprimentos_service "github.com/luiszkm/masterCostrutora/internal/service/suprimentos"
 
 	financeiro_events "github.com/luiszkm/masterCostrutora/internal/service/financeiro/events"
@@ -43,7 +62,8 @@ import (
 
 func main() {
 	// 1. Configuração do Logger Estruturado (Correto)
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	// O LogHandler inclui trace_id/span_id nos logs emitidos com contexto
+	logger := slog.New(tracing.NovoLogHandler(slog.NewJSONHandler(os.Stdout, nil)))
 	slog.SetDefault(logger)
 	logger.Info("iniciando o sistema Master Construtora")
 
@@ -76,9 +96,36 @@ func main() {
 	if port == "" {
 		port = "8080" // Valor padrão se não estiver definido
 	}
+	// /metrics fica numa porta administrativa, fora da API pública
+	metricsPort := os.Getenv("METRICS_PORT")
+	if metricsPort == "" {
+		metricsPort = "9090"
+	}
+
+	// 2.1. Tracing distribuído (W3C traceparent). TRACING_EXPORTER=stdout imprime os spans
+	// em JSON; sem a variável, os spans são propagados mas descartados.
+	var exportador tracing.Exportador = tracing.ExportadorNulo{}
+	if os.Getenv("TRACING_EXPORTER") == "stdout" {
+		exportador = tracing.NovoExportadorStdout(os.Stdout)
+	}
+	tracer := tracing.NovoTracer("master-construtora", exportador)
+	tracing.DefinirTracerPadrao(tracer)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := tracer.Encerrar(ctx); err != nil {
+			logger.Error("falha ao encerrar o tracer", "erro", err)
+		}
+	}()
 
 	// 3. Inicialização de Plataforma (Correto)
-	dbpool, err := pgxpool.New(context.Background(), dbURL)
+	dbConfig, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		logger.Error("DATABASE_URL inválida", "erro", err)
+		os.Exit(1)
+	}
+	dbConfig.ConnConfig.Tracer = tracing.PgxTracer{}
+	dbpool, err := pgxpool.NewWithConfig(context.Background(), dbConfig)
 	if err != nil {
 		logger.Error("não foi possível conectar ao banco de dados", "erro", err)
 		os.Exit(1)
@@ -90,6 +137,11 @@ func main() {
 	passwordHasher := security.NewBcryptHasher()
 	eventBus := bus.NovoEventBus(logger.With("component", "EventBus"))
 
+	// Métricas Prometheus (/metrics)
+	registroMetricas := metrics.NovoRegistro()
+	metrics.RegistrarPgxPool(registroMetricas, dbpool)
+	eventBus.DefinirObservador(metrics.NovoEventBusMetrics(registroMetricas))
+
 	// Repositórios Concretos
 	usuarioRepo := postgres.NewUsuarioRepository(dbpool, logger)
 	obraRepo := postgres.NovaObraRepository(dbpool, logger)
@@ -107,13 +159,35 @@ func main() {
 	contaReceberRepo := postgres.NovoContaReceberRepositoryPostgres(dbpool)
 	contaPagarRepo := postgres.NovoContaPagarRepositoryPostgres(dbpool)
 	cronogramaRepo := postgres.NovoCronogramaRecebimentoRepositoryPostgres(dbpool)
+	webhookAssinaturaRepo := postgres.NovoAssinaturaWebhookRepository(dbpool, logger)
+	webhookEntregaRepo := postgres.NovoEntregaWebhookRepository(dbpool, logger)
+	dependenciaEtapaRepo := postgres.NovoDependenciaEtapaRepository(dbpool, logger)
+	orcamentoAnaliticoRepo := postgres.NovoOrcamentoAnaliticoRepository(dbpool, logger)
+	diarioObraRepo := postgres.NovoDiarioObraRepository(dbpool, logger)
+	medicaoRepo := postgres.NovoMedicaoRepository(dbpool, logger)
+	aditivoRepo := postgres.NovoAditivoRepository(dbpool, logger)
+	clienteRepo := postgres.NovoClienteRepository(dbpool, logger)
+	portalLinkRepo := postgres.NovoPortalLinkRepository(dbpool, logger)
+	efetivoPlanejadoRepo := postgres.NovoEfetivoPlanejadoRepository(dbpool, logger)
+	modeloObraRepo := postgres.NovoModeloObraRepository(dbpool, logger)
+	vistoriaRepo := postgres.NovoVistoriaRepository(dbpool, logger)
+	checklistQualidadeRepo := postgres.NovoChecklistQualidadeRepository(dbpool, logger)
+	alertaRepo := postgres.NovoAlertaRepository(dbpool, logger)
+	notificacaoRepo := postgres.NovoNotificacaoRepository(dbpool, logger)
 
 	// Serviços
 	identidadeSvc := identidade_service.NovoServico(usuarioRepo, passwordHasher, jwtService, logger)
+
+	// Serviço de alocações (período na obra, encerramento, transferência e conflitos)
+	alocacaoSvc := obras_service.NovoAlocacaoService(obraRepo, alocacaoRepo, funcionarioRepo, alocacaoRepo, logger)
+
+	// Calendário de capacidade (efetivo alocado x disponível x planejado, por cargo e semana)
+	capacidadeSvc := obras_service.NovoCapacidadeService(obraRepo, etapaRepo, efetivoPlanejadoRepo, alocacaoRepo, efetivoPlanejadoRepo, logger)
+
 	pessoalSvc := pessoal_service.NovoServico(
 		funcionarioRepo, // Satisafaz pessoal.FuncionarioRepository
 		apontamentoRepo, // A dependência que estava faltando
-		alocacaoRepo,    // Satisafaz pessoal.AlocacaoFinder
+		alocacaoSvc,     // Encerra as alocações no desligamento
 		obraRepo,        // Satisafaz pessoal.ObraFinder
 		eventBus,        // Satisafaz pessoal.EventPublisher
 		funcionarioRepo,
@@ -131,24 +205,86 @@ func main() {
 		logger,
 	)
 
+	// Cadastro de clientes e extrato (obras, contas a receber e pontualidade)
+	clientesSvc := clientes_service.NovoServico(clienteRepo, obraRepo, contaReceberRepo, logger)
+
 	// Services financeiros específicos
-	contaReceberSvc := financeiro_service.NovoContaReceberService(contaReceberRepo, eventBus, logger)
+	contaReceberSvc := financeiro_service.NovoContaReceberService(contaReceberRepo, clientesSvc, eventBus, logger)
 	contaPagarSvc := financeiro_service.NovoContaPagarService(contaPagarRepo, orcamentoRepo, fornecedorRepo, eventBus, logger)
 	
 	// Serviço do cronograma
-	cronogramaSvc := obras_service.NovoCronogramaService(cronogramaRepo, obraRepo, eventBus, logger, dbpool)
+	cronogramaSvc := obras_service.NovoCronogramaService(cronogramaRepo, obraRepo, etapaRepo, medicaoRepo, contaReceberRepo, eventBus, logger, dbpool)
+
+	// Serviço do ciclo de vida da obra (iniciar, concluir, cancelar)
+	transicaoSvc := obras_service.NovoTransicaoService(obraRepo, etapaRepo, cronogramaRepo, contaReceberRepo, contaPagarRepo, vistoriaRepo, eventBus, logger)
+
+	// Serviço do cronograma físico (dependências entre etapas e caminho crítico)
+	cronogramaFisicoSvc := obras_service.NovoCronogramaFisicoService(obraRepo, etapaRepo, dependenciaEtapaRepo, logger)
+
+	// Serviço do cronograma físico-financeiro (curva S e valor agregado)
+	fisicoFinanceiroSvc := obras_service.NovoFisicoFinanceiroService(obraRepo, etapaRepo, cronogramaRepo, obraRepo, logger)
+
+	// Serviço do orçamento analítico (previsto x realizado por etapa e categoria)
+	orcamentoAnaliticoSvc := obras_service.NovoOrcamentoAnaliticoService(obraRepo, etapaRepo, orcamentoAnaliticoRepo, obraRepo, logger)
+
+	// Serviço de resultado (margem e exposição de caixa por obra e da carteira)
+	resultadoSvc := obras_service.NovoResultadoService(obraRepo, etapaRepo, obraRepo, logger)
+
+	// Serviço do diário de obra (registro diário, assinatura e PDF)
+	diarioObraSvc := obras_service.NovoDiarioObraService(obraRepo, etapaRepo, alocacaoRepo, diarioObraRepo, funcionarioRepo, logger)
+
+	// Serviço de vistoria final (pendências, revistorias e termo de entrega)
+	vistoriaSvc := obras_service.NovoVistoriaService(obraRepo, etapaRepo, vistoriaRepo, logger)
+
+	// Serviço de medições (avanço por etapa que gera as parcelas de recebimento)
+	medicaoSvc := obras_service.NovoMedicaoService(obraRepo, etapaRepo, medicaoRepo, cronogramaRepo, eventBus, dbpool, logger)
+
+	// Serviço de aditivos (alterações de valor e prazo do contrato)
+	aditivoSvc := obras_service.NovoAditivoService(obraRepo, aditivoRepo, cronogramaRepo, cronogramaSvc, logger, dbpool)
+
+	// Serviço de modelos de obra (etapas, durações, dependências, orçamento e cronograma)
+	modeloObraSvc := obras_service.NovoModeloObraService(modeloObraRepo, obraRepo, etapaRepo, dependenciaEtapaRepo, orcamentoAnaliticoRepo, cronogramaRepo, etapaPadraoRepo, logger)
+
+	// Checklists de qualidade (FVS) por etapa padrão, instanciadas nas etapas das obras
+	checklistQualidadeSvc := obras_service.NovoChecklistQualidadeService(checklistQualidadeRepo, etapaRepo, etapaPadraoRepo, logger, dbpool)
 
 	obraSvc := obras_service.NovoServico(
 		obraRepo,
 		etapaRepo,
 		etapaPadraoRepo,
-		alocacaoRepo,
-		funcionarioRepo, // PessoalFinder implementado por FuncionarioRepository,
+		aditivoRepo, // Valor e prazo travados depois do primeiro aditivo
+		dependenciaEtapaRepo,
+		orcamentoAnaliticoRepo,
+		clientesSvc,   // Obras só são criadas para clientes cadastrados
+		modeloObraSvc, // Estrutura inicial a partir de um modelo ou de outra obra
+		cronogramaSvc, // Cronograma de recebimento do modelo
 		obraRepo,
+		cronogramaFisicoSvc,   // Reprograma as sucessoras quando uma etapa muda
+		checklistQualidadeSvc, // FVS das etapas novas e bloqueio da conclusão
+		transicaoSvc,          // Pendências financeiras que impedem a exclusão
 		logger,
 		dbpool, //
 	)
 
+	// Portal do cliente: links assinados com a mesma chave do JWT (com prefixo próprio no HMAC).
+	// PIX_CHAVE habilita o PIX copia e cola nas parcelas em aberto.
+	portalSvc := portal_service.NovoServico(
+		portalLinkRepo,
+		obraRepo,
+		etapaRepo,
+		diarioObraRepo,
+		cronogramaRepo,
+		contaReceberRepo,
+		[]byte(jwtSecret),
+		os.Getenv("PORTAL_URL_BASE"),
+		portal_service.DadosPix{
+			Chave:        os.Getenv("PIX_CHAVE"),
+			Beneficiario: os.Getenv("PIX_BENEFICIARIO"),
+			Cidade:       os.Getenv("PIX_CIDADE"),
+		},
+		logger,
+	)
+
 	suprimentosSvc := suprimentos_service.NovoServico(
 		fornecedorRepo,
 		produtoRepo,
@@ -161,8 +297,64 @@ func main() {
 		logger,
 	)
 
-	// Serviço do Dashboard
-	dashboardSvc := dashboard_service.NovoServicoDashboard(dashboardQuerier, logger, dashLogger)
+	// Serviço do Dashboard, com cache das consultas. Sem DASHBOARD_CACHE_URL o cache
+	// fica em memória; com redis://host:porta/db é compartilhado entre as réplicas.
+	var backendCache cache.Backend = cache.NovaMemoria()
+	if cacheURL := os.Getenv("DASHBOARD_CACHE_URL"); cacheURL != "" {
+		redis, err := cache.NovoRedis(cacheURL)
+		if err != nil {
+			logger.Error("configuração de cache inválida", "erro", err)
+			os.Exit(1)
+		}
+		backendCache = redis
+	}
+	dashboardCache := dashboard_service.NovoQuerierComCache(
+		dashboard_service.NovoQuerierComMetricas(dashboardQuerier, registroMetricas),
+		backendCache,
+		registroMetricas,
+		dashLogger,
+	)
+	dashboardSvc := dashboard_service.NovoServicoDashboard(
+		dashboardCache,
+		alertaRepo,
+		logger,
+		dashLogger,
+	)
+
+	// Webhooks de saída
+	webhookDispatcher := integracoes_service.NovoDispatcher(webhookAssinaturaRepo, webhookEntregaRepo, logger)
+	integracoesSvc := integracoes_service.NovoServico(webhookAssinaturaRepo, webhookEntregaRepo, webhookDispatcher, logger)
+
+	// Alertas: regras configuráveis avaliadas periodicamente
+	alertasSvc := alertas_service.NovoServico(alertaRepo, alertaRepo, postgres.NovoAvaliadorAlertas(dbpool, obraRepo, logger), logger)
+
+	// Notificações: canais externos habilitados conforme a configuração. Com
+	// NOTIFICACAO_CANAIS_FAKE=true todos os canais apenas registram no log.
+	canaisNotificacao := map[notificacoes.Canal]notificacao.Canal{
+		notificacoes.CanalWebhook: notificacao.NovoWebhook(),
+	}
+	if smtpURL := os.Getenv("NOTIFICACAO_SMTP_URL"); smtpURL != "" {
+		email, err := notificacao.NovoEmail(smtpURL, os.Getenv("NOTIFICACAO_EMAIL_REMETENTE"))
+		if err != nil {
+			logger.Error("configuração de e-mail inválida", "erro", err)
+			os.Exit(1)
+		}
+		canaisNotificacao[notificacoes.CanalEmail] = email
+	}
+	if gatewayURL := os.Getenv("NOTIFICACAO_GATEWAY_URL"); gatewayURL != "" {
+		gateway, err := notificacao.NovoGateway(gatewayURL, os.Getenv("NOTIFICACAO_GATEWAY_TOKEN"))
+		if err != nil {
+			logger.Error("configuração do gateway de WhatsApp/SMS inválida", "erro", err)
+			os.Exit(1)
+		}
+		canaisNotificacao[notificacoes.CanalWhatsApp] = gateway
+	}
+	if os.Getenv("NOTIFICACAO_CANAIS_FAKE") == "true" {
+		for _, canal := range notificacoes.CanaisExternos {
+			canaisNotificacao[canal] = notificacao.NovoFake(string(canal), logger)
+		}
+	}
+	notificacoesSvc := notificacoes_service.NovoServico(notificacaoRepo, notificacaoRepo, notificacaoRepo, canaisNotificacao, logger)
 
 	// Handlers HTTP (Correto)
 	identidadeHandler := identidade_handler.NovoIdentidadeHandler(identidadeSvc, logger)
@@ -174,30 +366,100 @@ func main() {
 	contaPagarHandler := financeiro_handler.NovoContaPagarHandler(contaPagarSvc, logger)
 	// Handler do cronograma
 	cronogramaHandler := obras_handler.NovoCronogramaHandler(cronogramaSvc, logger)
+	transicaoHandler := obras_handler.NovoTransicaoHandler(transicaoSvc, logger)
+	cronogramaFisicoHandler := obras_handler.NovoCronogramaFisicoHandler(cronogramaFisicoSvc, logger)
+	fisicoFinanceiroHandler := obras_handler.NovoFisicoFinanceiroHandler(fisicoFinanceiroSvc, logger)
+	orcamentoAnaliticoHandler := obras_handler.NovoOrcamentoAnaliticoHandler(orcamentoAnaliticoSvc, logger)
+	resultadoHandler := obras_handler.NovoResultadoHandler(resultadoSvc, logger)
+	diarioObraHandler := obras_handler.NovoDiarioObraHandler(diarioObraSvc, logger)
+	vistoriaHandler := obras_handler.NovoVistoriaHandler(vistoriaSvc, logger)
+	checklistQualidadeHandler := obras_handler.NovoChecklistQualidadeHandler(checklistQualidadeSvc, logger)
+	medicaoHandler := obras_handler.NovoMedicaoHandler(medicaoSvc, logger)
+	aditivoHandler := obras_handler.NovoAditivoHandler(aditivoSvc, logger)
+	alocacaoHandler := obras_handler.NovoAlocacaoHandler(alocacaoSvc, logger)
+	capacidadeHandler := obras_handler.NovoCapacidadeHandler(capacidadeSvc, logger)
+	modeloObraHandler := obras_handler.NovoModeloObraHandler(modeloObraSvc, logger)
 	// CORREÇÃO: Usando a variável com nome correto 'suprimentosSvc'.
 	suprimentosHandler := suprimentos_handler.NovoSuprimentosHandler(suprimentosSvc, logger)
 	dashboardHandler := dashboard_handler.NovoDashboardHandler(dashboardSvc, logger, dashLogger, jwtService)
+	integracoesHandler := integracoes_handler.NovoIntegracoesHandler(integracoesSvc, logger)
+	alertasHandler := alertas_handler.NovoAlertasHandler(alertasSvc, logger)
+	notificacoesHandler := notificacoes_handler.NovoNotificacoesHandler(notificacoesSvc, logger)
+	clientesHandler := clientes_handler.NovoClientesHandler(clientesSvc, logger)
+	portalHandler := portal_handler.NovoPortalHandler(portalSvc, logger)
+	eventosBroker := stream.NovoBroker(500, logger)
+	eventosHandler := eventos_handler.NovoEventosHandler(eventosBroker, obraRepo, logger)
 
 	// 4. Configuração do Event Bus e Manipuladores de Eventos (Correto)
-	obrasEventHandler := obras_events.NovoObrasEventHandler(logger)
+	obrasEventHandler := obras_events.NovoObrasEventHandler(dashboardCache, logger)
 	eventBus.Subscrever(events.OrcamentoStatusAtualizado, obrasEventHandler.HandleOrcamentoStatusAtualizado)
 
+	// Invalidação do cache do dashboard por pagamentos, recebimentos e apontamentos
+	dashboardCache.Registrar(eventBus)
+
 	// Event Handlers Financeiros
 	financeiroEventHandler := financeiro_events.NovoFinanceiroEventHandler(contaReceberSvc, contaPagarSvc, logger)
 	financeiro_events.ConfigurarEventHandlers(*eventBus, financeiroEventHandler)
 
+	// Webhooks: entrega imediata via EventBus e retentativas em segundo plano
+	webhookDispatcher.Registrar(eventBus)
+	webhookCtx, cancelWebhooks := context.WithCancel(context.Background())
+	defer cancelWebhooks()
+	go webhookDispatcher.Iniciar(webhookCtx)
+
+	// Avaliação das regras de alerta em segundo plano
+	alertasCtx, cancelAlertas := context.WithCancel(context.Background())
+	defer cancelAlertas()
+	go alertasSvc.Iniciar(alertasCtx)
+
+	// Notificações por usuário para aprovações, vencimentos e recebimentos; as entregas
+	// nos canais externos são enviadas em segundo plano, com retentativas
+	notificacoesSvc.Registrar(eventBus)
+	notificacoesCtx, cancelNotificacoes := context.WithCancel(context.Background())
+	defer cancelNotificacoes()
+	go notificacoesSvc.IniciarEntregas(notificacoesCtx)
+
+	// Streaming (SSE) para o frontend
+	eventosHandler.Registrar(eventBus)
+
+	// Verificação horária de contas a receber vencidas (publica financeiro:conta_receber_vencida).
+	// Inicia depois das subscrições para que os eventos da primeira execução não se percam.
+	vencimentosCtx, cancelVencimentos := context.WithCancel(context.Background())
+	defer cancelVencimentos()
+	go contaReceberSvc.IniciarVerificacaoVencimentos(vencimentosCtx, time.Hour)
+
 	// 5. Configuração do Servidor HTTP e Roteamento (Correto)
 	routerCfg := router.Config{
-		JwtService:          jwtService,
-		IdentidadeHandler:   identidadeHandler,
-		ObrasHandler:        obraHandler,
-		PessoalHandler:      pessoalHandler,
-		SuprimentosHandler:  suprimentosHandler,
-		FinanceiroHandler:   financeiroHandler,
-		ContaReceberHandler: contaReceberHandler,
-		ContaPagarHandler:   contaPagarHandler,
-		CronogramaHandler:   cronogramaHandler,
-		DashboardHandler:    dashboardHandler,
+		JwtService:                jwtService,
+		IdentidadeHandler:         identidadeHandler,
+		ObrasHandler:              obraHandler,
+		PessoalHandler:            pessoalHandler,
+		SuprimentosHandler:        suprimentosHandler,
+		FinanceiroHandler:         financeiroHandler,
+		ContaReceberHandler:       contaReceberHandler,
+		ContaPagarHandler:         contaPagarHandler,
+		CronogramaHandler:         cronogramaHandler,
+		TransicaoHandler:          transicaoHandler,
+		CronogramaFisicoHandler:   cronogramaFisicoHandler,
+		FisicoFinanceiroHandler:   fisicoFinanceiroHandler,
+		OrcamentoAnaliticoHandler: orcamentoAnaliticoHandler,
+		ResultadoHandler:          resultadoHandler,
+		DiarioObraHandler:         diarioObraHandler,
+		VistoriaHandler:           vistoriaHandler,
+		ChecklistQualidadeHandler: checklistQualidadeHandler,
+		MedicaoHandler:            medicaoHandler,
+		AditivoHandler:            aditivoHandler,
+		AlocacaoHandler:           alocacaoHandler,
+		CapacidadeHandler:         capacidadeHandler,
+		ModeloObraHandler:         modeloObraHandler,
+		DashboardHandler:          dashboardHandler,
+		IntegracoesHandler:        integracoesHandler,
+		AlertasHandler:            alertasHandler,
+		NotificacoesHandler:       notificacoesHandler,
+		ClientesHandler:           clientesHandler,
+		PortalHandler:             portalHandler,
+		EventosHandler:            eventosHandler,
+		HTTPMetrics:               metrics.NovoHTTPMetrics(registroMetricas),
 	}
 	r := router.New(routerCfg)
 
@@ -205,9 +467,50 @@ func main() {
 		Addr:    ":" + port,
 		Handler: r,
 	}
+	// As conexões SSE só terminam quando o cliente desconecta; sem isso o Shutdown esperaria o prazo inteiro
+	server.RegisterOnShutdown(eventosBroker.Encerrar)
+
+	// Porta administrativa só com /metrics, para ser exposta apenas à rede do Prometheus
+	adminMux := http.NewServeMux()
+	adminMux.Handle("GET /metrics", registroMetricas.Handler())
+	adminServer := &http.Server{
+		Addr:    ":" + metricsPort,
+		Handler: adminMux,
+	}
+
+	// 6. Desligamento gracioso: em SIGTERM/SIGINT para de aceitar conexões, espera as
+	// requisições em andamento e só então deixa os defers encerrarem workers e tracer.
+	sinais, pararSinais := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer pararSinais()
+
+	erroServidor := make(chan error, 1)
+	go func() {
+		logger.Info("servidor escutando na porta", "port", port)
+		erroServidor <- server.ListenAndServe()
+	}()
+	go func() {
+		logger.Info("métricas escutando na porta", "port", metricsPort)
+		if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error("não foi possível iniciar o servidor de métricas", "erro", err)
+		}
+	}()
 
-	logger.Info("servidor escutando na porta", "port", port)
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		log.Fatalf("não foi possível iniciar o servidor: %v", err)
+	select {
+	case err := <-erroServidor:
+		if err != nil && err != http.ErrServerClosed {
+			logger.Error("não foi possível iniciar o servidor", "erro", err)
+			return
+		}
+	case <-sinais.Done():
+		logger.Info("sinal de desligamento recebido; encerrando o servidor")
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			logger.Error("falha ao encerrar o servidor", "erro", err)
+		}
+		if err := adminServer.Shutdown(ctx); err != nil {
+			logger.Error("falha ao encerrar o servidor de métricas", "erro", err)
+		}
 	}
+	logger.Info("servidor encerrado")
 }
@@ -0,0 +1,32 @@
+-- Migration to add outbound webhooks (subscriptions and delivery log)
+
+CREATE TABLE IF NOT EXISTS webhook_assinaturas (
+    id UUID PRIMARY KEY,
+    descricao VARCHAR(255) NOT NULL DEFAULT '',
+    url TEXT NOT NULL,
+    eventos TEXT[] NOT NULL,
+    segredo VARCHAR(255) NOT NULL,
+    ativa BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_entregas (
+    id UUID PRIMARY KEY,
+    assinatura_id UUID NOT NULL REFERENCES webhook_assinaturas(id) ON DELETE CASCADE,
+    evento VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDENTE' CHECK (status IN ('PENDENTE', 'SUCESSO', 'FALHA')),
+    tentativas INTEGER NOT NULL DEFAULT 0,
+    ultimo_status_http INTEGER,
+    ultimo_erro TEXT,
+    proxima_tentativa TIMESTAMPTZ,
+    entregue_em TIMESTAMPTZ,
+    reenvio_de_id UUID REFERENCES webhook_entregas(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_assinaturas_eventos ON webhook_assinaturas USING GIN (eventos);
+CREATE INDEX IF NOT EXISTS idx_webhook_entregas_assinatura ON webhook_entregas(assinatura_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_entregas_pendentes ON webhook_entregas(proxima_tentativa) WHERE status = 'PENDENTE';
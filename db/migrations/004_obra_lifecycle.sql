@@ -0,0 +1,6 @@
+-- Migration to support the obra lifecycle transitions
+-- Cancelling an obra cancels its open receivable schedule, so CANCELADO becomes a valid status
+
+ALTER TABLE cronograma_recebimentos DROP CONSTRAINT IF EXISTS cronograma_recebimentos_status_check;
+ALTER TABLE cronograma_recebimentos ADD CONSTRAINT cronograma_recebimentos_status_check
+    CHECK (status IN ('PENDENTE', 'RECEBIDO', 'VENCIDO', 'PARCIAL', 'CANCELADO'));
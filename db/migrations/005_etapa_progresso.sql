@@ -0,0 +1,16 @@
+-- Migration to track actual execution of etapas
+-- Actual start/end dates, percent executed and relative weight used to compute obra progress
+
+ALTER TABLE etapas ADD COLUMN IF NOT EXISTS data_inicio_real DATE;
+ALTER TABLE etapas ADD COLUMN IF NOT EXISTS data_fim_real DATE;
+ALTER TABLE etapas ADD COLUMN IF NOT EXISTS percentual_executado NUMERIC(5,2) NOT NULL DEFAULT 0
+    CHECK (percentual_executado >= 0 AND percentual_executado <= 100);
+ALTER TABLE etapas ADD COLUMN IF NOT EXISTS peso NUMERIC(10,2) NOT NULL DEFAULT 1
+    CHECK (peso > 0);
+
+-- Etapas already concluded count as fully executed
+UPDATE etapas SET percentual_executado = 100 WHERE status = 'Concluída';
+
+-- Etapas were created with zero-value dates ('0001-01-01') instead of NULL
+UPDATE etapas SET data_inicio_prevista = NULL WHERE data_inicio_prevista = '0001-01-01';
+UPDATE etapas SET data_fim_prevista = NULL WHERE data_fim_prevista = '0001-01-01';
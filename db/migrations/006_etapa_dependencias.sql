@@ -0,0 +1,16 @@
+-- Migration to add dependencies between etapas (physical schedule / critical path)
+-- tipo: TI = término-início (finish-to-start), II = início-início (start-to-start)
+
+CREATE TABLE IF NOT EXISTS etapa_dependencias (
+    id UUID PRIMARY KEY,
+    obra_id UUID NOT NULL REFERENCES obras(id) ON DELETE CASCADE,
+    etapa_id UUID NOT NULL REFERENCES etapas(id) ON DELETE CASCADE,
+    predecessora_id UUID NOT NULL REFERENCES etapas(id) ON DELETE CASCADE,
+    tipo VARCHAR(2) NOT NULL DEFAULT 'TI' CHECK (tipo IN ('TI', 'II')),
+    lag_dias INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (etapa_id <> predecessora_id),
+    UNIQUE (etapa_id, predecessora_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_etapa_dependencias_obra ON etapa_dependencias(obra_id);
@@ -0,0 +1,21 @@
+-- Migration to add the cost budget baseline (orçamento analítico) per obra
+-- Lines are broken down by etapa and cost category; etapa_id NULL means an obra-wide line
+
+CREATE TABLE IF NOT EXISTS obra_orcamento_analitico (
+    obra_id UUID PRIMARY KEY REFERENCES obras(id) ON DELETE CASCADE,
+    percentual_alerta NUMERIC(5,2) NOT NULL DEFAULT 90,
+    percentual_critico NUMERIC(5,2) NOT NULL DEFAULT 100,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (percentual_alerta > 0 AND percentual_alerta <= percentual_critico)
+);
+
+CREATE TABLE IF NOT EXISTS obra_orcamento_analitico_itens (
+    id UUID PRIMARY KEY,
+    obra_id UUID NOT NULL REFERENCES obra_orcamento_analitico(obra_id) ON DELETE CASCADE,
+    etapa_id UUID REFERENCES etapas(id) ON DELETE CASCADE,
+    categoria VARCHAR(20) NOT NULL CHECK (categoria IN ('MATERIAL', 'MAO_DE_OBRA', 'SERVICOS', 'EQUIPAMENTOS')),
+    valor_previsto NUMERIC(15,2) NOT NULL CHECK (valor_previsto >= 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orcamento_analitico_linha
+    ON obra_orcamento_analitico_itens(obra_id, COALESCE(etapa_id, '00000000-0000-0000-0000-000000000000'::uuid), categoria);
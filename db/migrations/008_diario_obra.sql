@@ -0,0 +1,23 @@
+-- Migration to add the daily site log (diário de obra)
+-- One log per obra and date; the day's records are stored as JSONB and locked after the engineer signs
+
+CREATE TABLE IF NOT EXISTS diarios_obra (
+    id UUID PRIMARY KEY,
+    obra_id UUID NOT NULL REFERENCES obras(id) ON DELETE CASCADE,
+    data DATE NOT NULL,
+    clima JSONB NOT NULL DEFAULT '{}',
+    efetivo JSONB NOT NULL DEFAULT '[]',
+    efetivo_terceirizado INTEGER NOT NULL DEFAULT 0 CHECK (efetivo_terceirizado >= 0),
+    equipamentos JSONB NOT NULL DEFAULT '[]',
+    servicos JSONB NOT NULL DEFAULT '[]',
+    ocorrencias JSONB NOT NULL DEFAULT '[]',
+    fotos JSONB NOT NULL DEFAULT '[]',
+    observacoes TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'RASCUNHO' CHECK (status IN ('RASCUNHO', 'ASSINADO')),
+    criado_por VARCHAR(100) NOT NULL,
+    assinado_por VARCHAR(100),
+    assinado_em TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (obra_id, data)
+);
@@ -0,0 +1,25 @@
+-- Migration to add progress measurements (medições) for obras billed by etapa
+-- Items are stored as JSONB; once the client approves, the measurement is linked to the receivable it generated
+
+CREATE TABLE IF NOT EXISTS medicoes (
+    id UUID PRIMARY KEY,
+    obra_id UUID NOT NULL REFERENCES obras(id) ON DELETE CASCADE,
+    numero INTEGER NOT NULL CHECK (numero > 0),
+    periodo_inicio DATE NOT NULL,
+    periodo_fim DATE NOT NULL,
+    itens JSONB NOT NULL DEFAULT '[]',
+    valor_total NUMERIC(15, 2) NOT NULL DEFAULT 0 CHECK (valor_total >= 0),
+    observacoes TEXT NOT NULL DEFAULT '',
+    status VARCHAR(30) NOT NULL DEFAULT 'RASCUNHO'
+        CHECK (status IN ('RASCUNHO', 'AGUARDANDO_APROVACAO', 'APROVADA', 'REJEITADA')),
+    criado_por VARCHAR(100) NOT NULL,
+    enviada_em TIMESTAMPTZ,
+    responsavel_cliente VARCHAR(255),
+    analisada_em TIMESTAMPTZ,
+    motivo_rejeicao TEXT,
+    cronograma_recebimento_id UUID REFERENCES cronograma_recebimentos(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (obra_id, numero),
+    CHECK (periodo_fim >= periodo_inicio)
+);
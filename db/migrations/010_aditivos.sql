@@ -0,0 +1,21 @@
+-- Migration to add contract amendments (aditivos)
+-- Each aditivo keeps the contract value and deadline before and after it, so the original baseline is never lost
+
+CREATE TABLE IF NOT EXISTS aditivos_obra (
+    id UUID PRIMARY KEY,
+    obra_id UUID NOT NULL REFERENCES obras(id) ON DELETE CASCADE,
+    numero INTEGER NOT NULL CHECK (numero > 0),
+    descricao TEXT NOT NULL,
+    valor_acrescimo NUMERIC(15, 2) NOT NULL DEFAULT 0,
+    nova_data_fim DATE,
+    data_aprovacao DATE NOT NULL,
+    documento_url TEXT,
+    valor_contrato_anterior NUMERIC(15, 2) NOT NULL,
+    valor_contrato_resultante NUMERIC(15, 2) NOT NULL CHECK (valor_contrato_resultante > 0),
+    data_fim_anterior DATE,
+    data_fim_resultante DATE,
+    criado_por VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (obra_id, numero),
+    CHECK (valor_acrescimo <> 0 OR nova_data_fim IS NOT NULL)
+);
@@ -0,0 +1,70 @@
+-- Migration to add the client (cliente) registry and link obras and contas a receber by ID
+-- Existing free-text names are deduplicated: spellings that differ only in case or spacing
+-- become a single cliente (named after the most frequent spelling), without tipo and documento
+-- until the registration is completed
+
+CREATE TABLE IF NOT EXISTS clientes (
+    id UUID PRIMARY KEY,
+    tipo VARCHAR(2) CHECK (tipo IN ('PF', 'PJ')),
+    nome VARCHAR(255) NOT NULL,
+    documento VARCHAR(14) UNIQUE,
+    email VARCHAR(255),
+    telefone VARCHAR(50),
+    contatos JSONB NOT NULL DEFAULT '[]',
+    endereco_cobranca JSONB,
+    observacoes TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ DEFAULT NULL,
+    CHECK ((tipo IS NULL) = (documento IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_clientes_nome_normalizado
+    ON clientes (LOWER(REGEXP_REPLACE(TRIM(nome), '\s+', ' ', 'g')));
+
+ALTER TABLE obras ADD COLUMN IF NOT EXISTS cliente_id UUID REFERENCES clientes(id);
+ALTER TABLE contas_receber ADD COLUMN IF NOT EXISTS cliente_id UUID REFERENCES clientes(id);
+
+CREATE INDEX IF NOT EXISTS idx_obras_cliente_id ON obras(cliente_id);
+CREATE INDEX IF NOT EXISTS idx_contas_receber_cliente_id ON contas_receber(cliente_id);
+
+-- Deduplicação dos nomes livres de obras e contas a receber
+CREATE TEMP TABLE clientes_legados AS
+SELECT
+    gen_random_uuid() AS id,
+    chave,
+    MODE() WITHIN GROUP (ORDER BY nome) AS nome
+FROM (
+    SELECT REGEXP_REPLACE(TRIM(cliente), '\s+', ' ', 'g') AS nome,
+           LOWER(REGEXP_REPLACE(TRIM(cliente), '\s+', ' ', 'g')) AS chave
+    FROM obras
+    WHERE cliente_id IS NULL AND TRIM(cliente) <> ''
+    UNION ALL
+    SELECT REGEXP_REPLACE(TRIM(cliente), '\s+', ' ', 'g'),
+           LOWER(REGEXP_REPLACE(TRIM(cliente), '\s+', ' ', 'g'))
+    FROM contas_receber
+    WHERE cliente_id IS NULL AND TRIM(cliente) <> ''
+) nomes
+WHERE chave NOT IN (
+    SELECT LOWER(REGEXP_REPLACE(TRIM(nome), '\s+', ' ', 'g')) FROM clientes WHERE deleted_at IS NULL
+)
+GROUP BY chave;
+
+INSERT INTO clientes (id, nome)
+SELECT id, nome FROM clientes_legados;
+
+UPDATE obras o
+SET cliente_id = c.id, cliente = c.nome
+FROM clientes c
+WHERE o.cliente_id IS NULL
+  AND c.deleted_at IS NULL
+  AND LOWER(REGEXP_REPLACE(TRIM(c.nome), '\s+', ' ', 'g')) = LOWER(REGEXP_REPLACE(TRIM(o.cliente), '\s+', ' ', 'g'));
+
+UPDATE contas_receber cr
+SET cliente_id = c.id, cliente = c.nome
+FROM clientes c
+WHERE cr.cliente_id IS NULL
+  AND c.deleted_at IS NULL
+  AND LOWER(REGEXP_REPLACE(TRIM(c.nome), '\s+', ' ', 'g')) = LOWER(REGEXP_REPLACE(TRIM(cr.cliente), '\s+', ' ', 'g'));
+
+DROP TABLE clientes_legados;
@@ -0,0 +1,20 @@
+-- Migration to add the client portal: shareable read-only links per obra and boleto data on receivables
+-- The token sent to the client is signed and carries the link ID and expiry; this table allows revocation and auditing
+
+CREATE TABLE IF NOT EXISTS portal_links (
+    id UUID PRIMARY KEY,
+    obra_id UUID NOT NULL REFERENCES obras(id) ON DELETE CASCADE,
+    descricao TEXT NOT NULL DEFAULT '',
+    criado_por VARCHAR(100) NOT NULL,
+    expira_em TIMESTAMPTZ NOT NULL,
+    revogado_em TIMESTAMPTZ,
+    ultimo_acesso_em TIMESTAMPTZ,
+    total_acessos INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_portal_links_obra ON portal_links(obra_id);
+
+-- Boleto emitted for a receivable, shown to the client in the portal
+ALTER TABLE contas_receber ADD COLUMN IF NOT EXISTS linha_digitavel VARCHAR(48);
+ALTER TABLE contas_receber ADD COLUMN IF NOT EXISTS url_boleto TEXT;
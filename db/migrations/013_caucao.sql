@@ -0,0 +1,18 @@
+-- Migration to add contractual retention (caução) on receivables
+-- The obra contract defines the percentage withheld from each measurement; every generated
+-- conta a receber keeps the net amount in valor_original and the withheld part in valor_retido.
+-- The accumulated balance is released as a CAUCAO receivable when the obra is concluded.
+
+ALTER TABLE obras ADD COLUMN IF NOT EXISTS percentual_retencao NUMERIC(5, 2) NOT NULL DEFAULT 0
+    CHECK (percentual_retencao >= 0 AND percentual_retencao <= 30);
+
+ALTER TABLE contas_receber ADD COLUMN IF NOT EXISTS valor_retido NUMERIC(15, 2) NOT NULL DEFAULT 0
+    CHECK (valor_retido >= 0);
+
+ALTER TABLE contas_receber DROP CONSTRAINT IF EXISTS contas_receber_tipo_conta_receber_check;
+ALTER TABLE contas_receber ADD CONSTRAINT contas_receber_tipo_conta_receber_check
+    CHECK (tipo_conta_receber IN ('OBRA', 'SERVICO', 'OUTROS', 'CAUCAO'));
+
+COMMENT ON COLUMN obras.percentual_retencao IS 'Percentual retido de cada medição como caução até a entrega';
+COMMENT ON COLUMN contas_receber.valor_retido IS 'Parte do valor bruto retida como caução; liberada na conclusão da obra';
+COMMENT ON COLUMN contas_receber.tipo_conta_receber IS 'Tipo da conta: OBRA, SERVICO, OUTROS ou CAUCAO';
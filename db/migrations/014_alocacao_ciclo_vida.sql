@@ -0,0 +1,15 @@
+-- Migration to support the alocação lifecycle: ending, transfer between obras and
+-- justified overlaps. data_fim_alocacao is the last allocated day (inclusive).
+
+ALTER TABLE alocacoes ADD COLUMN IF NOT EXISTS motivo_encerramento TEXT;
+ALTER TABLE alocacoes ADD COLUMN IF NOT EXISTS motivo_sobreposicao TEXT;
+ALTER TABLE alocacoes ADD COLUMN IF NOT EXISTS transferida_de_id UUID REFERENCES alocacoes(id) ON DELETE SET NULL;
+
+ALTER TABLE alocacoes DROP CONSTRAINT IF EXISTS alocacoes_periodo_check;
+ALTER TABLE alocacoes ADD CONSTRAINT alocacoes_periodo_check
+    CHECK (data_fim_alocacao IS NULL OR data_fim_alocacao >= data_inicio_alocacao);
+
+CREATE INDEX IF NOT EXISTS idx_alocacoes_funcionario_periodo
+    ON alocacoes(funcionario_id, data_inicio_alocacao, data_fim_alocacao);
+
+COMMENT ON COLUMN alocacoes.motivo_sobreposicao IS 'Justificativa para alocar o funcionário em período que conflita com outra alocação';
@@ -0,0 +1,14 @@
+-- Migration to add the planned headcount (efetivo planejado) per etapa and cargo,
+-- used by the workforce capacity calendar together with the etapa planned dates
+
+CREATE TABLE IF NOT EXISTS obra_efetivo_planejado (
+    id UUID PRIMARY KEY,
+    obra_id UUID NOT NULL REFERENCES obras(id) ON DELETE CASCADE,
+    etapa_id UUID NOT NULL REFERENCES etapas(id) ON DELETE CASCADE,
+    cargo VARCHAR(100) NOT NULL,
+    quantidade INT NOT NULL CHECK (quantidade > 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_efetivo_planejado_etapa_cargo
+    ON obra_efetivo_planejado(etapa_id, LOWER(cargo));
+CREATE INDEX IF NOT EXISTS idx_efetivo_planejado_obra ON obra_efetivo_planejado(obra_id);
@@ -0,0 +1,17 @@
+-- Migration to add named obra templates (modelos de obra). Etapas, with duration,
+-- weight, dependencies and cronograma percentage, and the budget lines as a
+-- percentage of the contract are kept as JSONB on the template row.
+
+CREATE TABLE IF NOT EXISTS modelos_obra (
+    id UUID PRIMARY KEY,
+    nome VARCHAR(150) NOT NULL,
+    descricao TEXT,
+    percentual_entrada NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    prazo_dias_etapa INT NOT NULL DEFAULT 0,
+    etapas JSONB NOT NULL DEFAULT '[]',
+    orcamento JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_modelos_obra_nome ON modelos_obra(LOWER(nome));
@@ -0,0 +1,43 @@
+-- Migration to add the final inspection (vistoria) workflow: inspection rounds and
+-- the punch list (pendências). Blocking pendências must be resolved in a
+-- re-inspection before the obra can be concluded. The verification history of
+-- each pendência across rounds is kept as JSONB.
+
+CREATE TABLE IF NOT EXISTS vistorias (
+    id UUID PRIMARY KEY,
+    obra_id UUID NOT NULL REFERENCES obras(id) ON DELETE CASCADE,
+    numero INT NOT NULL,
+    data DATE NOT NULL,
+    vistoriador VARCHAR(255) NOT NULL,
+    observacoes TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'ABERTA' CHECK (status IN ('ABERTA', 'ENCERRADA')),
+    criado_por VARCHAR(255) NOT NULL,
+    encerrada_em TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (obra_id, numero)
+);
+
+-- Apenas uma rodada aberta por obra
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vistorias_aberta ON vistorias(obra_id) WHERE status = 'ABERTA';
+
+CREATE TABLE IF NOT EXISTS vistoria_pendencias (
+    id UUID PRIMARY KEY,
+    obra_id UUID NOT NULL REFERENCES obras(id) ON DELETE CASCADE,
+    vistoria_id UUID NOT NULL REFERENCES vistorias(id) ON DELETE CASCADE,
+    descricao TEXT NOT NULL,
+    local VARCHAR(255) NOT NULL,
+    etapa_id UUID REFERENCES etapas(id) ON DELETE SET NULL,
+    responsavel VARCHAR(255) NOT NULL DEFAULT '',
+    foto_url TEXT NOT NULL DEFAULT '',
+    prazo DATE,
+    bloqueante BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL DEFAULT 'ABERTA' CHECK (status IN ('ABERTA', 'CORRIGIDA', 'RESOLVIDA')),
+    corrigida_em TIMESTAMPTZ,
+    resolvida_em TIMESTAMPTZ,
+    verificacoes JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vistoria_pendencias_obra ON vistoria_pendencias(obra_id, status);
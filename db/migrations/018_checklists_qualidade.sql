@@ -0,0 +1,35 @@
+-- Migration to add quality verification checklists (FVS - ficha de verificação de
+-- serviço). Templates are configured per etapa padrão and copied to each obra
+-- etapa of the same name; the inspection history of each copy is kept as JSONB.
+-- Mandatory checklists must be approved before the etapa can be concluded.
+
+CREATE TABLE IF NOT EXISTS modelos_checklist (
+    id UUID PRIMARY KEY,
+    etapa_padrao_id UUID NOT NULL REFERENCES etapas_padrao(id) ON DELETE CASCADE,
+    nome VARCHAR(255) NOT NULL,
+    descricao TEXT NOT NULL DEFAULT '',
+    obrigatorio BOOLEAN NOT NULL DEFAULT TRUE,
+    itens JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_modelos_checklist_etapa_padrao ON modelos_checklist(etapa_padrao_id);
+
+CREATE TABLE IF NOT EXISTS etapa_checklists (
+    id UUID PRIMARY KEY,
+    obra_id UUID NOT NULL REFERENCES obras(id) ON DELETE CASCADE,
+    etapa_id UUID NOT NULL REFERENCES etapas(id) ON DELETE CASCADE,
+    modelo_id UUID REFERENCES modelos_checklist(id) ON DELETE SET NULL,
+    nome VARCHAR(255) NOT NULL,
+    obrigatorio BOOLEAN NOT NULL DEFAULT TRUE,
+    itens JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDENTE' CHECK (status IN ('PENDENTE', 'APROVADA', 'REPROVADA')),
+    inspecoes JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- Um mesmo modelo é instanciado uma única vez por etapa
+    UNIQUE (etapa_id, modelo_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_etapa_checklists_obra ON etapa_checklists(obra_id);
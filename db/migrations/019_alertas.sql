@@ -0,0 +1,34 @@
+-- Migration to replace the hardcoded dashboard alerts with a rule engine. Each alert
+-- type has one company-wide rule (threshold and severity); rows only exist once the
+-- default has been customised. Detected alerts keep acknowledge/snooze state and are
+-- resolved automatically when the condition disappears.
+
+CREATE TABLE IF NOT EXISTS regras_alerta (
+    tipo VARCHAR(40) PRIMARY KEY CHECK (tipo IN ('OBRA_ATRASADA', 'CONTA_VENCENDO', 'ORCAMENTO_PARADO', 'FUNCIONARIO_SEM_APONTAMENTO', 'ORCAMENTO_ESTOURADO')),
+    ativa BOOLEAN NOT NULL DEFAULT TRUE,
+    limite INT NOT NULL CHECK (limite >= 0),
+    severidade VARCHAR(10) NOT NULL CHECK (severidade IN ('INFO', 'ATENCAO', 'CRITICA')),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS alertas (
+    id UUID PRIMARY KEY,
+    tipo VARCHAR(40) NOT NULL,
+    chave VARCHAR(100) NOT NULL,
+    referencia_id UUID NOT NULL,
+    obra_id UUID REFERENCES obras(id) ON DELETE CASCADE,
+    titulo VARCHAR(255) NOT NULL,
+    descricao TEXT NOT NULL DEFAULT '',
+    severidade VARCHAR(10) NOT NULL CHECK (severidade IN ('INFO', 'ATENCAO', 'CRITICA')),
+    status VARCHAR(20) NOT NULL DEFAULT 'ATIVO' CHECK (status IN ('ATIVO', 'RECONHECIDO', 'ADIADO', 'RESOLVIDO')),
+    detectado_em TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    atualizado_em TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reconhecido_por VARCHAR(100),
+    reconhecido_em TIMESTAMPTZ,
+    adiado_ate TIMESTAMPTZ,
+    resolvido_em TIMESTAMPTZ
+);
+
+-- Só pode haver um alerta aberto por condição
+CREATE UNIQUE INDEX IF NOT EXISTS idx_alertas_abertos_chave ON alertas(tipo, chave) WHERE status <> 'RESOLVIDO';
+CREATE INDEX IF NOT EXISTS idx_alertas_status ON alertas(status, severidade);
@@ -0,0 +1,28 @@
+-- Migration to add the in-app notification center. Notifications are created per
+-- user from domain events; the delivery attempts on external channels (email,
+-- WhatsApp/SMS gateway, webhook) are kept as JSONB on the notification row.
+
+CREATE TABLE IF NOT EXISTS notificacoes (
+    id UUID PRIMARY KEY,
+    usuario_id UUID NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    evento VARCHAR(100) NOT NULL,
+    titulo VARCHAR(255) NOT NULL,
+    mensagem TEXT NOT NULL DEFAULT '',
+    referencia_id VARCHAR(100),
+    obra_id UUID REFERENCES obras(id) ON DELETE SET NULL,
+    lida BOOLEAN NOT NULL DEFAULT FALSE,
+    lida_em TIMESTAMPTZ,
+    entregas JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notificacoes_usuario ON notificacoes(usuario_id, lida, created_at DESC);
+
+-- Uma linha por usuário; eventos sem entrada em "eventos" usam o padrão (somente in-app)
+CREATE TABLE IF NOT EXISTS preferencias_notificacao (
+    usuario_id UUID PRIMARY KEY REFERENCES usuarios(id) ON DELETE CASCADE,
+    telefone VARCHAR(20) NOT NULL DEFAULT '',
+    webhook_url TEXT NOT NULL DEFAULT '',
+    eventos JSONB NOT NULL DEFAULT '[]',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
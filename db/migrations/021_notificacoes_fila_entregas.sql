@@ -0,0 +1,10 @@
+-- Migration to queue the external deliveries of notifications. The event handler
+-- only enqueues the deliveries; a background worker sends them with retries.
+-- proxima_entrega is the earliest pending attempt among the row's entregas
+-- (NULL when nothing is pending) and doubles as a lease while a worker holds it.
+
+ALTER TABLE notificacoes ADD COLUMN IF NOT EXISTS proxima_entrega TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_notificacoes_proxima_entrega
+    ON notificacoes(proxima_entrega)
+    WHERE proxima_entrega IS NOT NULL;
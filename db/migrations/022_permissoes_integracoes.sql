@@ -0,0 +1,9 @@
+-- Migration to grant the webhook permission to existing users. Permissions are copied
+-- into usuarios.permissoes at registration, so users created before outbound webhooks
+-- lack "integracoes:gerenciar" and get 403 on /webhooks.
+-- The role is not stored; ADMIN users are the ones holding both "obras:escrever"
+-- (GERENTE_OBRAS) and "pessoal:apontamento:ler" (VISUALIZADOR).
+
+UPDATE usuarios
+SET permissoes = array(SELECT DISTINCT unnest(permissoes || ARRAY['integracoes:gerenciar']))
+WHERE permissoes @> ARRAY['obras:escrever', 'pessoal:apontamento:ler'];
@@ -0,0 +1,8 @@
+-- Migration to grant the diário sign-off permission to existing users. Permissions are
+-- copied into usuarios.permissoes at registration, so users created before the diário
+-- de obra lack "obras:diario:assinar" and cannot sign entries.
+-- The role is not stored; GERENTE_OBRAS and ADMIN are the users holding "obras:escrever".
+
+UPDATE usuarios
+SET permissoes = array(SELECT DISTINCT unnest(permissoes || ARRAY['obras:diario:assinar']))
+WHERE 'obras:escrever' = ANY(permissoes);
@@ -0,0 +1,13 @@
+-- Migration to grant the cliente registry permissions to existing users. Permissions
+-- are copied into usuarios.permissoes at registration, so users created before the
+-- registry lack "clientes:*" and get 403 on /clientes.
+-- The role is not stored: everyone holding "obras:ler" (all roles) reads clientes and
+-- GERENTE_OBRAS and ADMIN, holding "obras:escrever", also write them.
+
+UPDATE usuarios
+SET permissoes = array(SELECT DISTINCT unnest(permissoes || ARRAY['clientes:ler']))
+WHERE 'obras:ler' = ANY(permissoes);
+
+UPDATE usuarios
+SET permissoes = array(SELECT DISTINCT unnest(permissoes || ARRAY['clientes:escrever']))
+WHERE 'obras:escrever' = ANY(permissoes);
@@ -0,0 +1,18 @@
+-- Migration to grant the alert permissions to existing users. Permissions are copied
+-- into usuarios.permissoes at registration, so users created before the alert rules
+-- engine lack "alertas:*" and get 403 on /alertas.
+-- The role is not stored: "obras:ler" identifies every role, "obras:escrever" the
+-- GERENTE_OBRAS and ADMIN users, and "obras:escrever" plus "pessoal:apontamento:ler"
+-- the ADMIN users.
+
+UPDATE usuarios
+SET permissoes = array(SELECT DISTINCT unnest(permissoes || ARRAY['alertas:ler']))
+WHERE 'obras:ler' = ANY(permissoes);
+
+UPDATE usuarios
+SET permissoes = array(SELECT DISTINCT unnest(permissoes || ARRAY['alertas:gerenciar']))
+WHERE 'obras:escrever' = ANY(permissoes);
+
+UPDATE usuarios
+SET permissoes = array(SELECT DISTINCT unnest(permissoes || ARRAY['alertas:configurar']))
+WHERE permissoes @> ARRAY['obras:escrever', 'pessoal:apontamento:ler'];
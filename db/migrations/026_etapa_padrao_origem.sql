@@ -0,0 +1,18 @@
+-- Migration to record the catalog entry (etapa padrão) each obra etapa was created
+-- from. Quality checklist templates (FVS) are matched by this ID instead of by the
+-- etapa name, so renaming an etapa padrão or an obra etapa no longer breaks the link.
+-- Removing an etapa padrão keeps the obra etapas and only clears the reference.
+
+ALTER TABLE etapas ADD COLUMN IF NOT EXISTS etapa_padrao_id UUID
+    REFERENCES etapas_padrao(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_etapas_etapa_padrao ON etapas(etapa_padrao_id);
+
+-- Existing etapas were copied from the catalog by name; link the ones whose name
+-- still matches a single etapa padrão
+UPDATE etapas e
+SET etapa_padrao_id = ep.id
+FROM etapas_padrao ep
+WHERE e.etapa_padrao_id IS NULL
+  AND LOWER(TRIM(e.nome)) = LOWER(TRIM(ep.nome))
+  AND (SELECT COUNT(*) FROM etapas_padrao o WHERE LOWER(TRIM(o.nome)) = LOWER(TRIM(ep.nome))) = 1;
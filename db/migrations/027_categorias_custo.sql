@@ -0,0 +1,15 @@
+-- Migration to map every conta a pagar type to an explicit cost category. Equipment
+-- rental and purchases get their own type (EQUIPAMENTO), feeding the EQUIPAMENTOS
+-- category, and costs that fit no category (type OUTROS, or unknown types) are
+-- reported as OUTROS instead of being counted as SERVICOS or MATERIAL.
+
+ALTER TABLE contas_pagar DROP CONSTRAINT IF EXISTS contas_pagar_tipo_conta_pagar_check;
+ALTER TABLE contas_pagar ADD CONSTRAINT contas_pagar_tipo_conta_pagar_check
+    CHECK (tipo_conta_pagar IN ('FORNECEDOR', 'SERVICO', 'MATERIAL', 'EQUIPAMENTO', 'OUTROS'));
+
+COMMENT ON COLUMN contas_pagar.tipo_conta_pagar IS 'Tipo da conta: FORNECEDOR, SERVICO, MATERIAL, EQUIPAMENTO ou OUTROS';
+
+-- The budget baseline accepts an OUTROS line so those costs can be planned too
+ALTER TABLE obra_orcamento_analitico_itens DROP CONSTRAINT IF EXISTS obra_orcamento_analitico_itens_categoria_check;
+ALTER TABLE obra_orcamento_analitico_itens ADD CONSTRAINT obra_orcamento_analitico_itens_categoria_check
+    CHECK (categoria IN ('MATERIAL', 'MAO_DE_OBRA', 'SERVICOS', 'EQUIPAMENTOS', 'OUTROS'));
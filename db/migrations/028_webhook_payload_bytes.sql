@@ -0,0 +1,7 @@
+-- Migration to store webhook delivery payloads as the exact bytes sent. JSONB
+-- reorders keys and normalizes whitespace, so retries and replays went out with a
+-- body different from the first attempt. Existing rows keep their JSONB text form.
+
+ALTER TABLE webhook_entregas ALTER COLUMN payload TYPE BYTEA USING convert_to(payload::text, 'UTF8');
+
+COMMENT ON COLUMN webhook_entregas.payload IS 'Corpo JSON da entrega, byte a byte como enviado e assinado';
@@ -0,0 +1,25 @@
+-- Migration to record the caução on each cronograma parcel. The conta a receber
+-- generated from a parcel already kept the withheld part in valor_retido, but the
+-- parcel stayed gross: once the client paid the net amount it remained PARCIAL,
+-- blocked the conclusion of the obra and kept charging the caução on the portal.
+
+ALTER TABLE cronograma_recebimentos ADD COLUMN IF NOT EXISTS valor_retido NUMERIC(15, 2) NOT NULL DEFAULT 0
+    CHECK (valor_retido >= 0);
+
+-- Copy the retention from the receivables generated by each parcel
+UPDATE cronograma_recebimentos cr
+SET valor_retido = c.valor_retido
+FROM contas_receber c
+WHERE c.cronograma_recebimento_id = cr.id
+  AND c.status <> 'CANCELADO'
+  AND c.valor_retido > 0;
+
+-- Parcels whose net amount was already paid are settled
+UPDATE cronograma_recebimentos
+SET status = 'RECEBIDO', updated_at = NOW()
+WHERE valor_retido > 0
+  AND valor_recebido > 0
+  AND valor_recebido >= valor_previsto - valor_retido
+  AND status IN ('PENDENTE', 'VENCIDO', 'PARCIAL');
+
+COMMENT ON COLUMN cronograma_recebimentos.valor_retido IS 'Caução retida do valor previsto; cobrada na liberação da caução, não na parcela';
@@ -0,0 +1,11 @@
+-- Migration to keep the planned etapa dates as an immutable baseline. The automatic
+-- rescheduling used to overwrite data_inicio_prevista/data_fim_prevista, so a pushed
+-- etapa never moved back and the planned progress (SPI) drifted toward the actual one.
+-- Recalculated dates now go to their own columns, filled only when they differ from
+-- the baseline. Baselines already overwritten by earlier reschedules cannot be restored.
+
+ALTER TABLE etapas ADD COLUMN IF NOT EXISTS data_inicio_programada DATE;
+ALTER TABLE etapas ADD COLUMN IF NOT EXISTS data_fim_programada DATE;
+
+COMMENT ON COLUMN etapas.data_inicio_programada IS 'Início recalculado pelas dependências, quando difere do previsto';
+COMMENT ON COLUMN etapas.data_fim_programada IS 'Fim recalculado pelas dependências, quando difere do previsto';
@@ -28,5 +28,5 @@ require (
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
 	golang.org/x/crypto v0.39.0
 	golang.org/x/sync v0.15.0
-	golang.org/x/text v0.26.0 // indirect
+	golang.org/x/text v0.26.0
 )
@@ -6,6 +6,7 @@ package authz
 const (
 	PermissaoObrasLer                   = "obras:ler"
 	PermissaoObrasEscrever              = "obras:escrever"
+	PermissaoObrasDiarioAssinar         = "obras:diario:assinar"
 	PermissaoPessoalEscrever            = "pessoal:escrever"
 	PermissaoPessoalLer                 = "pessoal:ler"
 	PermissaoSuprimentosLer             = "suprimentos:ler"
@@ -16,6 +17,12 @@ const (
 	PermissaoPessoalApontamentoLer      = "pessoal:apontamento:ler"
 	PermissaoPessoalApontamentoAprovar  = "pessoal:apontamento:aprovar"
 	PermissaoPessoalApontamentoPagar    = "pessoal:apontamento:pagar"
+	PermissaoIntegracoesGerenciar       = "integracoes:gerenciar"
+	PermissaoClientesLer                = "clientes:ler"
+	PermissaoClientesEscrever           = "clientes:escrever"
+	PermissaoAlertasLer                 = "alertas:ler"
+	PermissaoAlertasGerenciar           = "alertas:gerenciar"  // Reconhecer e adiar alertas
+	PermissaoAlertasConfigurar          = "alertas:configurar" // Regras de alerta da empresa
 )
 
 // Papel define um nome de papel/função para um conjunto de permissões.
@@ -28,10 +35,13 @@ const (
 )
 
 // mapaDePapeis associa cada Papel a uma lista de suas permissões.
+// As permissões são gravadas no usuário no cadastro: ao incluir uma nova aqui, crie também
+// uma migration que a conceda aos usuários existentes (ex.: 024_permissoes_clientes.sql).
 var mapaDePapeis = map[Papel][]string{
 	PapelGerenteDeObras: {
 		PermissaoObrasLer,
 		PermissaoObrasEscrever,
+		PermissaoObrasDiarioAssinar,
 		PermissaoPessoalEscrever,
 		PermissaoPessoalLer,
 		PermissaoSuprimentosLer,
@@ -41,6 +51,10 @@ var mapaDePapeis = map[Papel][]string{
 		PermissaoPessoalApontamentoEscrever,
 		PermissaoPessoalApontamentoAprovar,
 		PermissaoPessoalApontamentoPagar,
+		PermissaoClientesLer,
+		PermissaoClientesEscrever,
+		PermissaoAlertasLer,
+		PermissaoAlertasGerenciar,
 	},
 	PapelVisualizador: {
 		PermissaoObrasLer,
@@ -48,8 +62,15 @@ var mapaDePapeis = map[Papel][]string{
 		PermissaoSuprimentosLer,
 		PermissaoFinanceiroLer,
 		PermissaoPessoalApontamentoLer,
+		PermissaoClientesLer,
+		PermissaoAlertasLer,
 	},
 	// O PapelAdmin é especial e terá todas as permissões.
+	// As listadas aqui são exclusivas dele e entram na união feita por GetPermissoesParaPapel.
+	PapelAdmin: {
+		PermissaoIntegracoesGerenciar,
+		PermissaoAlertasConfigurar,
+	},
 }
 
 // GetPermissoesParaPapel retorna a lista de permissões para um dado papel.
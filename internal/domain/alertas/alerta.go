@@ -0,0 +1,157 @@
+// file: internal/domain/alertas/alerta.go
+package alertas
+
+import (
+	"errors"
+	"time"
+)
+
+// StatusAlerta representa o ciclo de vida de um alerta.
+type StatusAlerta string
+
+const (
+	StatusAtivo       StatusAlerta = "ATIVO"
+	StatusReconhecido StatusAlerta = "RECONHECIDO"
+	StatusAdiado      StatusAlerta = "ADIADO"
+	StatusResolvido   StatusAlerta = "RESOLVIDO"
+)
+
+var (
+	ErrAlertaResolvido  = errors.New("alerta já resolvido")
+	ErrAdiamentoPassado = errors.New("o adiamento deve terminar no futuro")
+)
+
+// Alerta é uma condição detectada por uma regra. Enquanto a condição persistir o
+// mesmo alerta (identificado por Tipo + Chave) é atualizado a cada avaliação; quando
+// ela deixa de ocorrer o alerta é resolvido automaticamente.
+type Alerta struct {
+	ID             string       `json:"id"`
+	Tipo           TipoAlerta   `json:"tipo"`
+	Chave          string       `json:"chave"`
+	ReferenciaID   string       `json:"referenciaId"`
+	ObraID         *string      `json:"obraId,omitempty"`
+	Titulo         string       `json:"titulo"`
+	Descricao      string       `json:"descricao"`
+	Severidade     Severidade   `json:"severidade"`
+	Status         StatusAlerta `json:"status"`
+	DetectadoEm    time.Time    `json:"detectadoEm"`
+	AtualizadoEm   time.Time    `json:"atualizadoEm"`
+	ReconhecidoPor *string      `json:"reconhecidoPor,omitempty"`
+	ReconhecidoEm  *time.Time   `json:"reconhecidoEm,omitempty"`
+	AdiadoAte      *time.Time   `json:"adiadoAte,omitempty"`
+	ResolvidoEm    *time.Time   `json:"resolvidoEm,omitempty"`
+}
+
+// Ocorrencia é uma condição encontrada na avaliação de uma regra.
+// Critica força a severidade CRITICA independentemente da regra (ex.: conta já vencida).
+type Ocorrencia struct {
+	Chave        string
+	ReferenciaID string
+	ObraID       *string
+	Titulo       string
+	Descricao    string
+	Critica      bool
+}
+
+// Aberto indica se o alerta ainda não foi resolvido.
+func (a *Alerta) Aberto() bool {
+	return a.Status != StatusResolvido
+}
+
+// Reconhecer registra que alguém tomou ciência do alerta.
+func (a *Alerta) Reconhecer(usuarioID string, agora time.Time) error {
+	if !a.Aberto() {
+		return ErrAlertaResolvido
+	}
+	a.Status = StatusReconhecido
+	a.ReconhecidoPor = &usuarioID
+	a.ReconhecidoEm = &agora
+	a.AdiadoAte = nil
+	a.AtualizadoEm = agora
+	return nil
+}
+
+// Adiar oculta o alerta até a data informada; ao expirar ele volta a ficar ativo.
+func (a *Alerta) Adiar(ate, agora time.Time) error {
+	if !a.Aberto() {
+		return ErrAlertaResolvido
+	}
+	if !ate.After(agora) {
+		return ErrAdiamentoPassado
+	}
+	a.Status = StatusAdiado
+	a.AdiadoAte = &ate
+	a.AtualizadoEm = agora
+	return nil
+}
+
+// Reconciliar aplica o resultado de uma avaliação aos alertas abertos do mesmo tipo:
+// cria alertas para ocorrências novas, atualiza os existentes, reativa adiamentos
+// vencidos e resolve os alertas cuja condição desapareceu. Retorna apenas os alertas
+// que precisam ser persistidos.
+func Reconciliar(abertos []*Alerta, ocorrencias []Ocorrencia, regra RegraAlerta, agora time.Time, novoID func() string) []*Alerta {
+	porChave := make(map[string]*Alerta, len(abertos))
+	for _, a := range abertos {
+		porChave[a.Chave] = a
+	}
+
+	var alterados []*Alerta
+	vistos := make(map[string]bool, len(ocorrencias))
+	for _, o := range ocorrencias {
+		if vistos[o.Chave] {
+			continue
+		}
+		vistos[o.Chave] = true
+
+		severidade := regra.Severidade
+		if o.Critica {
+			severidade = SeveridadeCritica
+		}
+
+		alerta, existe := porChave[o.Chave]
+		if !existe {
+			alterados = append(alterados, &Alerta{
+				ID:           novoID(),
+				Tipo:         regra.Tipo,
+				Chave:        o.Chave,
+				ReferenciaID: o.ReferenciaID,
+				ObraID:       o.ObraID,
+				Titulo:       o.Titulo,
+				Descricao:    o.Descricao,
+				Severidade:   severidade,
+				Status:       StatusAtivo,
+				DetectadoEm:  agora,
+				AtualizadoEm: agora,
+			})
+			continue
+		}
+
+		// Um alerta já reconhecido ou adiado volta a exigir atenção se agravar.
+		if alerta.Status != StatusAtivo && severidade.MaisGraveQue(alerta.Severidade) {
+			alerta.Status = StatusAtivo
+			alerta.AdiadoAte = nil
+		}
+		if alerta.Status == StatusAdiado && alerta.AdiadoAte != nil && !alerta.AdiadoAte.After(agora) {
+			alerta.Status = StatusAtivo
+			alerta.AdiadoAte = nil
+		}
+		alerta.Titulo = o.Titulo
+		alerta.Descricao = o.Descricao
+		alerta.ObraID = o.ObraID
+		alerta.Severidade = severidade
+		alerta.AtualizadoEm = agora
+		alterados = append(alterados, alerta)
+	}
+
+	for _, a := range abertos {
+		if vistos[a.Chave] {
+			continue
+		}
+		a.Status = StatusResolvido
+		a.ResolvidoEm = &agora
+		a.AdiadoAte = nil
+		a.AtualizadoEm = agora
+		alterados = append(alterados, a)
+	}
+	return alterados
+}
@@ -0,0 +1,134 @@
+// file: internal/domain/alertas/regra.go
+package alertas
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TipoAlerta identifica a condição verificada por uma regra de alerta.
+type TipoAlerta string
+
+const (
+	TipoObraAtrasada              TipoAlerta = "OBRA_ATRASADA"
+	TipoContaVencendo             TipoAlerta = "CONTA_VENCENDO"
+	TipoOrcamentoParado           TipoAlerta = "ORCAMENTO_PARADO"
+	TipoFuncionarioSemApontamento TipoAlerta = "FUNCIONARIO_SEM_APONTAMENTO"
+	TipoOrcamentoEstourado        TipoAlerta = "ORCAMENTO_ESTOURADO"
+)
+
+// Severidade classifica a urgência de um alerta.
+type Severidade string
+
+const (
+	SeveridadeInfo    Severidade = "INFO"
+	SeveridadeAtencao Severidade = "ATENCAO"
+	SeveridadeCritica Severidade = "CRITICA"
+)
+
+var (
+	ErrTipoAlertaInvalido = errors.New("tipo de alerta inválido")
+	ErrRegraInvalida      = errors.New("regra de alerta inválida")
+)
+
+// TiposAlerta lista os tipos suportados, na ordem em que são avaliados.
+var TiposAlerta = []TipoAlerta{
+	TipoObraAtrasada,
+	TipoContaVencendo,
+	TipoOrcamentoParado,
+	TipoFuncionarioSemApontamento,
+	TipoOrcamentoEstourado,
+}
+
+// RegraAlerta é a configuração da empresa para um tipo de alerta.
+// Limite é interpretado conforme o tipo (ver UnidadeLimite).
+type RegraAlerta struct {
+	Tipo       TipoAlerta `json:"tipo"`
+	Ativa      bool       `json:"ativa"`
+	Limite     int        `json:"limite"`
+	Severidade Severidade `json:"severidade"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+// RegraPadrao retorna a configuração usada enquanto a empresa não personalizar o tipo.
+func RegraPadrao(tipo TipoAlerta) RegraAlerta {
+	switch tipo {
+	case TipoObraAtrasada:
+		return RegraAlerta{Tipo: tipo, Ativa: true, Limite: 0, Severidade: SeveridadeCritica}
+	case TipoContaVencendo:
+		return RegraAlerta{Tipo: tipo, Ativa: true, Limite: 7, Severidade: SeveridadeAtencao}
+	case TipoOrcamentoParado:
+		return RegraAlerta{Tipo: tipo, Ativa: true, Limite: 15, Severidade: SeveridadeAtencao}
+	case TipoFuncionarioSemApontamento:
+		return RegraAlerta{Tipo: tipo, Ativa: true, Limite: 20, Severidade: SeveridadeAtencao}
+	case TipoOrcamentoEstourado:
+		return RegraAlerta{Tipo: tipo, Ativa: true, Limite: 100, Severidade: SeveridadeCritica}
+	}
+	return RegraAlerta{Tipo: tipo}
+}
+
+// UnidadeLimite descreve como o limite da regra é interpretado.
+func (t TipoAlerta) UnidadeLimite() string {
+	switch t {
+	case TipoObraAtrasada:
+		return "dias de tolerância após a data de término"
+	case TipoContaVencendo:
+		return "dias de antecedência do vencimento"
+	case TipoOrcamentoParado:
+		return "dias sem movimentação"
+	case TipoFuncionarioSemApontamento:
+		return "dias desde o último período apontado"
+	case TipoOrcamentoEstourado:
+		return "percentual do orçamento analítico consumido"
+	}
+	return ""
+}
+
+// Valido indica se o tipo é suportado.
+func (t TipoAlerta) Valido() bool {
+	for _, tipo := range TiposAlerta {
+		if t == tipo {
+			return true
+		}
+	}
+	return false
+}
+
+// Valida indica se a severidade é conhecida.
+func (s Severidade) Valida() bool {
+	return s == SeveridadeInfo || s == SeveridadeAtencao || s == SeveridadeCritica
+}
+
+// peso ordena as severidades da menos para a mais urgente.
+func (s Severidade) peso() int {
+	switch s {
+	case SeveridadeAtencao:
+		return 1
+	case SeveridadeCritica:
+		return 2
+	}
+	return 0
+}
+
+// MaisGraveQue indica se s é mais urgente que outra.
+func (s Severidade) MaisGraveQue(outra Severidade) bool {
+	return s.peso() > outra.peso()
+}
+
+// Validar verifica a configuração da regra.
+func (r *RegraAlerta) Validar() error {
+	if !r.Tipo.Valido() {
+		return ErrTipoAlertaInvalido
+	}
+	if !r.Severidade.Valida() {
+		return fmt.Errorf("%w: severidade deve ser INFO, ATENCAO ou CRITICA", ErrRegraInvalida)
+	}
+	if r.Limite < 0 {
+		return fmt.Errorf("%w: limite não pode ser negativo", ErrRegraInvalida)
+	}
+	if r.Tipo == TipoOrcamentoEstourado && r.Limite == 0 {
+		return fmt.Errorf("%w: percentual de estouro deve ser maior que zero", ErrRegraInvalida)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+// file: internal/domain/alertas/repository.go
+package alertas
+
+import (
+	"context"
+	"time"
+)
+
+// FiltroAlertas restringe a listagem de alertas. Sem Status, são retornados os
+// alertas ativos e reconhecidos; adiados reaparecem na avaliação seguinte ao prazo.
+type FiltroAlertas struct {
+	Status     []StatusAlerta
+	Tipo       *TipoAlerta
+	Severidade *Severidade
+	ObraID     *string
+	Limite     int
+}
+
+// RegraAlertaRepository define o contrato para persistência das regras configuradas.
+type RegraAlertaRepository interface {
+	ListarRegras(ctx context.Context) ([]*RegraAlerta, error)
+	SalvarRegra(ctx context.Context, regra *RegraAlerta) error
+}
+
+// AlertaRepository define o contrato para persistência dos alertas detectados.
+type AlertaRepository interface {
+	Salvar(ctx context.Context, alerta *Alerta) error
+	BuscarPorID(ctx context.Context, id string) (*Alerta, error)
+	ListarAbertosPorTipo(ctx context.Context, tipo TipoAlerta) ([]*Alerta, error)
+	Listar(ctx context.Context, filtro FiltroAlertas) ([]*Alerta, error)
+}
+
+// Avaliador executa a verificação de uma regra contra os dados atuais.
+type Avaliador interface {
+	Avaliar(ctx context.Context, regra RegraAlerta, hoje time.Time) ([]Ocorrencia, error)
+}
@@ -0,0 +1,233 @@
+// file: internal/domain/clientes/cliente.go
+package clientes
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// TipoPessoa indica se o cliente é pessoa física (CPF) ou jurídica (CNPJ).
+type TipoPessoa string
+
+const (
+	TipoPessoaFisica   TipoPessoa = "PF"
+	TipoPessoaJuridica TipoPessoa = "PJ"
+)
+
+var (
+	ErrClienteInvalido      = errors.New("cliente inválido")
+	ErrDocumentoInvalido    = errors.New("CPF ou CNPJ inválido")
+	ErrClienteNaoCadastrado = errors.New("cliente não cadastrado")
+	ErrMesclagemInvalida    = errors.New("mesclagem de clientes inválida")
+)
+
+// Contato é uma pessoa de contato do cliente (comprador, engenheiro, financeiro).
+type Contato struct {
+	Nome     string `json:"nome"`
+	Cargo    string `json:"cargo,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Telefone string `json:"telefone,omitempty"`
+}
+
+// Endereco é o endereço de cobrança do cliente.
+type Endereco struct {
+	Logradouro  string `json:"logradouro"`
+	Numero      string `json:"numero,omitempty"`
+	Complemento string `json:"complemento,omitempty"`
+	Bairro      string `json:"bairro,omitempty"`
+	Cidade      string `json:"cidade"`
+	UF          string `json:"uf"`
+	CEP         string `json:"cep,omitempty"`
+}
+
+// Cliente é o contratante das obras e o devedor das contas a receber. Clientes
+// migrados dos nomes livres antigos ficam sem tipo e documento até serem completados.
+type Cliente struct {
+	ID               string     `json:"id"`
+	Tipo             TipoPessoa `json:"tipo,omitempty"`
+	Nome             string     `json:"nome"`
+	Documento        *string    `json:"documento,omitempty"` // Apenas dígitos
+	Email            *string    `json:"email,omitempty"`
+	Telefone         *string    `json:"telefone,omitempty"`
+	Contatos         []Contato  `json:"contatos"`
+	EnderecoCobranca *Endereco  `json:"enderecoCobranca,omitempty"`
+	Observacoes      *string    `json:"observacoes,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+}
+
+// NovoCliente cria um cliente com tipo e documento válidos.
+func NovoCliente(id string, tipo TipoPessoa, nome, documento string, agora time.Time) (*Cliente, error) {
+	c := &Cliente{ID: id, Contatos: []Contato{}, CreatedAt: agora}
+	if err := c.Identificar(tipo, nome, documento, agora); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Identificar define nome, tipo e documento. O documento é obrigatório e deve
+// ser um CPF válido para pessoa física ou um CNPJ válido para pessoa jurídica.
+func (c *Cliente) Identificar(tipo TipoPessoa, nome, documento string, agora time.Time) error {
+	nome = strings.Join(strings.Fields(nome), " ")
+	if nome == "" {
+		return fmt.Errorf("%w: nome é obrigatório", ErrClienteInvalido)
+	}
+	documento = NormalizarDocumento(documento)
+	switch tipo {
+	case TipoPessoaFisica:
+		if !CPFValido(documento) {
+			return fmt.Errorf("%w: CPF %q", ErrDocumentoInvalido, documento)
+		}
+	case TipoPessoaJuridica:
+		if !CNPJValido(documento) {
+			return fmt.Errorf("%w: CNPJ %q", ErrDocumentoInvalido, documento)
+		}
+	default:
+		return fmt.Errorf("%w: tipo deve ser PF ou PJ", ErrClienteInvalido)
+	}
+
+	c.Nome = nome
+	c.Tipo = tipo
+	c.Documento = &documento
+	c.UpdatedAt = agora
+	return nil
+}
+
+// DefinirContatos substitui os contatos, exigindo o nome de cada um.
+func (c *Cliente) DefinirContatos(contatos []Contato) error {
+	for _, ct := range contatos {
+		if strings.TrimSpace(ct.Nome) == "" {
+			return fmt.Errorf("%w: contato sem nome", ErrClienteInvalido)
+		}
+	}
+	if contatos == nil {
+		contatos = []Contato{}
+	}
+	c.Contatos = contatos
+	return nil
+}
+
+// DefinirEnderecoCobranca substitui o endereço de cobrança (nil remove).
+func (c *Cliente) DefinirEnderecoCobranca(e *Endereco) error {
+	if e != nil {
+		if strings.TrimSpace(e.Logradouro) == "" || strings.TrimSpace(e.Cidade) == "" {
+			return fmt.Errorf("%w: endereço de cobrança exige logradouro e cidade", ErrClienteInvalido)
+		}
+		if len(e.UF) != 2 {
+			return fmt.Errorf("%w: UF deve ter 2 letras", ErrClienteInvalido)
+		}
+		e.UF = strings.ToUpper(e.UF)
+		e.CEP = NormalizarDocumento(e.CEP)
+	}
+	c.EnderecoCobranca = e
+	return nil
+}
+
+// Absorver completa o cliente com os dados do duplicado que será mesclado nele: campos
+// vazios são preenchidos e contatos com nomes novos são acrescentados. Clientes com
+// CPF/CNPJ diferentes são pessoas distintas e não podem ser mesclados.
+func (c *Cliente) Absorver(duplicado *Cliente, agora time.Time) error {
+	if c.ID == duplicado.ID {
+		return fmt.Errorf("%w: o cliente não pode ser mesclado com ele mesmo", ErrMesclagemInvalida)
+	}
+	if c.Documento != nil && duplicado.Documento != nil && *c.Documento != *duplicado.Documento {
+		return fmt.Errorf("%w: os clientes têm CPF/CNPJ diferentes", ErrMesclagemInvalida)
+	}
+
+	if c.Documento == nil && duplicado.Documento != nil {
+		c.Tipo, c.Documento = duplicado.Tipo, duplicado.Documento
+	}
+	if c.Email == nil {
+		c.Email = duplicado.Email
+	}
+	if c.Telefone == nil {
+		c.Telefone = duplicado.Telefone
+	}
+	if c.EnderecoCobranca == nil {
+		c.EnderecoCobranca = duplicado.EnderecoCobranca
+	}
+	if c.Observacoes == nil {
+		c.Observacoes = duplicado.Observacoes
+	}
+	for _, contato := range duplicado.Contatos {
+		existe := slices.ContainsFunc(c.Contatos, func(atual Contato) bool {
+			return NormalizarNome(atual.Nome) == NormalizarNome(contato.Nome)
+		})
+		if !existe {
+			c.Contatos = append(c.Contatos, contato)
+		}
+	}
+	c.UpdatedAt = agora
+	return nil
+}
+
+// CadastroCompleto indica se o cliente já tem tipo e documento.
+func (c *Cliente) CadastroCompleto() bool {
+	return c.Tipo != "" && c.Documento != nil
+}
+
+// NormalizarDocumento mantém apenas os dígitos de CPF, CNPJ ou CEP.
+func NormalizarDocumento(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// NormalizarNome é a chave usada para reconhecer o mesmo cliente escrito de formas
+// diferentes: sem espaços extras e sem diferença de maiúsculas.
+func NormalizarNome(nome string) string {
+	return strings.ToLower(strings.Join(strings.Fields(nome), " "))
+}
+
+// CPFValido confere os dígitos verificadores do CPF (11 dígitos).
+func CPFValido(cpf string) bool {
+	if len(cpf) != 11 || digitosRepetidos(cpf) {
+		return false
+	}
+	return digitoVerificador(cpf[:9], 10) == cpf[9] && digitoVerificador(cpf[:10], 11) == cpf[10]
+}
+
+// CNPJValido confere os dígitos verificadores do CNPJ (14 dígitos).
+func CNPJValido(cnpj string) bool {
+	if len(cnpj) != 14 || digitosRepetidos(cnpj) {
+		return false
+	}
+	pesos := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	return digitoCNPJ(cnpj[:12], pesos[1:]) == cnpj[12] && digitoCNPJ(cnpj[:13], pesos) == cnpj[13]
+}
+
+// digitoVerificador calcula um dígito do CPF com pesos decrescentes a partir de pesoInicial.
+func digitoVerificador(base string, pesoInicial int) byte {
+	soma := 0
+	for i, d := range base {
+		soma += int(d-'0') * (pesoInicial - i)
+	}
+	resto := soma * 10 % 11
+	if resto == 10 {
+		resto = 0
+	}
+	return byte('0' + resto)
+}
+
+func digitoCNPJ(base string, pesos []int) byte {
+	soma := 0
+	for i, d := range base {
+		soma += int(d-'0') * pesos[i]
+	}
+	resto := soma % 11
+	if resto < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - resto)
+}
+
+func digitosRepetidos(s string) bool {
+	return strings.Count(s, s[:1]) == len(s)
+}
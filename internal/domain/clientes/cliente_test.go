@@ -0,0 +1,94 @@
+package clientes
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func ptr(s string) *string { return &s }
+
+func TestAbsorver(t *testing.T) {
+	agora := time.Date(2025, 5, 20, 10, 0, 0, 0, time.UTC)
+
+	casos := []struct {
+		nome         string
+		sobrevivente Cliente
+		duplicado    Cliente
+		erro         error
+		verificar    func(t *testing.T, c *Cliente)
+	}{
+		{
+			nome:         "mesmo cliente",
+			sobrevivente: Cliente{ID: "a"},
+			duplicado:    Cliente{ID: "a"},
+			erro:         ErrMesclagemInvalida,
+		},
+		{
+			nome:         "documentos diferentes",
+			sobrevivente: Cliente{ID: "a", Tipo: TipoPessoaFisica, Documento: ptr("52998224725")},
+			duplicado:    Cliente{ID: "b", Tipo: TipoPessoaFisica, Documento: ptr("11144477735")},
+			erro:         ErrMesclagemInvalida,
+		},
+		{
+			nome:         "herda documento e campos vazios",
+			sobrevivente: Cliente{ID: "a", Nome: "João Silva", Email: ptr("joao@exemplo.com")},
+			duplicado: Cliente{
+				ID: "b", Nome: "Joao Silv", Tipo: TipoPessoaFisica, Documento: ptr("52998224725"),
+				Email: ptr("outro@exemplo.com"), Telefone: ptr("11 99999-0000"),
+				EnderecoCobranca: &Endereco{Logradouro: "Rua A", Cidade: "São Paulo", UF: "SP"},
+			},
+			verificar: func(t *testing.T, c *Cliente) {
+				if c.Tipo != TipoPessoaFisica || c.Documento == nil || *c.Documento != "52998224725" {
+					t.Errorf("documento não herdado: %v %v", c.Tipo, c.Documento)
+				}
+				if *c.Email != "joao@exemplo.com" {
+					t.Errorf("e-mail do sobrevivente não deveria mudar: %s", *c.Email)
+				}
+				if c.Telefone == nil || c.EnderecoCobranca == nil {
+					t.Error("telefone e endereço vazios deveriam ser herdados")
+				}
+				if c.Nome != "João Silva" {
+					t.Errorf("nome do sobrevivente não deveria mudar: %s", c.Nome)
+				}
+			},
+		},
+		{
+			nome:         "mesmo documento",
+			sobrevivente: Cliente{ID: "a", Tipo: TipoPessoaJuridica, Documento: ptr("11222333000181")},
+			duplicado:    Cliente{ID: "b", Tipo: TipoPessoaJuridica, Documento: ptr("11222333000181")},
+		},
+		{
+			nome:         "acrescenta apenas contatos novos",
+			sobrevivente: Cliente{ID: "a", Contatos: []Contato{{Nome: "Maria  Souza"}}},
+			duplicado:    Cliente{ID: "b", Contatos: []Contato{{Nome: "maria souza", Cargo: "Compras"}, {Nome: "Carlos"}}},
+			verificar: func(t *testing.T, c *Cliente) {
+				if len(c.Contatos) != 2 || c.Contatos[1].Nome != "Carlos" {
+					t.Errorf("contatos inesperados: %+v", c.Contatos)
+				}
+			},
+		},
+	}
+
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			sobrevivente := c.sobrevivente
+			err := sobrevivente.Absorver(&c.duplicado, agora)
+			if c.erro != nil {
+				if !errors.Is(err, c.erro) {
+					t.Fatalf("esperava %v, obteve %v", c.erro, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if !sobrevivente.UpdatedAt.Equal(agora) {
+				t.Error("UpdatedAt deveria ser atualizado")
+			}
+			if c.verificar != nil {
+				c.verificar(t, &sobrevivente)
+			}
+		})
+	}
+}
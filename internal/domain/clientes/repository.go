@@ -0,0 +1,24 @@
+// file: internal/domain/clientes/repository.go
+package clientes
+
+import (
+	"context"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/common"
+)
+
+// ClienteRepository define o contrato para persistência dos clientes.
+type ClienteRepository interface {
+	Salvar(ctx context.Context, cliente *Cliente) error
+	// Atualizar grava o cliente e repassa o nome para as obras e contas a receber vinculadas.
+	Atualizar(ctx context.Context, cliente *Cliente) error
+	BuscarPorID(ctx context.Context, id string) (*Cliente, error)
+	BuscarPorDocumento(ctx context.Context, documento string) (*Cliente, error)
+	// BuscarPorNome compara pelo nome normalizado (ver NormalizarNome).
+	BuscarPorNome(ctx context.Context, nome string) (*Cliente, error)
+	Listar(ctx context.Context, busca string, filtros common.ListarFiltros) ([]*Cliente, *common.PaginacaoInfo, error)
+	ContarVinculos(ctx context.Context, id string) (int, error)
+	// Mesclar grava o sobrevivente, transfere as obras e contas do duplicado e exclui o duplicado.
+	Mesclar(ctx context.Context, sobrevivente *Cliente, duplicadoID string) error
+	Deletar(ctx context.Context, id string) error
+}
@@ -8,6 +8,7 @@ type ListarFiltros struct {
 	FornecedorID      string
 	FuncionarioID     string
 	ObraID            string
+	ClienteID         string
 	DataInicio        string
 	DataFim           string
 }
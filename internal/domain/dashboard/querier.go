@@ -30,7 +30,6 @@ type Querier interface {
 	ObterGastosFornecedores(ctx context.Context, dataInicio, dataFim time.Time, limite int) (*dto.GastosFornecedoresDTO, error)
 	ObterEstatisticasGeraisFornecedores(ctx context.Context) (*dto.EstatisticasGeraisFornecedoresDTO, error)
 
-	// Resumo Geral e Alertas
+	// Resumo Geral (os alertas vêm do módulo de alertas, ver service.FonteAlertas)
 	ObterResumoGeral(ctx context.Context) (*dto.ResumoGeralDTO, error)
-	ObterAlertas(ctx context.Context) (*dto.AlertasDTO, error)
 }
\ No newline at end of file
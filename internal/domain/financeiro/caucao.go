@@ -0,0 +1,55 @@
+package financeiro
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrRetencaoInvalida indica um percentual de caução inválido ou aplicado fora de hora
+var ErrRetencaoInvalida = errors.New("retenção contratual inválida")
+
+// DiasLiberacaoCaucao é o prazo, a partir da conclusão da obra, para o vencimento da caução
+const DiasLiberacaoCaucao = 30
+
+// ResumoCaucao é a posição da caução contratual de uma obra
+type ResumoCaucao struct {
+	TotalRetido   float64 // Retido das contas não canceladas
+	TotalLiberado float64 // Lançado em contas CAUCAO não canceladas
+	TotalRecebido float64 // Já recebido das contas CAUCAO
+	SaldoALiberar float64 // Retido e ainda não lançado para recebimento
+}
+
+// CalcularCaucao consolida a caução a partir das contas a receber da obra.
+// Contas canceladas não retêm nem liberam nada.
+func CalcularCaucao(contas []*ContaReceber) ResumoCaucao {
+	var r ResumoCaucao
+	for _, c := range contas {
+		if c.Status == StatusContaReceberCancelado {
+			continue
+		}
+		if c.TipoContaReceber == TipoContaReceberCaucao {
+			r.TotalLiberado += c.ValorOriginal
+			r.TotalRecebido += c.ValorRecebido
+			continue
+		}
+		r.TotalRetido += c.ValorRetido
+	}
+	r.TotalRetido = arredondarCentavos(r.TotalRetido)
+	r.TotalLiberado = arredondarCentavos(r.TotalLiberado)
+	r.TotalRecebido = arredondarCentavos(r.TotalRecebido)
+	r.SaldoALiberar = arredondarCentavos(r.TotalRetido - r.TotalLiberado)
+	return r
+}
+
+// CalcularRetencao devolve a caução retida de um valor bruto. A conta é feita em
+// centavos e centésimos de ponto percentual inteiros: em float, 333,33 × 5% daria
+// 16,6649... e arredondaria para baixo.
+func CalcularRetencao(bruto, percentual float64) float64 {
+	brutoCentavos := int64(math.Round(bruto * 100))
+	basePontos := int64(math.Round(percentual * 100))
+	return float64((brutoCentavos*basePontos+5000)/10000) / 100
+}
+
+func arredondarCentavos(v float64) float64 {
+	return math.Round(v*100) / 100
+}
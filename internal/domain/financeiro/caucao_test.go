@@ -0,0 +1,62 @@
+package financeiro
+
+import "testing"
+
+func TestCalcularCaucao(t *testing.T) {
+	medicao := func(status string, retido float64) *ContaReceber {
+		return &ContaReceber{TipoContaReceber: TipoContaReceberObra, Status: status, ValorRetido: retido}
+	}
+	liberacao := func(status string, valor, recebido float64) *ContaReceber {
+		return &ContaReceber{TipoContaReceber: TipoContaReceberCaucao, Status: status, ValorOriginal: valor, ValorRecebido: recebido}
+	}
+
+	casos := []struct {
+		nome     string
+		contas   []*ContaReceber
+		esperado ResumoCaucao
+	}{
+		{nome: "obra sem contas"},
+		{
+			nome: "retido ainda não liberado",
+			contas: []*ContaReceber{
+				medicao(StatusContaReceberRecebido, 50),
+				medicao(StatusContaReceberPendente, 16.67),
+			},
+			esperado: ResumoCaucao{TotalRetido: 66.67, SaldoALiberar: 66.67},
+		},
+		{
+			nome: "contas canceladas não contam",
+			contas: []*ContaReceber{
+				medicao(StatusContaReceberRecebido, 50),
+				medicao(StatusContaReceberCancelado, 30),
+				liberacao(StatusContaReceberCancelado, 50, 0),
+			},
+			esperado: ResumoCaucao{TotalRetido: 50, SaldoALiberar: 50},
+		},
+		{
+			nome: "caução liberada e recebida em parte",
+			contas: []*ContaReceber{
+				medicao(StatusContaReceberRecebido, 0.1),
+				medicao(StatusContaReceberRecebido, 0.2),
+				liberacao(StatusContaReceberParcial, 0.3, 0.1),
+			},
+			esperado: ResumoCaucao{TotalRetido: 0.3, TotalLiberado: 0.3, TotalRecebido: 0.1},
+		},
+		{
+			nome: "conta de caução não retém",
+			contas: []*ContaReceber{
+				medicao(StatusContaReceberRecebido, 100),
+				{TipoContaReceber: TipoContaReceberCaucao, Status: StatusContaReceberPendente, ValorOriginal: 40, ValorRetido: 5},
+			},
+			esperado: ResumoCaucao{TotalRetido: 100, TotalLiberado: 40, SaldoALiberar: 60},
+		},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			if got := CalcularCaucao(tc.contas); got != tc.esperado {
+				t.Errorf("CalcularCaucao() = %+v, esperado %+v", got, tc.esperado)
+			}
+		})
+	}
+}
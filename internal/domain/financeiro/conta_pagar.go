@@ -16,10 +16,11 @@ const (
 
 // TipoContaPagar representa os tipos de conta a pagar
 const (
-	TipoContaPagarFornecedor = "FORNECEDOR"
-	TipoContaPagarServico    = "SERVICO"
-	TipoContaPagarMaterial   = "MATERIAL"
-	TipoContaPagarOutros     = "OUTROS"
+	TipoContaPagarFornecedor  = "FORNECEDOR"
+	TipoContaPagarServico     = "SERVICO"
+	TipoContaPagarMaterial    = "MATERIAL"
+	TipoContaPagarEquipamento = "EQUIPAMENTO" // Locação e compra de equipamentos
+	TipoContaPagarOutros      = "OUTROS"
 )
 
 // CategoriaContaPagar representa as categorias de conta a pagar
@@ -37,7 +38,7 @@ type ContaPagar struct {
 	ObraID            *string    `json:"obraId,omitempty"`              // Referência à obra (opcional)
 	OrcamentoID       *string    `json:"orcamentoId,omitempty"`         // Referência ao orçamento que originou
 	FornecedorNome    string     `json:"fornecedorNome"`                // Nome do fornecedor
-	TipoContaPagar    string     `json:"tipoContaPagar"`                // FORNECEDOR, SERVICO, MATERIAL, OUTROS
+	TipoContaPagar    string     `json:"tipoContaPagar"`                // FORNECEDOR, SERVICO, MATERIAL, EQUIPAMENTO, OUTROS
 	Categoria         string     `json:"categoria"`                     // ORCAMENTO, APONTAMENTO, MANUAL, OUTROS
 	Descricao         string     `json:"descricao"`                     // Descrição da conta
 	ValorOriginal     float64    `json:"valorOriginal"`                 // Valor original
@@ -119,6 +120,11 @@ func (cp *ContaPagar) MarcarComoVencido() {
 	}
 }
 
+// EstaEmAberto indica se a conta ainda aguarda pagamento
+func (cp *ContaPagar) EstaEmAberto() bool {
+	return cp.Status != StatusContaPagarPago && cp.Status != StatusContaPagarCancelado
+}
+
 // Cancelar cancela a conta a pagar
 func (cp *ContaPagar) Cancelar(motivo *string) error {
 	if cp.Status == StatusContaPagarPago {
@@ -146,8 +152,9 @@ func (cp *ContaPagar) Validar() error {
 	if cp.TipoContaPagar != TipoContaPagarFornecedor && 
 		cp.TipoContaPagar != TipoContaPagarServico && 
 		cp.TipoContaPagar != TipoContaPagarMaterial &&
+		cp.TipoContaPagar != TipoContaPagarEquipamento &&
 		cp.TipoContaPagar != TipoContaPagarOutros {
-		return errors.New("tipoContaPagar deve ser FORNECEDOR, SERVICO, MATERIAL ou OUTROS")
+		return errors.New("tipoContaPagar deve ser FORNECEDOR, SERVICO, MATERIAL, EQUIPAMENTO ou OUTROS")
 	}
 	if cp.Descricao == "" {
 		return errors.New("descrição é obrigatória")
@@ -2,6 +2,8 @@ package financeiro
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -19,24 +21,32 @@ const (
 	TipoContaReceberObra      = "OBRA"
 	TipoContaReceberServico   = "SERVICO"
 	TipoContaReceberOutros    = "OUTROS"
+	TipoContaReceberCaucao    = "CAUCAO" // Liberação da caução retida das medições
 )
 
+// ErrBoletoInvalido indica uma linha digitável malformada ou conta que não aceita boleto
+var ErrBoletoInvalido = errors.New("boleto inválido")
+
 // ContaReceber representa uma conta a receber no sistema
 type ContaReceber struct {
 	ID                      string     `json:"id"`
 	ObraID                  *string    `json:"obraId,omitempty"`              // Referência à obra (opcional)
 	CronogramaRecebimentoID *string    `json:"cronogramaRecebimentoId,omitempty"` // Referência ao cronograma
+	ClienteID               *string    `json:"clienteId,omitempty"`           // Referência ao cadastro de clientes
 	Cliente                 string     `json:"cliente"`                       // Nome do cliente
 	TipoContaReceber        string     `json:"tipoContaReceber"`              // OBRA, SERVICO, OUTROS
 	Descricao               string     `json:"descricao"`                     // Descrição da conta
 	ValorOriginal           float64    `json:"valorOriginal"`                 // Valor original
 	ValorRecebido           float64    `json:"valorRecebido"`                 // Valor já recebido
+	ValorRetido             float64    `json:"valorRetido"`                   // Caução retida do valor bruto, fora do saldo a receber
 	DataVencimento          time.Time  `json:"dataVencimento"`                // Data de vencimento
 	DataRecebimento         *time.Time `json:"dataRecebimento,omitempty"`     // Data do recebimento
 	Status                  string     `json:"status"`                        // Status da conta
 	FormaPagamento          *string    `json:"formaPagamento,omitempty"`      // Como foi pago
 	Observacoes             *string    `json:"observacoes,omitempty"`         // Observações gerais
 	NumeroDocumento         *string    `json:"numeroDocumento,omitempty"`     // Número do documento/nota fiscal
+	LinhaDigitavel          *string    `json:"linhaDigitavel,omitempty"`      // Linha digitável do boleto emitido
+	URLBoleto               *string    `json:"urlBoleto,omitempty"`           // Link para o PDF do boleto
 	CreatedAt               time.Time  `json:"createdAt"`
 	UpdatedAt               time.Time  `json:"updatedAt"`
 }
@@ -107,6 +117,49 @@ func (cr *ContaReceber) MarcarComoVencido() {
 	}
 }
 
+// EstaEmAberto indica se a conta ainda aguarda recebimento
+func (cr *ContaReceber) EstaEmAberto() bool {
+	return cr.Status != StatusContaReceberRecebido && cr.Status != StatusContaReceberCancelado
+}
+
+// DefinirBoleto registra o boleto emitido para a conta. A linha digitável é gravada só com dígitos.
+func (cr *ContaReceber) DefinirBoleto(linhaDigitavel string, url *string) error {
+	if !cr.EstaEmAberto() {
+		return fmt.Errorf("%w: a conta já foi recebida ou cancelada", ErrBoletoInvalido)
+	}
+	digitos := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, linhaDigitavel)
+	// 47 dígitos para boletos bancários, 48 para arrecadação (convênios)
+	if len(digitos) != 47 && len(digitos) != 48 {
+		return fmt.Errorf("%w: a linha digitável deve ter 47 ou 48 dígitos", ErrBoletoInvalido)
+	}
+	cr.LinhaDigitavel = &digitos
+	cr.URLBoleto = url
+	cr.UpdatedAt = time.Now()
+	return nil
+}
+
+// AplicarRetencao separa a caução contratual do valor bruto da conta: ValorOriginal passa
+// a ser o líquido a receber agora e ValorRetido o que só é liberado na entrega da obra.
+func (cr *ContaReceber) AplicarRetencao(percentual float64) error {
+	if percentual < 0 || percentual >= 100 {
+		return fmt.Errorf("%w: percentual deve estar entre 0 e 100", ErrRetencaoInvalida)
+	}
+	if cr.ValorRecebido > 0 || cr.ValorRetido > 0 {
+		return fmt.Errorf("%w: a retenção só pode ser aplicada na criação da conta", ErrRetencaoInvalida)
+	}
+	if cr.TipoContaReceber == TipoContaReceberCaucao {
+		return fmt.Errorf("%w: a liberação da caução não sofre nova retenção", ErrRetencaoInvalida)
+	}
+	cr.ValorRetido = CalcularRetencao(cr.ValorOriginal, percentual)
+	cr.ValorOriginal = arredondarCentavos(cr.ValorOriginal - cr.ValorRetido)
+	return nil
+}
+
 // Cancelar cancela a conta a receber
 func (cr *ContaReceber) Cancelar(motivo *string) error {
 	if cr.Status == StatusContaReceberRecebido {
@@ -133,8 +186,9 @@ func (cr *ContaReceber) Validar() error {
 	}
 	if cr.TipoContaReceber != TipoContaReceberObra && 
 		cr.TipoContaReceber != TipoContaReceberServico && 
-		cr.TipoContaReceber != TipoContaReceberOutros {
-		return errors.New("tipoContaReceber deve ser OBRA, SERVICO ou OUTROS")
+		cr.TipoContaReceber != TipoContaReceberOutros &&
+		cr.TipoContaReceber != TipoContaReceberCaucao {
+		return errors.New("tipoContaReceber deve ser OBRA, SERVICO, OUTROS ou CAUCAO")
 	}
 	if cr.Descricao == "" {
 		return errors.New("descrição é obrigatória")
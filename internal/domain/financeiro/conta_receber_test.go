@@ -0,0 +1,50 @@
+package financeiro
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAplicarRetencao(t *testing.T) {
+	casos := []struct {
+		nome            string
+		conta           ContaReceber
+		percentual      float64
+		esperadoLiquido float64
+		esperadoRetido  float64
+		erro            error
+	}{
+		{nome: "sem retenção", conta: ContaReceber{ValorOriginal: 1000}, percentual: 0, esperadoLiquido: 1000},
+		{nome: "retenção de 5%", conta: ContaReceber{ValorOriginal: 1000}, percentual: 5, esperadoLiquido: 950, esperadoRetido: 50},
+		{nome: "meio centavo arredonda para cima", conta: ContaReceber{ValorOriginal: 333.33}, percentual: 5, esperadoLiquido: 316.66, esperadoRetido: 16.67},
+		{nome: "percentual fracionado", conta: ContaReceber{ValorOriginal: 1234.56}, percentual: 2.5, esperadoLiquido: 1203.70, esperadoRetido: 30.86},
+		{nome: "líquido mais retido fecha o bruto", conta: ContaReceber{ValorOriginal: 0.10}, percentual: 30, esperadoLiquido: 0.07, esperadoRetido: 0.03},
+		{nome: "percentual negativo", conta: ContaReceber{ValorOriginal: 1000}, percentual: -1, erro: ErrRetencaoInvalida},
+		{nome: "percentual de 100%", conta: ContaReceber{ValorOriginal: 1000}, percentual: 100, erro: ErrRetencaoInvalida},
+		{nome: "conta já recebida em parte", conta: ContaReceber{ValorOriginal: 1000, ValorRecebido: 10}, percentual: 5, erro: ErrRetencaoInvalida},
+		{nome: "retenção já aplicada", conta: ContaReceber{ValorOriginal: 950, ValorRetido: 50}, percentual: 5, erro: ErrRetencaoInvalida},
+		{nome: "liberação da caução", conta: ContaReceber{ValorOriginal: 50, TipoContaReceber: TipoContaReceberCaucao}, percentual: 5, erro: ErrRetencaoInvalida},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			c := tc.conta
+			err := c.AplicarRetencao(tc.percentual)
+			if tc.erro != nil {
+				if !errors.Is(err, tc.erro) {
+					t.Fatalf("erro = %v, esperado %v", err, tc.erro)
+				}
+				if c.ValorOriginal != tc.conta.ValorOriginal || c.ValorRetido != tc.conta.ValorRetido {
+					t.Errorf("conta não deveria mudar quando a retenção é recusada")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if c.ValorOriginal != tc.esperadoLiquido || c.ValorRetido != tc.esperadoRetido {
+				t.Errorf("líquido/retido = %.2f/%.2f, esperado %.2f/%.2f", c.ValorOriginal, c.ValorRetido, tc.esperadoLiquido, tc.esperadoRetido)
+			}
+		})
+	}
+}
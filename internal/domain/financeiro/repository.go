@@ -18,12 +18,13 @@ type Repository interface {
 type ContaReceberRepository interface {
 	Salvar(ctx context.Context, db db.DBTX, conta *ContaReceber) error
 	Atualizar(ctx context.Context, conta *ContaReceber) error
+	AtualizarMuitas(ctx context.Context, db db.DBTX, contas []*ContaReceber) error
 	BuscarPorID(ctx context.Context, id string) (*ContaReceber, error)
 	ListarPorObraID(ctx context.Context, obraID string) ([]*ContaReceber, error)
 	ListarVencidas(ctx context.Context) ([]*ContaReceber, error)
 	ListarVencidasPorPeriodo(ctx context.Context, dataInicio, dataFim time.Time) ([]*ContaReceber, error)
 	ListarPorStatus(ctx context.Context, status string) ([]*ContaReceber, error)
-	ListarPorCliente(ctx context.Context, cliente string) ([]*ContaReceber, error)
+	ListarPorClienteID(ctx context.Context, clienteID string) ([]*ContaReceber, error)
 	Listar(ctx context.Context, filtros common.ListarFiltros) ([]*ContaReceber, *common.PaginacaoInfo, error)
 	Deletar(ctx context.Context, id string) error
 }
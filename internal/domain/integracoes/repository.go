@@ -0,0 +1,28 @@
+// file: internal/domain/integracoes/repository.go
+package integracoes
+
+import (
+	"context"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/common"
+)
+
+// AssinaturaWebhookRepository define o contrato para persistência das assinaturas de webhook.
+type AssinaturaWebhookRepository interface {
+	Salvar(ctx context.Context, assinatura *AssinaturaWebhook) error
+	Atualizar(ctx context.Context, assinatura *AssinaturaWebhook) error
+	BuscarPorID(ctx context.Context, id string) (*AssinaturaWebhook, error)
+	Listar(ctx context.Context) ([]*AssinaturaWebhook, error)
+	ListarAtivasPorEvento(ctx context.Context, evento string) ([]*AssinaturaWebhook, error)
+	Deletar(ctx context.Context, id string) error
+}
+
+// EntregaWebhookRepository define o contrato para o log de entregas de webhook.
+type EntregaWebhookRepository interface {
+	Salvar(ctx context.Context, entrega *EntregaWebhook) error
+	Atualizar(ctx context.Context, entrega *EntregaWebhook) error
+	BuscarPorID(ctx context.Context, id string) (*EntregaWebhook, error)
+	ListarPorAssinatura(ctx context.Context, assinaturaID string, filtros common.ListarFiltros) ([]*EntregaWebhook, *common.PaginacaoInfo, error)
+	ListarPendentes(ctx context.Context, ate time.Time, limite int) ([]*EntregaWebhook, error)
+}
@@ -0,0 +1,114 @@
+// file: internal/domain/integracoes/webhook.go
+package integracoes
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/pkg/retentativa"
+)
+
+// EventoCuringa permite que uma assinatura receba todos os eventos publicados.
+const EventoCuringa = "*"
+
+// Status possíveis de uma entrega de webhook
+const (
+	StatusEntregaPendente = "PENDENTE"
+	StatusEntregaSucesso  = "SUCESSO"
+	StatusEntregaFalha    = "FALHA"
+)
+
+// MaxTentativasEntrega é o número máximo de tentativas antes de a entrega ser marcada como FALHA.
+const MaxTentativasEntrega = 8
+
+// AssinaturaWebhook representa um sistema externo interessado em eventos de domínio.
+type AssinaturaWebhook struct {
+	ID        string    `json:"id"`
+	Descricao string    `json:"descricao"`
+	URL       string    `json:"url"`
+	Eventos   []string  `json:"eventos"` // Nomes dos tópicos do EventBus ou "*"
+	Segredo   string    `json:"-"`       // Usado para assinar o corpo com HMAC-SHA256
+	Ativa     bool      `json:"ativa"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// EntregaWebhook registra cada envio (e suas retentativas) de um evento para uma assinatura.
+type EntregaWebhook struct {
+	ID               string     `json:"id"`
+	AssinaturaID     string     `json:"assinaturaId"`
+	Evento           string     `json:"evento"`
+	Payload          []byte     `json:"-"` // Corpo JSON exatamente como enviado
+	Status           string     `json:"status"`
+	Tentativas       int        `json:"tentativas"`
+	UltimoStatusHTTP *int       `json:"ultimoStatusHttp,omitempty"`
+	UltimoErro       *string    `json:"ultimoErro,omitempty"`
+	ProximaTentativa *time.Time `json:"proximaTentativa,omitempty"`
+	EntregueEm       *time.Time `json:"entregueEm,omitempty"`
+	ReenvioDeID      *string    `json:"reenvioDeId,omitempty"` // Entrega original, quando é um replay
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+}
+
+// Validar verifica as regras básicas de uma assinatura.
+func (a *AssinaturaWebhook) Validar() error {
+	u, err := url.Parse(a.URL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return errors.New("url do webhook deve ser absoluta e usar http ou https")
+	}
+	if len(a.Eventos) == 0 {
+		return errors.New("a assinatura deve filtrar ao menos um evento")
+	}
+	if a.Segredo == "" {
+		return errors.New("segredo da assinatura é obrigatório")
+	}
+	return nil
+}
+
+// AceitaEvento indica se a assinatura deve receber o evento informado.
+func (a *AssinaturaWebhook) AceitaEvento(nome string) bool {
+	if !a.Ativa {
+		return false
+	}
+	for _, e := range a.Eventos {
+		if e == EventoCuringa || e == nome {
+			return true
+		}
+	}
+	return false
+}
+
+// RegistrarSucesso marca a entrega como concluída.
+func (e *EntregaWebhook) RegistrarSucesso(statusHTTP int, agora time.Time) {
+	e.Tentativas++
+	e.Status = StatusEntregaSucesso
+	e.UltimoStatusHTTP = &statusHTTP
+	e.UltimoErro = nil
+	e.ProximaTentativa = nil
+	e.EntregueEm = &agora
+	e.UpdatedAt = agora
+}
+
+// RegistrarFalha contabiliza a tentativa e agenda a próxima com backoff exponencial.
+// Ao atingir MaxTentativasEntrega a entrega é marcada como FALHA definitiva.
+func (e *EntregaWebhook) RegistrarFalha(statusHTTP *int, erro string, agora time.Time) {
+	e.Tentativas++
+	e.UltimoStatusHTTP = statusHTTP
+	e.UltimoErro = &erro
+	e.UpdatedAt = agora
+
+	e.ProximaTentativa = retentativa.Proxima(e.Tentativas, MaxTentativasEntrega, agora)
+	e.Status = StatusEntregaPendente
+	if e.ProximaTentativa == nil {
+		e.Status = StatusEntregaFalha
+	}
+}
+
+// Abandonar encerra a entrega como FALHA sem novas tentativas.
+func (e *EntregaWebhook) Abandonar(motivo string, agora time.Time) {
+	e.Status = StatusEntregaFalha
+	e.UltimoErro = &motivo
+	e.ProximaTentativa = nil
+	e.UpdatedAt = agora
+}
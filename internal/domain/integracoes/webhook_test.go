@@ -0,0 +1,52 @@
+package integracoes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/pkg/retentativa"
+)
+
+func TestEntregaWebhookRegistrarFalha(t *testing.T) {
+	agora := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	status := 503
+
+	casos := []struct {
+		nome             string
+		tentativas       int
+		esperadoStatus   string
+		esperadoProxima  time.Duration
+		semNovaTentativa bool
+	}{
+		{nome: "primeira falha", tentativas: 0, esperadoStatus: StatusEntregaPendente, esperadoProxima: 30 * time.Second},
+		{nome: "terceira falha", tentativas: 2, esperadoStatus: StatusEntregaPendente, esperadoProxima: 2 * time.Minute},
+		{nome: "penúltima tentativa", tentativas: MaxTentativasEntrega - 2, esperadoStatus: StatusEntregaPendente, esperadoProxima: retentativa.Backoff(MaxTentativasEntrega - 1)},
+		{nome: "última tentativa", tentativas: MaxTentativasEntrega - 1, esperadoStatus: StatusEntregaFalha, semNovaTentativa: true},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			e := &EntregaWebhook{Status: StatusEntregaPendente, Tentativas: tc.tentativas}
+			e.RegistrarFalha(&status, "serviço indisponível", agora)
+
+			if e.Tentativas != tc.tentativas+1 {
+				t.Errorf("tentativas = %d, esperado %d", e.Tentativas, tc.tentativas+1)
+			}
+			if e.Status != tc.esperadoStatus {
+				t.Errorf("status = %s, esperado %s", e.Status, tc.esperadoStatus)
+			}
+			if e.UltimoStatusHTTP == nil || *e.UltimoStatusHTTP != status || e.UltimoErro == nil {
+				t.Errorf("status HTTP e erro da tentativa deveriam ser registrados")
+			}
+			if tc.semNovaTentativa {
+				if e.ProximaTentativa != nil {
+					t.Errorf("entrega em FALHA não deveria ter próxima tentativa")
+				}
+				return
+			}
+			if e.ProximaTentativa == nil || !e.ProximaTentativa.Equal(agora.Add(tc.esperadoProxima)) {
+				t.Errorf("próxima tentativa = %v, esperado %v", e.ProximaTentativa, agora.Add(tc.esperadoProxima))
+			}
+		})
+	}
+}
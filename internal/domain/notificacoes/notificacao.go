@@ -0,0 +1,127 @@
+// file: internal/domain/notificacoes/notificacao.go
+package notificacoes
+
+import (
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/pkg/retentativa"
+)
+
+// Canal identifica um meio de entrega. IN_APP é sempre usado; os demais dependem
+// das preferências do usuário e de o canal estar configurado no servidor.
+type Canal string
+
+const (
+	CanalInApp    Canal = "IN_APP"
+	CanalEmail    Canal = "EMAIL"
+	CanalWhatsApp Canal = "WHATSAPP" // Gateway de WhatsApp/SMS
+	CanalWebhook  Canal = "WEBHOOK"
+)
+
+// CanaisExternos lista os canais que podem ser escolhidos nas preferências.
+var CanaisExternos = []Canal{CanalEmail, CanalWhatsApp, CanalWebhook}
+
+// Status de entrega em um canal externo
+const (
+	StatusEntregaPendente = "PENDENTE"
+	StatusEntregaEnviada  = "ENVIADA"
+	StatusEntregaFalha    = "FALHA"
+)
+
+// MaxTentativasEntrega é o número de tentativas antes de a entrega ser marcada como FALHA.
+const MaxTentativasEntrega = 5
+
+// Notificacao é uma mensagem para um usuário, gerada a partir de um evento de domínio.
+type Notificacao struct {
+	ID           string         `json:"id"`
+	UsuarioID    string         `json:"usuarioId"`
+	Evento       string         `json:"evento"`
+	Titulo       string         `json:"titulo"`
+	Mensagem     string         `json:"mensagem"`
+	ReferenciaID *string        `json:"referenciaId,omitempty"` // Registro que originou a notificação
+	ObraID       *string        `json:"obraId,omitempty"`
+	Lida         bool           `json:"lida"`
+	LidaEm       *time.Time     `json:"lidaEm,omitempty"`
+	Entregas     []EntregaCanal `json:"entregas"`
+	CreatedAt    time.Time      `json:"createdAt"`
+}
+
+// EntregaCanal é a fila de envio da notificação em um canal externo. O envio é feito
+// em segundo plano, com retentativas, e o resultado fica registrado aqui.
+type EntregaCanal struct {
+	Canal            Canal      `json:"canal"`
+	Destino          string     `json:"destino"`
+	Status           string     `json:"status"`
+	Tentativas       int        `json:"tentativas"`
+	Erro             string     `json:"erro,omitempty"`
+	ProximaTentativa *time.Time `json:"proximaTentativa,omitempty"`
+	Em               time.Time  `json:"em"` // Última alteração
+}
+
+// AgendarEntrega enfileira o envio da notificação no canal para o quanto antes. O ponteiro
+// retornado vale até o próximo agendamento, que pode realocar Entregas.
+func (n *Notificacao) AgendarEntrega(canal Canal, destino string, agora time.Time) *EntregaCanal {
+	n.Entregas = append(n.Entregas, EntregaCanal{
+		Canal:            canal,
+		Destino:          destino,
+		Status:           StatusEntregaPendente,
+		ProximaTentativa: &agora,
+		Em:               agora,
+	})
+	return &n.Entregas[len(n.Entregas)-1]
+}
+
+// ProximaEntrega retorna a tentativa pendente mais próxima, ou nil se não há entregas pendentes.
+func (n *Notificacao) ProximaEntrega() *time.Time {
+	var proxima *time.Time
+	for _, e := range n.Entregas {
+		if e.Status == StatusEntregaPendente && e.ProximaTentativa != nil &&
+			(proxima == nil || e.ProximaTentativa.Before(*proxima)) {
+			t := *e.ProximaTentativa
+			proxima = &t
+		}
+	}
+	return proxima
+}
+
+// Vencida informa se a entrega está pendente e sua tentativa já pode ser feita.
+func (e *EntregaCanal) Vencida(agora time.Time) bool {
+	return e.Status == StatusEntregaPendente && e.ProximaTentativa != nil && !e.ProximaTentativa.After(agora)
+}
+
+// RegistrarSucesso marca a entrega como enviada.
+func (e *EntregaCanal) RegistrarSucesso(agora time.Time) {
+	e.Tentativas++
+	e.Status = StatusEntregaEnviada
+	e.Erro = ""
+	e.ProximaTentativa = nil
+	e.Em = agora
+}
+
+// RegistrarFalha agenda a próxima tentativa com backoff exponencial.
+// Ao atingir MaxTentativasEntrega a entrega é marcada como FALHA definitiva.
+func (e *EntregaCanal) RegistrarFalha(erro string, agora time.Time) {
+	e.Tentativas++
+	e.Erro = erro
+	e.Em = agora
+	e.ProximaTentativa = retentativa.Proxima(e.Tentativas, MaxTentativasEntrega, agora)
+	e.Status = StatusEntregaPendente
+	if e.ProximaTentativa == nil {
+		e.Status = StatusEntregaFalha
+	}
+}
+
+// Abandonar encerra a entrega como FALHA sem novas tentativas (canal indisponível, sem destino).
+func (e *EntregaCanal) Abandonar(motivo string, agora time.Time) {
+	e.Status = StatusEntregaFalha
+	e.Erro = motivo
+	e.ProximaTentativa = nil
+	e.Em = agora
+}
+
+// Destinatario é um usuário ativo que pode receber notificações.
+type Destinatario struct {
+	UsuarioID string
+	Nome      string
+	Email     string
+}
@@ -0,0 +1,50 @@
+package notificacoes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/pkg/retentativa"
+)
+
+func TestEntregaCanalRetentativas(t *testing.T) {
+	agora := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	n := &Notificacao{}
+	e := n.AgendarEntrega(CanalEmail, "a@b.com", agora)
+
+	if !e.Vencida(agora) || n.ProximaEntrega() == nil {
+		t.Fatal("entrega agendada deveria estar vencida imediatamente")
+	}
+
+	for i := 1; i < MaxTentativasEntrega; i++ {
+		e.RegistrarFalha("timeout", agora)
+		if e.Status != StatusEntregaPendente || e.Tentativas != i {
+			t.Fatalf("tentativa %d: status %s", i, e.Status)
+		}
+		if esperado := agora.Add(retentativa.Backoff(i)); !e.ProximaTentativa.Equal(esperado) {
+			t.Fatalf("tentativa %d: próxima em %v, esperado %v", i, e.ProximaTentativa, esperado)
+		}
+		if e.Vencida(agora) {
+			t.Fatalf("tentativa %d: não deveria estar vencida antes do backoff", i)
+		}
+	}
+
+	e.RegistrarFalha("timeout", agora)
+	if e.Status != StatusEntregaFalha || e.ProximaTentativa != nil || n.ProximaEntrega() != nil {
+		t.Fatalf("após %d tentativas a entrega deveria falhar definitivamente: %+v", MaxTentativasEntrega, e)
+	}
+}
+
+func TestProximaEntregaConsideraApenasPendentes(t *testing.T) {
+	agora := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	n := &Notificacao{}
+	n.AgendarEntrega(CanalEmail, "a@b.com", agora).RegistrarSucesso(agora)
+	n.AgendarEntrega(CanalWebhook, "", agora).Abandonar("sem destino", agora)
+	whatsapp := n.AgendarEntrega(CanalWhatsApp, "+5511999990000", agora)
+	whatsapp.RegistrarFalha("gateway indisponível", agora)
+
+	proxima := n.ProximaEntrega()
+	if proxima == nil || !proxima.Equal(agora.Add(retentativa.Backoff(1))) {
+		t.Fatalf("próxima entrega = %v, esperado %v", proxima, agora.Add(retentativa.Backoff(1)))
+	}
+}
@@ -0,0 +1,96 @@
+// file: internal/domain/notificacoes/preferencias.go
+package notificacoes
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+)
+
+var ErrPreferenciasInvalidas = errors.New("preferências de notificação inválidas")
+
+// PreferenciasNotificacao guarda, por usuário, os contatos dos canais externos e
+// como cada evento deve ser notificado. Eventos sem preferência usam o padrão:
+// notificação in-app, sem canais externos.
+type PreferenciasNotificacao struct {
+	UsuarioID  string              `json:"usuarioId"`
+	Telefone   string              `json:"telefone"`   // Destino do canal WHATSAPP, formato internacional (+5511...)
+	WebhookURL string              `json:"webhookUrl"` // Destino do canal WEBHOOK
+	Eventos    []PreferenciaEvento `json:"eventos"`
+	UpdatedAt  time.Time           `json:"updatedAt"`
+}
+
+// PreferenciaEvento define se um evento gera notificação e por quais canais externos.
+type PreferenciaEvento struct {
+	Evento string  `json:"evento"`
+	Ativo  bool    `json:"ativo"`
+	Canais []Canal `json:"canais"`
+}
+
+// PreferenciasPadrao retorna as preferências de quem nunca configurou nada.
+func PreferenciasPadrao(usuarioID string) *PreferenciasNotificacao {
+	return &PreferenciasNotificacao{UsuarioID: usuarioID, Eventos: []PreferenciaEvento{}}
+}
+
+// ParaEvento retorna a preferência do evento, ou a padrão quando não configurada.
+func (p *PreferenciasNotificacao) ParaEvento(evento string) PreferenciaEvento {
+	for _, e := range p.Eventos {
+		if e.Evento == evento {
+			return e
+		}
+	}
+	return PreferenciaEvento{Evento: evento, Ativo: true, Canais: []Canal{}}
+}
+
+// Validar confere os eventos e canais escolhidos e se os canais têm destino.
+func (p *PreferenciasNotificacao) Validar(eventosDisponiveis []string) error {
+	if p.WebhookURL != "" {
+		u, err := url.Parse(p.WebhookURL)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("%w: webhookUrl deve ser absoluta e usar http ou https", ErrPreferenciasInvalidas)
+		}
+	}
+
+	vistos := make(map[string]bool, len(p.Eventos))
+	for _, e := range p.Eventos {
+		if !slices.Contains(eventosDisponiveis, e.Evento) {
+			return fmt.Errorf("%w: evento %q não gera notificações", ErrPreferenciasInvalidas, e.Evento)
+		}
+		if vistos[e.Evento] {
+			return fmt.Errorf("%w: evento %q repetido", ErrPreferenciasInvalidas, e.Evento)
+		}
+		vistos[e.Evento] = true
+
+		for _, c := range e.Canais {
+			switch c {
+			case CanalEmail:
+			case CanalWhatsApp:
+				if p.Telefone == "" {
+					return fmt.Errorf("%w: informe o telefone para usar o canal WHATSAPP", ErrPreferenciasInvalidas)
+				}
+			case CanalWebhook:
+				if p.WebhookURL == "" {
+					return fmt.Errorf("%w: informe a webhookUrl para usar o canal WEBHOOK", ErrPreferenciasInvalidas)
+				}
+			default:
+				return fmt.Errorf("%w: canal %q inválido", ErrPreferenciasInvalidas, c)
+			}
+		}
+	}
+	return nil
+}
+
+// Destino retorna o endereço do usuário no canal informado.
+func (p *PreferenciasNotificacao) Destino(canal Canal, d Destinatario) string {
+	switch canal {
+	case CanalEmail:
+		return d.Email
+	case CanalWhatsApp:
+		return p.Telefone
+	case CanalWebhook:
+		return p.WebhookURL
+	}
+	return ""
+}
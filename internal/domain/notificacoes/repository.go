@@ -0,0 +1,35 @@
+// file: internal/domain/notificacoes/repository.go
+package notificacoes
+
+import (
+	"context"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/common"
+)
+
+// NotificacaoRepository define o contrato para persistência das notificações. Salvar e
+// AtualizarEntregas mantêm o agendamento da próxima entrega (Notificacao.ProximaEntrega).
+// Filtros.Status aceita LIDA ou NAO_LIDA.
+type NotificacaoRepository interface {
+	Salvar(ctx context.Context, n *Notificacao) error
+	AtualizarEntregas(ctx context.Context, n *Notificacao) error
+	ListarPorUsuario(ctx context.Context, usuarioID string, filtros common.ListarFiltros) ([]*Notificacao, *common.PaginacaoInfo, error)
+	ContarNaoLidas(ctx context.Context, usuarioID string) (int, error)
+	MarcarLida(ctx context.Context, usuarioID, id string, agora time.Time) (*Notificacao, error)
+	MarcarTodasLidas(ctx context.Context, usuarioID string, agora time.Time) (int64, error)
+	// ReservarEntregasPendentes retorna notificações com entregas vencidas até "agora" e as
+	// reserva até "reservaAte", para que outra instância não as processe ao mesmo tempo.
+	ReservarEntregasPendentes(ctx context.Context, agora, reservaAte time.Time, limite int) ([]*Notificacao, error)
+}
+
+// PreferenciasRepository define o contrato para persistência das preferências por usuário.
+type PreferenciasRepository interface {
+	BuscarPreferencias(ctx context.Context, usuarioID string) (*PreferenciasNotificacao, error)
+	SalvarPreferencias(ctx context.Context, p *PreferenciasNotificacao) error
+}
+
+// DestinatarioRepository localiza os usuários ativos que têm uma permissão.
+type DestinatarioRepository interface {
+	ListarDestinatarios(ctx context.Context, permissao string) ([]Destinatario, error)
+}
@@ -0,0 +1,98 @@
+// file: internal/domain/obras/aditivo.go
+package obras
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAditivoInvalido    = errors.New("aditivo de contrato inválido")
+	ErrContratoComAditivo = errors.New("valor e prazo do contrato só mudam por aditivo depois do primeiro aditivo")
+)
+
+// Aditivo altera o contrato da obra: acréscimo ou supressão de valor, mudança de
+// prazo e descrição do escopo. Guarda o contrato antes e depois da alteração,
+// formando o histórico a partir da linha de base original.
+type Aditivo struct {
+	ID                      string     `json:"id"`
+	ObraID                  string     `json:"obraId"`
+	Numero                  int        `json:"numero"`
+	Descricao               string     `json:"descricao"`
+	ValorAcrescimo          float64    `json:"valorAcrescimo"` // Negativo para supressão
+	NovaDataFim             *time.Time `json:"novaDataFim,omitempty"`
+	DataAprovacao           time.Time  `json:"dataAprovacao"`
+	DocumentoURL            *string    `json:"documentoUrl,omitempty"`
+	ValorContratoAnterior   float64    `json:"valorContratoAnterior"`
+	ValorContratoResultante float64    `json:"valorContratoResultante"`
+	DataFimAnterior         *time.Time `json:"dataFimAnterior,omitempty"`
+	DataFimResultante       *time.Time `json:"dataFimResultante,omitempty"`
+	CriadoPor               string     `json:"criadoPor"`
+	CreatedAt               time.Time  `json:"createdAt"`
+}
+
+// NovoAditivo monta o aditivo sobre o contrato atual da obra. O aditivo precisa
+// alterar o valor ou o prazo, não pode reduzir o contrato abaixo do já recebido
+// e a aprovação não pode estar no futuro.
+func NovoAditivo(id string, obra *Obra, numero int, descricao string, valorAcrescimo float64, novaDataFim *time.Time, dataAprovacao time.Time, documentoURL *string, criadoPor string, agora time.Time) (*Aditivo, error) {
+	descricao = strings.TrimSpace(descricao)
+	if descricao == "" {
+		return nil, fmt.Errorf("%w: descreva o escopo do aditivo", ErrAditivoInvalido)
+	}
+	if valorAcrescimo == 0 && novaDataFim == nil {
+		return nil, fmt.Errorf("%w: o aditivo precisa alterar o valor ou o prazo", ErrAditivoInvalido)
+	}
+	dataAprovacao = dia(dataAprovacao)
+	if dataAprovacao.After(dia(agora)) {
+		return nil, fmt.Errorf("%w: data de aprovação no futuro", ErrAditivoInvalido)
+	}
+
+	resultante := obra.ValorContratoTotal + valorAcrescimo
+	if resultante <= 0 {
+		return nil, fmt.Errorf("%w: o contrato ficaria sem valor", ErrAditivoInvalido)
+	}
+	if resultante < obra.ValorRecebido {
+		return nil, fmt.Errorf("%w: o contrato ficaria abaixo do valor já recebido", ErrAditivoInvalido)
+	}
+
+	dataFimResultante := obra.DataFim
+	if novaDataFim != nil {
+		d := dia(*novaDataFim)
+		if d.Before(dia(obra.DataInicio)) {
+			return nil, fmt.Errorf("%w: novo prazo anterior ao início da obra", ErrAditivoInvalido)
+		}
+		if obra.DataFim != nil && d.Equal(dia(*obra.DataFim)) {
+			return nil, fmt.Errorf("%w: novo prazo igual ao atual", ErrAditivoInvalido)
+		}
+		novaDataFim = &d
+		dataFimResultante = &d
+	}
+	if documentoURL != nil && strings.TrimSpace(*documentoURL) == "" {
+		documentoURL = nil
+	}
+
+	return &Aditivo{
+		ID:                      id,
+		ObraID:                  obra.ID,
+		Numero:                  numero,
+		Descricao:               descricao,
+		ValorAcrescimo:          valorAcrescimo,
+		NovaDataFim:             novaDataFim,
+		DataAprovacao:           dataAprovacao,
+		DocumentoURL:            documentoURL,
+		ValorContratoAnterior:   obra.ValorContratoTotal,
+		ValorContratoResultante: resultante,
+		DataFimAnterior:         obra.DataFim,
+		DataFimResultante:       dataFimResultante,
+		CriadoPor:               criadoPor,
+		CreatedAt:               agora,
+	}, nil
+}
+
+// Aplicar leva o contrato resultante do aditivo para a obra.
+func (a *Aditivo) Aplicar(obra *Obra) {
+	obra.ValorContratoTotal = a.ValorContratoResultante
+	obra.DataFim = a.DataFimResultante
+}
@@ -2,15 +2,131 @@
 package obras
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
+var (
+	ErrAlocacaoInvalida    = errors.New("alocação inválida")
+	ErrAlocacaoEncerrada   = errors.New("alocação já encerrada")
+	ErrAlocacaoConflitante = errors.New("funcionário já alocado no período")
+)
+
 // Alocacao representa o agregado que vincula um funcionário a uma obra
-// por um determinado período.
+// por um determinado período. As datas são dias inteiros e o fim é inclusivo;
+// sem fim, a alocação vale por tempo indeterminado.
 type Alocacao struct {
-	ID                 string
-	ObraID             string
-	FuncionarioID      string
-	DataInicioAlocacao time.Time
-	DataFimAlocacao    *time.Time
+	ID                 string     `json:"id"`
+	ObraID             string     `json:"obraId"`
+	FuncionarioID      string     `json:"funcionarioId"`
+	DataInicioAlocacao time.Time  `json:"dataInicioAlocacao"`
+	DataFimAlocacao    *time.Time `json:"dataFimAlocacao,omitempty"`
+	MotivoEncerramento *string    `json:"motivoEncerramento,omitempty"`
+	MotivoSobreposicao *string    `json:"motivoSobreposicao,omitempty"` // Justificativa para alocar apesar do conflito
+	TransferidaDeID    *string    `json:"transferidaDeId,omitempty"`    // Alocação encerrada pela transferência
+}
+
+// ErroConflitoAlocacao lista as alocações do funcionário que se sobrepõem ao período pedido.
+type ErroConflitoAlocacao struct {
+	Conflitos []*Alocacao
+}
+
+func (e *ErroConflitoAlocacao) Error() string {
+	periodos := make([]string, 0, len(e.Conflitos))
+	for _, c := range e.Conflitos {
+		periodos = append(periodos, fmt.Sprintf("obra %s desde %s", c.ObraID, c.DataInicioAlocacao.Format("2006-01-02")))
+	}
+	return fmt.Sprintf("funcionário já alocado no período: %s", strings.Join(periodos, "; "))
+}
+
+func (e *ErroConflitoAlocacao) Unwrap() error { return ErrAlocacaoConflitante }
+
+// NovaAlocacao valida o período e monta a alocação.
+func NovaAlocacao(id, obraID, funcionarioID string, inicio time.Time, fim *time.Time) (*Alocacao, error) {
+	inicio = dia(inicio)
+	if fim != nil {
+		f := dia(*fim)
+		if f.Before(inicio) {
+			return nil, fmt.Errorf("%w: data de fim anterior à data de início", ErrAlocacaoInvalida)
+		}
+		fim = &f
+	}
+	return &Alocacao{
+		ID:                 id,
+		ObraID:             obraID,
+		FuncionarioID:      funcionarioID,
+		DataInicioAlocacao: inicio,
+		DataFimAlocacao:    fim,
+	}, nil
+}
+
+// AtivaEm indica se a alocação cobre o dia informado.
+func (a *Alocacao) AtivaEm(data time.Time) bool {
+	return a.Sobrepoe(data, &data)
+}
+
+// Sobrepoe indica se a alocação tem algum dia em comum com o período (fim nil = sem fim).
+func (a *Alocacao) Sobrepoe(inicio time.Time, fim *time.Time) bool {
+	if fim != nil && dia(*fim).Before(dia(a.DataInicioAlocacao)) {
+		return false
+	}
+	if a.DataFimAlocacao != nil && dia(*a.DataFimAlocacao).Before(dia(inicio)) {
+		return false
+	}
+	return true
+}
+
+// Encerrar define o último dia da alocação. Uma alocação com fim previsto pode ser
+// antecipada, mas não prorrogada por aqui.
+func (a *Alocacao) Encerrar(data time.Time, motivo string) error {
+	data = dia(data)
+	if data.Before(dia(a.DataInicioAlocacao)) {
+		return fmt.Errorf("%w: data de encerramento anterior ao início da alocação", ErrAlocacaoInvalida)
+	}
+	if a.DataFimAlocacao != nil && !data.Before(dia(*a.DataFimAlocacao)) {
+		return fmt.Errorf("%w em %s", ErrAlocacaoEncerrada, a.DataFimAlocacao.Format("2006-01-02"))
+	}
+	a.DataFimAlocacao = &data
+	if motivo = strings.TrimSpace(motivo); motivo != "" {
+		a.MotivoEncerramento = &motivo
+	}
+	return nil
+}
+
+// EncerrarNoDesligamento aplica o desligamento do funcionário na data às alocações
+// dele: as já iniciadas terminam na data e as que ainda não começaram são
+// devolvidas em canceladas, para serem removidas em vez de encerradas.
+func EncerrarNoDesligamento(alocacoes []*Alocacao, data time.Time, motivo string) (encerradas, canceladas []*Alocacao, err error) {
+	data = dia(data)
+	for _, a := range alocacoes {
+		if dia(a.DataInicioAlocacao).After(data) {
+			canceladas = append(canceladas, a)
+			continue
+		}
+		if a.DataFimAlocacao != nil && !dia(*a.DataFimAlocacao).After(data) {
+			continue // Já termina até a data do desligamento
+		}
+		if err := a.Encerrar(data, motivo); err != nil {
+			return nil, nil, fmt.Errorf("alocação %s: %w", a.ID, err)
+		}
+		encerradas = append(encerradas, a)
+	}
+	return encerradas, canceladas, nil
+}
+
+// Conflitos retorna as alocações que se sobrepõem à nova, desconsiderando ela mesma
+// e a alocação que está sendo substituída (ignorarID).
+func (a *Alocacao) Conflitos(existentes []*Alocacao, ignorarID string) []*Alocacao {
+	var conflitos []*Alocacao
+	for _, e := range existentes {
+		if e.ID == a.ID || (ignorarID != "" && e.ID == ignorarID) {
+			continue
+		}
+		if e.Sobrepoe(a.DataInicioAlocacao, a.DataFimAlocacao) {
+			conflitos = append(conflitos, e)
+		}
+	}
+	return conflitos
 }
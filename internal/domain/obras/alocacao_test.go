@@ -0,0 +1,79 @@
+package obras
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEncerrarNoDesligamento(t *testing.T) {
+	desligamento := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	data := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	fim := func(d int) *time.Time { f := data(d); return &f }
+
+	casos := []struct {
+		nome      string
+		alocacao  *Alocacao
+		encerrada bool
+		cancelada bool
+		fim       *time.Time
+	}{
+		{
+			nome:      "sem fim, iniciada",
+			alocacao:  &Alocacao{ID: "a", DataInicioAlocacao: data(1)},
+			encerrada: true,
+			fim:       fim(10),
+		},
+		{
+			nome:      "fim previsto depois do desligamento",
+			alocacao:  &Alocacao{ID: "a", DataInicioAlocacao: data(1), DataFimAlocacao: fim(20)},
+			encerrada: true,
+			fim:       fim(10),
+		},
+		{
+			nome:      "começa no dia do desligamento",
+			alocacao:  &Alocacao{ID: "a", DataInicioAlocacao: data(10)},
+			encerrada: true,
+			fim:       fim(10),
+		},
+		{
+			nome:     "termina no dia do desligamento",
+			alocacao: &Alocacao{ID: "a", DataInicioAlocacao: data(1), DataFimAlocacao: fim(10)},
+			fim:      fim(10),
+		},
+		{
+			nome:      "ainda não começou",
+			alocacao:  &Alocacao{ID: "a", DataInicioAlocacao: data(15)},
+			cancelada: true,
+		},
+		{
+			nome:      "futura de um dia só",
+			alocacao:  &Alocacao{ID: "a", DataInicioAlocacao: data(15), DataFimAlocacao: fim(15)},
+			cancelada: true,
+			fim:       fim(15),
+		},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			encerradas, canceladas, err := EncerrarNoDesligamento([]*Alocacao{tc.alocacao}, desligamento, "Funcionário desligado")
+			if err != nil {
+				t.Fatalf("EncerrarNoDesligamento() erro inesperado: %v", err)
+			}
+			if got := len(encerradas) == 1; got != tc.encerrada {
+				t.Errorf("encerrada = %v, esperado %v", got, tc.encerrada)
+			}
+			if got := len(canceladas) == 1; got != tc.cancelada {
+				t.Errorf("cancelada = %v, esperado %v", got, tc.cancelada)
+			}
+			switch got := tc.alocacao.DataFimAlocacao; {
+			case tc.fim == nil && got != nil:
+				t.Errorf("DataFimAlocacao = %s, esperado sem fim", got.Format("2006-01-02"))
+			case tc.fim != nil && (got == nil || !got.Equal(*tc.fim)):
+				t.Errorf("DataFimAlocacao = %v, esperado %s", got, tc.fim.Format("2006-01-02"))
+			}
+			if tc.encerrada && (tc.alocacao.MotivoEncerramento == nil || *tc.alocacao.MotivoEncerramento != "Funcionário desligado") {
+				t.Errorf("MotivoEncerramento = %v, esperado o motivo do desligamento", tc.alocacao.MotivoEncerramento)
+			}
+		})
+	}
+}
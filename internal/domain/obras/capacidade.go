@@ -0,0 +1,240 @@
+// file: internal/domain/obras/capacidade.go
+package obras
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrEfetivoPlanejadoInvalido = errors.New("efetivo planejado inválido")
+
+// CargoNaoInformado agrupa os funcionários cadastrados sem cargo.
+const CargoNaoInformado = "Sem cargo"
+
+// AlertaCapacidade sinaliza um problema de efetivo em uma semana e cargo.
+type AlertaCapacidade string
+
+const (
+	AlertaCapacidadeFalta         AlertaCapacidade = "FALTA"         // Demanda não coberta maior que os disponíveis
+	AlertaCapacidadeSobrealocacao AlertaCapacidade = "SOBREALOCACAO" // Funcionário em duas alocações no mesmo dia
+)
+
+// EfetivoPlanejado é a quantidade de funcionários de um cargo que a etapa precisa
+// durante o seu período previsto.
+type EfetivoPlanejado struct {
+	ID         string `json:"id"`
+	ObraID     string `json:"obraId"`
+	EtapaID    string `json:"etapaId"`
+	Cargo      string `json:"cargo"`
+	Quantidade int    `json:"quantidade"`
+}
+
+// ValidarEfetivoPlanejado confere cargos, quantidades e linhas repetidas por etapa e cargo.
+func ValidarEfetivoPlanejado(itens []*EfetivoPlanejado) error {
+	vistos := make(map[string]bool, len(itens))
+	for _, item := range itens {
+		item.Cargo = strings.TrimSpace(item.Cargo)
+		if item.Cargo == "" {
+			return fmt.Errorf("%w: cargo é obrigatório", ErrEfetivoPlanejadoInvalido)
+		}
+		if item.Quantidade <= 0 {
+			return fmt.Errorf("%w: quantidade do cargo '%s' deve ser positiva", ErrEfetivoPlanejadoInvalido, item.Cargo)
+		}
+		chave := item.EtapaID + "|" + chaveCargo(item.Cargo)
+		if vistos[chave] {
+			return fmt.Errorf("%w: cargo '%s' repetido na mesma etapa", ErrEfetivoPlanejadoInvalido, item.Cargo)
+		}
+		vistos[chave] = true
+	}
+	return nil
+}
+
+// FuncionarioCapacidade é um funcionário disponível para alocação.
+type FuncionarioCapacidade struct {
+	ID    string `json:"id"`
+	Nome  string `json:"nome"`
+	Cargo string `json:"cargo"`
+}
+
+// DemandaEfetivo é o efetivo planejado de uma etapa com as datas previstas dela.
+type DemandaEfetivo struct {
+	ObraID     string
+	EtapaID    string
+	Cargo      string
+	Quantidade int
+	Inicio     time.Time
+	Fim        time.Time
+}
+
+// SemanaCapacidade é uma coluna do calendário de capacidade (segunda a domingo).
+type SemanaCapacidade struct {
+	Inicio time.Time          `json:"inicio"`
+	Fim    time.Time          `json:"fim"`
+	Cargos []*CapacidadeCargo `json:"cargos"`
+}
+
+// CapacidadeCargo compara, em uma semana, o efetivo de um cargo com o que as obras pedem.
+type CapacidadeCargo struct {
+	Cargo         string                  `json:"cargo"`
+	Total         int                     `json:"total"`         // Funcionários ativos do cargo
+	Alocados      int                     `json:"alocados"`      // Alocados em alguma obra na semana
+	Disponiveis   int                     `json:"disponiveis"`   // Total - Alocados
+	Necessarios   int                     `json:"necessarios"`   // Efetivo planejado das etapas previstas na semana
+	Descobertos   int                     `json:"descobertos"`   // Necessário nas obras além de quem já está alocado nelas
+	Falta         int                     `json:"falta"`         // Descobertos que nem os disponíveis cobrem
+	Sobrealocados []FuncionarioCapacidade `json:"sobrealocados"` // Funcionários com alocações sobrepostas na semana
+	Alertas       []AlertaCapacidade      `json:"alertas"`
+}
+
+// InicioSemana retorna a segunda-feira da semana da data.
+func InicioSemana(data time.Time) time.Time {
+	data = dia(data)
+	return data.AddDate(0, 0, -((int(data.Weekday()) + 6) % 7))
+}
+
+// CalcularCapacidade monta o calendário semanal de capacidade por cargo a partir da
+// semana de inicio. O cargo do funcionário vale para todas as suas alocações;
+// alocações de quem não está na lista (desligados, inativos) são ignoradas.
+func CalcularCapacidade(inicio time.Time, semanas int, funcionarios []FuncionarioCapacidade, alocacoes []*Alocacao, demandas []DemandaEfetivo) []*SemanaCapacidade {
+	rotulos := make(map[string]string)
+	cargoDoFuncionario := make(map[string]string, len(funcionarios))
+	funcionarioPorID := make(map[string]FuncionarioCapacidade, len(funcionarios))
+	totalPorCargo := make(map[string]int)
+	for _, f := range funcionarios {
+		chave := registrarCargo(rotulos, f.Cargo)
+		cargoDoFuncionario[f.ID] = chave
+		funcionarioPorID[f.ID] = f
+		totalPorCargo[chave]++
+	}
+	for _, d := range demandas {
+		registrarCargo(rotulos, d.Cargo)
+	}
+	chaves := make([]string, 0, len(rotulos))
+	for chave := range rotulos {
+		chaves = append(chaves, chave)
+	}
+	sort.Slice(chaves, func(i, j int) bool { return rotulos[chaves[i]] < rotulos[chaves[j]] })
+
+	calendario := make([]*SemanaCapacidade, 0, semanas)
+	segunda := InicioSemana(inicio)
+	for s := 0; s < semanas; s++ {
+		ini := segunda.AddDate(0, 0, 7*s)
+		fim := ini.AddDate(0, 0, 6)
+
+		// Quem está alocado onde na semana, e quem tem duas alocações no mesmo dia
+		alocadosPorCargo := make(map[string]map[string]bool)
+		alocadosNaObra := make(map[string]int) // obra|cargo -> funcionários distintos
+		vistosNaObra := make(map[string]bool)
+		sobrealocados := make(map[string][]FuncionarioCapacidade)
+		alocacoesPorFuncionario := make(map[string][]*Alocacao)
+		for _, a := range alocacoes {
+			chave, ok := cargoDoFuncionario[a.FuncionarioID]
+			if !ok || !a.Sobrepoe(ini, &fim) {
+				continue
+			}
+			if alocadosPorCargo[chave] == nil {
+				alocadosPorCargo[chave] = make(map[string]bool)
+			}
+			alocadosPorCargo[chave][a.FuncionarioID] = true
+			if obraCargo := a.ObraID + "|" + chave; !vistosNaObra[obraCargo+"|"+a.FuncionarioID] {
+				vistosNaObra[obraCargo+"|"+a.FuncionarioID] = true
+				alocadosNaObra[obraCargo]++
+			}
+			alocacoesPorFuncionario[a.FuncionarioID] = append(alocacoesPorFuncionario[a.FuncionarioID], a)
+		}
+		for funcionarioID, lista := range alocacoesPorFuncionario {
+			if sobrepostasNaSemana(lista, ini) {
+				chave := cargoDoFuncionario[funcionarioID]
+				sobrealocados[chave] = append(sobrealocados[chave], funcionarioPorID[funcionarioID])
+			}
+		}
+
+		// Demanda das etapas previstas na semana, por obra e cargo
+		necessarioNaObra := make(map[string]int)
+		for _, d := range demandas {
+			if dia(d.Fim).Before(ini) || dia(d.Inicio).After(fim) {
+				continue
+			}
+			necessarioNaObra[d.ObraID+"|"+chaveCargo(d.Cargo)] += d.Quantidade
+		}
+		necessarios := make(map[string]int)
+		descobertos := make(map[string]int)
+		for obraCargo, quantidade := range necessarioNaObra {
+			chave := obraCargo[strings.Index(obraCargo, "|")+1:]
+			necessarios[chave] += quantidade
+			descobertos[chave] += max(0, quantidade-alocadosNaObra[obraCargo])
+		}
+
+		semana := &SemanaCapacidade{Inicio: ini, Fim: fim, Cargos: make([]*CapacidadeCargo, 0, len(chaves))}
+		for _, chave := range chaves {
+			c := &CapacidadeCargo{
+				Cargo:         rotulos[chave],
+				Total:         totalPorCargo[chave],
+				Alocados:      len(alocadosPorCargo[chave]),
+				Necessarios:   necessarios[chave],
+				Descobertos:   descobertos[chave],
+				Sobrealocados: sobrealocados[chave],
+				Alertas:       []AlertaCapacidade{},
+			}
+			c.Disponiveis = c.Total - c.Alocados
+			c.Falta = max(0, c.Descobertos-c.Disponiveis)
+			if c.Sobrealocados == nil {
+				c.Sobrealocados = []FuncionarioCapacidade{}
+			}
+			sort.Slice(c.Sobrealocados, func(i, j int) bool { return c.Sobrealocados[i].Nome < c.Sobrealocados[j].Nome })
+			if c.Falta > 0 {
+				c.Alertas = append(c.Alertas, AlertaCapacidadeFalta)
+			}
+			if len(c.Sobrealocados) > 0 {
+				c.Alertas = append(c.Alertas, AlertaCapacidadeSobrealocacao)
+			}
+			semana.Cargos = append(semana.Cargos, c)
+		}
+		calendario = append(calendario, semana)
+	}
+	return calendario
+}
+
+// sobrepostasNaSemana indica se em algum dia da semana há duas alocações ativas.
+func sobrepostasNaSemana(alocacoes []*Alocacao, segunda time.Time) bool {
+	if len(alocacoes) < 2 {
+		return false
+	}
+	for d := 0; d < 7; d++ {
+		data := segunda.AddDate(0, 0, d)
+		ativas := 0
+		for _, a := range alocacoes {
+			if a.AtivaEm(data) {
+				ativas++
+			}
+		}
+		if ativas > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func registrarCargo(rotulos map[string]string, cargo string) string {
+	cargo = strings.TrimSpace(cargo)
+	if cargo == "" {
+		cargo = CargoNaoInformado
+	}
+	chave := chaveCargo(cargo)
+	if _, ok := rotulos[chave]; !ok {
+		rotulos[chave] = cargo
+	}
+	return chave
+}
+
+// chaveCargo agrupa cargos sem diferenciar maiúsculas e espaços nas pontas.
+func chaveCargo(cargo string) string {
+	cargo = strings.ToLower(strings.TrimSpace(cargo))
+	if cargo == "" {
+		return strings.ToLower(CargoNaoInformado)
+	}
+	return cargo
+}
@@ -0,0 +1,198 @@
+package obras
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInicioSemana(t *testing.T) {
+	segunda := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	casos := []struct {
+		nome string
+		data time.Time
+	}{
+		{"segunda", segunda},
+		{"quarta com hora", time.Date(2025, 3, 5, 15, 30, 0, 0, time.UTC)},
+		{"domingo", time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			if got := InicioSemana(tc.data); !got.Equal(segunda) {
+				t.Errorf("InicioSemana() = %s, esperado %s", got.Format("2006-01-02"), segunda.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestCalcularCapacidade(t *testing.T) {
+	segunda := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	data := func(d int) time.Time { return segunda.AddDate(0, 0, d) }
+	ate := func(d int) *time.Time {
+		v := data(d)
+		return &v
+	}
+	alocacao := func(funcionarioID, obraID string, inicio int, fim *time.Time) *Alocacao {
+		return &Alocacao{FuncionarioID: funcionarioID, ObraID: obraID, DataInicioAlocacao: data(inicio), DataFimAlocacao: fim}
+	}
+	demanda := func(obraID, cargo string, quantidade, inicio, fim int) DemandaEfetivo {
+		return DemandaEfetivo{ObraID: obraID, Cargo: cargo, Quantidade: quantidade, Inicio: data(inicio), Fim: data(fim)}
+	}
+
+	funcionarios := []FuncionarioCapacidade{
+		{ID: "ana", Nome: "Ana", Cargo: "Pedreiro"},
+		{ID: "bruno", Nome: "Bruno", Cargo: " pedreiro "},
+		{ID: "carla", Nome: "Carla", Cargo: "Servente"},
+		{ID: "davi", Nome: "Davi"},
+	}
+
+	// resumo é a linha do calendário sem os ponteiros, para comparar por valor.
+	type resumo struct {
+		Cargo                                                         string
+		Total, Alocados, Disponiveis, Necessarios, Descobertos, Falta int
+		Sobrealocados                                                 []string
+		Alertas                                                       []AlertaCapacidade
+	}
+	livres := func(cargo string, total int) resumo {
+		return resumo{Cargo: cargo, Total: total, Disponiveis: total, Sobrealocados: []string{}, Alertas: []AlertaCapacidade{}}
+	}
+
+	casos := []struct {
+		nome      string
+		alocacoes []*Alocacao
+		demandas  []DemandaEfetivo
+		esperado  []resumo
+	}{
+		{
+			nome: "sem alocações nem demanda",
+			esperado: []resumo{
+				livres("Pedreiro", 2),
+				livres(CargoNaoInformado, 1),
+				livres("Servente", 1),
+			},
+		},
+		{
+			nome:      "demanda coberta por quem já está na obra",
+			alocacoes: []*Alocacao{alocacao("ana", "A", 0, nil)},
+			demandas:  []DemandaEfetivo{demanda("A", "PEDREIRO", 1, 2, 4)},
+			esperado: []resumo{
+				{Cargo: "Pedreiro", Total: 2, Alocados: 1, Disponiveis: 1, Necessarios: 1, Sobrealocados: []string{}, Alertas: []AlertaCapacidade{}},
+				livres(CargoNaoInformado, 1),
+				livres("Servente", 1),
+			},
+		},
+		{
+			nome:      "demanda maior que os disponíveis gera falta",
+			alocacoes: []*Alocacao{alocacao("ana", "A", 0, nil)},
+			demandas:  []DemandaEfetivo{demanda("A", "Pedreiro", 3, 0, 6)},
+			esperado: []resumo{
+				{Cargo: "Pedreiro", Total: 2, Alocados: 1, Disponiveis: 1, Necessarios: 3, Descobertos: 2, Falta: 1, Sobrealocados: []string{}, Alertas: []AlertaCapacidade{AlertaCapacidadeFalta}},
+				livres(CargoNaoInformado, 1),
+				livres("Servente", 1),
+			},
+		},
+		{
+			nome:      "alocado em outra obra não cobre a demanda",
+			alocacoes: []*Alocacao{alocacao("ana", "B", 0, nil)},
+			demandas:  []DemandaEfetivo{demanda("A", "Pedreiro", 2, 0, 6)},
+			esperado: []resumo{
+				{Cargo: "Pedreiro", Total: 2, Alocados: 1, Disponiveis: 1, Necessarios: 2, Descobertos: 2, Falta: 1, Sobrealocados: []string{}, Alertas: []AlertaCapacidade{AlertaCapacidadeFalta}},
+				livres(CargoNaoInformado, 1),
+				livres("Servente", 1),
+			},
+		},
+		{
+			nome:      "duas alocações no mesmo dia",
+			alocacoes: []*Alocacao{alocacao("carla", "A", 0, ate(2)), alocacao("carla", "B", 2, ate(4))},
+			esperado: []resumo{
+				livres("Pedreiro", 2),
+				livres(CargoNaoInformado, 1),
+				{Cargo: "Servente", Total: 1, Alocados: 1, Sobrealocados: []string{"Carla"}, Alertas: []AlertaCapacidade{AlertaCapacidadeSobrealocacao}},
+			},
+		},
+		{
+			nome:      "transferência sem sobreposição",
+			alocacoes: []*Alocacao{alocacao("carla", "A", 0, ate(1)), alocacao("carla", "B", 2, nil)},
+			esperado: []resumo{
+				livres("Pedreiro", 2),
+				livres(CargoNaoInformado, 1),
+				{Cargo: "Servente", Total: 1, Alocados: 1, Sobrealocados: []string{}, Alertas: []AlertaCapacidade{}},
+			},
+		},
+		{
+			nome: "fora da semana e funcionário fora da lista são ignorados",
+			alocacoes: []*Alocacao{
+				alocacao("ana", "A", -10, ate(-1)),
+				alocacao("bruno", "A", 7, nil),
+				alocacao("desligado", "A", 0, nil),
+			},
+			demandas: []DemandaEfetivo{demanda("A", "Pedreiro", 2, 7, 10)},
+			esperado: []resumo{
+				livres("Pedreiro", 2),
+				livres(CargoNaoInformado, 1),
+				livres("Servente", 1),
+			},
+		},
+		{
+			nome:     "cargo sem funcionários",
+			demandas: []DemandaEfetivo{demanda("A", "Eletricista", 2, 0, 0)},
+			esperado: []resumo{
+				{Cargo: "Eletricista", Necessarios: 2, Descobertos: 2, Falta: 2, Sobrealocados: []string{}, Alertas: []AlertaCapacidade{AlertaCapacidadeFalta}},
+				livres("Pedreiro", 2),
+				livres(CargoNaoInformado, 1),
+				livres("Servente", 1),
+			},
+		},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			calendario := CalcularCapacidade(data(2), 1, funcionarios, tc.alocacoes, tc.demandas)
+			if len(calendario) != 1 {
+				t.Fatalf("semanas = %d, esperado 1", len(calendario))
+			}
+			semana := calendario[0]
+			if !semana.Inicio.Equal(segunda) || !semana.Fim.Equal(data(6)) {
+				t.Errorf("semana = %s a %s", semana.Inicio.Format("2006-01-02"), semana.Fim.Format("2006-01-02"))
+			}
+
+			got := make([]resumo, 0, len(semana.Cargos))
+			for _, c := range semana.Cargos {
+				nomes := make([]string, 0, len(c.Sobrealocados))
+				for _, f := range c.Sobrealocados {
+					nomes = append(nomes, f.Nome)
+				}
+				got = append(got, resumo{
+					Cargo: c.Cargo, Total: c.Total, Alocados: c.Alocados, Disponiveis: c.Disponiveis,
+					Necessarios: c.Necessarios, Descobertos: c.Descobertos, Falta: c.Falta,
+					Sobrealocados: nomes, Alertas: c.Alertas,
+				})
+			}
+			if !reflect.DeepEqual(got, tc.esperado) {
+				t.Errorf("capacidade =\n%+v\nesperado\n%+v", got, tc.esperado)
+			}
+		})
+	}
+}
+
+func TestCalcularCapacidadeVariasSemanas(t *testing.T) {
+	segunda := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	fim := segunda.AddDate(0, 0, 8)
+	funcionarios := []FuncionarioCapacidade{{ID: "ana", Nome: "Ana", Cargo: "Pedreiro"}}
+	alocacoes := []*Alocacao{{FuncionarioID: "ana", ObraID: "A", DataInicioAlocacao: segunda, DataFimAlocacao: &fim}}
+
+	calendario := CalcularCapacidade(segunda, 3, funcionarios, alocacoes, nil)
+
+	esperado := []int{1, 1, 0}
+	if len(calendario) != len(esperado) {
+		t.Fatalf("semanas = %d, esperado %d", len(calendario), len(esperado))
+	}
+	for i, semana := range calendario {
+		if !semana.Inicio.Equal(segunda.AddDate(0, 0, 7*i)) {
+			t.Errorf("semana %d começa em %s", i, semana.Inicio.Format("2006-01-02"))
+		}
+		if got := semana.Cargos[0].Alocados; got != esperado[i] {
+			t.Errorf("semana %d: alocados = %d, esperado %d", i, got, esperado[i])
+		}
+	}
+}
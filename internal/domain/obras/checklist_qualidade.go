@@ -0,0 +1,213 @@
+// file: internal/domain/obras/checklist_qualidade.go
+package obras
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StatusChecklist é a situação da ficha de verificação de serviço (FVS) da etapa.
+type StatusChecklist string
+
+const (
+	StatusChecklistPendente  StatusChecklist = "PENDENTE"  // Ainda não inspecionada
+	StatusChecklistAprovada  StatusChecklist = "APROVADA"  // Todos os itens conformes
+	StatusChecklistReprovada StatusChecklist = "REPROVADA" // Há item não conforme; aguarda reinspeção
+)
+
+// TipoInspecao diferencia a primeira inspeção das reinspeções após reprovação.
+type TipoInspecao string
+
+const (
+	TipoInspecaoInicial    TipoInspecao = "INSPECAO"
+	TipoInspecaoReinspecao TipoInspecao = "REINSPECAO"
+)
+
+var (
+	ErrModeloChecklistInvalido = errors.New("modelo de checklist inválido")
+	ErrInspecaoInvalida        = errors.New("inspeção de checklist inválida")
+	ErrChecklistAprovada       = errors.New("checklist já aprovada não aceita nova inspeção")
+	ErrChecklistInspecionada   = errors.New("checklist com inspeção registrada não pode ser removida")
+)
+
+// ItemChecklist é um ponto a verificar no serviço, com o critério de aceitação.
+type ItemChecklist struct {
+	Descricao string `json:"descricao"`
+	Criterio  string `json:"criterio,omitempty"` // Tolerância ou método de verificação
+}
+
+// ModeloChecklist é a FVS configurada para uma etapa do catálogo (ex.: concretagem,
+// alvenaria). É copiada para cada etapa de obra criada a partir da etapa padrão.
+type ModeloChecklist struct {
+	ID            string          `json:"id"`
+	EtapaPadraoID string          `json:"etapaPadraoId"`
+	Nome          string          `json:"nome"`
+	Descricao     string          `json:"descricao"`
+	Obrigatorio   bool            `json:"obrigatorio"` // Obrigatórias impedem concluir a etapa sem aprovação
+	Itens         []ItemChecklist `json:"itens"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	UpdatedAt     time.Time       `json:"updatedAt"`
+}
+
+// ChecklistEtapa é a FVS instanciada na etapa da obra, com o histórico de inspeções.
+// Os itens são copiados do modelo: mudanças posteriores no modelo não a afetam.
+type ChecklistEtapa struct {
+	ID          string              `json:"id"`
+	ObraID      string              `json:"obraId"`
+	EtapaID     string              `json:"etapaId"`
+	ModeloID    *string             `json:"modeloId,omitempty"`
+	Nome        string              `json:"nome"`
+	Obrigatorio bool                `json:"obrigatorio"`
+	Itens       []ItemChecklist     `json:"itens"`
+	Status      StatusChecklist     `json:"status"`
+	Inspecoes   []InspecaoChecklist `json:"inspecoes"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	UpdatedAt   time.Time           `json:"updatedAt"`
+}
+
+// InspecaoChecklist registra uma inspeção da FVS: quem inspecionou, quando, o
+// resultado de cada item e as evidências (URLs de fotos ou documentos).
+type InspecaoChecklist struct {
+	Numero        int                      `json:"numero"`
+	Tipo          TipoInspecao             `json:"tipo"`
+	Data          time.Time                `json:"data"`
+	Inspetor      string                   `json:"inspetor"`
+	Resultado     StatusChecklist          `json:"resultado"` // APROVADA ou REPROVADA
+	Itens         []ResultadoItemChecklist `json:"itens"`
+	Evidencias    []string                 `json:"evidencias"`
+	Observacoes   string                   `json:"observacoes"`
+	RegistradoPor string                   `json:"registradoPor"`
+	RegistradoEm  time.Time                `json:"registradoEm"`
+}
+
+// ResultadoItemChecklist é a verificação de um item, pela posição (a partir de 1).
+type ResultadoItemChecklist struct {
+	Item       int    `json:"item"`
+	Conforme   bool   `json:"conforme"`
+	Observacao string `json:"observacao,omitempty"`
+}
+
+// DadosInspecao são os dados informados ao registrar uma inspeção.
+type DadosInspecao struct {
+	Data        time.Time
+	Inspetor    string
+	Itens       []ResultadoItemChecklist
+	Evidencias  []string
+	Observacoes string
+}
+
+// Validar confere nome, etapa padrão e itens do modelo.
+func (m *ModeloChecklist) Validar() error {
+	m.Nome = strings.TrimSpace(m.Nome)
+	if m.Nome == "" {
+		return fmt.Errorf("%w: nome é obrigatório", ErrModeloChecklistInvalido)
+	}
+	if m.EtapaPadraoID == "" {
+		return fmt.Errorf("%w: etapa padrão é obrigatória", ErrModeloChecklistInvalido)
+	}
+	if len(m.Itens) == 0 {
+		return fmt.Errorf("%w: informe ao menos um item", ErrModeloChecklistInvalido)
+	}
+	for i := range m.Itens {
+		m.Itens[i].Descricao = strings.TrimSpace(m.Itens[i].Descricao)
+		m.Itens[i].Criterio = strings.TrimSpace(m.Itens[i].Criterio)
+		if m.Itens[i].Descricao == "" {
+			return fmt.Errorf("%w: item %d sem descrição", ErrModeloChecklistInvalido, i+1)
+		}
+	}
+	return nil
+}
+
+// Instanciar copia o modelo para a etapa da obra, pendente de inspeção.
+func (m *ModeloChecklist) Instanciar(id string, etapa *Etapa, agora time.Time) *ChecklistEtapa {
+	modeloID := m.ID
+	itens := make([]ItemChecklist, len(m.Itens))
+	copy(itens, m.Itens)
+	return &ChecklistEtapa{
+		ID:          id,
+		ObraID:      etapa.ObraID,
+		EtapaID:     etapa.ID,
+		ModeloID:    &modeloID,
+		Nome:        m.Nome,
+		Obrigatorio: m.Obrigatorio,
+		Itens:       itens,
+		Status:      StatusChecklistPendente,
+		Inspecoes:   []InspecaoChecklist{},
+		CreatedAt:   agora,
+		UpdatedAt:   agora,
+	}
+}
+
+// RegistrarInspecao valida o resultado de todos os itens e atualiza a situação da
+// FVS: aprovada se todos estiverem conformes, reprovada caso contrário. Depois de
+// uma reprovação, a próxima inspeção é registrada como reinspeção.
+func (c *ChecklistEtapa) RegistrarInspecao(dados DadosInspecao, usuarioID string, agora time.Time) (*InspecaoChecklist, error) {
+	if c.Status == StatusChecklistAprovada {
+		return nil, ErrChecklistAprovada
+	}
+	dados.Inspetor = strings.TrimSpace(dados.Inspetor)
+	if dados.Inspetor == "" {
+		return nil, fmt.Errorf("%w: inspetor é obrigatório", ErrInspecaoInvalida)
+	}
+	if len(dados.Itens) != len(c.Itens) {
+		return nil, fmt.Errorf("%w: informe o resultado dos %d itens", ErrInspecaoInvalida, len(c.Itens))
+	}
+
+	vistos := make(map[int]bool, len(dados.Itens))
+	resultado := StatusChecklistAprovada
+	for i, r := range dados.Itens {
+		if r.Item < 1 || r.Item > len(c.Itens) || vistos[r.Item] {
+			return nil, fmt.Errorf("%w: item %d inexistente ou repetido", ErrInspecaoInvalida, r.Item)
+		}
+		vistos[r.Item] = true
+		dados.Itens[i].Observacao = strings.TrimSpace(r.Observacao)
+		if !r.Conforme {
+			resultado = StatusChecklistReprovada
+		}
+	}
+	if dados.Evidencias == nil {
+		dados.Evidencias = []string{}
+	}
+
+	tipo := TipoInspecaoInicial
+	if len(c.Inspecoes) > 0 {
+		tipo = TipoInspecaoReinspecao
+	}
+	c.Inspecoes = append(c.Inspecoes, InspecaoChecklist{
+		Numero:        len(c.Inspecoes) + 1,
+		Tipo:          tipo,
+		Data:          dia(dados.Data),
+		Inspetor:      dados.Inspetor,
+		Resultado:     resultado,
+		Itens:         dados.Itens,
+		Evidencias:    dados.Evidencias,
+		Observacoes:   strings.TrimSpace(dados.Observacoes),
+		RegistradoPor: usuarioID,
+		RegistradoEm:  agora,
+	})
+	c.Status = resultado
+	c.UpdatedAt = agora
+	return &c.Inspecoes[len(c.Inspecoes)-1], nil
+}
+
+// PodeRemover indica se a FVS pode ser retirada da etapa (nenhuma inspeção registrada).
+func (c *ChecklistEtapa) PodeRemover() error {
+	if len(c.Inspecoes) > 0 {
+		return ErrChecklistInspecionada
+	}
+	return nil
+}
+
+// ChecklistsObrigatoriasPendentes retorna as FVS obrigatórias ainda não aprovadas,
+// que impedem concluir a etapa.
+func ChecklistsObrigatoriasPendentes(checklists []*ChecklistEtapa) []*ChecklistEtapa {
+	var pendentes []*ChecklistEtapa
+	for _, c := range checklists {
+		if c.Obrigatorio && c.Status != StatusChecklistAprovada {
+			pendentes = append(pendentes, c)
+		}
+	}
+	return pendentes
+}
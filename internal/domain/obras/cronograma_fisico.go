@@ -0,0 +1,262 @@
+// file: internal/domain/obras/cronograma_fisico.go
+package obras
+
+import (
+	"time"
+)
+
+// ItemCronogramaFisico é a programação de uma etapa calculada a partir das dependências.
+type ItemCronogramaFisico struct {
+	Etapa         *Etapa
+	Inicio        time.Time
+	Fim           time.Time
+	DuracaoDias   int
+	FolgaDias     int
+	Critica       bool
+	Predecessoras []*DependenciaEtapa
+}
+
+// CronogramaFisico é o resultado do método do caminho crítico para as etapas de uma obra.
+type CronogramaFisico struct {
+	Inicio         time.Time
+	Fim            time.Time
+	DuracaoDias    int
+	Itens          []*ItemCronogramaFisico // em ordem topológica
+	CaminhoCritico []string                // IDs das etapas críticas, na ordem de execução
+}
+
+// CalcularCronogramaFisico programa as etapas respeitando as dependências (passada
+// para frente) e calcula a folga de cada uma (passada para trás).
+//
+// Etapas concluídas ficam nas datas reais. Etapas em andamento começam na data real
+// e, se já passaram do fim previsto, terminam no mínimo hoje — é esse atraso que
+// empurra as sucessoras. As demais começam na data prevista ou, se uma predecessora
+// atrasou, na primeira data que as dependências permitem.
+func CalcularCronogramaFisico(etapas []*Etapa, dependencias []*DependenciaEtapa, inicioObra, hoje time.Time) (*CronogramaFisico, error) {
+	ordem, err := ordenarTopologicamente(etapas, dependencias)
+	if err != nil {
+		return nil, err
+	}
+
+	base := dia(inicioObra)
+	hojeDia := diasEntre(base, dia(hoje))
+	naObra := make(map[string]bool, len(etapas))
+	for _, e := range etapas {
+		naObra[e.ID] = true
+	}
+	predecessoras := make(map[string][]*DependenciaEtapa)
+	sucessoras := make(map[string][]*DependenciaEtapa)
+	for _, d := range dependencias {
+		if !naObra[d.EtapaID] || !naObra[d.PredecessoraID] {
+			continue
+		}
+		predecessoras[d.EtapaID] = append(predecessoras[d.EtapaID], d)
+		sucessoras[d.PredecessoraID] = append(sucessoras[d.PredecessoraID], d)
+	}
+
+	// Passada para frente: início (es) e fim (ef) mais cedo, em dias a partir de base.
+	es := make(map[string]int, len(ordem))
+	ef := make(map[string]int, len(ordem))
+	for _, e := range ordem {
+		duracao := duracaoPrevista(e)
+		switch {
+		case e.Status == StatusEtapaConcluida && e.DataInicioReal != nil && e.DataFimReal != nil:
+			es[e.ID] = diasEntre(base, dia(*e.DataInicioReal))
+			ef[e.ID] = diasEntre(base, dia(*e.DataFimReal))
+		case e.DataInicioReal != nil:
+			es[e.ID] = diasEntre(base, dia(*e.DataInicioReal))
+			ef[e.ID] = es[e.ID] + duracao - 1
+			if e.Status != StatusEtapaConcluida && ef[e.ID] < hojeDia {
+				ef[e.ID] = hojeDia
+			}
+		default:
+			inicio := 0
+			if e.DataInicioPrevista != nil {
+				inicio = diasEntre(base, dia(*e.DataInicioPrevista))
+			}
+			for _, d := range predecessoras[e.ID] {
+				if restricao := restricaoInicio(d, es, ef); restricao > inicio {
+					inicio = restricao
+				}
+			}
+			es[e.ID] = inicio
+			ef[e.ID] = inicio + duracao - 1
+		}
+	}
+
+	cronograma := &CronogramaFisico{Inicio: base, Fim: base, CaminhoCritico: []string{}}
+	if len(ordem) == 0 {
+		return cronograma, nil
+	}
+
+	inicioProjeto, fimProjeto := es[ordem[0].ID], ef[ordem[0].ID]
+	for _, e := range ordem {
+		inicioProjeto = min(inicioProjeto, es[e.ID])
+		fimProjeto = max(fimProjeto, ef[e.ID])
+	}
+
+	// Passada para trás: fim (lf) e início (ls) mais tarde sem atrasar a obra.
+	lf := make(map[string]int, len(ordem))
+	ls := make(map[string]int, len(ordem))
+	for i := len(ordem) - 1; i >= 0; i-- {
+		e := ordem[i]
+		duracao := ef[e.ID] - es[e.ID] + 1
+		fimTarde := fimProjeto
+		for _, d := range sucessoras[e.ID] {
+			var limite int
+			if d.Tipo == DependenciaInicioInicio {
+				limite = ls[d.EtapaID] - d.LagDias + duracao - 1
+			} else {
+				limite = ls[d.EtapaID] - d.LagDias - 1
+			}
+			fimTarde = min(fimTarde, limite)
+		}
+		lf[e.ID] = fimTarde
+		ls[e.ID] = fimTarde - duracao + 1
+	}
+
+	cronograma.Inicio = base.AddDate(0, 0, inicioProjeto)
+	cronograma.Fim = base.AddDate(0, 0, fimProjeto)
+	cronograma.DuracaoDias = fimProjeto - inicioProjeto + 1
+	for _, e := range ordem {
+		item := &ItemCronogramaFisico{
+			Etapa:         e,
+			Inicio:        base.AddDate(0, 0, es[e.ID]),
+			Fim:           base.AddDate(0, 0, ef[e.ID]),
+			DuracaoDias:   ef[e.ID] - es[e.ID] + 1,
+			Predecessoras: predecessoras[e.ID],
+		}
+		if e.Status != StatusEtapaConcluida {
+			item.FolgaDias = max(ls[e.ID]-es[e.ID], 0)
+			item.Critica = ls[e.ID] <= es[e.ID]
+		}
+		if item.Critica {
+			cronograma.CaminhoCritico = append(cronograma.CaminhoCritico, e.ID)
+		}
+		cronograma.Itens = append(cronograma.Itens, item)
+	}
+	return cronograma, nil
+}
+
+// AplicarReprogramacao grava nas etapas pendentes as datas programadas calculadas e
+// retorna as que mudaram. As datas previstas são a linha de base e não mudam: a
+// programação só é gravada quando difere delas, e some quando a etapa volta à
+// previsão. Etapas iniciadas mantêm a programação anterior.
+func (c *CronogramaFisico) AplicarReprogramacao() []*Etapa {
+	var alteradas []*Etapa
+	for _, item := range c.Itens {
+		e := item.Etapa
+		if e.Status != StatusEtapaPendente {
+			continue
+		}
+		var inicio, fim *time.Time
+		if !mesmaData(e.DataInicioPrevista, item.Inicio) || !mesmaData(e.DataFimPrevista, item.Fim) {
+			i, f := item.Inicio, item.Fim
+			inicio, fim = &i, &f
+		}
+		if mesmasDatas(e.DataInicioProgramada, inicio) && mesmasDatas(e.DataFimProgramada, fim) {
+			continue
+		}
+		e.DataInicioProgramada = inicio
+		e.DataFimProgramada = fim
+		alteradas = append(alteradas, e)
+	}
+	return alteradas
+}
+
+// DefinirLinhaDeBase grava as datas calculadas como previstas das etapas pendentes.
+// Serve ao planejamento inicial, antes de a obra ter uma linha de base.
+func (c *CronogramaFisico) DefinirLinhaDeBase() {
+	for _, item := range c.Itens {
+		e := item.Etapa
+		if e.Status != StatusEtapaPendente {
+			continue
+		}
+		inicio, fim := item.Inicio, item.Fim
+		e.DataInicioPrevista = &inicio
+		e.DataFimPrevista = &fim
+		e.DataInicioProgramada, e.DataFimProgramada = nil, nil
+	}
+}
+
+// VerificarCiclos retorna ErrCicloDependencias se as dependências não formam um grafo acíclico.
+func VerificarCiclos(etapas []*Etapa, dependencias []*DependenciaEtapa) error {
+	_, err := ordenarTopologicamente(etapas, dependencias)
+	return err
+}
+
+// ordenarTopologicamente ordena as etapas de forma que toda predecessora venha antes
+// das sucessoras, preservando a ordem recebida entre etapas independentes.
+func ordenarTopologicamente(etapas []*Etapa, dependencias []*DependenciaEtapa) ([]*Etapa, error) {
+	porID := make(map[string]*Etapa, len(etapas))
+	for _, e := range etapas {
+		porID[e.ID] = e
+	}
+	grauEntrada := make(map[string]int, len(etapas))
+	sucessoras := make(map[string][]string)
+	for _, d := range dependencias {
+		if porID[d.EtapaID] == nil || porID[d.PredecessoraID] == nil {
+			continue
+		}
+		grauEntrada[d.EtapaID]++
+		sucessoras[d.PredecessoraID] = append(sucessoras[d.PredecessoraID], d.EtapaID)
+	}
+
+	ordem := make([]*Etapa, 0, len(etapas))
+	prontas := make([]*Etapa, 0, len(etapas))
+	for _, e := range etapas {
+		if grauEntrada[e.ID] == 0 {
+			prontas = append(prontas, e)
+		}
+	}
+	for len(prontas) > 0 {
+		e := prontas[0]
+		prontas = prontas[1:]
+		ordem = append(ordem, e)
+		for _, id := range sucessoras[e.ID] {
+			grauEntrada[id]--
+			if grauEntrada[id] == 0 {
+				prontas = append(prontas, porID[id])
+			}
+		}
+	}
+	if len(ordem) != len(etapas) {
+		return nil, ErrCicloDependencias
+	}
+	return ordem, nil
+}
+
+func restricaoInicio(d *DependenciaEtapa, es, ef map[string]int) int {
+	if d.Tipo == DependenciaInicioInicio {
+		return es[d.PredecessoraID] + d.LagDias
+	}
+	return ef[d.PredecessoraID] + 1 + d.LagDias
+}
+
+// duracaoPrevista é a duração em dias corridos (inclusive) entre as datas previstas; no mínimo 1.
+func duracaoPrevista(e *Etapa) int {
+	if e.DataInicioPrevista == nil || e.DataFimPrevista == nil {
+		return 1
+	}
+	return max(diasEntre(dia(*e.DataInicioPrevista), dia(*e.DataFimPrevista))+1, 1)
+}
+
+func dia(t time.Time) time.Time {
+	a, m, d := t.Date()
+	return time.Date(a, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func diasEntre(inicio, fim time.Time) int {
+	return int(fim.Sub(inicio).Hours() / 24)
+}
+
+func mesmaData(atual *time.Time, data time.Time) bool {
+	return atual != nil && dia(*atual).Equal(data)
+}
+
+func mesmasDatas(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return mesmaData(a, dia(*b))
+}
@@ -0,0 +1,234 @@
+package obras
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCalcularCronogramaFisico(t *testing.T) {
+	base := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	data := func(d int) *time.Time {
+		v := base.AddDate(0, 0, d)
+		return &v
+	}
+	// etapa cria uma etapa pendente prevista do dia inicio ao dia fim (inclusive), contados de base.
+	etapa := func(id string, inicio, fim int) *Etapa {
+		return &Etapa{ID: id, Nome: id, Status: StatusEtapaPendente, DataInicioPrevista: data(inicio), DataFimPrevista: data(fim)}
+	}
+	dep := func(etapaID, predecessoraID string, tipo TipoDependencia, lag int) *DependenciaEtapa {
+		return &DependenciaEtapa{EtapaID: etapaID, PredecessoraID: predecessoraID, Tipo: tipo, LagDias: lag}
+	}
+
+	type programacao struct {
+		inicio, fim, folga int
+		critica            bool
+	}
+
+	casos := []struct {
+		nome         string
+		etapas       func() []*Etapa
+		dependencias []*DependenciaEtapa
+		hoje         int
+		esperado     map[string]programacao
+		critico      []string
+		duracao      int
+		erro         error
+	}{
+		{
+			nome:    "obra sem etapas",
+			etapas:  func() []*Etapa { return nil },
+			critico: []string{},
+		},
+		{
+			nome: "término-início empurra a sucessora prevista cedo demais",
+			etapas: func() []*Etapa {
+				return []*Etapa{etapa("A", 0, 4), etapa("B", 2, 4)}
+			},
+			dependencias: []*DependenciaEtapa{dep("B", "A", DependenciaTerminoInicio, 0)},
+			esperado: map[string]programacao{
+				"A": {inicio: 0, fim: 4, critica: true},
+				"B": {inicio: 5, fim: 7, critica: true},
+			},
+			critico: []string{"A", "B"},
+			duracao: 8,
+		},
+		{
+			nome: "caminho paralelo mais curto tem folga",
+			etapas: func() []*Etapa {
+				return []*Etapa{etapa("A", 0, 4), etapa("B", 0, 1), etapa("C", 0, 2)}
+			},
+			dependencias: []*DependenciaEtapa{
+				dep("C", "A", DependenciaTerminoInicio, 0),
+				dep("C", "B", DependenciaTerminoInicio, 0),
+			},
+			esperado: map[string]programacao{
+				"A": {inicio: 0, fim: 4, critica: true},
+				"B": {inicio: 0, fim: 1, folga: 3},
+				"C": {inicio: 5, fim: 7, critica: true},
+			},
+			critico: []string{"A", "C"},
+			duracao: 8,
+		},
+		{
+			nome: "início-início com lag",
+			etapas: func() []*Etapa {
+				return []*Etapa{etapa("A", 0, 3), etapa("B", 0, 2)}
+			},
+			dependencias: []*DependenciaEtapa{dep("B", "A", DependenciaInicioInicio, 2)},
+			esperado: map[string]programacao{
+				"A": {inicio: 0, fim: 3, critica: true},
+				"B": {inicio: 2, fim: 4, critica: true},
+			},
+			critico: []string{"A", "B"},
+			duracao: 5,
+		},
+		{
+			nome: "lag negativo sobrepõe as etapas",
+			etapas: func() []*Etapa {
+				return []*Etapa{etapa("A", 0, 4), etapa("B", 0, 2)}
+			},
+			dependencias: []*DependenciaEtapa{dep("B", "A", DependenciaTerminoInicio, -2)},
+			esperado: map[string]programacao{
+				"A": {inicio: 0, fim: 4, critica: true},
+				"B": {inicio: 3, fim: 5, critica: true},
+			},
+			critico: []string{"A", "B"},
+			duracao: 6,
+		},
+		{
+			nome: "etapa em andamento atrasada termina no mínimo hoje",
+			etapas: func() []*Etapa {
+				a := etapa("A", 0, 2)
+				a.Status = StatusEtapaEmAndamento
+				a.DataInicioReal = data(0)
+				return []*Etapa{a, etapa("B", 3, 4)}
+			},
+			dependencias: []*DependenciaEtapa{dep("B", "A", DependenciaTerminoInicio, 0)},
+			hoje:         6,
+			esperado: map[string]programacao{
+				"A": {inicio: 0, fim: 6, critica: true},
+				"B": {inicio: 7, fim: 8, critica: true},
+			},
+			critico: []string{"A", "B"},
+			duracao: 9,
+		},
+		{
+			nome: "etapa concluída fica nas datas reais e não é crítica",
+			etapas: func() []*Etapa {
+				a := etapa("A", 0, 4)
+				a.Status = StatusEtapaConcluida
+				a.DataInicioReal, a.DataFimReal = data(0), data(1)
+				return []*Etapa{a, etapa("B", 5, 7)}
+			},
+			dependencias: []*DependenciaEtapa{dep("B", "A", DependenciaTerminoInicio, 0)},
+			hoje:         2,
+			esperado: map[string]programacao{
+				"A": {inicio: 0, fim: 1},
+				"B": {inicio: 5, fim: 7, critica: true},
+			},
+			critico: []string{"B"},
+			duracao: 8,
+		},
+		{
+			nome: "dependência com etapa de outra obra é ignorada",
+			etapas: func() []*Etapa {
+				return []*Etapa{etapa("A", 0, 1)}
+			},
+			dependencias: []*DependenciaEtapa{dep("A", "X", DependenciaTerminoInicio, 0)},
+			esperado: map[string]programacao{
+				"A": {inicio: 0, fim: 1, critica: true},
+			},
+			critico: []string{"A"},
+			duracao: 2,
+		},
+		{
+			nome: "ciclo entre etapas",
+			etapas: func() []*Etapa {
+				return []*Etapa{etapa("A", 0, 1), etapa("B", 0, 1)}
+			},
+			dependencias: []*DependenciaEtapa{
+				dep("B", "A", DependenciaTerminoInicio, 0),
+				dep("A", "B", DependenciaTerminoInicio, 0),
+			},
+			erro: ErrCicloDependencias,
+		},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			c, err := CalcularCronogramaFisico(tc.etapas(), tc.dependencias, base, base.AddDate(0, 0, tc.hoje))
+			if tc.erro != nil {
+				if !errors.Is(err, tc.erro) {
+					t.Fatalf("erro = %v, esperado %v", err, tc.erro)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+
+			if len(c.Itens) != len(tc.esperado) {
+				t.Fatalf("itens = %d, esperado %d", len(c.Itens), len(tc.esperado))
+			}
+			for _, item := range c.Itens {
+				esp := tc.esperado[item.Etapa.ID]
+				got := programacao{
+					inicio:  diasEntre(base, item.Inicio),
+					fim:     diasEntre(base, item.Fim),
+					folga:   item.FolgaDias,
+					critica: item.Critica,
+				}
+				if got != esp {
+					t.Errorf("etapa %s = %+v, esperado %+v", item.Etapa.ID, got, esp)
+				}
+			}
+			if !reflect.DeepEqual(c.CaminhoCritico, tc.critico) {
+				t.Errorf("caminho crítico = %v, esperado %v", c.CaminhoCritico, tc.critico)
+			}
+			if c.DuracaoDias != tc.duracao {
+				t.Errorf("duração = %d, esperado %d", c.DuracaoDias, tc.duracao)
+			}
+		})
+	}
+}
+
+func TestAplicarReprogramacaoPreservaLinhaDeBase(t *testing.T) {
+	base := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	data := func(d int) *time.Time {
+		v := base.AddDate(0, 0, d)
+		return &v
+	}
+	a := &Etapa{ID: "A", Status: StatusEtapaEmAndamento, DataInicioPrevista: data(0), DataFimPrevista: data(4), DataInicioReal: data(0)}
+	b := &Etapa{ID: "B", Status: StatusEtapaPendente, DataInicioPrevista: data(5), DataFimPrevista: data(7)}
+	deps := []*DependenciaEtapa{{EtapaID: "B", PredecessoraID: "A", Tipo: DependenciaTerminoInicio}}
+
+	// A atrasou até o dia 6: B é empurrada, mas a previsão continua a original
+	cronograma, err := CalcularCronogramaFisico([]*Etapa{a, b}, deps, base, *data(6))
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if alteradas := cronograma.AplicarReprogramacao(); len(alteradas) != 1 {
+		t.Fatalf("alteradas = %d, esperado 1", len(alteradas))
+	}
+	if !b.DataInicioPrevista.Equal(*data(5)) || !b.DataFimPrevista.Equal(*data(7)) {
+		t.Fatalf("previsão alterada para %v a %v", b.DataInicioPrevista, b.DataFimPrevista)
+	}
+	if b.DataInicioProgramada == nil || !b.DataInicioProgramada.Equal(*data(7)) || !b.DataFimProgramada.Equal(*data(9)) {
+		t.Fatalf("programação = %v a %v, esperado dias 7 a 9", b.DataInicioProgramada, b.DataFimProgramada)
+	}
+
+	// A terminou no prazo: B volta à previsão e a programação deixa de existir
+	a.Status, a.DataFimReal = StatusEtapaConcluida, data(4)
+	cronograma, err = CalcularCronogramaFisico([]*Etapa{a, b}, deps, base, *data(6))
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if alteradas := cronograma.AplicarReprogramacao(); len(alteradas) != 1 {
+		t.Fatalf("alteradas = %d, esperado 1", len(alteradas))
+	}
+	if b.DataInicioProgramada != nil || b.DataFimProgramada != nil {
+		t.Fatalf("programação = %v a %v, esperado nenhuma", b.DataInicioProgramada, b.DataFimProgramada)
+	}
+}
@@ -2,15 +2,17 @@ package obras
 
 import (
 	"errors"
+	"math"
 	"time"
 )
 
 // StatusRecebimento representa os possíveis status de um cronograma de recebimento
 const (
-	StatusRecebimentoPendente = "PENDENTE"
-	StatusRecebimentoRecebido = "RECEBIDO"
-	StatusRecebimentoVencido  = "VENCIDO"
-	StatusRecebimentoParcial  = "PARCIAL"
+	StatusRecebimentoPendente  = "PENDENTE"
+	StatusRecebimentoRecebido  = "RECEBIDO"
+	StatusRecebimentoVencido   = "VENCIDO"
+	StatusRecebimentoParcial   = "PARCIAL"
+	StatusRecebimentoCancelado = "CANCELADO"
 )
 
 // CronogramaRecebimento representa uma etapa de recebimento de uma obra
@@ -24,22 +26,28 @@ type CronogramaRecebimento struct {
 	Status            string     `json:"status"`
 	DataRecebimento   *time.Time `json:"dataRecebimento,omitempty"`
 	ValorRecebido     float64    `json:"valorRecebido"`
+	ValorRetido       float64    `json:"valorRetido"` // Caução retida do valor previsto, liberada à parte na conclusão da obra
 	ObservacoesRecebimento *string `json:"observacoesRecebimento,omitempty"`
 	CreatedAt         time.Time  `json:"createdAt"`
 	UpdatedAt         time.Time  `json:"updatedAt"`
 }
 
+// ValorLiquido é o valor a receber nesta etapa: a caução retida é cobrada à parte, na conclusão da obra
+func (cr *CronogramaRecebimento) ValorLiquido() float64 {
+	return centavos(cr.ValorPrevisto - cr.ValorRetido)
+}
+
 // ValorSaldo retorna o valor que ainda falta receber nesta etapa
 func (cr *CronogramaRecebimento) ValorSaldo() float64 {
-	return cr.ValorPrevisto - cr.ValorRecebido
+	return centavos(cr.ValorLiquido() - cr.ValorRecebido)
 }
 
-// PercentualRecebido calcula o percentual já recebido desta etapa
+// PercentualRecebido calcula o percentual já recebido do valor líquido desta etapa
 func (cr *CronogramaRecebimento) PercentualRecebido() float64 {
-	if cr.ValorPrevisto == 0 {
+	if cr.ValorLiquido() == 0 {
 		return 0
 	}
-	return (cr.ValorRecebido / cr.ValorPrevisto) * 100
+	return (cr.ValorRecebido / cr.ValorLiquido()) * 100
 }
 
 // EstaVencido verifica se o cronograma está vencido
@@ -53,9 +61,9 @@ func (cr *CronogramaRecebimento) RegistrarRecebimento(valor float64, observacoes
 		return errors.New("valor deve ser positivo")
 	}
 
-	novoValorRecebido := cr.ValorRecebido + valor
-	if novoValorRecebido > cr.ValorPrevisto {
-		return errors.New("valor recebido não pode exceder o valor previsto")
+	novoValorRecebido := centavos(cr.ValorRecebido + valor)
+	if novoValorRecebido > cr.ValorLiquido() {
+		return errors.New("valor recebido não pode exceder o valor previsto, descontada a caução")
 	}
 
 	cr.ValorRecebido = novoValorRecebido
@@ -67,8 +75,8 @@ func (cr *CronogramaRecebimento) RegistrarRecebimento(valor float64, observacoes
 		cr.ObservacoesRecebimento = observacoes
 	}
 
-	// Atualiza status baseado no valor recebido
-	if cr.ValorRecebido >= cr.ValorPrevisto {
+	// Atualiza status baseado no valor recebido; a caução retida não fica em aberto na parcela
+	if cr.ValorRecebido >= cr.ValorLiquido() {
 		cr.Status = StatusRecebimentoRecebido
 	} else if cr.ValorRecebido > 0 {
 		cr.Status = StatusRecebimentoParcial
@@ -77,6 +85,26 @@ func (cr *CronogramaRecebimento) RegistrarRecebimento(valor float64, observacoes
 	return nil
 }
 
+// EstaEmAberto indica se ainda há valor a receber nesta etapa
+func (cr *CronogramaRecebimento) EstaEmAberto() bool {
+	return cr.Status != StatusRecebimentoRecebido && cr.Status != StatusRecebimentoCancelado
+}
+
+// Cancelar encerra a etapa de recebimento sem recebimento, mantendo o que já foi recebido
+func (cr *CronogramaRecebimento) Cancelar() error {
+	if cr.Status == StatusRecebimentoRecebido {
+		return errors.New("não é possível cancelar uma etapa já recebida")
+	}
+	cr.Status = StatusRecebimentoCancelado
+	cr.UpdatedAt = time.Now()
+	return nil
+}
+
+// centavos arredonda o valor ao centavo, para comparar somas e diferenças de float.
+func centavos(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
 // MarcarComoVencido marca o cronograma como vencido
 func (cr *CronogramaRecebimento) MarcarComoVencido() {
 	if cr.Status == StatusRecebimentoPendente && cr.EstaVencido() {
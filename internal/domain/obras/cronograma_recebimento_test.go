@@ -0,0 +1,27 @@
+package obras
+
+import "testing"
+
+func TestRecebimentoDeParcelaComCaucao(t *testing.T) {
+	// 333,33 com 5% de caução: 16,67 retidos e 316,66 a receber na parcela
+	parcela := &CronogramaRecebimento{ValorPrevisto: 333.33, ValorRetido: 16.67, Status: StatusRecebimentoPendente}
+
+	if err := parcela.RegistrarRecebimento(316.67, nil); err == nil {
+		t.Fatal("esperava erro ao receber mais que o líquido da parcela")
+	}
+	if err := parcela.RegistrarRecebimento(300, nil); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if parcela.Status != StatusRecebimentoParcial || !parcela.EstaEmAberto() || parcela.ValorSaldo() != 16.66 {
+		t.Fatalf("depois do primeiro recebimento: status %s, saldo %v", parcela.Status, parcela.ValorSaldo())
+	}
+	if err := parcela.RegistrarRecebimento(16.66, nil); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if parcela.Status != StatusRecebimentoRecebido || parcela.EstaEmAberto() || parcela.ValorSaldo() != 0 {
+		t.Fatalf("com o líquido recebido: status %s, saldo %v", parcela.Status, parcela.ValorSaldo())
+	}
+	if parcela.PercentualRecebido() != 100 {
+		t.Fatalf("percentual recebido = %v, esperava 100", parcela.PercentualRecebido())
+	}
+}
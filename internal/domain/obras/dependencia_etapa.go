@@ -0,0 +1,50 @@
+// file: internal/domain/obras/dependencia_etapa.go
+package obras
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TipoDependencia define como a etapa sucessora se relaciona com a predecessora.
+type TipoDependencia string
+
+const (
+	// DependenciaTerminoInicio: a sucessora só começa depois que a predecessora termina (FS).
+	DependenciaTerminoInicio TipoDependencia = "TI"
+	// DependenciaInicioInicio: a sucessora só começa depois que a predecessora começa (SS).
+	DependenciaInicioInicio TipoDependencia = "II"
+)
+
+var (
+	ErrDependenciaInvalida = errors.New("dependência entre etapas inválida")
+	ErrCicloDependencias   = errors.New("as dependências formam um ciclo entre as etapas")
+)
+
+// DependenciaEtapa liga duas etapas da mesma obra. LagDias é o intervalo, em dias,
+// entre o marco da predecessora e o início da sucessora (pode ser negativo).
+type DependenciaEtapa struct {
+	ID             string          `json:"id"`
+	ObraID         string          `json:"obraId"`
+	EtapaID        string          `json:"etapaId"`
+	PredecessoraID string          `json:"predecessoraId"`
+	Tipo           TipoDependencia `json:"tipo"`
+	LagDias        int             `json:"lagDias"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+// Validar confere os campos da dependência isoladamente; ciclos são verificados
+// pelo cronograma físico, que conhece todas as dependências da obra.
+func (d *DependenciaEtapa) Validar() error {
+	if d.EtapaID == "" || d.PredecessoraID == "" {
+		return fmt.Errorf("%w: etapa e predecessora são obrigatórias", ErrDependenciaInvalida)
+	}
+	if d.EtapaID == d.PredecessoraID {
+		return fmt.Errorf("%w: uma etapa não pode depender de si mesma", ErrDependenciaInvalida)
+	}
+	if d.Tipo != DependenciaTerminoInicio && d.Tipo != DependenciaInicioInicio {
+		return fmt.Errorf("%w: tipo deve ser TI (término-início) ou II (início-início)", ErrDependenciaInvalida)
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+// file: internal/domain/obras/diario_obra.go
+package obras
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CondicaoClima registra o tempo em um turno do dia.
+type CondicaoClima string
+
+const (
+	ClimaBom        CondicaoClima = "BOM"
+	ClimaNublado    CondicaoClima = "NUBLADO"
+	ClimaChuvaFraca CondicaoClima = "CHUVA_FRACA"
+	ClimaChuvaForte CondicaoClima = "CHUVA_FORTE"
+)
+
+// TipoOcorrencia classifica os fatos relevantes registrados no diário.
+type TipoOcorrencia string
+
+const (
+	OcorrenciaAcidente      TipoOcorrencia = "ACIDENTE"
+	OcorrenciaParalisacao   TipoOcorrencia = "PARALISACAO"
+	OcorrenciaAtrasoEntrega TipoOcorrencia = "ATRASO_ENTREGA"
+	OcorrenciaVisita        TipoOcorrencia = "VISITA"
+	OcorrenciaFiscalizacao  TipoOcorrencia = "FISCALIZACAO"
+	OcorrenciaOutro         TipoOcorrencia = "OUTRO"
+)
+
+// StatusDiario indica se o diário ainda pode ser editado.
+type StatusDiario string
+
+const (
+	StatusDiarioRascunho StatusDiario = "RASCUNHO"
+	StatusDiarioAssinado StatusDiario = "ASSINADO"
+)
+
+var (
+	ErrDiarioInvalido    = errors.New("diário de obra inválido")
+	ErrDiarioAssinado    = errors.New("diário de obra já assinado não pode ser alterado")
+	ErrDiarioDataFutura  = errors.New("diário de obra não pode ter data futura")
+	ErrFotoNaoEncontrada = errors.New("foto não encontrada no diário")
+)
+
+// DiarioObra é o registro do que aconteceu na obra em um dia. Depois de assinado
+// pelo engenheiro responsável, o diário fica travado.
+type DiarioObra struct {
+	ID                  string              `json:"id"`
+	ObraID              string              `json:"obraId"`
+	Data                time.Time           `json:"data"`
+	Clima               ClimaDiario         `json:"clima"`
+	Efetivo             []PresencaDiario    `json:"efetivo"`
+	EfetivoTerceirizado int                 `json:"efetivoTerceirizado"`
+	Equipamentos        []EquipamentoDiario `json:"equipamentos"`
+	Servicos            []ServicoDiario     `json:"servicos"`
+	Ocorrencias         []OcorrenciaDiario  `json:"ocorrencias"`
+	Fotos               []FotoDiario        `json:"fotos"`
+	Observacoes         string              `json:"observacoes"`
+	Status              StatusDiario        `json:"status"`
+	CriadoPor           string              `json:"criadoPor"`
+	AssinadoPor         *string             `json:"assinadoPor,omitempty"`
+	AssinadoEm          *time.Time          `json:"assinadoEm,omitempty"`
+	CreatedAt           time.Time           `json:"createdAt"`
+	UpdatedAt           time.Time           `json:"updatedAt"`
+}
+
+// ClimaDiario registra o tempo de manhã e à tarde e se foi possível trabalhar.
+type ClimaDiario struct {
+	Manha      CondicaoClima `json:"manha"`
+	Tarde      CondicaoClima `json:"tarde"`
+	Praticavel bool          `json:"praticavel"`
+}
+
+// PresencaDiario é um funcionário alocado que esteve na obra no dia.
+type PresencaDiario struct {
+	FuncionarioID    string  `json:"funcionarioId"`
+	FuncionarioNome  string  `json:"funcionarioNome"`
+	AlocacaoID       string  `json:"alocacaoId"`
+	HorasTrabalhadas float64 `json:"horasTrabalhadas"`
+}
+
+type EquipamentoDiario struct {
+	Descricao  string  `json:"descricao"`
+	Quantidade int     `json:"quantidade"`
+	HorasUso   float64 `json:"horasUso"`
+}
+
+// ServicoDiario é um serviço executado no dia em uma etapa da obra.
+type ServicoDiario struct {
+	EtapaID   string `json:"etapaId"`
+	Descricao string `json:"descricao"`
+}
+
+type OcorrenciaDiario struct {
+	Tipo      TipoOcorrencia `json:"tipo"`
+	Descricao string         `json:"descricao"`
+}
+
+// FotoDiario é uma foto anexada ao diário; o arquivo fica no armazenamento externo.
+type FotoDiario struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Legenda   string    `json:"legenda"`
+	EtapaID   *string   `json:"etapaId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ConteudoDiario são os campos editáveis do diário enquanto ele não é assinado.
+type ConteudoDiario struct {
+	Clima               ClimaDiario
+	Efetivo             []PresencaDiario
+	EfetivoTerceirizado int
+	Equipamentos        []EquipamentoDiario
+	Servicos            []ServicoDiario
+	Ocorrencias         []OcorrenciaDiario
+	Observacoes         string
+}
+
+// NovoDiarioObra abre o diário do dia. A data não pode estar no futuro.
+func NovoDiarioObra(id, obraID string, data time.Time, criadoPor string, agora time.Time) (*DiarioObra, error) {
+	data = dia(data)
+	if data.After(dia(agora)) {
+		return nil, ErrDiarioDataFutura
+	}
+	return &DiarioObra{
+		ID:           id,
+		ObraID:       obraID,
+		Data:         data,
+		Efetivo:      []PresencaDiario{},
+		Equipamentos: []EquipamentoDiario{},
+		Servicos:     []ServicoDiario{},
+		Ocorrencias:  []OcorrenciaDiario{},
+		Fotos:        []FotoDiario{},
+		Status:       StatusDiarioRascunho,
+		CriadoPor:    criadoPor,
+		CreatedAt:    agora,
+		UpdatedAt:    agora,
+	}, nil
+}
+
+// Assinado informa se o diário já foi travado pela assinatura.
+func (d *DiarioObra) Assinado() bool {
+	return d.Status == StatusDiarioAssinado
+}
+
+// AtualizarConteudo substitui os registros do dia, se o diário ainda não foi assinado.
+func (d *DiarioObra) AtualizarConteudo(c ConteudoDiario, agora time.Time) error {
+	if d.Assinado() {
+		return ErrDiarioAssinado
+	}
+	if err := c.Validar(); err != nil {
+		return err
+	}
+	d.Clima = c.Clima
+	d.Efetivo = naoNulo(c.Efetivo)
+	d.EfetivoTerceirizado = c.EfetivoTerceirizado
+	d.Equipamentos = naoNulo(c.Equipamentos)
+	d.Servicos = naoNulo(c.Servicos)
+	d.Ocorrencias = naoNulo(c.Ocorrencias)
+	d.Observacoes = strings.TrimSpace(c.Observacoes)
+	d.UpdatedAt = agora
+	return nil
+}
+
+// AdicionarFoto anexa uma foto ao diário ainda não assinado.
+func (d *DiarioObra) AdicionarFoto(foto FotoDiario, agora time.Time) error {
+	if d.Assinado() {
+		return ErrDiarioAssinado
+	}
+	if strings.TrimSpace(foto.URL) == "" {
+		return fmt.Errorf("%w: a foto precisa de uma URL", ErrDiarioInvalido)
+	}
+	d.Fotos = append(d.Fotos, foto)
+	d.UpdatedAt = agora
+	return nil
+}
+
+// RemoverFoto retira uma foto do diário ainda não assinado.
+func (d *DiarioObra) RemoverFoto(fotoID string, agora time.Time) error {
+	if d.Assinado() {
+		return ErrDiarioAssinado
+	}
+	for i, f := range d.Fotos {
+		if f.ID == fotoID {
+			d.Fotos = append(d.Fotos[:i], d.Fotos[i+1:]...)
+			d.UpdatedAt = agora
+			return nil
+		}
+	}
+	return ErrFotoNaoEncontrada
+}
+
+// Assinar registra o aceite do engenheiro e trava o diário. O diário precisa ter
+// ao menos o clima do dia preenchido.
+func (d *DiarioObra) Assinar(usuarioID string, agora time.Time) error {
+	if d.Assinado() {
+		return ErrDiarioAssinado
+	}
+	if d.Clima.Manha == "" || d.Clima.Tarde == "" {
+		return fmt.Errorf("%w: informe o clima do dia antes de assinar", ErrDiarioInvalido)
+	}
+	d.Status = StatusDiarioAssinado
+	d.AssinadoPor = &usuarioID
+	d.AssinadoEm = &agora
+	d.UpdatedAt = agora
+	return nil
+}
+
+// Validar confere os valores informados para o dia.
+func (c ConteudoDiario) Validar() error {
+	for _, clima := range []CondicaoClima{c.Clima.Manha, c.Clima.Tarde} {
+		switch clima {
+		case "", ClimaBom, ClimaNublado, ClimaChuvaFraca, ClimaChuvaForte:
+		default:
+			return fmt.Errorf("%w: clima '%s' desconhecido", ErrDiarioInvalido, clima)
+		}
+	}
+	if c.EfetivoTerceirizado < 0 {
+		return fmt.Errorf("%w: efetivo terceirizado não pode ser negativo", ErrDiarioInvalido)
+	}
+
+	presentes := make(map[string]bool, len(c.Efetivo))
+	for _, p := range c.Efetivo {
+		if p.FuncionarioID == "" {
+			return fmt.Errorf("%w: funcionário não informado no efetivo", ErrDiarioInvalido)
+		}
+		if presentes[p.FuncionarioID] {
+			return fmt.Errorf("%w: funcionário repetido no efetivo", ErrDiarioInvalido)
+		}
+		presentes[p.FuncionarioID] = true
+		if p.HorasTrabalhadas < 0 || p.HorasTrabalhadas > 24 {
+			return fmt.Errorf("%w: horas trabalhadas devem estar entre 0 e 24", ErrDiarioInvalido)
+		}
+	}
+	for _, e := range c.Equipamentos {
+		if strings.TrimSpace(e.Descricao) == "" || e.Quantidade <= 0 {
+			return fmt.Errorf("%w: equipamento precisa de descrição e quantidade positiva", ErrDiarioInvalido)
+		}
+		if e.HorasUso < 0 || e.HorasUso > 24 {
+			return fmt.Errorf("%w: horas de uso devem estar entre 0 e 24", ErrDiarioInvalido)
+		}
+	}
+	for _, s := range c.Servicos {
+		if s.EtapaID == "" || strings.TrimSpace(s.Descricao) == "" {
+			return fmt.Errorf("%w: serviço precisa de etapa e descrição", ErrDiarioInvalido)
+		}
+	}
+	for _, o := range c.Ocorrencias {
+		switch o.Tipo {
+		case OcorrenciaAcidente, OcorrenciaParalisacao, OcorrenciaAtrasoEntrega, OcorrenciaVisita, OcorrenciaFiscalizacao, OcorrenciaOutro:
+		default:
+			return fmt.Errorf("%w: tipo de ocorrência '%s' desconhecido", ErrDiarioInvalido, o.Tipo)
+		}
+		if strings.TrimSpace(o.Descricao) == "" {
+			return fmt.Errorf("%w: ocorrência precisa de descrição", ErrDiarioInvalido)
+		}
+	}
+	return nil
+}
+
+func naoNulo[T any](itens []T) []T {
+	if itens == nil {
+		return []T{}
+	}
+	return itens
+}
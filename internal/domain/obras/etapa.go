@@ -2,6 +2,8 @@
 package obras
 
 import (
+	"errors"
+	"fmt"
 	"time"
 )
 
@@ -14,11 +16,152 @@ const (
 	StatusEtapaConcluida   StatusEtapa = "Concluída"
 )
 
+// PesoEtapaPadrao é o peso usado quando nenhum peso é informado para a etapa.
+const PesoEtapaPadrao = 1.0
+
+var (
+	ErrTransicaoEtapaInvalida = errors.New("transição de status da etapa inválida")
+	ErrPercentualInvalido     = errors.New("percentual executado deve estar entre 0 e 100")
+	ErrPesoInvalido           = errors.New("peso da etapa deve ser positivo")
+	ErrDatasReaisInvalidas    = errors.New("data de fim real não pode ser anterior à data de início real")
+	ErrReaberturaSemProgresso = errors.New("reabrir a etapa exige o percentual executado, abaixo de 100")
+)
+
+// transicoesEtapa lista para quais status cada status pode ir. Uma etapa concluída
+// só volta para "Em Andamento" (reabertura, por Reabrir), nunca para "Pendente".
+var transicoesEtapa = map[StatusEtapa][]StatusEtapa{
+	StatusEtapaPendente:    {StatusEtapaEmAndamento},
+	StatusEtapaEmAndamento: {StatusEtapaConcluida, StatusEtapaPendente},
+	StatusEtapaConcluida:   {StatusEtapaEmAndamento},
+}
+
 type Etapa struct {
-	ID                 string
-	ObraID             string
-	Nome               string
-	DataInicioPrevista *time.Time `json:"data_inicio_prevista"`
-	DataFimPrevista    *time.Time `json:"data_fim_prevista"`
-	Status             StatusEtapa
+	ID                   string
+	ObraID               string
+	Nome                 string
+	EtapaPadraoID        *string    `json:"etapa_padrao_id"` // Etapa do catálogo que originou a etapa; define as FVS instanciadas
+	DataInicioPrevista   *time.Time `json:"data_inicio_prevista"`
+	DataFimPrevista      *time.Time `json:"data_fim_prevista"`
+	DataInicioProgramada *time.Time `json:"data_inicio_programada,omitempty"` // Recalculada pelas dependências quando difere da prevista (linha de base)
+	DataFimProgramada    *time.Time `json:"data_fim_programada,omitempty"`
+	DataInicioReal       *time.Time `json:"data_inicio_real"`
+	DataFimReal          *time.Time `json:"data_fim_real"`
+	PercentualExecutado  float64    `json:"percentual_executado"`
+	Peso                 float64    `json:"peso"`
+	Status               StatusEtapa
+}
+
+// AlterarStatus valida a transição e mantém datas reais e percentual coerentes com o novo status.
+func (e *Etapa) AlterarStatus(novo StatusEtapa, data time.Time) error {
+	if novo == e.Status {
+		return nil
+	}
+	permitida := false
+	for _, s := range transicoesEtapa[e.Status] {
+		if s == novo {
+			permitida = true
+			break
+		}
+	}
+	if !permitida {
+		return fmt.Errorf("%w: de '%s' para '%s'", ErrTransicaoEtapaInvalida, e.Status, novo)
+	}
+
+	switch novo {
+	case StatusEtapaEmAndamento:
+		if e.Status == StatusEtapaConcluida {
+			return ErrReaberturaSemProgresso
+		}
+		if e.DataInicioReal == nil {
+			e.DataInicioReal = &data
+		}
+	case StatusEtapaConcluida:
+		if e.DataInicioReal != nil && data.Before(*e.DataInicioReal) {
+			return ErrDatasReaisInvalidas
+		}
+		e.DataFimReal = &data
+		e.PercentualExecutado = 100
+	case StatusEtapaPendente:
+		if e.PercentualExecutado > 0 {
+			return fmt.Errorf("%w: etapa já possui execução registrada", ErrTransicaoEtapaInvalida)
+		}
+		e.DataInicioReal = nil
+	}
+	e.Status = novo
+	return nil
+}
+
+// Reabrir volta a etapa concluída para "Em Andamento" com o percentual executado
+// informado, que precisa ficar abaixo de 100.
+func (e *Etapa) Reabrir(percentual float64) error {
+	if e.Status != StatusEtapaConcluida {
+		return fmt.Errorf("%w: só uma etapa concluída pode ser reaberta", ErrTransicaoEtapaInvalida)
+	}
+	if percentual < 0 || percentual >= 100 {
+		return ErrReaberturaSemProgresso
+	}
+	e.Status = StatusEtapaEmAndamento
+	e.DataFimReal = nil
+	e.PercentualExecutado = percentual
+	return nil
+}
+
+// RegistrarProgresso atualiza o percentual executado. Registrar execução numa etapa
+// pendente a inicia, chegar a 100% a conclui e reduzir o percentual de uma etapa
+// concluída a reabre.
+func (e *Etapa) RegistrarProgresso(percentual float64, data time.Time) error {
+	if percentual < 0 || percentual > 100 {
+		return ErrPercentualInvalido
+	}
+	if e.Status == StatusEtapaConcluida && percentual < 100 {
+		return e.Reabrir(percentual)
+	}
+
+	if e.Status == StatusEtapaPendente && percentual > 0 {
+		if err := e.AlterarStatus(StatusEtapaEmAndamento, data); err != nil {
+			return err
+		}
+	}
+	e.PercentualExecutado = percentual
+	if percentual == 100 && e.Status != StatusEtapaConcluida {
+		return e.AlterarStatus(StatusEtapaConcluida, data)
+	}
+	return nil
+}
+
+// DefinirPeso altera o peso da etapa no cálculo do progresso da obra.
+func (e *Etapa) DefinirPeso(peso float64) error {
+	if peso <= 0 {
+		return ErrPesoInvalido
+	}
+	e.Peso = peso
+	return nil
+}
+
+// DefinirDatasReais corrige as datas reais informadas manualmente.
+func (e *Etapa) DefinirDatasReais(inicio, fim *time.Time) error {
+	if inicio != nil {
+		e.DataInicioReal = inicio
+	}
+	if fim != nil {
+		e.DataFimReal = fim
+	}
+	if e.DataInicioReal != nil && e.DataFimReal != nil && e.DataFimReal.Before(*e.DataInicioReal) {
+		return ErrDatasReaisInvalidas
+	}
+	return nil
+}
+
+// PercentualPonderado calcula o progresso da obra como a média do percentual
+// executado das etapas ponderada pelo peso de cada uma.
+func PercentualPonderado(etapas []*Etapa) float64 {
+	var somaPesos, somaPonderada float64
+	for _, e := range etapas {
+		somaPesos += e.Peso
+		somaPonderada += e.Peso * e.PercentualExecutado
+	}
+	if somaPesos == 0 {
+		return 0
+	}
+	return somaPonderada / somaPesos
 }
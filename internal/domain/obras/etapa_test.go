@@ -0,0 +1,76 @@
+package obras
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReaberturaDeEtapaConcluida(t *testing.T) {
+	inicio := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	fim := time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+	concluida := func() *Etapa {
+		i, f := inicio, fim
+		return &Etapa{ID: "e", Peso: 1, Status: StatusEtapaConcluida, PercentualExecutado: 100, DataInicioReal: &i, DataFimReal: &f}
+	}
+
+	casos := []struct {
+		nome       string
+		reabrir    func(e *Etapa) error
+		erro       error
+		status     StatusEtapa
+		percentual float64
+	}{
+		{
+			nome:       "pelo status sem percentual",
+			reabrir:    func(e *Etapa) error { return e.AlterarStatus(StatusEtapaEmAndamento, fim) },
+			erro:       ErrReaberturaSemProgresso,
+			status:     StatusEtapaConcluida,
+			percentual: 100,
+		},
+		{
+			nome:       "com percentual",
+			reabrir:    func(e *Etapa) error { return e.Reabrir(80) },
+			status:     StatusEtapaEmAndamento,
+			percentual: 80,
+		},
+		{
+			nome:       "com percentual 100",
+			reabrir:    func(e *Etapa) error { return e.Reabrir(100) },
+			erro:       ErrReaberturaSemProgresso,
+			status:     StatusEtapaConcluida,
+			percentual: 100,
+		},
+		{
+			nome:       "reduzindo o progresso",
+			reabrir:    func(e *Etapa) error { return e.RegistrarProgresso(60, fim) },
+			status:     StatusEtapaEmAndamento,
+			percentual: 60,
+		},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			e := concluida()
+			err := c.reabrir(e)
+			if !errors.Is(err, c.erro) {
+				t.Fatalf("erro = %v, esperado %v", err, c.erro)
+			}
+			if e.Status != c.status || e.PercentualExecutado != c.percentual {
+				t.Fatalf("status %q com %v%%, esperado %q com %v%%", e.Status, e.PercentualExecutado, c.status, c.percentual)
+			}
+			if (e.DataFimReal == nil) != (c.status == StatusEtapaEmAndamento) {
+				t.Fatalf("dataFimReal = %v com status %q", e.DataFimReal, e.Status)
+			}
+			if got := PercentualPonderado([]*Etapa{e}); got != c.percentual {
+				t.Fatalf("PercentualPonderado = %v, esperado %v", got, c.percentual)
+			}
+		})
+	}
+}
+
+func TestReabrirEtapaNaoConcluida(t *testing.T) {
+	e := &Etapa{Status: StatusEtapaEmAndamento, PercentualExecutado: 40}
+	if err := e.Reabrir(30); !errors.Is(err, ErrTransicaoEtapaInvalida) {
+		t.Fatalf("erro = %v, esperado ErrTransicaoEtapaInvalida", err)
+	}
+}
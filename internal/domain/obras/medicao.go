@@ -0,0 +1,226 @@
+// file: internal/domain/obras/medicao.go
+package obras
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// StatusMedicao acompanha a medição da elaboração até o aceite do cliente.
+type StatusMedicao string
+
+const (
+	StatusMedicaoRascunho            StatusMedicao = "RASCUNHO"
+	StatusMedicaoAguardandoAprovacao StatusMedicao = "AGUARDANDO_APROVACAO"
+	StatusMedicaoAprovada            StatusMedicao = "APROVADA"
+	StatusMedicaoRejeitada           StatusMedicao = "REJEITADA"
+)
+
+var (
+	ErrMedicaoInvalida          = errors.New("medição inválida")
+	ErrMedicaoAprovada          = errors.New("medição já aprovada não pode ser alterada")
+	ErrTransicaoMedicaoInvalida = errors.New("transição de status da medição inválida")
+	ErrMedicaoAcimaDoContratado = errors.New("percentual medido acumulado da etapa ultrapassa 100%")
+)
+
+// Medicao registra o avanço medido das etapas da obra em um período. Aprovada
+// pelo cliente, ela vira uma parcela do cronograma de recebimento.
+type Medicao struct {
+	ID                      string        `json:"id"`
+	ObraID                  string        `json:"obraId"`
+	Numero                  int           `json:"numero"`
+	PeriodoInicio           time.Time     `json:"periodoInicio"`
+	PeriodoFim              time.Time     `json:"periodoFim"`
+	Itens                   []ItemMedicao `json:"itens"`
+	ValorTotal              float64       `json:"valorTotal"`
+	Observacoes             string        `json:"observacoes"`
+	Status                  StatusMedicao `json:"status"`
+	CriadoPor               string        `json:"criadoPor"`
+	EnviadaEm               *time.Time    `json:"enviadaEm,omitempty"`
+	ResponsavelCliente      *string       `json:"responsavelCliente,omitempty"`
+	AnalisadaEm             *time.Time    `json:"analisadaEm,omitempty"`
+	MotivoRejeicao          *string       `json:"motivoRejeicao,omitempty"`
+	CronogramaRecebimentoID *string       `json:"cronogramaRecebimentoId,omitempty"`
+	CreatedAt               time.Time     `json:"createdAt"`
+	UpdatedAt               time.Time     `json:"updatedAt"`
+}
+
+// ItemMedicao é o avanço de uma etapa no período. O avanço é informado em
+// percentual ou em quantidade medida sobre a quantidade prevista da etapa.
+// ValorEtapa é a parte do contrato atribuída à etapa e PercentualAnterior o que
+// já foi medido e aprovado em medições anteriores.
+type ItemMedicao struct {
+	EtapaID             string   `json:"etapaId"`
+	EtapaNome           string   `json:"etapaNome"`
+	Unidade             string   `json:"unidade,omitempty"`
+	QuantidadePrevista  *float64 `json:"quantidadePrevista,omitempty"`
+	QuantidadeMedida    *float64 `json:"quantidadeMedida,omitempty"`
+	PercentualPeriodo   float64  `json:"percentualPeriodo"`
+	PercentualAnterior  float64  `json:"percentualAnterior"`
+	PercentualAcumulado float64  `json:"percentualAcumulado"`
+	ValorEtapa          float64  `json:"valorEtapa"`
+	Valor               float64  `json:"valor"`
+}
+
+// NovaMedicao abre uma medição em rascunho para o período informado.
+func NovaMedicao(id, obraID string, numero int, inicio, fim time.Time, criadoPor string, agora time.Time) (*Medicao, error) {
+	inicio, fim = dia(inicio), dia(fim)
+	if fim.Before(inicio) {
+		return nil, fmt.Errorf("%w: fim do período anterior ao início", ErrMedicaoInvalida)
+	}
+	return &Medicao{
+		ID:            id,
+		ObraID:        obraID,
+		Numero:        numero,
+		PeriodoInicio: inicio,
+		PeriodoFim:    fim,
+		Itens:         []ItemMedicao{},
+		Status:        StatusMedicaoRascunho,
+		CriadoPor:     criadoPor,
+		CreatedAt:     agora,
+		UpdatedAt:     agora,
+	}, nil
+}
+
+// Aprovada informa se a medição já foi aceita pelo cliente e, portanto, travada.
+func (m *Medicao) Aprovada() bool {
+	return m.Status == StatusMedicaoAprovada
+}
+
+// Atualizar substitui período, itens e observações. Uma medição aguardando
+// aprovação ou rejeitada volta para rascunho e precisa ser reenviada.
+func (m *Medicao) Atualizar(inicio, fim time.Time, itens []ItemMedicao, observacoes string, agora time.Time) error {
+	if m.Aprovada() {
+		return ErrMedicaoAprovada
+	}
+	inicio, fim = dia(inicio), dia(fim)
+	if fim.Before(inicio) {
+		return fmt.Errorf("%w: fim do período anterior ao início", ErrMedicaoInvalida)
+	}
+
+	vistas := make(map[string]bool, len(itens))
+	var total float64
+	for i := range itens {
+		item := &itens[i]
+		if vistas[item.EtapaID] {
+			return fmt.Errorf("%w: etapa repetida na medição", ErrMedicaoInvalida)
+		}
+		vistas[item.EtapaID] = true
+		if err := item.calcular(); err != nil {
+			return err
+		}
+		total += item.Valor
+	}
+
+	m.PeriodoInicio = inicio
+	m.PeriodoFim = fim
+	m.Itens = naoNulo(itens)
+	m.ValorTotal = math.Round(total*100) / 100
+	m.Observacoes = strings.TrimSpace(observacoes)
+	m.Status = StatusMedicaoRascunho
+	m.EnviadaEm = nil
+	m.UpdatedAt = agora
+	return nil
+}
+
+// Enviar submete a medição ao cliente. Só rascunhos com valor medido podem ser enviados.
+func (m *Medicao) Enviar(agora time.Time) error {
+	if m.Status != StatusMedicaoRascunho {
+		return fmt.Errorf("%w: apenas medições em rascunho podem ser enviadas", ErrTransicaoMedicaoInvalida)
+	}
+	if len(m.Itens) == 0 || m.ValorTotal <= 0 {
+		return fmt.Errorf("%w: a medição não tem avanço medido", ErrMedicaoInvalida)
+	}
+	m.Status = StatusMedicaoAguardandoAprovacao
+	m.EnviadaEm = &agora
+	m.ResponsavelCliente = nil
+	m.AnalisadaEm = nil
+	m.MotivoRejeicao = nil
+	m.UpdatedAt = agora
+	return nil
+}
+
+// Aprovar registra o aceite do cliente e vincula a parcela gerada no cronograma de recebimento.
+func (m *Medicao) Aprovar(responsavel, cronogramaRecebimentoID string, agora time.Time) error {
+	if err := m.analisar(responsavel, agora); err != nil {
+		return err
+	}
+	m.Status = StatusMedicaoAprovada
+	m.CronogramaRecebimentoID = &cronogramaRecebimentoID
+	return nil
+}
+
+// Rejeitar registra a recusa do cliente; a medição pode ser corrigida e reenviada.
+func (m *Medicao) Rejeitar(responsavel, motivo string, agora time.Time) error {
+	motivo = strings.TrimSpace(motivo)
+	if motivo == "" {
+		return fmt.Errorf("%w: informe o motivo da rejeição", ErrMedicaoInvalida)
+	}
+	if err := m.analisar(responsavel, agora); err != nil {
+		return err
+	}
+	m.Status = StatusMedicaoRejeitada
+	m.MotivoRejeicao = &motivo
+	return nil
+}
+
+func (m *Medicao) analisar(responsavel string, agora time.Time) error {
+	if m.Status != StatusMedicaoAguardandoAprovacao {
+		return fmt.Errorf("%w: a medição não está aguardando aprovação", ErrTransicaoMedicaoInvalida)
+	}
+	responsavel = strings.TrimSpace(responsavel)
+	if responsavel == "" {
+		return fmt.Errorf("%w: informe o responsável do cliente", ErrMedicaoInvalida)
+	}
+	m.ResponsavelCliente = &responsavel
+	m.AnalisadaEm = &agora
+	m.UpdatedAt = agora
+	return nil
+}
+
+// calcular converte a quantidade medida em percentual, quando informada, e
+// valoriza o avanço do período sobre a parte do contrato atribuída à etapa.
+func (i *ItemMedicao) calcular() error {
+	if i.EtapaID == "" {
+		return fmt.Errorf("%w: etapa não informada", ErrMedicaoInvalida)
+	}
+	if i.QuantidadeMedida != nil {
+		if i.QuantidadePrevista == nil || *i.QuantidadePrevista <= 0 {
+			return fmt.Errorf("%w: quantidade prevista da etapa deve ser positiva", ErrMedicaoInvalida)
+		}
+		if *i.QuantidadeMedida < 0 {
+			return fmt.Errorf("%w: quantidade medida não pode ser negativa", ErrMedicaoInvalida)
+		}
+		i.PercentualPeriodo = *i.QuantidadeMedida / *i.QuantidadePrevista * 100
+	}
+	if i.PercentualPeriodo <= 0 {
+		return fmt.Errorf("%w: avanço medido da etapa deve ser positivo", ErrMedicaoInvalida)
+	}
+
+	i.PercentualPeriodo = math.Round(i.PercentualPeriodo*100) / 100
+	i.PercentualAcumulado = math.Round((i.PercentualAnterior+i.PercentualPeriodo)*100) / 100
+	if i.PercentualAcumulado > 100 {
+		return fmt.Errorf("%w: etapa '%s' chegaria a %.2f%%", ErrMedicaoAcimaDoContratado, i.EtapaNome, i.PercentualAcumulado)
+	}
+	i.Valor = math.Round(i.ValorEtapa*i.PercentualPeriodo) / 100
+	return nil
+}
+
+// ValorContratoPorEtapa reparte o valor do contrato entre as etapas na proporção do peso de cada uma.
+func ValorContratoPorEtapa(valorContrato float64, etapas []*Etapa) map[string]float64 {
+	var somaPesos float64
+	for _, e := range etapas {
+		somaPesos += e.Peso
+	}
+	valores := make(map[string]float64, len(etapas))
+	if somaPesos == 0 {
+		return valores
+	}
+	for _, e := range etapas {
+		valores[e.ID] = valorContrato * e.Peso / somaPesos
+	}
+	return valores
+}
@@ -0,0 +1,371 @@
+// file: internal/domain/obras/modelo_obra.go
+package obras
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrModeloObraInvalido = errors.New("modelo de obra inválido")
+
+// ModeloObra é um roteiro nomeado de obra (ex.: residência térrea, sobrado,
+// reforma): as etapas com duração, peso e dependências, o orçamento analítico em
+// percentual do contrato e a divisão do cronograma de recebimento por etapa.
+type ModeloObra struct {
+	ID                string                 `json:"id"`
+	Nome              string                 `json:"nome"`
+	Descricao         string                 `json:"descricao,omitempty"`
+	PercentualEntrada float64                `json:"percentualEntrada"` // Cobrança por etapas: entrada na assinatura
+	PrazoDiasEtapa    int                    `json:"prazoDiasEtapa"`    // Cobrança por etapas: dias após o fim previsto
+	Etapas            []*EtapaModelo         `json:"etapas"`
+	Orcamento         []*ItemOrcamentoModelo `json:"orcamento"`
+	CreatedAt         time.Time              `json:"createdAt"`
+	UpdatedAt         time.Time              `json:"updatedAt"`
+}
+
+// EtapaModelo é uma etapa do modelo. A Ordem identifica a etapa dentro do modelo
+// e é usada pelas dependências e pelo orçamento.
+type EtapaModelo struct {
+	Ordem                int                   `json:"ordem"`
+	Nome                 string                `json:"nome"`
+	InicioDias           int                   `json:"inicioDias"` // Dias após o início da obra, antes das dependências
+	DuracaoDias          int                   `json:"duracaoDias"`
+	Peso                 float64               `json:"peso"`
+	PercentualCronograma float64               `json:"percentualCronograma,omitempty"` // Fatia do contrato cobrada ao fim da etapa
+	Predecessoras        []*PredecessoraModelo `json:"predecessoras,omitempty"`
+	EtapaPadraoID        string                `json:"etapaPadraoId,omitempty"` // Etapa do catálogo; as etapas criadas recebem as FVS dela
+}
+
+// PredecessoraModelo liga a etapa a outra etapa do modelo, pela ordem.
+type PredecessoraModelo struct {
+	Ordem   int             `json:"ordem"`
+	Tipo    TipoDependencia `json:"tipo"`
+	LagDias int             `json:"lagDias"`
+}
+
+// ItemOrcamentoModelo é a fatia do contrato prevista para uma categoria de custo.
+// EtapaOrdem nula representa custos da obra como um todo.
+type ItemOrcamentoModelo struct {
+	EtapaOrdem         *int           `json:"etapaOrdem,omitempty"`
+	Categoria          CategoriaCusto `json:"categoria"`
+	PercentualContrato float64        `json:"percentualContrato"`
+}
+
+// PlanoObra é o modelo aplicado a uma obra: etapas com datas previstas,
+// dependências, orçamento analítico e percentuais do cronograma por etapa.
+type PlanoObra struct {
+	Etapas                []*Etapa
+	Dependencias          []*DependenciaEtapa
+	Orcamento             *OrcamentoAnalitico // Nulo quando o modelo não tem orçamento ou a obra não tem valor de contrato
+	PercentualEntrada     float64
+	PrazoDiasEtapa        int
+	PercentuaisCronograma []PercentualCronogramaEtapa // Vazio quando o modelo não divide o contrato por etapa
+}
+
+// PercentualCronogramaEtapa é a fatia do contrato cobrada ao fim de uma etapa do plano.
+type PercentualCronogramaEtapa struct {
+	EtapaID    string
+	Percentual float64
+}
+
+// Validar confere nome, etapas, dependências (sem ciclos), orçamento e a divisão
+// do cronograma, que, quando informada, deve fechar 100% com a entrada.
+func (m *ModeloObra) Validar() error {
+	m.Nome = strings.TrimSpace(m.Nome)
+	if m.Nome == "" {
+		return fmt.Errorf("%w: nome é obrigatório", ErrModeloObraInvalido)
+	}
+	if len(m.Etapas) == 0 {
+		return fmt.Errorf("%w: o modelo precisa de ao menos uma etapa", ErrModeloObraInvalido)
+	}
+	if m.PercentualEntrada < 0 || m.PercentualEntrada >= 100 {
+		return fmt.Errorf("%w: percentual de entrada deve estar entre 0 e 100", ErrModeloObraInvalido)
+	}
+	if m.PrazoDiasEtapa < 0 {
+		return fmt.Errorf("%w: prazo após a etapa não pode ser negativo", ErrModeloObraInvalido)
+	}
+
+	porOrdem := make(map[int]*EtapaModelo, len(m.Etapas))
+	nomes := make(map[string]bool, len(m.Etapas))
+	var somaCronograma float64
+	for _, e := range m.Etapas {
+		e.Nome = strings.TrimSpace(e.Nome)
+		if e.Nome == "" {
+			return fmt.Errorf("%w: nome da etapa %d é obrigatório", ErrModeloObraInvalido, e.Ordem)
+		}
+		if porOrdem[e.Ordem] != nil {
+			return fmt.Errorf("%w: ordem %d repetida", ErrModeloObraInvalido, e.Ordem)
+		}
+		if nomes[strings.ToLower(e.Nome)] {
+			return fmt.Errorf("%w: etapa '%s' repetida", ErrModeloObraInvalido, e.Nome)
+		}
+		if e.DuracaoDias < 1 {
+			return fmt.Errorf("%w: duração da etapa '%s' deve ser de ao menos 1 dia", ErrModeloObraInvalido, e.Nome)
+		}
+		if e.InicioDias < 0 {
+			return fmt.Errorf("%w: início da etapa '%s' não pode ser negativo", ErrModeloObraInvalido, e.Nome)
+		}
+		if e.Peso == 0 {
+			e.Peso = PesoEtapaPadrao
+		}
+		if e.Peso < 0 {
+			return fmt.Errorf("%w: peso da etapa '%s' deve ser positivo", ErrModeloObraInvalido, e.Nome)
+		}
+		if e.PercentualCronograma < 0 {
+			return fmt.Errorf("%w: percentual do cronograma da etapa '%s' não pode ser negativo", ErrModeloObraInvalido, e.Nome)
+		}
+		somaCronograma += e.PercentualCronograma
+		porOrdem[e.Ordem] = e
+		nomes[strings.ToLower(e.Nome)] = true
+	}
+
+	for _, e := range m.Etapas {
+		for _, p := range e.Predecessoras {
+			if porOrdem[p.Ordem] == nil {
+				return fmt.Errorf("%w: a etapa '%s' depende da ordem %d, que não existe", ErrModeloObraInvalido, e.Nome, p.Ordem)
+			}
+			d := DependenciaEtapa{EtapaID: ordemID(e.Ordem), PredecessoraID: ordemID(p.Ordem), Tipo: p.Tipo}
+			if err := d.Validar(); err != nil {
+				return fmt.Errorf("%w: etapa '%s': %w", ErrModeloObraInvalido, e.Nome, err)
+			}
+		}
+	}
+
+	if _, err := ordenarTopologicamente(m.etapasTemporarias(), m.dependenciasTemporarias()); err != nil {
+		return fmt.Errorf("%w: %w", ErrModeloObraInvalido, err)
+	}
+
+	if somaCronograma > 0 && math.Abs(m.PercentualEntrada+somaCronograma-100) > 0.01 {
+		return fmt.Errorf("%w: entrada e etapas do cronograma somam %.2f%%, e não 100%%", ErrModeloObraInvalido, m.PercentualEntrada+somaCronograma)
+	}
+
+	vistos := make(map[string]bool, len(m.Orcamento))
+	for _, item := range m.Orcamento {
+		switch item.Categoria {
+		case CategoriaCustoMaterial, CategoriaCustoMaoDeObra, CategoriaCustoServicos, CategoriaCustoEquipamentos, CategoriaCustoOutros:
+		default:
+			return fmt.Errorf("%w: categoria '%s' desconhecida", ErrModeloObraInvalido, item.Categoria)
+		}
+		if item.PercentualContrato < 0 {
+			return fmt.Errorf("%w: percentual do orçamento não pode ser negativo", ErrModeloObraInvalido)
+		}
+		chave := string(item.Categoria)
+		if item.EtapaOrdem != nil {
+			if porOrdem[*item.EtapaOrdem] == nil {
+				return fmt.Errorf("%w: o orçamento usa a ordem %d, que não existe", ErrModeloObraInvalido, *item.EtapaOrdem)
+			}
+			chave = ordemID(*item.EtapaOrdem) + "|" + chave
+		}
+		if vistos[chave] {
+			return fmt.Errorf("%w: categoria '%s' repetida na mesma etapa", ErrModeloObraInvalido, item.Categoria)
+		}
+		vistos[chave] = true
+	}
+	return nil
+}
+
+// Instanciar aplica o modelo à obra: cria as etapas pendentes com as datas
+// previstas calculadas pelas dependências a partir do início da obra, as
+// dependências e o orçamento proporcional ao valor do contrato.
+func (m *ModeloObra) Instanciar(obra *Obra, agora time.Time, novoID func() string) (*PlanoObra, error) {
+	plano := &PlanoObra{
+		PercentualEntrada: m.PercentualEntrada,
+		PrazoDiasEtapa:    m.PrazoDiasEtapa,
+	}
+	inicioObra := dia(obra.DataInicio)
+
+	idPorOrdem := make(map[int]string, len(m.Etapas))
+	for _, em := range m.Etapas {
+		inicio := inicioObra.AddDate(0, 0, em.InicioDias)
+		fim := inicio.AddDate(0, 0, em.DuracaoDias-1)
+		etapa := &Etapa{
+			ID:                 novoID(),
+			ObraID:             obra.ID,
+			Nome:               em.Nome,
+			EtapaPadraoID:      em.etapaPadrao(),
+			DataInicioPrevista: &inicio,
+			DataFimPrevista:    &fim,
+			Status:             StatusEtapaPendente,
+			Peso:               em.Peso,
+		}
+		idPorOrdem[em.Ordem] = etapa.ID
+		plano.Etapas = append(plano.Etapas, etapa)
+		if em.PercentualCronograma > 0 {
+			plano.PercentuaisCronograma = append(plano.PercentuaisCronograma, PercentualCronogramaEtapa{EtapaID: etapa.ID, Percentual: em.PercentualCronograma})
+		}
+	}
+	for _, em := range m.Etapas {
+		for _, p := range em.Predecessoras {
+			plano.Dependencias = append(plano.Dependencias, &DependenciaEtapa{
+				ID:             novoID(),
+				ObraID:         obra.ID,
+				EtapaID:        idPorOrdem[em.Ordem],
+				PredecessoraID: idPorOrdem[p.Ordem],
+				Tipo:           p.Tipo,
+				LagDias:        p.LagDias,
+				CreatedAt:      agora,
+			})
+		}
+	}
+
+	cronograma, err := CalcularCronogramaFisico(plano.Etapas, plano.Dependencias, inicioObra, inicioObra)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrModeloObraInvalido, err)
+	}
+	cronograma.DefinirLinhaDeBase()
+
+	if len(m.Orcamento) > 0 && obra.ValorContratoTotal > 0 {
+		plano.Orcamento = &OrcamentoAnalitico{
+			ObraID:            obra.ID,
+			PercentualAlerta:  PercentualAlertaPadrao,
+			PercentualCritico: PercentualCriticoPadrao,
+			UpdatedAt:         agora,
+		}
+		for _, item := range m.Orcamento {
+			var etapaID *string
+			if item.EtapaOrdem != nil {
+				id := idPorOrdem[*item.EtapaOrdem]
+				etapaID = &id
+			}
+			plano.Orcamento.Itens = append(plano.Orcamento.Itens, &ItemOrcamentoAnalitico{
+				ID:            novoID(),
+				EtapaID:       etapaID,
+				Categoria:     item.Categoria,
+				ValorPrevisto: math.Round(obra.ValorContratoTotal*item.PercentualContrato) / 100,
+			})
+		}
+	}
+	return plano, nil
+}
+
+// ModeloAPartirDaObra monta um modelo com a estrutura de uma obra existente: a
+// duração e o deslocamento das etapas saem das datas previstas, o orçamento vira
+// percentual do contrato e a divisão do cronograma é aproveitada quando as
+// parcelas "Entrada" e "Etapa: <nome>" fecham 100% do contrato.
+func ModeloAPartirDaObra(obra *Obra, etapas []*Etapa, dependencias []*DependenciaEtapa, orcamento *OrcamentoAnalitico, cronograma []*CronogramaRecebimento) *ModeloObra {
+	modelo := &ModeloObra{
+		Etapas:    make([]*EtapaModelo, 0, len(etapas)),
+		Orcamento: make([]*ItemOrcamentoModelo, 0),
+	}
+	inicioObra := dia(obra.DataInicio)
+
+	ordemPorID := make(map[string]int, len(etapas))
+	porNome := make(map[string]*EtapaModelo, len(etapas))
+	for i, e := range etapas {
+		em := &EtapaModelo{
+			Ordem:       i + 1,
+			Nome:        e.Nome,
+			DuracaoDias: duracaoPrevista(e),
+			Peso:        e.Peso,
+		}
+		if e.DataInicioPrevista != nil {
+			em.InicioDias = max(diasEntre(inicioObra, dia(*e.DataInicioPrevista)), 0)
+		}
+		if em.Peso <= 0 {
+			em.Peso = PesoEtapaPadrao
+		}
+		if e.EtapaPadraoID != nil {
+			em.EtapaPadraoID = *e.EtapaPadraoID
+		}
+		ordemPorID[e.ID] = em.Ordem
+		porNome[strings.ToLower(e.Nome)] = em
+		modelo.Etapas = append(modelo.Etapas, em)
+	}
+	for _, d := range dependencias {
+		sucessora, okS := ordemPorID[d.EtapaID]
+		predecessora, okP := ordemPorID[d.PredecessoraID]
+		if !okS || !okP {
+			continue
+		}
+		em := modelo.Etapas[sucessora-1]
+		em.Predecessoras = append(em.Predecessoras, &PredecessoraModelo{Ordem: predecessora, Tipo: d.Tipo, LagDias: d.LagDias})
+	}
+
+	if obra.ValorContratoTotal <= 0 {
+		return modelo
+	}
+	if orcamento != nil {
+		for _, item := range orcamento.Itens {
+			linha := &ItemOrcamentoModelo{
+				Categoria:          item.Categoria,
+				PercentualContrato: math.Round(item.ValorPrevisto/obra.ValorContratoTotal*1e6) / 1e4,
+			}
+			if item.EtapaID != nil {
+				ordem, ok := ordemPorID[*item.EtapaID]
+				if !ok {
+					continue
+				}
+				linha.EtapaOrdem = &ordem
+			}
+			modelo.Orcamento = append(modelo.Orcamento, linha)
+		}
+	}
+
+	var entrada, soma float64
+	percentuais := make(map[*EtapaModelo]float64)
+	prazo := -1
+	for _, c := range cronograma {
+		percentual := math.Round(c.ValorPrevisto/obra.ValorContratoTotal*1e6) / 1e4
+		if c.DescricaoEtapa == "Entrada" {
+			entrada += percentual
+			soma += percentual
+			continue
+		}
+		nome, ok := strings.CutPrefix(c.DescricaoEtapa, "Etapa: ")
+		em := porNome[strings.ToLower(nome)]
+		if !ok || em == nil {
+			continue
+		}
+		percentuais[em] += percentual
+		soma += percentual
+		if e := etapas[em.Ordem-1]; prazo < 0 && e.DataFimPrevista != nil {
+			prazo = max(diasEntre(dia(*e.DataFimPrevista), dia(c.DataVencimento)), 0)
+		}
+	}
+	if len(percentuais) > 0 && math.Abs(soma-100) <= 0.01 && entrada < 100 {
+		modelo.PercentualEntrada = entrada
+		modelo.PrazoDiasEtapa = max(prazo, 0)
+		for em, p := range percentuais {
+			em.PercentualCronograma = p
+		}
+	}
+	return modelo
+}
+
+// etapasTemporarias representa as etapas do modelo como etapas de obra, com a
+// ordem como ID, para reaproveitar a verificação de ciclos do cronograma físico.
+func (m *ModeloObra) etapasTemporarias() []*Etapa {
+	etapas := make([]*Etapa, 0, len(m.Etapas))
+	for _, e := range m.Etapas {
+		etapas = append(etapas, &Etapa{ID: ordemID(e.Ordem), Nome: e.Nome})
+	}
+	return etapas
+}
+
+func (m *ModeloObra) dependenciasTemporarias() []*DependenciaEtapa {
+	var deps []*DependenciaEtapa
+	for _, e := range m.Etapas {
+		for _, p := range e.Predecessoras {
+			deps = append(deps, &DependenciaEtapa{EtapaID: ordemID(e.Ordem), PredecessoraID: ordemID(p.Ordem), Tipo: p.Tipo})
+		}
+	}
+	return deps
+}
+
+// etapaPadrao devolve a referência ao catálogo para a etapa da obra, nula quando
+// a etapa do modelo não vem do catálogo.
+func (e *EtapaModelo) etapaPadrao() *string {
+	if e.EtapaPadraoID == "" {
+		return nil
+	}
+	id := e.EtapaPadraoID
+	return &id
+}
+
+func ordemID(ordem int) string {
+	return "ordem:" + strconv.Itoa(ordem)
+}
@@ -0,0 +1,56 @@
+package obras
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestModeloObraEtapaPadrao(t *testing.T) {
+	modelo := &ModeloObra{
+		Nome: "residência térrea",
+		Etapas: []*EtapaModelo{
+			{Ordem: 1, Nome: "Fundação", EtapaPadraoID: "ep-fundacao", DuracaoDias: 10},
+			{Ordem: 2, Nome: "Paisagismo", DuracaoDias: 5},
+		},
+	}
+	if err := modelo.Validar(); err != nil {
+		t.Fatalf("Validar() erro inesperado: %v", err)
+	}
+	obra := &Obra{ID: "obra", DataInicio: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)}
+	n := 0
+	novoID := func() string { n++; return "id-" + strconv.Itoa(n) }
+
+	plano, err := modelo.Instanciar(obra, obra.DataInicio, novoID)
+	if err != nil {
+		t.Fatalf("Instanciar() erro inesperado: %v", err)
+	}
+	copia := ModeloAPartirDaObra(obra, plano.Etapas, plano.Dependencias, nil, nil)
+
+	casos := []struct {
+		nome     string
+		ordem    int
+		esperado string
+	}{
+		{"etapa do catálogo", 1, "ep-fundacao"},
+		{"etapa fora do catálogo", 2, ""},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			etapa := plano.Etapas[tc.ordem-1]
+			var got string
+			if etapa.EtapaPadraoID != nil {
+				got = *etapa.EtapaPadraoID
+			}
+			if got != tc.esperado {
+				t.Errorf("Instanciar(): EtapaPadraoID = %q, esperado %q", got, tc.esperado)
+			}
+			if tc.esperado == "" && etapa.EtapaPadraoID != nil {
+				t.Errorf("Instanciar(): EtapaPadraoID deveria ser nulo")
+			}
+			if got := copia.Etapas[tc.ordem-1].EtapaPadraoID; got != tc.esperado {
+				t.Errorf("ModeloAPartirDaObra(): EtapaPadraoID = %q, esperado %q", got, tc.esperado)
+			}
+		})
+	}
+}
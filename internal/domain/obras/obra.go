@@ -3,6 +3,7 @@ package obras
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -20,7 +21,8 @@ const (
 type Obra struct {
 	ID         string     `json:"id" db:"id"`
 	Nome       string     `json:"nome" db:"nome"`
-	Cliente    string     `json:"cliente" db:"cliente"`
+	Cliente    string     `json:"cliente" db:"cliente"` // Nome do cliente, copiado do cadastro
+	ClienteID  *string    `json:"clienteId,omitempty" db:"cliente_id"`
 	Endereco   string     `json:"endereco" db:"endereco"`
 	DataInicio time.Time  `json:"dataInicio" db:"data_inicio"`
 	DataFim    *time.Time `json:"dataFim,omitempty" db:"data_fim"`
@@ -33,8 +35,15 @@ type Obra struct {
 	ValorRecebido         float64 `json:"valorRecebido" db:"valor_recebido"`         // Valor já recebido
 	TipoCobranca          string  `json:"tipoCobranca" db:"tipo_cobranca"`          // "VISTA", "PARCELADO", "ETAPAS"
 	DataAssinaturaContrato *time.Time `json:"dataAssinaturaContrato,omitempty" db:"data_assinatura_contrato"` // Data da assinatura do contrato
+	PercentualRetencao     float64    `json:"percentualRetencao" db:"percentual_retencao"`                    // Caução retida de cada medição
 }
 
+// PercentualRetencaoMaximo limita a caução contratual retida de cada medição.
+const PercentualRetencaoMaximo = 30.0
+
+// ErrRetencaoInvalida indica um percentual de retenção fora da faixa aceita.
+var ErrRetencaoInvalida = errors.New("percentual de retenção inválido")
+
 // TipoCobranca representa os tipos de cobrança possíveis
 const (
 	TipoCobrancaVista     = "VISTA"
@@ -42,6 +51,16 @@ const (
 	TipoCobrancaEtapas    = "ETAPAS"
 )
 
+// DefinirRetencao altera o percentual de caução. Só vale para as contas geradas
+// depois da alteração; o que já foi retido não é recalculado.
+func (o *Obra) DefinirRetencao(percentual float64) error {
+	if percentual < 0 || percentual > PercentualRetencaoMaximo {
+		return fmt.Errorf("%w: deve estar entre 0 e %.0f%%", ErrRetencaoInvalida, PercentualRetencaoMaximo)
+	}
+	o.PercentualRetencao = percentual
+	return nil
+}
+
 // ValorSaldo calcula o saldo a receber da obra
 func (o *Obra) ValorSaldo() float64 {
 	return o.ValorContratoTotal - o.ValorRecebido
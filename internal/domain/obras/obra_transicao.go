@@ -0,0 +1,176 @@
+// file: internal/domain/obras/obra_transicao.go
+package obras
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Transicao identifica uma mudança de ciclo de vida da obra.
+type Transicao string
+
+const (
+	TransicaoIniciar  Transicao = "iniciar"
+	TransicaoConcluir Transicao = "concluir"
+	TransicaoCancelar Transicao = "cancelar"
+)
+
+// ErrTransicaoNaoPermitida indica que as pré-condições da transição não foram atendidas.
+var ErrTransicaoNaoPermitida = errors.New("transição de status não permitida")
+
+// ErroTransicao detalha por que uma transição foi recusada.
+type ErroTransicao struct {
+	Transicao Transicao
+	Motivos   []string
+}
+
+func (e *ErroTransicao) Error() string {
+	return fmt.Sprintf("não é possível %s a obra: %s", e.Transicao, strings.Join(e.Motivos, "; "))
+}
+
+func (e *ErroTransicao) Unwrap() error { return ErrTransicaoNaoPermitida }
+
+// regraTransicao descreve de quais status uma transição parte e para qual status leva.
+type regraTransicao struct {
+	origem  []Status
+	destino Status
+}
+
+var regrasTransicao = map[Transicao]regraTransicao{
+	TransicaoIniciar:  {origem: []Status{StatusEmPlanejamento}, destino: StatusEmAndamento},
+	TransicaoConcluir: {origem: []Status{StatusEmAndamento}, destino: StatusConcluida},
+	TransicaoCancelar: {origem: []Status{StatusEmPlanejamento, StatusEmAndamento}, destino: StatusCancelada},
+}
+
+// ordemTransicoes fixa a ordem de exibição das transições.
+var ordemTransicoes = []Transicao{TransicaoIniciar, TransicaoConcluir, TransicaoCancelar}
+
+// SituacaoObra reúne os fatos de outros agregados necessários para validar as transições.
+type SituacaoObra struct {
+	Etapas                    []*Etapa
+	ParcelasCronogramaAbertas int
+	ContasReceberAbertas      int
+	ContasPagarAbertas        int
+	PendenciasBloqueantes     int // Pendências de vistoria bloqueantes ainda não resolvidas
+}
+
+// AvaliacaoTransicao informa se uma transição pode ser executada agora e, se não, por quê.
+type AvaliacaoTransicao struct {
+	Transicao     Transicao `json:"transicao"`
+	StatusDestino Status    `json:"statusDestino"`
+	Permitida     bool      `json:"permitida"`
+	Motivos       []string  `json:"motivos"`
+}
+
+// AvaliarTransicoes lista todas as transições com o resultado das validações.
+func (o *Obra) AvaliarTransicoes(situacao SituacaoObra) []AvaliacaoTransicao {
+	avaliacoes := make([]AvaliacaoTransicao, 0, len(ordemTransicoes))
+	for _, t := range ordemTransicoes {
+		motivos := o.motivosBloqueio(t, situacao)
+		avaliacoes = append(avaliacoes, AvaliacaoTransicao{
+			Transicao:     t,
+			StatusDestino: regrasTransicao[t].destino,
+			Permitida:     len(motivos) == 0,
+			Motivos:       motivos,
+		})
+	}
+	return avaliacoes
+}
+
+// AplicarTransicao valida e executa a transição, alterando o status da obra.
+func (o *Obra) AplicarTransicao(t Transicao, situacao SituacaoObra) error {
+	regra, ok := regrasTransicao[t]
+	if !ok {
+		return &ErroTransicao{Transicao: t, Motivos: []string{fmt.Sprintf("transição '%s' desconhecida", t)}}
+	}
+	if motivos := o.motivosBloqueio(t, situacao); len(motivos) > 0 {
+		return &ErroTransicao{Transicao: t, Motivos: motivos}
+	}
+
+	o.Status = regra.destino
+	if t == TransicaoConcluir && o.DataFim == nil {
+		hoje := time.Now()
+		o.DataFim = &hoje
+	}
+	return nil
+}
+
+// PodeSerExcluida protege o histórico financeiro: só obras que não começaram
+// ou foram canceladas, e sem parcelas ou contas em aberto, vão para a lixeira.
+func (o *Obra) PodeSerExcluida(situacao SituacaoObra) error {
+	if o.Status != StatusEmPlanejamento && o.Status != StatusCancelada {
+		return &ErroTransicao{Transicao: "excluir", Motivos: []string{
+			fmt.Sprintf("obra com status '%s'; cancele a obra antes de excluí-la", o.Status),
+		}}
+	}
+
+	motivos := []string{}
+	if situacao.ParcelasCronogramaAbertas > 0 {
+		motivos = append(motivos, fmt.Sprintf("%d parcela(s) do cronograma de recebimento em aberto", situacao.ParcelasCronogramaAbertas))
+	}
+	if situacao.ContasReceberAbertas > 0 {
+		motivos = append(motivos, fmt.Sprintf("%d conta(s) a receber em aberto", situacao.ContasReceberAbertas))
+	}
+	if situacao.ContasPagarAbertas > 0 {
+		motivos = append(motivos, fmt.Sprintf("%d conta(s) a pagar em aberto", situacao.ContasPagarAbertas))
+	}
+	if len(motivos) > 0 {
+		return &ErroTransicao{Transicao: "excluir", Motivos: motivos}
+	}
+	return nil
+}
+
+func (o *Obra) motivosBloqueio(t Transicao, situacao SituacaoObra) []string {
+	regra, ok := regrasTransicao[t]
+	if !ok {
+		return []string{fmt.Sprintf("transição '%s' desconhecida", t)}
+	}
+
+	origemValida := false
+	for _, s := range regra.origem {
+		if o.Status == s {
+			origemValida = true
+			break
+		}
+	}
+	if !origemValida {
+		return []string{fmt.Sprintf("obra está '%s'", o.Status)}
+	}
+
+	motivos := []string{}
+	switch t {
+	case TransicaoIniciar:
+		if o.DataAssinaturaContrato == nil {
+			motivos = append(motivos, "contrato ainda não assinado")
+		}
+		if len(situacao.Etapas) == 0 {
+			motivos = append(motivos, "obra não possui etapas")
+		}
+		for _, e := range situacao.Etapas {
+			if e.DataInicioPrevista == nil || e.DataFimPrevista == nil {
+				motivos = append(motivos, fmt.Sprintf("etapa '%s' sem datas previstas", e.Nome))
+			}
+		}
+	case TransicaoConcluir:
+		for _, e := range situacao.Etapas {
+			if e.Status != StatusEtapaConcluida {
+				motivos = append(motivos, fmt.Sprintf("etapa '%s' está '%s'", e.Nome, e.Status))
+			}
+		}
+		if situacao.ParcelasCronogramaAbertas > 0 {
+			motivos = append(motivos, fmt.Sprintf("%d parcela(s) do cronograma de recebimento em aberto", situacao.ParcelasCronogramaAbertas))
+		}
+		if situacao.ContasReceberAbertas > 0 {
+			motivos = append(motivos, fmt.Sprintf("%d conta(s) a receber em aberto", situacao.ContasReceberAbertas))
+		}
+		if situacao.ContasPagarAbertas > 0 {
+			motivos = append(motivos, fmt.Sprintf("%d conta(s) a pagar em aberto", situacao.ContasPagarAbertas))
+		}
+		if situacao.PendenciasBloqueantes > 0 {
+			motivos = append(motivos, fmt.Sprintf("%d pendência(s) bloqueante(s) da vistoria não resolvida(s)", situacao.PendenciasBloqueantes))
+		}
+	}
+	return motivos
+}
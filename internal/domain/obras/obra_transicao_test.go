@@ -0,0 +1,60 @@
+package obras
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPodeSerExcluida(t *testing.T) {
+	casos := []struct {
+		nome     string
+		status   Status
+		situacao SituacaoObra
+		motivos  int
+	}{
+		{nome: "em planejamento sem pendências", status: StatusEmPlanejamento},
+		{nome: "cancelada sem pendências", status: StatusCancelada},
+		{nome: "em andamento", status: StatusEmAndamento, motivos: 1},
+		{nome: "concluída", status: StatusConcluida, motivos: 1},
+		{
+			nome:     "parcela do cronograma em aberto",
+			status:   StatusEmPlanejamento,
+			situacao: SituacaoObra{ParcelasCronogramaAbertas: 2},
+			motivos:  1,
+		},
+		{
+			nome:     "contas em aberto",
+			status:   StatusCancelada,
+			situacao: SituacaoObra{ParcelasCronogramaAbertas: 1, ContasReceberAbertas: 1, ContasPagarAbertas: 3},
+			motivos:  3,
+		},
+		{
+			nome:     "pendência de vistoria não impede",
+			status:   StatusEmPlanejamento,
+			situacao: SituacaoObra{PendenciasBloqueantes: 1},
+		},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			o := &Obra{Status: tc.status}
+			err := o.PodeSerExcluida(tc.situacao)
+			if tc.motivos == 0 {
+				if err != nil {
+					t.Fatalf("erro inesperado: %v", err)
+				}
+				return
+			}
+			var erroTransicao *ErroTransicao
+			if !errors.As(err, &erroTransicao) {
+				t.Fatalf("esperado ErroTransicao, recebido %v", err)
+			}
+			if !errors.Is(err, ErrTransicaoNaoPermitida) {
+				t.Errorf("erro deveria envolver ErrTransicaoNaoPermitida")
+			}
+			if len(erroTransicao.Motivos) != tc.motivos {
+				t.Errorf("motivos = %v, esperado %d", erroTransicao.Motivos, tc.motivos)
+			}
+		})
+	}
+}
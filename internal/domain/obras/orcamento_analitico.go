@@ -0,0 +1,119 @@
+// file: internal/domain/obras/orcamento_analitico.go
+package obras
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CategoriaCusto classifica os custos da obra no orçamento analítico.
+type CategoriaCusto string
+
+const (
+	CategoriaCustoMaterial     CategoriaCusto = "MATERIAL"
+	CategoriaCustoMaoDeObra    CategoriaCusto = "MAO_DE_OBRA"
+	CategoriaCustoServicos     CategoriaCusto = "SERVICOS"
+	CategoriaCustoEquipamentos CategoriaCusto = "EQUIPAMENTOS"
+	CategoriaCustoOutros       CategoriaCusto = "OUTROS" // Custos que não se encaixam nas demais
+)
+
+// Limites de alerta padrão, em percentual do valor previsto consumido.
+const (
+	PercentualAlertaPadrao  = 90.0
+	PercentualCriticoPadrao = 100.0
+)
+
+// NivelAlertaCusto indica o quanto uma linha do orçamento já foi consumida.
+type NivelAlertaCusto string
+
+const (
+	NivelAlertaOK      NivelAlertaCusto = "OK"
+	NivelAlertaAtencao NivelAlertaCusto = "ALERTA"
+	NivelAlertaCritico NivelAlertaCusto = "CRITICO"
+)
+
+var ErrOrcamentoAnaliticoInvalido = errors.New("orçamento analítico inválido")
+
+// OrcamentoAnalitico é a linha de base de custo da obra, por etapa e categoria.
+type OrcamentoAnalitico struct {
+	ObraID            string                    `json:"obraId"`
+	PercentualAlerta  float64                   `json:"percentualAlerta"`
+	PercentualCritico float64                   `json:"percentualCritico"`
+	Itens             []*ItemOrcamentoAnalitico `json:"itens"`
+	UpdatedAt         time.Time                 `json:"updatedAt"`
+}
+
+// ItemOrcamentoAnalitico é o valor previsto para uma categoria de custo em uma etapa.
+// EtapaID nulo representa custos da obra como um todo (ex.: mão de obra apontada).
+type ItemOrcamentoAnalitico struct {
+	ID            string         `json:"id"`
+	EtapaID       *string        `json:"etapaId,omitempty"`
+	Categoria     CategoriaCusto `json:"categoria"`
+	ValorPrevisto float64        `json:"valorPrevisto"`
+}
+
+// ValorPrevistoTotal soma todas as linhas do orçamento.
+func (o *OrcamentoAnalitico) ValorPrevistoTotal() float64 {
+	var total float64
+	for _, item := range o.Itens {
+		total += item.ValorPrevisto
+	}
+	return total
+}
+
+// Validar confere categorias, valores, limites e linhas duplicadas.
+func (o *OrcamentoAnalitico) Validar() error {
+	if o.PercentualAlerta <= 0 || o.PercentualCritico <= 0 {
+		return fmt.Errorf("%w: limites de alerta devem ser positivos", ErrOrcamentoAnaliticoInvalido)
+	}
+	if o.PercentualAlerta > o.PercentualCritico {
+		return fmt.Errorf("%w: percentual de alerta não pode ser maior que o crítico", ErrOrcamentoAnaliticoInvalido)
+	}
+
+	vistos := make(map[string]bool, len(o.Itens))
+	for _, item := range o.Itens {
+		switch item.Categoria {
+		case CategoriaCustoMaterial, CategoriaCustoMaoDeObra, CategoriaCustoServicos, CategoriaCustoEquipamentos, CategoriaCustoOutros:
+		default:
+			return fmt.Errorf("%w: categoria '%s' desconhecida", ErrOrcamentoAnaliticoInvalido, item.Categoria)
+		}
+		if item.ValorPrevisto < 0 {
+			return fmt.Errorf("%w: valor previsto não pode ser negativo", ErrOrcamentoAnaliticoInvalido)
+		}
+		chave := ChaveLinhaCusto(item.EtapaID, item.Categoria)
+		if vistos[chave] {
+			return fmt.Errorf("%w: categoria '%s' repetida na mesma etapa", ErrOrcamentoAnaliticoInvalido, item.Categoria)
+		}
+		vistos[chave] = true
+	}
+	return nil
+}
+
+// NivelAlerta classifica o consumo de uma linha conforme os limites do orçamento.
+// Custo sem valor previsto é sempre crítico.
+func (o *OrcamentoAnalitico) NivelAlerta(previsto, consumido float64) NivelAlertaCusto {
+	if consumido <= 0 {
+		return NivelAlertaOK
+	}
+	if previsto <= 0 {
+		return NivelAlertaCritico
+	}
+	percentual := consumido / previsto * 100
+	switch {
+	case percentual >= o.PercentualCritico:
+		return NivelAlertaCritico
+	case percentual >= o.PercentualAlerta:
+		return NivelAlertaAtencao
+	default:
+		return NivelAlertaOK
+	}
+}
+
+// ChaveLinhaCusto identifica uma linha (etapa + categoria) do orçamento analítico.
+func ChaveLinhaCusto(etapaID *string, categoria CategoriaCusto) string {
+	if etapaID == nil {
+		return "|" + string(categoria)
+	}
+	return *etapaID + "|" + string(categoria)
+}
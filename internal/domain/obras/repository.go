@@ -11,13 +11,22 @@ type ObrasRepository interface {
 	Salvar(ctx context.Context, db db.DBTX, obra *Obra) error // Modificado
 	BuscarPorID(ctx context.Context, id string) (*Obra, error)
 	Deletar(ctx context.Context, id string) error
-	Atualizar(ctx context.Context, obra *Obra) error
+	Atualizar(ctx context.Context, db db.DBTX, obra *Obra) error
 }
 
 type AlocacaoRepository interface {
 	Salvar(ctx context.Context, alocacao *Alocacao) error
 	SalvarMuitos(ctx context.Context, alocacoes []*Alocacao) error
 	ExistemAlocacoesAtivasParaFuncionario(ctx context.Context, funcionarioID string) (bool, error) // NOVO
+	ListarPorObraID(ctx context.Context, obraID string) ([]*Alocacao, error)
+	ListarPorFuncionarioID(ctx context.Context, funcionarioID string) ([]*Alocacao, error)
+	ListarAtivasDoFuncionario(ctx context.Context, dbtx db.DBTX, funcionarioID string, data time.Time) ([]*Alocacao, error)
+	BuscarPorID(ctx context.Context, id string) (*Alocacao, error)
+	Atualizar(ctx context.Context, alocacao *Alocacao) error
+	AtualizarMuitas(ctx context.Context, dbtx db.DBTX, alocacoes []*Alocacao) error
+	DeletarMuitas(ctx context.Context, dbtx db.DBTX, ids []string) error
+	Transferir(ctx context.Context, encerrada, nova *Alocacao) error
+	ListarNoPeriodo(ctx context.Context, inicio, fim time.Time) ([]*Alocacao, error)
 
 }
 
@@ -29,6 +38,87 @@ type EtapaRepository interface {
 
 }
 
+type DependenciaEtapaRepository interface {
+	Salvar(ctx context.Context, dependencia *DependenciaEtapa) error
+	SalvarMuitos(ctx context.Context, db db.DBTX, dependencias []*DependenciaEtapa) error
+	BuscarPorID(ctx context.Context, id string) (*DependenciaEtapa, error)
+	ListarPorObraID(ctx context.Context, obraID string) ([]*DependenciaEtapa, error)
+	Deletar(ctx context.Context, id string) error
+}
+
+// EfetivoPlanejadoRepository guarda o efetivo que cada etapa da obra precisa, por cargo.
+type EfetivoPlanejadoRepository interface {
+	Salvar(ctx context.Context, obraID string, itens []*EfetivoPlanejado) error // Substitui o da obra
+	ListarPorObraID(ctx context.Context, obraID string) ([]*EfetivoPlanejado, error)
+}
+
+type OrcamentoAnaliticoRepository interface {
+	Salvar(ctx context.Context, orcamento *OrcamentoAnalitico) error
+	Criar(ctx context.Context, db db.DBTX, orcamento *OrcamentoAnalitico) error // Orçamento de obra nova, na transação dela
+	BuscarPorObraID(ctx context.Context, obraID string) (*OrcamentoAnalitico, error)
+}
+
+type DiarioObraRepository interface {
+	Salvar(ctx context.Context, diario *DiarioObra) error
+	Atualizar(ctx context.Context, diario *DiarioObra) error
+	BuscarPorID(ctx context.Context, id string) (*DiarioObra, error)
+	ExisteNaData(ctx context.Context, obraID string, data time.Time) (bool, error)
+	ListarPorPeriodo(ctx context.Context, obraID string, inicio, fim *time.Time) ([]*DiarioObra, error)
+}
+
+// ChecklistQualidadeRepository guarda os modelos de FVS do catálogo e as FVS
+// instanciadas nas etapas das obras.
+type ChecklistQualidadeRepository interface {
+	SalvarModelo(ctx context.Context, modelo *ModeloChecklist) error
+	AtualizarModelo(ctx context.Context, modelo *ModeloChecklist) error
+	BuscarModeloPorID(ctx context.Context, id string) (*ModeloChecklist, error)
+	ListarModelos(ctx context.Context, etapaPadraoID string) ([]*ModeloChecklist, error)
+	DeletarModelo(ctx context.Context, id string) error
+	SalvarChecklists(ctx context.Context, db db.DBTX, checklists []*ChecklistEtapa) error
+	AtualizarChecklist(ctx context.Context, checklist *ChecklistEtapa) error
+	BuscarChecklistPorID(ctx context.Context, id string) (*ChecklistEtapa, error)
+	ListarChecklistsPorEtapaID(ctx context.Context, etapaID string) ([]*ChecklistEtapa, error)
+	ListarChecklistsPorObraID(ctx context.Context, obraID string) ([]*ChecklistEtapa, error)
+	DeletarChecklist(ctx context.Context, id string) error
+}
+
+// VistoriaRepository guarda as rodadas de vistoria e a lista de pendências da obra.
+type VistoriaRepository interface {
+	Salvar(ctx context.Context, vistoria *Vistoria) error
+	Atualizar(ctx context.Context, vistoria *Vistoria) error
+	BuscarPorID(ctx context.Context, id string) (*Vistoria, error)
+	ListarPorObraID(ctx context.Context, obraID string) ([]*Vistoria, error)
+	SalvarPendencia(ctx context.Context, pendencia *PendenciaVistoria) error
+	AtualizarPendencia(ctx context.Context, pendencia *PendenciaVistoria) error
+	BuscarPendenciaPorID(ctx context.Context, id string) (*PendenciaVistoria, error)
+	ListarPendenciasPorObraID(ctx context.Context, obraID string) ([]*PendenciaVistoria, error)
+	DeletarPendencia(ctx context.Context, id string) error
+}
+
+type MedicaoRepository interface {
+	Salvar(ctx context.Context, medicao *Medicao) error
+	Atualizar(ctx context.Context, db db.DBTX, medicao *Medicao) error
+	BuscarPorID(ctx context.Context, id string) (*Medicao, error)
+	ListarPorObraID(ctx context.Context, obraID string) ([]*Medicao, error)
+	Deletar(ctx context.Context, id string) error
+}
+
+// AditivoRepository grava o aditivo junto com o contrato resultante da obra.
+type AditivoRepository interface {
+	Salvar(ctx context.Context, db db.DBTX, aditivo *Aditivo) error
+	ListarPorObraID(ctx context.Context, obraID string) ([]*Aditivo, error)
+}
+
+// ModeloObraRepository guarda os modelos de obra escolhidos na criação.
+type ModeloObraRepository interface {
+	Salvar(ctx context.Context, modelo *ModeloObra) error
+	Atualizar(ctx context.Context, modelo *ModeloObra) error
+	BuscarPorID(ctx context.Context, id string) (*ModeloObra, error)
+	BuscarPorNome(ctx context.Context, nome string) (*ModeloObra, error)
+	ListarTodos(ctx context.Context) ([]*ModeloObra, error)
+	Deletar(ctx context.Context, id string) error
+}
+
 type EtapaPadraoRepository interface {
 	Salvar(ctx context.Context, etapa *EtapaPadrao) error
 	Atualizar(ctx context.Context, etapa *EtapaPadrao) error
@@ -44,5 +134,5 @@ type CronogramaRecebimentoRepository interface {
 	BuscarPorID(ctx context.Context, id string) (*CronogramaRecebimento, error)
 	ListarPorObraID(ctx context.Context, obraID string) ([]*CronogramaRecebimento, error)
 	ListarVencidosPorPeriodo(ctx context.Context, dataInicio, dataFim time.Time) ([]*CronogramaRecebimento, error)
-	Deletar(ctx context.Context, id string) error
+	Deletar(ctx context.Context, db db.DBTX, id string) error
 }
@@ -0,0 +1,233 @@
+// file: internal/domain/obras/vistoria.go
+package obras
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StatusVistoria indica se a rodada de vistoria ainda aceita apontamentos.
+type StatusVistoria string
+
+const (
+	StatusVistoriaAberta    StatusVistoria = "ABERTA"
+	StatusVistoriaEncerrada StatusVistoria = "ENCERRADA"
+)
+
+// StatusPendencia acompanha uma pendência da vistoria até a aprovação na revistoria.
+type StatusPendencia string
+
+const (
+	StatusPendenciaAberta    StatusPendencia = "ABERTA"    // Apontada ou reprovada na revistoria
+	StatusPendenciaCorrigida StatusPendencia = "CORRIGIDA" // Responsável informou a correção; aguarda revistoria
+	StatusPendenciaResolvida StatusPendencia = "RESOLVIDA" // Aprovada em uma revistoria
+)
+
+var (
+	ErrVistoriaInvalida         = errors.New("vistoria inválida")
+	ErrVistoriaEncerrada        = errors.New("vistoria encerrada não aceita alterações")
+	ErrPendenciaInvalida        = errors.New("pendência de vistoria inválida")
+	ErrPendenciaResolvida       = errors.New("pendência já resolvida não pode ser alterada")
+	ErrTransicaoPendencia       = errors.New("transição de status da pendência inválida")
+	ErrVerificacaoMesmaVistoria = errors.New("a pendência só pode ser verificada em uma revistoria posterior à que a apontou")
+)
+
+// Vistoria é uma rodada de inspeção da obra. A primeira aponta as pendências; as
+// seguintes (revistorias) verificam as correções e podem apontar novas.
+type Vistoria struct {
+	ID          string         `json:"id"`
+	ObraID      string         `json:"obraId"`
+	Numero      int            `json:"numero"`
+	Data        time.Time      `json:"data"`
+	Vistoriador string         `json:"vistoriador"`
+	Observacoes string         `json:"observacoes"`
+	Status      StatusVistoria `json:"status"`
+	CriadoPor   string         `json:"criadoPor"`
+	EncerradaEm *time.Time     `json:"encerradaEm,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+// PendenciaVistoria é um item da lista de pendências (punch list) da obra.
+// Pendências bloqueantes impedem a conclusão da obra até serem resolvidas.
+type PendenciaVistoria struct {
+	ID           string                 `json:"id"`
+	ObraID       string                 `json:"obraId"`
+	VistoriaID   string                 `json:"vistoriaId"` // Rodada em que foi apontada
+	Descricao    string                 `json:"descricao"`
+	Local        string                 `json:"local"`
+	EtapaID      *string                `json:"etapaId,omitempty"`
+	Responsavel  string                 `json:"responsavel"`
+	FotoURL      string                 `json:"fotoUrl,omitempty"`
+	Prazo        *time.Time             `json:"prazo,omitempty"`
+	Bloqueante   bool                   `json:"bloqueante"`
+	Status       StatusPendencia        `json:"status"`
+	CorrigidaEm  *time.Time             `json:"corrigidaEm,omitempty"`
+	ResolvidaEm  *time.Time             `json:"resolvidaEm,omitempty"`
+	Verificacoes []VerificacaoPendencia `json:"verificacoes"`
+	CreatedAt    time.Time              `json:"createdAt"`
+	UpdatedAt    time.Time              `json:"updatedAt"`
+}
+
+// VerificacaoPendencia registra o resultado da pendência em uma revistoria.
+type VerificacaoPendencia struct {
+	VistoriaID     string    `json:"vistoriaId"`
+	NumeroVistoria int       `json:"numeroVistoria"`
+	Aprovada       bool      `json:"aprovada"`
+	Observacao     string    `json:"observacao"`
+	VerificadoPor  string    `json:"verificadoPor"`
+	Data           time.Time `json:"data"`
+}
+
+// DadosPendencia são os campos editáveis da pendência.
+type DadosPendencia struct {
+	Descricao   string
+	Local       string
+	EtapaID     *string
+	Responsavel string
+	FotoURL     string
+	Prazo       *time.Time
+	Bloqueante  bool
+}
+
+// NovaVistoria abre a rodada de vistoria de número informado.
+func NovaVistoria(id, obraID string, numero int, data time.Time, vistoriador, observacoes, criadoPor string, agora time.Time) (*Vistoria, error) {
+	vistoriador = strings.TrimSpace(vistoriador)
+	if vistoriador == "" {
+		return nil, fmt.Errorf("%w: vistoriador é obrigatório", ErrVistoriaInvalida)
+	}
+	return &Vistoria{
+		ID:          id,
+		ObraID:      obraID,
+		Numero:      numero,
+		Data:        dia(data),
+		Vistoriador: vistoriador,
+		Observacoes: strings.TrimSpace(observacoes),
+		Status:      StatusVistoriaAberta,
+		CriadoPor:   criadoPor,
+		CreatedAt:   agora,
+		UpdatedAt:   agora,
+	}, nil
+}
+
+// Aberta informa se a vistoria ainda aceita apontamentos e verificações.
+func (v *Vistoria) Aberta() bool {
+	return v.Status == StatusVistoriaAberta
+}
+
+// Encerrar fecha a rodada; novas pendências e verificações vão para a próxima.
+func (v *Vistoria) Encerrar(agora time.Time) error {
+	if !v.Aberta() {
+		return ErrVistoriaEncerrada
+	}
+	v.Status = StatusVistoriaEncerrada
+	v.EncerradaEm = &agora
+	v.UpdatedAt = agora
+	return nil
+}
+
+// NovaPendencia aponta uma pendência na vistoria aberta.
+func NovaPendencia(id string, vistoria *Vistoria, dados DadosPendencia, agora time.Time) (*PendenciaVistoria, error) {
+	if !vistoria.Aberta() {
+		return nil, ErrVistoriaEncerrada
+	}
+	p := &PendenciaVistoria{
+		ID:           id,
+		ObraID:       vistoria.ObraID,
+		VistoriaID:   vistoria.ID,
+		Status:       StatusPendenciaAberta,
+		Verificacoes: []VerificacaoPendencia{},
+		CreatedAt:    agora,
+	}
+	if err := p.AtualizarDados(dados, agora); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// AtualizarDados altera descrição, local, responsável, foto, prazo e se bloqueia a entrega.
+func (p *PendenciaVistoria) AtualizarDados(dados DadosPendencia, agora time.Time) error {
+	if p.Status == StatusPendenciaResolvida {
+		return ErrPendenciaResolvida
+	}
+	dados.Descricao = strings.TrimSpace(dados.Descricao)
+	dados.Local = strings.TrimSpace(dados.Local)
+	if dados.Descricao == "" || dados.Local == "" {
+		return fmt.Errorf("%w: descrição e local são obrigatórios", ErrPendenciaInvalida)
+	}
+	p.Descricao = dados.Descricao
+	p.Local = dados.Local
+	p.EtapaID = dados.EtapaID
+	p.Responsavel = strings.TrimSpace(dados.Responsavel)
+	p.FotoURL = strings.TrimSpace(dados.FotoURL)
+	if dados.Prazo != nil {
+		prazo := dia(*dados.Prazo)
+		dados.Prazo = &prazo
+	}
+	p.Prazo = dados.Prazo
+	p.Bloqueante = dados.Bloqueante
+	p.UpdatedAt = agora
+	return nil
+}
+
+// MarcarCorrigida registra que o responsável corrigiu a pendência; ela continua
+// em aberto até ser aprovada em uma revistoria.
+func (p *PendenciaVistoria) MarcarCorrigida(agora time.Time) error {
+	if p.Status != StatusPendenciaAberta {
+		return fmt.Errorf("%w: pendência está '%s'", ErrTransicaoPendencia, p.Status)
+	}
+	p.Status = StatusPendenciaCorrigida
+	p.CorrigidaEm = &agora
+	p.UpdatedAt = agora
+	return nil
+}
+
+// Verificar registra o resultado da pendência em uma revistoria aberta: aprovada,
+// fica resolvida; reprovada, volta a ficar aberta.
+func (p *PendenciaVistoria) Verificar(vistoria *Vistoria, aprovada bool, observacao, usuarioID string, agora time.Time) error {
+	if p.Status == StatusPendenciaResolvida {
+		return ErrPendenciaResolvida
+	}
+	if !vistoria.Aberta() {
+		return ErrVistoriaEncerrada
+	}
+	if vistoria.ID == p.VistoriaID {
+		return ErrVerificacaoMesmaVistoria
+	}
+
+	p.Verificacoes = append(p.Verificacoes, VerificacaoPendencia{
+		VistoriaID:     vistoria.ID,
+		NumeroVistoria: vistoria.Numero,
+		Aprovada:       aprovada,
+		Observacao:     strings.TrimSpace(observacao),
+		VerificadoPor:  usuarioID,
+		Data:           agora,
+	})
+	if aprovada {
+		p.Status = StatusPendenciaResolvida
+		p.ResolvidaEm = &agora
+	} else {
+		p.Status = StatusPendenciaAberta
+		p.CorrigidaEm = nil
+	}
+	p.UpdatedAt = agora
+	return nil
+}
+
+// Atrasada indica se o prazo de correção já passou sem a pendência ser resolvida.
+func (p *PendenciaVistoria) Atrasada(hoje time.Time) bool {
+	return p.Status != StatusPendenciaResolvida && p.Prazo != nil && p.Prazo.Before(dia(hoje))
+}
+
+// ContarBloqueantesEmAberto conta as pendências que ainda impedem a conclusão da obra.
+func ContarBloqueantesEmAberto(pendencias []*PendenciaVistoria) int {
+	total := 0
+	for _, p := range pendencias {
+		if p.Bloqueante && p.Status != StatusPendenciaResolvida {
+			total++
+		}
+	}
+	return total
+}
@@ -11,9 +11,9 @@ import (
 type FuncionarioRepository interface {
 	Salvar(ctx context.Context, funcionario *Funcionario) error
 	BuscarPorID(ctx context.Context, funcionarioID string) (*Funcionario, error)
-	Deletar(ctx context.Context, id string) error // NOVO
+	Deletar(ctx context.Context, dbtx db.DBTX, id string) error // NOVO
 	Listar(ctx context.Context) ([]*Funcionario, error)
-	Atualizar(ctx context.Context, funcionario *Funcionario) error // NOVO
+	Atualizar(ctx context.Context, dbtx db.DBTX, funcionario *Funcionario) error // NOVO
 	AtivarFuncionario(ctx context.Context, id string) error
 }
 
@@ -0,0 +1,74 @@
+// file: internal/domain/portal/link.go
+package portal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// ValidadePadrao é usada quando o link é criado sem validade informada.
+	ValidadePadrao = 30 * 24 * time.Hour
+	// ValidadeMaxima limita por quanto tempo um link pode ficar aberto.
+	ValidadeMaxima = 180 * 24 * time.Hour
+)
+
+var (
+	ErrLinkInvalido = errors.New("link de compartilhamento inválido")
+	ErrLinkExpirado = errors.New("link de compartilhamento expirado")
+	ErrLinkRevogado = errors.New("link de compartilhamento revogado")
+)
+
+// LinkCompartilhamento dá ao cliente acesso somente leitura a uma obra, sem login.
+// O token enviado ao cliente é assinado e carrega o ID e a expiração do link; o
+// registro existe para permitir a revogação e acompanhar os acessos.
+type LinkCompartilhamento struct {
+	ID             string     `json:"id"`
+	ObraID         string     `json:"obraId"`
+	Descricao      string     `json:"descricao"`
+	CriadoPor      string     `json:"criadoPor"`
+	ExpiraEm       time.Time  `json:"expiraEm"`
+	RevogadoEm     *time.Time `json:"revogadoEm,omitempty"`
+	UltimoAcessoEm *time.Time `json:"ultimoAcessoEm,omitempty"`
+	TotalAcessos   int        `json:"totalAcessos"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// NovoLink cria um link válido por validade a partir de agora. Validade zero usa a padrão.
+func NovoLink(id, obraID, descricao, criadoPor string, validade time.Duration, agora time.Time) (*LinkCompartilhamento, error) {
+	if validade == 0 {
+		validade = ValidadePadrao
+	}
+	if validade < 0 || validade > ValidadeMaxima {
+		return nil, fmt.Errorf("%w: a validade deve ser de até %d dias", ErrLinkInvalido, int(ValidadeMaxima.Hours()/24))
+	}
+	return &LinkCompartilhamento{
+		ID:        id,
+		ObraID:    obraID,
+		Descricao: descricao,
+		CriadoPor: criadoPor,
+		ExpiraEm:  agora.Add(validade).Truncate(time.Second),
+		CreatedAt: agora,
+	}, nil
+}
+
+// VerificarAcesso indica por que o link não pode mais ser usado, ou nil se estiver ativo.
+func (l *LinkCompartilhamento) VerificarAcesso(agora time.Time) error {
+	if l.RevogadoEm != nil {
+		return ErrLinkRevogado
+	}
+	if !agora.Before(l.ExpiraEm) {
+		return ErrLinkExpirado
+	}
+	return nil
+}
+
+// Revogar encerra o link antes da expiração.
+func (l *LinkCompartilhamento) Revogar(agora time.Time) error {
+	if l.RevogadoEm != nil {
+		return ErrLinkRevogado
+	}
+	l.RevogadoEm = &agora
+	return nil
+}
@@ -0,0 +1,17 @@
+// file: internal/domain/portal/repository.go
+package portal
+
+import (
+	"context"
+	"time"
+)
+
+// LinkRepository define o contrato para persistência dos links de compartilhamento.
+type LinkRepository interface {
+	Salvar(ctx context.Context, link *LinkCompartilhamento) error
+	BuscarPorID(ctx context.Context, id string) (*LinkCompartilhamento, error)
+	ListarPorObraID(ctx context.Context, obraID string) ([]*LinkCompartilhamento, error)
+	Revogar(ctx context.Context, id string, em time.Time) error
+	// RegistrarAcesso incrementa o contador de acessos e grava o horário do último.
+	RegistrarAcesso(ctx context.Context, id string, em time.Time) error
+}
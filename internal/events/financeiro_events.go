@@ -24,6 +24,7 @@ type ContaReceberCriadaPayload struct {
 	ContaReceberID          string     `json:"contaReceberId"`
 	ObraID                  *string    `json:"obraId,omitempty"`
 	CronogramaRecebimentoID *string    `json:"cronogramaRecebimentoId,omitempty"`
+	ClienteID               *string    `json:"clienteId,omitempty"`
 	Cliente                 string     `json:"cliente"`
 	TipoContaReceber        string     `json:"tipoContaReceber"`
 	Descricao               string     `json:"descricao"`
@@ -17,6 +17,9 @@ const (
 	
 	// Evento disparado quando um recebimento é realizado
 	RecebimentoRealizado = "obra:recebimento_realizado"
+
+	// Evento disparado quando a obra muda de status por uma transição de ciclo de vida
+	ObraStatusAlterado = "obra:status_alterado"
 )
 
 // ObraContratoDefinidoPayload contém os dados do evento de contrato definido
@@ -35,11 +38,22 @@ type CronogramaRecebimentoCriadoPayload struct {
 	ObraID             string                     `json:"obraId"`
 	ObraNome           string                     `json:"obraNome"`
 	Cliente            string                     `json:"cliente"`
+	ClienteID          *string                    `json:"clienteId,omitempty"`
 	CronogramasIds     []string                   `json:"cronogramasIds"`
 	ValorTotalPrevisto float64                    `json:"valorTotalPrevisto"`
 	QuantidadeEtapas   int                        `json:"quantidadeEtapas"`
 	PrimeiroVencimento time.Time                  `json:"primeiroVencimento"`
+	PercentualRetencao float64                    `json:"percentualRetencao"` // Caução a reter do valor de cada parcela
 	UsuarioID          string                     `json:"usuarioId"`
+	Parcelas           []ParcelaCronogramaPayload `json:"parcelas,omitempty"` // Opcional: valor e vencimento de cada parcela criada
+}
+
+// ParcelaCronogramaPayload detalha uma parcela do cronograma de recebimento criado
+type ParcelaCronogramaPayload struct {
+	CronogramaID   string    `json:"cronogramaId"`
+	Descricao      string    `json:"descricao"`
+	Valor          float64   `json:"valor"`
+	DataVencimento time.Time `json:"dataVencimento"`
 }
 
 // EtapaRecebimentoVencidaPayload contém dados da etapa vencida
@@ -68,4 +82,19 @@ type RecebimentoRealizadoPayload struct {
 	Descricao               string     `json:"descricao"`
 	ContaBancariaID         *string    `json:"contaBancariaId,omitempty"` // Onde foi depositado
 	UsuarioID               string     `json:"usuarioId"` // Quem registrou o recebimento
-}
\ No newline at end of file
+}
+
+// ObraStatusAlteradoPayload contém dados da transição de status da obra
+type ObraStatusAlteradoPayload struct {
+	ObraID                  string    `json:"obraId"`
+	ObraNome                string    `json:"obraNome"`
+	Transicao               string    `json:"transicao"`
+	StatusAnterior          string    `json:"statusAnterior"`
+	NovoStatus              string    `json:"novoStatus"`
+	Motivo                  string    `json:"motivo,omitempty"`
+	CronogramasCancelados   int       `json:"cronogramasCancelados,omitempty"`
+	ContasReceberCanceladas int       `json:"contasReceberCanceladas,omitempty"`
+	ContasPagarCanceladas   int       `json:"contasPagarCanceladas,omitempty"`
+	UsuarioID               string    `json:"usuarioId"`
+	DataAlteracao           time.Time `json:"dataAlteracao"`
+}
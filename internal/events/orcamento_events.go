@@ -10,11 +10,11 @@ const (
 
 // OrcamentoStatusAtualizadoPayload contém os dados que queremos enviar no evento.
 type OrcamentoStatusAtualizadoPayload struct {
-	OrcamentoID   string
-	EtapaID       string
-	StatusAnterior string  // Status antes da mudança
-	NovoStatus    string  // Status após a mudança
-	Valor         float64
+	OrcamentoID    string  `json:"orcamentoId"`
+	EtapaID        string  `json:"etapaId"`
+	StatusAnterior string  `json:"statusAnterior"` // Status antes da mudança
+	NovoStatus     string  `json:"novoStatus"`     // Status após a mudança
+	Valor          float64 `json:"valor"`
 }
 
 // OrcamentoExcluidoPayload contém dados do orçamento excluído
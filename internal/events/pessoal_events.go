@@ -24,10 +24,10 @@ type ApontamentoAprovadoPayload struct {
 
 // PagamentoApontamentoRealizadoPayload são os dados que o evento carrega.
 type PagamentoApontamentoRealizadoPayload struct {
-	FuncionarioID     string
-	ObraID            string
-	PeriodoReferencia string
-	ValorCalculado    float64
-	DataDeEfetivacao  time.Time
-	ContaBancariaID   string
+	FuncionarioID     string    `json:"funcionarioId"`
+	ObraID            string    `json:"obraId"`
+	PeriodoReferencia string    `json:"periodoReferencia"`
+	ValorCalculado    float64   `json:"valorCalculado"`
+	DataDeEfetivacao  time.Time `json:"dataDeEfetivacao"`
+	ContaBancariaID   string    `json:"contaBancariaId"`
 }
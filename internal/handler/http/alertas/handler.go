@@ -0,0 +1,174 @@
+// file: internal/handler/http/alertas/handler.go
+package alertas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/alertas"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/service/alertas/dto"
+)
+
+// Service define a interface do serviço de alertas
+type Service interface {
+	ListarRegras(ctx context.Context) ([]*dto.RegraAlertaOutput, error)
+	AtualizarRegra(ctx context.Context, tipo string, input dto.AtualizarRegraAlertaInput) (*dto.RegraAlertaOutput, error)
+	Avaliar(ctx context.Context) (*dto.ResultadoAvaliacaoOutput, error)
+	ListarAlertas(ctx context.Context, filtro alertas.FiltroAlertas) ([]*alertas.Alerta, error)
+	ReconhecerAlerta(ctx context.Context, id string) (*alertas.Alerta, error)
+	AdiarAlerta(ctx context.Context, id string, input dto.AdiarAlertaInput) (*alertas.Alerta, error)
+}
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NovoAlertasHandler(s Service, l *slog.Logger) *Handler {
+	return &Handler{service: s, logger: l.With("handler", "alertas")}
+}
+
+// HandleListarRegras lista a regra em vigor para cada tipo de alerta
+func (h *Handler) HandleListarRegras(w http.ResponseWriter, r *http.Request) {
+	regras, err := h.service.ListarRegras(r.Context())
+	if err != nil {
+		h.responderErro(w, r, "falha ao listar regras de alerta", err)
+		return
+	}
+	web.Respond(w, r, regras, http.StatusOK)
+}
+
+// HandleAtualizarRegra personaliza limite, severidade ou ativação de um tipo de alerta
+func (h *Handler) HandleAtualizarRegra(w http.ResponseWriter, r *http.Request) {
+	var input dto.AtualizarRegraAlertaInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	regra, err := h.service.AtualizarRegra(r.Context(), chi.URLParam(r, "tipo"), input)
+	if err != nil {
+		h.responderErro(w, r, "falha ao atualizar regra de alerta", err)
+		return
+	}
+	web.Respond(w, r, regra, http.StatusOK)
+}
+
+// HandleAvaliar executa imediatamente todas as regras de alerta
+func (h *Handler) HandleAvaliar(w http.ResponseWriter, r *http.Request) {
+	resultado, err := h.service.Avaliar(r.Context())
+	if err != nil {
+		h.responderErro(w, r, "falha ao avaliar alertas", err)
+		return
+	}
+	web.Respond(w, r, resultado, http.StatusOK)
+}
+
+// HandleListarAlertas lista os alertas, filtrando por status, tipo, severidade e obra
+func (h *Handler) HandleListarAlertas(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var filtro alertas.FiltroAlertas
+
+	if v := query.Get("status"); v != "" {
+		for _, s := range strings.Split(v, ",") {
+			status := alertas.StatusAlerta(strings.TrimSpace(s))
+			switch status {
+			case alertas.StatusAtivo, alertas.StatusReconhecido, alertas.StatusAdiado, alertas.StatusResolvido:
+				filtro.Status = append(filtro.Status, status)
+			default:
+				web.RespondError(w, r, "FILTRO_INVALIDO", "Status deve ser ATIVO, RECONHECIDO, ADIADO ou RESOLVIDO", http.StatusBadRequest)
+				return
+			}
+		}
+	}
+	if v := query.Get("tipo"); v != "" {
+		tipo := alertas.TipoAlerta(v)
+		if !tipo.Valido() {
+			web.RespondError(w, r, "FILTRO_INVALIDO", alertas.ErrTipoAlertaInvalido.Error(), http.StatusBadRequest)
+			return
+		}
+		filtro.Tipo = &tipo
+	}
+	if v := query.Get("severidade"); v != "" {
+		severidade := alertas.Severidade(v)
+		if !severidade.Valida() {
+			web.RespondError(w, r, "FILTRO_INVALIDO", "Severidade deve ser INFO, ATENCAO ou CRITICA", http.StatusBadRequest)
+			return
+		}
+		filtro.Severidade = &severidade
+	}
+	if v := query.Get("obraId"); v != "" {
+		filtro.ObraID = &v
+	}
+	if v := query.Get("limite"); v != "" {
+		limite, err := strconv.Atoi(v)
+		if err != nil || limite < 0 {
+			web.RespondError(w, r, "FILTRO_INVALIDO", "Limite deve ser um número positivo", http.StatusBadRequest)
+			return
+		}
+		filtro.Limite = limite
+	}
+
+	lista, err := h.service.ListarAlertas(r.Context(), filtro)
+	if err != nil {
+		h.responderErro(w, r, "falha ao listar alertas", err)
+		return
+	}
+	web.Respond(w, r, lista, http.StatusOK)
+}
+
+// HandleReconhecerAlerta registra a ciência do usuário logado sobre o alerta
+func (h *Handler) HandleReconhecerAlerta(w http.ResponseWriter, r *http.Request) {
+	alerta, err := h.service.ReconhecerAlerta(r.Context(), chi.URLParam(r, "alertaId"))
+	if err != nil {
+		h.responderErro(w, r, "falha ao reconhecer alerta", err)
+		return
+	}
+	web.Respond(w, r, alerta, http.StatusOK)
+}
+
+// HandleAdiarAlerta oculta o alerta até a data informada
+func (h *Handler) HandleAdiarAlerta(w http.ResponseWriter, r *http.Request) {
+	var input dto.AdiarAlertaInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	alerta, err := h.service.AdiarAlerta(r.Context(), chi.URLParam(r, "alertaId"), input)
+	if err != nil {
+		h.responderErro(w, r, "falha ao adiar alerta", err)
+		return
+	}
+	web.Respond(w, r, alerta, http.StatusOK)
+}
+
+func (h *Handler) responderErro(w http.ResponseWriter, r *http.Request, msg string, err error) {
+	var erroData *time.ParseError
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "ALERTA_NAO_ENCONTRADO", "Alerta não encontrado", http.StatusNotFound)
+	case errors.Is(err, alertas.ErrTipoAlertaInvalido):
+		web.RespondError(w, r, "TIPO_ALERTA_INVALIDO", err.Error(), http.StatusNotFound)
+	case errors.Is(err, alertas.ErrRegraInvalida):
+		web.RespondError(w, r, "REGRA_INVALIDA", err.Error(), http.StatusBadRequest)
+	case errors.As(err, &erroData):
+		web.RespondError(w, r, "DATA_INVALIDA", "Data inválida, use o formato AAAA-MM-DD", http.StatusBadRequest)
+	case errors.Is(err, alertas.ErrAdiamentoPassado):
+		web.RespondError(w, r, "ADIAMENTO_INVALIDO", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, alertas.ErrAlertaResolvido):
+		web.RespondError(w, r, "ALERTA_RESOLVIDO", err.Error(), http.StatusConflict)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno no servidor", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,142 @@
+// file: internal/handler/http/clientes/handler.go
+package clientes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/clientes"
+	"github.com/luiszkm/masterCostrutora/internal/domain/common"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	clientes_service "github.com/luiszkm/masterCostrutora/internal/service/clientes"
+	"github.com/luiszkm/masterCostrutora/internal/service/clientes/dto"
+)
+
+// Service define a interface do serviço de clientes
+type Service interface {
+	CadastrarCliente(ctx context.Context, input dto.ClienteInput) (*clientes.Cliente, error)
+	AtualizarCliente(ctx context.Context, id string, input dto.ClienteInput) (*clientes.Cliente, error)
+	BuscarCliente(ctx context.Context, id string) (*clientes.Cliente, error)
+	ListarClientes(ctx context.Context, busca string, filtros common.ListarFiltros) (*common.RespostaPaginada[*clientes.Cliente], error)
+	DeletarCliente(ctx context.Context, id string) error
+	MesclarClientes(ctx context.Context, id string, input dto.MesclarClienteInput) (*clientes.Cliente, error)
+	ObterExtrato(ctx context.Context, id string) (*dto.ExtratoClienteDTO, error)
+}
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NovoClientesHandler(s Service, l *slog.Logger) *Handler {
+	return &Handler{service: s, logger: l.With("handler", "clientes")}
+}
+
+// HandleCadastrarCliente cadastra um cliente PF ou PJ
+func (h *Handler) HandleCadastrarCliente(w http.ResponseWriter, r *http.Request) {
+	var input dto.ClienteInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	cliente, err := h.service.CadastrarCliente(r.Context(), input)
+	if err != nil {
+		h.responderErro(w, r, "falha ao cadastrar cliente", err)
+		return
+	}
+	web.Respond(w, r, cliente, http.StatusCreated)
+}
+
+// HandleListarClientes lista os clientes; ?busca= filtra por nome ou documento
+func (h *Handler) HandleListarClientes(w http.ResponseWriter, r *http.Request) {
+	resposta, err := h.service.ListarClientes(r.Context(), r.URL.Query().Get("busca"), web.ParseFiltros(r))
+	if err != nil {
+		h.responderErro(w, r, "falha ao listar clientes", err)
+		return
+	}
+	web.Respond(w, r, resposta, http.StatusOK)
+}
+
+// HandleBuscarCliente busca um cliente por ID
+func (h *Handler) HandleBuscarCliente(w http.ResponseWriter, r *http.Request) {
+	cliente, err := h.service.BuscarCliente(r.Context(), chi.URLParam(r, "clienteId"))
+	if err != nil {
+		h.responderErro(w, r, "falha ao buscar cliente", err)
+		return
+	}
+	web.Respond(w, r, cliente, http.StatusOK)
+}
+
+// HandleAtualizarCliente substitui os dados do cliente
+func (h *Handler) HandleAtualizarCliente(w http.ResponseWriter, r *http.Request) {
+	var input dto.ClienteInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	cliente, err := h.service.AtualizarCliente(r.Context(), chi.URLParam(r, "clienteId"), input)
+	if err != nil {
+		h.responderErro(w, r, "falha ao atualizar cliente", err)
+		return
+	}
+	web.Respond(w, r, cliente, http.StatusOK)
+}
+
+// HandleDeletarCliente exclui um cliente sem obras nem contas vinculadas
+func (h *Handler) HandleDeletarCliente(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeletarCliente(r.Context(), chi.URLParam(r, "clienteId")); err != nil {
+		h.responderErro(w, r, "falha ao deletar cliente", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleMesclarClientes incorpora um cliente duplicado ao cliente da rota
+func (h *Handler) HandleMesclarClientes(w http.ResponseWriter, r *http.Request) {
+	var input dto.MesclarClienteInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	cliente, err := h.service.MesclarClientes(r.Context(), chi.URLParam(r, "clienteId"), input)
+	if err != nil {
+		h.responderErro(w, r, "falha ao mesclar clientes", err)
+		return
+	}
+	web.Respond(w, r, cliente, http.StatusOK)
+}
+
+// HandleObterExtrato retorna obras, contas a receber e comportamento de pagamento do cliente
+func (h *Handler) HandleObterExtrato(w http.ResponseWriter, r *http.Request) {
+	extrato, err := h.service.ObterExtrato(r.Context(), chi.URLParam(r, "clienteId"))
+	if err != nil {
+		h.responderErro(w, r, "falha ao gerar extrato do cliente", err)
+		return
+	}
+	web.Respond(w, r, extrato, http.StatusOK)
+}
+
+func (h *Handler) responderErro(w http.ResponseWriter, r *http.Request, msg string, err error) {
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "NAO_ENCONTRADO", "Cliente não encontrado", http.StatusNotFound)
+	case errors.Is(err, clientes.ErrClienteInvalido), errors.Is(err, clientes.ErrDocumentoInvalido),
+		errors.Is(err, clientes.ErrMesclagemInvalida):
+		web.RespondError(w, r, "DADOS_INVALIDOS", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, clientes_service.ErrDocumentoDuplicado):
+		web.RespondError(w, r, "DOCUMENTO_DUPLICADO", err.Error(), http.StatusConflict)
+	case errors.Is(err, clientes_service.ErrClienteComVinculos):
+		web.RespondError(w, r, "CLIENTE_COM_VINCULOS", err.Error(), http.StatusConflict)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno no servidor", http.StatusInternalServerError)
+	}
+}
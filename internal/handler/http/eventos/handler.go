@@ -0,0 +1,232 @@
+// file: internal/handler/http/eventos/handler.go
+package eventos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/authz"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/events"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus"
+	"github.com/luiszkm/masterCostrutora/internal/platform/stream"
+	"github.com/luiszkm/masterCostrutora/pkg/auth"
+)
+
+// permissoesPorEvento define quais eventos são repassados ao frontend e a
+// permissão de leitura exigida para recebê-los.
+var permissoesPorEvento = map[string]string{
+	events.OrcamentoStatusAtualizado:     authz.PermissaoSuprimentosLer,
+	events.OrcamentoExcluido:             authz.PermissaoSuprimentosLer,
+	events.ApontamentoAprovado:           authz.PermissaoPessoalApontamentoLer,
+	events.PagamentoApontamentoRealizado: authz.PermissaoPessoalApontamentoLer,
+	events.ContaReceberCriada:            authz.PermissaoFinanceiroLer,
+	events.ContaReceberPaga:              authz.PermissaoFinanceiroLer,
+	events.ContaReceberVencida:           authz.PermissaoFinanceiroLer,
+	events.ObraContratoDefinido:          authz.PermissaoObrasLer,
+	events.CronogramaRecebimentoCriado:   authz.PermissaoObrasLer,
+	events.EtapaRecebimentoVencida:       authz.PermissaoObrasLer,
+	events.RecebimentoRealizado:          authz.PermissaoFinanceiroLer,
+	events.ObraStatusAlterado:            authz.PermissaoObrasLer,
+}
+
+const intervaloHeartbeat = 25 * time.Second
+
+// EventSubscriber é a parte do EventBus usada para alimentar o broker.
+type EventSubscriber interface {
+	Subscrever(nomeEvento string, handler bus.HandlerFunc)
+}
+
+// ObraFinder confere se as obras pedidas no stream existem.
+type ObraFinder interface {
+	BuscarPorID(ctx context.Context, id string) (*obras.Obra, error)
+}
+
+type Handler struct {
+	broker *stream.Broker
+	obras  ObraFinder
+	logger *slog.Logger
+}
+
+func NovoEventosHandler(broker *stream.Broker, obraFinder ObraFinder, logger *slog.Logger) *Handler {
+	return &Handler{broker: broker, obras: obraFinder, logger: logger.With("handler", "eventos")}
+}
+
+// Registrar subscreve o broker nos eventos que podem ser enviados ao frontend.
+func (h *Handler) Registrar(eventBus EventSubscriber) {
+	for nome := range permissoesPorEvento {
+		eventBus.Subscrever(nome, h.broker.HandleEvento)
+	}
+}
+
+// HandleStream mantém uma conexão Server-Sent Events aberta com o cliente.
+//
+// Query params opcionais:
+//   - eventos: lista separada por vírgula dos eventos desejados (padrão: todos permitidos)
+//   - obraId: restringe aos eventos dessas obras (repetível ou separado por vírgula);
+//     eventos sem obra associada não são enviados quando o escopo é informado. Exige
+//     obras:ler e que todas as obras existam; o stream é recusado antes de abrir.
+//   - lastEventId: alternativa ao header Last-Event-ID na primeira conexão
+//
+// Eventos de uma obra só chegam a quem tem obras:ler, além da permissão do próprio evento.
+func (h *Handler) HandleStream(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+
+	permissoes, _ := r.Context().Value(auth.PermissoesContextKey).([]string)
+	filtro, err := montarFiltro(r, permissoes)
+	if err != nil {
+		web.RespondError(w, r, "PARAMETRO_INVALIDO", err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.verificarObras(r.Context(), separarLista(r.URL.Query()["obraId"]), permissoes); err != nil {
+		switch {
+		case errors.Is(err, errSemAcessoObras):
+			web.RespondError(w, r, "ACESSO_NEGADO", err.Error(), http.StatusForbidden)
+		case errors.Is(err, postgres.ErrNaoEncontrado):
+			web.RespondError(w, r, "OBRA_NAO_ENCONTRADA", "Obra não encontrada", http.StatusNotFound)
+		default:
+			h.logger.ErrorContext(r.Context(), "falha ao verificar obras do stream", "erro", err)
+			web.RespondError(w, r, "ERRO_INTERNO", "Erro ao abrir o stream de eventos", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ultimoID := r.Header.Get("Last-Event-ID")
+	if ultimoID == "" {
+		ultimoID = r.URL.Query().Get("lastEventId")
+	}
+
+	assinante, pendentes, completo := h.broker.Assinar(ultimoID, filtro)
+	defer h.broker.Cancelar(assinante)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprint(w, "retry: 5000\n\n")
+	if !completo {
+		// O cliente perdeu eventos que já saíram do buffer: deve recarregar os dados.
+		fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+	}
+	for _, m := range pendentes {
+		escreverMensagem(w, m)
+	}
+	if err := rc.Flush(); err != nil {
+		h.logger.ErrorContext(r.Context(), "streaming não suportado pela conexão", "erro", err)
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "cliente conectado ao stream de eventos",
+		"usuario_id", r.Context().Value(auth.UserContextKey), "reenviados", len(pendentes))
+
+	heartbeat := time.NewTicker(intervaloHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			h.logger.InfoContext(r.Context(), "cliente desconectado do stream de eventos")
+			return
+		case m, ok := <-assinante.C:
+			if !ok {
+				// Descartado pelo broker; o cliente reconecta usando Last-Event-ID.
+				return
+			}
+			escreverMensagem(w, m)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+var errSemAcessoObras = errors.New("a permissão obras:ler é necessária para acompanhar obras")
+
+// verificarObras recusa o escopo de obras de quem não pode lê-las ou que não existem.
+func (h *Handler) verificarObras(ctx context.Context, obraIDs []string, permissoes []string) error {
+	if len(obraIDs) == 0 {
+		return nil
+	}
+	if !slices.Contains(permissoes, authz.PermissaoObrasLer) {
+		return errSemAcessoObras
+	}
+	for _, id := range obraIDs {
+		if _, err := h.obras.BuscarPorID(ctx, id); err != nil {
+			return fmt.Errorf("obra %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func escreverMensagem(w http.ResponseWriter, m stream.Mensagem) {
+	dados, err := json.Marshal(m)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", m.ID, m.Evento, dados)
+}
+
+func montarFiltro(r *http.Request, permissoes []string) (stream.Filtro, error) {
+	q := r.URL.Query()
+
+	permitidos := make(map[string]struct{})
+	for nome, permissao := range permissoesPorEvento {
+		if slices.Contains(permissoes, permissao) {
+			permitidos[nome] = struct{}{}
+		}
+	}
+
+	if selecionados := separarLista(q["eventos"]); len(selecionados) > 0 {
+		escolhidos := make(map[string]struct{}, len(selecionados))
+		for _, nome := range selecionados {
+			if _, ok := permissoesPorEvento[nome]; !ok {
+				return nil, fmt.Errorf("evento desconhecido: %s", nome)
+			}
+			if _, ok := permitidos[nome]; ok {
+				escolhidos[nome] = struct{}{}
+			}
+		}
+		permitidos = escolhidos
+	}
+
+	obraIDs := separarLista(q["obraId"])
+	lerObras := slices.Contains(permissoes, authz.PermissaoObrasLer)
+
+	return func(m stream.Mensagem) bool {
+		if _, ok := permitidos[m.Evento]; !ok {
+			return false
+		}
+		if m.ObraID != "" && !lerObras {
+			return false
+		}
+		if len(obraIDs) > 0 {
+			return m.ObraID != "" && slices.Contains(obraIDs, m.ObraID)
+		}
+		return true
+	}, nil
+}
+
+func separarLista(valores []string) []string {
+	var itens []string
+	for _, v := range valores {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				itens = append(itens, item)
+			}
+		}
+	}
+	return itens
+}
@@ -0,0 +1,125 @@
+package eventos
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/luiszkm/masterCostrutora/internal/authz"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/events"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/platform/stream"
+)
+
+func TestMontarFiltro(t *testing.T) {
+	statusObraA := stream.Mensagem{Evento: events.ObraStatusAlterado, ObraID: "a"}
+	statusObraB := stream.Mensagem{Evento: events.ObraStatusAlterado, ObraID: "b"}
+	contaObraA := stream.Mensagem{Evento: events.ContaReceberPaga, ObraID: "a"}
+	contaAvulsa := stream.Mensagem{Evento: events.ContaReceberPaga}
+	orcamento := stream.Mensagem{Evento: events.OrcamentoExcluido}
+
+	casos := []struct {
+		nome       string
+		query      string
+		permissoes []string
+		entregues  []stream.Mensagem
+		recusadas  []stream.Mensagem
+	}{
+		{
+			nome:      "sem permissões",
+			recusadas: []stream.Mensagem{statusObraA, contaAvulsa, orcamento},
+		},
+		{
+			nome:       "financeiro sem obras:ler não recebe eventos de obra",
+			permissoes: []string{authz.PermissaoFinanceiroLer},
+			entregues:  []stream.Mensagem{contaAvulsa},
+			recusadas:  []stream.Mensagem{contaObraA, statusObraA, orcamento},
+		},
+		{
+			nome:       "financeiro e obras",
+			permissoes: []string{authz.PermissaoFinanceiroLer, authz.PermissaoObrasLer},
+			entregues:  []stream.Mensagem{contaAvulsa, contaObraA, statusObraA, statusObraB},
+			recusadas:  []stream.Mensagem{orcamento},
+		},
+		{
+			nome:       "escopo de obra",
+			query:      "obraId=a",
+			permissoes: []string{authz.PermissaoFinanceiroLer, authz.PermissaoObrasLer},
+			entregues:  []stream.Mensagem{contaObraA, statusObraA},
+			recusadas:  []stream.Mensagem{statusObraB, contaAvulsa},
+		},
+		{
+			nome:       "seleção de eventos não amplia as permissões",
+			query:      "eventos=" + events.ObraStatusAlterado + "," + events.OrcamentoExcluido,
+			permissoes: []string{authz.PermissaoObrasLer},
+			entregues:  []stream.Mensagem{statusObraA},
+			recusadas:  []stream.Mensagem{orcamento, contaObraA},
+		},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/eventos/stream?"+tc.query, nil)
+			filtro, err := montarFiltro(r, tc.permissoes)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			for _, m := range tc.entregues {
+				if !filtro(m) {
+					t.Errorf("%s (obra %q) deveria ser entregue", m.Evento, m.ObraID)
+				}
+			}
+			for _, m := range tc.recusadas {
+				if filtro(m) {
+					t.Errorf("%s (obra %q) não deveria ser entregue", m.Evento, m.ObraID)
+				}
+			}
+		})
+	}
+}
+
+func TestMontarFiltroEventoDesconhecido(t *testing.T) {
+	r := httptest.NewRequest("GET", "/eventos/stream?eventos=inexistente", nil)
+	if _, err := montarFiltro(r, []string{authz.PermissaoObrasLer}); err == nil {
+		t.Fatal("evento desconhecido deveria ser recusado")
+	}
+}
+
+type obrasFake map[string]bool
+
+func (f obrasFake) BuscarPorID(_ context.Context, id string) (*obras.Obra, error) {
+	if !f[id] {
+		return nil, postgres.ErrNaoEncontrado
+	}
+	return &obras.Obra{ID: id}, nil
+}
+
+func TestVerificarObras(t *testing.T) {
+	h := &Handler{obras: obrasFake{"a": true, "b": true}}
+	ler := []string{authz.PermissaoObrasLer}
+
+	casos := []struct {
+		nome       string
+		obraIDs    []string
+		permissoes []string
+		erro       error
+	}{
+		{nome: "sem escopo", permissoes: nil},
+		{nome: "obras existentes", obraIDs: []string{"a", "b"}, permissoes: ler},
+		{nome: "sem obras:ler", obraIDs: []string{"a"}, permissoes: []string{authz.PermissaoFinanceiroLer}, erro: errSemAcessoObras},
+		{nome: "obra inexistente", obraIDs: []string{"a", "x"}, permissoes: ler, erro: postgres.ErrNaoEncontrado},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			err := h.verificarObras(context.Background(), tc.obraIDs, tc.permissoes)
+			if tc.erro == nil && err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if tc.erro != nil && !errors.Is(err, tc.erro) {
+				t.Fatalf("erro = %v, esperado %v", err, tc.erro)
+			}
+		})
+	}
+}
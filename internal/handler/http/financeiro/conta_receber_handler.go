@@ -3,12 +3,16 @@ package financeiro
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/clientes"
 	"github.com/luiszkm/masterCostrutora/internal/domain/common"
+	"github.com/luiszkm/masterCostrutora/internal/domain/financeiro"
 	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
 	"github.com/luiszkm/masterCostrutora/internal/service/financeiro/dto"
 )
 
@@ -16,8 +20,10 @@ import (
 type ContaReceberService interface {
 	CriarConta(ctx context.Context, input dto.CriarContaReceberInput) (*dto.ContaReceberOutput, error)
 	RegistrarRecebimento(ctx context.Context, contaID string, input dto.RegistrarRecebimentoContaInput) (*dto.ContaReceberOutput, error)
+	RegistrarBoleto(ctx context.Context, contaID string, input dto.RegistrarBoletoInput) (*dto.ContaReceberOutput, error)
 	BuscarPorID(ctx context.Context, id string) (*dto.ContaReceberOutput, error)
 	ListarPorObraID(ctx context.Context, obraID string) ([]*dto.ContaReceberOutput, error)
+	ObterCaucao(ctx context.Context, obraID string) (*dto.CaucaoObraOutput, error)
 	ListarVencidas(ctx context.Context) ([]*dto.ContaReceberOutput, error)
 	Listar(ctx context.Context, filtros common.ListarFiltros) (*common.RespostaPaginada[*dto.ContaReceberOutput], error)
 	ObterResumo(ctx context.Context, filtros dto.FiltrosContaReceberInput) (*dto.ResumoContasReceberOutput, error)
@@ -46,6 +52,18 @@ func (h *ContaReceberHandler) HandleCriarConta(w http.ResponseWriter, r *http.Re
 
 	conta, err := h.service.CriarConta(r.Context(), input)
 	if err != nil {
+		if errors.Is(err, clientes.ErrClienteNaoCadastrado) {
+			web.RespondError(w, r, "CLIENTE_NAO_CADASTRADO", "Cadastre o cliente antes de lançar a conta", http.StatusUnprocessableEntity)
+			return
+		}
+		if errors.Is(err, financeiro.ErrBoletoInvalido) {
+			web.RespondError(w, r, "BOLETO_INVALIDO", err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, financeiro.ErrRetencaoInvalida) {
+			web.RespondError(w, r, "RETENCAO_INVALIDA", err.Error(), http.StatusBadRequest)
+			return
+		}
 		h.logger.ErrorContext(r.Context(), "falha ao criar conta a receber", "erro", err)
 		web.RespondError(w, r, "ERRO_INTERNO", "Erro ao criar conta a receber", http.StatusInternalServerError)
 		return
@@ -79,6 +97,33 @@ func (h *ContaReceberHandler) HandleRegistrarRecebimento(w http.ResponseWriter,
 	web.Respond(w, r, conta, http.StatusOK)
 }
 
+// HandleRegistrarBoleto registra o boleto emitido para uma conta
+func (h *ContaReceberHandler) HandleRegistrarBoleto(w http.ResponseWriter, r *http.Request) {
+	contaID := chi.URLParam(r, "contaId")
+
+	var input dto.RegistrarBoletoInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	conta, err := h.service.RegistrarBoleto(r.Context(), contaID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, postgres.ErrNaoEncontrado):
+			web.RespondError(w, r, "NAO_ENCONTRADO", "Conta não encontrada", http.StatusNotFound)
+		case errors.Is(err, financeiro.ErrBoletoInvalido):
+			web.RespondError(w, r, "BOLETO_INVALIDO", err.Error(), http.StatusBadRequest)
+		default:
+			h.logger.ErrorContext(r.Context(), "falha ao registrar boleto", "conta_id", contaID, "erro", err)
+			web.RespondError(w, r, "ERRO_INTERNO", "Erro ao registrar boleto", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	web.Respond(w, r, conta, http.StatusOK)
+}
+
 // HandleBuscarConta busca uma conta por ID
 func (h *ContaReceberHandler) HandleBuscarConta(w http.ResponseWriter, r *http.Request) {
 	contaID := chi.URLParam(r, "contaId")
@@ -115,6 +160,20 @@ func (h *ContaReceberHandler) HandleListarContasPorObra(w http.ResponseWriter, r
 	web.Respond(w, r, contas, http.StatusOK)
 }
 
+// HandleObterCaucao retorna a caução retida da obra, o que já foi liberado e o saldo
+func (h *ContaReceberHandler) HandleObterCaucao(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	caucao, err := h.service.ObterCaucao(r.Context(), obraID)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "falha ao consultar caução da obra", "obra_id", obraID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro ao consultar caução", http.StatusInternalServerError)
+		return
+	}
+
+	web.Respond(w, r, caucao, http.StatusOK)
+}
+
 // HandleListarContasVencidas lista contas vencidas
 func (h *ContaReceberHandler) HandleListarContasVencidas(w http.ResponseWriter, r *http.Request) {
 	contas, err := h.service.ListarVencidas(r.Context())
@@ -0,0 +1,137 @@
+// file: internal/handler/http/integracoes/handler.go
+package integracoes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/common"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	integracoes_service "github.com/luiszkm/masterCostrutora/internal/service/integracoes"
+	"github.com/luiszkm/masterCostrutora/internal/service/integracoes/dto"
+)
+
+// Service define a interface do serviço de integrações (webhooks)
+type Service interface {
+	ListarEventosDisponiveis() []string
+	CriarAssinatura(ctx context.Context, input dto.CriarAssinaturaWebhookInput) (*dto.AssinaturaWebhookComSegredoOutput, error)
+	AtualizarAssinatura(ctx context.Context, id string, input dto.AtualizarAssinaturaWebhookInput) (*dto.AssinaturaWebhookComSegredoOutput, error)
+	BuscarAssinatura(ctx context.Context, id string) (*dto.AssinaturaWebhookOutput, error)
+	ListarAssinaturas(ctx context.Context) ([]*dto.AssinaturaWebhookOutput, error)
+	DeletarAssinatura(ctx context.Context, id string) error
+	ListarEntregas(ctx context.Context, assinaturaID string, filtros common.ListarFiltros) (*common.RespostaPaginada[*dto.EntregaWebhookOutput], error)
+	ReenviarEntrega(ctx context.Context, entregaID string) (*dto.EntregaWebhookOutput, error)
+}
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NovoIntegracoesHandler(s Service, l *slog.Logger) *Handler {
+	return &Handler{service: s, logger: l.With("handler", "integracoes")}
+}
+
+// HandleListarEventos lista os eventos que podem ser assinados
+func (h *Handler) HandleListarEventos(w http.ResponseWriter, r *http.Request) {
+	web.Respond(w, r, h.service.ListarEventosDisponiveis(), http.StatusOK)
+}
+
+// HandleCriarWebhook cadastra uma nova assinatura de webhook
+func (h *Handler) HandleCriarWebhook(w http.ResponseWriter, r *http.Request) {
+	var input dto.CriarAssinaturaWebhookInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	assinatura, err := h.service.CriarAssinatura(r.Context(), input)
+	if err != nil {
+		h.responderErro(w, r, "falha ao criar webhook", err)
+		return
+	}
+
+	web.Respond(w, r, assinatura, http.StatusCreated)
+}
+
+// HandleListarWebhooks lista as assinaturas cadastradas
+func (h *Handler) HandleListarWebhooks(w http.ResponseWriter, r *http.Request) {
+	assinaturas, err := h.service.ListarAssinaturas(r.Context())
+	if err != nil {
+		h.responderErro(w, r, "falha ao listar webhooks", err)
+		return
+	}
+	web.Respond(w, r, assinaturas, http.StatusOK)
+}
+
+// HandleBuscarWebhook busca uma assinatura por ID
+func (h *Handler) HandleBuscarWebhook(w http.ResponseWriter, r *http.Request) {
+	assinatura, err := h.service.BuscarAssinatura(r.Context(), chi.URLParam(r, "webhookId"))
+	if err != nil {
+		h.responderErro(w, r, "falha ao buscar webhook", err)
+		return
+	}
+	web.Respond(w, r, assinatura, http.StatusOK)
+}
+
+// HandleAtualizarWebhook atualiza URL, filtro de eventos, status ou segredo
+func (h *Handler) HandleAtualizarWebhook(w http.ResponseWriter, r *http.Request) {
+	var input dto.AtualizarAssinaturaWebhookInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	assinatura, err := h.service.AtualizarAssinatura(r.Context(), chi.URLParam(r, "webhookId"), input)
+	if err != nil {
+		h.responderErro(w, r, "falha ao atualizar webhook", err)
+		return
+	}
+	web.Respond(w, r, assinatura, http.StatusOK)
+}
+
+// HandleDeletarWebhook remove uma assinatura e seu log de entregas
+func (h *Handler) HandleDeletarWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeletarAssinatura(r.Context(), chi.URLParam(r, "webhookId")); err != nil {
+		h.responderErro(w, r, "falha ao deletar webhook", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleListarEntregas lista o log de entregas de uma assinatura
+func (h *Handler) HandleListarEntregas(w http.ResponseWriter, r *http.Request) {
+	entregas, err := h.service.ListarEntregas(r.Context(), chi.URLParam(r, "webhookId"), web.ParseFiltros(r))
+	if err != nil {
+		h.responderErro(w, r, "falha ao listar entregas de webhook", err)
+		return
+	}
+	web.Respond(w, r, entregas, http.StatusOK)
+}
+
+// HandleReenviarEntrega reenvia (replay) uma entrega existente
+func (h *Handler) HandleReenviarEntrega(w http.ResponseWriter, r *http.Request) {
+	entrega, err := h.service.ReenviarEntrega(r.Context(), chi.URLParam(r, "entregaId"))
+	if err != nil {
+		h.responderErro(w, r, "falha ao reenviar entrega de webhook", err)
+		return
+	}
+	web.Respond(w, r, entrega, http.StatusAccepted)
+}
+
+func (h *Handler) responderErro(w http.ResponseWriter, r *http.Request, msg string, err error) {
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "NAO_ENCONTRADO", "Recurso não encontrado", http.StatusNotFound)
+	case errors.Is(err, integracoes_service.ErrAssinaturaInvalida), errors.Is(err, integracoes_service.ErrEventoNaoSuportado):
+		web.RespondError(w, r, "DADOS_INVALIDOS", err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno no servidor", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,120 @@
+// file: internal/handler/http/notificacoes/handler.go
+package notificacoes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/common"
+	"github.com/luiszkm/masterCostrutora/internal/domain/notificacoes"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/service/notificacoes/dto"
+)
+
+// Service define a interface do serviço de notificações
+type Service interface {
+	ListarNotificacoes(ctx context.Context, filtros common.ListarFiltros) (*common.RespostaPaginada[*notificacoes.Notificacao], error)
+	ContarNaoLidas(ctx context.Context) (*dto.ContagemNaoLidasOutput, error)
+	MarcarLida(ctx context.Context, id string) (*notificacoes.Notificacao, error)
+	MarcarTodasLidas(ctx context.Context) (*dto.MarcarTodasLidasOutput, error)
+	BuscarPreferencias(ctx context.Context) (*dto.PreferenciasOutput, error)
+	AtualizarPreferencias(ctx context.Context, input dto.AtualizarPreferenciasInput) (*dto.PreferenciasOutput, error)
+}
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NovoNotificacoesHandler(s Service, l *slog.Logger) *Handler {
+	return &Handler{service: s, logger: l.With("handler", "notificacoes")}
+}
+
+// HandleListarNotificacoes lista as notificações do usuário logado, mais recentes primeiro
+func (h *Handler) HandleListarNotificacoes(w http.ResponseWriter, r *http.Request) {
+	filtros := web.ParseFiltros(r)
+	if filtros.Status != "" && filtros.Status != "LIDA" && filtros.Status != "NAO_LIDA" {
+		web.RespondError(w, r, "FILTRO_INVALIDO", "Status deve ser LIDA ou NAO_LIDA", http.StatusBadRequest)
+		return
+	}
+
+	resposta, err := h.service.ListarNotificacoes(r.Context(), filtros)
+	if err != nil {
+		h.responderErro(w, r, "falha ao listar notificações", err)
+		return
+	}
+	web.Respond(w, r, resposta, http.StatusOK)
+}
+
+// HandleContarNaoLidas retorna quantas notificações o usuário logado ainda não leu
+func (h *Handler) HandleContarNaoLidas(w http.ResponseWriter, r *http.Request) {
+	contagem, err := h.service.ContarNaoLidas(r.Context())
+	if err != nil {
+		h.responderErro(w, r, "falha ao contar notificações não lidas", err)
+		return
+	}
+	web.Respond(w, r, contagem, http.StatusOK)
+}
+
+// HandleMarcarLida marca uma notificação do usuário logado como lida
+func (h *Handler) HandleMarcarLida(w http.ResponseWriter, r *http.Request) {
+	n, err := h.service.MarcarLida(r.Context(), chi.URLParam(r, "notificacaoId"))
+	if err != nil {
+		h.responderErro(w, r, "falha ao marcar notificação como lida", err)
+		return
+	}
+	web.Respond(w, r, n, http.StatusOK)
+}
+
+// HandleMarcarTodasLidas marca todas as notificações do usuário logado como lidas
+func (h *Handler) HandleMarcarTodasLidas(w http.ResponseWriter, r *http.Request) {
+	resultado, err := h.service.MarcarTodasLidas(r.Context())
+	if err != nil {
+		h.responderErro(w, r, "falha ao marcar notificações como lidas", err)
+		return
+	}
+	web.Respond(w, r, resultado, http.StatusOK)
+}
+
+// HandleBuscarPreferencias retorna os eventos e canais escolhidos pelo usuário logado
+func (h *Handler) HandleBuscarPreferencias(w http.ResponseWriter, r *http.Request) {
+	prefs, err := h.service.BuscarPreferencias(r.Context())
+	if err != nil {
+		h.responderErro(w, r, "falha ao buscar preferências de notificação", err)
+		return
+	}
+	web.Respond(w, r, prefs, http.StatusOK)
+}
+
+// HandleAtualizarPreferencias altera os eventos, canais e contatos do usuário logado
+func (h *Handler) HandleAtualizarPreferencias(w http.ResponseWriter, r *http.Request) {
+	var input dto.AtualizarPreferenciasInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	prefs, err := h.service.AtualizarPreferencias(r.Context(), input)
+	if err != nil {
+		h.responderErro(w, r, "falha ao atualizar preferências de notificação", err)
+		return
+	}
+	web.Respond(w, r, prefs, http.StatusOK)
+}
+
+func (h *Handler) responderErro(w http.ResponseWriter, r *http.Request, msg string, err error) {
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "NOTIFICACAO_NAO_ENCONTRADA", "Notificação não encontrada", http.StatusNotFound)
+	case errors.Is(err, notificacoes.ErrPreferenciasInvalidas):
+		web.RespondError(w, r, "PREFERENCIAS_INVALIDAS", err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno no servidor", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,111 @@
+package obras
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	obras_service "github.com/luiszkm/masterCostrutora/internal/service/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// AditivoService define a interface para o service de aditivos de contrato
+type AditivoService interface {
+	RegistrarAditivo(ctx context.Context, obraID string, input dto.RegistrarAditivoInput) (*obras.Aditivo, error)
+	ListarAditivos(ctx context.Context, obraID string) ([]*obras.Aditivo, error)
+	ObterContrato(ctx context.Context, obraID string) (*dto.ContratoObraDTO, error)
+}
+
+// AditivoHandler gerencia as rotas de aditivos e do histórico do contrato
+type AditivoHandler struct {
+	service AditivoService
+	logger  *slog.Logger
+}
+
+func NovoAditivoHandler(service AditivoService, logger *slog.Logger) *AditivoHandler {
+	return &AditivoHandler{
+		service: service,
+		logger:  logger.With("handler", "aditivo"),
+	}
+}
+
+// HandleRegistrarAditivo registra um aditivo aprovado e ajusta o contrato da obra
+func (h *AditivoHandler) HandleRegistrarAditivo(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.RegistrarAditivoInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	aditivo, err := h.service.RegistrarAditivo(r.Context(), obraID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao registrar aditivo", obraID)
+		return
+	}
+
+	web.Respond(w, r, aditivo, http.StatusCreated)
+}
+
+// HandleListarAditivos lista os aditivos da obra
+func (h *AditivoHandler) HandleListarAditivos(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	aditivos, err := h.service.ListarAditivos(r.Context(), obraID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao listar aditivos", obraID)
+		return
+	}
+
+	web.Respond(w, r, aditivos, http.StatusOK)
+}
+
+// HandleObterContrato retorna a linha de base, o contrato vigente e o histórico
+func (h *AditivoHandler) HandleObterContrato(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	contrato, err := h.service.ObterContrato(r.Context(), obraID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao obter contrato", obraID)
+		return
+	}
+
+	web.Respond(w, r, contrato, http.StatusOK)
+}
+
+func (h *AditivoHandler) responderErro(w http.ResponseWriter, r *http.Request, err error, msg, obraID string) {
+	var erroData *time.ParseError
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "OBRA_NAO_ENCONTRADA", "Obra não encontrada", http.StatusNotFound)
+	case errors.As(err, &erroData):
+		web.RespondError(w, r, "DATA_INVALIDA", "Data inválida, use o formato AAAA-MM-DD", http.StatusBadRequest)
+	case errors.Is(err, obras.ErrAditivoInvalido):
+		web.RespondError(w, r, "ADITIVO_INVALIDO", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrAjusteCronogramaAditivo),
+		errors.Is(err, obras_service.ErrParametrosCronograma):
+		web.RespondError(w, r, "AJUSTE_CRONOGRAMA_INVALIDO", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrEtapaForaDaObra):
+		web.RespondError(w, r, "ETAPA_FORA_DA_OBRA", obras_service.ErrEtapaForaDaObra.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrAditivoObraEncerrada):
+		web.RespondError(w, r, "OBRA_ENCERRADA", obras_service.ErrAditivoObraEncerrada.Error(), http.StatusConflict)
+	case errors.Is(err, obras_service.ErrCronogramaComRecebimentos):
+		web.RespondError(w, r, "CRONOGRAMA_COM_RECEBIMENTOS", obras_service.ErrCronogramaComRecebimentos.Error(), http.StatusConflict)
+	case errors.Is(err, obras_service.ErrCronogramaComMedicoes):
+		web.RespondError(w, r, "OBRA_FATURADA_POR_MEDICAO", obras_service.ErrCronogramaComMedicoes.Error(), http.StatusConflict)
+	case errors.Is(err, obras_service.ErrObraSemValorContrato),
+		errors.Is(err, obras_service.ErrTipoCobrancaNaoDefinido):
+		web.RespondError(w, r, "CONTRATO_INCOMPLETO", err.Error(), http.StatusUnprocessableEntity)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "obra_id", obraID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao processar o aditivo", http.StatusInternalServerError)
+	}
+}
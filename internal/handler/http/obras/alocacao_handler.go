@@ -0,0 +1,160 @@
+package obras
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	obras_service "github.com/luiszkm/masterCostrutora/internal/service/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// AlocacaoService define a interface para o service de alocações de funcionários
+type AlocacaoService interface {
+	AlocarFuncionarios(ctx context.Context, obraID string, input dto.AlocarFuncionariosInput) ([]*obras.Alocacao, error)
+	EncerrarAlocacao(ctx context.Context, obraID, alocacaoID string, input dto.EncerrarAlocacaoInput) (*obras.Alocacao, error)
+	TransferirAlocacao(ctx context.Context, obraID, alocacaoID string, input dto.TransferirAlocacaoInput) (*dto.TransferenciaAlocacaoOutput, error)
+	ListarAlocacoesDaObra(ctx context.Context, obraID string) ([]*dto.AlocacaoHistoricoDTO, error)
+	ListarAlocacoesDoFuncionario(ctx context.Context, funcionarioID string) ([]*dto.AlocacaoHistoricoDTO, error)
+}
+
+// AlocacaoHandler gerencia as rotas de alocação, encerramento e transferência de funcionários
+type AlocacaoHandler struct {
+	service AlocacaoService
+	logger  *slog.Logger
+}
+
+func NovoAlocacaoHandler(service AlocacaoService, logger *slog.Logger) *AlocacaoHandler {
+	return &AlocacaoHandler{
+		service: service,
+		logger:  logger.With("handler", "alocacao"),
+	}
+}
+
+// erroConflitoAlocacaoResponse estende o erro padrão com as alocações sobrepostas
+type erroConflitoAlocacaoResponse struct {
+	web.ErrorResponse
+	Conflitos []*obras.Alocacao `json:"conflitos"`
+}
+
+// HandleAlocarFuncionarios aloca um ou mais funcionários na obra
+func (h *AlocacaoHandler) HandleAlocarFuncionarios(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.AlocarFuncionariosInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	alocacoes, err := h.service.AlocarFuncionarios(r.Context(), obraID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao alocar funcionários", obraID)
+		return
+	}
+
+	web.Respond(w, r, alocacoes, http.StatusCreated)
+}
+
+// HandleListarAlocacoesDaObra lista o histórico de alocações da obra
+func (h *AlocacaoHandler) HandleListarAlocacoesDaObra(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	alocacoes, err := h.service.ListarAlocacoesDaObra(r.Context(), obraID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao listar alocações da obra", obraID)
+		return
+	}
+
+	web.Respond(w, r, alocacoes, http.StatusOK)
+}
+
+// HandleEncerrarAlocacao define o último dia do funcionário na obra
+func (h *AlocacaoHandler) HandleEncerrarAlocacao(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+	alocacaoID := chi.URLParam(r, "alocacaoId")
+
+	var input dto.EncerrarAlocacaoInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	alocacao, err := h.service.EncerrarAlocacao(r.Context(), obraID, alocacaoID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao encerrar alocação", obraID)
+		return
+	}
+
+	web.Respond(w, r, alocacao, http.StatusOK)
+}
+
+// HandleTransferirAlocacao move o funcionário para outra obra
+func (h *AlocacaoHandler) HandleTransferirAlocacao(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+	alocacaoID := chi.URLParam(r, "alocacaoId")
+
+	var input dto.TransferirAlocacaoInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	transferencia, err := h.service.TransferirAlocacao(r.Context(), obraID, alocacaoID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao transferir alocação", obraID)
+		return
+	}
+
+	web.Respond(w, r, transferencia, http.StatusCreated)
+}
+
+// HandleListarAlocacoesDoFuncionario lista por quais obras o funcionário passou
+func (h *AlocacaoHandler) HandleListarAlocacoesDoFuncionario(w http.ResponseWriter, r *http.Request) {
+	funcionarioID := chi.URLParam(r, "funcionarioId")
+
+	alocacoes, err := h.service.ListarAlocacoesDoFuncionario(r.Context(), funcionarioID)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "falha ao listar alocações do funcionário", "funcionario_id", funcionarioID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro ao listar alocações do funcionário", http.StatusInternalServerError)
+		return
+	}
+
+	web.Respond(w, r, alocacoes, http.StatusOK)
+}
+
+func (h *AlocacaoHandler) responderErro(w http.ResponseWriter, r *http.Request, err error, msg, obraID string) {
+	var erroData *time.ParseError
+	var erroConflito *obras.ErroConflitoAlocacao
+	switch {
+	case errors.As(err, &erroConflito):
+		web.Respond(w, r, erroConflitoAlocacaoResponse{
+			ErrorResponse: web.ErrorResponse{Codigo: "ALOCACAO_CONFLITANTE", Mensagem: erroConflito.Error()},
+			Conflitos:     erroConflito.Conflitos,
+		}, http.StatusConflict)
+	case errors.Is(err, obras_service.ErrAlocacaoNaoEncontrada):
+		web.RespondError(w, r, "ALOCACAO_NAO_ENCONTRADA", obras_service.ErrAlocacaoNaoEncontrada.Error(), http.StatusNotFound)
+	case errors.Is(err, obras_service.ErrFuncionarioNaoEncontrado):
+		web.RespondError(w, r, "FUNCIONARIO_NAO_ENCONTRADO", err.Error(), http.StatusNotFound)
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "OBRA_NAO_ENCONTRADA", "Obra não encontrada", http.StatusNotFound)
+	case errors.As(err, &erroData):
+		web.RespondError(w, r, "DATA_INVALIDA", "Data inválida, use o formato AAAA-MM-DD", http.StatusBadRequest)
+	case errors.Is(err, obras.ErrAlocacaoInvalida):
+		web.RespondError(w, r, "ALOCACAO_INVALIDA", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras.ErrAlocacaoEncerrada):
+		web.RespondError(w, r, "ALOCACAO_ENCERRADA", err.Error(), http.StatusConflict)
+	case errors.Is(err, obras_service.ErrAlocacaoObraEncerrada):
+		web.RespondError(w, r, "OBRA_ENCERRADA", obras_service.ErrAlocacaoObraEncerrada.Error(), http.StatusConflict)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "obra_id", obraID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao processar a alocação", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,104 @@
+package obras
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	obras_service "github.com/luiszkm/masterCostrutora/internal/service/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// CapacidadeService define a interface para o service de efetivo planejado e capacidade
+type CapacidadeService interface {
+	DefinirEfetivoPlanejado(ctx context.Context, obraID string, input dto.DefinirEfetivoPlanejadoInput) ([]*obras.EfetivoPlanejado, error)
+	ListarEfetivoPlanejado(ctx context.Context, obraID string) ([]*obras.EfetivoPlanejado, error)
+	ObterCapacidade(ctx context.Context, filtro dto.FiltroCapacidadeInput) (*dto.CapacidadeOutput, error)
+}
+
+// CapacidadeHandler gerencia as rotas do efetivo planejado e do calendário de capacidade
+type CapacidadeHandler struct {
+	service CapacidadeService
+	logger  *slog.Logger
+}
+
+func NovoCapacidadeHandler(service CapacidadeService, logger *slog.Logger) *CapacidadeHandler {
+	return &CapacidadeHandler{
+		service: service,
+		logger:  logger.With("handler", "capacidade"),
+	}
+}
+
+// HandleObterCapacidade retorna o calendário semanal de efetivo alocado x disponível por cargo
+func (h *CapacidadeHandler) HandleObterCapacidade(w http.ResponseWriter, r *http.Request) {
+	filtro := dto.FiltroCapacidadeInput{
+		Inicio:  r.URL.Query().Get("inicio"),
+		Semanas: r.URL.Query().Get("semanas"),
+	}
+
+	capacidade, err := h.service.ObterCapacidade(r.Context(), filtro)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao obter calendário de capacidade", "")
+		return
+	}
+
+	web.Respond(w, r, capacidade, http.StatusOK)
+}
+
+// HandleListarEfetivoPlanejado lista o efetivo planejado das etapas da obra
+func (h *CapacidadeHandler) HandleListarEfetivoPlanejado(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	itens, err := h.service.ListarEfetivoPlanejado(r.Context(), obraID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao listar efetivo planejado", obraID)
+		return
+	}
+
+	web.Respond(w, r, itens, http.StatusOK)
+}
+
+// HandleDefinirEfetivoPlanejado substitui o efetivo planejado das etapas da obra
+func (h *CapacidadeHandler) HandleDefinirEfetivoPlanejado(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.DefinirEfetivoPlanejadoInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	itens, err := h.service.DefinirEfetivoPlanejado(r.Context(), obraID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao definir efetivo planejado", obraID)
+		return
+	}
+
+	web.Respond(w, r, itens, http.StatusOK)
+}
+
+func (h *CapacidadeHandler) responderErro(w http.ResponseWriter, r *http.Request, err error, msg, obraID string) {
+	var erroData *time.ParseError
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "OBRA_NAO_ENCONTRADA", "Obra não encontrada", http.StatusNotFound)
+	case errors.As(err, &erroData):
+		web.RespondError(w, r, "DATA_INVALIDA", "Data inválida, use o formato AAAA-MM-DD", http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrHorizonteCapacidadeInvalido):
+		web.RespondError(w, r, "HORIZONTE_INVALIDO", obras_service.ErrHorizonteCapacidadeInvalido.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras.ErrEfetivoPlanejadoInvalido):
+		web.RespondError(w, r, "EFETIVO_INVALIDO", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrEtapaForaDaObra):
+		web.RespondError(w, r, "ETAPA_FORA_DA_OBRA", obras_service.ErrEtapaForaDaObra.Error(), http.StatusBadRequest)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "obra_id", obraID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao processar a capacidade", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,221 @@
+package obras
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	obras_service "github.com/luiszkm/masterCostrutora/internal/service/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// ChecklistQualidadeService define a interface para o service das checklists de qualidade (FVS)
+type ChecklistQualidadeService interface {
+	CriarModelo(ctx context.Context, input dto.SalvarModeloChecklistInput) (*obras.ModeloChecklist, error)
+	AtualizarModelo(ctx context.Context, modeloID string, input dto.SalvarModeloChecklistInput) (*obras.ModeloChecklist, error)
+	BuscarModelo(ctx context.Context, modeloID string) (*obras.ModeloChecklist, error)
+	ListarModelos(ctx context.Context, etapaPadraoID string) ([]*obras.ModeloChecklist, error)
+	DeletarModelo(ctx context.Context, modeloID string) error
+	ListarChecklistsObra(ctx context.Context, obraID string) (*dto.ChecklistsObraOutput, error)
+	ListarChecklistsEtapa(ctx context.Context, etapaID string) ([]*obras.ChecklistEtapa, error)
+	AplicarChecklists(ctx context.Context, etapaID string, input dto.AplicarChecklistsInput) ([]*obras.ChecklistEtapa, error)
+	RegistrarInspecao(ctx context.Context, obraID, checklistID string, input dto.RegistrarInspecaoInput) (*obras.ChecklistEtapa, error)
+	RemoverChecklist(ctx context.Context, obraID, checklistID string) error
+}
+
+// ChecklistQualidadeHandler gerencia as rotas dos modelos de FVS e das FVS das etapas
+type ChecklistQualidadeHandler struct {
+	service ChecklistQualidadeService
+	logger  *slog.Logger
+}
+
+func NovoChecklistQualidadeHandler(service ChecklistQualidadeService, logger *slog.Logger) *ChecklistQualidadeHandler {
+	return &ChecklistQualidadeHandler{
+		service: service,
+		logger:  logger.With("handler", "checklist_qualidade"),
+	}
+}
+
+// HandleListarModelos lista os modelos de FVS (?etapaPadraoId= filtra pela etapa padrão)
+func (h *ChecklistQualidadeHandler) HandleListarModelos(w http.ResponseWriter, r *http.Request) {
+	modelos, err := h.service.ListarModelos(r.Context(), r.URL.Query().Get("etapaPadraoId"))
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao listar modelos de checklist", "")
+		return
+	}
+
+	web.Respond(w, r, modelos, http.StatusOK)
+}
+
+// HandleBuscarModelo retorna um modelo de FVS
+func (h *ChecklistQualidadeHandler) HandleBuscarModelo(w http.ResponseWriter, r *http.Request) {
+	modeloID := chi.URLParam(r, "modeloId")
+
+	modelo, err := h.service.BuscarModelo(r.Context(), modeloID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao buscar modelo de checklist", modeloID)
+		return
+	}
+
+	web.Respond(w, r, modelo, http.StatusOK)
+}
+
+// HandleCriarModelo cadastra um modelo de FVS para uma etapa padrão
+func (h *ChecklistQualidadeHandler) HandleCriarModelo(w http.ResponseWriter, r *http.Request) {
+	var input dto.SalvarModeloChecklistInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	modelo, err := h.service.CriarModelo(r.Context(), input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao criar modelo de checklist", "")
+		return
+	}
+
+	web.Respond(w, r, modelo, http.StatusCreated)
+}
+
+// HandleAtualizarModelo substitui um modelo de FVS; as FVS já instanciadas não mudam
+func (h *ChecklistQualidadeHandler) HandleAtualizarModelo(w http.ResponseWriter, r *http.Request) {
+	modeloID := chi.URLParam(r, "modeloId")
+
+	var input dto.SalvarModeloChecklistInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	modelo, err := h.service.AtualizarModelo(r.Context(), modeloID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao atualizar modelo de checklist", modeloID)
+		return
+	}
+
+	web.Respond(w, r, modelo, http.StatusOK)
+}
+
+// HandleDeletarModelo remove um modelo de FVS; as FVS já instanciadas continuam nas etapas
+func (h *ChecklistQualidadeHandler) HandleDeletarModelo(w http.ResponseWriter, r *http.Request) {
+	modeloID := chi.URLParam(r, "modeloId")
+
+	if err := h.service.DeletarModelo(r.Context(), modeloID); err != nil {
+		h.responderErro(w, r, err, "falha ao deletar modelo de checklist", modeloID)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleListarChecklistsObra lista as FVS de todas as etapas da obra
+func (h *ChecklistQualidadeHandler) HandleListarChecklistsObra(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	out, err := h.service.ListarChecklistsObra(r.Context(), obraID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao listar checklists da obra", obraID)
+		return
+	}
+
+	web.Respond(w, r, out, http.StatusOK)
+}
+
+// HandleListarChecklistsEtapa lista as FVS da etapa
+func (h *ChecklistQualidadeHandler) HandleListarChecklistsEtapa(w http.ResponseWriter, r *http.Request) {
+	etapaID := chi.URLParam(r, "etapaId")
+
+	checklists, err := h.service.ListarChecklistsEtapa(r.Context(), etapaID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao listar checklists da etapa", etapaID)
+		return
+	}
+
+	web.Respond(w, r, checklists, http.StatusOK)
+}
+
+// HandleAplicarChecklists instancia na etapa um modelo (modeloId) ou os modelos da etapa padrão
+func (h *ChecklistQualidadeHandler) HandleAplicarChecklists(w http.ResponseWriter, r *http.Request) {
+	etapaID := chi.URLParam(r, "etapaId")
+
+	var input dto.AplicarChecklistsInput
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+			return
+		}
+	}
+
+	checklists, err := h.service.AplicarChecklists(r.Context(), etapaID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao aplicar checklists na etapa", etapaID)
+		return
+	}
+
+	web.Respond(w, r, checklists, http.StatusOK)
+}
+
+// HandleRegistrarInspecao registra a inspeção (ou reinspeção) de uma FVS da obra
+func (h *ChecklistQualidadeHandler) HandleRegistrarInspecao(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.RegistrarInspecaoInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	checklist, err := h.service.RegistrarInspecao(r.Context(), obraID, chi.URLParam(r, "checklistId"), input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao registrar inspeção", obraID)
+		return
+	}
+
+	web.Respond(w, r, checklist, http.StatusOK)
+}
+
+// HandleRemoverChecklist retira da etapa uma FVS ainda não inspecionada
+func (h *ChecklistQualidadeHandler) HandleRemoverChecklist(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	if err := h.service.RemoverChecklist(r.Context(), obraID, chi.URLParam(r, "checklistId")); err != nil {
+		h.responderErro(w, r, err, "falha ao remover checklist", obraID)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ChecklistQualidadeHandler) responderErro(w http.ResponseWriter, r *http.Request, err error, msg, recursoID string) {
+	var erroData *time.ParseError
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "ETAPA_NAO_ENCONTRADA", "Etapa não encontrada", http.StatusNotFound)
+	case errors.Is(err, obras_service.ErrModeloChecklistNaoEncontrado):
+		web.RespondError(w, r, "MODELO_NAO_ENCONTRADO", obras_service.ErrModeloChecklistNaoEncontrado.Error(), http.StatusNotFound)
+	case errors.Is(err, obras_service.ErrEtapaPadraoNaoEncontrada):
+		web.RespondError(w, r, "ETAPA_PADRAO_NAO_ENCONTRADA", obras_service.ErrEtapaPadraoNaoEncontrada.Error(), http.StatusNotFound)
+	case errors.Is(err, obras_service.ErrChecklistNaoEncontrada):
+		web.RespondError(w, r, "CHECKLIST_NAO_ENCONTRADA", obras_service.ErrChecklistNaoEncontrada.Error(), http.StatusNotFound)
+	case errors.As(err, &erroData):
+		web.RespondError(w, r, "DATA_INVALIDA", "Data inválida, use o formato AAAA-MM-DD", http.StatusBadRequest)
+	case errors.Is(err, obras.ErrModeloChecklistInvalido):
+		web.RespondError(w, r, "MODELO_INVALIDO", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras.ErrInspecaoInvalida):
+		web.RespondError(w, r, "INSPECAO_INVALIDA", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras.ErrChecklistAprovada):
+		web.RespondError(w, r, "CHECKLIST_APROVADA", obras.ErrChecklistAprovada.Error(), http.StatusConflict)
+	case errors.Is(err, obras.ErrChecklistInspecionada):
+		web.RespondError(w, r, "CHECKLIST_INSPECIONADA", obras.ErrChecklistInspecionada.Error(), http.StatusConflict)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "recurso_id", recursoID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao processar a checklist de qualidade", http.StatusInternalServerError)
+	}
+}
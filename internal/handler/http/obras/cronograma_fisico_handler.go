@@ -0,0 +1,97 @@
+package obras
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	obras_service "github.com/luiszkm/masterCostrutora/internal/service/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// CronogramaFisicoService define a interface para o service de dependências e caminho crítico
+type CronogramaFisicoService interface {
+	ObterCronogramaFisico(ctx context.Context, obraID string) (*dto.CronogramaFisicoOutput, error)
+	AdicionarDependencia(ctx context.Context, obraID string, input dto.AdicionarDependenciaInput) (*obras.DependenciaEtapa, error)
+	RemoverDependencia(ctx context.Context, obraID, dependenciaID string) error
+}
+
+// CronogramaFisicoHandler gerencia as rotas do cronograma físico (Gantt) da obra
+type CronogramaFisicoHandler struct {
+	service CronogramaFisicoService
+	logger  *slog.Logger
+}
+
+func NovoCronogramaFisicoHandler(service CronogramaFisicoService, logger *slog.Logger) *CronogramaFisicoHandler {
+	return &CronogramaFisicoHandler{
+		service: service,
+		logger:  logger.With("handler", "cronograma_fisico"),
+	}
+}
+
+// HandleObterCronogramaFisico retorna as etapas programadas, folgas e caminho crítico
+func (h *CronogramaFisicoHandler) HandleObterCronogramaFisico(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	cronograma, err := h.service.ObterCronogramaFisico(r.Context(), obraID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao calcular cronograma físico", obraID)
+		return
+	}
+
+	web.Respond(w, r, cronograma, http.StatusOK)
+}
+
+// HandleAdicionarDependencia cria uma dependência entre duas etapas da obra
+func (h *CronogramaFisicoHandler) HandleAdicionarDependencia(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.AdicionarDependenciaInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	dependencia, err := h.service.AdicionarDependencia(r.Context(), obraID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao adicionar dependência", obraID)
+		return
+	}
+
+	web.Respond(w, r, dependencia, http.StatusCreated)
+}
+
+// HandleRemoverDependencia exclui uma dependência entre etapas
+func (h *CronogramaFisicoHandler) HandleRemoverDependencia(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+	dependenciaID := chi.URLParam(r, "dependenciaId")
+
+	if err := h.service.RemoverDependencia(r.Context(), obraID, dependenciaID); err != nil {
+		h.responderErro(w, r, err, "falha ao remover dependência", obraID)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CronogramaFisicoHandler) responderErro(w http.ResponseWriter, r *http.Request, err error, msg, obraID string) {
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "RECURSO_NAO_ENCONTRADO", "Obra ou dependência não encontrada", http.StatusNotFound)
+	case errors.Is(err, obras_service.ErrEtapaForaDaObra):
+		web.RespondError(w, r, "ETAPA_FORA_DA_OBRA", obras_service.ErrEtapaForaDaObra.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras.ErrDependenciaInvalida):
+		web.RespondError(w, r, "DEPENDENCIA_INVALIDA", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras.ErrCicloDependencias):
+		web.RespondError(w, r, "CICLO_DEPENDENCIAS", obras.ErrCicloDependencias.Error(), http.StatusUnprocessableEntity)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "obra_id", obraID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao processar o cronograma físico", http.StatusInternalServerError)
+	}
+}
@@ -3,11 +3,15 @@ package obras
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	obras_service "github.com/luiszkm/masterCostrutora/internal/service/obras"
 	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
 )
 
@@ -18,6 +22,8 @@ type CronogramaService interface {
 	RegistrarRecebimento(ctx context.Context, cronogramaID string, input dto.RegistrarRecebimentoInput) (*dto.CronogramaRecebimentoOutput, error)
 	ListarPorObraID(ctx context.Context, obraID string) ([]*dto.CronogramaRecebimentoOutput, error)
 	BuscarPorID(ctx context.Context, id string) (*dto.CronogramaRecebimentoOutput, error)
+	PreverCronograma(ctx context.Context, obraID string, input dto.GerarCronogramaInput) (*dto.CronogramaGeradoOutput, error)
+	GerarCronograma(ctx context.Context, obraID string, input dto.GerarCronogramaInput) ([]*dto.CronogramaRecebimentoOutput, error)
 }
 
 // CronogramaHandler gerencia as rotas de cronograma de recebimento
@@ -130,6 +136,70 @@ func (h *CronogramaHandler) HandleBuscarCronograma(w http.ResponseWriter, r *htt
 	web.Respond(w, r, cronograma, http.StatusOK)
 }
 
+// HandlePreverCronograma monta o cronograma a partir do contrato da obra, sem salvar
+func (h *CronogramaHandler) HandlePreverCronograma(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.GerarCronogramaInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	previa, err := h.service.PreverCronograma(r.Context(), obraID, input)
+	if err != nil {
+		h.responderErroGeracao(w, r, err, "falha ao prever cronograma", obraID)
+		return
+	}
+
+	web.Respond(w, r, previa, http.StatusOK)
+}
+
+// HandleGerarCronograma monta e salva o cronograma a partir do contrato da obra
+func (h *CronogramaHandler) HandleGerarCronograma(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.GerarCronogramaInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	cronogramas, err := h.service.GerarCronograma(r.Context(), obraID, input)
+	if err != nil {
+		h.responderErroGeracao(w, r, err, "falha ao gerar cronograma", obraID)
+		return
+	}
+
+	web.Respond(w, r, cronogramas, http.StatusCreated)
+}
+
+func (h *CronogramaHandler) responderErroGeracao(w http.ResponseWriter, r *http.Request, err error, msg, obraID string) {
+	var erroData *time.ParseError
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "OBRA_NAO_ENCONTRADA", "Obra não encontrada", http.StatusNotFound)
+	case errors.As(err, &erroData):
+		web.RespondError(w, r, "DATA_INVALIDA", "Data inválida, use o formato AAAA-MM-DD", http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrParametrosCronograma):
+		web.RespondError(w, r, "PARAMETROS_INVALIDOS", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrEtapaForaDaObra):
+		web.RespondError(w, r, "ETAPA_FORA_DA_OBRA", obras_service.ErrEtapaForaDaObra.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrCronogramaExistente):
+		web.RespondError(w, r, "CRONOGRAMA_EXISTENTE", obras_service.ErrCronogramaExistente.Error(), http.StatusConflict)
+	case errors.Is(err, obras_service.ErrCronogramaComRecebimentos):
+		web.RespondError(w, r, "CRONOGRAMA_COM_RECEBIMENTOS", obras_service.ErrCronogramaComRecebimentos.Error(), http.StatusConflict)
+	case errors.Is(err, obras_service.ErrCronogramaComMedicoes):
+		web.RespondError(w, r, "OBRA_FATURADA_POR_MEDICAO", obras_service.ErrCronogramaComMedicoes.Error(), http.StatusConflict)
+	case errors.Is(err, obras_service.ErrObraSemValorContrato),
+		errors.Is(err, obras_service.ErrTipoCobrancaNaoDefinido):
+		web.RespondError(w, r, "CONTRATO_INCOMPLETO", err.Error(), http.StatusUnprocessableEntity)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "obra_id", obraID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro ao gerar cronograma", http.StatusInternalServerError)
+	}
+}
+
 // SetupCronogramaRoutes configura as rotas do cronograma
 func SetupCronogramaRoutes(r chi.Router, handler *CronogramaHandler) {
 	r.Route("/cronograma-recebimentos", func(r chi.Router) {
@@ -0,0 +1,211 @@
+package obras
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	obras_service "github.com/luiszkm/masterCostrutora/internal/service/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// DiarioObraService define a interface para o service do diário de obra
+type DiarioObraService interface {
+	CriarDiario(ctx context.Context, obraID string, input dto.DiarioObraInput) (*obras.DiarioObra, error)
+	AtualizarDiario(ctx context.Context, obraID, diarioID string, input dto.DiarioObraInput) (*obras.DiarioObra, error)
+	BuscarDiario(ctx context.Context, obraID, diarioID string) (*obras.DiarioObra, error)
+	ListarDiarios(ctx context.Context, obraID string, filtro dto.FiltroDiariosInput) ([]*obras.DiarioObra, error)
+	AdicionarFoto(ctx context.Context, obraID, diarioID string, input dto.AdicionarFotoDiarioInput) (*obras.FotoDiario, error)
+	RemoverFoto(ctx context.Context, obraID, diarioID, fotoID string) error
+	AssinarDiario(ctx context.Context, obraID, diarioID string) (*obras.DiarioObra, error)
+	ExportarPDF(ctx context.Context, obraID string, filtro dto.FiltroDiariosInput) ([]byte, error)
+}
+
+// DiarioObraHandler gerencia as rotas do diário de obra
+type DiarioObraHandler struct {
+	service DiarioObraService
+	logger  *slog.Logger
+}
+
+func NovoDiarioObraHandler(service DiarioObraService, logger *slog.Logger) *DiarioObraHandler {
+	return &DiarioObraHandler{
+		service: service,
+		logger:  logger.With("handler", "diario_obra"),
+	}
+}
+
+// HandleCriarDiario abre o diário da obra para uma data
+func (h *DiarioObraHandler) HandleCriarDiario(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.DiarioObraInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	diario, err := h.service.CriarDiario(r.Context(), obraID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao criar diário de obra", obraID)
+		return
+	}
+
+	web.Respond(w, r, diario, http.StatusCreated)
+}
+
+// HandleAtualizarDiario substitui os registros do dia enquanto o diário não foi assinado
+func (h *DiarioObraHandler) HandleAtualizarDiario(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+	diarioID := chi.URLParam(r, "diarioId")
+
+	var input dto.DiarioObraInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	diario, err := h.service.AtualizarDiario(r.Context(), obraID, diarioID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao atualizar diário de obra", obraID)
+		return
+	}
+
+	web.Respond(w, r, diario, http.StatusOK)
+}
+
+// HandleBuscarDiario retorna um diário da obra
+func (h *DiarioObraHandler) HandleBuscarDiario(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+	diarioID := chi.URLParam(r, "diarioId")
+
+	diario, err := h.service.BuscarDiario(r.Context(), obraID, diarioID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao buscar diário de obra", obraID)
+		return
+	}
+
+	web.Respond(w, r, diario, http.StatusOK)
+}
+
+// HandleListarDiarios lista os diários da obra (?dataInicio=&dataFim= no formato AAAA-MM-DD)
+func (h *DiarioObraHandler) HandleListarDiarios(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	diarios, err := h.service.ListarDiarios(r.Context(), obraID, filtroDiarios(r))
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao listar diários de obra", obraID)
+		return
+	}
+
+	web.Respond(w, r, diarios, http.StatusOK)
+}
+
+// HandleAdicionarFoto anexa uma foto ao diário
+func (h *DiarioObraHandler) HandleAdicionarFoto(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+	diarioID := chi.URLParam(r, "diarioId")
+
+	var input dto.AdicionarFotoDiarioInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	foto, err := h.service.AdicionarFoto(r.Context(), obraID, diarioID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao anexar foto ao diário", obraID)
+		return
+	}
+
+	web.Respond(w, r, foto, http.StatusCreated)
+}
+
+// HandleRemoverFoto retira uma foto do diário
+func (h *DiarioObraHandler) HandleRemoverFoto(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+	diarioID := chi.URLParam(r, "diarioId")
+	fotoID := chi.URLParam(r, "fotoId")
+
+	if err := h.service.RemoverFoto(r.Context(), obraID, diarioID, fotoID); err != nil {
+		h.responderErro(w, r, err, "falha ao remover foto do diário", obraID)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleAssinarDiario registra a assinatura do engenheiro e trava o diário
+func (h *DiarioObraHandler) HandleAssinarDiario(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+	diarioID := chi.URLParam(r, "diarioId")
+
+	diario, err := h.service.AssinarDiario(r.Context(), obraID, diarioID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao assinar diário de obra", obraID)
+		return
+	}
+
+	web.Respond(w, r, diario, http.StatusOK)
+}
+
+// HandleExportarPDF gera o PDF dos diários do período (?dataInicio=&dataFim=)
+func (h *DiarioObraHandler) HandleExportarPDF(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	conteudo, err := h.service.ExportarPDF(r.Context(), obraID, filtroDiarios(r))
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao exportar diários de obra", obraID)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="diario-obra-%s.pdf"`, obraID))
+	w.Header().Set("Content-Length", strconv.Itoa(len(conteudo)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(conteudo)
+}
+
+func filtroDiarios(r *http.Request) dto.FiltroDiariosInput {
+	return dto.FiltroDiariosInput{
+		DataInicio: r.URL.Query().Get("dataInicio"),
+		DataFim:    r.URL.Query().Get("dataFim"),
+	}
+}
+
+func (h *DiarioObraHandler) responderErro(w http.ResponseWriter, r *http.Request, err error, msg, obraID string) {
+	var erroData *time.ParseError
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "RECURSO_NAO_ENCONTRADO", "Obra ou diário não encontrado", http.StatusNotFound)
+	case errors.Is(err, obras.ErrFotoNaoEncontrada):
+		web.RespondError(w, r, "FOTO_NAO_ENCONTRADA", obras.ErrFotoNaoEncontrada.Error(), http.StatusNotFound)
+	case errors.As(err, &erroData):
+		web.RespondError(w, r, "DATA_INVALIDA", "Data inválida, use o formato AAAA-MM-DD", http.StatusBadRequest)
+	case errors.Is(err, obras.ErrDiarioInvalido):
+		web.RespondError(w, r, "DIARIO_INVALIDO", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrEtapaForaDaObra):
+		web.RespondError(w, r, "ETAPA_FORA_DA_OBRA", obras_service.ErrEtapaForaDaObra.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrPeriodoDiariosInvalido):
+		web.RespondError(w, r, "PERIODO_INVALIDO", obras_service.ErrPeriodoDiariosInvalido.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrDiarioJaExiste):
+		web.RespondError(w, r, "DIARIO_JA_EXISTE", obras_service.ErrDiarioJaExiste.Error(), http.StatusConflict)
+	case errors.Is(err, obras.ErrDiarioAssinado):
+		web.RespondError(w, r, "DIARIO_ASSINADO", obras.ErrDiarioAssinado.Error(), http.StatusConflict)
+	case errors.Is(err, obras.ErrDiarioDataFutura),
+		errors.Is(err, obras_service.ErrDiarioObraCancelada),
+		errors.Is(err, obras_service.ErrFuncionarioNaoAlocado):
+		web.RespondError(w, r, "REGRA_NEGOCIO_VIOLADA", err.Error(), http.StatusUnprocessableEntity)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "obra_id", obraID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao processar o diário de obra", http.StatusInternalServerError)
+	}
+}
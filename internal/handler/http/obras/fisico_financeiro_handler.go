@@ -0,0 +1,49 @@
+package obras
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// FisicoFinanceiroService define a interface para o service do cronograma físico-financeiro
+type FisicoFinanceiroService interface {
+	ObterFisicoFinanceiro(ctx context.Context, obraID string) (*dto.FisicoFinanceiroOutput, error)
+}
+
+// FisicoFinanceiroHandler expõe a curva S e os indicadores de valor agregado da obra
+type FisicoFinanceiroHandler struct {
+	service FisicoFinanceiroService
+	logger  *slog.Logger
+}
+
+func NovoFisicoFinanceiroHandler(service FisicoFinanceiroService, logger *slog.Logger) *FisicoFinanceiroHandler {
+	return &FisicoFinanceiroHandler{
+		service: service,
+		logger:  logger.With("handler", "fisico_financeiro"),
+	}
+}
+
+// HandleObterFisicoFinanceiro retorna o previsto x realizado mensal e os indicadores SPI/CPI
+func (h *FisicoFinanceiroHandler) HandleObterFisicoFinanceiro(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	resultado, err := h.service.ObterFisicoFinanceiro(r.Context(), obraID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNaoEncontrado) {
+			web.RespondError(w, r, "OBRA_NAO_ENCONTRADA", "Obra não encontrada", http.StatusNotFound)
+			return
+		}
+		h.logger.ErrorContext(r.Context(), "falha ao montar cronograma físico-financeiro", "obra_id", obraID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro ao montar o cronograma físico-financeiro", http.StatusInternalServerError)
+		return
+	}
+
+	web.Respond(w, r, resultado, http.StatusOK)
+}
@@ -5,16 +5,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/clientes"
 	"github.com/luiszkm/masterCostrutora/internal/domain/common"
 	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
 	"github.com/luiszkm/masterCostrutora/internal/handler/web"
 	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	obras_service "github.com/luiszkm/masterCostrutora/internal/service/obras"
 	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
 )
 
@@ -25,7 +27,7 @@ type Service interface {
 	BuscarDashboard(ctx context.Context, id string) (*dto.ObraDashboard, error)
 	AdicionarEtapa(ctx context.Context, obraID string, input dto.AdicionarEtapaInput) (*obras.Etapa, error)
 	AtualizarStatusEtapa(ctx context.Context, etapaID string, input dto.AtualizarStatusEtapaInput) (*obras.Etapa, error)
-	AlocarFuncionarios(ctx context.Context, obraID string, input dto.AlocarFuncionariosInput) ([]*obras.Alocacao, error)
+	AtualizarProgressoEtapa(ctx context.Context, etapaID string, input dto.AtualizarProgressoEtapaInput) (*obras.Etapa, error)
 	ListarObras(ctx context.Context, filtros common.ListarFiltros) (*common.RespostaPaginada[*dto.ObraListItemDTO], error)
 	DeletarObra(ctx context.Context, obraID string) error
 	BuscarDetalhesPorID(ctx context.Context, obraID string) (*dto.ObraDetalhadaDTO, error)
@@ -143,6 +145,31 @@ func (h *Handler) HandleCriarObra(w http.ResponseWriter, r *http.Request) {
 
 	obra, err := h.service.CriarNovaObra(r.Context(), input)
 	if err != nil {
+		if errors.Is(err, clientes.ErrClienteNaoCadastrado) {
+			web.RespondError(w, r, "CLIENTE_NAO_CADASTRADO", "Cadastre o cliente antes de criar a obra", http.StatusUnprocessableEntity)
+			return
+		}
+		if errors.Is(err, obras.ErrRetencaoInvalida) {
+			web.RespondError(w, r, "RETENCAO_INVALIDA", err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch {
+		case errors.Is(err, obras_service.ErrOrigemEstruturaAmbigua):
+			web.RespondError(w, r, "ORIGEM_AMBIGUA", obras_service.ErrOrigemEstruturaAmbigua.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, obras_service.ErrModeloObraNaoEncontrado):
+			web.RespondError(w, r, "MODELO_NAO_ENCONTRADO", obras_service.ErrModeloObraNaoEncontrado.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, obras_service.ErrObraOrigemNaoEncontrada):
+			web.RespondError(w, r, "OBRA_ORIGEM_NAO_ENCONTRADA", obras_service.ErrObraOrigemNaoEncontrada.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, obras.ErrModeloObraInvalido):
+			web.RespondError(w, r, "MODELO_INVALIDO", err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, obras_service.ErrParametrosCronograma), errors.Is(err, obras_service.ErrEtapaForaDaObra):
+			web.RespondError(w, r, "CRONOGRAMA_DO_MODELO_INVALIDO", err.Error(), http.StatusBadRequest)
+			return
+		}
 		// Aqui poderíamos ter uma lógica mais granular para mapear
 		// erros de serviço para status HTTP (ex: 400, 409, etc).
 		h.logger.ErrorContext(r.Context(), "falha ao criar obra", "erro", err)
@@ -218,36 +245,49 @@ func (h *Handler) HandleAtualizarEtapaStatus(w http.ResponseWriter, r *http.Requ
 
 	etapa, err := h.service.AtualizarStatusEtapa(r.Context(), etapaID, input)
 	if err != nil {
-		if errors.Is(err, postgres.ErrNaoEncontrado) {
-			web.RespondError(w, r, "ETAPA_NAO_ENCONTRADA", "Etapa não encontrada", http.StatusNotFound)
-			return
-		}
-		h.logger.ErrorContext(r.Context(), "falha ao atualizar etapa", "erro", err)
-		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao processar sua requisição", http.StatusInternalServerError)
+		h.responderErroEtapa(w, r, err)
 		return
 	}
 	web.Respond(w, r, etapa, http.StatusOK)
 }
-func (h *Handler) HandleAlocarFuncionario(w http.ResponseWriter, r *http.Request) {
-	obraID := chi.URLParam(r, "obraId")
 
-	var input dto.AlocarFuncionariosInput
+// HandleAtualizarProgressoEtapa registra o percentual executado, o peso e as datas reais da etapa.
+func (h *Handler) HandleAtualizarProgressoEtapa(w http.ResponseWriter, r *http.Request) {
+	etapaID := chi.URLParam(r, "etapaId")
+
+	var input dto.AtualizarProgressoEtapaInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
 		return
 	}
-	fmt.Println("Alocando funcionários para a obra:", obraID, "com os dados:", input)
 
-	alocacoes, err := h.service.AlocarFuncionarios(r.Context(), obraID, input)
-	fmt.Println(alocacoes)
+	etapa, err := h.service.AtualizarProgressoEtapa(r.Context(), etapaID, input)
 	if err != nil {
-		// TODO: Tratar erros específicos (obra ou funcionário não encontrado) com 404
-		h.logger.ErrorContext(r.Context(), "falha ao alocar funcionário", "erro", err)
-		web.RespondError(w, r, "ERRO_INTERNO", "Erro ao alocar funcionário", http.StatusInternalServerError)
+		h.responderErroEtapa(w, r, err)
 		return
 	}
+	web.Respond(w, r, etapa, http.StatusOK)
+}
 
-	web.Respond(w, r, alocacoes, http.StatusCreated)
+func (h *Handler) responderErroEtapa(w http.ResponseWriter, r *http.Request, err error) {
+	var erroData *time.ParseError
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "ETAPA_NAO_ENCONTRADA", "Etapa não encontrada", http.StatusNotFound)
+	case errors.As(err, &erroData):
+		web.RespondError(w, r, "DATA_INVALIDA", "Datas devem estar no formato YYYY-MM-DD", http.StatusBadRequest)
+	case errors.Is(err, obras.ErrTransicaoEtapaInvalida),
+		errors.Is(err, obras.ErrPercentualInvalido),
+		errors.Is(err, obras.ErrReaberturaSemProgresso),
+		errors.Is(err, obras.ErrPesoInvalido),
+		errors.Is(err, obras.ErrDatasReaisInvalidas):
+		web.RespondError(w, r, "REGRA_NEGOCIO_VIOLADA", err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, obras_service.ErrChecklistsObrigatoriasAbertas):
+		web.RespondError(w, r, "CHECKLISTS_PENDENTES", err.Error(), http.StatusUnprocessableEntity)
+	default:
+		h.logger.ErrorContext(r.Context(), "falha ao atualizar etapa", "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao processar sua requisição", http.StatusInternalServerError)
+	}
 }
 func (h *Handler) HandleListarObras(w http.ResponseWriter, r *http.Request) {
 	filtros := web.ParseFiltros(r)
@@ -269,6 +309,11 @@ func (h *Handler) HandleDeletarObra(w http.ResponseWriter, r *http.Request) {
 			web.RespondError(w, r, "OBRA_NAO_ENCONTRADA", "Obra não encontrada", http.StatusNotFound)
 			return
 		}
+		var erroTransicao *obras.ErroTransicao
+		if errors.As(err, &erroTransicao) {
+			web.RespondError(w, r, "OBRA_NAO_EXCLUIVEL", erroTransicao.Error(), http.StatusConflict)
+			return
+		}
 		h.logger.ErrorContext(r.Context(), "falha ao deletar obra", "erro", err)
 		web.RespondError(w, r, "ERRO_INTERNO", "Erro ao deletar obra", http.StatusInternalServerError)
 		return
@@ -308,6 +353,22 @@ func (h *Handler) HandleAtualizarObra(w http.ResponseWriter, r *http.Request) {
 	}
 	_, err := h.service.AtualizarObra(r.Context(), obraID, input)
 	if err != nil {
+		if errors.Is(err, obras_service.ErrStatusSomentePorTransicao) {
+			web.RespondError(w, r, "TRANSICAO_OBRIGATORIA", obras_service.ErrStatusSomentePorTransicao.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, obras.ErrContratoComAditivo) {
+			web.RespondError(w, r, "CONTRATO_COM_ADITIVO", obras.ErrContratoComAditivo.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, clientes.ErrClienteNaoCadastrado) {
+			web.RespondError(w, r, "CLIENTE_NAO_CADASTRADO", clientes.ErrClienteNaoCadastrado.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		if errors.Is(err, obras.ErrRetencaoInvalida) {
+			web.RespondError(w, r, "RETENCAO_INVALIDA", err.Error(), http.StatusBadRequest)
+			return
+		}
 		h.logger.ErrorContext(r.Context(), "falha ao atualizar obra", "erro", err)
 		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao processar sua requisição", http.StatusInternalServerError)
 		return
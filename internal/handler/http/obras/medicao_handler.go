@@ -0,0 +1,208 @@
+package obras
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	obras_service "github.com/luiszkm/masterCostrutora/internal/service/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// MedicaoService define a interface para o service de medições
+type MedicaoService interface {
+	CriarMedicao(ctx context.Context, obraID string, input dto.MedicaoInput) (*obras.Medicao, error)
+	AtualizarMedicao(ctx context.Context, obraID, medicaoID string, input dto.MedicaoInput) (*obras.Medicao, error)
+	BuscarMedicao(ctx context.Context, obraID, medicaoID string) (*obras.Medicao, error)
+	ListarMedicoes(ctx context.Context, obraID string) ([]*obras.Medicao, error)
+	ExcluirMedicao(ctx context.Context, obraID, medicaoID string) error
+	EnviarMedicao(ctx context.Context, obraID, medicaoID string) (*obras.Medicao, error)
+	AprovarMedicao(ctx context.Context, obraID, medicaoID string, input dto.AprovarMedicaoInput) (*obras.Medicao, error)
+	RejeitarMedicao(ctx context.Context, obraID, medicaoID string, input dto.RejeitarMedicaoInput) (*obras.Medicao, error)
+}
+
+// MedicaoHandler gerencia as rotas de medições da obra
+type MedicaoHandler struct {
+	service MedicaoService
+	logger  *slog.Logger
+}
+
+func NovoMedicaoHandler(service MedicaoService, logger *slog.Logger) *MedicaoHandler {
+	return &MedicaoHandler{
+		service: service,
+		logger:  logger.With("handler", "medicao"),
+	}
+}
+
+// HandleCriarMedicao abre a próxima medição da obra
+func (h *MedicaoHandler) HandleCriarMedicao(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.MedicaoInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	medicao, err := h.service.CriarMedicao(r.Context(), obraID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao criar medição", obraID)
+		return
+	}
+
+	web.Respond(w, r, medicao, http.StatusCreated)
+}
+
+// HandleAtualizarMedicao substitui período e itens de uma medição não aprovada
+func (h *MedicaoHandler) HandleAtualizarMedicao(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+	medicaoID := chi.URLParam(r, "medicaoId")
+
+	var input dto.MedicaoInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	medicao, err := h.service.AtualizarMedicao(r.Context(), obraID, medicaoID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao atualizar medição", obraID)
+		return
+	}
+
+	web.Respond(w, r, medicao, http.StatusOK)
+}
+
+// HandleBuscarMedicao retorna uma medição da obra
+func (h *MedicaoHandler) HandleBuscarMedicao(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+	medicaoID := chi.URLParam(r, "medicaoId")
+
+	medicao, err := h.service.BuscarMedicao(r.Context(), obraID, medicaoID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao buscar medição", obraID)
+		return
+	}
+
+	web.Respond(w, r, medicao, http.StatusOK)
+}
+
+// HandleListarMedicoes lista as medições da obra
+func (h *MedicaoHandler) HandleListarMedicoes(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	medicoes, err := h.service.ListarMedicoes(r.Context(), obraID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao listar medições", obraID)
+		return
+	}
+
+	web.Respond(w, r, medicoes, http.StatusOK)
+}
+
+// HandleExcluirMedicao descarta uma medição ainda não aprovada
+func (h *MedicaoHandler) HandleExcluirMedicao(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+	medicaoID := chi.URLParam(r, "medicaoId")
+
+	if err := h.service.ExcluirMedicao(r.Context(), obraID, medicaoID); err != nil {
+		h.responderErro(w, r, err, "falha ao excluir medição", obraID)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleEnviarMedicao submete a medição à aprovação do cliente
+func (h *MedicaoHandler) HandleEnviarMedicao(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+	medicaoID := chi.URLParam(r, "medicaoId")
+
+	medicao, err := h.service.EnviarMedicao(r.Context(), obraID, medicaoID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao enviar medição", obraID)
+		return
+	}
+
+	web.Respond(w, r, medicao, http.StatusOK)
+}
+
+// HandleAprovarMedicao registra o aceite do cliente e gera a parcela de recebimento
+func (h *MedicaoHandler) HandleAprovarMedicao(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+	medicaoID := chi.URLParam(r, "medicaoId")
+
+	var input dto.AprovarMedicaoInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	medicao, err := h.service.AprovarMedicao(r.Context(), obraID, medicaoID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao aprovar medição", obraID)
+		return
+	}
+
+	web.Respond(w, r, medicao, http.StatusOK)
+}
+
+// HandleRejeitarMedicao registra a recusa do cliente
+func (h *MedicaoHandler) HandleRejeitarMedicao(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+	medicaoID := chi.URLParam(r, "medicaoId")
+
+	var input dto.RejeitarMedicaoInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	medicao, err := h.service.RejeitarMedicao(r.Context(), obraID, medicaoID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao rejeitar medição", obraID)
+		return
+	}
+
+	web.Respond(w, r, medicao, http.StatusOK)
+}
+
+func (h *MedicaoHandler) responderErro(w http.ResponseWriter, r *http.Request, err error, msg, obraID string) {
+	var erroData *time.ParseError
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "RECURSO_NAO_ENCONTRADO", "Obra ou medição não encontrada", http.StatusNotFound)
+	case errors.As(err, &erroData):
+		web.RespondError(w, r, "DATA_INVALIDA", "Data inválida, use o formato AAAA-MM-DD", http.StatusBadRequest)
+	case errors.Is(err, obras.ErrMedicaoInvalida):
+		web.RespondError(w, r, "MEDICAO_INVALIDA", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrPeriodoMedicaoObrigatorio):
+		web.RespondError(w, r, "PERIODO_INVALIDO", obras_service.ErrPeriodoMedicaoObrigatorio.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrEtapaForaDaObra):
+		web.RespondError(w, r, "ETAPA_FORA_DA_OBRA", obras_service.ErrEtapaForaDaObra.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras.ErrMedicaoAprovada):
+		web.RespondError(w, r, "MEDICAO_APROVADA", obras.ErrMedicaoAprovada.Error(), http.StatusConflict)
+	case errors.Is(err, obras_service.ErrMedicaoEmAberto):
+		web.RespondError(w, r, "MEDICAO_EM_ABERTO", obras_service.ErrMedicaoEmAberto.Error(), http.StatusConflict)
+	case errors.Is(err, obras_service.ErrMedicaoComCronogramaObra):
+		web.RespondError(w, r, "CRONOGRAMA_DO_CONTRATO", obras_service.ErrMedicaoComCronogramaObra.Error(), http.StatusConflict)
+	case errors.Is(err, obras.ErrTransicaoMedicaoInvalida):
+		web.RespondError(w, r, "TRANSICAO_INVALIDA", err.Error(), http.StatusConflict)
+	case errors.Is(err, obras.ErrMedicaoAcimaDoContratado),
+		errors.Is(err, obras_service.ErrPeriodoMedicaoSobreposto),
+		errors.Is(err, obras_service.ErrObraNaoCobradaPorEtapas),
+		errors.Is(err, obras_service.ErrObraSemValorContrato),
+		errors.Is(err, obras_service.ErrMedicaoObraCancelada):
+		web.RespondError(w, r, "REGRA_NEGOCIO_VIOLADA", err.Error(), http.StatusUnprocessableEntity)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "obra_id", obraID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao processar a medição", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,125 @@
+package obras
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	obras_service "github.com/luiszkm/masterCostrutora/internal/service/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// ModeloObraService define a interface para o service de modelos de obra
+type ModeloObraService interface {
+	CriarModelo(ctx context.Context, input dto.SalvarModeloObraInput) (*obras.ModeloObra, error)
+	AtualizarModelo(ctx context.Context, modeloID string, input dto.SalvarModeloObraInput) (*obras.ModeloObra, error)
+	BuscarModelo(ctx context.Context, modeloID string) (*obras.ModeloObra, error)
+	ListarModelos(ctx context.Context) ([]*obras.ModeloObra, error)
+	DeletarModelo(ctx context.Context, modeloID string) error
+}
+
+// ModeloObraHandler gerencia as rotas dos modelos de obra
+type ModeloObraHandler struct {
+	service ModeloObraService
+	logger  *slog.Logger
+}
+
+func NovoModeloObraHandler(service ModeloObraService, logger *slog.Logger) *ModeloObraHandler {
+	return &ModeloObraHandler{
+		service: service,
+		logger:  logger.With("handler", "modelo_obra"),
+	}
+}
+
+// HandleListarModelos lista os modelos de obra disponíveis na criação
+func (h *ModeloObraHandler) HandleListarModelos(w http.ResponseWriter, r *http.Request) {
+	modelos, err := h.service.ListarModelos(r.Context())
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao listar modelos de obra", "")
+		return
+	}
+
+	web.Respond(w, r, modelos, http.StatusOK)
+}
+
+// HandleBuscarModelo retorna um modelo de obra
+func (h *ModeloObraHandler) HandleBuscarModelo(w http.ResponseWriter, r *http.Request) {
+	modeloID := chi.URLParam(r, "modeloId")
+
+	modelo, err := h.service.BuscarModelo(r.Context(), modeloID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao buscar modelo de obra", modeloID)
+		return
+	}
+
+	web.Respond(w, r, modelo, http.StatusOK)
+}
+
+// HandleCriarModelo cadastra um modelo, informado por completo ou copiado de uma obra (obraOrigemId)
+func (h *ModeloObraHandler) HandleCriarModelo(w http.ResponseWriter, r *http.Request) {
+	var input dto.SalvarModeloObraInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	modelo, err := h.service.CriarModelo(r.Context(), input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao criar modelo de obra", "")
+		return
+	}
+
+	web.Respond(w, r, modelo, http.StatusCreated)
+}
+
+// HandleAtualizarModelo substitui o conteúdo de um modelo de obra
+func (h *ModeloObraHandler) HandleAtualizarModelo(w http.ResponseWriter, r *http.Request) {
+	modeloID := chi.URLParam(r, "modeloId")
+
+	var input dto.SalvarModeloObraInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	modelo, err := h.service.AtualizarModelo(r.Context(), modeloID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao atualizar modelo de obra", modeloID)
+		return
+	}
+
+	web.Respond(w, r, modelo, http.StatusOK)
+}
+
+// HandleDeletarModelo remove um modelo de obra; as obras criadas com ele não mudam
+func (h *ModeloObraHandler) HandleDeletarModelo(w http.ResponseWriter, r *http.Request) {
+	modeloID := chi.URLParam(r, "modeloId")
+
+	if err := h.service.DeletarModelo(r.Context(), modeloID); err != nil {
+		h.responderErro(w, r, err, "falha ao deletar modelo de obra", modeloID)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ModeloObraHandler) responderErro(w http.ResponseWriter, r *http.Request, err error, msg, modeloID string) {
+	switch {
+	case errors.Is(err, obras_service.ErrModeloObraNaoEncontrado):
+		web.RespondError(w, r, "MODELO_NAO_ENCONTRADO", obras_service.ErrModeloObraNaoEncontrado.Error(), http.StatusNotFound)
+	case errors.Is(err, obras_service.ErrObraOrigemNaoEncontrada):
+		web.RespondError(w, r, "OBRA_ORIGEM_NAO_ENCONTRADA", obras_service.ErrObraOrigemNaoEncontrada.Error(), http.StatusNotFound)
+	case errors.Is(err, obras_service.ErrModeloObraNomeDuplicado):
+		web.RespondError(w, r, "MODELO_DUPLICADO", obras_service.ErrModeloObraNomeDuplicado.Error(), http.StatusConflict)
+	case errors.Is(err, obras.ErrModeloObraInvalido):
+		web.RespondError(w, r, "MODELO_INVALIDO", err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "modelo_id", modeloID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao processar o modelo de obra", http.StatusInternalServerError)
+	}
+}
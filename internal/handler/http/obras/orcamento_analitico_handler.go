@@ -0,0 +1,81 @@
+package obras
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	obras_service "github.com/luiszkm/masterCostrutora/internal/service/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// OrcamentoAnaliticoService define a interface para o service de orçamento analítico e controle de custos
+type OrcamentoAnaliticoService interface {
+	DefinirOrcamentoAnalitico(ctx context.Context, obraID string, input dto.DefinirOrcamentoAnaliticoInput) (*obras.OrcamentoAnalitico, error)
+	ObterControleCustos(ctx context.Context, obraID string) (*dto.ControleCustosOutput, error)
+}
+
+// OrcamentoAnaliticoHandler gerencia as rotas do orçamento analítico (previsto x realizado) da obra
+type OrcamentoAnaliticoHandler struct {
+	service OrcamentoAnaliticoService
+	logger  *slog.Logger
+}
+
+func NovoOrcamentoAnaliticoHandler(service OrcamentoAnaliticoService, logger *slog.Logger) *OrcamentoAnaliticoHandler {
+	return &OrcamentoAnaliticoHandler{
+		service: service,
+		logger:  logger.With("handler", "orcamento_analitico"),
+	}
+}
+
+// HandleObterControleCustos retorna o previsto x comprometido x realizado com os alertas de estouro
+func (h *OrcamentoAnaliticoHandler) HandleObterControleCustos(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	controle, err := h.service.ObterControleCustos(r.Context(), obraID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao obter controle de custos", obraID)
+		return
+	}
+
+	web.Respond(w, r, controle, http.StatusOK)
+}
+
+// HandleDefinirOrcamentoAnalitico substitui a linha de base de custo da obra
+func (h *OrcamentoAnaliticoHandler) HandleDefinirOrcamentoAnalitico(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.DefinirOrcamentoAnaliticoInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	orcamento, err := h.service.DefinirOrcamentoAnalitico(r.Context(), obraID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao definir orçamento analítico", obraID)
+		return
+	}
+
+	web.Respond(w, r, orcamento, http.StatusOK)
+}
+
+func (h *OrcamentoAnaliticoHandler) responderErro(w http.ResponseWriter, r *http.Request, err error, msg, obraID string) {
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "OBRA_NAO_ENCONTRADA", "Obra não encontrada", http.StatusNotFound)
+	case errors.Is(err, obras_service.ErrEtapaForaDaObra):
+		web.RespondError(w, r, "ETAPA_FORA_DA_OBRA", obras_service.ErrEtapaForaDaObra.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras.ErrOrcamentoAnaliticoInvalido):
+		web.RespondError(w, r, "ORCAMENTO_ANALITICO_INVALIDO", err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "obra_id", obraID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao processar o orçamento analítico", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,64 @@
+package obras
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// ResultadoService define a interface para o service de rentabilidade das obras
+type ResultadoService interface {
+	ObterResultadoObra(ctx context.Context, obraID string) (*dto.ResultadoObraOutput, error)
+	ObterResultadoCarteira(ctx context.Context, status string) (*dto.ResultadoCarteiraOutput, error)
+}
+
+// ResultadoHandler gerencia as rotas do relatório de resultado (margem) das obras
+type ResultadoHandler struct {
+	service ResultadoService
+	logger  *slog.Logger
+}
+
+func NovoResultadoHandler(service ResultadoService, logger *slog.Logger) *ResultadoHandler {
+	return &ResultadoHandler{
+		service: service,
+		logger:  logger.With("handler", "resultado"),
+	}
+}
+
+// HandleObterResultadoObra retorna receitas, custos, margens e exposição de caixa da obra
+func (h *ResultadoHandler) HandleObterResultadoObra(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	resultado, err := h.service.ObterResultadoObra(r.Context(), obraID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNaoEncontrado) {
+			web.RespondError(w, r, "OBRA_NAO_ENCONTRADA", "Obra não encontrada", http.StatusNotFound)
+			return
+		}
+		h.logger.ErrorContext(r.Context(), "falha ao calcular resultado da obra", "obra_id", obraID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao calcular o resultado da obra", http.StatusInternalServerError)
+		return
+	}
+
+	web.Respond(w, r, resultado, http.StatusOK)
+}
+
+// HandleObterResultadoCarteira retorna o resultado consolidado das obras (filtro opcional ?status=)
+func (h *ResultadoHandler) HandleObterResultadoCarteira(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+
+	resultado, err := h.service.ObterResultadoCarteira(r.Context(), status)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "falha ao calcular resultado da carteira", "status", status, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao calcular o resultado da carteira", http.StatusInternalServerError)
+		return
+	}
+
+	web.Respond(w, r, resultado, http.StatusOK)
+}
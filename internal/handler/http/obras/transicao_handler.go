@@ -0,0 +1,92 @@
+package obras
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	obras_service "github.com/luiszkm/masterCostrutora/internal/service/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// TransicaoService define a interface para o service de ciclo de vida da obra
+type TransicaoService interface {
+	ListarTransicoes(ctx context.Context, obraID string) (*dto.TransicoesObraOutput, error)
+	ExecutarTransicao(ctx context.Context, obraID string, input dto.ExecutarTransicaoInput) (*dto.TransicoesObraOutput, error)
+}
+
+// TransicaoHandler gerencia as rotas de transição de status da obra
+type TransicaoHandler struct {
+	service TransicaoService
+	logger  *slog.Logger
+}
+
+func NovoTransicaoHandler(service TransicaoService, logger *slog.Logger) *TransicaoHandler {
+	return &TransicaoHandler{
+		service: service,
+		logger:  logger.With("handler", "transicao_obra"),
+	}
+}
+
+// erroTransicaoResponse estende o erro padrão com os motivos do bloqueio
+type erroTransicaoResponse struct {
+	web.ErrorResponse
+	Motivos []string `json:"motivos"`
+}
+
+// HandleListarTransicoes lista as transições da obra e o que impede cada uma
+func (h *TransicaoHandler) HandleListarTransicoes(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	transicoes, err := h.service.ListarTransicoes(r.Context(), obraID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNaoEncontrado) {
+			web.RespondError(w, r, "OBRA_NAO_ENCONTRADA", "Obra não encontrada", http.StatusNotFound)
+			return
+		}
+		h.logger.ErrorContext(r.Context(), "falha ao listar transições da obra", "obra_id", obraID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro ao listar transições da obra", http.StatusInternalServerError)
+		return
+	}
+
+	web.Respond(w, r, transicoes, http.StatusOK)
+}
+
+// HandleExecutarTransicao inicia, conclui ou cancela a obra
+func (h *TransicaoHandler) HandleExecutarTransicao(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.ExecutarTransicaoInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	resultado, err := h.service.ExecutarTransicao(r.Context(), obraID, input)
+	if err != nil {
+		var erroTransicao *obras.ErroTransicao
+		switch {
+		case errors.Is(err, postgres.ErrNaoEncontrado):
+			web.RespondError(w, r, "OBRA_NAO_ENCONTRADA", "Obra não encontrada", http.StatusNotFound)
+		case errors.Is(err, obras_service.ErrMotivoObrigatorio):
+			web.RespondError(w, r, "MOTIVO_OBRIGATORIO", obras_service.ErrMotivoObrigatorio.Error(), http.StatusBadRequest)
+		case errors.As(err, &erroTransicao):
+			web.Respond(w, r, erroTransicaoResponse{
+				ErrorResponse: web.ErrorResponse{Codigo: "TRANSICAO_NAO_PERMITIDA", Mensagem: erroTransicao.Error()},
+				Motivos:       erroTransicao.Motivos,
+			}, http.StatusUnprocessableEntity)
+		default:
+			h.logger.ErrorContext(r.Context(), "falha ao executar transição da obra", "obra_id", obraID, "transicao", input.Transicao, "erro", err)
+			web.RespondError(w, r, "ERRO_INTERNO", "Erro ao alterar o status da obra", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	web.Respond(w, r, resultado, http.StatusOK)
+}
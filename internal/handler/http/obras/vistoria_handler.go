@@ -0,0 +1,228 @@
+package obras
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	obras_service "github.com/luiszkm/masterCostrutora/internal/service/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// VistoriaService define a interface para o service de vistoria e termo de entrega
+type VistoriaService interface {
+	AbrirVistoria(ctx context.Context, obraID string, input dto.AbrirVistoriaInput) (*obras.Vistoria, error)
+	EncerrarVistoria(ctx context.Context, obraID, vistoriaID string) (*obras.Vistoria, error)
+	ListarVistorias(ctx context.Context, obraID string) (*dto.VistoriasObraOutput, error)
+	AdicionarPendencia(ctx context.Context, obraID string, input dto.PendenciaVistoriaInput) (*obras.PendenciaVistoria, error)
+	AtualizarPendencia(ctx context.Context, obraID, pendenciaID string, input dto.PendenciaVistoriaInput) (*obras.PendenciaVistoria, error)
+	MarcarCorrigida(ctx context.Context, obraID, pendenciaID string) (*obras.PendenciaVistoria, error)
+	VerificarPendencia(ctx context.Context, obraID, vistoriaID, pendenciaID string, input dto.VerificarPendenciaInput) (*obras.PendenciaVistoria, error)
+	RemoverPendencia(ctx context.Context, obraID, pendenciaID string) error
+	GerarTermoEntrega(ctx context.Context, obraID string) ([]byte, error)
+}
+
+// VistoriaHandler gerencia as rotas de vistoria, pendências e termo de entrega
+type VistoriaHandler struct {
+	service VistoriaService
+	logger  *slog.Logger
+}
+
+func NovoVistoriaHandler(service VistoriaService, logger *slog.Logger) *VistoriaHandler {
+	return &VistoriaHandler{
+		service: service,
+		logger:  logger.With("handler", "vistoria"),
+	}
+}
+
+// HandleListarVistorias retorna as rodadas de vistoria e a lista de pendências da obra
+func (h *VistoriaHandler) HandleListarVistorias(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	out, err := h.service.ListarVistorias(r.Context(), obraID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao listar vistorias", obraID)
+		return
+	}
+
+	web.Respond(w, r, out, http.StatusOK)
+}
+
+// HandleAbrirVistoria abre a próxima rodada de vistoria (a primeira ou uma revistoria)
+func (h *VistoriaHandler) HandleAbrirVistoria(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.AbrirVistoriaInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	vistoria, err := h.service.AbrirVistoria(r.Context(), obraID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao abrir vistoria", obraID)
+		return
+	}
+
+	web.Respond(w, r, vistoria, http.StatusCreated)
+}
+
+// HandleEncerrarVistoria encerra a rodada de vistoria
+func (h *VistoriaHandler) HandleEncerrarVistoria(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	vistoria, err := h.service.EncerrarVistoria(r.Context(), obraID, chi.URLParam(r, "vistoriaId"))
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao encerrar vistoria", obraID)
+		return
+	}
+
+	web.Respond(w, r, vistoria, http.StatusOK)
+}
+
+// HandleAdicionarPendencia aponta uma pendência na vistoria aberta
+func (h *VistoriaHandler) HandleAdicionarPendencia(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.PendenciaVistoriaInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	pendencia, err := h.service.AdicionarPendencia(r.Context(), obraID, input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao adicionar pendência", obraID)
+		return
+	}
+
+	web.Respond(w, r, pendencia, http.StatusCreated)
+}
+
+// HandleAtualizarPendencia altera os dados de uma pendência não resolvida
+func (h *VistoriaHandler) HandleAtualizarPendencia(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.PendenciaVistoriaInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	pendencia, err := h.service.AtualizarPendencia(r.Context(), obraID, chi.URLParam(r, "pendenciaId"), input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao atualizar pendência", obraID)
+		return
+	}
+
+	web.Respond(w, r, pendencia, http.StatusOK)
+}
+
+// HandleRemoverPendencia remove uma pendência apontada por engano na vistoria aberta
+func (h *VistoriaHandler) HandleRemoverPendencia(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	if err := h.service.RemoverPendencia(r.Context(), obraID, chi.URLParam(r, "pendenciaId")); err != nil {
+		h.responderErro(w, r, err, "falha ao remover pendência", obraID)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleMarcarCorrigida registra que a pendência foi corrigida e aguarda revistoria
+func (h *VistoriaHandler) HandleMarcarCorrigida(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	pendencia, err := h.service.MarcarCorrigida(r.Context(), obraID, chi.URLParam(r, "pendenciaId"))
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao marcar pendência como corrigida", obraID)
+		return
+	}
+
+	web.Respond(w, r, pendencia, http.StatusOK)
+}
+
+// HandleVerificarPendencia aprova ou reprova a pendência na revistoria
+func (h *VistoriaHandler) HandleVerificarPendencia(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	var input dto.VerificarPendenciaInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	pendencia, err := h.service.VerificarPendencia(r.Context(), obraID, chi.URLParam(r, "vistoriaId"), chi.URLParam(r, "pendenciaId"), input)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao verificar pendência", obraID)
+		return
+	}
+
+	web.Respond(w, r, pendencia, http.StatusOK)
+}
+
+// HandleTermoEntregaPDF gera o termo de entrega para assinatura do cliente
+func (h *VistoriaHandler) HandleTermoEntregaPDF(w http.ResponseWriter, r *http.Request) {
+	obraID := chi.URLParam(r, "obraId")
+
+	conteudo, err := h.service.GerarTermoEntrega(r.Context(), obraID)
+	if err != nil {
+		h.responderErro(w, r, err, "falha ao gerar termo de entrega", obraID)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="termo-entrega-%s.pdf"`, obraID))
+	w.Header().Set("Content-Length", strconv.Itoa(len(conteudo)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(conteudo)
+}
+
+func (h *VistoriaHandler) responderErro(w http.ResponseWriter, r *http.Request, err error, msg, obraID string) {
+	var erroData *time.ParseError
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "OBRA_NAO_ENCONTRADA", "Obra não encontrada", http.StatusNotFound)
+	case errors.Is(err, obras_service.ErrVistoriaNaoEncontrada):
+		web.RespondError(w, r, "VISTORIA_NAO_ENCONTRADA", obras_service.ErrVistoriaNaoEncontrada.Error(), http.StatusNotFound)
+	case errors.Is(err, obras_service.ErrPendenciaNaoEncontrada):
+		web.RespondError(w, r, "PENDENCIA_NAO_ENCONTRADA", obras_service.ErrPendenciaNaoEncontrada.Error(), http.StatusNotFound)
+	case errors.As(err, &erroData):
+		web.RespondError(w, r, "DATA_INVALIDA", "Data inválida, use o formato AAAA-MM-DD", http.StatusBadRequest)
+	case errors.Is(err, obras.ErrVistoriaInvalida):
+		web.RespondError(w, r, "VISTORIA_INVALIDA", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras.ErrPendenciaInvalida):
+		web.RespondError(w, r, "PENDENCIA_INVALIDA", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrEtapaForaDaObra):
+		web.RespondError(w, r, "ETAPA_FORA_DA_OBRA", obras_service.ErrEtapaForaDaObra.Error(), http.StatusBadRequest)
+	case errors.Is(err, obras_service.ErrVistoriaEmAberto):
+		web.RespondError(w, r, "VISTORIA_EM_ABERTO", obras_service.ErrVistoriaEmAberto.Error(), http.StatusConflict)
+	case errors.Is(err, obras.ErrVistoriaEncerrada):
+		web.RespondError(w, r, "VISTORIA_ENCERRADA", obras.ErrVistoriaEncerrada.Error(), http.StatusConflict)
+	case errors.Is(err, obras_service.ErrPendenciaJaVerificada):
+		web.RespondError(w, r, "PENDENCIA_JA_VERIFICADA", obras_service.ErrPendenciaJaVerificada.Error(), http.StatusConflict)
+	case errors.Is(err, obras.ErrPendenciaResolvida):
+		web.RespondError(w, r, "PENDENCIA_RESOLVIDA", obras.ErrPendenciaResolvida.Error(), http.StatusConflict)
+	case errors.Is(err, obras_service.ErrPendenciasBloqueantesAbertas):
+		web.RespondError(w, r, "PENDENCIAS_BLOQUEANTES", obras_service.ErrPendenciasBloqueantesAbertas.Error(), http.StatusConflict)
+	case errors.Is(err, obras_service.ErrVistoriaObraStatus),
+		errors.Is(err, obras_service.ErrSemVistoriaAberta),
+		errors.Is(err, obras_service.ErrTermoSemVistoria),
+		errors.Is(err, obras.ErrTransicaoPendencia),
+		errors.Is(err, obras.ErrVerificacaoMesmaVistoria):
+		web.RespondError(w, r, "REGRA_NEGOCIO_VIOLADA", err.Error(), http.StatusUnprocessableEntity)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "obra_id", obraID, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno ao processar a vistoria", http.StatusInternalServerError)
+	}
+}
@@ -15,7 +15,6 @@ import (
 	"github.com/luiszkm/masterCostrutora/internal/handler/web"
 	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
 
-	pessoal_service "github.com/luiszkm/masterCostrutora/internal/service/pessoal"
 	"github.com/luiszkm/masterCostrutora/internal/service/pessoal/dto"
 )
 
@@ -73,10 +72,6 @@ func (h *Handler) HandleDeletarFuncionario(w http.ResponseWriter, r *http.Reques
 			web.RespondError(w, r, "FUNCIONARIO_NAO_ENCONTRADO", "Funcionário não encontrado", http.StatusNotFound)
 			return
 		}
-		if errors.Is(err, pessoal_service.ErrFuncionarioAlocado) {
-			web.RespondError(w, r, "CONFLITO_REGRA_NEGOCIO", err.Error(), http.StatusConflict) // 409 Conflict
-			return
-		}
 		h.logger.ErrorContext(r.Context(), "falha ao deletar funcionário", "erro", err)
 		web.RespondError(w, r, "ERRO_INTERNO", "Erro ao deletar funcionário", http.StatusInternalServerError)
 		return
@@ -10,7 +10,6 @@ import (
 	"github.com/google/uuid"
 	"github.com/luiszkm/masterCostrutora/internal/handler/web"
 	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
-	pessoal_service "github.com/luiszkm/masterCostrutora/internal/service/pessoal"
 	"github.com/luiszkm/masterCostrutora/internal/service/pessoal/dto"
 )
 
@@ -69,7 +68,7 @@ func (h *Handler) HandleRegistrarPagamentoApontamento(w http.ResponseWriter, r *
 			return
 		}
 		// Trata o erro de regra de negócio vindo do método .RegistrarPagamento() do agregado.
-		if errors.Is(err, pessoal_service.ErrFuncionarioAlocado) || err.Error() == "só é possível pagar um apontamento que está 'Aprovado para Pagamento'" {
+		if err.Error() == "só é possível pagar um apontamento que está 'Aprovado para Pagamento'" {
 			web.RespondError(w, r, "REGRA_NEGOCIO_VIOLADA", err.Error(), http.StatusConflict) // 409 Conflict
 			return
 		}
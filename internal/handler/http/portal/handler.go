@@ -0,0 +1,103 @@
+// file: internal/handler/http/portal/handler.go
+package portal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/luiszkm/masterCostrutora/internal/domain/portal"
+	"github.com/luiszkm/masterCostrutora/internal/handler/web"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/service/portal/dto"
+)
+
+// Service define a interface do serviço do portal do cliente
+type Service interface {
+	CriarLink(ctx context.Context, obraID string, input dto.CriarLinkInput) (*dto.LinkOutput, error)
+	ListarLinks(ctx context.Context, obraID string) ([]*dto.LinkOutput, error)
+	RevogarLink(ctx context.Context, obraID, linkID string) error
+	ObterVisao(ctx context.Context, token string) (*dto.PortalObraDTO, error)
+}
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NovoPortalHandler(s Service, l *slog.Logger) *Handler {
+	return &Handler{service: s, logger: l.With("handler", "portal")}
+}
+
+// HandleCriarLink gera um link de compartilhamento da obra para o cliente
+func (h *Handler) HandleCriarLink(w http.ResponseWriter, r *http.Request) {
+	var input dto.CriarLinkInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		web.RespondError(w, r, "PAYLOAD_INVALIDO", "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	link, err := h.service.CriarLink(r.Context(), chi.URLParam(r, "obraId"), input)
+	if err != nil {
+		h.responderErro(w, r, "falha ao criar link do portal", err)
+		return
+	}
+	web.Respond(w, r, link, http.StatusCreated)
+}
+
+// HandleListarLinks lista os links da obra, ativos ou não
+func (h *Handler) HandleListarLinks(w http.ResponseWriter, r *http.Request) {
+	links, err := h.service.ListarLinks(r.Context(), chi.URLParam(r, "obraId"))
+	if err != nil {
+		h.responderErro(w, r, "falha ao listar links do portal", err)
+		return
+	}
+	web.Respond(w, r, links, http.StatusOK)
+}
+
+// HandleRevogarLink encerra o acesso de um link
+func (h *Handler) HandleRevogarLink(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.RevogarLink(r.Context(), chi.URLParam(r, "obraId"), chi.URLParam(r, "linkId")); err != nil {
+		h.responderErro(w, r, "falha ao revogar link do portal", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleObterVisao é a rota pública acessada pelo cliente com o token do link
+func (h *Handler) HandleObterVisao(w http.ResponseWriter, r *http.Request) {
+	visao, err := h.service.ObterVisao(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		// Para o cliente, link inexistente e link adulterado são indistinguíveis
+		switch {
+		case errors.Is(err, portal.ErrLinkExpirado):
+			web.RespondError(w, r, "LINK_EXPIRADO", "Este link expirou. Solicite um novo à construtora", http.StatusGone)
+		case errors.Is(err, portal.ErrLinkRevogado):
+			web.RespondError(w, r, "LINK_REVOGADO", "Este link foi desativado pela construtora", http.StatusGone)
+		case errors.Is(err, portal.ErrLinkInvalido), errors.Is(err, postgres.ErrNaoEncontrado):
+			web.RespondError(w, r, "LINK_INVALIDO", "Link inválido", http.StatusNotFound)
+		default:
+			h.logger.ErrorContext(r.Context(), "falha ao montar portal do cliente", "erro", err)
+			web.RespondError(w, r, "ERRO_INTERNO", "Erro interno no servidor", http.StatusInternalServerError)
+		}
+		return
+	}
+	web.Respond(w, r, visao, http.StatusOK)
+}
+
+func (h *Handler) responderErro(w http.ResponseWriter, r *http.Request, msg string, err error) {
+	switch {
+	case errors.Is(err, postgres.ErrNaoEncontrado):
+		web.RespondError(w, r, "NAO_ENCONTRADO", "Recurso não encontrado", http.StatusNotFound)
+	case errors.Is(err, portal.ErrLinkInvalido):
+		web.RespondError(w, r, "DADOS_INVALIDOS", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, portal.ErrLinkRevogado):
+		web.RespondError(w, r, "LINK_REVOGADO", err.Error(), http.StatusConflict)
+	default:
+		h.logger.ErrorContext(r.Context(), msg, "erro", err)
+		web.RespondError(w, r, "ERRO_INTERNO", "Erro interno no servidor", http.StatusInternalServerError)
+	}
+}
@@ -7,33 +7,63 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/luiszkm/masterCostrutora/internal/authz"
+	"github.com/luiszkm/masterCostrutora/internal/handler/http/alertas"
+	"github.com/luiszkm/masterCostrutora/internal/handler/http/clientes"
 	"github.com/luiszkm/masterCostrutora/internal/handler/http/dashboard"
+	"github.com/luiszkm/masterCostrutora/internal/handler/http/eventos"
 	"github.com/luiszkm/masterCostrutora/internal/handler/http/financeiro"
 	"github.com/luiszkm/masterCostrutora/internal/handler/http/identidade"
+	"github.com/luiszkm/masterCostrutora/internal/handler/http/integracoes"
+	"github.com/luiszkm/masterCostrutora/internal/handler/http/notificacoes"
 	"github.com/luiszkm/masterCostrutora/internal/handler/http/obras"
 	"github.com/luiszkm/masterCostrutora/internal/handler/http/pessoal"
+	"github.com/luiszkm/masterCostrutora/internal/handler/http/portal"
 	"github.com/luiszkm/masterCostrutora/internal/handler/http/suprimentos"
 	"github.com/luiszkm/masterCostrutora/internal/handler/web"
 	"github.com/luiszkm/masterCostrutora/pkg/auth"
+	"github.com/luiszkm/masterCostrutora/pkg/metrics"
+	"github.com/luiszkm/masterCostrutora/pkg/tracing"
 )
 
 type Config struct {
-	JwtService          *auth.JWTService
-	IdentidadeHandler   *identidade.Handler
-	ObrasHandler        *obras.Handler
-	PessoalHandler      *pessoal.Handler
-	SuprimentosHandler  *suprimentos.Handler
-	FinanceiroHandler   *financeiro.Handler
-	ContaReceberHandler *financeiro.ContaReceberHandler
-	ContaPagarHandler   *financeiro.ContaPagarHandler
-	CronogramaHandler   *obras.CronogramaHandler
-	DashboardHandler    *dashboard.Handler
+	JwtService                *auth.JWTService
+	IdentidadeHandler         *identidade.Handler
+	ObrasHandler              *obras.Handler
+	PessoalHandler            *pessoal.Handler
+	SuprimentosHandler        *suprimentos.Handler
+	FinanceiroHandler         *financeiro.Handler
+	ContaReceberHandler       *financeiro.ContaReceberHandler
+	ContaPagarHandler         *financeiro.ContaPagarHandler
+	CronogramaHandler         *obras.CronogramaHandler
+	TransicaoHandler          *obras.TransicaoHandler
+	CronogramaFisicoHandler   *obras.CronogramaFisicoHandler
+	FisicoFinanceiroHandler   *obras.FisicoFinanceiroHandler
+	OrcamentoAnaliticoHandler *obras.OrcamentoAnaliticoHandler
+	ResultadoHandler          *obras.ResultadoHandler
+	DiarioObraHandler         *obras.DiarioObraHandler
+	MedicaoHandler            *obras.MedicaoHandler
+	AditivoHandler            *obras.AditivoHandler
+	AlocacaoHandler           *obras.AlocacaoHandler
+	CapacidadeHandler         *obras.CapacidadeHandler
+	ModeloObraHandler         *obras.ModeloObraHandler
+	VistoriaHandler           *obras.VistoriaHandler
+	ChecklistQualidadeHandler *obras.ChecklistQualidadeHandler
+	DashboardHandler          *dashboard.Handler
+	IntegracoesHandler        *integracoes.Handler
+	AlertasHandler            *alertas.Handler
+	NotificacoesHandler       *notificacoes.Handler
+	ClientesHandler           *clientes.Handler
+	PortalHandler             *portal.Handler
+	EventosHandler            *eventos.Handler
+	HTTPMetrics               *metrics.HTTPMetrics
 }
 
 func New(c Config) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Middlewares globais aplicados a todas as rotas
+	r.Use(tracing.Middleware)
+	r.Use(c.HTTPMetrics.Middleware)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 
@@ -46,6 +76,10 @@ func New(c Config) *chi.Mux {
 		r.Post("/login", c.IdentidadeHandler.HandleLogin)
 	})
 
+	// --- PORTAL DO CLIENTE ---
+	// O acesso é pelo token assinado do link; a visão não inclui custos, fornecedores nem equipe.
+	r.Get("/portal/{token}", c.PortalHandler.HandleObterVisao)
+
 	// --- DASHBOARD PÚBLICO PARA DEBUG ---
 	r.Route("/dashboard", func(r chi.Router) {
 		// Dashboard completo - sem autenticação para debug
@@ -103,6 +137,10 @@ func New(c Config) *chi.Mux {
 				r.With(auth.Authorize(authz.PermissaoPessoalEscrever)).
 					Delete("/", c.PessoalHandler.HandleDeletarFuncionario)
 
+				// Histórico de obras em que o funcionário esteve alocado
+				r.With(auth.Authorize(authz.PermissaoPessoalLer)).
+					Get("/alocacoes", c.AlocacaoHandler.HandleListarAlocacoesDoFuncionario)
+
 				// Rota aninhada para listar os apontamentos deste funcionário
 				r.With(auth.Authorize(authz.PermissaoPessoalApontamentoLer)).
 					Get("/apontamentos", c.PessoalHandler.HandleListarApontamentosPorFuncionario)
@@ -143,6 +181,8 @@ func New(c Config) *chi.Mux {
 		r.Route("/obras", func(r chi.Router) {
 			r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/", c.ObrasHandler.HandleListarObras)
 			r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/", c.ObrasHandler.HandleCriarObra)
+			r.With(auth.Authorize(authz.PermissaoFinanceiroLer)).Get("/resultado", c.ResultadoHandler.HandleObterResultadoCarteira)
+			r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/capacidade", c.CapacidadeHandler.HandleObterCapacidade)
 
 			// Sub-recursos de uma obra específica
 			r.Route("/{obraId}", func(r chi.Router) {
@@ -151,12 +191,88 @@ func New(c Config) *chi.Mux {
 				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/", c.ObrasHandler.HandleBuscarObraPorID)
 				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Put("/", c.ObrasHandler.HandleAtualizarObra)
 				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/etapas", c.ObrasHandler.HandleAdicionarEtapa)
-				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/alocacoes", c.ObrasHandler.HandleAlocarFuncionario)
 				r.With(auth.Authorize(authz.PermissaoObrasLer)).
 					Get("/etapas", c.ObrasHandler.HandleListarEtapasPorObra)
 				
 				// Cronograma de recebimento
 				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/cronograma-recebimentos", c.CronogramaHandler.HandleListarCronogramasPorObra)
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Post("/cronograma-recebimentos/previa", c.CronogramaHandler.HandlePreverCronograma)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/cronograma-recebimentos/gerar", c.CronogramaHandler.HandleGerarCronograma)
+
+				// Ciclo de vida: transições de status com os motivos de bloqueio
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/transicoes", c.TransicaoHandler.HandleListarTransicoes)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/transicoes", c.TransicaoHandler.HandleExecutarTransicao)
+
+				// Cronograma físico: dependências entre etapas, Gantt e caminho crítico
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/cronograma-fisico", c.CronogramaFisicoHandler.HandleObterCronogramaFisico)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/dependencias", c.CronogramaFisicoHandler.HandleAdicionarDependencia)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Delete("/dependencias/{dependenciaId}", c.CronogramaFisicoHandler.HandleRemoverDependencia)
+
+				// Cronograma físico-financeiro: curva S (previsto x realizado) e SPI/CPI
+				r.With(auth.Authorize(authz.PermissaoFinanceiroLer)).Get("/fisico-financeiro", c.FisicoFinanceiroHandler.HandleObterFisicoFinanceiro)
+
+				// Orçamento analítico: linha de base de custo por etapa/categoria e alertas de estouro
+				r.With(auth.Authorize(authz.PermissaoFinanceiroLer)).Get("/orcamento-analitico", c.OrcamentoAnaliticoHandler.HandleObterControleCustos)
+				r.With(auth.Authorize(authz.PermissaoFinanceiroEscrever)).Put("/orcamento-analitico", c.OrcamentoAnaliticoHandler.HandleDefinirOrcamentoAnalitico)
+
+				// Resultado: receitas, custos, margens e exposição de caixa da obra
+				r.With(auth.Authorize(authz.PermissaoFinanceiroLer)).Get("/resultado", c.ResultadoHandler.HandleObterResultadoObra)
+
+				// Diário de obra: registro diário, fotos, assinatura do engenheiro e exportação em PDF
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/diarios", c.DiarioObraHandler.HandleListarDiarios)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/diarios", c.DiarioObraHandler.HandleCriarDiario)
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/diarios/pdf", c.DiarioObraHandler.HandleExportarPDF)
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/diarios/{diarioId}", c.DiarioObraHandler.HandleBuscarDiario)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Put("/diarios/{diarioId}", c.DiarioObraHandler.HandleAtualizarDiario)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/diarios/{diarioId}/fotos", c.DiarioObraHandler.HandleAdicionarFoto)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Delete("/diarios/{diarioId}/fotos/{fotoId}", c.DiarioObraHandler.HandleRemoverFoto)
+				r.With(auth.Authorize(authz.PermissaoObrasDiarioAssinar)).Post("/diarios/{diarioId}/assinatura", c.DiarioObraHandler.HandleAssinarDiario)
+
+				// Vistoria final: rodadas de vistoria/revistoria, pendências e termo de entrega em PDF
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/vistorias", c.VistoriaHandler.HandleListarVistorias)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/vistorias", c.VistoriaHandler.HandleAbrirVistoria)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/vistorias/{vistoriaId}/encerrar", c.VistoriaHandler.HandleEncerrarVistoria)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/vistorias/{vistoriaId}/pendencias/{pendenciaId}/verificacao", c.VistoriaHandler.HandleVerificarPendencia)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/pendencias", c.VistoriaHandler.HandleAdicionarPendencia)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Put("/pendencias/{pendenciaId}", c.VistoriaHandler.HandleAtualizarPendencia)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Delete("/pendencias/{pendenciaId}", c.VistoriaHandler.HandleRemoverPendencia)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/pendencias/{pendenciaId}/corrigida", c.VistoriaHandler.HandleMarcarCorrigida)
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/termo-entrega/pdf", c.VistoriaHandler.HandleTermoEntregaPDF)
+
+				// Qualidade: FVS das etapas e inspeções (aprovação, reprovação e reinspeção)
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/checklists", c.ChecklistQualidadeHandler.HandleListarChecklistsObra)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/checklists/{checklistId}/inspecoes", c.ChecklistQualidadeHandler.HandleRegistrarInspecao)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Delete("/checklists/{checklistId}", c.ChecklistQualidadeHandler.HandleRemoverChecklist)
+
+				// Medições: avanço medido por etapa, aprovação do cliente e geração da parcela de recebimento
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/medicoes", c.MedicaoHandler.HandleListarMedicoes)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/medicoes", c.MedicaoHandler.HandleCriarMedicao)
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/medicoes/{medicaoId}", c.MedicaoHandler.HandleBuscarMedicao)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Put("/medicoes/{medicaoId}", c.MedicaoHandler.HandleAtualizarMedicao)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Delete("/medicoes/{medicaoId}", c.MedicaoHandler.HandleExcluirMedicao)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/medicoes/{medicaoId}/envio", c.MedicaoHandler.HandleEnviarMedicao)
+				r.With(auth.Authorize(authz.PermissaoFinanceiroEscrever)).Post("/medicoes/{medicaoId}/aprovacao", c.MedicaoHandler.HandleAprovarMedicao)
+				r.With(auth.Authorize(authz.PermissaoFinanceiroEscrever)).Post("/medicoes/{medicaoId}/rejeicao", c.MedicaoHandler.HandleRejeitarMedicao)
+
+				// Alocações: período de cada funcionário na obra, encerramento e transferência
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/alocacoes", c.AlocacaoHandler.HandleListarAlocacoesDaObra)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/alocacoes", c.AlocacaoHandler.HandleAlocarFuncionarios)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/alocacoes/{alocacaoId}/encerrar", c.AlocacaoHandler.HandleEncerrarAlocacao)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/alocacoes/{alocacaoId}/transferir", c.AlocacaoHandler.HandleTransferirAlocacao)
+
+				// Efetivo planejado por etapa e cargo (alimenta o calendário de capacidade)
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/efetivo-planejado", c.CapacidadeHandler.HandleListarEfetivoPlanejado)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Put("/efetivo-planejado", c.CapacidadeHandler.HandleDefinirEfetivoPlanejado)
+
+				// Contrato: aditivos de valor e prazo, com linha de base e histórico
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/contrato", c.AditivoHandler.HandleObterContrato)
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/aditivos", c.AditivoHandler.HandleListarAditivos)
+				r.With(auth.Authorize(authz.PermissaoFinanceiroEscrever)).Post("/aditivos", c.AditivoHandler.HandleRegistrarAditivo)
+
+				// Portal do cliente: links de compartilhamento somente leitura
+				r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/portal/links", c.PortalHandler.HandleListarLinks)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/portal/links", c.PortalHandler.HandleCriarLink)
+				r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Delete("/portal/links/{linkId}", c.PortalHandler.HandleRevogarLink)
 
 			})
 		})
@@ -177,6 +293,9 @@ func New(c Config) *chi.Mux {
 		// pois seu ID já é único.
 		r.Route("/etapas/{etapaId}", func(r chi.Router) {
 			r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Patch("/", c.ObrasHandler.HandleAtualizarEtapaStatus)
+			r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Patch("/progresso", c.ObrasHandler.HandleAtualizarProgressoEtapa)
+			r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/checklists", c.ChecklistQualidadeHandler.HandleListarChecklistsEtapa)
+			r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/checklists", c.ChecklistQualidadeHandler.HandleAplicarChecklists)
 			r.With(auth.Authorize(authz.PermissaoSuprimentosEscrever)).Post("/orcamentos", c.SuprimentosHandler.HandleCriarOrcamento)
 		})
 
@@ -220,6 +339,8 @@ func New(c Config) *chi.Mux {
 			// Ações específicas
 			r.With(auth.Authorize(authz.PermissaoFinanceiroEscrever)).
 				Post("/{contaId}/recebimentos", c.ContaReceberHandler.HandleRegistrarRecebimento)
+			r.With(auth.Authorize(authz.PermissaoFinanceiroEscrever)).
+				Put("/{contaId}/boleto", c.ContaReceberHandler.HandleRegistrarBoleto)
 			
 			// Relatórios e consultas
 			r.With(auth.Authorize(authz.PermissaoFinanceiroLer)).
@@ -254,6 +375,8 @@ func New(c Config) *chi.Mux {
 		// Rotas específicas por entidade relacionada
 		r.With(auth.Authorize(authz.PermissaoFinanceiroLer)).
 			Get("/obras/{obraId}/contas-receber", c.ContaReceberHandler.HandleListarContasPorObra)
+		r.With(auth.Authorize(authz.PermissaoFinanceiroLer)).
+			Get("/obras/{obraId}/caucao", c.ContaReceberHandler.HandleObterCaucao)
 		r.With(auth.Authorize(authz.PermissaoFinanceiroLer)).
 			Get("/obras/{obraId}/contas-pagar", c.ContaPagarHandler.HandleListarContasPorObra)
 		r.With(auth.Authorize(authz.PermissaoFinanceiroLer)).
@@ -267,6 +390,83 @@ func New(c Config) *chi.Mux {
 			r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Delete("/{etapaId}", c.ObrasHandler.HandleDeletarEtapaPadrao)
 		})
 
+		r.Route("/modelos-checklist", func(r chi.Router) {
+			r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/", c.ChecklistQualidadeHandler.HandleListarModelos)
+			r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/{modeloId}", c.ChecklistQualidadeHandler.HandleBuscarModelo)
+			r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/", c.ChecklistQualidadeHandler.HandleCriarModelo)
+			r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Put("/{modeloId}", c.ChecklistQualidadeHandler.HandleAtualizarModelo)
+			r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Delete("/{modeloId}", c.ChecklistQualidadeHandler.HandleDeletarModelo)
+		})
+
+		r.Route("/modelos-obra", func(r chi.Router) {
+			r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/", c.ModeloObraHandler.HandleListarModelos)
+			r.With(auth.Authorize(authz.PermissaoObrasLer)).Get("/{modeloId}", c.ModeloObraHandler.HandleBuscarModelo)
+			r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Post("/", c.ModeloObraHandler.HandleCriarModelo)
+			r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Put("/{modeloId}", c.ModeloObraHandler.HandleAtualizarModelo)
+			r.With(auth.Authorize(authz.PermissaoObrasEscrever)).Delete("/{modeloId}", c.ModeloObraHandler.HandleDeletarModelo)
+		})
+
+		// --- Atualizações em tempo real (SSE) ---
+		// Sem permissão específica: os eventos são filtrados pelas permissões do usuário.
+		r.Get("/eventos/stream", c.EventosHandler.HandleStream)
+
+		// --- Cadastro de clientes ---
+		r.Route("/clientes", func(r chi.Router) {
+			r.With(auth.Authorize(authz.PermissaoClientesLer)).Get("/", c.ClientesHandler.HandleListarClientes)
+			r.With(auth.Authorize(authz.PermissaoClientesEscrever)).Post("/", c.ClientesHandler.HandleCadastrarCliente)
+
+			r.Route("/{clienteId}", func(r chi.Router) {
+				r.With(auth.Authorize(authz.PermissaoClientesLer)).Get("/", c.ClientesHandler.HandleBuscarCliente)
+				r.With(auth.Authorize(authz.PermissaoClientesEscrever)).Put("/", c.ClientesHandler.HandleAtualizarCliente)
+				r.With(auth.Authorize(authz.PermissaoClientesEscrever)).Delete("/", c.ClientesHandler.HandleDeletarCliente)
+				r.With(auth.Authorize(authz.PermissaoClientesEscrever)).Post("/mesclar", c.ClientesHandler.HandleMesclarClientes)
+				// O extrato expõe valores a receber, por isso exige também leitura financeira
+				r.With(auth.Authorize(authz.PermissaoClientesLer), auth.Authorize(authz.PermissaoFinanceiroLer)).
+					Get("/extrato", c.ClientesHandler.HandleObterExtrato)
+			})
+		})
+
+		// --- Integrações: webhooks de saída ---
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Use(auth.Authorize(authz.PermissaoIntegracoesGerenciar))
+
+			r.Get("/", c.IntegracoesHandler.HandleListarWebhooks)
+			r.Post("/", c.IntegracoesHandler.HandleCriarWebhook)
+			r.Get("/eventos", c.IntegracoesHandler.HandleListarEventos)
+			r.Post("/entregas/{entregaId}/reenviar", c.IntegracoesHandler.HandleReenviarEntrega)
+
+			r.Route("/{webhookId}", func(r chi.Router) {
+				r.Get("/", c.IntegracoesHandler.HandleBuscarWebhook)
+				r.Put("/", c.IntegracoesHandler.HandleAtualizarWebhook)
+				r.Delete("/", c.IntegracoesHandler.HandleDeletarWebhook)
+				r.Get("/entregas", c.IntegracoesHandler.HandleListarEntregas)
+			})
+		})
+
+		// --- Alertas: regras configuráveis e alertas detectados ---
+		r.Route("/alertas", func(r chi.Router) {
+			r.With(auth.Authorize(authz.PermissaoAlertasLer)).Get("/", c.AlertasHandler.HandleListarAlertas)
+			r.With(auth.Authorize(authz.PermissaoAlertasConfigurar)).Post("/avaliar", c.AlertasHandler.HandleAvaliar)
+			r.With(auth.Authorize(authz.PermissaoAlertasLer)).Get("/regras", c.AlertasHandler.HandleListarRegras)
+			r.With(auth.Authorize(authz.PermissaoAlertasConfigurar)).Put("/regras/{tipo}", c.AlertasHandler.HandleAtualizarRegra)
+
+			r.Route("/{alertaId}", func(r chi.Router) {
+				r.Use(auth.Authorize(authz.PermissaoAlertasGerenciar))
+				r.Post("/reconhecer", c.AlertasHandler.HandleReconhecerAlerta)
+				r.Post("/adiar", c.AlertasHandler.HandleAdiarAlerta)
+			})
+		})
+
+		// --- Notificações: cada usuário autenticado acessa apenas as suas ---
+		r.Route("/notificacoes", func(r chi.Router) {
+			r.Get("/", c.NotificacoesHandler.HandleListarNotificacoes)
+			r.Get("/nao-lidas", c.NotificacoesHandler.HandleContarNaoLidas)
+			r.Post("/lidas", c.NotificacoesHandler.HandleMarcarTodasLidas)
+			r.Get("/preferencias", c.NotificacoesHandler.HandleBuscarPreferencias)
+			r.Put("/preferencias", c.NotificacoesHandler.HandleAtualizarPreferencias)
+			r.Post("/{notificacaoId}/lida", c.NotificacoesHandler.HandleMarcarLida)
+		})
+
 		// --- Recursos de Dashboard (COMENTADO PARA DEBUG) ---
 		// r.Route("/dashboard", func(r chi.Router) {
 		// 	// Dashboard completo - requer permissão de leitura geral
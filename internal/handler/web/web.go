@@ -88,6 +88,7 @@ func ParseFiltros(r *http.Request) common.ListarFiltros {
 		Pagina:        pagina,
 		FornecedorID:  fornecedorID,
 		ObraID:        obraID,
+		ClienteID:     q.Get("clienteId"),
 		TamanhoPagina: tamanhoPagina,
 	}
 }
@@ -0,0 +1,72 @@
+// file: internal/infrastructure/repository/postgres/aditivo_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
+)
+
+// AditivoRepositoryPostgres persiste os aditivos de contrato das obras.
+type AditivoRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoAditivoRepository(db *pgxpool.Pool, logger *slog.Logger) *AditivoRepositoryPostgres {
+	return &AditivoRepositoryPostgres{db: db, logger: logger}
+}
+
+const colunasAditivo = `id, obra_id, numero, descricao, valor_acrescimo, nova_data_fim, data_aprovacao, documento_url,
+	valor_contrato_anterior, valor_contrato_resultante, data_fim_anterior, data_fim_resultante, criado_por, created_at`
+
+// Salvar grava o aditivo na transação do chamador.
+func (r *AditivoRepositoryPostgres) Salvar(ctx context.Context, dbtx db.DBTX, a *obras.Aditivo) error {
+	const op = "repository.postgres.aditivo.Salvar"
+
+	query := `
+		INSERT INTO aditivos_obra (` + colunasAditivo + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+	_, err := dbtx.Exec(ctx, query,
+		a.ID, a.ObraID, a.Numero, a.Descricao, a.ValorAcrescimo, a.NovaDataFim, a.DataAprovacao, a.DocumentoURL,
+		a.ValorContratoAnterior, a.ValorContratoResultante, a.DataFimAnterior, a.DataFimResultante, a.CriadoPor, a.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// ListarPorObraID lista os aditivos da obra em ordem de número.
+func (r *AditivoRepositoryPostgres) ListarPorObraID(ctx context.Context, obraID string) ([]*obras.Aditivo, error) {
+	const op = "repository.postgres.aditivo.ListarPorObraID"
+	query := `SELECT ` + colunasAditivo + ` FROM aditivos_obra WHERE obra_id = $1 ORDER BY numero`
+
+	rows, err := r.db.Query(ctx, query, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	aditivos := make([]*obras.Aditivo, 0)
+	for rows.Next() {
+		var a obras.Aditivo
+		err := rows.Scan(
+			&a.ID, &a.ObraID, &a.Numero, &a.Descricao, &a.ValorAcrescimo, &a.NovaDataFim, &a.DataAprovacao, &a.DocumentoURL,
+			&a.ValorContratoAnterior, &a.ValorContratoResultante, &a.DataFimAnterior, &a.DataFimResultante, &a.CriadoPor, &a.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: falha ao escanear aditivo: %w", op, err)
+		}
+		aditivos = append(aditivos, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return aditivos, nil
+}
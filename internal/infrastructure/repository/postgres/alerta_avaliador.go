@@ -0,0 +1,279 @@
+// file: internal/infrastructure/repository/postgres/alerta_avaliador.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/alertas"
+)
+
+const formatoDataAlerta = "02/01/2006"
+
+// AvaliadorAlertasPostgres verifica as regras de alerta diretamente nas tabelas dos módulos.
+type AvaliadorAlertasPostgres struct {
+	db     *pgxpool.Pool
+	custos *ObraRepositoryPostgres
+	logger *slog.Logger
+}
+
+// NovoAvaliadorAlertas usa o querier de custos da obra para o alerta de orçamento,
+// para apurar o custo exatamente como o controle de custos.
+func NovoAvaliadorAlertas(db *pgxpool.Pool, custos *ObraRepositoryPostgres, logger *slog.Logger) *AvaliadorAlertasPostgres {
+	return &AvaliadorAlertasPostgres{db: db, custos: custos, logger: logger}
+}
+
+// Avaliar implementa alertas.Avaliador
+func (a *AvaliadorAlertasPostgres) Avaliar(ctx context.Context, regra alertas.RegraAlerta, hoje time.Time) ([]alertas.Ocorrencia, error) {
+	switch regra.Tipo {
+	case alertas.TipoObraAtrasada:
+		return a.obrasAtrasadas(ctx, regra.Limite, hoje)
+	case alertas.TipoContaVencendo:
+		return a.contasVencendo(ctx, regra.Limite, hoje)
+	case alertas.TipoOrcamentoParado:
+		return a.orcamentosParados(ctx, regra.Limite, hoje)
+	case alertas.TipoFuncionarioSemApontamento:
+		return a.funcionariosSemApontamento(ctx, regra.Limite, hoje)
+	case alertas.TipoOrcamentoEstourado:
+		return a.orcamentosEstourados(ctx, regra.Limite)
+	}
+	return nil, alertas.ErrTipoAlertaInvalido
+}
+
+// obrasAtrasadas: obras em andamento cujo término previsto passou há mais de `tolerancia` dias.
+func (a *AvaliadorAlertasPostgres) obrasAtrasadas(ctx context.Context, tolerancia int, hoje time.Time) ([]alertas.Ocorrencia, error) {
+	const op = "repository.postgres.alerta_avaliador.obrasAtrasadas"
+	query := `
+		SELECT id::text, nome, data_fim
+		FROM obras
+		WHERE status = 'Em Andamento'
+			AND deleted_at IS NULL
+			AND data_fim IS NOT NULL
+			AND data_fim < $1::date - $2::int
+	`
+	rows, err := a.db.Query(ctx, query, hoje, tolerancia)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var ocorrencias []alertas.Ocorrencia
+	var id, nome string
+	var dataFim time.Time
+	_, err = pgx.ForEachRow(rows, []any{&id, &nome, &dataFim}, func() error {
+		obraID := id
+		ocorrencias = append(ocorrencias, alertas.Ocorrencia{
+			Chave:        id,
+			ReferenciaID: id,
+			ObraID:       &obraID,
+			Titulo:       fmt.Sprintf("Obra atrasada: %s", nome),
+			Descricao: fmt.Sprintf("Término previsto em %s; %d dia(s) de atraso.",
+				dataFim.Format(formatoDataAlerta), diasEntre(dataFim, hoje)),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return ocorrencias, nil
+}
+
+// contasVencendo: contas a pagar em aberto que vencem nos próximos `antecedencia` dias ou já venceram.
+func (a *AvaliadorAlertasPostgres) contasVencendo(ctx context.Context, antecedencia int, hoje time.Time) ([]alertas.Ocorrencia, error) {
+	const op = "repository.postgres.alerta_avaliador.contasVencendo"
+	query := `
+		SELECT id::text, obra_id::text, fornecedor_nome, descricao, (valor_original - valor_pago)::float, data_vencimento
+		FROM contas_pagar
+		WHERE status IN ('PENDENTE', 'PARCIAL', 'VENCIDO')
+			AND data_vencimento <= $1::date + $2::int
+	`
+	rows, err := a.db.Query(ctx, query, hoje, antecedencia)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var ocorrencias []alertas.Ocorrencia
+	var id, fornecedor, descricao string
+	var obraID *string
+	var saldo float64
+	var vencimento time.Time
+	_, err = pgx.ForEachRow(rows, []any{&id, &obraID, &fornecedor, &descricao, &saldo, &vencimento}, func() error {
+		dias := diasEntre(hoje, vencimento)
+		prazo := fmt.Sprintf("vence em %d dia(s)", dias)
+		if dias == 0 {
+			prazo = "vence hoje"
+		} else if dias < 0 {
+			prazo = fmt.Sprintf("vencida há %d dia(s)", -dias)
+		}
+		ocorrencias = append(ocorrencias, alertas.Ocorrencia{
+			Chave:        id,
+			ReferenciaID: id,
+			ObraID:       obraID,
+			Titulo:       fmt.Sprintf("Conta a pagar %s: %s", prazo, fornecedor),
+			Descricao: fmt.Sprintf("%s. Saldo de R$ %.2f com vencimento em %s.",
+				descricao, saldo, vencimento.Format(formatoDataAlerta)),
+			Critica: dias < 0,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return ocorrencias, nil
+}
+
+// orcamentosParados: orçamentos de fornecedores em aberto sem movimentação há mais de `dias` dias.
+func (a *AvaliadorAlertasPostgres) orcamentosParados(ctx context.Context, dias int, hoje time.Time) ([]alertas.Ocorrencia, error) {
+	const op = "repository.postgres.alerta_avaliador.orcamentosParados"
+	query := `
+		SELECT o.id::text, o.numero, f.nome, ob.id::text, ob.nome, o.valor_total::float, o.updated_at
+		FROM orcamentos o
+		JOIN etapas e ON e.id = o.etapa_id
+		JOIN obras ob ON ob.id = e.obra_id
+		JOIN fornecedores f ON f.id = o.fornecedor_id
+		WHERE o.status = 'Em Aberto'
+			AND o.deleted_at IS NULL
+			AND ob.deleted_at IS NULL
+			AND o.updated_at::date <= $1::date - $2::int
+	`
+	rows, err := a.db.Query(ctx, query, hoje, dias)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var ocorrencias []alertas.Ocorrencia
+	var id, numero, fornecedor, obraID, obraNome string
+	var valor float64
+	var ultimaAtualizacao time.Time
+	_, err = pgx.ForEachRow(rows, []any{&id, &numero, &fornecedor, &obraID, &obraNome, &valor, &ultimaAtualizacao}, func() error {
+		obra := obraID
+		ocorrencias = append(ocorrencias, alertas.Ocorrencia{
+			Chave:        id,
+			ReferenciaID: id,
+			ObraID:       &obra,
+			Titulo:       fmt.Sprintf("Orçamento %s parado há %d dia(s)", numero, diasEntre(ultimaAtualizacao, hoje)),
+			Descricao: fmt.Sprintf("Orçamento de %s (R$ %.2f) da obra %s aguarda aprovação desde %s.",
+				fornecedor, valor, obraNome, ultimaAtualizacao.Format(formatoDataAlerta)),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return ocorrencias, nil
+}
+
+// funcionariosSemApontamento: funcionários ativos, alocados há mais de `dias` dias, cujo
+// último período apontado terminou há mais de `dias` dias (ou que nunca foram apontados).
+func (a *AvaliadorAlertasPostgres) funcionariosSemApontamento(ctx context.Context, dias int, hoje time.Time) ([]alertas.Ocorrencia, error) {
+	const op = "repository.postgres.alerta_avaliador.funcionariosSemApontamento"
+	query := `
+		SELECT f.id::text, f.nome, MAX(ap.periodo_fim)
+		FROM funcionarios f
+		LEFT JOIN apontamentos_quinzenais ap ON ap.funcionario_id = f.id
+		WHERE f.status = 'Ativo'
+			AND EXISTS (
+				SELECT 1 FROM alocacoes al
+				WHERE al.funcionario_id = f.id
+					AND al.data_inicio_alocacao <= $1::date - $2::int
+					AND (al.data_fim_alocacao IS NULL OR al.data_fim_alocacao >= $1::date)
+			)
+		GROUP BY f.id, f.nome
+		HAVING MAX(ap.periodo_fim) IS NULL OR MAX(ap.periodo_fim) < $1::date - $2::int
+	`
+	rows, err := a.db.Query(ctx, query, hoje, dias)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var ocorrencias []alertas.Ocorrencia
+	var id, nome string
+	var ultimoPeriodo *time.Time
+	_, err = pgx.ForEachRow(rows, []any{&id, &nome, &ultimoPeriodo}, func() error {
+		descricao := "Funcionário alocado sem nenhum apontamento registrado."
+		if ultimoPeriodo != nil {
+			descricao = fmt.Sprintf("Último período apontado terminou em %s.", ultimoPeriodo.Format(formatoDataAlerta))
+		}
+		ocorrencias = append(ocorrencias, alertas.Ocorrencia{
+			Chave:        id,
+			ReferenciaID: id,
+			Titulo:       fmt.Sprintf("Funcionário sem apontamento: %s", nome),
+			Descricao:    descricao,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return ocorrencias, nil
+}
+
+// orcamentosEstourados: obras ativas cujo custo projetado (maior entre comprometido e
+// realizado, apurados por ListarCustosApurados como no controle de custos) atingiu
+// `percentual`% do orçamento analítico.
+func (a *AvaliadorAlertasPostgres) orcamentosEstourados(ctx context.Context, percentual int) ([]alertas.Ocorrencia, error) {
+	const op = "repository.postgres.alerta_avaliador.orcamentosEstourados"
+	query := `
+		SELECT ob.id::text, ob.nome, SUM(i.valor_previsto)::float AS previsto
+		FROM obra_orcamento_analitico_itens i
+		JOIN obras ob ON ob.id = i.obra_id
+		WHERE ob.deleted_at IS NULL
+			AND ob.status IN ('Em Planejamento', 'Em Andamento')
+		GROUP BY ob.id, ob.nome
+		HAVING SUM(i.valor_previsto) > 0
+	`
+	rows, err := a.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	type orcamentoObra struct {
+		id, nome string
+		previsto float64
+	}
+	var orcadas []orcamentoObra
+	var o orcamentoObra
+	_, err = pgx.ForEachRow(rows, []any{&o.id, &o.nome, &o.previsto}, func() error {
+		orcadas = append(orcadas, o)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var ocorrencias []alertas.Ocorrencia
+	for _, obra := range orcadas {
+		custos, err := a.custos.ListarCustosApurados(ctx, obra.id)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		var comprometido, realizado float64
+		for _, c := range custos {
+			comprometido += c.Comprometido
+			realizado += c.Realizado
+		}
+		projetado := max(comprometido, realizado)
+		if projetado < obra.previsto*float64(percentual)/100 {
+			continue
+		}
+		obraID := obra.id
+		ocorrencias = append(ocorrencias, alertas.Ocorrencia{
+			Chave:        obra.id,
+			ReferenciaID: obra.id,
+			ObraID:       &obraID,
+			Titulo:       fmt.Sprintf("Orçamento da obra %s em %.0f%%", obra.nome, projetado/obra.previsto*100),
+			Descricao:    fmt.Sprintf("Custo projetado de R$ %.2f para R$ %.2f previstos no orçamento analítico.", projetado, obra.previsto),
+			Critica:      projetado > obra.previsto,
+		})
+	}
+	return ocorrencias, nil
+}
+
+// diasEntre conta os dias de calendário de inicio até fim.
+func diasEntre(inicio, fim time.Time) int {
+	i := time.Date(inicio.Year(), inicio.Month(), inicio.Day(), 0, 0, 0, 0, time.UTC)
+	f := time.Date(fim.Year(), fim.Month(), fim.Day(), 0, 0, 0, 0, time.UTC)
+	return int(f.Sub(i).Hours() / 24)
+}
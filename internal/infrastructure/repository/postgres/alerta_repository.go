@@ -0,0 +1,159 @@
+// file: internal/infrastructure/repository/postgres/alerta_repository.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/alertas"
+)
+
+// AlertaRepositoryPostgres persiste as regras de alerta da empresa e os alertas detectados.
+type AlertaRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoAlertaRepository(db *pgxpool.Pool, logger *slog.Logger) *AlertaRepositoryPostgres {
+	return &AlertaRepositoryPostgres{db: db, logger: logger}
+}
+
+const colunasAlerta = `id, tipo, chave, referencia_id, obra_id, titulo, descricao, severidade, status,
+	detectado_em, atualizado_em, reconhecido_por, reconhecido_em, adiado_ate, resolvido_em`
+
+// --- Regras ---
+
+// ListarRegras retorna apenas as regras personalizadas; os tipos ausentes usam o padrão.
+func (r *AlertaRepositoryPostgres) ListarRegras(ctx context.Context) ([]*alertas.RegraAlerta, error) {
+	const op = "repository.postgres.alerta.ListarRegras"
+	rows, err := r.db.Query(ctx, `SELECT tipo, ativa, limite, severidade, updated_at FROM regras_alerta ORDER BY tipo`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	regras, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByPos[alertas.RegraAlerta])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return regras, nil
+}
+
+func (r *AlertaRepositoryPostgres) SalvarRegra(ctx context.Context, regra *alertas.RegraAlerta) error {
+	const op = "repository.postgres.alerta.SalvarRegra"
+	query := `
+		INSERT INTO regras_alerta (tipo, ativa, limite, severidade, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tipo) DO UPDATE
+		SET ativa = EXCLUDED.ativa, limite = EXCLUDED.limite, severidade = EXCLUDED.severidade, updated_at = EXCLUDED.updated_at
+	`
+	if _, err := r.db.Exec(ctx, query, regra.Tipo, regra.Ativa, regra.Limite, regra.Severidade, regra.UpdatedAt); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// --- Alertas ---
+
+// Salvar insere o alerta ou atualiza seu estado, se já existir.
+func (r *AlertaRepositoryPostgres) Salvar(ctx context.Context, a *alertas.Alerta) error {
+	const op = "repository.postgres.alerta.Salvar"
+	query := `
+		INSERT INTO alertas (` + colunasAlerta + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (id) DO UPDATE
+		SET obra_id = EXCLUDED.obra_id, titulo = EXCLUDED.titulo, descricao = EXCLUDED.descricao,
+			severidade = EXCLUDED.severidade, status = EXCLUDED.status, atualizado_em = EXCLUDED.atualizado_em,
+			reconhecido_por = EXCLUDED.reconhecido_por, reconhecido_em = EXCLUDED.reconhecido_em,
+			adiado_ate = EXCLUDED.adiado_ate, resolvido_em = EXCLUDED.resolvido_em
+	`
+	_, err := r.db.Exec(ctx, query,
+		a.ID, a.Tipo, a.Chave, a.ReferenciaID, a.ObraID, a.Titulo, a.Descricao, a.Severidade, a.Status,
+		a.DetectadoEm, a.AtualizadoEm, a.ReconhecidoPor, a.ReconhecidoEm, a.AdiadoAte, a.ResolvidoEm,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *AlertaRepositoryPostgres) BuscarPorID(ctx context.Context, id string) (*alertas.Alerta, error) {
+	const op = "repository.postgres.alerta.BuscarPorID"
+	rows, err := r.db.Query(ctx, `SELECT `+colunasAlerta+` FROM alertas WHERE id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	a, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByPos[alertas.Alerta])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return a, nil
+}
+
+func (r *AlertaRepositoryPostgres) ListarAbertosPorTipo(ctx context.Context, tipo alertas.TipoAlerta) ([]*alertas.Alerta, error) {
+	const op = "repository.postgres.alerta.ListarAbertosPorTipo"
+	query := `SELECT ` + colunasAlerta + ` FROM alertas WHERE tipo = $1 AND status <> 'RESOLVIDO'`
+	rows, err := r.db.Query(ctx, query, tipo)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	lista, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByPos[alertas.Alerta])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return lista, nil
+}
+
+// Listar retorna os alertas do filtro, dos mais graves para os menos graves e dos mais recentes para os mais antigos.
+func (r *AlertaRepositoryPostgres) Listar(ctx context.Context, filtro alertas.FiltroAlertas) ([]*alertas.Alerta, error) {
+	const op = "repository.postgres.alerta.Listar"
+
+	status := []string{string(alertas.StatusAtivo), string(alertas.StatusReconhecido)}
+	if len(filtro.Status) > 0 {
+		status = make([]string, 0, len(filtro.Status))
+		for _, s := range filtro.Status {
+			status = append(status, string(s))
+		}
+	}
+	args := pgx.NamedArgs{"status": status}
+	condicoes := []string{"status = ANY(@status)"}
+	if filtro.Tipo != nil {
+		condicoes = append(condicoes, "tipo = @tipo")
+		args["tipo"] = *filtro.Tipo
+	}
+	if filtro.Severidade != nil {
+		condicoes = append(condicoes, "severidade = @severidade")
+		args["severidade"] = *filtro.Severidade
+	}
+	if filtro.ObraID != nil {
+		condicoes = append(condicoes, "obra_id = @obra_id")
+		args["obra_id"] = *filtro.ObraID
+	}
+
+	query := `
+		SELECT ` + colunasAlerta + `
+		FROM alertas
+		WHERE ` + strings.Join(condicoes, " AND ") + `
+		ORDER BY CASE severidade WHEN 'CRITICA' THEN 0 WHEN 'ATENCAO' THEN 1 ELSE 2 END, detectado_em DESC
+	`
+	if filtro.Limite > 0 {
+		query += " LIMIT @limite"
+		args["limite"] = filtro.Limite
+	}
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	lista, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByPos[alertas.Alerta])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return lista, nil
+}
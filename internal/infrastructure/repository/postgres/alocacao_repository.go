@@ -3,12 +3,16 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
 )
 
 type AlocacaoRepositoryPostgres struct {
@@ -16,17 +20,28 @@ type AlocacaoRepositoryPostgres struct {
 	logger *slog.Logger
 }
 
-func NovoAlocacaoRepository(db *pgxpool.Pool, logger *slog.Logger) obras.AlocacaoRepository {
+func NovoAlocacaoRepository(db *pgxpool.Pool, logger *slog.Logger) *AlocacaoRepositoryPostgres {
 	return &AlocacaoRepositoryPostgres{db: db, logger: logger}
 }
 
+const colunasAlocacao = `id, obra_id, funcionario_id, data_inicio_alocacao, data_fim_alocacao,
+	motivo_encerramento, motivo_sobreposicao, transferida_de_id`
+
+const inserirAlocacao = `
+	INSERT INTO alocacoes (` + colunasAlocacao + `)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+const encerrarAlocacao = `UPDATE alocacoes SET data_fim_alocacao = $2, motivo_encerramento = $3 WHERE id = $1`
+
+func argsAlocacao(a *obras.Alocacao) []any {
+	return []any{a.ID, a.ObraID, a.FuncionarioID, a.DataInicioAlocacao, a.DataFimAlocacao,
+		a.MotivoEncerramento, a.MotivoSobreposicao, a.TransferidaDeID}
+}
+
 func (r *AlocacaoRepositoryPostgres) Salvar(ctx context.Context, a *obras.Alocacao) error {
 	const op = "repository.postgres.alocacao.Salvar"
-	query := `
-		INSERT INTO alocacoes (id, obra_id, funcionario_id, data_inicio_alocacao, data_fim_alocacao)
-		VALUES ($1, $2, $3, $4, $5)
-	`
-	_, err := r.db.Exec(ctx, query, a.ID, a.ObraID, a.FuncionarioID, a.DataInicioAlocacao, a.DataFimAlocacao)
+	_, err := r.db.Exec(ctx, inserirAlocacao, argsAlocacao(a)...)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -38,12 +53,8 @@ func (r *AlocacaoRepositoryPostgres) SalvarMuitos(ctx context.Context, alocacoes
 	const op = "repository.postgres.alocacao.SalvarMuitos"
 
 	batch := &pgx.Batch{}
-	query := `
-		INSERT INTO alocacoes (id, obra_id, funcionario_id, data_inicio_alocacao, data_fim_alocacao)
-		VALUES ($1, $2, $3, $4, $5)
-	`
 	for _, a := range alocacoes {
-		batch.Queue(query, a.ID, a.ObraID, a.FuncionarioID, a.DataInicioAlocacao, a.DataFimAlocacao)
+		batch.Queue(inserirAlocacao, argsAlocacao(a)...)
 	}
 
 	br := r.db.SendBatch(ctx, batch)
@@ -54,6 +65,79 @@ func (r *AlocacaoRepositoryPostgres) SalvarMuitos(ctx context.Context, alocacoes
 	return nil
 }
 
+// Atualizar grava o encerramento da alocação.
+func (r *AlocacaoRepositoryPostgres) Atualizar(ctx context.Context, a *obras.Alocacao) error {
+	const op = "repository.postgres.alocacao.Atualizar"
+	cmd, err := r.db.Exec(ctx, encerrarAlocacao, a.ID, a.DataFimAlocacao, a.MotivoEncerramento)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrNaoEncontrado
+	}
+	return nil
+}
+
+// AtualizarMuitas grava o encerramento das alocações na transação do chamador.
+func (r *AlocacaoRepositoryPostgres) AtualizarMuitas(ctx context.Context, dbtx db.DBTX, alocacoes []*obras.Alocacao) error {
+	const op = "repository.postgres.alocacao.AtualizarMuitas"
+	for _, a := range alocacoes {
+		cmd, err := dbtx.Exec(ctx, encerrarAlocacao, a.ID, a.DataFimAlocacao, a.MotivoEncerramento)
+		if err != nil {
+			return fmt.Errorf("%s: alocação %s: %w", op, a.ID, err)
+		}
+		if cmd.RowsAffected() == 0 {
+			return ErrNaoEncontrado
+		}
+	}
+	return nil
+}
+
+// DeletarMuitas remove as alocações na transação do chamador. Alocações
+// transferidas a partir delas perdem a referência (transferida_de_id).
+func (r *AlocacaoRepositoryPostgres) DeletarMuitas(ctx context.Context, dbtx db.DBTX, ids []string) error {
+	const op = "repository.postgres.alocacao.DeletarMuitas"
+	if len(ids) == 0 {
+		return nil
+	}
+	if _, err := dbtx.Exec(ctx, `DELETE FROM alocacoes WHERE id = ANY($1)`, ids); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Transferir encerra a alocação de origem e cria a de destino na mesma transação.
+func (r *AlocacaoRepositoryPostgres) Transferir(ctx context.Context, encerrada, nova *obras.Alocacao) error {
+	const op = "repository.postgres.alocacao.Transferir"
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		cmd, err := tx.Exec(ctx, encerrarAlocacao, encerrada.ID, encerrada.DataFimAlocacao, encerrada.MotivoEncerramento)
+		if err != nil {
+			return err
+		}
+		if cmd.RowsAffected() == 0 {
+			return ErrNaoEncontrado
+		}
+		_, err = tx.Exec(ctx, inserirAlocacao, argsAlocacao(nova)...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *AlocacaoRepositoryPostgres) BuscarPorID(ctx context.Context, id string) (*obras.Alocacao, error) {
+	const op = "repository.postgres.alocacao.BuscarPorID"
+	a, err := scanAlocacao(r.db.QueryRow(ctx, `SELECT `+colunasAlocacao+` FROM alocacoes WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return a, nil
+}
+
 func (r *AlocacaoRepositoryPostgres) ExistemAlocacoesAtivasParaFuncionario(ctx context.Context, funcionarioID string) (bool, error) {
 	const op = "repository.postgres.alocacao.ExistemAlocacoesAtivasParaFuncionario"
 	query := `SELECT EXISTS(SELECT 1 FROM alocacoes WHERE funcionario_id = $1 AND (data_fim_alocacao IS NULL OR data_fim_alocacao >= CURRENT_DATE))`
@@ -65,3 +149,102 @@ func (r *AlocacaoRepositoryPostgres) ExistemAlocacoesAtivasParaFuncionario(ctx c
 	}
 	return existe, nil
 }
+
+func (r *AlocacaoRepositoryPostgres) ListarPorObraID(ctx context.Context, obraID string) ([]*obras.Alocacao, error) {
+	const op = "repository.postgres.alocacao.ListarPorObraID"
+	query := `SELECT ` + colunasAlocacao + ` FROM alocacoes WHERE obra_id = $1 ORDER BY data_inicio_alocacao`
+	alocacoes, err := r.listar(ctx, r.db, query, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return alocacoes, nil
+}
+
+func (r *AlocacaoRepositoryPostgres) ListarPorFuncionarioID(ctx context.Context, funcionarioID string) ([]*obras.Alocacao, error) {
+	const op = "repository.postgres.alocacao.ListarPorFuncionarioID"
+	query := `SELECT ` + colunasAlocacao + ` FROM alocacoes WHERE funcionario_id = $1 ORDER BY data_inicio_alocacao`
+	alocacoes, err := r.listar(ctx, r.db, query, funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return alocacoes, nil
+}
+
+// ListarAtivasDoFuncionario lista as alocações sem fim ou que terminam depois da data.
+func (r *AlocacaoRepositoryPostgres) ListarAtivasDoFuncionario(ctx context.Context, dbtx db.DBTX, funcionarioID string, data time.Time) ([]*obras.Alocacao, error) {
+	const op = "repository.postgres.alocacao.ListarAtivasDoFuncionario"
+	query := `SELECT ` + colunasAlocacao + ` FROM alocacoes
+		WHERE funcionario_id = $1 AND (data_fim_alocacao IS NULL OR data_fim_alocacao > $2)
+		ORDER BY data_inicio_alocacao`
+	alocacoes, err := r.listar(ctx, dbtx, query, funcionarioID, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return alocacoes, nil
+}
+
+// ListarNoPeriodo lista as alocações que têm algum dia entre inicio e fim.
+func (r *AlocacaoRepositoryPostgres) ListarNoPeriodo(ctx context.Context, inicio, fim time.Time) ([]*obras.Alocacao, error) {
+	const op = "repository.postgres.alocacao.ListarNoPeriodo"
+	query := `SELECT ` + colunasAlocacao + ` FROM alocacoes
+		WHERE data_inicio_alocacao <= $2 AND (data_fim_alocacao IS NULL OR data_fim_alocacao >= $1)
+		ORDER BY funcionario_id, data_inicio_alocacao`
+	alocacoes, err := r.listar(ctx, r.db, query, inicio, fim)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return alocacoes, nil
+}
+
+// ListarHistoricoAlocacoes traz as alocações com os nomes da obra e do funcionário,
+// filtrando pela obra ou pelo funcionário (o filtro vazio é ignorado).
+func (r *AlocacaoRepositoryPostgres) ListarHistoricoAlocacoes(ctx context.Context, obraID, funcionarioID string) ([]*dto.AlocacaoHistoricoDTO, error) {
+	const op = "repository.postgres.alocacao.ListarHistoricoAlocacoes"
+	query := `
+		SELECT a.id, a.obra_id, o.nome AS obra_nome, a.funcionario_id, f.nome AS funcionario_nome,
+		       a.data_inicio_alocacao, a.data_fim_alocacao, a.motivo_encerramento,
+		       a.motivo_sobreposicao, a.transferida_de_id
+		FROM alocacoes a
+		JOIN obras o ON o.id = a.obra_id
+		JOIN funcionarios f ON f.id = a.funcionario_id
+		WHERE ($1 = '' OR a.obra_id::text = $1) AND ($2 = '' OR a.funcionario_id::text = $2)
+		ORDER BY a.data_inicio_alocacao DESC, f.nome
+	`
+	rows, err := r.db.Query(ctx, query, obraID, funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	historico, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[dto.AlocacaoHistoricoDTO])
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao escanear histórico: %w", op, err)
+	}
+	return historico, nil
+}
+
+func (r *AlocacaoRepositoryPostgres) listar(ctx context.Context, dbtx db.DBTX, query string, args ...any) ([]*obras.Alocacao, error) {
+	rows, err := dbtx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	alocacoes := make([]*obras.Alocacao, 0)
+	for rows.Next() {
+		a, err := scanAlocacao(rows)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao escanear alocação: %w", err)
+		}
+		alocacoes = append(alocacoes, a)
+	}
+	return alocacoes, rows.Err()
+}
+
+func scanAlocacao(row pgx.Row) (*obras.Alocacao, error) {
+	var a obras.Alocacao
+	err := row.Scan(&a.ID, &a.ObraID, &a.FuncionarioID, &a.DataInicioAlocacao, &a.DataFimAlocacao,
+		&a.MotivoEncerramento, &a.MotivoSobreposicao, &a.TransferidaDeID)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
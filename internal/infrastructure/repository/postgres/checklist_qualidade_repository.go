@@ -0,0 +1,202 @@
+// file: internal/infrastructure/repository/postgres/checklist_qualidade_repository.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
+)
+
+// ChecklistQualidadeRepositoryPostgres persiste os modelos de FVS e as FVS das
+// etapas. Itens e inspeções ficam em colunas JSONB.
+type ChecklistQualidadeRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoChecklistQualidadeRepository(db *pgxpool.Pool, logger *slog.Logger) *ChecklistQualidadeRepositoryPostgres {
+	return &ChecklistQualidadeRepositoryPostgres{db: db, logger: logger}
+}
+
+const colunasModeloChecklist = `id, etapa_padrao_id, nome, descricao, obrigatorio, itens, created_at, updated_at`
+
+const colunasChecklistEtapa = `id, obra_id, etapa_id, modelo_id, nome, obrigatorio, itens, status, inspecoes, created_at, updated_at`
+
+func (r *ChecklistQualidadeRepositoryPostgres) SalvarModelo(ctx context.Context, m *obras.ModeloChecklist) error {
+	const op = "repository.postgres.checklist_qualidade.SalvarModelo"
+	query := `
+		INSERT INTO modelos_checklist (` + colunasModeloChecklist + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.db.Exec(ctx, query, m.ID, m.EtapaPadraoID, m.Nome, m.Descricao, m.Obrigatorio, m.Itens, m.CreatedAt, m.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *ChecklistQualidadeRepositoryPostgres) AtualizarModelo(ctx context.Context, m *obras.ModeloChecklist) error {
+	const op = "repository.postgres.checklist_qualidade.AtualizarModelo"
+	query := `
+		UPDATE modelos_checklist
+		SET etapa_padrao_id = $2, nome = $3, descricao = $4, obrigatorio = $5, itens = $6, updated_at = $7
+		WHERE id = $1
+	`
+	cmd, err := r.db.Exec(ctx, query, m.ID, m.EtapaPadraoID, m.Nome, m.Descricao, m.Obrigatorio, m.Itens, m.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrNaoEncontrado
+	}
+	return nil
+}
+
+func (r *ChecklistQualidadeRepositoryPostgres) BuscarModeloPorID(ctx context.Context, id string) (*obras.ModeloChecklist, error) {
+	const op = "repository.postgres.checklist_qualidade.BuscarModeloPorID"
+	query := `SELECT ` + colunasModeloChecklist + ` FROM modelos_checklist WHERE id = $1`
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	m, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByPos[obras.ModeloChecklist])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return m, nil
+}
+
+// ListarModelos lista os modelos de FVS; com etapaPadraoID, apenas os daquela etapa padrão.
+func (r *ChecklistQualidadeRepositoryPostgres) ListarModelos(ctx context.Context, etapaPadraoID string) ([]*obras.ModeloChecklist, error) {
+	const op = "repository.postgres.checklist_qualidade.ListarModelos"
+	query := `
+		SELECT ` + colunasModeloChecklist + `
+		FROM modelos_checklist
+		WHERE ($1 = '' OR etapa_padrao_id::text = $1)
+		ORDER BY nome
+	`
+	rows, err := r.db.Query(ctx, query, etapaPadraoID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	modelos, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByPos[obras.ModeloChecklist])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return modelos, nil
+}
+
+func (r *ChecklistQualidadeRepositoryPostgres) DeletarModelo(ctx context.Context, id string) error {
+	const op = "repository.postgres.checklist_qualidade.DeletarModelo"
+	cmd, err := r.db.Exec(ctx, `DELETE FROM modelos_checklist WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrNaoEncontrado
+	}
+	return nil
+}
+
+// SalvarChecklists grava as FVS instanciadas nas etapas, na transação de quem cria as etapas.
+func (r *ChecklistQualidadeRepositoryPostgres) SalvarChecklists(ctx context.Context, dbtx db.DBTX, checklists []*obras.ChecklistEtapa) error {
+	const op = "repository.postgres.checklist_qualidade.SalvarChecklists"
+	query := `
+		INSERT INTO etapa_checklists (` + colunasChecklistEtapa + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	for _, c := range checklists {
+		_, err := dbtx.Exec(ctx, query,
+			c.ID, c.ObraID, c.EtapaID, c.ModeloID, c.Nome, c.Obrigatorio, c.Itens, c.Status, c.Inspecoes, c.CreatedAt, c.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return nil
+}
+
+func (r *ChecklistQualidadeRepositoryPostgres) AtualizarChecklist(ctx context.Context, c *obras.ChecklistEtapa) error {
+	const op = "repository.postgres.checklist_qualidade.AtualizarChecklist"
+	query := `
+		UPDATE etapa_checklists
+		SET status = $2, inspecoes = $3, updated_at = $4
+		WHERE id = $1
+	`
+	if _, err := r.db.Exec(ctx, query, c.ID, c.Status, c.Inspecoes, c.UpdatedAt); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *ChecklistQualidadeRepositoryPostgres) BuscarChecklistPorID(ctx context.Context, id string) (*obras.ChecklistEtapa, error) {
+	const op = "repository.postgres.checklist_qualidade.BuscarChecklistPorID"
+	query := `SELECT ` + colunasChecklistEtapa + ` FROM etapa_checklists WHERE id = $1`
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	c, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByPos[obras.ChecklistEtapa])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return c, nil
+}
+
+func (r *ChecklistQualidadeRepositoryPostgres) ListarChecklistsPorEtapaID(ctx context.Context, etapaID string) ([]*obras.ChecklistEtapa, error) {
+	const op = "repository.postgres.checklist_qualidade.ListarChecklistsPorEtapaID"
+	query := `SELECT ` + colunasChecklistEtapa + ` FROM etapa_checklists WHERE etapa_id = $1 ORDER BY obrigatorio DESC, nome`
+
+	rows, err := r.db.Query(ctx, query, etapaID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	checklists, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByPos[obras.ChecklistEtapa])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return checklists, nil
+}
+
+// ListarChecklistsPorObraID lista as FVS da obra na ordem das etapas.
+func (r *ChecklistQualidadeRepositoryPostgres) ListarChecklistsPorObraID(ctx context.Context, obraID string) ([]*obras.ChecklistEtapa, error) {
+	const op = "repository.postgres.checklist_qualidade.ListarChecklistsPorObraID"
+	query := `
+		SELECT c.id, c.obra_id, c.etapa_id, c.modelo_id, c.nome, c.obrigatorio, c.itens, c.status, c.inspecoes, c.created_at, c.updated_at
+		FROM etapa_checklists c
+		JOIN etapas e ON e.id = c.etapa_id
+		WHERE c.obra_id = $1
+		ORDER BY e.data_inicio_prevista NULLS LAST, e.nome, c.obrigatorio DESC, c.nome
+	`
+	rows, err := r.db.Query(ctx, query, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	checklists, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByPos[obras.ChecklistEtapa])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return checklists, nil
+}
+
+func (r *ChecklistQualidadeRepositoryPostgres) DeletarChecklist(ctx context.Context, id string) error {
+	const op = "repository.postgres.checklist_qualidade.DeletarChecklist"
+	if _, err := r.db.Exec(ctx, `DELETE FROM etapa_checklists WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
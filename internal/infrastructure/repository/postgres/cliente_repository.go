@@ -0,0 +1,274 @@
+// file: internal/infrastructure/repository/postgres/cliente_repository.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/clientes"
+	"github.com/luiszkm/masterCostrutora/internal/domain/common"
+)
+
+// ClienteRepositoryPostgres persiste os clientes. Contatos e endereço de cobrança
+// ficam em colunas JSONB.
+type ClienteRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoClienteRepository(db *pgxpool.Pool, logger *slog.Logger) *ClienteRepositoryPostgres {
+	return &ClienteRepositoryPostgres{db: db, logger: logger}
+}
+
+const colunasCliente = `id, tipo, nome, documento, email, telefone, contatos, endereco_cobranca, observacoes, created_at, updated_at`
+
+// nomeNormalizadoSQL espelha clientes.NormalizarNome e usa o índice da migração 011.
+const nomeNormalizadoSQL = `LOWER(REGEXP_REPLACE(TRIM(nome), '\s+', ' ', 'g'))`
+
+func (r *ClienteRepositoryPostgres) Salvar(ctx context.Context, c *clientes.Cliente) error {
+	const op = "repository.postgres.cliente.Salvar"
+	query := `
+		INSERT INTO clientes (` + colunasCliente + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err := r.db.Exec(ctx, query,
+		c.ID, tipoOuNulo(c.Tipo), c.Nome, c.Documento, c.Email, c.Telefone, c.Contatos, c.EnderecoCobranca,
+		c.Observacoes, c.CreatedAt, c.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Atualizar grava o cliente e repassa o nome para obras e contas a receber
+// vinculadas, que guardam uma cópia dele para listagens e relatórios.
+func (r *ClienteRepositoryPostgres) Atualizar(ctx context.Context, c *clientes.Cliente) error {
+	const op = "repository.postgres.cliente.Atualizar"
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: falha ao iniciar transação: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := atualizarCliente(ctx, tx, c); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: falha ao fazer commit: %w", op, err)
+	}
+	return nil
+}
+
+// Mesclar grava o cliente sobrevivente, transfere para ele as obras e contas a receber
+// do duplicado e exclui o duplicado, tudo em uma transação. O documento do duplicado é
+// liberado para que o sobrevivente possa assumi-lo.
+func (r *ClienteRepositoryPostgres) Mesclar(ctx context.Context, sobrevivente *clientes.Cliente, duplicadoID string) error {
+	const op = "repository.postgres.cliente.Mesclar"
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: falha ao iniciar transação: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	cmd, err := tx.Exec(ctx, `
+		UPDATE clientes SET deleted_at = NOW(), tipo = NULL, documento = NULL, updated_at = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`, duplicadoID, sobrevivente.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: falha ao excluir o cliente duplicado: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrNaoEncontrado
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE obras SET cliente_id = $2 WHERE cliente_id = $1`, duplicadoID, sobrevivente.ID); err != nil {
+		return fmt.Errorf("%s: falha ao transferir obras: %w", op, err)
+	}
+	if _, err := tx.Exec(ctx, `UPDATE contas_receber SET cliente_id = $2 WHERE cliente_id = $1`, duplicadoID, sobrevivente.ID); err != nil {
+		return fmt.Errorf("%s: falha ao transferir contas a receber: %w", op, err)
+	}
+
+	// Também repassa o nome do sobrevivente para os registros recém-transferidos
+	if err := atualizarCliente(ctx, tx, sobrevivente); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: falha ao fazer commit: %w", op, err)
+	}
+	return nil
+}
+
+// atualizarCliente grava o cliente e a cópia do nome nas obras e contas a receber vinculadas.
+func atualizarCliente(ctx context.Context, tx pgx.Tx, c *clientes.Cliente) error {
+	query := `
+		UPDATE clientes
+		SET tipo = $2, nome = $3, documento = $4, email = $5, telefone = $6, contatos = $7,
+			endereco_cobranca = $8, observacoes = $9, updated_at = $10
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	cmd, err := tx.Exec(ctx, query,
+		c.ID, tipoOuNulo(c.Tipo), c.Nome, c.Documento, c.Email, c.Telefone, c.Contatos, c.EnderecoCobranca,
+		c.Observacoes, c.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrNaoEncontrado
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE obras SET cliente = $2 WHERE cliente_id = $1 AND cliente <> $2`, c.ID, c.Nome); err != nil {
+		return fmt.Errorf("falha ao atualizar obras do cliente: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `UPDATE contas_receber SET cliente = $2 WHERE cliente_id = $1 AND cliente <> $2`, c.ID, c.Nome); err != nil {
+		return fmt.Errorf("falha ao atualizar contas a receber do cliente: %w", err)
+	}
+	return nil
+}
+
+func (r *ClienteRepositoryPostgres) BuscarPorID(ctx context.Context, id string) (*clientes.Cliente, error) {
+	const op = "repository.postgres.cliente.BuscarPorID"
+	query := `SELECT ` + colunasCliente + ` FROM clientes WHERE id = $1 AND deleted_at IS NULL`
+	return r.buscarUm(ctx, op, query, id)
+}
+
+func (r *ClienteRepositoryPostgres) BuscarPorDocumento(ctx context.Context, documento string) (*clientes.Cliente, error) {
+	const op = "repository.postgres.cliente.BuscarPorDocumento"
+	query := `SELECT ` + colunasCliente + ` FROM clientes WHERE documento = $1 AND deleted_at IS NULL`
+	return r.buscarUm(ctx, op, query, documento)
+}
+
+// BuscarPorNome encontra o cliente pelo nome normalizado. Havendo homônimos, o
+// mais antigo é retornado.
+func (r *ClienteRepositoryPostgres) BuscarPorNome(ctx context.Context, nome string) (*clientes.Cliente, error) {
+	const op = "repository.postgres.cliente.BuscarPorNome"
+	query := `SELECT ` + colunasCliente + ` FROM clientes
+		WHERE ` + nomeNormalizadoSQL + ` = $1 AND deleted_at IS NULL
+		ORDER BY created_at LIMIT 1`
+	return r.buscarUm(ctx, op, query, clientes.NormalizarNome(nome))
+}
+
+// Listar lista os clientes por nome, com busca opcional por nome ou documento.
+func (r *ClienteRepositoryPostgres) Listar(ctx context.Context, busca string, filtros common.ListarFiltros) ([]*clientes.Cliente, *common.PaginacaoInfo, error) {
+	const op = "repository.postgres.cliente.Listar"
+
+	args := pgx.NamedArgs{}
+	whereClauses := []string{"deleted_at IS NULL"}
+	if busca = strings.TrimSpace(busca); busca != "" {
+		clausula := "nome ILIKE @busca"
+		args["busca"] = "%" + busca + "%"
+		if documento := clientes.NormalizarDocumento(busca); documento != "" {
+			clausula += " OR documento LIKE @documento"
+			args["documento"] = documento + "%"
+		}
+		whereClauses = append(whereClauses, "("+clausula+")")
+	}
+	whereString := " WHERE " + strings.Join(whereClauses, " AND ")
+
+	var totalItens int
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM clientes"+whereString, args).Scan(&totalItens); err != nil {
+		return nil, nil, fmt.Errorf("%s: erro ao contar clientes: %w", op, err)
+	}
+	paginacao := common.NewPaginacaoInfo(totalItens, filtros.Pagina, filtros.TamanhoPagina)
+	if totalItens == 0 {
+		return []*clientes.Cliente{}, paginacao, nil
+	}
+
+	args["limit"] = filtros.TamanhoPagina
+	args["offset"] = (filtros.Pagina - 1) * filtros.TamanhoPagina
+	query := `SELECT ` + colunasCliente + ` FROM clientes` + whereString + ` ORDER BY nome LIMIT @limit OFFSET @offset`
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	lista := make([]*clientes.Cliente, 0)
+	for rows.Next() {
+		c, err := scanCliente(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: falha ao escanear cliente: %w", op, err)
+		}
+		lista = append(lista, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return lista, paginacao, nil
+}
+
+// ContarVinculos conta as obras e contas a receber que apontam para o cliente.
+func (r *ClienteRepositoryPostgres) ContarVinculos(ctx context.Context, id string) (int, error) {
+	const op = "repository.postgres.cliente.ContarVinculos"
+	query := `
+		SELECT (SELECT COUNT(*) FROM obras WHERE cliente_id = $1 AND deleted_at IS NULL)
+		     + (SELECT COUNT(*) FROM contas_receber WHERE cliente_id = $1)
+	`
+	var total int
+	if err := r.db.QueryRow(ctx, query, id).Scan(&total); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return total, nil
+}
+
+// Deletar faz a exclusão lógica do cliente.
+func (r *ClienteRepositoryPostgres) Deletar(ctx context.Context, id string) error {
+	const op = "repository.postgres.cliente.Deletar"
+	cmd, err := r.db.Exec(ctx, `UPDATE clientes SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrNaoEncontrado
+	}
+	return nil
+}
+
+func (r *ClienteRepositoryPostgres) buscarUm(ctx context.Context, op, query string, arg any) (*clientes.Cliente, error) {
+	c, err := scanCliente(r.db.QueryRow(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return c, nil
+}
+
+func scanCliente(row pgx.Row) (*clientes.Cliente, error) {
+	var c clientes.Cliente
+	var tipo *string
+	err := row.Scan(
+		&c.ID, &tipo, &c.Nome, &c.Documento, &c.Email, &c.Telefone, &c.Contatos, &c.EnderecoCobranca,
+		&c.Observacoes, &c.CreatedAt, &c.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tipo != nil {
+		c.Tipo = clientes.TipoPessoa(*tipo)
+	}
+	if c.Contatos == nil {
+		c.Contatos = []clientes.Contato{}
+	}
+	return &c, nil
+}
+
+func tipoOuNulo(tipo clientes.TipoPessoa) *string {
+	if tipo == "" {
+		return nil
+	}
+	t := string(tipo)
+	return &t
+}
@@ -28,8 +28,9 @@ func (r *ContaReceberRepositoryPostgres) Salvar(ctx context.Context, dbtx db.DBT
 			id, obra_id, cronograma_recebimento_id, cliente, tipo_conta_receber,
 			descricao, valor_original, valor_recebido, data_vencimento, 
 			data_recebimento, status, forma_pagamento, observacoes, 
-			numero_documento, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+			numero_documento, created_at, updated_at, cliente_id,
+			linha_digitavel, url_boleto, valor_retido
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
 	`
 
 	// Se não há transação, usar o pool
@@ -54,6 +55,10 @@ func (r *ContaReceberRepositoryPostgres) Salvar(ctx context.Context, dbtx db.DBT
 		conta.NumeroDocumento,
 		conta.CreatedAt,
 		conta.UpdatedAt,
+		conta.ClienteID,
+		conta.LinhaDigitavel,
+		conta.URLBoleto,
+		conta.ValorRetido,
 	)
 
 	if err != nil {
@@ -65,7 +70,24 @@ func (r *ContaReceberRepositoryPostgres) Salvar(ctx context.Context, dbtx db.DBT
 
 func (r *ContaReceberRepositoryPostgres) Atualizar(ctx context.Context, conta *financeiro.ContaReceber) error {
 	const op = "repository.postgres.conta_receber.Atualizar"
+	if err := r.atualizar(ctx, r.dbpool, conta); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// AtualizarMuitas atualiza as contas na transação do chamador.
+func (r *ContaReceberRepositoryPostgres) AtualizarMuitas(ctx context.Context, dbtx db.DBTX, contas []*financeiro.ContaReceber) error {
+	const op = "repository.postgres.conta_receber.AtualizarMuitas"
+	for _, conta := range contas {
+		if err := r.atualizar(ctx, dbtx, conta); err != nil {
+			return fmt.Errorf("%s: conta %s: %w", op, conta.ID, err)
+		}
+	}
+	return nil
+}
 
+func (r *ContaReceberRepositoryPostgres) atualizar(ctx context.Context, dbtx db.DBTX, conta *financeiro.ContaReceber) error {
 	query := `
 		UPDATE contas_receber 
 		SET cliente = $2,
@@ -79,11 +101,14 @@ func (r *ContaReceberRepositoryPostgres) Atualizar(ctx context.Context, conta *f
 			forma_pagamento = $10,
 			observacoes = $11,
 			numero_documento = $12,
-			updated_at = $13
+			updated_at = $13,
+			cliente_id = $14,
+			linha_digitavel = $15,
+			url_boleto = $16
 		WHERE id = $1
 	`
 
-	result, err := r.dbpool.Exec(ctx, query,
+	result, err := dbtx.Exec(ctx, query,
 		conta.ID,
 		conta.Cliente,
 		conta.TipoContaReceber,
@@ -97,14 +122,17 @@ func (r *ContaReceberRepositoryPostgres) Atualizar(ctx context.Context, conta *f
 		conta.Observacoes,
 		conta.NumeroDocumento,
 		conta.UpdatedAt,
+		conta.ClienteID,
+		conta.LinhaDigitavel,
+		conta.URLBoleto,
 	)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return err
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("%s: conta a receber não encontrada", op)
+		return fmt.Errorf("conta a receber não encontrada")
 	}
 
 	return nil
@@ -114,10 +142,10 @@ func (r *ContaReceberRepositoryPostgres) BuscarPorID(ctx context.Context, id str
 	const op = "repository.postgres.conta_receber.BuscarPorID"
 
 	query := `
-		SELECT id, obra_id, cronograma_recebimento_id, cliente, tipo_conta_receber,
-			   descricao, valor_original, valor_recebido, data_vencimento, 
+		SELECT id, obra_id, cronograma_recebimento_id, cliente_id, cliente, tipo_conta_receber,
+			   descricao, valor_original, valor_recebido, valor_retido, data_vencimento, 
 			   data_recebimento, status, forma_pagamento, observacoes, 
-			   numero_documento, created_at, updated_at
+			   numero_documento, linha_digitavel, url_boleto, created_at, updated_at
 		FROM contas_receber 
 		WHERE id = $1
 	`
@@ -129,17 +157,21 @@ func (r *ContaReceberRepositoryPostgres) BuscarPorID(ctx context.Context, id str
 		&conta.ID,
 		&conta.ObraID,
 		&conta.CronogramaRecebimentoID,
+		&conta.ClienteID,
 		&conta.Cliente,
 		&conta.TipoContaReceber,
 		&conta.Descricao,
 		&conta.ValorOriginal,
 		&conta.ValorRecebido,
+		&conta.ValorRetido,
 		&conta.DataVencimento,
 		&conta.DataRecebimento,
 		&conta.Status,
 		&conta.FormaPagamento,
 		&conta.Observacoes,
 		&conta.NumeroDocumento,
+		&conta.LinhaDigitavel,
+		&conta.URLBoleto,
 		&conta.CreatedAt,
 		&conta.UpdatedAt,
 	)
@@ -158,10 +190,10 @@ func (r *ContaReceberRepositoryPostgres) ListarPorObraID(ctx context.Context, ob
 	const op = "repository.postgres.conta_receber.ListarPorObraID"
 
 	query := `
-		SELECT id, obra_id, cronograma_recebimento_id, cliente, tipo_conta_receber,
-			   descricao, valor_original, valor_recebido, data_vencimento, 
+		SELECT id, obra_id, cronograma_recebimento_id, cliente_id, cliente, tipo_conta_receber,
+			   descricao, valor_original, valor_recebido, valor_retido, data_vencimento, 
 			   data_recebimento, status, forma_pagamento, observacoes, 
-			   numero_documento, created_at, updated_at
+			   numero_documento, linha_digitavel, url_boleto, created_at, updated_at
 		FROM contas_receber 
 		WHERE obra_id = $1
 		ORDER BY data_vencimento ASC
@@ -180,10 +212,10 @@ func (r *ContaReceberRepositoryPostgres) ListarVencidas(ctx context.Context) ([]
 	const op = "repository.postgres.conta_receber.ListarVencidas"
 
 	query := `
-		SELECT id, obra_id, cronograma_recebimento_id, cliente, tipo_conta_receber,
-			   descricao, valor_original, valor_recebido, data_vencimento, 
+		SELECT id, obra_id, cronograma_recebimento_id, cliente_id, cliente, tipo_conta_receber,
+			   descricao, valor_original, valor_recebido, valor_retido, data_vencimento, 
 			   data_recebimento, status, forma_pagamento, observacoes, 
-			   numero_documento, created_at, updated_at
+			   numero_documento, linha_digitavel, url_boleto, created_at, updated_at
 		FROM contas_receber 
 		WHERE data_vencimento < CURRENT_DATE
 		  AND status NOT IN ('RECEBIDO', 'CANCELADO')
@@ -204,10 +236,10 @@ func (r *ContaReceberRepositoryPostgres) ListarVencidasPorPeriodo(ctx context.Co
 	const op = "repository.postgres.conta_receber.ListarVencidasPorPeriodo"
 
 	query := `
-		SELECT id, obra_id, cronograma_recebimento_id, cliente, tipo_conta_receber,
-			   descricao, valor_original, valor_recebido, data_vencimento, 
+		SELECT id, obra_id, cronograma_recebimento_id, cliente_id, cliente, tipo_conta_receber,
+			   descricao, valor_original, valor_recebido, valor_retido, data_vencimento, 
 			   data_recebimento, status, forma_pagamento, observacoes, 
-			   numero_documento, created_at, updated_at
+			   numero_documento, linha_digitavel, url_boleto, created_at, updated_at
 		FROM contas_receber 
 		WHERE data_vencimento BETWEEN $1 AND $2
 		  AND status NOT IN ('RECEBIDO', 'CANCELADO')
@@ -228,10 +260,10 @@ func (r *ContaReceberRepositoryPostgres) ListarPorStatus(ctx context.Context, st
 	const op = "repository.postgres.conta_receber.ListarPorStatus"
 
 	query := `
-		SELECT id, obra_id, cronograma_recebimento_id, cliente, tipo_conta_receber,
-			   descricao, valor_original, valor_recebido, data_vencimento, 
+		SELECT id, obra_id, cronograma_recebimento_id, cliente_id, cliente, tipo_conta_receber,
+			   descricao, valor_original, valor_recebido, valor_retido, data_vencimento, 
 			   data_recebimento, status, forma_pagamento, observacoes, 
-			   numero_documento, created_at, updated_at
+			   numero_documento, linha_digitavel, url_boleto, created_at, updated_at
 		FROM contas_receber 
 		WHERE status = $1
 		ORDER BY data_vencimento ASC
@@ -246,20 +278,20 @@ func (r *ContaReceberRepositoryPostgres) ListarPorStatus(ctx context.Context, st
 	return r.scanContasReceber(ctx, rows, op)
 }
 
-func (r *ContaReceberRepositoryPostgres) ListarPorCliente(ctx context.Context, cliente string) ([]*financeiro.ContaReceber, error) {
-	const op = "repository.postgres.conta_receber.ListarPorCliente"
+func (r *ContaReceberRepositoryPostgres) ListarPorClienteID(ctx context.Context, clienteID string) ([]*financeiro.ContaReceber, error) {
+	const op = "repository.postgres.conta_receber.ListarPorClienteID"
 
 	query := `
-		SELECT id, obra_id, cronograma_recebimento_id, cliente, tipo_conta_receber,
-			   descricao, valor_original, valor_recebido, data_vencimento, 
+		SELECT id, obra_id, cronograma_recebimento_id, cliente_id, cliente, tipo_conta_receber,
+			   descricao, valor_original, valor_recebido, valor_retido, data_vencimento, 
 			   data_recebimento, status, forma_pagamento, observacoes, 
-			   numero_documento, created_at, updated_at
+			   numero_documento, linha_digitavel, url_boleto, created_at, updated_at
 		FROM contas_receber 
-		WHERE UPPER(cliente) LIKE UPPER($1)
+		WHERE cliente_id = $1
 		ORDER BY data_vencimento ASC
 	`
 
-	rows, err := r.dbpool.Query(ctx, query, "%"+cliente+"%")
+	rows, err := r.dbpool.Query(ctx, query, clienteID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -296,6 +328,13 @@ func (r *ContaReceberRepositoryPostgres) Listar(ctx context.Context, filtros com
 		args = append(args, filtros.ObraID)
 	}
 
+	// Filtros por cliente
+	if filtros.ClienteID != "" {
+		argCount++
+		whereClause += fmt.Sprintf(" AND cr.cliente_id = $%d", argCount)
+		args = append(args, filtros.ClienteID)
+	}
+
 	// Query para contar total
 	countQuery := "SELECT COUNT(*) " + baseQuery + whereClause
 	var total int64
@@ -306,10 +345,10 @@ func (r *ContaReceberRepositoryPostgres) Listar(ctx context.Context, filtros com
 
 	// Query para buscar dados
 	dataQuery := `
-		SELECT id, obra_id, cronograma_recebimento_id, cliente, tipo_conta_receber,
-			   descricao, valor_original, valor_recebido, data_vencimento, 
+		SELECT id, obra_id, cronograma_recebimento_id, cliente_id, cliente, tipo_conta_receber,
+			   descricao, valor_original, valor_recebido, valor_retido, data_vencimento, 
 			   data_recebimento, status, forma_pagamento, observacoes, 
-			   numero_documento, created_at, updated_at
+			   numero_documento, linha_digitavel, url_boleto, created_at, updated_at
 	` + baseQuery + whereClause + `
 		ORDER BY cr.data_vencimento ASC
 		LIMIT $` + fmt.Sprintf("%d", argCount+1) + ` OFFSET $` + fmt.Sprintf("%d", argCount+2)
@@ -384,17 +423,21 @@ func (r *ContaReceberRepositoryPostgres) scanContasReceber(ctx context.Context,
 			&conta.ID,
 			&conta.ObraID,
 			&conta.CronogramaRecebimentoID,
+			&conta.ClienteID,
 			&conta.Cliente,
 			&conta.TipoContaReceber,
 			&conta.Descricao,
 			&conta.ValorOriginal,
 			&conta.ValorRecebido,
+			&conta.ValorRetido,
 			&conta.DataVencimento,
 			&conta.DataRecebimento,
 			&conta.Status,
 			&conta.FormaPagamento,
 			&conta.Observacoes,
 			&conta.NumeroDocumento,
+			&conta.LinhaDigitavel,
+			&conta.URLBoleto,
 			&conta.CreatedAt,
 			&conta.UpdatedAt,
 		)
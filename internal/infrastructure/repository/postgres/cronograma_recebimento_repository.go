@@ -26,8 +26,8 @@ func (r *CronogramaRecebimentoRepositoryPostgres) Salvar(ctx context.Context, db
 		INSERT INTO cronograma_recebimentos (
 			id, obra_id, numero_etapa, descricao_etapa, valor_previsto, 
 			data_vencimento, status, data_recebimento, valor_recebido, 
-			observacoes_recebimento, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			observacoes_recebimento, created_at, updated_at, valor_retido
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
 	_, err := dbtx.Exec(ctx, query,
@@ -43,6 +43,7 @@ func (r *CronogramaRecebimentoRepositoryPostgres) Salvar(ctx context.Context, db
 		cronograma.ObservacoesRecebimento,
 		cronograma.CreatedAt,
 		cronograma.UpdatedAt,
+		cronograma.ValorRetido,
 	)
 
 	if err != nil {
@@ -63,8 +64,8 @@ func (r *CronogramaRecebimentoRepositoryPostgres) SalvarMuitos(ctx context.Conte
 		INSERT INTO cronograma_recebimentos (
 			id, obra_id, numero_etapa, descricao_etapa, valor_previsto, 
 			data_vencimento, status, data_recebimento, valor_recebido, 
-			observacoes_recebimento, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			observacoes_recebimento, created_at, updated_at, valor_retido
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
 	// Execute cada insert individualmente
@@ -82,6 +83,7 @@ func (r *CronogramaRecebimentoRepositoryPostgres) SalvarMuitos(ctx context.Conte
 			cronograma.ObservacoesRecebimento,
 			cronograma.CreatedAt,
 			cronograma.UpdatedAt,
+			cronograma.ValorRetido,
 		)
 		if err != nil {
 			return fmt.Errorf("%s: falha na inserção do cronograma %d: %w", op, i, err)
@@ -136,7 +138,7 @@ func (r *CronogramaRecebimentoRepositoryPostgres) BuscarPorID(ctx context.Contex
 	query := `
 		SELECT id, obra_id, numero_etapa, descricao_etapa, valor_previsto, 
 			   data_vencimento, status, data_recebimento, valor_recebido, 
-			   observacoes_recebimento, created_at, updated_at
+			   observacoes_recebimento, created_at, updated_at, valor_retido
 		FROM cronograma_recebimentos 
 		WHERE id = $1
 	`
@@ -157,6 +159,7 @@ func (r *CronogramaRecebimentoRepositoryPostgres) BuscarPorID(ctx context.Contex
 		&cronograma.ObservacoesRecebimento,
 		&cronograma.CreatedAt,
 		&cronograma.UpdatedAt,
+		&cronograma.ValorRetido,
 	)
 
 	if err != nil {
@@ -175,7 +178,7 @@ func (r *CronogramaRecebimentoRepositoryPostgres) ListarPorObraID(ctx context.Co
 	query := `
 		SELECT id, obra_id, numero_etapa, descricao_etapa, valor_previsto, 
 			   data_vencimento, status, data_recebimento, valor_recebido, 
-			   observacoes_recebimento, created_at, updated_at
+			   observacoes_recebimento, created_at, updated_at, valor_retido
 		FROM cronograma_recebimentos 
 		WHERE obra_id = $1
 		ORDER BY numero_etapa ASC
@@ -203,6 +206,7 @@ func (r *CronogramaRecebimentoRepositoryPostgres) ListarPorObraID(ctx context.Co
 			&cronograma.ObservacoesRecebimento,
 			&cronograma.CreatedAt,
 			&cronograma.UpdatedAt,
+			&cronograma.ValorRetido,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("%s: erro ao escanear cronograma: %w", op, err)
@@ -219,11 +223,11 @@ func (r *CronogramaRecebimentoRepositoryPostgres) ListarVencidosPorPeriodo(ctx c
 	query := `
 		SELECT cr.id, cr.obra_id, cr.numero_etapa, cr.descricao_etapa, cr.valor_previsto, 
 			   cr.data_vencimento, cr.status, cr.data_recebimento, cr.valor_recebido, 
-			   cr.observacoes_recebimento, cr.created_at, cr.updated_at
+			   cr.observacoes_recebimento, cr.created_at, cr.updated_at, cr.valor_retido
 		FROM cronograma_recebimentos cr
 		WHERE cr.data_vencimento BETWEEN $1 AND $2
 		  AND cr.status IN ('PENDENTE', 'VENCIDO', 'PARCIAL')
-		  AND cr.valor_recebido < cr.valor_previsto
+		  AND cr.valor_recebido < cr.valor_previsto - cr.valor_retido
 		ORDER BY cr.data_vencimento ASC
 	`
 
@@ -249,6 +253,7 @@ func (r *CronogramaRecebimentoRepositoryPostgres) ListarVencidosPorPeriodo(ctx c
 			&cronograma.ObservacoesRecebimento,
 			&cronograma.CreatedAt,
 			&cronograma.UpdatedAt,
+			&cronograma.ValorRetido,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("%s: erro ao escanear cronograma vencido: %w", op, err)
@@ -259,12 +264,12 @@ func (r *CronogramaRecebimentoRepositoryPostgres) ListarVencidosPorPeriodo(ctx c
 	return cronogramas, nil
 }
 
-func (r *CronogramaRecebimentoRepositoryPostgres) Deletar(ctx context.Context, id string) error {
+func (r *CronogramaRecebimentoRepositoryPostgres) Deletar(ctx context.Context, dbtx db.DBTX, id string) error {
 	const op = "repository.postgres.cronograma_recebimento.Deletar"
 
 	query := `DELETE FROM cronograma_recebimentos WHERE id = $1`
 
-	result, err := r.dbpool.Exec(ctx, query, id)
+	result, err := dbtx.Exec(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
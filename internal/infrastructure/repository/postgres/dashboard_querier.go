@@ -241,11 +241,7 @@ func (q *DashboardQuerierPostgres) ObterProgressoObras(ctx context.Context) (*dt
 				o.data_fim as data_fim_prevista,
 				COUNT(e.id) as etapas_total,
 				COUNT(CASE WHEN e.status = 'Concluída' THEN 1 END) as etapas_concluidas,
-				CASE 
-					WHEN COUNT(e.id) > 0 THEN 
-						(COUNT(CASE WHEN e.status = 'Concluída' THEN 1 END)::float / COUNT(e.id)::float) * 100
-					ELSE 0 
-				END as percentual_concluido
+				COALESCE(SUM(e.peso * e.percentual_executado) / NULLIF(SUM(e.peso), 0), 0)::float as percentual_concluido
 			FROM obras o
 			LEFT JOIN etapas e ON o.id = e.obra_id
 			WHERE o.deleted_at IS NULL
@@ -1062,80 +1058,3 @@ func (q *DashboardQuerierPostgres) ObterResumoGeral(ctx context.Context) (*dto.R
 
 	return &resumo, nil
 }
-
-// ObterAlertas implementa dashboard.Querier
-func (q *DashboardQuerierPostgres) ObterAlertas(ctx context.Context) (*dto.AlertasDTO, error) {
-	const op = "repository.postgres.dashboard.ObterAlertas"
-
-	alertas := &dto.AlertasDTO{}
-
-	// Obras com atraso
-	queryObrasAtraso := `
-		SELECT nome 
-		FROM obras 
-		WHERE status = 'Em Andamento' 
-			AND data_fim < CURRENT_DATE 
-			AND deleted_at IS NULL
-		ORDER BY data_fim
-		LIMIT 10`
-
-	rows, err := q.db.Query(ctx, queryObrasAtraso)
-	if err == nil {
-		obrasAtraso, _ := pgx.CollectRows(rows, pgx.RowTo[string])
-		alertas.ObrasComAtraso = obrasAtraso
-		rows.Close()
-	}
-
-	// Fornecedores inativos recentemente
-	queryFornecedoresInativos := `
-		SELECT nome 
-		FROM fornecedores 
-		WHERE status = 'Inativo' 
-			AND updated_at >= CURRENT_DATE - INTERVAL '30 days'
-			AND deleted_at IS NULL
-		LIMIT 10`
-
-	rows, err = q.db.Query(ctx, queryFornecedoresInativos)
-	if err == nil {
-		fornecedoresInativos, _ := pgx.CollectRows(rows, pgx.RowTo[string])
-		alertas.FornecedoresInativos = fornecedoresInativos
-		rows.Close()
-	}
-
-	// Funcionários sem apontamento recente
-	queryFuncionariosSemApontamento := `
-		SELECT f.nome 
-		FROM funcionarios f
-		LEFT JOIN apontamentos_quinzenais aq ON f.id = aq.funcionario_id 
-			AND aq.created_at >= CURRENT_DATE - INTERVAL '30 days'
-		WHERE f.status = 'Ativo' 
-			AND aq.id IS NULL
-		LIMIT 10`
-
-	rows, err = q.db.Query(ctx, queryFuncionariosSemApontamento)
-	if err == nil {
-		funcionariosSemApontamento, _ := pgx.CollectRows(rows, pgx.RowTo[string])
-		alertas.FuncionariosSemApontamento = funcionariosSemApontamento
-		rows.Close()
-	}
-
-	// Orçamentos pendentes
-	err = q.db.QueryRow(ctx, `
-		SELECT COUNT(*) 
-		FROM orcamentos 
-		WHERE status = 'Em Aberto' 
-			AND deleted_at IS NULL`).Scan(&alertas.OrcamentosPendentes)
-
-	// Pagamentos pendentes (apontamentos aprovados mas não pagos)
-	err = q.db.QueryRow(ctx, `
-		SELECT COUNT(*) 
-		FROM apontamentos_quinzenais 
-		WHERE status = 'Aprovado' 
-			AND id NOT IN (
-				SELECT DISTINCT apontamento_id 
-				FROM registros_pagamento 
-				WHERE apontamento_id IS NOT NULL
-			)`).Scan(&alertas.PagamentosPendentes)
-
-	return alertas, nil
-}
\ No newline at end of file
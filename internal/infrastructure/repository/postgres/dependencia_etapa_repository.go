@@ -0,0 +1,99 @@
+// file: internal/infrastructure/repository/postgres/dependencia_etapa_repository.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
+)
+
+// DependenciaEtapaRepositoryPostgres persiste as dependências entre etapas de uma obra.
+type DependenciaEtapaRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoDependenciaEtapaRepository(db *pgxpool.Pool, logger *slog.Logger) *DependenciaEtapaRepositoryPostgres {
+	return &DependenciaEtapaRepositoryPostgres{db: db, logger: logger}
+}
+
+const colunasDependenciaEtapa = `id, obra_id, etapa_id, predecessora_id, tipo, lag_dias, created_at`
+
+func (r *DependenciaEtapaRepositoryPostgres) Salvar(ctx context.Context, d *obras.DependenciaEtapa) error {
+	const op = "repository.postgres.dependencia_etapa.Salvar"
+	query := `
+		INSERT INTO etapa_dependencias (` + colunasDependenciaEtapa + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.Exec(ctx, query, d.ID, d.ObraID, d.EtapaID, d.PredecessoraID, d.Tipo, d.LagDias, d.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// SalvarMuitos grava as dependências dentro da transação informada (ex.: criação da obra a partir de um modelo).
+func (r *DependenciaEtapaRepositoryPostgres) SalvarMuitos(ctx context.Context, dbtx db.DBTX, dependencias []*obras.DependenciaEtapa) error {
+	const op = "repository.postgres.dependencia_etapa.SalvarMuitos"
+	query := `
+		INSERT INTO etapa_dependencias (` + colunasDependenciaEtapa + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	for _, d := range dependencias {
+		if _, err := dbtx.Exec(ctx, query, d.ID, d.ObraID, d.EtapaID, d.PredecessoraID, d.Tipo, d.LagDias, d.CreatedAt); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return nil
+}
+
+func (r *DependenciaEtapaRepositoryPostgres) BuscarPorID(ctx context.Context, id string) (*obras.DependenciaEtapa, error) {
+	const op = "repository.postgres.dependencia_etapa.BuscarPorID"
+	query := `SELECT ` + colunasDependenciaEtapa + ` FROM etapa_dependencias WHERE id = $1`
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	d, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[obras.DependenciaEtapa])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return d, nil
+}
+
+func (r *DependenciaEtapaRepositoryPostgres) ListarPorObraID(ctx context.Context, obraID string) ([]*obras.DependenciaEtapa, error) {
+	const op = "repository.postgres.dependencia_etapa.ListarPorObraID"
+	query := `SELECT ` + colunasDependenciaEtapa + ` FROM etapa_dependencias WHERE obra_id = $1 ORDER BY created_at`
+
+	rows, err := r.db.Query(ctx, query, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	dependencias, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[obras.DependenciaEtapa])
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao escanear dependências: %w", op, err)
+	}
+	return dependencias, nil
+}
+
+func (r *DependenciaEtapaRepositoryPostgres) Deletar(ctx context.Context, id string) error {
+	const op = "repository.postgres.dependencia_etapa.Deletar"
+	cmd, err := r.db.Exec(ctx, `DELETE FROM etapa_dependencias WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrNaoEncontrado
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// file: internal/infrastructure/repository/postgres/diario_obra_repository.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+)
+
+// DiarioObraRepositoryPostgres persiste os diários de obra. Os registros do dia
+// (clima, efetivo, equipamentos, serviços, ocorrências e fotos) ficam em colunas JSONB.
+type DiarioObraRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoDiarioObraRepository(db *pgxpool.Pool, logger *slog.Logger) *DiarioObraRepositoryPostgres {
+	return &DiarioObraRepositoryPostgres{db: db, logger: logger}
+}
+
+const colunasDiarioObra = `id, obra_id, data, clima, efetivo, efetivo_terceirizado, equipamentos, servicos,
+	ocorrencias, fotos, observacoes, status, criado_por, assinado_por, assinado_em, created_at, updated_at`
+
+func (r *DiarioObraRepositoryPostgres) Salvar(ctx context.Context, d *obras.DiarioObra) error {
+	const op = "repository.postgres.diario_obra.Salvar"
+	query := `
+		INSERT INTO diarios_obra (` + colunasDiarioObra + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	`
+	_, err := r.db.Exec(ctx, query,
+		d.ID, d.ObraID, d.Data, d.Clima, d.Efetivo, d.EfetivoTerceirizado, d.Equipamentos, d.Servicos,
+		d.Ocorrencias, d.Fotos, d.Observacoes, d.Status, d.CriadoPor, d.AssinadoPor, d.AssinadoEm, d.CreatedAt, d.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Atualizar grava o conteúdo e a assinatura. Diários já assinados no banco não são
+// alterados, mesmo que a entidade em memória tenha sido modificada.
+func (r *DiarioObraRepositoryPostgres) Atualizar(ctx context.Context, d *obras.DiarioObra) error {
+	const op = "repository.postgres.diario_obra.Atualizar"
+	query := `
+		UPDATE diarios_obra
+		SET clima = $2, efetivo = $3, efetivo_terceirizado = $4, equipamentos = $5, servicos = $6,
+			ocorrencias = $7, fotos = $8, observacoes = $9, status = $10, assinado_por = $11,
+			assinado_em = $12, updated_at = $13
+		WHERE id = $1 AND status <> 'ASSINADO'
+	`
+	cmd, err := r.db.Exec(ctx, query,
+		d.ID, d.Clima, d.Efetivo, d.EfetivoTerceirizado, d.Equipamentos, d.Servicos,
+		d.Ocorrencias, d.Fotos, d.Observacoes, d.Status, d.AssinadoPor, d.AssinadoEm, d.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, obras.ErrDiarioAssinado)
+	}
+	return nil
+}
+
+func (r *DiarioObraRepositoryPostgres) BuscarPorID(ctx context.Context, id string) (*obras.DiarioObra, error) {
+	const op = "repository.postgres.diario_obra.BuscarPorID"
+	query := `SELECT ` + colunasDiarioObra + ` FROM diarios_obra WHERE id = $1`
+
+	d, err := scanDiarioObra(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return d, nil
+}
+
+func (r *DiarioObraRepositoryPostgres) ExisteNaData(ctx context.Context, obraID string, data time.Time) (bool, error) {
+	const op = "repository.postgres.diario_obra.ExisteNaData"
+	var existe bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM diarios_obra WHERE obra_id = $1 AND data = $2)`, obraID, data).Scan(&existe)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	return existe, nil
+}
+
+// ListarPorPeriodo lista os diários da obra em ordem de data; limites nulos não filtram.
+func (r *DiarioObraRepositoryPostgres) ListarPorPeriodo(ctx context.Context, obraID string, inicio, fim *time.Time) ([]*obras.DiarioObra, error) {
+	const op = "repository.postgres.diario_obra.ListarPorPeriodo"
+	query := `
+		SELECT ` + colunasDiarioObra + `
+		FROM diarios_obra
+		WHERE obra_id = $1
+		  AND ($2::date IS NULL OR data >= $2)
+		  AND ($3::date IS NULL OR data <= $3)
+		ORDER BY data
+	`
+	rows, err := r.db.Query(ctx, query, obraID, inicio, fim)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	diarios := make([]*obras.DiarioObra, 0)
+	for rows.Next() {
+		d, err := scanDiarioObra(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: falha ao escanear diário: %w", op, err)
+		}
+		diarios = append(diarios, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return diarios, nil
+}
+
+func scanDiarioObra(row pgx.Row) (*obras.DiarioObra, error) {
+	var d obras.DiarioObra
+	err := row.Scan(
+		&d.ID, &d.ObraID, &d.Data, &d.Clima, &d.Efetivo, &d.EfetivoTerceirizado, &d.Equipamentos, &d.Servicos,
+		&d.Ocorrencias, &d.Fotos, &d.Observacoes, &d.Status, &d.CriadoPor, &d.AssinadoPor, &d.AssinadoEm, &d.CreatedAt, &d.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,156 @@
+// file: internal/infrastructure/repository/postgres/efetivo_planejado_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+)
+
+// EfetivoPlanejadoRepositoryPostgres persiste o efetivo planejado das etapas e
+// monta os dados do calendário de capacidade.
+type EfetivoPlanejadoRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoEfetivoPlanejadoRepository(db *pgxpool.Pool, logger *slog.Logger) *EfetivoPlanejadoRepositoryPostgres {
+	return &EfetivoPlanejadoRepositoryPostgres{db: db, logger: logger}
+}
+
+// Salvar substitui o efetivo planejado de todas as etapas da obra.
+func (r *EfetivoPlanejadoRepositoryPostgres) Salvar(ctx context.Context, obraID string, itens []*obras.EfetivoPlanejado) error {
+	const op = "repository.postgres.efetivo_planejado.Salvar"
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: falha ao iniciar transação: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM obra_efetivo_planejado WHERE obra_id = $1`, obraID); err != nil {
+		return fmt.Errorf("%s: falha ao remover efetivo anterior: %w", op, err)
+	}
+
+	linhas := make([][]any, 0, len(itens))
+	for _, item := range itens {
+		linhas = append(linhas, []any{item.ID, obraID, item.EtapaID, item.Cargo, item.Quantidade})
+	}
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"obra_efetivo_planejado"},
+		[]string{"id", "obra_id", "etapa_id", "cargo", "quantidade"},
+		pgx.CopyFromRows(linhas),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: falha ao inserir efetivo: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: falha ao comitar transação: %w", op, err)
+	}
+	return nil
+}
+
+func (r *EfetivoPlanejadoRepositoryPostgres) ListarPorObraID(ctx context.Context, obraID string) ([]*obras.EfetivoPlanejado, error) {
+	const op = "repository.postgres.efetivo_planejado.ListarPorObraID"
+
+	rows, err := r.db.Query(ctx, `
+		SELECT ep.id, ep.obra_id, ep.etapa_id, ep.cargo, ep.quantidade
+		FROM obra_efetivo_planejado ep
+		JOIN etapas e ON e.id = ep.etapa_id
+		WHERE ep.obra_id = $1
+		ORDER BY e.data_inicio_prevista NULLS LAST, e.nome, ep.cargo
+	`, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	itens := make([]*obras.EfetivoPlanejado, 0)
+	for rows.Next() {
+		var item obras.EfetivoPlanejado
+		if err := rows.Scan(&item.ID, &item.ObraID, &item.EtapaID, &item.Cargo, &item.Quantidade); err != nil {
+			return nil, fmt.Errorf("%s: falha ao escanear efetivo: %w", op, err)
+		}
+		itens = append(itens, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return itens, nil
+}
+
+// ListarDemandasNoPeriodo traz o efetivo das etapas ainda não concluídas cujo período
+// programado (ou, sem reprogramação, o previsto) cruza o intervalo, apenas de obras
+// em planejamento ou em andamento. Etapas sem datas previstas ficam de fora.
+func (r *EfetivoPlanejadoRepositoryPostgres) ListarDemandasNoPeriodo(ctx context.Context, inicio, fim time.Time) ([]obras.DemandaEfetivo, error) {
+	const op = "repository.postgres.efetivo_planejado.ListarDemandasNoPeriodo"
+
+	rows, err := r.db.Query(ctx, `
+		SELECT obra_id, etapa_id, cargo, quantidade, inicio, fim
+		FROM (
+			SELECT ep.obra_id, ep.etapa_id, ep.cargo, ep.quantidade,
+				COALESCE(e.data_inicio_programada, e.data_inicio_prevista) AS inicio,
+				COALESCE(e.data_fim_programada, e.data_fim_prevista) AS fim
+			FROM obra_efetivo_planejado ep
+			JOIN etapas e ON e.id = ep.etapa_id
+			JOIN obras o ON o.id = ep.obra_id
+			WHERE o.deleted_at IS NULL
+			  AND o.status IN ($3, $4)
+			  AND e.status <> $5
+		) demandas
+		WHERE inicio IS NOT NULL AND fim IS NOT NULL
+		  AND inicio <= $2 AND fim >= $1
+	`, inicio, fim, obras.StatusEmPlanejamento, obras.StatusEmAndamento, obras.StatusEtapaConcluida)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	demandas := make([]obras.DemandaEfetivo, 0)
+	for rows.Next() {
+		var d obras.DemandaEfetivo
+		if err := rows.Scan(&d.ObraID, &d.EtapaID, &d.Cargo, &d.Quantidade, &d.Inicio, &d.Fim); err != nil {
+			return nil, fmt.Errorf("%s: falha ao escanear demanda: %w", op, err)
+		}
+		demandas = append(demandas, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return demandas, nil
+}
+
+// ListarFuncionariosDisponiveis traz os funcionários ativos e não desligados, com o cargo.
+func (r *EfetivoPlanejadoRepositoryPostgres) ListarFuncionariosDisponiveis(ctx context.Context) ([]obras.FuncionarioCapacidade, error) {
+	const op = "repository.postgres.efetivo_planejado.ListarFuncionariosDisponiveis"
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, nome, cargo
+		FROM funcionarios
+		WHERE desligamento_data IS NULL AND status = 'Ativo'
+		ORDER BY nome
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	funcionarios := make([]obras.FuncionarioCapacidade, 0)
+	for rows.Next() {
+		var f obras.FuncionarioCapacidade
+		if err := rows.Scan(&f.ID, &f.Nome, &f.Cargo); err != nil {
+			return nil, fmt.Errorf("%s: falha ao escanear funcionário: %w", op, err)
+		}
+		funcionarios = append(funcionarios, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return funcionarios, nil
+}
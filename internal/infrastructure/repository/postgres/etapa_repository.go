@@ -29,12 +29,16 @@ func NovoEtapaRepository(db *pgxpool.Pool, logger *slog.Logger) obras.EtapaRepos
 func (r *EtapaRepositoryPostgres) Salvar(ctx context.Context, dbtx db.DBTX, etapa *obras.Etapa) error {
 	const op = "repository.postgres.etapa.Salvar"
 	query := `
-		INSERT INTO etapas (id, obra_id, nome, data_inicio_prevista, data_fim_prevista, status)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO etapas (id, obra_id, nome, data_inicio_prevista, data_fim_prevista, status,
+			data_inicio_real, data_fim_real, percentual_executado, peso, etapa_padrao_id,
+			data_inicio_programada, data_fim_programada)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 	_, err := dbtx.Exec(ctx, query,
 		etapa.ID, etapa.ObraID, etapa.Nome,
 		etapa.DataInicioPrevista, etapa.DataFimPrevista, etapa.Status,
+		etapa.DataInicioReal, etapa.DataFimReal, etapa.PercentualExecutado, etapa.Peso, etapa.EtapaPadraoID,
+		etapa.DataInicioProgramada, etapa.DataFimProgramada,
 	)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
@@ -44,7 +48,12 @@ func (r *EtapaRepositoryPostgres) Salvar(ctx context.Context, dbtx db.DBTX, etap
 
 func (r *EtapaRepositoryPostgres) BuscarPorID(ctx context.Context, etapaID string) (*obras.Etapa, error) {
 	const op = "repository.postgres.etapa.BuscarPorID"
-	query := `SELECT id, obra_id, nome, data_inicio_prevista, data_fim_prevista, status FROM etapas WHERE id = $1`
+	query := `
+		SELECT id, obra_id, nome, data_inicio_prevista, data_fim_prevista, status,
+			data_inicio_real, data_fim_real, percentual_executado, peso, etapa_padrao_id,
+			data_inicio_programada, data_fim_programada
+		FROM etapas WHERE id = $1
+	`
 	row := r.db.QueryRow(ctx, query, etapaID)
 
 	var etapa obras.Etapa
@@ -55,6 +64,13 @@ func (r *EtapaRepositoryPostgres) BuscarPorID(ctx context.Context, etapaID strin
 		&etapa.DataInicioPrevista,
 		&etapa.DataFimPrevista,
 		&etapa.Status,
+		&etapa.DataInicioReal,
+		&etapa.DataFimReal,
+		&etapa.PercentualExecutado,
+		&etapa.Peso,
+		&etapa.EtapaPadraoID,
+		&etapa.DataInicioProgramada,
+		&etapa.DataFimProgramada,
 	)
 
 	if err != nil {
@@ -69,14 +85,25 @@ func (r *EtapaRepositoryPostgres) BuscarPorID(ctx context.Context, etapaID strin
 
 func (r *EtapaRepositoryPostgres) Atualizar(ctx context.Context, etapa *obras.Etapa) error {
 	const op = "repository.postgres.etapa.Atualizar"
-	query := `UPDATE etapas SET nome = $1, data_inicio_prevista = $2, data_fim_prevista = $3, status = $4 WHERE id = $5`
+	query := `
+		UPDATE etapas SET nome = $1, data_inicio_prevista = $2, data_fim_prevista = $3, status = $4,
+			data_inicio_real = $5, data_fim_real = $6, percentual_executado = $7, peso = $8,
+			data_inicio_programada = $10, data_fim_programada = $11, updated_at = NOW()
+		WHERE id = $9
+	`
 
 	cmd, err := r.db.Exec(ctx, query,
 		etapa.Nome,
 		etapa.DataInicioPrevista,
 		etapa.DataFimPrevista,
 		etapa.Status,
+		etapa.DataInicioReal,
+		etapa.DataFimReal,
+		etapa.PercentualExecutado,
+		etapa.Peso,
 		etapa.ID,
+		etapa.DataInicioProgramada,
+		etapa.DataFimProgramada,
 	)
 
 	if err != nil {
@@ -93,7 +120,9 @@ func (r *EtapaRepositoryPostgres) Atualizar(ctx context.Context, etapa *obras.Et
 func (r *EtapaRepositoryPostgres) ListarPorObraID(ctx context.Context, obraID string) ([]*obras.Etapa, error) {
 	const op = "repository.postgres.etapa.ListarPorObraID"
 	query := `
-		SELECT id, obra_id, nome, data_inicio_prevista, data_fim_prevista, status
+		SELECT id, obra_id, nome, data_inicio_prevista, data_fim_prevista, status,
+			data_inicio_real, data_fim_real, percentual_executado, peso, etapa_padrao_id,
+			data_inicio_programada, data_fim_programada
 		FROM etapas
 		WHERE obra_id = $1
 		ORDER BY data_inicio_prevista, nome ASC
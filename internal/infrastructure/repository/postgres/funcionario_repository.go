@@ -13,6 +13,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/luiszkm/masterCostrutora/internal/domain/common"
 	"github.com/luiszkm/masterCostrutora/internal/domain/pessoal"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
 	pessoal_dto "github.com/luiszkm/masterCostrutora/internal/service/pessoal/dto"
 )
 
@@ -104,13 +105,13 @@ func (r *FuncionarioRepositoryPostgres) Salvar(ctx context.Context, f *pessoal.F
 	}
 	return nil
 }
-func (r *FuncionarioRepositoryPostgres) Deletar(ctx context.Context, id string) error {
+func (r *FuncionarioRepositoryPostgres) Deletar(ctx context.Context, dbtx db.DBTX, id string) error {
 	const op = "repository.postgres.funcionario.Deletar"
 	query := `UPDATE funcionarios SET
 	 desligamento_data = NOW(),
 	status = 'Inativo', updated_at = NOW()
 	 WHERE id = $1 `
-	cmd, err := r.db.Exec(ctx, query, id)
+	cmd, err := dbtx.Exec(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -119,7 +120,7 @@ func (r *FuncionarioRepositoryPostgres) Deletar(ctx context.Context, id string)
 	}
 	return nil
 }
-func (r *FuncionarioRepositoryPostgres) Atualizar(ctx context.Context, f *pessoal.Funcionario) error {
+func (r *FuncionarioRepositoryPostgres) Atualizar(ctx context.Context, dbtx db.DBTX, f *pessoal.Funcionario) error {
 	const op = "repository.postgres.funcionario.Atualizar"
 	query := `
 		UPDATE funcionarios
@@ -131,7 +132,7 @@ func (r *FuncionarioRepositoryPostgres) Atualizar(ctx context.Context, f *pessoa
 			updated_at = NOW()
 		WHERE id = $13 
 	`
-	cmd, err := r.db.Exec(ctx, query,
+	cmd, err := dbtx.Exec(ctx, query,
 		f.Nome, f.CPF, f.Telefone, f.Cargo, f.Departamento,
 		f.ValorDiaria, f.ChavePix, f.Status, f.AvaliacaoDesempenho,
 		f.MotivoDesligamento, f.Observacoes,
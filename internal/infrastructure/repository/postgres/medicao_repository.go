@@ -0,0 +1,132 @@
+// file: internal/infrastructure/repository/postgres/medicao_repository.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
+)
+
+// MedicaoRepositoryPostgres persiste as medições das obras. Os itens medidos
+// ficam em uma coluna JSONB.
+type MedicaoRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoMedicaoRepository(db *pgxpool.Pool, logger *slog.Logger) *MedicaoRepositoryPostgres {
+	return &MedicaoRepositoryPostgres{db: db, logger: logger}
+}
+
+const colunasMedicao = `id, obra_id, numero, periodo_inicio, periodo_fim, itens, valor_total, observacoes, status,
+	criado_por, enviada_em, responsavel_cliente, analisada_em, motivo_rejeicao, cronograma_recebimento_id, created_at, updated_at`
+
+func (r *MedicaoRepositoryPostgres) Salvar(ctx context.Context, m *obras.Medicao) error {
+	const op = "repository.postgres.medicao.Salvar"
+	query := `
+		INSERT INTO medicoes (` + colunasMedicao + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	`
+	_, err := r.db.Exec(ctx, query,
+		m.ID, m.ObraID, m.Numero, m.PeriodoInicio, m.PeriodoFim, m.Itens, m.ValorTotal, m.Observacoes, m.Status,
+		m.CriadoPor, m.EnviadaEm, m.ResponsavelCliente, m.AnalisadaEm, m.MotivoRejeicao, m.CronogramaRecebimentoID, m.CreatedAt, m.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Atualizar grava a medição. Medições já aprovadas no banco não são alteradas; a
+// aprovação roda na mesma transação que cria a parcela do cronograma de recebimento.
+func (r *MedicaoRepositoryPostgres) Atualizar(ctx context.Context, dbtx db.DBTX, m *obras.Medicao) error {
+	const op = "repository.postgres.medicao.Atualizar"
+	query := `
+		UPDATE medicoes
+		SET periodo_inicio = $2, periodo_fim = $3, itens = $4, valor_total = $5, observacoes = $6, status = $7,
+			enviada_em = $8, responsavel_cliente = $9, analisada_em = $10, motivo_rejeicao = $11,
+			cronograma_recebimento_id = $12, updated_at = $13
+		WHERE id = $1 AND status <> 'APROVADA'
+	`
+	cmd, err := dbtx.Exec(ctx, query,
+		m.ID, m.PeriodoInicio, m.PeriodoFim, m.Itens, m.ValorTotal, m.Observacoes, m.Status,
+		m.EnviadaEm, m.ResponsavelCliente, m.AnalisadaEm, m.MotivoRejeicao, m.CronogramaRecebimentoID, m.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, obras.ErrMedicaoAprovada)
+	}
+	return nil
+}
+
+func (r *MedicaoRepositoryPostgres) BuscarPorID(ctx context.Context, id string) (*obras.Medicao, error) {
+	const op = "repository.postgres.medicao.BuscarPorID"
+	query := `SELECT ` + colunasMedicao + ` FROM medicoes WHERE id = $1`
+
+	m, err := scanMedicao(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return m, nil
+}
+
+// ListarPorObraID lista as medições da obra em ordem de número.
+func (r *MedicaoRepositoryPostgres) ListarPorObraID(ctx context.Context, obraID string) ([]*obras.Medicao, error) {
+	const op = "repository.postgres.medicao.ListarPorObraID"
+	query := `SELECT ` + colunasMedicao + ` FROM medicoes WHERE obra_id = $1 ORDER BY numero`
+
+	rows, err := r.db.Query(ctx, query, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	medicoes := make([]*obras.Medicao, 0)
+	for rows.Next() {
+		m, err := scanMedicao(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: falha ao escanear medição: %w", op, err)
+		}
+		medicoes = append(medicoes, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return medicoes, nil
+}
+
+// Deletar remove uma medição ainda não aprovada.
+func (r *MedicaoRepositoryPostgres) Deletar(ctx context.Context, id string) error {
+	const op = "repository.postgres.medicao.Deletar"
+	cmd, err := r.db.Exec(ctx, `DELETE FROM medicoes WHERE id = $1 AND status <> 'APROVADA'`, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, obras.ErrMedicaoAprovada)
+	}
+	return nil
+}
+
+func scanMedicao(row pgx.Row) (*obras.Medicao, error) {
+	var m obras.Medicao
+	err := row.Scan(
+		&m.ID, &m.ObraID, &m.Numero, &m.PeriodoInicio, &m.PeriodoFim, &m.Itens, &m.ValorTotal, &m.Observacoes, &m.Status,
+		&m.CriadoPor, &m.EnviadaEm, &m.ResponsavelCliente, &m.AnalisadaEm, &m.MotivoRejeicao, &m.CronogramaRecebimentoID, &m.CreatedAt, &m.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -0,0 +1,131 @@
+// file: internal/infrastructure/repository/postgres/modelo_obra_repository.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+)
+
+// ModeloObraRepositoryPostgres persiste os modelos de obra. As etapas e as linhas
+// de orçamento do modelo ficam em colunas JSONB.
+type ModeloObraRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoModeloObraRepository(db *pgxpool.Pool, logger *slog.Logger) *ModeloObraRepositoryPostgres {
+	return &ModeloObraRepositoryPostgres{db: db, logger: logger}
+}
+
+const colunasModeloObra = `id, nome, COALESCE(descricao, ''), percentual_entrada::float, prazo_dias_etapa, etapas, orcamento, created_at, updated_at`
+
+func (r *ModeloObraRepositoryPostgres) Salvar(ctx context.Context, m *obras.ModeloObra) error {
+	const op = "repository.postgres.modelo_obra.Salvar"
+	query := `
+		INSERT INTO modelos_obra (id, nome, descricao, percentual_entrada, prazo_dias_etapa, etapas, orcamento, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.Exec(ctx, query, m.ID, m.Nome, m.Descricao, m.PercentualEntrada, m.PrazoDiasEtapa, m.Etapas, m.Orcamento, m.CreatedAt, m.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *ModeloObraRepositoryPostgres) Atualizar(ctx context.Context, m *obras.ModeloObra) error {
+	const op = "repository.postgres.modelo_obra.Atualizar"
+	query := `
+		UPDATE modelos_obra
+		SET nome = $2, descricao = NULLIF($3, ''), percentual_entrada = $4, prazo_dias_etapa = $5,
+			etapas = $6, orcamento = $7, updated_at = $8
+		WHERE id = $1
+	`
+	cmd, err := r.db.Exec(ctx, query, m.ID, m.Nome, m.Descricao, m.PercentualEntrada, m.PrazoDiasEtapa, m.Etapas, m.Orcamento, m.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrNaoEncontrado
+	}
+	return nil
+}
+
+func (r *ModeloObraRepositoryPostgres) BuscarPorID(ctx context.Context, id string) (*obras.ModeloObra, error) {
+	const op = "repository.postgres.modelo_obra.BuscarPorID"
+	query := `SELECT ` + colunasModeloObra + ` FROM modelos_obra WHERE id = $1`
+
+	m, err := scanModeloObra(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return m, nil
+}
+
+// BuscarPorNome localiza o modelo pelo nome, sem diferenciar maiúsculas.
+func (r *ModeloObraRepositoryPostgres) BuscarPorNome(ctx context.Context, nome string) (*obras.ModeloObra, error) {
+	const op = "repository.postgres.modelo_obra.BuscarPorNome"
+	query := `SELECT ` + colunasModeloObra + ` FROM modelos_obra WHERE LOWER(nome) = LOWER($1)`
+
+	m, err := scanModeloObra(r.db.QueryRow(ctx, query, nome))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return m, nil
+}
+
+func (r *ModeloObraRepositoryPostgres) ListarTodos(ctx context.Context) ([]*obras.ModeloObra, error) {
+	const op = "repository.postgres.modelo_obra.ListarTodos"
+	query := `SELECT ` + colunasModeloObra + ` FROM modelos_obra ORDER BY nome`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	modelos := make([]*obras.ModeloObra, 0)
+	for rows.Next() {
+		m, err := scanModeloObra(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: falha ao escanear modelo: %w", op, err)
+		}
+		modelos = append(modelos, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return modelos, nil
+}
+
+func (r *ModeloObraRepositoryPostgres) Deletar(ctx context.Context, id string) error {
+	const op = "repository.postgres.modelo_obra.Deletar"
+	cmd, err := r.db.Exec(ctx, `DELETE FROM modelos_obra WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrNaoEncontrado
+	}
+	return nil
+}
+
+func scanModeloObra(row pgx.Row) (*obras.ModeloObra, error) {
+	var m obras.ModeloObra
+	err := row.Scan(&m.ID, &m.Nome, &m.Descricao, &m.PercentualEntrada, &m.PrazoDiasEtapa, &m.Etapas, &m.Orcamento, &m.CreatedAt, &m.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
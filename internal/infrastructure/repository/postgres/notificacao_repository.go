@@ -0,0 +1,214 @@
+// file: internal/infrastructure/repository/postgres/notificacao_repository.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/common"
+	"github.com/luiszkm/masterCostrutora/internal/domain/notificacoes"
+)
+
+// NotificacaoRepositoryPostgres persiste as notificações, as preferências de cada
+// usuário e localiza os destinatários pelas permissões.
+type NotificacaoRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoNotificacaoRepository(db *pgxpool.Pool, logger *slog.Logger) *NotificacaoRepositoryPostgres {
+	return &NotificacaoRepositoryPostgres{db: db, logger: logger}
+}
+
+const colunasNotificacao = `id, usuario_id, evento, titulo, mensagem, referencia_id, obra_id, lida, lida_em, entregas, created_at`
+
+func (r *NotificacaoRepositoryPostgres) Salvar(ctx context.Context, n *notificacoes.Notificacao) error {
+	const op = "repository.postgres.notificacao.Salvar"
+	query := `
+		INSERT INTO notificacoes (` + colunasNotificacao + `, proxima_entrega)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err := r.db.Exec(ctx, query,
+		n.ID, n.UsuarioID, n.Evento, n.Titulo, n.Mensagem, n.ReferenciaID, n.ObraID, n.Lida, n.LidaEm, n.Entregas, n.CreatedAt,
+		n.ProximaEntrega(),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *NotificacaoRepositoryPostgres) AtualizarEntregas(ctx context.Context, n *notificacoes.Notificacao) error {
+	const op = "repository.postgres.notificacao.AtualizarEntregas"
+	cmd, err := r.db.Exec(ctx, `UPDATE notificacoes SET entregas = $2, proxima_entrega = $3 WHERE id = $1`,
+		n.ID, n.Entregas, n.ProximaEntrega())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrNaoEncontrado
+	}
+	return nil
+}
+
+// ListarPorUsuario retorna as notificações do usuário, das mais recentes para as mais antigas.
+func (r *NotificacaoRepositoryPostgres) ListarPorUsuario(ctx context.Context, usuarioID string, filtros common.ListarFiltros) ([]*notificacoes.Notificacao, *common.PaginacaoInfo, error) {
+	const op = "repository.postgres.notificacao.ListarPorUsuario"
+
+	args := []interface{}{usuarioID}
+	where := " WHERE usuario_id = $1"
+	switch filtros.Status {
+	case "LIDA":
+		where += " AND lida"
+	case "NAO_LIDA":
+		where += " AND NOT lida"
+	}
+	if filtros.ObraID != "" {
+		args = append(args, filtros.ObraID)
+		where += fmt.Sprintf(" AND obra_id = $%d", len(args))
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM notificacoes"+where, args...).Scan(&total); err != nil {
+		return nil, nil, fmt.Errorf("%s: falha ao contar notificações: %w", op, err)
+	}
+
+	pagina := filtros.Pagina
+	if pagina < 1 {
+		pagina = 1
+	}
+	tamanho := filtros.TamanhoPagina
+	if tamanho < 1 {
+		tamanho = 20
+	}
+	args = append(args, tamanho, (pagina-1)*tamanho)
+	query := `SELECT ` + colunasNotificacao + ` FROM notificacoes` + where +
+		fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	lista, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByPos[notificacoes.Notificacao])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return lista, common.NewPaginacaoInfo(total, pagina, tamanho), nil
+}
+
+func (r *NotificacaoRepositoryPostgres) ContarNaoLidas(ctx context.Context, usuarioID string) (int, error) {
+	const op = "repository.postgres.notificacao.ContarNaoLidas"
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM notificacoes WHERE usuario_id = $1 AND NOT lida`, usuarioID).Scan(&total); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return total, nil
+}
+
+// MarcarLida marca uma notificação do usuário como lida; notificações de outros usuários não são encontradas.
+func (r *NotificacaoRepositoryPostgres) MarcarLida(ctx context.Context, usuarioID, id string, agora time.Time) (*notificacoes.Notificacao, error) {
+	const op = "repository.postgres.notificacao.MarcarLida"
+	query := `
+		UPDATE notificacoes
+		SET lida = TRUE, lida_em = COALESCE(lida_em, $3)
+		WHERE id = $1 AND usuario_id = $2
+		RETURNING ` + colunasNotificacao
+	rows, err := r.db.Query(ctx, query, id, usuarioID, agora)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	n, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByPos[notificacoes.Notificacao])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return n, nil
+}
+
+func (r *NotificacaoRepositoryPostgres) MarcarTodasLidas(ctx context.Context, usuarioID string, agora time.Time) (int64, error) {
+	const op = "repository.postgres.notificacao.MarcarTodasLidas"
+	cmd, err := r.db.Exec(ctx, `UPDATE notificacoes SET lida = TRUE, lida_em = $2 WHERE usuario_id = $1 AND NOT lida`, usuarioID, agora)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return cmd.RowsAffected(), nil
+}
+
+// ReservarEntregasPendentes reserva, das mais antigas às mais novas, as notificações com
+// entregas vencidas. Linhas reservadas por outra transação são ignoradas (SKIP LOCKED).
+func (r *NotificacaoRepositoryPostgres) ReservarEntregasPendentes(ctx context.Context, agora, reservaAte time.Time, limite int) ([]*notificacoes.Notificacao, error) {
+	const op = "repository.postgres.notificacao.ReservarEntregasPendentes"
+	query := `
+		UPDATE notificacoes SET proxima_entrega = $2
+		WHERE id IN (
+			SELECT id FROM notificacoes
+			WHERE proxima_entrega IS NOT NULL AND proxima_entrega <= $1
+			ORDER BY proxima_entrega
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + colunasNotificacao
+	rows, err := r.db.Query(ctx, query, agora, reservaAte, limite)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	lista, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByPos[notificacoes.Notificacao])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return lista, nil
+}
+
+// BuscarPreferencias retorna ErrNaoEncontrado quando o usuário ainda não configurou nada.
+func (r *NotificacaoRepositoryPostgres) BuscarPreferencias(ctx context.Context, usuarioID string) (*notificacoes.PreferenciasNotificacao, error) {
+	const op = "repository.postgres.notificacao.BuscarPreferencias"
+	query := `SELECT usuario_id, telefone, webhook_url, eventos, updated_at FROM preferencias_notificacao WHERE usuario_id = $1`
+	rows, err := r.db.Query(ctx, query, usuarioID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	p, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByPos[notificacoes.PreferenciasNotificacao])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return p, nil
+}
+
+func (r *NotificacaoRepositoryPostgres) SalvarPreferencias(ctx context.Context, p *notificacoes.PreferenciasNotificacao) error {
+	const op = "repository.postgres.notificacao.SalvarPreferencias"
+	query := `
+		INSERT INTO preferencias_notificacao (usuario_id, telefone, webhook_url, eventos, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (usuario_id) DO UPDATE
+		SET telefone = EXCLUDED.telefone, webhook_url = EXCLUDED.webhook_url, eventos = EXCLUDED.eventos, updated_at = EXCLUDED.updated_at
+	`
+	if _, err := r.db.Exec(ctx, query, p.UsuarioID, p.Telefone, p.WebhookURL, p.Eventos, p.UpdatedAt); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// ListarDestinatarios retorna os usuários ativos que possuem a permissão.
+func (r *NotificacaoRepositoryPostgres) ListarDestinatarios(ctx context.Context, permissao string) ([]notificacoes.Destinatario, error) {
+	const op = "repository.postgres.notificacao.ListarDestinatarios"
+	query := `SELECT id::text, nome, email FROM usuarios WHERE ativo AND $1 = ANY(permissoes) ORDER BY nome`
+	rows, err := r.db.Query(ctx, query, permissao)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	destinatarios, err := pgx.CollectRows(rows, pgx.RowToStructByPos[notificacoes.Destinatario])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return destinatarios, nil
+}
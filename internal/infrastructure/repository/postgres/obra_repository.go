@@ -45,6 +45,10 @@ func (r *ObraRepositoryPostgres) ListarObras(ctx context.Context, filtros common
 		whereClauses = append(whereClauses, "o.status = @status")
 		args["status"] = filtros.Status
 	}
+	if filtros.ClienteID != "" {
+		whereClauses = append(whereClauses, "o.cliente_id = @cliente_id")
+		args["cliente_id"] = filtros.ClienteID
+	}
 
 	whereString := " WHERE " + strings.Join(whereClauses, " AND ")
 
@@ -81,7 +85,8 @@ func (r *ObraRepositoryPostgres) ListarObras(ctx context.Context, filtros common
 		) etapa_atual ON true
 		LEFT JOIN LATERAL (
 			SELECT
-				(CAST(COUNT(CASE WHEN e.status = 'Concluída' THEN 1 END) AS FLOAT) / GREATEST(COUNT(e.id), 1)) * 100 AS percentual_concluido
+				-- Média do percentual executado ponderada pelo peso de cada etapa
+				COALESCE(SUM(e.peso * e.percentual_executado) / NULLIF(SUM(e.peso), 0), 0)::float AS percentual_concluido
 			FROM etapas e
 			WHERE e.obra_id = o.id
 		) etapa_stats ON true
@@ -116,10 +121,10 @@ func (r *ObraRepositoryPostgres) BuscarDashboardPorID(ctx context.Context, id st
 	// Cada CTE calcula um pedaço da informação que precisamos.
 	query := `
     WITH etapa_stats AS (
-        -- CTE para calcular o percentual de conclusão
+        -- CTE para calcular o percentual de conclusão, ponderado pelo peso das etapas
         SELECT
             obra_id,
-            CAST(COUNT(CASE WHEN status = 'Concluída' THEN 1 END) AS FLOAT) / GREATEST(COUNT(*), 1) * 100 AS percentual_concluido
+            COALESCE(SUM(peso * percentual_executado) / NULLIF(SUM(peso), 0), 0)::float AS percentual_concluido
         FROM etapas
         GROUP BY obra_id
     ),
@@ -231,12 +236,12 @@ func (r *ObraRepositoryPostgres) BuscarDashboardPorID(ctx context.Context, id st
 func (r *ObraRepositoryPostgres) Salvar(ctx context.Context, dbtx db.DBTX, obra *obras.Obra) error {
 	const op = "repository.postgres.Salvar"
 	query := `INSERT INTO obras (id, nome, cliente, endereco, data_inicio, data_fim, descricao, status,
-	                           valor_contrato_total, valor_recebido, tipo_cobranca, data_assinatura_contrato)
-	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+	                           valor_contrato_total, valor_recebido, tipo_cobranca, data_assinatura_contrato, cliente_id, percentual_retencao)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
 	_, err := dbtx.Exec(ctx, query,
 		obra.ID, obra.Nome, obra.Cliente, obra.Endereco,
 		obra.DataInicio, obra.DataFim, obra.Descricao, obra.Status,
-		obra.ValorContratoTotal, obra.ValorRecebido, obra.TipoCobranca, obra.DataAssinaturaContrato,
+		obra.ValorContratoTotal, obra.ValorRecebido, obra.TipoCobranca, obra.DataAssinaturaContrato, obra.ClienteID, obra.PercentualRetencao,
 	)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
@@ -249,14 +254,14 @@ func (r *ObraRepositoryPostgres) BuscarPorID(ctx context.Context, id string) (*o
 	const op = "repository.postgres.BuscarPorID"
 
 	query := `SELECT id, nome, cliente, endereco, data_inicio, data_fim, status, descricao,
-	                 valor_contrato_total, valor_recebido, tipo_cobranca, data_assinatura_contrato 
+	                 valor_contrato_total, valor_recebido, tipo_cobranca, data_assinatura_contrato, cliente_id, percentual_retencao
 	          FROM obras WHERE id = $1 AND deleted_at IS NULL`
 
 	var obra obras.Obra
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&obra.ID, &obra.Nome, &obra.Cliente, &obra.Endereco, 
 		&obra.DataInicio, &obra.DataFim, &obra.Status, &obra.Descricao,
-		&obra.ValorContratoTotal, &obra.ValorRecebido, &obra.TipoCobranca, &obra.DataAssinaturaContrato,
+		&obra.ValorContratoTotal, &obra.ValorRecebido, &obra.TipoCobranca, &obra.DataAssinaturaContrato, &obra.ClienteID, &obra.PercentualRetencao,
 	)
 
 	if err != nil {
@@ -269,6 +274,40 @@ func (r *ObraRepositoryPostgres) BuscarPorID(ctx context.Context, id string) (*o
 	return &obra, nil
 }
 
+// ListarPorClienteID lista as obras do cliente, das mais recentes para as mais antigas.
+func (r *ObraRepositoryPostgres) ListarPorClienteID(ctx context.Context, clienteID string) ([]*obras.Obra, error) {
+	const op = "repository.postgres.obra.ListarPorClienteID"
+
+	query := `SELECT id, nome, cliente, endereco, data_inicio, data_fim, status, descricao,
+	                 valor_contrato_total, valor_recebido, tipo_cobranca, data_assinatura_contrato, cliente_id, percentual_retencao
+	          FROM obras WHERE cliente_id = $1 AND deleted_at IS NULL
+	          ORDER BY data_inicio DESC`
+
+	rows, err := r.db.Query(ctx, query, clienteID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	lista := make([]*obras.Obra, 0)
+	for rows.Next() {
+		var obra obras.Obra
+		err := rows.Scan(
+			&obra.ID, &obra.Nome, &obra.Cliente, &obra.Endereco,
+			&obra.DataInicio, &obra.DataFim, &obra.Status, &obra.Descricao,
+			&obra.ValorContratoTotal, &obra.ValorRecebido, &obra.TipoCobranca, &obra.DataAssinaturaContrato, &obra.ClienteID, &obra.PercentualRetencao,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: falha ao escanear obra: %w", op, err)
+		}
+		lista = append(lista, &obra)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return lista, nil
+}
+
 func (r *ObraRepositoryPostgres) Deletar(ctx context.Context, id string) error {
 	const op = "repository.postgres.obra.Deletar"
 	query := `UPDATE obras SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
@@ -283,17 +322,21 @@ func (r *ObraRepositoryPostgres) Deletar(ctx context.Context, id string) error {
 	return nil
 }
 
-func (r *ObraRepositoryPostgres) Atualizar(ctx context.Context, obra *obras.Obra) error {
+func (r *ObraRepositoryPostgres) Atualizar(ctx context.Context, dbtx db.DBTX, obra *obras.Obra) error {
 	const op = "repository.postgres.obra.Atualizar"
+	if dbtx == nil {
+		dbtx = r.db
+	}
 
 	query := `
 		UPDATE obras
 		SET nome = $1, cliente = $2, endereco = $3, data_inicio = $4, data_fim = $5, status = $6, descricao = $7,
-		    valor_contrato_total = $8, valor_recebido = $9, tipo_cobranca = $10, data_assinatura_contrato = $11
+		    valor_contrato_total = $8, valor_recebido = $9, tipo_cobranca = $10, data_assinatura_contrato = $11,
+		    cliente_id = $13, percentual_retencao = $14
 		WHERE id = $12 AND deleted_at IS NULL
 	`
 
-	cmd, err := r.db.Exec(ctx, query,
+	cmd, err := dbtx.Exec(ctx, query,
 		obra.Nome,
 		obra.Cliente,
 		obra.Endereco,
@@ -306,6 +349,8 @@ func (r *ObraRepositoryPostgres) Atualizar(ctx context.Context, obra *obras.Obra
 		obra.TipoCobranca,
 		obra.DataAssinaturaContrato,
 		obra.ID,
+		obra.ClienteID,
+		obra.PercentualRetencao,
 	)
 
 	if err != nil {
@@ -0,0 +1,121 @@
+// file: internal/infrastructure/repository/postgres/orcamento_analitico_repository.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
+)
+
+// OrcamentoAnaliticoRepositoryPostgres persiste a linha de base de custo das obras.
+type OrcamentoAnaliticoRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoOrcamentoAnaliticoRepository(db *pgxpool.Pool, logger *slog.Logger) *OrcamentoAnaliticoRepositoryPostgres {
+	return &OrcamentoAnaliticoRepositoryPostgres{db: db, logger: logger}
+}
+
+// Salvar substitui o orçamento analítico da obra (cabeçalho e todas as linhas).
+func (r *OrcamentoAnaliticoRepositoryPostgres) Salvar(ctx context.Context, o *obras.OrcamentoAnalitico) error {
+	const op = "repository.postgres.orcamento_analitico.Salvar"
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: falha ao iniciar transação: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO obra_orcamento_analitico (obra_id, percentual_alerta, percentual_critico, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (obra_id) DO UPDATE
+		SET percentual_alerta = EXCLUDED.percentual_alerta,
+			percentual_critico = EXCLUDED.percentual_critico,
+			updated_at = EXCLUDED.updated_at
+	`
+	if _, err := tx.Exec(ctx, query, o.ObraID, o.PercentualAlerta, o.PercentualCritico, o.UpdatedAt); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM obra_orcamento_analitico_itens WHERE obra_id = $1`, o.ObraID); err != nil {
+		return fmt.Errorf("%s: falha ao remover linhas anteriores: %w", op, err)
+	}
+
+	linhas := make([][]any, 0, len(o.Itens))
+	for _, item := range o.Itens {
+		linhas = append(linhas, []any{item.ID, o.ObraID, item.EtapaID, item.Categoria, item.ValorPrevisto})
+	}
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"obra_orcamento_analitico_itens"},
+		[]string{"id", "obra_id", "etapa_id", "categoria", "valor_previsto"},
+		pgx.CopyFromRows(linhas),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: falha ao inserir linhas: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: falha ao comitar transação: %w", op, err)
+	}
+	return nil
+}
+
+// Criar grava o orçamento de uma obra que ainda não tem orçamento, dentro da
+// transação informada (ex.: criação da obra a partir de um modelo).
+func (r *OrcamentoAnaliticoRepositoryPostgres) Criar(ctx context.Context, dbtx db.DBTX, o *obras.OrcamentoAnalitico) error {
+	const op = "repository.postgres.orcamento_analitico.Criar"
+
+	query := `
+		INSERT INTO obra_orcamento_analitico (obra_id, percentual_alerta, percentual_critico, updated_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err := dbtx.Exec(ctx, query, o.ObraID, o.PercentualAlerta, o.PercentualCritico, o.UpdatedAt); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	for _, item := range o.Itens {
+		_, err := dbtx.Exec(ctx, `
+			INSERT INTO obra_orcamento_analitico_itens (id, obra_id, etapa_id, categoria, valor_previsto)
+			VALUES ($1, $2, $3, $4, $5)
+		`, item.ID, o.ObraID, item.EtapaID, item.Categoria, item.ValorPrevisto)
+		if err != nil {
+			return fmt.Errorf("%s: falha ao inserir linha: %w", op, err)
+		}
+	}
+	return nil
+}
+
+func (r *OrcamentoAnaliticoRepositoryPostgres) BuscarPorObraID(ctx context.Context, obraID string) (*obras.OrcamentoAnalitico, error) {
+	const op = "repository.postgres.orcamento_analitico.BuscarPorObraID"
+
+	o := &obras.OrcamentoAnalitico{ObraID: obraID}
+	query := `SELECT percentual_alerta::float, percentual_critico::float, updated_at FROM obra_orcamento_analitico WHERE obra_id = $1`
+	err := r.db.QueryRow(ctx, query, obraID).Scan(&o.PercentualAlerta, &o.PercentualCritico, &o.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, etapa_id, categoria, valor_previsto::float
+		FROM obra_orcamento_analitico_itens
+		WHERE obra_id = $1
+		ORDER BY etapa_id NULLS FIRST, categoria
+	`, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	o.Itens, err = pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[obras.ItemOrcamentoAnalitico])
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao escanear linhas: %w", op, err)
+	}
+	return o, nil
+}
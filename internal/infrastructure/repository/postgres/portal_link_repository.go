@@ -0,0 +1,109 @@
+// file: internal/infrastructure/repository/postgres/portal_link_repository.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/portal"
+)
+
+// PortalLinkRepositoryPostgres persiste os links de compartilhamento do portal do cliente.
+type PortalLinkRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoPortalLinkRepository(db *pgxpool.Pool, logger *slog.Logger) *PortalLinkRepositoryPostgres {
+	return &PortalLinkRepositoryPostgres{db: db, logger: logger}
+}
+
+const colunasPortalLink = `id, obra_id, descricao, criado_por, expira_em, revogado_em, ultimo_acesso_em, total_acessos, created_at`
+
+func (r *PortalLinkRepositoryPostgres) Salvar(ctx context.Context, l *portal.LinkCompartilhamento) error {
+	const op = "repository.postgres.portal_link.Salvar"
+	query := `
+		INSERT INTO portal_links (` + colunasPortalLink + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.Exec(ctx, query,
+		l.ID, l.ObraID, l.Descricao, l.CriadoPor, l.ExpiraEm, l.RevogadoEm, l.UltimoAcessoEm, l.TotalAcessos, l.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *PortalLinkRepositoryPostgres) BuscarPorID(ctx context.Context, id string) (*portal.LinkCompartilhamento, error) {
+	const op = "repository.postgres.portal_link.BuscarPorID"
+	l, err := scanPortalLink(r.db.QueryRow(ctx, `SELECT `+colunasPortalLink+` FROM portal_links WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return l, nil
+}
+
+func (r *PortalLinkRepositoryPostgres) ListarPorObraID(ctx context.Context, obraID string) ([]*portal.LinkCompartilhamento, error) {
+	const op = "repository.postgres.portal_link.ListarPorObraID"
+
+	rows, err := r.db.Query(ctx, `SELECT `+colunasPortalLink+` FROM portal_links WHERE obra_id = $1 ORDER BY created_at DESC`, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	links := make([]*portal.LinkCompartilhamento, 0)
+	for rows.Next() {
+		l, err := scanPortalLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: falha ao escanear link: %w", op, err)
+		}
+		links = append(links, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return links, nil
+}
+
+func (r *PortalLinkRepositoryPostgres) Revogar(ctx context.Context, id string, em time.Time) error {
+	const op = "repository.postgres.portal_link.Revogar"
+	cmd, err := r.db.Exec(ctx, `UPDATE portal_links SET revogado_em = $2 WHERE id = $1 AND revogado_em IS NULL`, id, em)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrNaoEncontrado
+	}
+	return nil
+}
+
+func (r *PortalLinkRepositoryPostgres) RegistrarAcesso(ctx context.Context, id string, em time.Time) error {
+	const op = "repository.postgres.portal_link.RegistrarAcesso"
+	_, err := r.db.Exec(ctx,
+		`UPDATE portal_links SET total_acessos = total_acessos + 1, ultimo_acesso_em = $2 WHERE id = $1`, id, em)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func scanPortalLink(row pgx.Row) (*portal.LinkCompartilhamento, error) {
+	var l portal.LinkCompartilhamento
+	err := row.Scan(
+		&l.ID, &l.ObraID, &l.Descricao, &l.CriadoPor, &l.ExpiraEm, &l.RevogadoEm, &l.UltimoAcessoEm, &l.TotalAcessos, &l.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
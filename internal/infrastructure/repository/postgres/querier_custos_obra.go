@@ -0,0 +1,64 @@
+// file: internal/infrastructure/repository/postgres/querier_custos_obra.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// categoriaCustoContaPagar classifica a conta a pagar (alias cp) em uma categoria
+// de custo. Cada tipo tem a sua; FORNECEDOR só é material quando vem de um
+// orçamento de compra, e os demais casos, inclusive tipos desconhecidos, ficam em OUTROS.
+const categoriaCustoContaPagar = `CASE
+				WHEN cp.tipo_conta_pagar = 'MATERIAL' THEN 'MATERIAL'
+				WHEN cp.tipo_conta_pagar = 'SERVICO' THEN 'SERVICOS'
+				WHEN cp.tipo_conta_pagar = 'EQUIPAMENTO' THEN 'EQUIPAMENTOS'
+				WHEN cp.tipo_conta_pagar = 'FORNECEDOR' AND cp.orcamento_id IS NOT NULL THEN 'MATERIAL'
+				ELSE 'OUTROS'
+			END`
+
+// ListarCustosApurados agrupa o custo da obra por etapa e categoria:
+//   - comprometido: orçamentos aprovados ou pagos (material da etapa);
+//   - realizado: contas a pagar pagas, na categoria do tipo (categoriaCustoContaPagar)
+//     e na etapa do orçamento de origem, quando houver, e pagamentos de apontamentos
+//     (mão de obra da obra, sem etapa).
+func (q *ObraRepositoryPostgres) ListarCustosApurados(ctx context.Context, obraID string) ([]dto.CustoApurado, error) {
+	const op = "querier.postgres.obra.ListarCustosApurados"
+	query := `
+		SELECT etapa_id, categoria, SUM(comprometido)::float AS comprometido, SUM(realizado)::float AS realizado
+		FROM (
+			SELECT o.etapa_id::text AS etapa_id, 'MATERIAL' AS categoria, o.valor_total AS comprometido, 0 AS realizado
+			FROM orcamentos o
+			JOIN etapas e ON e.id = o.etapa_id
+			WHERE e.obra_id = $1 AND o.status IN ('Aprovado', 'Pago') AND o.deleted_at IS NULL
+
+			UNION ALL
+
+			SELECT o.etapa_id::text,
+			` + categoriaCustoContaPagar + `,
+				0, cp.valor_pago
+			FROM contas_pagar cp
+			LEFT JOIN orcamentos o ON o.id = cp.orcamento_id
+			WHERE cp.obra_id = $1 AND cp.valor_pago > 0 AND cp.status <> 'CANCELADO'
+
+			UNION ALL
+
+			SELECT NULL, 'MAO_DE_OBRA', 0, rp.valor_calculado
+			FROM registros_pagamento rp
+			WHERE rp.obra_id = $1
+		) custos
+		GROUP BY etapa_id, categoria
+	`
+	rows, err := q.db.Query(ctx, query, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	custos, err := pgx.CollectRows(rows, pgx.RowToStructByName[dto.CustoApurado])
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao escanear custos: %w", op, err)
+	}
+	return custos, nil
+}
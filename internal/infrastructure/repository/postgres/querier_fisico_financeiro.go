@@ -0,0 +1,75 @@
+// file: internal/infrastructure/repository/postgres/querier_fisico_financeiro.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// ListarCustoPrevistoPorEtapa soma os orçamentos aprovados (ou pagos) de cada etapa da obra.
+func (q *ObraRepositoryPostgres) ListarCustoPrevistoPorEtapa(ctx context.Context, obraID string) (map[string]float64, error) {
+	const op = "querier.postgres.obra.ListarCustoPrevistoPorEtapa"
+	query := `
+		SELECT e.id, COALESCE(SUM(o.valor_total), 0)::float
+		FROM etapas e
+		JOIN orcamentos o ON o.etapa_id = e.id
+		WHERE e.obra_id = $1
+		  AND o.status IN ('Aprovado', 'Pago')
+		  AND o.deleted_at IS NULL
+		GROUP BY e.id
+	`
+	rows, err := q.db.Query(ctx, query, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	custos := make(map[string]float64)
+	var etapaID string
+	var valor float64
+	_, err = pgx.ForEachRow(rows, []any{&etapaID, &valor}, func() error {
+		custos[etapaID] = valor
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return custos, nil
+}
+
+// ListarLancamentosRealizados retorna o que a obra efetivamente pagou e recebeu.
+// Recebimentos do cronograma e contas a receber avulsas são somados; contas a receber
+// geradas a partir do cronograma ficam de fora para não contar o valor duas vezes.
+func (q *ObraRepositoryPostgres) ListarLancamentosRealizados(ctx context.Context, obraID string) ([]dto.LancamentoRealizado, error) {
+	const op = "querier.postgres.obra.ListarLancamentosRealizados"
+	query := `
+		SELECT 'CUSTO' AS tipo, 'CONTA_PAGAR' AS origem, COALESCE(data_pagamento, updated_at) AS data, valor_pago::float AS valor
+		FROM contas_pagar
+		WHERE obra_id = $1 AND valor_pago > 0 AND status <> 'CANCELADO'
+		UNION ALL
+		SELECT 'CUSTO', 'APONTAMENTO', data_de_efetivacao, valor_calculado::float
+		FROM registros_pagamento
+		WHERE obra_id = $1
+		UNION ALL
+		SELECT 'RECEITA', 'CRONOGRAMA_RECEBIMENTO', COALESCE(data_recebimento, updated_at), valor_recebido::float
+		FROM cronograma_recebimentos
+		WHERE obra_id = $1 AND valor_recebido > 0
+		UNION ALL
+		SELECT 'RECEITA', 'CONTA_RECEBER', COALESCE(data_recebimento, updated_at), valor_recebido::float
+		FROM contas_receber
+		WHERE obra_id = $1 AND cronograma_recebimento_id IS NULL AND valor_recebido > 0 AND status <> 'CANCELADO'
+		ORDER BY data
+	`
+	rows, err := q.db.Query(ctx, query, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	lancamentos, err := pgx.CollectRows(rows, pgx.RowToStructByName[dto.LancamentoRealizado])
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao escanear lançamentos: %w", op, err)
+	}
+	return lancamentos, nil
+}
@@ -98,6 +98,7 @@ func (q *ObraRepositoryPostgres) BuscarDetalhesPorID(ctx context.Context, obraID
 		ID:           obraBase.ID,
 		Nome:         obraBase.Nome,
 		Cliente:      obraBase.Cliente,
+		ClienteID:    obraBase.ClienteID,
 		Endereco:     obraBase.Endereco,
 		DataInicio:   obraBase.DataInicio,
 		DataFim:      obraBase.DataFim,
@@ -114,7 +115,7 @@ func (q *ObraRepositoryPostgres) BuscarDetalhesPorID(ctx context.Context, obraID
 func (q *ObraRepositoryPostgres) fetchObraBase(ctx context.Context, obraID string) (*obras.Obra, error) {
 	query := `SELECT 
 		id, nome, cliente, endereco, descricao, data_inicio, data_fim, status, deleted_at,
-		valor_contrato_total, valor_recebido, tipo_cobranca, data_assinatura_contrato
+		valor_contrato_total, valor_recebido, tipo_cobranca, data_assinatura_contrato, cliente_id, percentual_retencao
 	FROM obras WHERE id = $1`
 	row, err := q.db.Query(ctx, query, obraID)
 	if err != nil {
@@ -0,0 +1,158 @@
+// file: internal/infrastructure/repository/postgres/querier_resultado_obra.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// ListarObrasResultado lista as obras ativas com valor de contrato e avanço físico
+// ponderado. Status vazio traz todas as obras não excluídas.
+func (q *ObraRepositoryPostgres) ListarObrasResultado(ctx context.Context, status string) ([]dto.ObraResultadoBase, error) {
+	const op = "querier.postgres.obra.ListarObrasResultado"
+	query := `
+		SELECT o.id::text AS id, o.nome, o.status, o.valor_contrato_total::float AS valor_contrato_total,
+			COALESCE((
+				SELECT SUM(e.peso * e.percentual_executado) / NULLIF(SUM(e.peso), 0)
+				FROM etapas e WHERE e.obra_id = o.id
+			), 0)::float AS percentual_fisico
+		FROM obras o
+		WHERE o.deleted_at IS NULL AND ($1 = '' OR o.status = $1)
+		ORDER BY o.nome
+	`
+	rows, err := q.db.Query(ctx, query, status)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	lista, err := pgx.CollectRows(rows, pgx.RowToStructByName[dto.ObraResultadoBase])
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao escanear obras: %w", op, err)
+	}
+	return lista, nil
+}
+
+// ListarCustosResultado agrupa o custo das obras por categoria em comprometido,
+// incorrido e pago. Contas a pagar geradas a partir de um orçamento não somam ao
+// comprometido, que já conta o orçamento.
+func (q *ObraRepositoryPostgres) ListarCustosResultado(ctx context.Context, obraIDs []string) ([]dto.CustoResultado, error) {
+	const op = "querier.postgres.obra.ListarCustosResultado"
+	query := `
+		SELECT obra_id, categoria,
+			SUM(comprometido)::float AS comprometido, SUM(incorrido)::float AS incorrido, SUM(pago)::float AS pago
+		FROM (
+			SELECT e.obra_id::text AS obra_id, 'MATERIAL' AS categoria, o.valor_total AS comprometido, 0 AS incorrido, 0 AS pago
+			FROM orcamentos o
+			JOIN etapas e ON e.id = o.etapa_id
+			WHERE e.obra_id = ANY($1::uuid[]) AND o.status IN ('Aprovado', 'Pago') AND o.deleted_at IS NULL
+
+			UNION ALL
+
+			SELECT cp.obra_id::text,
+			` + categoriaCustoContaPagar + `,
+				CASE WHEN cp.orcamento_id IS NULL THEN cp.valor_original ELSE 0 END,
+				cp.valor_original, cp.valor_pago
+			FROM contas_pagar cp
+			WHERE cp.obra_id = ANY($1::uuid[]) AND cp.status <> 'CANCELADO'
+
+			UNION ALL
+
+			SELECT aq.obra_id::text, 'MAO_DE_OBRA', aq.valor_total_calculado, aq.valor_total_calculado, 0
+			FROM apontamentos_quinzenais aq
+			WHERE aq.obra_id = ANY($1::uuid[]) AND aq.status IN ('APROVADO_PARA_PAGAMENTO', 'PAGO')
+
+			UNION ALL
+
+			SELECT rp.obra_id::text, 'MAO_DE_OBRA', 0, 0, rp.valor_calculado
+			FROM registros_pagamento rp
+			WHERE rp.obra_id = ANY($1::uuid[])
+		) custos
+		GROUP BY obra_id, categoria
+		ORDER BY obra_id, categoria
+	`
+	rows, err := q.db.Query(ctx, query, obraIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	custos, err := pgx.CollectRows(rows, pgx.RowToStructByName[dto.CustoResultado])
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao escanear custos: %w", op, err)
+	}
+	return custos, nil
+}
+
+// ListarFaturamentoResultado soma o que foi emitido e recebido de cada obra. Assim
+// como nos lançamentos realizados, contas a receber geradas pelo cronograma ficam de fora.
+// O faturado da parcela é o bruto, com a caução; a parcela só recebe o líquido e a
+// conta de liberação da caução entra apenas no recebido.
+func (q *ObraRepositoryPostgres) ListarFaturamentoResultado(ctx context.Context, obraIDs []string) ([]dto.FaturamentoResultado, error) {
+	const op = "querier.postgres.obra.ListarFaturamentoResultado"
+	query := `
+		SELECT obra_id, SUM(faturado)::float AS faturado, SUM(recebido)::float AS recebido
+		FROM (
+			SELECT obra_id::text AS obra_id, valor_previsto AS faturado, valor_recebido AS recebido
+			FROM cronograma_recebimentos
+			WHERE obra_id = ANY($1::uuid[]) AND status <> 'CANCELADO'
+
+			UNION ALL
+
+			SELECT obra_id::text, CASE WHEN tipo_conta_receber = 'CAUCAO' THEN 0 ELSE valor_original END, valor_recebido
+			FROM contas_receber
+			WHERE obra_id = ANY($1::uuid[]) AND cronograma_recebimento_id IS NULL AND status <> 'CANCELADO'
+		) receitas
+		GROUP BY obra_id
+	`
+	rows, err := q.db.Query(ctx, query, obraIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	faturamento, err := pgx.CollectRows(rows, pgx.RowToStructByName[dto.FaturamentoResultado])
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao escanear faturamento: %w", op, err)
+	}
+	return faturamento, nil
+}
+
+// ListarFluxoCaixaMensal agrupa por mês os recebimentos e pagamentos efetivados das obras.
+func (q *ObraRepositoryPostgres) ListarFluxoCaixaMensal(ctx context.Context, obraIDs []string) ([]dto.FluxoCaixaMensal, error) {
+	const op = "querier.postgres.obra.ListarFluxoCaixaMensal"
+	query := `
+		SELECT obra_id, to_char(data, 'YYYY-MM') AS mes, SUM(recebido)::float AS recebido, SUM(pago)::float AS pago
+		FROM (
+			SELECT obra_id::text AS obra_id, COALESCE(data_pagamento, updated_at) AS data, 0 AS recebido, valor_pago AS pago
+			FROM contas_pagar
+			WHERE obra_id = ANY($1::uuid[]) AND valor_pago > 0 AND status <> 'CANCELADO'
+
+			UNION ALL
+
+			SELECT obra_id::text, data_de_efetivacao, 0, valor_calculado
+			FROM registros_pagamento
+			WHERE obra_id = ANY($1::uuid[])
+
+			UNION ALL
+
+			SELECT obra_id::text, COALESCE(data_recebimento, updated_at), valor_recebido, 0
+			FROM cronograma_recebimentos
+			WHERE obra_id = ANY($1::uuid[]) AND valor_recebido > 0
+
+			UNION ALL
+
+			SELECT obra_id::text, COALESCE(data_recebimento, updated_at), valor_recebido, 0
+			FROM contas_receber
+			WHERE obra_id = ANY($1::uuid[]) AND cronograma_recebimento_id IS NULL AND valor_recebido > 0 AND status <> 'CANCELADO'
+		) caixa
+		GROUP BY obra_id, mes
+		ORDER BY mes
+	`
+	rows, err := q.db.Query(ctx, query, obraIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	fluxo, err := pgx.CollectRows(rows, pgx.RowToStructByName[dto.FluxoCaixaMensal])
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao escanear fluxo de caixa: %w", op, err)
+	}
+	return fluxo, nil
+}
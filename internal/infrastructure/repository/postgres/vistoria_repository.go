@@ -0,0 +1,173 @@
+// file: internal/infrastructure/repository/postgres/vistoria_repository.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+)
+
+// VistoriaRepositoryPostgres persiste as rodadas de vistoria e as pendências da
+// obra. O histórico de verificações de cada pendência fica em uma coluna JSONB.
+type VistoriaRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoVistoriaRepository(db *pgxpool.Pool, logger *slog.Logger) *VistoriaRepositoryPostgres {
+	return &VistoriaRepositoryPostgres{db: db, logger: logger}
+}
+
+const colunasVistoria = `id, obra_id, numero, data, vistoriador, observacoes, status, criado_por, encerrada_em, created_at, updated_at`
+
+const colunasPendenciaVistoria = `id, obra_id, vistoria_id, descricao, local, etapa_id, responsavel, foto_url, prazo,
+	bloqueante, status, corrigida_em, resolvida_em, verificacoes, created_at, updated_at`
+
+func (r *VistoriaRepositoryPostgres) Salvar(ctx context.Context, v *obras.Vistoria) error {
+	const op = "repository.postgres.vistoria.Salvar"
+	query := `
+		INSERT INTO vistorias (` + colunasVistoria + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err := r.db.Exec(ctx, query,
+		v.ID, v.ObraID, v.Numero, v.Data, v.Vistoriador, v.Observacoes, v.Status, v.CriadoPor, v.EncerradaEm, v.CreatedAt, v.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *VistoriaRepositoryPostgres) Atualizar(ctx context.Context, v *obras.Vistoria) error {
+	const op = "repository.postgres.vistoria.Atualizar"
+	query := `
+		UPDATE vistorias
+		SET data = $2, vistoriador = $3, observacoes = $4, status = $5, encerrada_em = $6, updated_at = $7
+		WHERE id = $1
+	`
+	_, err := r.db.Exec(ctx, query, v.ID, v.Data, v.Vistoriador, v.Observacoes, v.Status, v.EncerradaEm, v.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *VistoriaRepositoryPostgres) BuscarPorID(ctx context.Context, id string) (*obras.Vistoria, error) {
+	const op = "repository.postgres.vistoria.BuscarPorID"
+	query := `SELECT ` + colunasVistoria + ` FROM vistorias WHERE id = $1`
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	v, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByPos[obras.Vistoria])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return v, nil
+}
+
+// ListarPorObraID lista as rodadas de vistoria da obra em ordem de número.
+func (r *VistoriaRepositoryPostgres) ListarPorObraID(ctx context.Context, obraID string) ([]*obras.Vistoria, error) {
+	const op = "repository.postgres.vistoria.ListarPorObraID"
+	query := `SELECT ` + colunasVistoria + ` FROM vistorias WHERE obra_id = $1 ORDER BY numero`
+
+	rows, err := r.db.Query(ctx, query, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	vistorias, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByPos[obras.Vistoria])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return vistorias, nil
+}
+
+func (r *VistoriaRepositoryPostgres) SalvarPendencia(ctx context.Context, p *obras.PendenciaVistoria) error {
+	const op = "repository.postgres.vistoria.SalvarPendencia"
+	query := `
+		INSERT INTO vistoria_pendencias (` + colunasPendenciaVistoria + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	`
+	_, err := r.db.Exec(ctx, query,
+		p.ID, p.ObraID, p.VistoriaID, p.Descricao, p.Local, p.EtapaID, p.Responsavel, p.FotoURL, p.Prazo,
+		p.Bloqueante, p.Status, p.CorrigidaEm, p.ResolvidaEm, p.Verificacoes, p.CreatedAt, p.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *VistoriaRepositoryPostgres) AtualizarPendencia(ctx context.Context, p *obras.PendenciaVistoria) error {
+	const op = "repository.postgres.vistoria.AtualizarPendencia"
+	query := `
+		UPDATE vistoria_pendencias
+		SET descricao = $2, local = $3, etapa_id = $4, responsavel = $5, foto_url = $6, prazo = $7,
+			bloqueante = $8, status = $9, corrigida_em = $10, resolvida_em = $11, verificacoes = $12, updated_at = $13
+		WHERE id = $1
+	`
+	_, err := r.db.Exec(ctx, query,
+		p.ID, p.Descricao, p.Local, p.EtapaID, p.Responsavel, p.FotoURL, p.Prazo,
+		p.Bloqueante, p.Status, p.CorrigidaEm, p.ResolvidaEm, p.Verificacoes, p.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *VistoriaRepositoryPostgres) BuscarPendenciaPorID(ctx context.Context, id string) (*obras.PendenciaVistoria, error) {
+	const op = "repository.postgres.vistoria.BuscarPendenciaPorID"
+	query := `SELECT ` + colunasPendenciaVistoria + ` FROM vistoria_pendencias WHERE id = $1`
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	p, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByPos[obras.PendenciaVistoria])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return p, nil
+}
+
+// ListarPendenciasPorObraID lista as pendências da obra: as não resolvidas primeiro,
+// das bloqueantes para as demais, pelo prazo.
+func (r *VistoriaRepositoryPostgres) ListarPendenciasPorObraID(ctx context.Context, obraID string) ([]*obras.PendenciaVistoria, error) {
+	const op = "repository.postgres.vistoria.ListarPendenciasPorObraID"
+	query := `
+		SELECT ` + colunasPendenciaVistoria + `
+		FROM vistoria_pendencias
+		WHERE obra_id = $1
+		ORDER BY status = 'RESOLVIDA', bloqueante DESC, prazo NULLS LAST, created_at
+	`
+	rows, err := r.db.Query(ctx, query, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	pendencias, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByPos[obras.PendenciaVistoria])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return pendencias, nil
+}
+
+func (r *VistoriaRepositoryPostgres) DeletarPendencia(ctx context.Context, id string) error {
+	const op = "repository.postgres.vistoria.DeletarPendencia"
+	if _, err := r.db.Exec(ctx, `DELETE FROM vistoria_pendencias WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
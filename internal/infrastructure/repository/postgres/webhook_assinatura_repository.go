@@ -0,0 +1,135 @@
+// file: internal/infrastructure/repository/postgres/webhook_assinatura_repository.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/integracoes"
+)
+
+type AssinaturaWebhookRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoAssinaturaWebhookRepository(db *pgxpool.Pool, logger *slog.Logger) *AssinaturaWebhookRepositoryPostgres {
+	return &AssinaturaWebhookRepositoryPostgres{db: db, logger: logger}
+}
+
+const colunasAssinaturaWebhook = `id, descricao, url, eventos, segredo, ativa, created_at, updated_at`
+
+func (r *AssinaturaWebhookRepositoryPostgres) Salvar(ctx context.Context, a *integracoes.AssinaturaWebhook) error {
+	const op = "repository.postgres.webhook_assinatura.Salvar"
+	query := `
+		INSERT INTO webhook_assinaturas (` + colunasAssinaturaWebhook + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.db.Exec(ctx, query, a.ID, a.Descricao, a.URL, a.Eventos, a.Segredo, a.Ativa, a.CreatedAt, a.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *AssinaturaWebhookRepositoryPostgres) Atualizar(ctx context.Context, a *integracoes.AssinaturaWebhook) error {
+	const op = "repository.postgres.webhook_assinatura.Atualizar"
+	query := `
+		UPDATE webhook_assinaturas
+		SET descricao = $2, url = $3, eventos = $4, segredo = $5, ativa = $6, updated_at = $7
+		WHERE id = $1
+	`
+	cmd, err := r.db.Exec(ctx, query, a.ID, a.Descricao, a.URL, a.Eventos, a.Segredo, a.Ativa, a.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrNaoEncontrado
+	}
+	return nil
+}
+
+func (r *AssinaturaWebhookRepositoryPostgres) BuscarPorID(ctx context.Context, id string) (*integracoes.AssinaturaWebhook, error) {
+	const op = "repository.postgres.webhook_assinatura.BuscarPorID"
+	query := `SELECT ` + colunasAssinaturaWebhook + ` FROM webhook_assinaturas WHERE id = $1`
+
+	a, err := scanAssinaturaWebhook(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return a, nil
+}
+
+func (r *AssinaturaWebhookRepositoryPostgres) Listar(ctx context.Context) ([]*integracoes.AssinaturaWebhook, error) {
+	const op = "repository.postgres.webhook_assinatura.Listar"
+	query := `SELECT ` + colunasAssinaturaWebhook + ` FROM webhook_assinaturas ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	return coletarAssinaturasWebhook(rows, op)
+}
+
+// ListarAtivasPorEvento retorna as assinaturas ativas que filtram o evento informado ou o curinga "*".
+func (r *AssinaturaWebhookRepositoryPostgres) ListarAtivasPorEvento(ctx context.Context, evento string) ([]*integracoes.AssinaturaWebhook, error) {
+	const op = "repository.postgres.webhook_assinatura.ListarAtivasPorEvento"
+	query := `
+		SELECT ` + colunasAssinaturaWebhook + `
+		FROM webhook_assinaturas
+		WHERE ativa = TRUE AND ($1 = ANY(eventos) OR $2 = ANY(eventos))
+	`
+
+	rows, err := r.db.Query(ctx, query, evento, integracoes.EventoCuringa)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	return coletarAssinaturasWebhook(rows, op)
+}
+
+func (r *AssinaturaWebhookRepositoryPostgres) Deletar(ctx context.Context, id string) error {
+	const op = "repository.postgres.webhook_assinatura.Deletar"
+	cmd, err := r.db.Exec(ctx, `DELETE FROM webhook_assinaturas WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrNaoEncontrado
+	}
+	return nil
+}
+
+func scanAssinaturaWebhook(row pgx.Row) (*integracoes.AssinaturaWebhook, error) {
+	var a integracoes.AssinaturaWebhook
+	err := row.Scan(&a.ID, &a.Descricao, &a.URL, &a.Eventos, &a.Segredo, &a.Ativa, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func coletarAssinaturasWebhook(rows pgx.Rows, op string) ([]*integracoes.AssinaturaWebhook, error) {
+	assinaturas := make([]*integracoes.AssinaturaWebhook, 0)
+	for rows.Next() {
+		a, err := scanAssinaturaWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: falha ao escanear assinatura: %w", op, err)
+		}
+		assinaturas = append(assinaturas, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return assinaturas, nil
+}
@@ -0,0 +1,164 @@
+// file: internal/infrastructure/repository/postgres/webhook_entrega_repository.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/common"
+	"github.com/luiszkm/masterCostrutora/internal/domain/integracoes"
+)
+
+type EntregaWebhookRepositoryPostgres struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NovoEntregaWebhookRepository(db *pgxpool.Pool, logger *slog.Logger) *EntregaWebhookRepositoryPostgres {
+	return &EntregaWebhookRepositoryPostgres{db: db, logger: logger}
+}
+
+const colunasEntregaWebhook = `id, assinatura_id, evento, payload, status, tentativas, ultimo_status_http,
+	ultimo_erro, proxima_tentativa, entregue_em, reenvio_de_id, created_at, updated_at`
+
+func (r *EntregaWebhookRepositoryPostgres) Salvar(ctx context.Context, e *integracoes.EntregaWebhook) error {
+	const op = "repository.postgres.webhook_entrega.Salvar"
+	query := `
+		INSERT INTO webhook_entregas (` + colunasEntregaWebhook + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+	_, err := r.db.Exec(ctx, query,
+		e.ID, e.AssinaturaID, e.Evento, e.Payload, e.Status, e.Tentativas, e.UltimoStatusHTTP,
+		e.UltimoErro, e.ProximaTentativa, e.EntregueEm, e.ReenvioDeID, e.CreatedAt, e.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *EntregaWebhookRepositoryPostgres) Atualizar(ctx context.Context, e *integracoes.EntregaWebhook) error {
+	const op = "repository.postgres.webhook_entrega.Atualizar"
+	query := `
+		UPDATE webhook_entregas
+		SET status = $2, tentativas = $3, ultimo_status_http = $4, ultimo_erro = $5,
+			proxima_tentativa = $6, entregue_em = $7, updated_at = $8
+		WHERE id = $1
+	`
+	cmd, err := r.db.Exec(ctx, query,
+		e.ID, e.Status, e.Tentativas, e.UltimoStatusHTTP, e.UltimoErro,
+		e.ProximaTentativa, e.EntregueEm, e.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrNaoEncontrado
+	}
+	return nil
+}
+
+func (r *EntregaWebhookRepositoryPostgres) BuscarPorID(ctx context.Context, id string) (*integracoes.EntregaWebhook, error) {
+	const op = "repository.postgres.webhook_entrega.BuscarPorID"
+	query := `SELECT ` + colunasEntregaWebhook + ` FROM webhook_entregas WHERE id = $1`
+
+	e, err := scanEntregaWebhook(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return e, nil
+}
+
+func (r *EntregaWebhookRepositoryPostgres) ListarPorAssinatura(ctx context.Context, assinaturaID string, filtros common.ListarFiltros) ([]*integracoes.EntregaWebhook, *common.PaginacaoInfo, error) {
+	const op = "repository.postgres.webhook_entrega.ListarPorAssinatura"
+
+	args := []interface{}{assinaturaID}
+	where := " WHERE assinatura_id = $1"
+	if filtros.Status != "" {
+		args = append(args, filtros.Status)
+		where += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM webhook_entregas"+where, args...).Scan(&total); err != nil {
+		return nil, nil, fmt.Errorf("%s: falha ao contar entregas: %w", op, err)
+	}
+
+	pagina := filtros.Pagina
+	if pagina < 1 {
+		pagina = 1
+	}
+	tamanho := filtros.TamanhoPagina
+	if tamanho < 1 {
+		tamanho = 20
+	}
+	args = append(args, tamanho, (pagina-1)*tamanho)
+	query := `SELECT ` + colunasEntregaWebhook + ` FROM webhook_entregas` + where +
+		fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	entregas, err := coletarEntregasWebhook(rows, op)
+	if err != nil {
+		return nil, nil, err
+	}
+	return entregas, common.NewPaginacaoInfo(total, pagina, tamanho), nil
+}
+
+// ListarPendentes retorna entregas pendentes cuja próxima tentativa já venceu, das mais antigas às mais novas.
+func (r *EntregaWebhookRepositoryPostgres) ListarPendentes(ctx context.Context, ate time.Time, limite int) ([]*integracoes.EntregaWebhook, error) {
+	const op = "repository.postgres.webhook_entrega.ListarPendentes"
+	query := `
+		SELECT ` + colunasEntregaWebhook + `
+		FROM webhook_entregas
+		WHERE status = $1 AND proxima_tentativa IS NOT NULL AND proxima_tentativa <= $2
+		ORDER BY proxima_tentativa ASC
+		LIMIT $3
+	`
+	rows, err := r.db.Query(ctx, query, integracoes.StatusEntregaPendente, ate, limite)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	return coletarEntregasWebhook(rows, op)
+}
+
+func scanEntregaWebhook(row pgx.Row) (*integracoes.EntregaWebhook, error) {
+	var e integracoes.EntregaWebhook
+	err := row.Scan(
+		&e.ID, &e.AssinaturaID, &e.Evento, &e.Payload, &e.Status, &e.Tentativas, &e.UltimoStatusHTTP,
+		&e.UltimoErro, &e.ProximaTentativa, &e.EntregueEm, &e.ReenvioDeID, &e.CreatedAt, &e.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func coletarEntregasWebhook(rows pgx.Rows, op string) ([]*integracoes.EntregaWebhook, error) {
+	entregas := make([]*integracoes.EntregaWebhook, 0)
+	for rows.Next() {
+		e, err := scanEntregaWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: falha ao escanear entrega: %w", op, err)
+		}
+		entregas = append(entregas, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return entregas, nil
+}
@@ -4,8 +4,12 @@ package bus
 import (
 	"context"
 	"log/slog"
+	"reflect"
+	"runtime"
 	"sync"
 	"time"
+
+	"github.com/luiszkm/masterCostrutora/pkg/tracing"
 )
 
 // Evento define a estrutura básica de um evento no nosso sistema.
@@ -17,11 +21,19 @@ type Evento struct {
 // HandlerFunc é o tipo da função que irá tratar um evento.
 type HandlerFunc func(ctx context.Context, evento Evento)
 
+// Observador recebe notificações sobre a publicação e o processamento de eventos
+// (usado para métricas). Um handler que entra em pânico é contado como falha.
+type Observador interface {
+	EventoPublicado(nome string)
+	EventoProcessado(nome string, duracao time.Duration, falhou bool)
+}
+
 // EventBus gerencia a subscrição e publicação de eventos de forma assíncrona.
 type EventBus struct {
-	handlers map[string][]HandlerFunc
-	mu       sync.RWMutex
-	logger   *slog.Logger
+	handlers   map[string][]HandlerFunc
+	mu         sync.RWMutex
+	logger     *slog.Logger
+	observador Observador
 }
 
 func NovoEventBus(logger *slog.Logger) *EventBus {
@@ -31,6 +43,13 @@ func NovoEventBus(logger *slog.Logger) *EventBus {
 	}
 }
 
+// DefinirObservador registra o observador do bus. Deve ser chamado antes da primeira publicação.
+func (b *EventBus) DefinirObservador(o Observador) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.observador = o
+}
+
 // Subscrever adiciona um novo handler para um tópico de evento.
 func (b *EventBus) Subscrever(nomeEvento string, handler HandlerFunc) {
 	b.mu.Lock()
@@ -39,23 +58,65 @@ func (b *EventBus) Subscrever(nomeEvento string, handler HandlerFunc) {
 }
 
 // Publicar envia um evento para todos os handlers subscritos, cada um em sua própria goroutine.
+// Os handlers recebem os valores do contexto de origem (trace, request ID, usuário),
+// mas não o seu cancelamento.
 func (b *EventBus) Publicar(ctx context.Context, evento Evento) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
+	ctx, span := tracing.Iniciar(ctx, "publicar "+evento.Nome, tracing.TipoProdutor)
+	defer span.Finalizar()
+	span.DefinirAtributo("messaging.destination.name", evento.Nome)
+
+	observador := b.observador
+	if observador != nil {
+		observador.EventoPublicado(evento.Nome)
+	}
+
 	if handlers, ok := b.handlers[evento.Nome]; ok {
 		for _, handler := range handlers {
 			// Executa cada handler de forma assíncrona.
 			go func(h HandlerFunc) {
 				// Criar contexto com timeout para handlers de eventos
-				// Isso evita que o contexto seja cancelado quando a requisição HTTP termina
-				handlerCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				// WithoutCancel evita que o contexto seja cancelado quando a requisição HTTP termina
+				handlerCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
 				defer cancel()
 				
 				b.logger.InfoContext(handlerCtx, "processando evento", "evento", evento.Nome)
 				// Em um sistema real, adicionaríamos retentativas e DLQ aqui (ADR-007)
-				h(handlerCtx, evento)
+				b.executar(handlerCtx, h, evento, observador)
 			}(handler)
 		}
 	}
 }
+
+// executar roda o handler isolando pânicos para que um handler com defeito não derrube o servidor.
+func (b *EventBus) executar(ctx context.Context, h HandlerFunc, evento Evento, observador Observador) {
+	ctx, span := tracing.Iniciar(ctx, "processar "+evento.Nome, tracing.TipoConsumidor)
+	span.DefinirAtributo("messaging.destination.name", evento.Nome)
+	span.DefinirAtributo("code.function", nomeHandler(h))
+
+	inicio := time.Now()
+	falhou := true
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.ErrorContext(ctx, "pânico no handler de evento", "evento", evento.Nome, "erro", r)
+			span.DefinirStatus(tracing.StatusErro, "panic")
+		}
+		span.Finalizar()
+		if observador != nil {
+			observador.EventoProcessado(evento.Nome, time.Since(inicio), falhou)
+		}
+	}()
+
+	h(ctx, evento)
+	falhou = false
+}
+
+// nomeHandler identifica o handler no span, já que vários podem assinar o mesmo evento.
+func nomeHandler(h HandlerFunc) string {
+	if f := runtime.FuncForPC(reflect.ValueOf(h).Pointer()); f != nil {
+		return f.Name()
+	}
+	return "desconhecido"
+}
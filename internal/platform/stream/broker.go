@@ -0,0 +1,187 @@
+// file: internal/platform/stream/broker.go
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus"
+)
+
+// Mensagem é um evento do EventBus já serializado e numerado para envio aos clientes.
+type Mensagem struct {
+	ID         string          `json:"id"`
+	Evento     string          `json:"evento"`
+	ObraID     string          `json:"obraId,omitempty"`
+	OcorridoEm time.Time       `json:"ocorridoEm"`
+	Dados      json.RawMessage `json:"dados"`
+
+	seq uint64
+}
+
+// Filtro decide se uma mensagem deve ser entregue a um assinante.
+type Filtro func(m Mensagem) bool
+
+// Assinante recebe mensagens pelo canal C. O canal é fechado quando o assinante
+// é cancelado ou descartado por não acompanhar o ritmo de publicação.
+type Assinante struct {
+	C      chan Mensagem
+	filtro Filtro
+}
+
+// Broker distribui eventos do EventBus para conexões de streaming e mantém um
+// buffer circular curto para que clientes reconectados retomem de onde pararam.
+type Broker struct {
+	mu         sync.RWMutex
+	buffer     []Mensagem
+	inicio     int // posição da mensagem mais antiga no buffer circular
+	total      int // quantidade de mensagens válidas no buffer
+	seq        uint64
+	epoca      string // distingue IDs de execuções anteriores do servidor
+	assinantes map[*Assinante]struct{}
+	logger     *slog.Logger
+}
+
+func NovoBroker(capacidade int, logger *slog.Logger) *Broker {
+	if capacidade < 1 {
+		capacidade = 1
+	}
+	return &Broker{
+		buffer:     make([]Mensagem, capacidade),
+		epoca:      strconv.FormatInt(time.Now().Unix(), 36),
+		assinantes: make(map[*Assinante]struct{}),
+		logger:     logger.With("component", "StreamBroker"),
+	}
+}
+
+// HandleEvento converte o evento em Mensagem, guarda no buffer e repassa aos assinantes.
+func (b *Broker) HandleEvento(ctx context.Context, evento bus.Evento) {
+	dados, err := json.Marshal(evento.Payload)
+	if err != nil {
+		b.logger.ErrorContext(ctx, "falha ao serializar evento para streaming", "evento", evento.Nome, "erro", err)
+		return
+	}
+
+	var ref struct {
+		ObraID *string `json:"obraId"`
+	}
+	_ = json.Unmarshal(dados, &ref)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	m := Mensagem{
+		ID:         fmt.Sprintf("%s-%d", b.epoca, b.seq),
+		Evento:     evento.Nome,
+		OcorridoEm: time.Now(),
+		Dados:      dados,
+		seq:        b.seq,
+	}
+	if ref.ObraID != nil {
+		m.ObraID = *ref.ObraID
+	}
+	b.armazenar(m)
+
+	for a := range b.assinantes {
+		if !a.filtro(m) {
+			continue
+		}
+		select {
+		case a.C <- m:
+		default:
+			// Assinante lento: desconecta para não segurar o publicador.
+			// O cliente reconecta com Last-Event-ID e recupera pelo buffer.
+			b.logger.WarnContext(ctx, "assinante de streaming descartado por atraso")
+			b.remover(a)
+		}
+	}
+}
+
+// Assinar registra um novo assinante. Se ultimoID for informado, retorna também as
+// mensagens do buffer posteriores a ele; completo=false indica que o ID não pôde
+// ser localizado (buffer excedido ou servidor reiniciado) e o cliente deve recarregar.
+func (b *Broker) Assinar(ultimoID string, filtro Filtro) (a *Assinante, pendentes []Mensagem, completo bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	a = &Assinante{C: make(chan Mensagem, 32), filtro: filtro}
+	b.assinantes[a] = struct{}{}
+
+	if ultimoID == "" {
+		return a, nil, true
+	}
+
+	seq, ok := b.parseID(ultimoID)
+	if !ok {
+		return a, nil, false
+	}
+
+	completo = b.total == 0 || seq >= b.buffer[b.inicio].seq-1
+	for i := 0; i < b.total; i++ {
+		m := b.buffer[(b.inicio+i)%len(b.buffer)]
+		if m.seq > seq && filtro(m) {
+			pendentes = append(pendentes, m)
+		}
+	}
+	return a, pendentes, completo
+}
+
+// Cancelar remove o assinante e fecha seu canal. Pode ser chamado mais de uma vez.
+func (b *Broker) Cancelar(a *Assinante) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remover(a)
+}
+
+// TotalAssinantes retorna a quantidade de conexões ativas.
+func (b *Broker) TotalAssinantes() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.assinantes)
+}
+
+// Encerrar desconecta todos os assinantes. É chamado no desligamento do servidor
+// para que as conexões de streaming, que não terminam sozinhas, não segurem o Shutdown.
+func (b *Broker) Encerrar() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for a := range b.assinantes {
+		b.remover(a)
+	}
+}
+
+func (b *Broker) remover(a *Assinante) {
+	if _, ok := b.assinantes[a]; ok {
+		delete(b.assinantes, a)
+		close(a.C)
+	}
+}
+
+func (b *Broker) armazenar(m Mensagem) {
+	if b.total < len(b.buffer) {
+		b.buffer[(b.inicio+b.total)%len(b.buffer)] = m
+		b.total++
+		return
+	}
+	b.buffer[b.inicio] = m
+	b.inicio = (b.inicio + 1) % len(b.buffer)
+}
+
+func (b *Broker) parseID(id string) (uint64, bool) {
+	epoca, seqStr, ok := strings.Cut(id, "-")
+	if !ok || epoca != b.epoca {
+		return 0, false
+	}
+	seq, err := strconv.ParseUint(seqStr, 10, 64)
+	if err != nil || seq > b.seq {
+		return 0, false
+	}
+	return seq, true
+}
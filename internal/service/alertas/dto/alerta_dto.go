@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/alertas"
+)
+
+// RegraAlertaOutput representa a regra em vigor para um tipo de alerta
+type RegraAlertaOutput struct {
+	alertas.RegraAlerta
+	UnidadeLimite string `json:"unidadeLimite"`
+	Personalizada bool   `json:"personalizada"` // false quando a empresa ainda usa a configuração padrão
+}
+
+// AtualizarRegraAlertaInput representa o input para personalizar uma regra; campos ausentes são mantidos
+type AtualizarRegraAlertaInput struct {
+	Ativa      *bool   `json:"ativa,omitempty"`
+	Limite     *int    `json:"limite,omitempty"`
+	Severidade *string `json:"severidade,omitempty"`
+}
+
+// AdiarAlertaInput representa o input para adiar (snooze) um alerta
+type AdiarAlertaInput struct {
+	Ate string `json:"ate" validate:"required"` // YYYY-MM-DD; o alerta volta a ficar ativo nesse dia
+}
+
+// ResultadoAvaliacaoOutput resume uma rodada de avaliação das regras
+type ResultadoAvaliacaoOutput struct {
+	AvaliadoEm  time.Time `json:"avaliadoEm"`
+	Novos       int       `json:"novos"`
+	Atualizados int       `json:"atualizados"`
+	Resolvidos  int       `json:"resolvidos"`
+	Falhas      []string  `json:"falhas,omitempty"` // Tipos cuja avaliação falhou
+}
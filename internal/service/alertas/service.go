@@ -0,0 +1,262 @@
+// file: internal/service/alertas/service.go
+package alertas
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/alertas"
+	"github.com/luiszkm/masterCostrutora/internal/service/alertas/dto"
+	"github.com/luiszkm/masterCostrutora/pkg/auth"
+)
+
+// Service mantém as regras de alerta da empresa, avalia-as periodicamente e
+// controla o reconhecimento e o adiamento dos alertas detectados.
+type Service struct {
+	regraRepo  alertas.RegraAlertaRepository
+	alertaRepo alertas.AlertaRepository
+	avaliador  alertas.Avaliador
+	logger     *slog.Logger
+	intervalo  time.Duration
+	// avaliando serializa as avaliações (periódica e sob demanda) para não duplicar alertas
+	avaliando sync.Mutex
+}
+
+func NovoServico(
+	regraRepo alertas.RegraAlertaRepository,
+	alertaRepo alertas.AlertaRepository,
+	avaliador alertas.Avaliador,
+	logger *slog.Logger,
+) *Service {
+	return &Service{
+		regraRepo:  regraRepo,
+		alertaRepo: alertaRepo,
+		avaliador:  avaliador,
+		logger:     logger.With("service", "Alertas"),
+		intervalo:  15 * time.Minute,
+	}
+}
+
+// ListarRegras retorna a regra em vigor para cada tipo, usando o padrão onde não há personalização.
+func (s *Service) ListarRegras(ctx context.Context) ([]*dto.RegraAlertaOutput, error) {
+	const op = "service.alertas.ListarRegras"
+	regras, personalizadas, err := s.regrasEmVigor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	out := make([]*dto.RegraAlertaOutput, 0, len(regras))
+	for _, r := range regras {
+		out = append(out, &dto.RegraAlertaOutput{
+			RegraAlerta:   r,
+			UnidadeLimite: r.Tipo.UnidadeLimite(),
+			Personalizada: personalizadas[r.Tipo],
+		})
+	}
+	return out, nil
+}
+
+// AtualizarRegra personaliza a regra de um tipo e reavalia esse tipo imediatamente.
+func (s *Service) AtualizarRegra(ctx context.Context, tipo string, input dto.AtualizarRegraAlertaInput) (*dto.RegraAlertaOutput, error) {
+	const op = "service.alertas.AtualizarRegra"
+
+	regras, _, err := s.regrasEmVigor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	var regra *alertas.RegraAlerta
+	for i := range regras {
+		if string(regras[i].Tipo) == tipo {
+			regra = &regras[i]
+		}
+	}
+	if regra == nil {
+		return nil, fmt.Errorf("%s: %w", op, alertas.ErrTipoAlertaInvalido)
+	}
+
+	if input.Ativa != nil {
+		regra.Ativa = *input.Ativa
+	}
+	if input.Limite != nil {
+		regra.Limite = *input.Limite
+	}
+	if input.Severidade != nil {
+		regra.Severidade = alertas.Severidade(*input.Severidade)
+	}
+	if err := regra.Validar(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	regra.UpdatedAt = time.Now()
+
+	if err := s.regraRepo.SalvarRegra(ctx, regra); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s.logger.InfoContext(ctx, "regra de alerta atualizada", "tipo", regra.Tipo, "ativa", regra.Ativa, "limite", regra.Limite, "severidade", regra.Severidade)
+
+	s.avaliando.Lock()
+	resultado := &dto.ResultadoAvaliacaoOutput{AvaliadoEm: time.Now()}
+	if err := s.avaliarRegra(ctx, *regra, resultado); err != nil {
+		s.logger.ErrorContext(ctx, "falha ao reavaliar regra de alerta", "tipo", regra.Tipo, "erro", err)
+	}
+	s.avaliando.Unlock()
+
+	return &dto.RegraAlertaOutput{RegraAlerta: *regra, UnidadeLimite: regra.Tipo.UnidadeLimite(), Personalizada: true}, nil
+}
+
+// Avaliar executa todas as regras e reconcilia os alertas abertos com o resultado.
+// A falha de uma regra não impede as demais; os tipos com falha são informados no resultado.
+func (s *Service) Avaliar(ctx context.Context) (*dto.ResultadoAvaliacaoOutput, error) {
+	const op = "service.alertas.Avaliar"
+
+	s.avaliando.Lock()
+	defer s.avaliando.Unlock()
+
+	regras, _, err := s.regrasEmVigor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	resultado := &dto.ResultadoAvaliacaoOutput{AvaliadoEm: time.Now()}
+	for _, regra := range regras {
+		if err := s.avaliarRegra(ctx, regra, resultado); err != nil {
+			s.logger.ErrorContext(ctx, "falha ao avaliar regra de alerta", "tipo", regra.Tipo, "erro", err)
+			resultado.Falhas = append(resultado.Falhas, string(regra.Tipo))
+		}
+	}
+	return resultado, nil
+}
+
+// avaliarRegra reconcilia os alertas de um tipo. Uma regra inativa não gera
+// ocorrências, o que resolve os alertas que ela havia aberto.
+func (s *Service) avaliarRegra(ctx context.Context, regra alertas.RegraAlerta, resultado *dto.ResultadoAvaliacaoOutput) error {
+	agora := resultado.AvaliadoEm
+
+	var ocorrencias []alertas.Ocorrencia
+	if regra.Ativa {
+		hoje := time.Date(agora.Year(), agora.Month(), agora.Day(), 0, 0, 0, 0, time.UTC)
+		var err error
+		if ocorrencias, err = s.avaliador.Avaliar(ctx, regra, hoje); err != nil {
+			return err
+		}
+	}
+
+	abertos, err := s.alertaRepo.ListarAbertosPorTipo(ctx, regra.Tipo)
+	if err != nil {
+		return err
+	}
+	existentes := make(map[string]bool, len(abertos))
+	for _, a := range abertos {
+		existentes[a.ID] = true
+	}
+
+	for _, alerta := range alertas.Reconciliar(abertos, ocorrencias, regra, agora, uuid.NewString) {
+		if err := s.alertaRepo.Salvar(ctx, alerta); err != nil {
+			return err
+		}
+		switch {
+		case alerta.Status == alertas.StatusResolvido:
+			resultado.Resolvidos++
+		case existentes[alerta.ID]:
+			resultado.Atualizados++
+		default:
+			resultado.Novos++
+		}
+	}
+	return nil
+}
+
+// Iniciar avalia as regras ao subir o servidor e depois periodicamente, até o contexto ser cancelado.
+func (s *Service) Iniciar(ctx context.Context) {
+	ticker := time.NewTicker(s.intervalo)
+	defer ticker.Stop()
+
+	for {
+		if resultado, err := s.Avaliar(ctx); err != nil {
+			s.logger.ErrorContext(ctx, "falha na avaliação periódica de alertas", "erro", err)
+		} else {
+			s.logger.InfoContext(ctx, "alertas avaliados",
+				"novos", resultado.Novos, "atualizados", resultado.Atualizados, "resolvidos", resultado.Resolvidos, "falhas", len(resultado.Falhas))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ListarAlertas lista os alertas do filtro; sem status, apenas os que exigem atenção.
+func (s *Service) ListarAlertas(ctx context.Context, filtro alertas.FiltroAlertas) ([]*alertas.Alerta, error) {
+	const op = "service.alertas.ListarAlertas"
+	lista, err := s.alertaRepo.Listar(ctx, filtro)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return lista, nil
+}
+
+// ReconhecerAlerta registra que o usuário logado tomou ciência do alerta.
+func (s *Service) ReconhecerAlerta(ctx context.Context, id string) (*alertas.Alerta, error) {
+	const op = "service.alertas.ReconhecerAlerta"
+	alerta, err := s.alertaRepo.BuscarPorID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := alerta.Reconhecer(auth.UsuarioDoContexto(ctx), time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.alertaRepo.Salvar(ctx, alerta); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return alerta, nil
+}
+
+// AdiarAlerta oculta o alerta até a data informada, em UTC como as datas da avaliação.
+func (s *Service) AdiarAlerta(ctx context.Context, id string, input dto.AdiarAlertaInput) (*alertas.Alerta, error) {
+	const op = "service.alertas.AdiarAlerta"
+	ate, err := time.Parse("2006-01-02", input.Ate)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	alerta, err := s.alertaRepo.BuscarPorID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := alerta.Adiar(ate, time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.alertaRepo.Salvar(ctx, alerta); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return alerta, nil
+}
+
+// regrasEmVigor combina os padrões com as regras personalizadas, na ordem de alertas.TiposAlerta.
+func (s *Service) regrasEmVigor(ctx context.Context) ([]alertas.RegraAlerta, map[alertas.TipoAlerta]bool, error) {
+	salvas, err := s.regraRepo.ListarRegras(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	personalizadas := make(map[alertas.TipoAlerta]*alertas.RegraAlerta, len(salvas))
+	for _, r := range salvas {
+		personalizadas[r.Tipo] = r
+	}
+
+	regras := make([]alertas.RegraAlerta, 0, len(alertas.TiposAlerta))
+	marcadas := make(map[alertas.TipoAlerta]bool, len(salvas))
+	for _, tipo := range alertas.TiposAlerta {
+		if r, ok := personalizadas[tipo]; ok {
+			regras = append(regras, *r)
+			marcadas[tipo] = true
+			continue
+		}
+		regras = append(regras, alertas.RegraPadrao(tipo))
+	}
+	return regras, marcadas, nil
+}
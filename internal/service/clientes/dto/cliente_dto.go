@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/clientes"
+)
+
+// ClienteInput representa os dados de cadastro e de atualização de um cliente
+type ClienteInput struct {
+	Tipo             string             `json:"tipo" validate:"required,oneof=PF PJ"`
+	Nome             string             `json:"nome" validate:"required"`
+	Documento        string             `json:"documento" validate:"required"` // CPF ou CNPJ, com ou sem máscara
+	Email            *string            `json:"email,omitempty"`
+	Telefone         *string            `json:"telefone,omitempty"`
+	Contatos         []clientes.Contato `json:"contatos,omitempty"`
+	EnderecoCobranca *clientes.Endereco `json:"enderecoCobranca,omitempty"`
+	Observacoes      *string            `json:"observacoes,omitempty"`
+}
+
+// MesclarClienteInput indica o cliente duplicado que será incorporado ao cliente da rota
+type MesclarClienteInput struct {
+	DuplicadoID string `json:"duplicadoId" validate:"required"`
+}
+
+// ExtratoClienteDTO reúne as obras, as contas a receber e o histórico de pagamento do cliente
+type ExtratoClienteDTO struct {
+	Cliente       *clientes.Cliente     `json:"cliente"`
+	Resumo        ResumoExtratoDTO      `json:"resumo"`
+	Comportamento ComportamentoPagtoDTO `json:"comportamento"`
+	Obras         []ObraExtratoDTO      `json:"obras"`
+	Contas        []ContaExtratoDTO     `json:"contas"`
+	GeradoEm      time.Time             `json:"geradoEm"`
+}
+
+// ResumoExtratoDTO totaliza o relacionamento financeiro com o cliente
+type ResumoExtratoDTO struct {
+	TotalObras         int     `json:"totalObras"`
+	ValorContratado    float64 `json:"valorContratado"`
+	TotalFaturado      float64 `json:"totalFaturado"`
+	TotalRecebido      float64 `json:"totalRecebido"`
+	SaldoEmAberto      float64 `json:"saldoEmAberto"`
+	SaldoVencido       float64 `json:"saldoVencido"`
+	ContasEmAberto     int     `json:"contasEmAberto"`
+	ContasVencidas     int     `json:"contasVencidas"`
+	PercentualRecebido float64 `json:"percentualRecebido"`
+}
+
+// ComportamentoPagtoDTO descreve a pontualidade do cliente nas contas já quitadas e nas vencidas
+type ComportamentoPagtoDTO struct {
+	ContasQuitadas    int     `json:"contasQuitadas"`
+	QuitadasEmDia     int     `json:"quitadasEmDia"`
+	QuitadasComAtraso int     `json:"quitadasComAtraso"`
+	PercentualEmDia   float64 `json:"percentualEmDia"`
+	AtrasoMedioDias   float64 `json:"atrasoMedioDias"`  // Média entre as quitadas com atraso
+	AtrasoMaximoDias  int     `json:"atrasoMaximoDias"` // Considera também as vencidas em aberto
+	ContasVencidas    int     `json:"contasVencidas"`
+	Classificacao     string  `json:"classificacao"` // PONTUAL, ATRASOS_EVENTUAIS, INADIMPLENTE, SEM_HISTORICO
+}
+
+// ObraExtratoDTO resume uma obra do cliente
+type ObraExtratoDTO struct {
+	ObraID          string  `json:"obraId"`
+	Nome            string  `json:"nome"`
+	Status          string  `json:"status"`
+	ValorContratado float64 `json:"valorContratado"`
+	TotalFaturado   float64 `json:"totalFaturado"`
+	TotalRecebido   float64 `json:"totalRecebido"`
+	SaldoEmAberto   float64 `json:"saldoEmAberto"`
+}
+
+// ContaExtratoDTO é uma linha do extrato de contas a receber
+type ContaExtratoDTO struct {
+	ContaID         string     `json:"contaId"`
+	ObraID          *string    `json:"obraId,omitempty"`
+	Descricao       string     `json:"descricao"`
+	Status          string     `json:"status"`
+	ValorOriginal   float64    `json:"valorOriginal"`
+	ValorRecebido   float64    `json:"valorRecebido"`
+	ValorSaldo      float64    `json:"valorSaldo"`
+	DataVencimento  time.Time  `json:"dataVencimento"`
+	DataRecebimento *time.Time `json:"dataRecebimento,omitempty"`
+	DiasAtraso      int        `json:"diasAtraso"` // Até o recebimento, ou até hoje se ainda em aberto
+}
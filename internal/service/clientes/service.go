@@ -0,0 +1,367 @@
+// file: internal/service/clientes/service.go
+package clientes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/clientes"
+	"github.com/luiszkm/masterCostrutora/internal/domain/common"
+	"github.com/luiszkm/masterCostrutora/internal/domain/financeiro"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/service/clientes/dto"
+)
+
+var (
+	ErrDocumentoDuplicado = errors.New("já existe um cliente com este CPF/CNPJ")
+	ErrClienteComVinculos = errors.New("o cliente possui obras ou contas a receber e não pode ser excluído")
+)
+
+// diasInadimplencia é o atraso, em uma conta ainda em aberto, a partir do qual o
+// cliente é classificado como inadimplente no extrato.
+const diasInadimplencia = 30
+
+// ObraFinder lista as obras contratadas por um cliente.
+type ObraFinder interface {
+	ListarPorClienteID(ctx context.Context, clienteID string) ([]*obras.Obra, error)
+}
+
+// ContaReceberFinder lista as contas a receber de um cliente.
+type ContaReceberFinder interface {
+	ListarPorClienteID(ctx context.Context, clienteID string) ([]*financeiro.ContaReceber, error)
+}
+
+// Service encapsula o cadastro de clientes e o extrato de relacionamento.
+type Service struct {
+	clienteRepo clientes.ClienteRepository
+	obraFinder  ObraFinder
+	contaFinder ContaReceberFinder
+	logger      *slog.Logger
+}
+
+func NovoServico(
+	clienteRepo clientes.ClienteRepository,
+	obraFinder ObraFinder,
+	contaFinder ContaReceberFinder,
+	logger *slog.Logger,
+) *Service {
+	return &Service{
+		clienteRepo: clienteRepo,
+		obraFinder:  obraFinder,
+		contaFinder: contaFinder,
+		logger:      logger.With("service", "Clientes"),
+	}
+}
+
+func (s *Service) CadastrarCliente(ctx context.Context, input dto.ClienteInput) (*clientes.Cliente, error) {
+	const op = "service.clientes.CadastrarCliente"
+
+	cliente, err := clientes.NovoCliente(uuid.NewString(), clientes.TipoPessoa(input.Tipo), input.Nome, input.Documento, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := aplicarDadosComplementares(cliente, input); err != nil {
+		return nil, err
+	}
+	if err := s.verificarDocumentoUnico(ctx, cliente); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.clienteRepo.Salvar(ctx, cliente); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s.logger.InfoContext(ctx, "cliente cadastrado", "clienteId", cliente.ID, "tipo", cliente.Tipo)
+	return cliente, nil
+}
+
+// AtualizarCliente substitui os dados do cliente. Clientes vindos da migração dos
+// nomes livres ficam com o cadastro completo a partir da primeira atualização.
+func (s *Service) AtualizarCliente(ctx context.Context, id string, input dto.ClienteInput) (*clientes.Cliente, error) {
+	const op = "service.clientes.AtualizarCliente"
+
+	cliente, err := s.clienteRepo.BuscarPorID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := cliente.Identificar(clientes.TipoPessoa(input.Tipo), input.Nome, input.Documento, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := aplicarDadosComplementares(cliente, input); err != nil {
+		return nil, err
+	}
+	if err := s.verificarDocumentoUnico(ctx, cliente); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.clienteRepo.Atualizar(ctx, cliente); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return cliente, nil
+}
+
+func (s *Service) BuscarCliente(ctx context.Context, id string) (*clientes.Cliente, error) {
+	const op = "service.clientes.BuscarCliente"
+	cliente, err := s.clienteRepo.BuscarPorID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return cliente, nil
+}
+
+// ResolverCliente busca o cliente pelo ID ou, na falta dele, pelo nome normalizado.
+// Obras e contas a receber só apontam para clientes cadastrados.
+func (s *Service) ResolverCliente(ctx context.Context, clienteID, nome string) (*clientes.Cliente, error) {
+	const op = "service.clientes.ResolverCliente"
+
+	var cliente *clientes.Cliente
+	var err error
+	switch {
+	case strings.TrimSpace(clienteID) != "":
+		cliente, err = s.clienteRepo.BuscarPorID(ctx, clienteID)
+	case strings.TrimSpace(nome) != "":
+		cliente, err = s.clienteRepo.BuscarPorNome(ctx, nome)
+	default:
+		return nil, fmt.Errorf("%s: %w: informe o clienteId", op, clientes.ErrClienteNaoCadastrado)
+	}
+	if errors.Is(err, postgres.ErrNaoEncontrado) {
+		return nil, fmt.Errorf("%s: %w", op, clientes.ErrClienteNaoCadastrado)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return cliente, nil
+}
+
+func (s *Service) ListarClientes(ctx context.Context, busca string, filtros common.ListarFiltros) (*common.RespostaPaginada[*clientes.Cliente], error) {
+	const op = "service.clientes.ListarClientes"
+
+	lista, paginacao, err := s.clienteRepo.Listar(ctx, busca, filtros)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &common.RespostaPaginada[*clientes.Cliente]{
+		Dados:     lista,
+		Paginacao: *paginacao,
+	}, nil
+}
+
+// DeletarCliente exclui um cliente sem obras nem contas a receber.
+func (s *Service) DeletarCliente(ctx context.Context, id string) error {
+	const op = "service.clientes.DeletarCliente"
+
+	vinculos, err := s.clienteRepo.ContarVinculos(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if vinculos > 0 {
+		return ErrClienteComVinculos
+	}
+	if err := s.clienteRepo.Deletar(ctx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// MesclarClientes incorpora o cliente duplicado ao cliente id: as obras e contas a
+// receber do duplicado passam para o sobrevivente, que herda os dados que não tinha,
+// e o duplicado é excluído. Usado para nomes migrados com grafias diferentes.
+func (s *Service) MesclarClientes(ctx context.Context, id string, input dto.MesclarClienteInput) (*clientes.Cliente, error) {
+	const op = "service.clientes.MesclarClientes"
+
+	if input.DuplicadoID == "" {
+		return nil, fmt.Errorf("%w: informe o duplicadoId", clientes.ErrMesclagemInvalida)
+	}
+	sobrevivente, err := s.clienteRepo.BuscarPorID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	duplicado, err := s.clienteRepo.BuscarPorID(ctx, input.DuplicadoID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: cliente duplicado: %w", op, err)
+	}
+	if err := sobrevivente.Absorver(duplicado, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.clienteRepo.Mesclar(ctx, sobrevivente, duplicado.ID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s.logger.InfoContext(ctx, "clientes mesclados", "clienteId", sobrevivente.ID, "duplicadoId", duplicado.ID)
+	return sobrevivente, nil
+}
+
+// ObterExtrato monta o extrato do cliente: obras, contas a receber e comportamento de pagamento.
+func (s *Service) ObterExtrato(ctx context.Context, id string) (*dto.ExtratoClienteDTO, error) {
+	const op = "service.clientes.ObterExtrato"
+
+	cliente, err := s.clienteRepo.BuscarPorID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	obrasCliente, err := s.obraFinder.ListarPorClienteID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao listar obras: %w", op, err)
+	}
+	contas, err := s.contaFinder.ListarPorClienteID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao listar contas a receber: %w", op, err)
+	}
+
+	return montarExtrato(cliente, obrasCliente, contas, time.Now()), nil
+}
+
+func (s *Service) verificarDocumentoUnico(ctx context.Context, cliente *clientes.Cliente) error {
+	existente, err := s.clienteRepo.BuscarPorDocumento(ctx, *cliente.Documento)
+	if errors.Is(err, postgres.ErrNaoEncontrado) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existente.ID != cliente.ID {
+		return ErrDocumentoDuplicado
+	}
+	return nil
+}
+
+func aplicarDadosComplementares(cliente *clientes.Cliente, input dto.ClienteInput) error {
+	if err := cliente.DefinirContatos(input.Contatos); err != nil {
+		return err
+	}
+	if err := cliente.DefinirEnderecoCobranca(input.EnderecoCobranca); err != nil {
+		return err
+	}
+	cliente.Email = input.Email
+	cliente.Telefone = input.Telefone
+	cliente.Observacoes = input.Observacoes
+	return nil
+}
+
+// montarExtrato consolida obras e contas do cliente na data de referência hoje.
+// Canceladas entram na listagem mas ficam fora dos totais e do comportamento.
+func montarExtrato(cliente *clientes.Cliente, obrasCliente []*obras.Obra, contas []*financeiro.ContaReceber, hoje time.Time) *dto.ExtratoClienteDTO {
+	extrato := &dto.ExtratoClienteDTO{
+		Cliente:  cliente,
+		Obras:    make([]dto.ObraExtratoDTO, 0, len(obrasCliente)),
+		Contas:   make([]dto.ContaExtratoDTO, 0, len(contas)),
+		GeradoEm: hoje,
+	}
+
+	porObra := make(map[string]*dto.ObraExtratoDTO, len(obrasCliente))
+	for _, o := range obrasCliente {
+		extrato.Obras = append(extrato.Obras, dto.ObraExtratoDTO{
+			ObraID:          o.ID,
+			Nome:            o.Nome,
+			Status:          string(o.Status),
+			ValorContratado: o.ValorContratoTotal,
+		})
+		extrato.Resumo.ValorContratado += o.ValorContratoTotal
+	}
+	for i := range extrato.Obras {
+		porObra[extrato.Obras[i].ObraID] = &extrato.Obras[i]
+	}
+	extrato.Resumo.TotalObras = len(extrato.Obras)
+
+	resumo := &extrato.Resumo
+	comp := &extrato.Comportamento
+	somaAtrasos := 0
+	for _, c := range contas {
+		linha := dto.ContaExtratoDTO{
+			ContaID:         c.ID,
+			ObraID:          c.ObraID,
+			Descricao:       c.Descricao,
+			Status:          c.Status,
+			ValorOriginal:   c.ValorOriginal,
+			ValorRecebido:   c.ValorRecebido,
+			ValorSaldo:      c.ValorSaldo(),
+			DataVencimento:  c.DataVencimento,
+			DataRecebimento: c.DataRecebimento,
+		}
+		if c.Status == financeiro.StatusContaReceberCancelado {
+			extrato.Contas = append(extrato.Contas, linha)
+			continue
+		}
+
+		resumo.TotalFaturado += c.ValorOriginal
+		resumo.TotalRecebido += c.ValorRecebido
+		if o, ok := porObra[derefString(c.ObraID)]; ok {
+			o.TotalFaturado += c.ValorOriginal
+			o.TotalRecebido += c.ValorRecebido
+			o.SaldoEmAberto += c.ValorSaldo()
+		}
+
+		if c.EstaEmAberto() {
+			resumo.ContasEmAberto++
+			resumo.SaldoEmAberto += c.ValorSaldo()
+			linha.DiasAtraso = diasEntre(c.DataVencimento, hoje)
+			if linha.DiasAtraso > 0 {
+				resumo.ContasVencidas++
+				resumo.SaldoVencido += c.ValorSaldo()
+			}
+		} else if c.DataRecebimento != nil {
+			linha.DiasAtraso = diasEntre(c.DataVencimento, *c.DataRecebimento)
+			comp.ContasQuitadas++
+			if linha.DiasAtraso > 0 {
+				comp.QuitadasComAtraso++
+				somaAtrasos += linha.DiasAtraso
+			} else {
+				comp.QuitadasEmDia++
+			}
+		}
+		if linha.DiasAtraso > comp.AtrasoMaximoDias {
+			comp.AtrasoMaximoDias = linha.DiasAtraso
+		}
+		extrato.Contas = append(extrato.Contas, linha)
+	}
+
+	if resumo.TotalFaturado > 0 {
+		resumo.PercentualRecebido = resumo.TotalRecebido / resumo.TotalFaturado * 100
+	}
+	comp.ContasVencidas = resumo.ContasVencidas
+	if comp.ContasQuitadas > 0 {
+		comp.PercentualEmDia = float64(comp.QuitadasEmDia) / float64(comp.ContasQuitadas) * 100
+	}
+	if comp.QuitadasComAtraso > 0 {
+		comp.AtrasoMedioDias = float64(somaAtrasos) / float64(comp.QuitadasComAtraso)
+	}
+	comp.Classificacao = classificarPagador(extrato)
+	return extrato
+}
+
+func classificarPagador(extrato *dto.ExtratoClienteDTO) string {
+	comp := extrato.Comportamento
+	for _, c := range extrato.Contas {
+		if c.Status != financeiro.StatusContaReceberCancelado && c.Status != financeiro.StatusContaReceberRecebido &&
+			c.DiasAtraso > diasInadimplencia {
+			return "INADIMPLENTE"
+		}
+	}
+	switch {
+	case comp.ContasQuitadas == 0 && comp.ContasVencidas == 0:
+		return "SEM_HISTORICO"
+	case comp.QuitadasComAtraso > 0 || comp.ContasVencidas > 0:
+		return "ATRASOS_EVENTUAIS"
+	default:
+		return "PONTUAL"
+	}
+}
+
+// diasEntre conta os dias corridos de vencimento até ref (negativo se ref for anterior).
+func diasEntre(vencimento, ref time.Time) int {
+	v := time.Date(vencimento.Year(), vencimento.Month(), vencimento.Day(), 0, 0, 0, 0, time.UTC)
+	r := time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, time.UTC)
+	return int(r.Sub(v).Hours() / 24)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
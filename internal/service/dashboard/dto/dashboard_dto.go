@@ -17,13 +17,23 @@ type ResumoGeralDTO struct {
 	PercentualAtraso        float64 `json:"percentualAtraso" db:"-"`
 }
 
-// AlertasDTO representa alertas e notificações importantes
+// AlertasDTO resume os alertas que exigem atenção (ativos ou reconhecidos)
 type AlertasDTO struct {
-	ObrasComAtraso       []string `json:"obrasComAtraso" db:"-"`
-	FornecedoresInativos []string `json:"fornecedoresInativos" db:"-"`
-	FuncionariosSemApontamento []string `json:"funcionariosSemApontamento" db:"-"`
-	OrcamentosPendentes  int      `json:"orcamentosPendentes" db:"-"`
-	PagamentosPendentes  int      `json:"pagamentosPendentes" db:"-"`
+	Total      int              `json:"total" db:"-"`
+	Criticos   int              `json:"criticos" db:"-"`
+	PorTipo    map[string]int   `json:"porTipo" db:"-"`
+	Principais []*AlertaItemDTO `json:"principais" db:"-"` // Os mais graves primeiro, limitado a LimiteAlertasDashboard
+}
+
+// AlertaItemDTO representa um alerta na lista do dashboard
+type AlertaItemDTO struct {
+	ID          string    `json:"id" db:"-"`
+	Tipo        string    `json:"tipo" db:"-"`
+	Titulo      string    `json:"titulo" db:"-"`
+	Severidade  string    `json:"severidade" db:"-"`
+	Status      string    `json:"status" db:"-"`
+	ObraID      *string   `json:"obraId,omitempty" db:"-"`
+	DetectadoEm time.Time `json:"detectadoEm" db:"-"`
 }
 
 // DashboardGeralDTO representa o dashboard completo da aplicação
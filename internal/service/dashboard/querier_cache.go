@@ -0,0 +1,241 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/dashboard"
+	"github.com/luiszkm/masterCostrutora/internal/events"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus"
+	"github.com/luiszkm/masterCostrutora/internal/service/dashboard/dto"
+	"github.com/luiszkm/masterCostrutora/pkg/cache"
+	"github.com/luiszkm/masterCostrutora/pkg/logging"
+	"github.com/luiszkm/masterCostrutora/pkg/metrics"
+)
+
+// Seções do cache do dashboard. Cada consulta pertence a uma seção e as invalidações
+// são feitas por seção.
+const (
+	SecaoFinanceiro   = "financeiro"
+	SecaoObras        = "obras"
+	SecaoFuncionarios = "funcionarios"
+	SecaoFornecedores = "fornecedores"
+	SecaoGeral        = "geral" // resumo geral e alertas, que cruzam todas as seções
+)
+
+const prefixoChaveCache = "dashboard:"
+
+// ttlPorSecao define por quanto tempo cada seção pode ser servida do cache.
+// Mudanças relevantes invalidam antes disso via eventos.
+var ttlPorSecao = map[string]time.Duration{
+	SecaoFinanceiro:   5 * time.Minute,
+	SecaoObras:        5 * time.Minute,
+	SecaoFuncionarios: 10 * time.Minute,
+	SecaoFornecedores: 30 * time.Minute,
+	SecaoGeral:        2 * time.Minute,
+}
+
+// ttlPeriodoFechado é usado quando o período consultado termina antes de hoje.
+const ttlPeriodoFechado = time.Hour
+
+// secoesPorEvento indica quais seções ficam desatualizadas a cada evento.
+// orcamento:status_atualizado é tratado pelo ObrasEventHandler.
+var secoesPorEvento = map[string][]string{
+	events.OrcamentoExcluido:                {SecaoFinanceiro, SecaoFornecedores, SecaoObras, SecaoGeral},
+	events.ContaReceberCriada:               {SecaoFinanceiro, SecaoGeral},
+	events.ContaReceberPaga:                 {SecaoFinanceiro, SecaoGeral},
+	events.ContaReceberVencida:              {SecaoFinanceiro, SecaoGeral},
+	events.MovimentacaoFinanceiraRegistrada: {SecaoFinanceiro, SecaoGeral},
+	events.ObraContratoDefinido:             {SecaoFinanceiro, SecaoObras, SecaoGeral},
+	events.CronogramaRecebimentoCriado:      {SecaoFinanceiro, SecaoGeral},
+	events.EtapaRecebimentoVencida:          {SecaoFinanceiro, SecaoGeral},
+	events.RecebimentoRealizado:             {SecaoFinanceiro, SecaoObras, SecaoGeral},
+	events.ObraStatusAlterado:               {SecaoObras, SecaoFinanceiro, SecaoGeral},
+	events.ApontamentoAprovado:              {SecaoFuncionarios, SecaoFinanceiro, SecaoGeral},
+	events.PagamentoApontamentoRealizado:    {SecaoFuncionarios, SecaoFinanceiro, SecaoGeral},
+}
+
+// QuerierComCache decora um dashboard.Querier guardando os resultados em um cache.Backend.
+// Falhas do backend nunca quebram o dashboard: a consulta segue para o banco.
+type QuerierComCache struct {
+	querier    dashboard.Querier
+	backend    cache.Backend
+	consultas  *metrics.CounterVec
+	invalidado *metrics.CounterVec
+	dashLogger *logging.DashboardLogger
+}
+
+// NovoQuerierComCache registra os contadores dashboard_cache_* e retorna o decorador.
+func NovoQuerierComCache(querier dashboard.Querier, backend cache.Backend, registro *metrics.Registro, dashLogger *logging.DashboardLogger) *QuerierComCache {
+	return &QuerierComCache{
+		querier: querier,
+		backend: backend,
+		consultas: registro.NovoCounterVec("dashboard_cache_requests_total",
+			"Consultas ao cache do dashboard por resultado (hit, miss, erro).", "secao", "resultado"),
+		invalidado: registro.NovoCounterVec("dashboard_cache_invalidations_total",
+			"Invalidações do cache do dashboard.", "secao"),
+		dashLogger: dashLogger,
+	}
+}
+
+// Registrar assina os eventos que desatualizam o dashboard.
+func (q *QuerierComCache) Registrar(eventBus *bus.EventBus) {
+	for nome := range secoesPorEvento {
+		eventBus.Subscrever(nome, q.HandleEvento)
+	}
+}
+
+// HandleEvento invalida as seções afetadas pelo evento recebido.
+func (q *QuerierComCache) HandleEvento(ctx context.Context, evento bus.Evento) {
+	secoes, ok := secoesPorEvento[evento.Nome]
+	if !ok {
+		return
+	}
+	q.InvalidarSecoes(ctx, secoes...)
+}
+
+// InvalidarSecoes remove do cache todas as consultas das seções informadas.
+func (q *QuerierComCache) InvalidarSecoes(ctx context.Context, secoes ...string) {
+	for _, secao := range secoes {
+		if err := q.backend.RemoverPorPrefixo(ctx, prefixoChaveCache+secao+":"); err != nil {
+			q.dashLogger.LogDashboardError(ctx, secao, "cache_invalidate", err, nil)
+			continue
+		}
+		q.invalidado.Inc(secao)
+		q.dashLogger.LogDashboardCache(ctx, secao, "invalidate", false, nil)
+	}
+}
+
+func ttlPara(secao string, dataFim time.Time) time.Duration {
+	if !dataFim.IsZero() {
+		agora := time.Now()
+		hoje := time.Date(agora.Year(), agora.Month(), agora.Day(), 0, 0, 0, 0, agora.Location())
+		if dataFim.Before(hoje) {
+			return ttlPeriodoFechado
+		}
+	}
+	return ttlPorSecao[secao]
+}
+
+// periodo normaliza o período para a chave. Os valores padrão do serviço usam time.Now(),
+// por isso a granularidade é o dia.
+func periodo(dataInicio, dataFim time.Time) string {
+	return dataInicio.Format(time.DateOnly) + "_" + dataFim.Format(time.DateOnly)
+}
+
+func comCache[T any](ctx context.Context, q *QuerierComCache, secao, consulta, parametros string, ttl time.Duration, fn func() (T, error)) (T, error) {
+	chave := fmt.Sprintf("%s%s:%s:%s", prefixoChaveCache, secao, consulta, parametros)
+
+	if dados, ok, err := q.backend.Obter(ctx, chave); err != nil {
+		q.consultas.Inc(secao, "erro")
+		q.dashLogger.Warn(ctx, "falha ao ler cache do dashboard", map[string]interface{}{"chave": chave, "erro": err.Error()})
+	} else if ok {
+		var res T
+		if err := json.Unmarshal(dados, &res); err == nil {
+			q.consultas.Inc(secao, "hit")
+			return res, nil
+		}
+		// Formato antigo ou corrompido: trata como miss e regrava
+		q.consultas.Inc(secao, "miss")
+	} else {
+		q.consultas.Inc(secao, "miss")
+	}
+
+	res, err := fn()
+	if err != nil {
+		return res, err
+	}
+	if dados, err := json.Marshal(res); err == nil {
+		if err := q.backend.Definir(ctx, chave, dados, ttl); err != nil {
+			q.dashLogger.Warn(ctx, "falha ao gravar cache do dashboard", map[string]interface{}{"chave": chave, "erro": err.Error()})
+		}
+	}
+	return res, nil
+}
+
+func (q *QuerierComCache) ObterFluxoCaixa(ctx context.Context, dataInicio, dataFim time.Time) ([]*dto.FluxoCaixaDTO, error) {
+	return comCache(ctx, q, SecaoFinanceiro, "ObterFluxoCaixa", periodo(dataInicio, dataFim), ttlPara(SecaoFinanceiro, dataFim), func() ([]*dto.FluxoCaixaDTO, error) {
+		return q.querier.ObterFluxoCaixa(ctx, dataInicio, dataFim)
+	})
+}
+
+func (q *QuerierComCache) ObterFluxoCaixaResumo(ctx context.Context, dataInicio, dataFim time.Time) (*dto.FluxoCaixaResumoDTO, error) {
+	return comCache(ctx, q, SecaoFinanceiro, "ObterFluxoCaixaResumo", periodo(dataInicio, dataFim), ttlPara(SecaoFinanceiro, dataFim), func() (*dto.FluxoCaixaResumoDTO, error) {
+		return q.querier.ObterFluxoCaixaResumo(ctx, dataInicio, dataFim)
+	})
+}
+
+func (q *QuerierComCache) ObterDistribuicaoDespesas(ctx context.Context, dataInicio, dataFim time.Time) (*dto.DistribuicaoDespesasDTO, error) {
+	return comCache(ctx, q, SecaoFinanceiro, "ObterDistribuicaoDespesas", periodo(dataInicio, dataFim), ttlPara(SecaoFinanceiro, dataFim), func() (*dto.DistribuicaoDespesasDTO, error) {
+		return q.querier.ObterDistribuicaoDespesas(ctx, dataInicio, dataFim)
+	})
+}
+
+func (q *QuerierComCache) ObterProgressoObras(ctx context.Context) (*dto.ProgressoObrasDTO, error) {
+	return comCache(ctx, q, SecaoObras, "ObterProgressoObras", "", ttlPara(SecaoObras, time.Time{}), func() (*dto.ProgressoObrasDTO, error) {
+		return q.querier.ObterProgressoObras(ctx)
+	})
+}
+
+func (q *QuerierComCache) ObterDistribuicaoObras(ctx context.Context) (*dto.DistribuicaoObrasDTO, error) {
+	return comCache(ctx, q, SecaoObras, "ObterDistribuicaoObras", "", ttlPara(SecaoObras, time.Time{}), func() (*dto.DistribuicaoObrasDTO, error) {
+		return q.querier.ObterDistribuicaoObras(ctx)
+	})
+}
+
+func (q *QuerierComCache) ObterTendenciasObras(ctx context.Context, mesesAtras int) (*dto.TendenciasObrasDTO, error) {
+	return comCache(ctx, q, SecaoObras, "ObterTendenciasObras", fmt.Sprint(mesesAtras), ttlPara(SecaoObras, time.Time{}), func() (*dto.TendenciasObrasDTO, error) {
+		return q.querier.ObterTendenciasObras(ctx, mesesAtras)
+	})
+}
+
+func (q *QuerierComCache) ObterProdutividadeFuncionarios(ctx context.Context) (*dto.ProdutividadeFuncionariosDTO, error) {
+	return comCache(ctx, q, SecaoFuncionarios, "ObterProdutividadeFuncionarios", "", ttlPara(SecaoFuncionarios, time.Time{}), func() (*dto.ProdutividadeFuncionariosDTO, error) {
+		return q.querier.ObterProdutividadeFuncionarios(ctx)
+	})
+}
+
+func (q *QuerierComCache) ObterCustosMaoObra(ctx context.Context, dataInicio, dataFim time.Time) (*dto.CustosMaoObraDTO, error) {
+	return comCache(ctx, q, SecaoFuncionarios, "ObterCustosMaoObra", periodo(dataInicio, dataFim), ttlPara(SecaoFuncionarios, dataFim), func() (*dto.CustosMaoObraDTO, error) {
+		return q.querier.ObterCustosMaoObra(ctx, dataInicio, dataFim)
+	})
+}
+
+func (q *QuerierComCache) ObterTopFuncionarios(ctx context.Context, limite int) (*dto.TopFuncionariosDTO, error) {
+	return comCache(ctx, q, SecaoFuncionarios, "ObterTopFuncionarios", fmt.Sprint(limite), ttlPara(SecaoFuncionarios, time.Time{}), func() (*dto.TopFuncionariosDTO, error) {
+		return q.querier.ObterTopFuncionarios(ctx, limite)
+	})
+}
+
+func (q *QuerierComCache) ObterFornecedoresPorCategoria(ctx context.Context) (*dto.FornecedoresPorCategoriaDTO, error) {
+	return comCache(ctx, q, SecaoFornecedores, "ObterFornecedoresPorCategoria", "", ttlPara(SecaoFornecedores, time.Time{}), func() (*dto.FornecedoresPorCategoriaDTO, error) {
+		return q.querier.ObterFornecedoresPorCategoria(ctx)
+	})
+}
+
+func (q *QuerierComCache) ObterTopFornecedores(ctx context.Context, limite int) (*dto.TopFornecedoresDTO, error) {
+	return comCache(ctx, q, SecaoFornecedores, "ObterTopFornecedores", fmt.Sprint(limite), ttlPara(SecaoFornecedores, time.Time{}), func() (*dto.TopFornecedoresDTO, error) {
+		return q.querier.ObterTopFornecedores(ctx, limite)
+	})
+}
+
+func (q *QuerierComCache) ObterGastosFornecedores(ctx context.Context, dataInicio, dataFim time.Time, limite int) (*dto.GastosFornecedoresDTO, error) {
+	parametros := fmt.Sprintf("%s:%d", periodo(dataInicio, dataFim), limite)
+	return comCache(ctx, q, SecaoFornecedores, "ObterGastosFornecedores", parametros, ttlPara(SecaoFornecedores, dataFim), func() (*dto.GastosFornecedoresDTO, error) {
+		return q.querier.ObterGastosFornecedores(ctx, dataInicio, dataFim, limite)
+	})
+}
+
+func (q *QuerierComCache) ObterEstatisticasGeraisFornecedores(ctx context.Context) (*dto.EstatisticasGeraisFornecedoresDTO, error) {
+	return comCache(ctx, q, SecaoFornecedores, "ObterEstatisticasGeraisFornecedores", "", ttlPara(SecaoFornecedores, time.Time{}), func() (*dto.EstatisticasGeraisFornecedoresDTO, error) {
+		return q.querier.ObterEstatisticasGeraisFornecedores(ctx)
+	})
+}
+
+func (q *QuerierComCache) ObterResumoGeral(ctx context.Context) (*dto.ResumoGeralDTO, error) {
+	return comCache(ctx, q, SecaoGeral, "ObterResumoGeral", "", ttlPara(SecaoGeral, time.Time{}), func() (*dto.ResumoGeralDTO, error) {
+		return q.querier.ObterResumoGeral(ctx)
+	})
+}
@@ -0,0 +1,120 @@
+package dashboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/dashboard"
+	"github.com/luiszkm/masterCostrutora/internal/service/dashboard/dto"
+	"github.com/luiszkm/masterCostrutora/pkg/metrics"
+)
+
+// QuerierComMetricas decora um dashboard.Querier registrando a duração de cada consulta.
+type QuerierComMetricas struct {
+	querier dashboard.Querier
+	duracao *metrics.HistogramVec
+}
+
+// NovoQuerierComMetricas registra o histograma dashboard_query_duration_seconds e retorna o decorador.
+func NovoQuerierComMetricas(querier dashboard.Querier, registro *metrics.Registro) *QuerierComMetricas {
+	return &QuerierComMetricas{
+		querier: querier,
+		duracao: registro.NovoHistogramVec("dashboard_query_duration_seconds",
+			"Duração das consultas do dashboard.", nil, "query", "resultado"),
+	}
+}
+
+func medir[T any](q *QuerierComMetricas, nome string, fn func() (T, error)) (T, error) {
+	inicio := time.Now()
+	res, err := fn()
+	resultado := "sucesso"
+	if err != nil {
+		resultado = "erro"
+	}
+	q.duracao.Observe(time.Since(inicio).Seconds(), nome, resultado)
+	return res, err
+}
+
+func (q *QuerierComMetricas) ObterFluxoCaixa(ctx context.Context, dataInicio, dataFim time.Time) ([]*dto.FluxoCaixaDTO, error) {
+	return medir(q, "ObterFluxoCaixa", func() ([]*dto.FluxoCaixaDTO, error) {
+		return q.querier.ObterFluxoCaixa(ctx, dataInicio, dataFim)
+	})
+}
+
+func (q *QuerierComMetricas) ObterFluxoCaixaResumo(ctx context.Context, dataInicio, dataFim time.Time) (*dto.FluxoCaixaResumoDTO, error) {
+	return medir(q, "ObterFluxoCaixaResumo", func() (*dto.FluxoCaixaResumoDTO, error) {
+		return q.querier.ObterFluxoCaixaResumo(ctx, dataInicio, dataFim)
+	})
+}
+
+func (q *QuerierComMetricas) ObterDistribuicaoDespesas(ctx context.Context, dataInicio, dataFim time.Time) (*dto.DistribuicaoDespesasDTO, error) {
+	return medir(q, "ObterDistribuicaoDespesas", func() (*dto.DistribuicaoDespesasDTO, error) {
+		return q.querier.ObterDistribuicaoDespesas(ctx, dataInicio, dataFim)
+	})
+}
+
+func (q *QuerierComMetricas) ObterProgressoObras(ctx context.Context) (*dto.ProgressoObrasDTO, error) {
+	return medir(q, "ObterProgressoObras", func() (*dto.ProgressoObrasDTO, error) {
+		return q.querier.ObterProgressoObras(ctx)
+	})
+}
+
+func (q *QuerierComMetricas) ObterDistribuicaoObras(ctx context.Context) (*dto.DistribuicaoObrasDTO, error) {
+	return medir(q, "ObterDistribuicaoObras", func() (*dto.DistribuicaoObrasDTO, error) {
+		return q.querier.ObterDistribuicaoObras(ctx)
+	})
+}
+
+func (q *QuerierComMetricas) ObterTendenciasObras(ctx context.Context, mesesAtras int) (*dto.TendenciasObrasDTO, error) {
+	return medir(q, "ObterTendenciasObras", func() (*dto.TendenciasObrasDTO, error) {
+		return q.querier.ObterTendenciasObras(ctx, mesesAtras)
+	})
+}
+
+func (q *QuerierComMetricas) ObterProdutividadeFuncionarios(ctx context.Context) (*dto.ProdutividadeFuncionariosDTO, error) {
+	return medir(q, "ObterProdutividadeFuncionarios", func() (*dto.ProdutividadeFuncionariosDTO, error) {
+		return q.querier.ObterProdutividadeFuncionarios(ctx)
+	})
+}
+
+func (q *QuerierComMetricas) ObterCustosMaoObra(ctx context.Context, dataInicio, dataFim time.Time) (*dto.CustosMaoObraDTO, error) {
+	return medir(q, "ObterCustosMaoObra", func() (*dto.CustosMaoObraDTO, error) {
+		return q.querier.ObterCustosMaoObra(ctx, dataInicio, dataFim)
+	})
+}
+
+func (q *QuerierComMetricas) ObterTopFuncionarios(ctx context.Context, limite int) (*dto.TopFuncionariosDTO, error) {
+	return medir(q, "ObterTopFuncionarios", func() (*dto.TopFuncionariosDTO, error) {
+		return q.querier.ObterTopFuncionarios(ctx, limite)
+	})
+}
+
+func (q *QuerierComMetricas) ObterFornecedoresPorCategoria(ctx context.Context) (*dto.FornecedoresPorCategoriaDTO, error) {
+	return medir(q, "ObterFornecedoresPorCategoria", func() (*dto.FornecedoresPorCategoriaDTO, error) {
+		return q.querier.ObterFornecedoresPorCategoria(ctx)
+	})
+}
+
+func (q *QuerierComMetricas) ObterTopFornecedores(ctx context.Context, limite int) (*dto.TopFornecedoresDTO, error) {
+	return medir(q, "ObterTopFornecedores", func() (*dto.TopFornecedoresDTO, error) {
+		return q.querier.ObterTopFornecedores(ctx, limite)
+	})
+}
+
+func (q *QuerierComMetricas) ObterGastosFornecedores(ctx context.Context, dataInicio, dataFim time.Time, limite int) (*dto.GastosFornecedoresDTO, error) {
+	return medir(q, "ObterGastosFornecedores", func() (*dto.GastosFornecedoresDTO, error) {
+		return q.querier.ObterGastosFornecedores(ctx, dataInicio, dataFim, limite)
+	})
+}
+
+func (q *QuerierComMetricas) ObterEstatisticasGeraisFornecedores(ctx context.Context) (*dto.EstatisticasGeraisFornecedoresDTO, error) {
+	return medir(q, "ObterEstatisticasGeraisFornecedores", func() (*dto.EstatisticasGeraisFornecedoresDTO, error) {
+		return q.querier.ObterEstatisticasGeraisFornecedores(ctx)
+	})
+}
+
+func (q *QuerierComMetricas) ObterResumoGeral(ctx context.Context) (*dto.ResumoGeralDTO, error) {
+	return medir(q, "ObterResumoGeral", func() (*dto.ResumoGeralDTO, error) {
+		return q.querier.ObterResumoGeral(ctx)
+	})
+}
@@ -6,22 +6,33 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/luiszkm/masterCostrutora/internal/domain/alertas"
 	"github.com/luiszkm/masterCostrutora/internal/domain/dashboard"
 	"github.com/luiszkm/masterCostrutora/internal/service/dashboard/dto"
 	"github.com/luiszkm/masterCostrutora/pkg/logging"
 )
 
+// LimiteAlertasDashboard é o número de alertas listados no resumo do dashboard
+const LimiteAlertasDashboard = 10
+
+// FonteAlertas fornece os alertas mantidos pelo motor de regras de alertas
+type FonteAlertas interface {
+	Listar(ctx context.Context, filtro alertas.FiltroAlertas) ([]*alertas.Alerta, error)
+}
+
 // Service representa o serviço de dashboard
 type Service struct {
 	querier       dashboard.Querier
+	alertas       FonteAlertas
 	logger        *slog.Logger
 	dashLogger    *logging.DashboardLogger
 }
 
 // NovoServicoDashboard cria uma nova instância do serviço de dashboard
-func NovoServicoDashboard(querier dashboard.Querier, logger *slog.Logger, dashLogger *logging.DashboardLogger) *Service {
+func NovoServicoDashboard(querier dashboard.Querier, fonteAlertas FonteAlertas, logger *slog.Logger, dashLogger *logging.DashboardLogger) *Service {
 	return &Service{
 		querier:    querier,
+		alertas:    fonteAlertas,
 		logger:     logger,
 		dashLogger: dashLogger,
 	}
@@ -80,14 +91,14 @@ func (s *Service) ObterDashboardCompleto(ctx context.Context, parametros dto.Par
 
 	// Obter alertas sempre
 	queryStart = time.Now()
-	dashboard.Alertas, err = s.querier.ObterAlertas(ctx)
+	dashboard.Alertas, err = s.ObterResumoAlertas(ctx)
 	if err != nil {
-		s.dashLogger.LogDashboardError(ctx, "geral", "ObterAlertas", err, map[string]interface{}{
+		s.dashLogger.LogDashboardError(ctx, "geral", "ObterResumoAlertas", err, map[string]interface{}{
 			"operation": op,
 		})
 		return nil, fmt.Errorf("%s: falha ao obter alertas: %w", op, err)
 	}
-	s.dashLogger.LogDashboardQuery(ctx, "geral", "ObterAlertas", time.Since(queryStart), len(dashboard.Alertas.ObrasComAtraso), nil)
+	s.dashLogger.LogDashboardQuery(ctx, "geral", "ObterResumoAlertas", time.Since(queryStart), dashboard.Alertas.Total, nil)
 	queryCount++
 
 	// Obter seções específicas ou todas se não especificado
@@ -280,4 +291,40 @@ func (s *Service) ObterFluxoCaixa(ctx context.Context, dataInicio, dataFim time.
 	}
 
 	return fluxo, nil
+}
+
+// ObterResumoAlertas conta os alertas que exigem atenção e lista os mais graves
+func (s *Service) ObterResumoAlertas(ctx context.Context) (*dto.AlertasDTO, error) {
+	const op = "service.dashboard.ObterResumoAlertas"
+
+	lista, err := s.alertas.Listar(ctx, alertas.FiltroAlertas{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	resumo := &dto.AlertasDTO{
+		Total:      len(lista),
+		PorTipo:    make(map[string]int),
+		Principais: make([]*dto.AlertaItemDTO, 0, min(len(lista), LimiteAlertasDashboard)),
+	}
+	for _, a := range lista {
+		resumo.PorTipo[string(a.Tipo)]++
+		if a.Severidade == alertas.SeveridadeCritica {
+			resumo.Criticos++
+		}
+		// A lista já vem ordenada por severidade e data de detecção
+		if len(resumo.Principais) < LimiteAlertasDashboard {
+			resumo.Principais = append(resumo.Principais, &dto.AlertaItemDTO{
+				ID:          a.ID,
+				Tipo:        string(a.Tipo),
+				Titulo:      a.Titulo,
+				Severidade:  string(a.Severidade),
+				Status:      string(a.Status),
+				ObraID:      a.ObraID,
+				DetectadoEm: a.DetectadoEm,
+			})
+		}
+	}
+
+	return resumo, nil
 }
\ No newline at end of file
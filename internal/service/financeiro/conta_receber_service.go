@@ -18,17 +18,20 @@ import (
 // ContaReceberService encapsula a lógica de negócio para contas a receber
 type ContaReceberService struct {
 	contaReceberRepo financeiro.ContaReceberRepository
+	clienteFinder    ClienteFinder
 	eventBus         EventPublisher
 	logger           *slog.Logger
 }
 
 func NovoContaReceberService(
 	contaReceberRepo financeiro.ContaReceberRepository,
+	clienteFinder ClienteFinder,
 	eventBus EventPublisher,
 	logger *slog.Logger,
 ) *ContaReceberService {
 	return &ContaReceberService{
 		contaReceberRepo: contaReceberRepo,
+		clienteFinder:    clienteFinder,
 		eventBus:         eventBus,
 		logger:           logger.With("service", "ContaReceber"),
 	}
@@ -38,11 +41,22 @@ func NovoContaReceberService(
 func (s *ContaReceberService) CriarConta(ctx context.Context, input dto.CriarContaReceberInput) (*dto.ContaReceberOutput, error) {
 	const op = "service.financeiro.conta_receber.CriarConta"
 
+	// A conta sempre aponta para um cliente cadastrado; o nome é copiado do cadastro
+	clienteID := ""
+	if input.ClienteID != nil {
+		clienteID = *input.ClienteID
+	}
+	cliente, err := s.clienteFinder.ResolverCliente(ctx, clienteID, input.Cliente)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	conta := &financeiro.ContaReceber{
 		ID:                      uuid.NewString(),
 		ObraID:                  input.ObraID,
 		CronogramaRecebimentoID: input.CronogramaRecebimentoID,
-		Cliente:                 input.Cliente,
+		ClienteID:               &cliente.ID,
+		Cliente:                 cliente.Nome,
 		TipoContaReceber:        input.TipoContaReceber,
 		Descricao:               input.Descricao,
 		ValorOriginal:           input.ValorOriginal,
@@ -58,6 +72,16 @@ func (s *ContaReceberService) CriarConta(ctx context.Context, input dto.CriarCon
 	if err := conta.Validar(); err != nil {
 		return nil, fmt.Errorf("%s: dados inválidos: %w", op, err)
 	}
+	if input.PercentualRetencao != nil && *input.PercentualRetencao > 0 {
+		if err := conta.AplicarRetencao(*input.PercentualRetencao); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if input.LinhaDigitavel != nil {
+		if err := conta.DefinirBoleto(*input.LinhaDigitavel, input.URLBoleto); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
 
 	// Salvar no banco
 	if err := s.contaReceberRepo.Salvar(ctx, nil, conta); err != nil {
@@ -69,6 +93,7 @@ func (s *ContaReceberService) CriarConta(ctx context.Context, input dto.CriarCon
 		ContaReceberID:          conta.ID,
 		ObraID:                  conta.ObraID,
 		CronogramaRecebimentoID: conta.CronogramaRecebimentoID,
+		ClienteID:               conta.ClienteID,
 		Cliente:                 conta.Cliente,
 		TipoContaReceber:        conta.TipoContaReceber,
 		Descricao:               conta.Descricao,
@@ -137,6 +162,25 @@ func (s *ContaReceberService) RegistrarRecebimento(ctx context.Context, contaID
 	return s.toOutput(conta), nil
 }
 
+// RegistrarBoleto grava a linha digitável e o link do boleto emitido para a conta,
+// que passam a aparecer no portal do cliente
+func (s *ContaReceberService) RegistrarBoleto(ctx context.Context, contaID string, input dto.RegistrarBoletoInput) (*dto.ContaReceberOutput, error) {
+	const op = "service.financeiro.conta_receber.RegistrarBoleto"
+
+	conta, err := s.contaReceberRepo.BuscarPorID(ctx, contaID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := conta.DefinirBoleto(input.LinhaDigitavel, input.URLBoleto); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.contaReceberRepo.Atualizar(ctx, conta); err != nil {
+		return nil, fmt.Errorf("%s: falha ao atualizar conta: %w", op, err)
+	}
+
+	return s.toOutput(conta), nil
+}
+
 // BuscarPorID busca uma conta por ID
 func (s *ContaReceberService) BuscarPorID(ctx context.Context, id string) (*dto.ContaReceberOutput, error) {
 	const op = "service.financeiro.conta_receber.BuscarPorID"
@@ -166,6 +210,85 @@ func (s *ContaReceberService) ListarPorObraID(ctx context.Context, obraID string
 	return outputs, nil
 }
 
+// ObterCaucao consolida a caução retida das contas da obra e o que já foi liberado
+func (s *ContaReceberService) ObterCaucao(ctx context.Context, obraID string) (*dto.CaucaoObraOutput, error) {
+	const op = "service.financeiro.conta_receber.ObterCaucao"
+
+	contas, err := s.contaReceberRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	resumo := financeiro.CalcularCaucao(contas)
+	output := &dto.CaucaoObraOutput{
+		ObraID:        obraID,
+		TotalRetido:   resumo.TotalRetido,
+		TotalLiberado: resumo.TotalLiberado,
+		TotalRecebido: resumo.TotalRecebido,
+		SaldoALiberar: resumo.SaldoALiberar,
+		Movimentos:    []dto.MovimentoCaucaoOutput{},
+	}
+	for _, c := range contas {
+		movimento := dto.MovimentoCaucaoOutput{
+			ContaReceberID: c.ID,
+			Descricao:      c.Descricao,
+			Status:         c.Status,
+			DataVencimento: c.DataVencimento,
+		}
+		switch {
+		case c.TipoContaReceber == financeiro.TipoContaReceberCaucao:
+			movimento.Tipo = "LIBERACAO"
+			movimento.Valor = c.ValorOriginal
+		case c.ValorRetido > 0:
+			movimento.Tipo = "RETENCAO"
+			movimento.Valor = c.ValorRetido
+		default:
+			continue
+		}
+		output.Movimentos = append(output.Movimentos, movimento)
+	}
+	return output, nil
+}
+
+// LiberarCaucao lança o saldo da caução da obra como uma conta a receber do tipo CAUCAO.
+// Retorna nil quando não há saldo, o que torna a operação segura para repetir.
+func (s *ContaReceberService) LiberarCaucao(ctx context.Context, obraID, obraNome string) (*dto.ContaReceberOutput, error) {
+	const op = "service.financeiro.conta_receber.LiberarCaucao"
+
+	contas, err := s.contaReceberRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	resumo := financeiro.CalcularCaucao(contas)
+	if resumo.SaldoALiberar <= 0 {
+		return nil, nil
+	}
+
+	// O cliente da liberação é o mesmo das contas que sofreram a retenção
+	var origem *financeiro.ContaReceber
+	for _, c := range contas {
+		if c.ValorRetido > 0 && c.Status != financeiro.StatusContaReceberCancelado {
+			origem = c
+		}
+	}
+
+	conta, err := s.CriarConta(ctx, dto.CriarContaReceberInput{
+		ObraID:           &obraID,
+		ClienteID:        origem.ClienteID,
+		Cliente:          origem.Cliente,
+		TipoContaReceber: financeiro.TipoContaReceberCaucao,
+		Descricao:        obraNome + " - Liberação da caução contratual",
+		ValorOriginal:    resumo.SaldoALiberar,
+		DataVencimento:   time.Now().AddDate(0, 0, financeiro.DiasLiberacaoCaucao),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "caução da obra liberada", "obra_id", obraID, "conta_id", conta.ID, "valor", conta.ValorOriginal)
+	return conta, nil
+}
+
 // ListarVencidas lista contas vencidas
 func (s *ContaReceberService) ListarVencidas(ctx context.Context) ([]*dto.ContaReceberOutput, error) {
 	const op = "service.financeiro.conta_receber.ListarVencidas"
@@ -251,6 +374,25 @@ func (s *ContaReceberService) VerificarContasVencidas(ctx context.Context) error
 	return nil
 }
 
+// IniciarVerificacaoVencimentos marca as contas vencidas na partida e a cada intervalo,
+// até que o contexto seja cancelado. Cada conta gera o evento de vencimento uma única vez.
+func (s *ContaReceberService) IniciarVerificacaoVencimentos(ctx context.Context, intervalo time.Duration) {
+	ticker := time.NewTicker(intervalo)
+	defer ticker.Stop()
+
+	for {
+		if err := s.VerificarContasVencidas(ctx); err != nil {
+			s.logger.ErrorContext(ctx, "falha na verificação periódica de contas vencidas", "erro", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // ObterResumo obtém resumo das contas a receber
 func (s *ContaReceberService) ObterResumo(ctx context.Context, filtros dto.FiltrosContaReceberInput) (*dto.ResumoContasReceberOutput, error) {
 	const op = "service.financeiro.conta_receber.ObterResumo"
@@ -297,11 +439,13 @@ func (s *ContaReceberService) toOutput(conta *financeiro.ContaReceber) *dto.Cont
 		ID:                      conta.ID,
 		ObraID:                  conta.ObraID,
 		CronogramaRecebimentoID: conta.CronogramaRecebimentoID,
+		ClienteID:               conta.ClienteID,
 		Cliente:                 conta.Cliente,
 		TipoContaReceber:        conta.TipoContaReceber,
 		Descricao:               conta.Descricao,
 		ValorOriginal:           conta.ValorOriginal,
 		ValorRecebido:           conta.ValorRecebido,
+		ValorRetido:             conta.ValorRetido,
 		ValorSaldo:              conta.ValorSaldo(),
 		PercentualRecebido:      conta.PercentualRecebido(),
 		DataVencimento:          conta.DataVencimento,
@@ -310,6 +454,8 @@ func (s *ContaReceberService) toOutput(conta *financeiro.ContaReceber) *dto.Cont
 		FormaPagamento:          conta.FormaPagamento,
 		Observacoes:             conta.Observacoes,
 		NumeroDocumento:         conta.NumeroDocumento,
+		LinhaDigitavel:          conta.LinhaDigitavel,
+		URLBoleto:               conta.URLBoleto,
 		EstaVencido:             conta.EstaVencido(),
 		DiasVencimento:          conta.DiasVencimento(),
 		CreatedAt:               conta.CreatedAt,
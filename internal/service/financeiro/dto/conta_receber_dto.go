@@ -6,12 +6,16 @@ import "time"
 type CriarContaReceberInput struct {
 	ObraID                  *string    `json:"obraId,omitempty"`
 	CronogramaRecebimentoID *string    `json:"cronogramaRecebimentoId,omitempty"`
-	Cliente                 string     `json:"cliente" validate:"required"`
+	ClienteID               *string    `json:"clienteId,omitempty"`
+	Cliente                 string     `json:"cliente,omitempty"` // Usado para localizar o cliente quando não há clienteId
 	TipoContaReceber        string     `json:"tipoContaReceber" validate:"required,oneof=OBRA SERVICO OUTROS"`
 	Descricao               string     `json:"descricao" validate:"required"`
-	ValorOriginal           float64    `json:"valorOriginal" validate:"required,gt=0"`
+	ValorOriginal           float64    `json:"valorOriginal" validate:"required,gt=0"` // Valor bruto quando há retenção
+	PercentualRetencao      *float64   `json:"percentualRetencao,omitempty"`           // Caução a reter do valor bruto
 	DataVencimento          time.Time  `json:"dataVencimento" validate:"required"`
 	NumeroDocumento         *string    `json:"numeroDocumento,omitempty"`
+	LinhaDigitavel          *string    `json:"linhaDigitavel,omitempty"`
+	URLBoleto               *string    `json:"urlBoleto,omitempty"`
 }
 
 // AtualizarContaReceberInput representa o input para atualizar uma conta a receber
@@ -33,16 +37,24 @@ type RegistrarRecebimentoContaInput struct {
 	Observacoes     *string `json:"observacoes,omitempty"`
 }
 
+// RegistrarBoletoInput representa o boleto emitido para uma conta a receber
+type RegistrarBoletoInput struct {
+	LinhaDigitavel string  `json:"linhaDigitavel" validate:"required"`
+	URLBoleto      *string `json:"urlBoleto,omitempty"`
+}
+
 // ContaReceberOutput representa o output de uma conta a receber
 type ContaReceberOutput struct {
 	ID                      string     `json:"id"`
 	ObraID                  *string    `json:"obraId,omitempty"`
 	CronogramaRecebimentoID *string    `json:"cronogramaRecebimentoId,omitempty"`
+	ClienteID               *string    `json:"clienteId,omitempty"`
 	Cliente                 string     `json:"cliente"`
 	TipoContaReceber        string     `json:"tipoContaReceber"`
 	Descricao               string     `json:"descricao"`
 	ValorOriginal           float64    `json:"valorOriginal"`
 	ValorRecebido           float64    `json:"valorRecebido"`
+	ValorRetido             float64    `json:"valorRetido"`
 	ValorSaldo              float64    `json:"valorSaldo"`
 	PercentualRecebido      float64    `json:"percentualRecebido"`
 	DataVencimento          time.Time  `json:"dataVencimento"`
@@ -51,6 +63,8 @@ type ContaReceberOutput struct {
 	FormaPagamento          *string    `json:"formaPagamento,omitempty"`
 	Observacoes             *string    `json:"observacoes,omitempty"`
 	NumeroDocumento         *string    `json:"numeroDocumento,omitempty"`
+	LinhaDigitavel          *string    `json:"linhaDigitavel,omitempty"`
+	URLBoleto               *string    `json:"urlBoleto,omitempty"`
 	EstaVencido             bool       `json:"estaVencido"`
 	DiasVencimento          int        `json:"diasVencimento"`
 	CreatedAt               time.Time  `json:"createdAt"`
@@ -78,4 +92,24 @@ type ResumoContasReceberOutput struct {
 	ContasVencidas        int     `json:"contasVencidas"`
 	ContasRecebidas       int     `json:"contasRecebidas"`
 	PercentualRecebimento float64 `json:"percentualRecebimento"`
-}
\ No newline at end of file
+}
+
+// CaucaoObraOutput é a posição da caução contratual retida de uma obra
+type CaucaoObraOutput struct {
+	ObraID        string                  `json:"obraId"`
+	TotalRetido   float64                 `json:"totalRetido"`
+	TotalLiberado float64                 `json:"totalLiberado"`
+	TotalRecebido float64                 `json:"totalRecebido"`
+	SaldoALiberar float64                 `json:"saldoALiberar"`
+	Movimentos    []MovimentoCaucaoOutput `json:"movimentos"`
+}
+
+// MovimentoCaucaoOutput é uma retenção em uma conta da obra ou a liberação da caução
+type MovimentoCaucaoOutput struct {
+	ContaReceberID string    `json:"contaReceberId"`
+	Tipo           string    `json:"tipo"` // RETENCAO ou LIBERACAO
+	Descricao      string    `json:"descricao"`
+	Valor          float64   `json:"valor"`
+	Status         string    `json:"status"`
+	DataVencimento time.Time `json:"dataVencimento"`
+}
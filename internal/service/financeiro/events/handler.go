@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
 	"github.com/luiszkm/masterCostrutora/internal/events"
 	"github.com/luiszkm/masterCostrutora/internal/service/financeiro/dto"
 	"github.com/luiszkm/masterCostrutora/internal/platform/bus"
@@ -15,6 +16,7 @@ import (
 // ContaReceberService interface para o service de contas a receber
 type ContaReceberService interface {
 	CriarConta(ctx context.Context, input dto.CriarContaReceberInput) (*dto.ContaReceberOutput, error)
+	LiberarCaucao(ctx context.Context, obraID, obraNome string) (*dto.ContaReceberOutput, error)
 }
 
 // ContaPagarService interface para o service de contas a pagar
@@ -56,19 +58,32 @@ func (h *FinanceiroEventHandler) HandleCronogramaRecebimentoCriado(ctx context.C
 		"quantidade_etapas", payload.QuantidadeEtapas,
 		"valor_total", payload.ValorTotalPrevisto)
 
+	// Eventos sem o detalhe das parcelas dividem o valor igualmente entre as etapas
+	parcelas := payload.Parcelas
+	if len(parcelas) == 0 {
+		for i, cronogramaID := range payload.CronogramasIds {
+			parcelas = append(parcelas, events.ParcelaCronogramaPayload{
+				CronogramaID:   cronogramaID,
+				Descricao:      "Etapa " + string(rune('1'+i)),
+				Valor:          payload.ValorTotalPrevisto / float64(payload.QuantidadeEtapas),
+				DataVencimento: payload.PrimeiroVencimento,
+			})
+		}
+	}
+
 	// Para cada cronograma criado, criar uma conta a receber correspondente
-	for i, cronogramaID := range payload.CronogramasIds {
-		// Calcular valor proporcional (assumindo divisão igual por etapa)
-		valorEtapa := payload.ValorTotalPrevisto / float64(payload.QuantidadeEtapas)
-		
+	for _, parcela := range parcelas {
+		cronogramaID := parcela.CronogramaID
 		input := dto.CriarContaReceberInput{
 			ObraID:                  &payload.ObraID,
 			CronogramaRecebimentoID: &cronogramaID,
+			ClienteID:               payload.ClienteID,
 			Cliente:                 payload.Cliente,
 			TipoContaReceber:        "OBRA",
-			Descricao:               payload.ObraNome + " - Etapa " + string(rune('1'+i)),
-			ValorOriginal:           valorEtapa,
-			DataVencimento:          payload.PrimeiroVencimento,
+			Descricao:               payload.ObraNome + " - " + parcela.Descricao,
+			ValorOriginal:           parcela.Valor,
+			PercentualRetencao:      &payload.PercentualRetencao,
+			DataVencimento:          parcela.DataVencimento,
 		}
 
 		conta, err := h.contaReceberService.CriarConta(ctx, input)
@@ -83,8 +98,36 @@ func (h *FinanceiroEventHandler) HandleCronogramaRecebimentoCriado(ctx context.C
 		h.logger.InfoContext(ctx, "conta a receber criada a partir do cronograma", 
 			"conta_id", conta.ID,
 			"cronograma_id", cronogramaID,
-			"valor", valorEtapa)
+			"valor", parcela.Valor)
+	}
+}
+
+// HandleObraStatusAlterado libera a caução retida quando a obra é concluída
+func (h *FinanceiroEventHandler) HandleObraStatusAlterado(ctx context.Context, evento bus.Evento) {
+	payload, ok := evento.Payload.(events.ObraStatusAlteradoPayload)
+	if !ok {
+		h.logger.ErrorContext(ctx, "payload de evento de status da obra inválido", "evento", evento.Nome)
+		return
+	}
+	if payload.NovoStatus != string(obras.StatusConcluida) {
+		return
 	}
+
+	conta, err := h.contaReceberService.LiberarCaucao(ctx, payload.ObraID, payload.ObraNome)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "falha ao liberar caução da obra concluída",
+			"obra_id", payload.ObraID,
+			"erro", err)
+		return
+	}
+	if conta == nil {
+		return
+	}
+
+	h.logger.InfoContext(ctx, "conta a receber da caução criada na conclusão da obra",
+		"conta_id", conta.ID,
+		"obra_id", payload.ObraID,
+		"valor", conta.ValorOriginal)
 }
 
 // HandleRecebimentoRealizado processa evento de recebimento realizado
@@ -286,6 +329,7 @@ func ConfigurarEventHandlers(eventBus bus.EventBus, handler *FinanceiroEventHand
 	// Eventos de cronograma de recebimento
 	eventBus.Subscrever(events.CronogramaRecebimentoCriado, handler.HandleCronogramaRecebimentoCriado)
 	eventBus.Subscrever(events.RecebimentoRealizado, handler.HandleRecebimentoRealizado)
+	eventBus.Subscrever(events.ObraStatusAlterado, handler.HandleObraStatusAlterado)
 	
 	// Eventos de orçamento (integração com Suprimentos)
 	eventBus.Subscrever(events.OrcamentoStatusAtualizado, handler.HandleOrcamentoStatusAtualizado)
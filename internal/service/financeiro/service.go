@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/clientes"
 	"github.com/luiszkm/masterCostrutora/internal/domain/common"
 	"github.com/luiszkm/masterCostrutora/internal/domain/financeiro"
 	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
@@ -32,6 +33,9 @@ type FuncionarioFinder interface {
 type ObraFinder interface {
 	BuscarPorID(ctx context.Context, id string) (*obras.Obra, error)
 }
+type ClienteFinder interface {
+	ResolverCliente(ctx context.Context, clienteID, nome string) (*clientes.Cliente, error)
+}
 type EventPublisher interface {
 	Publicar(ctx context.Context, evento bus.Evento)
 }
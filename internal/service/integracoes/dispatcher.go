@@ -0,0 +1,240 @@
+// file: internal/service/integracoes/dispatcher.go
+package integracoes
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/integracoes"
+	"github.com/luiszkm/masterCostrutora/internal/events"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus"
+	"github.com/luiszkm/masterCostrutora/pkg/notificacao"
+)
+
+// Cabeçalhos enviados em cada entrega. O receptor deve recalcular
+// HMAC-SHA256(segredo, timestamp + "." + corpo) e comparar com HeaderAssinatura.
+const (
+	HeaderEvento     = "X-MasterConstrutora-Evento"
+	HeaderEntrega    = "X-MasterConstrutora-Entrega"
+	HeaderTimestamp  = "X-MasterConstrutora-Timestamp"
+	HeaderAssinatura = "X-MasterConstrutora-Assinatura"
+)
+
+// EventosDisponiveis lista os tópicos do EventBus que podem ser assinados via webhook.
+var EventosDisponiveis = []string{
+	events.OrcamentoStatusAtualizado,
+	events.OrcamentoExcluido,
+	events.ApontamentoAprovado,
+	events.PagamentoApontamentoRealizado,
+	events.ContaReceberCriada,
+	events.ContaReceberPaga,
+	events.ContaReceberVencida,
+	events.ObraContratoDefinido,
+	events.CronogramaRecebimentoCriado,
+	events.EtapaRecebimentoVencida,
+	events.RecebimentoRealizado,
+	events.ObraStatusAlterado,
+}
+
+// EventSubscriber é a parte do EventBus usada pelo Dispatcher.
+type EventSubscriber interface {
+	Subscrever(nomeEvento string, handler bus.HandlerFunc)
+}
+
+// envelopeWebhook é o corpo JSON enviado aos sistemas externos.
+type envelopeWebhook struct {
+	ID         string    `json:"id"` // Identificador do evento; igual em reenvios, útil para deduplicação
+	Evento     string    `json:"evento"`
+	OcorridoEm time.Time `json:"ocorridoEm"`
+	Dados      any       `json:"dados"`
+}
+
+// Dispatcher recebe eventos do EventBus, registra as entregas e as envia com retentativas.
+type Dispatcher struct {
+	assinaturaRepo integracoes.AssinaturaWebhookRepository
+	entregaRepo    integracoes.EntregaWebhookRepository
+	client         *http.Client
+	logger         *slog.Logger
+	intervalo      time.Duration
+}
+
+func NovoDispatcher(
+	assinaturaRepo integracoes.AssinaturaWebhookRepository,
+	entregaRepo integracoes.EntregaWebhookRepository,
+	logger *slog.Logger,
+) *Dispatcher {
+	return &Dispatcher{
+		assinaturaRepo: assinaturaRepo,
+		entregaRepo:    entregaRepo,
+		client:         notificacao.ClientePublico(), // URLs na rede interna são recusadas na conexão
+		logger:         logger.With("component", "WebhookDispatcher"),
+		intervalo:      15 * time.Second,
+	}
+}
+
+// Registrar subscreve o Dispatcher em todos os eventos disponíveis para webhooks.
+func (d *Dispatcher) Registrar(eventBus EventSubscriber) {
+	for _, nome := range EventosDisponiveis {
+		eventBus.Subscrever(nome, d.HandleEvento)
+	}
+}
+
+// HandleEvento cria uma entrega para cada assinatura interessada e faz a primeira tentativa.
+func (d *Dispatcher) HandleEvento(ctx context.Context, evento bus.Evento) {
+	assinaturas, err := d.assinaturaRepo.ListarAtivasPorEvento(ctx, evento.Nome)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "falha ao buscar assinaturas de webhook", "evento", evento.Nome, "erro", err)
+		return
+	}
+	if len(assinaturas) == 0 {
+		return
+	}
+
+	agora := time.Now()
+	corpo, err := json.Marshal(envelopeWebhook{
+		ID:         uuid.NewString(),
+		Evento:     evento.Nome,
+		OcorridoEm: agora,
+		Dados:      evento.Payload,
+	})
+	if err != nil {
+		d.logger.ErrorContext(ctx, "falha ao serializar payload do webhook", "evento", evento.Nome, "erro", err)
+		return
+	}
+
+	for _, assinatura := range assinaturas {
+		entrega := novaEntrega(assinatura.ID, evento.Nome, corpo, nil, agora)
+		if err := d.entregaRepo.Salvar(ctx, entrega); err != nil {
+			d.logger.ErrorContext(ctx, "falha ao registrar entrega de webhook", "assinatura_id", assinatura.ID, "erro", err)
+			continue
+		}
+		d.Entregar(ctx, assinatura, entrega)
+	}
+}
+
+// Entregar envia a entrega para a URL da assinatura e persiste o resultado da tentativa.
+func (d *Dispatcher) Entregar(ctx context.Context, assinatura *integracoes.AssinaturaWebhook, entrega *integracoes.EntregaWebhook) {
+	statusHTTP, err := d.enviar(ctx, assinatura, entrega)
+	agora := time.Now()
+	if errors.Is(err, notificacao.ErrDestinoBloqueado) {
+		entrega.Abandonar(err.Error(), agora)
+		d.logger.WarnContext(ctx, "webhook com destino em rede interna abandonado",
+			"entrega_id", entrega.ID, "assinatura_id", assinatura.ID, "erro", err)
+	} else if err != nil {
+		entrega.RegistrarFalha(statusHTTP, err.Error(), agora)
+		d.logger.WarnContext(ctx, "falha na entrega de webhook",
+			"entrega_id", entrega.ID, "assinatura_id", assinatura.ID, "tentativas", entrega.Tentativas, "status", entrega.Status, "erro", err)
+	} else {
+		entrega.RegistrarSucesso(*statusHTTP, agora)
+		d.logger.InfoContext(ctx, "webhook entregue", "entrega_id", entrega.ID, "assinatura_id", assinatura.ID, "evento", entrega.Evento)
+	}
+
+	if err := d.entregaRepo.Atualizar(ctx, entrega); err != nil {
+		d.logger.ErrorContext(ctx, "falha ao atualizar entrega de webhook", "entrega_id", entrega.ID, "erro", err)
+	}
+}
+
+// Iniciar processa periodicamente as retentativas pendentes até o contexto ser cancelado.
+func (d *Dispatcher) Iniciar(ctx context.Context) {
+	ticker := time.NewTicker(d.intervalo)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.processarPendentes(ctx)
+		}
+	}
+}
+
+func (d *Dispatcher) processarPendentes(ctx context.Context) {
+	pendentes, err := d.entregaRepo.ListarPendentes(ctx, time.Now(), 50)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "falha ao listar entregas pendentes", "erro", err)
+		return
+	}
+
+	for _, entrega := range pendentes {
+		assinatura, err := d.assinaturaRepo.BuscarPorID(ctx, entrega.AssinaturaID)
+		if err != nil {
+			d.logger.ErrorContext(ctx, "falha ao buscar assinatura da entrega", "entrega_id", entrega.ID, "erro", err)
+			continue
+		}
+		if !assinatura.Ativa {
+			entrega.Abandonar("assinatura desativada", time.Now())
+			if err := d.entregaRepo.Atualizar(ctx, entrega); err != nil {
+				d.logger.ErrorContext(ctx, "falha ao atualizar entrega de webhook", "entrega_id", entrega.ID, "erro", err)
+			}
+			continue
+		}
+		d.Entregar(ctx, assinatura, entrega)
+	}
+}
+
+func (d *Dispatcher) enviar(ctx context.Context, assinatura *integracoes.AssinaturaWebhook, entrega *integracoes.EntregaWebhook) (*int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, assinatura.URL, bytes.NewReader(entrega.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("requisição inválida: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "MasterConstrutora-Webhooks/1.0")
+	req.Header.Set(HeaderEvento, entrega.Evento)
+	req.Header.Set(HeaderEntrega, entrega.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderAssinatura, "sha256="+AssinarPayload(assinatura.Segredo, timestamp, entrega.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	status := resp.StatusCode
+	if status < 200 || status >= 300 {
+		return &status, fmt.Errorf("resposta inesperada do destino: %d", status)
+	}
+	return &status, nil
+}
+
+// AssinarPayload calcula a assinatura HMAC-SHA256 (hex) de timestamp + "." + corpo.
+func AssinarPayload(segredo, timestamp string, corpo []byte) string {
+	mac := hmac.New(sha256.New, []byte(segredo))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(corpo)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// novaEntrega cria uma entrega pendente. A próxima tentativa fica reservada um minuto
+// à frente para que o loop de retentativas não dispute com o envio imediato.
+func novaEntrega(assinaturaID, evento string, corpo []byte, reenvioDeID *string, agora time.Time) *integracoes.EntregaWebhook {
+	reserva := agora.Add(time.Minute)
+	return &integracoes.EntregaWebhook{
+		ID:               uuid.NewString(),
+		AssinaturaID:     assinaturaID,
+		Evento:           evento,
+		Payload:          corpo,
+		Status:           integracoes.StatusEntregaPendente,
+		ProximaTentativa: &reserva,
+		ReenvioDeID:      reenvioDeID,
+		CreatedAt:        agora,
+		UpdatedAt:        agora,
+	}
+}
@@ -0,0 +1,53 @@
+package integracoes
+
+import "testing"
+
+func TestAssinarPayload(t *testing.T) {
+	corpo := []byte(`{"evento":"obra:status_alterado"}`)
+
+	// Valores de referência calculados fora do Go: HMAC-SHA256(segredo, timestamp + "." + corpo).
+	casos := []struct {
+		nome      string
+		segredo   string
+		timestamp string
+		corpo     []byte
+		esperado  string
+	}{
+		{
+			nome:      "assinatura de referência",
+			segredo:   "segredo",
+			timestamp: "1700000000",
+			corpo:     corpo,
+			esperado:  "2abda39a91344872c738cf5916e4305509662ec7dbca66835705b6f19c58c98b",
+		},
+		{
+			nome:      "outro segredo",
+			segredo:   "outro-segredo",
+			timestamp: "1700000000",
+			corpo:     corpo,
+			esperado:  "c5330950855ff51e3c0e8bef5d44726b74dde2add51e2cd3b512a04b55cba212",
+		},
+		{
+			nome:      "outro timestamp",
+			segredo:   "segredo",
+			timestamp: "1700000001",
+			corpo:     corpo,
+			esperado:  "114fc640c20e05905101f15d6ee5d623f7b58b4dc94576c709475ba431c08bf3",
+		},
+		{
+			nome:      "corpo vazio",
+			segredo:   "segredo",
+			timestamp: "1700000000",
+			corpo:     nil,
+			esperado:  "4cd9bf60aad616fa0e7d4a3f57776275cd46520d85ed4bfc21a0d2b060706ee2",
+		},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			if got := AssinarPayload(tc.segredo, tc.timestamp, tc.corpo); got != tc.esperado {
+				t.Errorf("AssinarPayload() = %s, esperado %s", got, tc.esperado)
+			}
+		})
+	}
+}
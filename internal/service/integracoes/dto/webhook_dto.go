@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/integracoes"
+)
+
+// CriarAssinaturaWebhookInput representa o input para cadastrar uma assinatura de webhook
+type CriarAssinaturaWebhookInput struct {
+	Descricao string   `json:"descricao"`
+	URL       string   `json:"url" validate:"required,url"`
+	Eventos   []string `json:"eventos" validate:"required,min=1"`
+	Segredo   string   `json:"segredo,omitempty"` // Opcional: gerado automaticamente quando vazio
+}
+
+// AtualizarAssinaturaWebhookInput representa o input para atualizar uma assinatura de webhook
+type AtualizarAssinaturaWebhookInput struct {
+	Descricao *string   `json:"descricao,omitempty"`
+	URL       *string   `json:"url,omitempty"`
+	Eventos   *[]string `json:"eventos,omitempty"`
+	Ativa     *bool     `json:"ativa,omitempty"`
+	Segredo   *string   `json:"segredo,omitempty"` // String vazia gera um novo segredo
+}
+
+// AssinaturaWebhookOutput representa uma assinatura sem expor o segredo
+type AssinaturaWebhookOutput struct {
+	ID        string    `json:"id"`
+	Descricao string    `json:"descricao"`
+	URL       string    `json:"url"`
+	Eventos   []string  `json:"eventos"`
+	Ativa     bool      `json:"ativa"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// AssinaturaWebhookComSegredoOutput é devolvido apenas na criação ou rotação do segredo
+type AssinaturaWebhookComSegredoOutput struct {
+	AssinaturaWebhookOutput
+	Segredo string `json:"segredo"`
+}
+
+// EntregaWebhookOutput representa uma linha do log de entregas
+type EntregaWebhookOutput struct {
+	ID               string          `json:"id"`
+	AssinaturaID     string          `json:"assinaturaId"`
+	Evento           string          `json:"evento"`
+	Payload          json.RawMessage `json:"payload"`
+	Status           string          `json:"status"`
+	Tentativas       int             `json:"tentativas"`
+	UltimoStatusHTTP *int            `json:"ultimoStatusHttp,omitempty"`
+	UltimoErro       *string         `json:"ultimoErro,omitempty"`
+	ProximaTentativa *time.Time      `json:"proximaTentativa,omitempty"`
+	EntregueEm       *time.Time      `json:"entregueEm,omitempty"`
+	ReenvioDeID      *string         `json:"reenvioDeId,omitempty"`
+	CreatedAt        time.Time       `json:"createdAt"`
+}
+
+// NovoAssinaturaWebhookOutput converte a entidade de domínio para o output
+func NovoAssinaturaWebhookOutput(a *integracoes.AssinaturaWebhook) *AssinaturaWebhookOutput {
+	return &AssinaturaWebhookOutput{
+		ID:        a.ID,
+		Descricao: a.Descricao,
+		URL:       a.URL,
+		Eventos:   a.Eventos,
+		Ativa:     a.Ativa,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+// NovoEntregaWebhookOutput converte a entidade de domínio para o output
+func NovoEntregaWebhookOutput(e *integracoes.EntregaWebhook) *EntregaWebhookOutput {
+	return &EntregaWebhookOutput{
+		ID:               e.ID,
+		AssinaturaID:     e.AssinaturaID,
+		Evento:           e.Evento,
+		Payload:          json.RawMessage(e.Payload),
+		Status:           e.Status,
+		Tentativas:       e.Tentativas,
+		UltimoStatusHTTP: e.UltimoStatusHTTP,
+		UltimoErro:       e.UltimoErro,
+		ProximaTentativa: e.ProximaTentativa,
+		EntregueEm:       e.EntregueEm,
+		ReenvioDeID:      e.ReenvioDeID,
+		CreatedAt:        e.CreatedAt,
+	}
+}
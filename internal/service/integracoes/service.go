@@ -0,0 +1,238 @@
+// file: internal/service/integracoes/service.go
+package integracoes
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/common"
+	"github.com/luiszkm/masterCostrutora/internal/domain/integracoes"
+	"github.com/luiszkm/masterCostrutora/internal/service/integracoes/dto"
+	"github.com/luiszkm/masterCostrutora/pkg/notificacao"
+)
+
+var (
+	ErrEventoNaoSuportado = errors.New("evento não disponível para webhooks")
+	ErrAssinaturaInvalida = errors.New("assinatura de webhook inválida")
+)
+
+// Service encapsula o gerenciamento das assinaturas de webhook e do log de entregas.
+type Service struct {
+	assinaturaRepo integracoes.AssinaturaWebhookRepository
+	entregaRepo    integracoes.EntregaWebhookRepository
+	dispatcher     *Dispatcher
+	logger         *slog.Logger
+}
+
+func NovoServico(
+	assinaturaRepo integracoes.AssinaturaWebhookRepository,
+	entregaRepo integracoes.EntregaWebhookRepository,
+	dispatcher *Dispatcher,
+	logger *slog.Logger,
+) *Service {
+	return &Service{
+		assinaturaRepo: assinaturaRepo,
+		entregaRepo:    entregaRepo,
+		dispatcher:     dispatcher,
+		logger:         logger.With("service", "Integracoes"),
+	}
+}
+
+// ListarEventosDisponiveis retorna os tópicos que podem ser usados no filtro de eventos.
+func (s *Service) ListarEventosDisponiveis() []string {
+	return append([]string{integracoes.EventoCuringa}, EventosDisponiveis...)
+}
+
+func (s *Service) CriarAssinatura(ctx context.Context, input dto.CriarAssinaturaWebhookInput) (*dto.AssinaturaWebhookComSegredoOutput, error) {
+	const op = "service.integracoes.CriarAssinatura"
+
+	segredo := input.Segredo
+	if segredo == "" {
+		var err error
+		if segredo, err = gerarSegredo(); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	agora := time.Now()
+	assinatura := &integracoes.AssinaturaWebhook{
+		ID:        uuid.NewString(),
+		Descricao: input.Descricao,
+		URL:       input.URL,
+		Eventos:   input.Eventos,
+		Segredo:   segredo,
+		Ativa:     true,
+		CreatedAt: agora,
+		UpdatedAt: agora,
+	}
+	if err := validarAssinatura(assinatura); err != nil {
+		return nil, err
+	}
+
+	if err := s.assinaturaRepo.Salvar(ctx, assinatura); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "assinatura de webhook criada", "assinatura_id", assinatura.ID, "eventos", assinatura.Eventos)
+	return &dto.AssinaturaWebhookComSegredoOutput{
+		AssinaturaWebhookOutput: *dto.NovoAssinaturaWebhookOutput(assinatura),
+		Segredo:                 assinatura.Segredo,
+	}, nil
+}
+
+// AtualizarAssinatura aplica as alterações informadas. O segredo só é devolvido quando rotacionado.
+func (s *Service) AtualizarAssinatura(ctx context.Context, id string, input dto.AtualizarAssinaturaWebhookInput) (*dto.AssinaturaWebhookComSegredoOutput, error) {
+	const op = "service.integracoes.AtualizarAssinatura"
+
+	assinatura, err := s.assinaturaRepo.BuscarPorID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if input.Descricao != nil {
+		assinatura.Descricao = *input.Descricao
+	}
+	if input.URL != nil {
+		assinatura.URL = *input.URL
+	}
+	if input.Eventos != nil {
+		assinatura.Eventos = *input.Eventos
+	}
+	if input.Ativa != nil {
+		assinatura.Ativa = *input.Ativa
+	}
+	segredoRotacionado := false
+	if input.Segredo != nil {
+		novo := *input.Segredo
+		if novo == "" {
+			if novo, err = gerarSegredo(); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+		assinatura.Segredo = novo
+		segredoRotacionado = true
+	}
+	assinatura.UpdatedAt = time.Now()
+
+	if err := validarAssinatura(assinatura); err != nil {
+		return nil, err
+	}
+	if err := s.assinaturaRepo.Atualizar(ctx, assinatura); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "assinatura de webhook atualizada", "assinatura_id", id, "segredo_rotacionado", segredoRotacionado)
+	output := &dto.AssinaturaWebhookComSegredoOutput{AssinaturaWebhookOutput: *dto.NovoAssinaturaWebhookOutput(assinatura)}
+	if segredoRotacionado {
+		output.Segredo = assinatura.Segredo
+	}
+	return output, nil
+}
+
+func (s *Service) BuscarAssinatura(ctx context.Context, id string) (*dto.AssinaturaWebhookOutput, error) {
+	const op = "service.integracoes.BuscarAssinatura"
+
+	assinatura, err := s.assinaturaRepo.BuscarPorID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return dto.NovoAssinaturaWebhookOutput(assinatura), nil
+}
+
+func (s *Service) ListarAssinaturas(ctx context.Context) ([]*dto.AssinaturaWebhookOutput, error) {
+	const op = "service.integracoes.ListarAssinaturas"
+
+	assinaturas, err := s.assinaturaRepo.Listar(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	outputs := make([]*dto.AssinaturaWebhookOutput, len(assinaturas))
+	for i, a := range assinaturas {
+		outputs[i] = dto.NovoAssinaturaWebhookOutput(a)
+	}
+	return outputs, nil
+}
+
+func (s *Service) DeletarAssinatura(ctx context.Context, id string) error {
+	const op = "service.integracoes.DeletarAssinatura"
+
+	if err := s.assinaturaRepo.Deletar(ctx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	s.logger.InfoContext(ctx, "assinatura de webhook removida", "assinatura_id", id)
+	return nil
+}
+
+// ListarEntregas retorna o log de entregas de uma assinatura, das mais recentes para as mais antigas.
+func (s *Service) ListarEntregas(ctx context.Context, assinaturaID string, filtros common.ListarFiltros) (*common.RespostaPaginada[*dto.EntregaWebhookOutput], error) {
+	const op = "service.integracoes.ListarEntregas"
+
+	if _, err := s.assinaturaRepo.BuscarPorID(ctx, assinaturaID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	entregas, paginacao, err := s.entregaRepo.ListarPorAssinatura(ctx, assinaturaID, filtros)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	dados := make([]*dto.EntregaWebhookOutput, len(entregas))
+	for i, e := range entregas {
+		dados[i] = dto.NovoEntregaWebhookOutput(e)
+	}
+	return &common.RespostaPaginada[*dto.EntregaWebhookOutput]{Dados: dados, Paginacao: *paginacao}, nil
+}
+
+// ReenviarEntrega cria uma nova entrega com o mesmo corpo da original e a envia imediatamente.
+func (s *Service) ReenviarEntrega(ctx context.Context, entregaID string) (*dto.EntregaWebhookOutput, error) {
+	const op = "service.integracoes.ReenviarEntrega"
+
+	original, err := s.entregaRepo.BuscarPorID(ctx, entregaID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	assinatura, err := s.assinaturaRepo.BuscarPorID(ctx, original.AssinaturaID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	reenvio := novaEntrega(assinatura.ID, original.Evento, original.Payload, &original.ID, time.Now())
+	if err := s.entregaRepo.Salvar(ctx, reenvio); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.dispatcher.Entregar(ctx, assinatura, reenvio)
+	s.logger.InfoContext(ctx, "entrega de webhook reenviada", "entrega_original_id", original.ID, "entrega_id", reenvio.ID, "status", reenvio.Status)
+	return dto.NovoEntregaWebhookOutput(reenvio), nil
+}
+
+func validarAssinatura(a *integracoes.AssinaturaWebhook) error {
+	if err := a.Validar(); err != nil {
+		return fmt.Errorf("%w: %v", ErrAssinaturaInvalida, err)
+	}
+	if err := notificacao.ValidarDestinoPublico(a.URL); err != nil {
+		return fmt.Errorf("%w: %v", ErrAssinaturaInvalida, err)
+	}
+	for _, e := range a.Eventos {
+		if e != integracoes.EventoCuringa && !slices.Contains(EventosDisponiveis, e) {
+			return fmt.Errorf("%w: %s", ErrEventoNaoSuportado, e)
+		}
+	}
+	return nil
+}
+
+func gerarSegredo() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("falha ao gerar segredo: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
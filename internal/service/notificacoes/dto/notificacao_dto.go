@@ -0,0 +1,28 @@
+package dto
+
+import "github.com/luiszkm/masterCostrutora/internal/domain/notificacoes"
+
+// PreferenciasOutput traz a preferência de cada evento notificável (as não
+// configuradas com o valor padrão) e os canais externos habilitados no servidor
+type PreferenciasOutput struct {
+	notificacoes.PreferenciasNotificacao
+	CanaisDisponiveis []notificacoes.Canal `json:"canaisDisponiveis"`
+}
+
+// AtualizarPreferenciasInput representa o input para alterar as preferências;
+// campos ausentes são mantidos e os eventos informados substituem os existentes
+type AtualizarPreferenciasInput struct {
+	Telefone   *string                          `json:"telefone,omitempty"`
+	WebhookURL *string                          `json:"webhookUrl,omitempty"`
+	Eventos    []notificacoes.PreferenciaEvento `json:"eventos,omitempty"`
+}
+
+// ContagemNaoLidasOutput representa o contador exibido no sino de notificações
+type ContagemNaoLidasOutput struct {
+	NaoLidas int `json:"naoLidas"`
+}
+
+// MarcarTodasLidasOutput informa quantas notificações foram marcadas como lidas
+type MarcarTodasLidasOutput struct {
+	Marcadas int64 `json:"marcadas"`
+}
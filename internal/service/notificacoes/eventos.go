@@ -0,0 +1,114 @@
+// file: internal/service/notificacoes/eventos.go
+package notificacoes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/luiszkm/masterCostrutora/internal/authz"
+	"github.com/luiszkm/masterCostrutora/internal/domain/suprimentos"
+	"github.com/luiszkm/masterCostrutora/internal/events"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus"
+)
+
+// EventosDisponiveis lista os eventos que geram notificações para os usuários.
+var EventosDisponiveis = []string{
+	events.ApontamentoAprovado,
+	events.OrcamentoStatusAtualizado,
+	events.ContaReceberVencida,
+	events.RecebimentoRealizado,
+}
+
+// EventSubscriber é a parte do EventBus usada pelo serviço de notificações.
+type EventSubscriber interface {
+	Subscrever(nomeEvento string, handler bus.HandlerFunc)
+}
+
+// Registrar subscreve o serviço nos eventos que geram notificações.
+func (s *Service) Registrar(eventBus EventSubscriber) {
+	eventBus.Subscrever(events.ApontamentoAprovado, s.HandleApontamentoAprovado)
+	eventBus.Subscrever(events.OrcamentoStatusAtualizado, s.HandleOrcamentoStatusAtualizado)
+	eventBus.Subscrever(events.ContaReceberVencida, s.HandleContaReceberVencida)
+	eventBus.Subscrever(events.RecebimentoRealizado, s.HandleRecebimentoRealizado)
+}
+
+// HandleApontamentoAprovado avisa quem paga apontamentos que há um novo pagamento a programar.
+func (s *Service) HandleApontamentoAprovado(ctx context.Context, evento bus.Evento) {
+	payload, ok := evento.Payload.(events.ApontamentoAprovadoPayload)
+	if !ok {
+		s.logger.ErrorContext(ctx, "payload de evento de apontamento inválido", "evento", evento.Nome)
+		return
+	}
+
+	s.notificar(ctx, evento.Nome, conteudo{
+		permissao: authz.PermissaoPessoalApontamentoPagar,
+		autorID:   payload.UsuarioID,
+		titulo:    "Apontamento aprovado para pagamento",
+		mensagem: fmt.Sprintf("%s (%s, período %s): R$ %.2f com pagamento previsto para %s.",
+			payload.FuncionarioNome, payload.ObraNome, payload.PeriodoReferencia,
+			payload.ValorCalculado, payload.DataVencimentoPrevisto.Format("02/01/2006")),
+		referenciaID: &payload.ApontamentoID,
+		obraID:       &payload.ObraID,
+	})
+}
+
+// HandleOrcamentoStatusAtualizado avisa o financeiro quando um orçamento é aprovado.
+func (s *Service) HandleOrcamentoStatusAtualizado(ctx context.Context, evento bus.Evento) {
+	payload, ok := evento.Payload.(events.OrcamentoStatusAtualizadoPayload)
+	if !ok {
+		s.logger.ErrorContext(ctx, "payload de evento de orçamento inválido", "evento", evento.Nome)
+		return
+	}
+	if payload.NovoStatus != string(suprimentos.StatusAprovado) {
+		return
+	}
+
+	s.notificar(ctx, evento.Nome, conteudo{
+		permissao:    authz.PermissaoFinanceiroEscrever,
+		titulo:       "Orçamento aprovado",
+		mensagem:     fmt.Sprintf("Um orçamento de R$ %.2f foi aprovado e gerou uma conta a pagar.", payload.Valor),
+		referenciaID: &payload.OrcamentoID,
+	})
+}
+
+// HandleContaReceberVencida avisa o financeiro sobre contas a receber em atraso.
+func (s *Service) HandleContaReceberVencida(ctx context.Context, evento bus.Evento) {
+	payload, ok := evento.Payload.(events.ContaReceberVencidaPayload)
+	if !ok {
+		s.logger.ErrorContext(ctx, "payload de evento de conta vencida inválido", "evento", evento.Nome)
+		return
+	}
+
+	s.notificar(ctx, evento.Nome, conteudo{
+		permissao: authz.PermissaoFinanceiroLer,
+		titulo:    fmt.Sprintf("Conta a receber vencida: %s", payload.Cliente),
+		mensagem: fmt.Sprintf("%s: saldo de R$ %.2f vencido em %s (%d dia(s) de atraso).",
+			payload.Descricao, payload.ValorSaldo, payload.DataVencimento.Format("02/01/2006"), payload.DiasVencidos),
+		referenciaID: &payload.ContaReceberID,
+		obraID:       payload.ObraID,
+	})
+}
+
+// HandleRecebimentoRealizado avisa o financeiro sobre valores recebidos de clientes.
+func (s *Service) HandleRecebimentoRealizado(ctx context.Context, evento bus.Evento) {
+	payload, ok := evento.Payload.(events.RecebimentoRealizadoPayload)
+	if !ok {
+		s.logger.ErrorContext(ctx, "payload de evento de recebimento inválido", "evento", evento.Nome)
+		return
+	}
+
+	mensagem := fmt.Sprintf("R$ %.2f recebido de %s em %s", payload.ValorRecebido, payload.Cliente, payload.DataRecebimento.Format("02/01/2006"))
+	if payload.ObraNome != nil {
+		mensagem += fmt.Sprintf(" (obra %s)", *payload.ObraNome)
+	}
+	mensagem += fmt.Sprintf(": %s.", payload.Descricao)
+
+	s.notificar(ctx, evento.Nome, conteudo{
+		permissao:    authz.PermissaoFinanceiroLer,
+		autorID:      payload.UsuarioID,
+		titulo:       "Recebimento realizado",
+		mensagem:     mensagem,
+		referenciaID: payload.CronogramaRecebimentoID,
+		obraID:       payload.ObraID,
+	})
+}
@@ -0,0 +1,334 @@
+// file: internal/service/notificacoes/service.go
+package notificacoes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/common"
+	"github.com/luiszkm/masterCostrutora/internal/domain/notificacoes"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/service/notificacoes/dto"
+	"github.com/luiszkm/masterCostrutora/pkg/auth"
+	"github.com/luiszkm/masterCostrutora/pkg/notificacao"
+)
+
+var (
+	ErrCanalNaoConfigurado = errors.New("canal não configurado no servidor")
+	ErrSemDestino          = errors.New("usuário sem endereço cadastrado para o canal")
+)
+
+// Parâmetros da fila de entregas nos canais externos
+const (
+	intervaloEntregas   = 15 * time.Second // Ciclo das retentativas
+	loteEntregas        = 20               // Notificações reservadas por vez
+	paralelismoEntregas = 5                // Notificações enviadas em paralelo
+	timeoutEntrega      = 15 * time.Second // Limite de cada envio
+	reservaEntregas     = 5 * time.Minute  // Tempo em que um lote fica reservado para esta instância
+)
+
+// Service cria as notificações a partir dos eventos de domínio, entrega-as nos
+// canais escolhidos por cada usuário e mantém o estado de leitura.
+type Service struct {
+	notificacaoRepo  notificacoes.NotificacaoRepository
+	preferenciasRepo notificacoes.PreferenciasRepository
+	destinatarioRepo notificacoes.DestinatarioRepository
+	canais           map[notificacoes.Canal]notificacao.Canal
+	aviso            chan struct{}
+	logger           *slog.Logger
+}
+
+// NovoServico recebe os canais externos habilitados; canais ausentes do mapa
+// ficam indisponíveis nas preferências.
+func NovoServico(
+	notificacaoRepo notificacoes.NotificacaoRepository,
+	preferenciasRepo notificacoes.PreferenciasRepository,
+	destinatarioRepo notificacoes.DestinatarioRepository,
+	canais map[notificacoes.Canal]notificacao.Canal,
+	logger *slog.Logger,
+) *Service {
+	return &Service{
+		notificacaoRepo:  notificacaoRepo,
+		preferenciasRepo: preferenciasRepo,
+		destinatarioRepo: destinatarioRepo,
+		canais:           canais,
+		aviso:            make(chan struct{}, 1),
+		logger:           logger.With("service", "Notificacoes"),
+	}
+}
+
+// ListarNotificacoes lista as notificações do usuário logado; filtros.Status aceita LIDA ou NAO_LIDA.
+func (s *Service) ListarNotificacoes(ctx context.Context, filtros common.ListarFiltros) (*common.RespostaPaginada[*notificacoes.Notificacao], error) {
+	const op = "service.notificacoes.ListarNotificacoes"
+	lista, paginacao, err := s.notificacaoRepo.ListarPorUsuario(ctx, auth.UsuarioDoContexto(ctx), filtros)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &common.RespostaPaginada[*notificacoes.Notificacao]{Dados: lista, Paginacao: *paginacao}, nil
+}
+
+func (s *Service) ContarNaoLidas(ctx context.Context) (*dto.ContagemNaoLidasOutput, error) {
+	const op = "service.notificacoes.ContarNaoLidas"
+	total, err := s.notificacaoRepo.ContarNaoLidas(ctx, auth.UsuarioDoContexto(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &dto.ContagemNaoLidasOutput{NaoLidas: total}, nil
+}
+
+func (s *Service) MarcarLida(ctx context.Context, id string) (*notificacoes.Notificacao, error) {
+	const op = "service.notificacoes.MarcarLida"
+	n, err := s.notificacaoRepo.MarcarLida(ctx, auth.UsuarioDoContexto(ctx), id, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return n, nil
+}
+
+func (s *Service) MarcarTodasLidas(ctx context.Context) (*dto.MarcarTodasLidasOutput, error) {
+	const op = "service.notificacoes.MarcarTodasLidas"
+	marcadas, err := s.notificacaoRepo.MarcarTodasLidas(ctx, auth.UsuarioDoContexto(ctx), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &dto.MarcarTodasLidasOutput{Marcadas: marcadas}, nil
+}
+
+// BuscarPreferencias retorna as preferências do usuário logado para todos os eventos notificáveis.
+func (s *Service) BuscarPreferencias(ctx context.Context) (*dto.PreferenciasOutput, error) {
+	const op = "service.notificacoes.BuscarPreferencias"
+	prefs, err := s.preferencias(ctx, auth.UsuarioDoContexto(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return s.montarPreferencias(prefs), nil
+}
+
+func (s *Service) AtualizarPreferencias(ctx context.Context, input dto.AtualizarPreferenciasInput) (*dto.PreferenciasOutput, error) {
+	const op = "service.notificacoes.AtualizarPreferencias"
+
+	prefs, err := s.preferencias(ctx, auth.UsuarioDoContexto(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if input.Telefone != nil {
+		prefs.Telefone = *input.Telefone
+	}
+	if input.WebhookURL != nil {
+		prefs.WebhookURL = *input.WebhookURL
+	}
+	for _, e := range input.Eventos {
+		if e.Canais == nil {
+			e.Canais = []notificacoes.Canal{}
+		}
+		i := slices.IndexFunc(prefs.Eventos, func(atual notificacoes.PreferenciaEvento) bool { return atual.Evento == e.Evento })
+		if i >= 0 {
+			prefs.Eventos[i] = e
+		} else {
+			prefs.Eventos = append(prefs.Eventos, e)
+		}
+	}
+	if err := prefs.Validar(EventosDisponiveis); err != nil {
+		return nil, err
+	}
+	if prefs.WebhookURL != "" {
+		if err := notificacao.ValidarDestinoPublico(prefs.WebhookURL); err != nil {
+			return nil, fmt.Errorf("%w: webhookUrl não pode apontar para a rede interna", notificacoes.ErrPreferenciasInvalidas)
+		}
+	}
+	prefs.UpdatedAt = time.Now()
+
+	if err := s.preferenciasRepo.SalvarPreferencias(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return s.montarPreferencias(prefs), nil
+}
+
+// conteudo descreve a notificação gerada por um evento e quem deve recebê-la.
+type conteudo struct {
+	permissao    string // Destinatários: usuários ativos com esta permissão
+	autorID      string // Quem originou o evento não é notificado
+	titulo       string
+	mensagem     string
+	referenciaID *string
+	obraID       *string
+}
+
+// notificar cria uma notificação para cada destinatário que não desativou o evento e
+// enfileira as entregas nos canais externos que ele escolheu. O envio é feito por
+// IniciarEntregas, fora do handler do EventBus.
+func (s *Service) notificar(ctx context.Context, evento string, c conteudo) {
+	destinatarios, err := s.destinatarioRepo.ListarDestinatarios(ctx, c.permissao)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "falha ao buscar destinatários da notificação", "evento", evento, "erro", err)
+		return
+	}
+
+	agora := time.Now()
+	enfileiradas := false
+	for _, d := range destinatarios {
+		if d.UsuarioID == c.autorID {
+			continue
+		}
+		prefs, err := s.preferencias(ctx, d.UsuarioID)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "falha ao buscar preferências de notificação", "usuario_id", d.UsuarioID, "erro", err)
+			prefs = notificacoes.PreferenciasPadrao(d.UsuarioID)
+		}
+		preferencia := prefs.ParaEvento(evento)
+		if !preferencia.Ativo {
+			continue
+		}
+
+		n := &notificacoes.Notificacao{
+			ID:           uuid.NewString(),
+			UsuarioID:    d.UsuarioID,
+			Evento:       evento,
+			Titulo:       c.titulo,
+			Mensagem:     c.mensagem,
+			ReferenciaID: c.referenciaID,
+			ObraID:       c.obraID,
+			Entregas:     []notificacoes.EntregaCanal{},
+			CreatedAt:    agora,
+		}
+		for _, canal := range preferencia.Canais {
+			entrega := n.AgendarEntrega(canal, prefs.Destino(canal, d), agora)
+			switch {
+			case s.canais[canal] == nil:
+				entrega.Abandonar(ErrCanalNaoConfigurado.Error(), agora)
+			case entrega.Destino == "":
+				entrega.Abandonar(ErrSemDestino.Error(), agora)
+			}
+		}
+
+		if err := s.notificacaoRepo.Salvar(ctx, n); err != nil {
+			s.logger.ErrorContext(ctx, "falha ao salvar notificação", "evento", evento, "usuario_id", d.UsuarioID, "erro", err)
+			continue
+		}
+		enfileiradas = enfileiradas || n.ProximaEntrega() != nil
+	}
+
+	if enfileiradas {
+		s.avisarEntregas()
+	}
+}
+
+// avisarEntregas acorda IniciarEntregas sem esperar o próximo ciclo.
+func (s *Service) avisarEntregas() {
+	select {
+	case s.aviso <- struct{}{}:
+	default:
+	}
+}
+
+// IniciarEntregas envia as entregas enfileiradas e as retentativas vencidas até o
+// contexto ser cancelado. Roda a cada intervaloEntregas ou quando há novas entregas.
+func (s *Service) IniciarEntregas(ctx context.Context) {
+	ticker := time.NewTicker(intervaloEntregas)
+	defer ticker.Stop()
+
+	for {
+		s.ProcessarEntregas(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.aviso:
+		}
+	}
+}
+
+// ProcessarEntregas reserva lotes de notificações com entregas vencidas e as envia,
+// até `paralelismoEntregas` notificações por vez, enquanto houver lotes completos.
+func (s *Service) ProcessarEntregas(ctx context.Context) {
+	for ctx.Err() == nil {
+		agora := time.Now()
+		lote, err := s.notificacaoRepo.ReservarEntregasPendentes(ctx, agora, agora.Add(reservaEntregas), loteEntregas)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "falha ao reservar entregas de notificação", "erro", err)
+			return
+		}
+
+		var wg sync.WaitGroup
+		vagas := make(chan struct{}, paralelismoEntregas)
+		for _, n := range lote {
+			vagas <- struct{}{}
+			wg.Add(1)
+			go func(n *notificacoes.Notificacao) {
+				defer func() { <-vagas; wg.Done() }()
+				s.entregar(ctx, n)
+			}(n)
+		}
+		wg.Wait()
+
+		if len(lote) < loteEntregas {
+			return
+		}
+	}
+}
+
+// entregar tenta as entregas vencidas da notificação e persiste o resultado de cada canal.
+func (s *Service) entregar(ctx context.Context, n *notificacoes.Notificacao) {
+	for i := range n.Entregas {
+		entrega := &n.Entregas[i]
+		if !entrega.Vencida(time.Now()) {
+			continue
+		}
+		canal, ok := s.canais[entrega.Canal]
+		if !ok {
+			entrega.Abandonar(ErrCanalNaoConfigurado.Error(), time.Now())
+			continue
+		}
+
+		envioCtx, cancel := context.WithTimeout(ctx, timeoutEntrega)
+		err := canal.Enviar(envioCtx, notificacao.Mensagem{
+			Destino: entrega.Destino,
+			Assunto: n.Titulo,
+			Texto:   n.Mensagem,
+			Dados:   n,
+		})
+		cancel()
+
+		if err != nil {
+			entrega.RegistrarFalha(err.Error(), time.Now())
+			s.logger.WarnContext(ctx, "falha na entrega de notificação",
+				"notificacao_id", n.ID, "canal", entrega.Canal, "tentativas", entrega.Tentativas, "status", entrega.Status, "erro", err)
+			continue
+		}
+		entrega.RegistrarSucesso(time.Now())
+	}
+
+	if err := s.notificacaoRepo.AtualizarEntregas(ctx, n); err != nil {
+		s.logger.ErrorContext(ctx, "falha ao registrar entregas da notificação", "notificacao_id", n.ID, "erro", err)
+	}
+}
+
+// preferencias busca as preferências do usuário, usando o padrão quando não há registro.
+func (s *Service) preferencias(ctx context.Context, usuarioID string) (*notificacoes.PreferenciasNotificacao, error) {
+	prefs, err := s.preferenciasRepo.BuscarPreferencias(ctx, usuarioID)
+	if errors.Is(err, postgres.ErrNaoEncontrado) {
+		return notificacoes.PreferenciasPadrao(usuarioID), nil
+	}
+	return prefs, err
+}
+
+func (s *Service) montarPreferencias(prefs *notificacoes.PreferenciasNotificacao) *dto.PreferenciasOutput {
+	out := &dto.PreferenciasOutput{PreferenciasNotificacao: *prefs, CanaisDisponiveis: []notificacoes.Canal{}}
+	out.Eventos = make([]notificacoes.PreferenciaEvento, 0, len(EventosDisponiveis))
+	for _, evento := range EventosDisponiveis {
+		out.Eventos = append(out.Eventos, prefs.ParaEvento(evento))
+	}
+	for _, canal := range notificacoes.CanaisExternos {
+		if _, ok := s.canais[canal]; ok {
+			out.CanaisDisponiveis = append(out.CanaisDisponiveis, canal)
+		}
+	}
+	return out
+}
@@ -0,0 +1,289 @@
+package notificacoes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/authz"
+	"github.com/luiszkm/masterCostrutora/internal/domain/common"
+	"github.com/luiszkm/masterCostrutora/internal/domain/notificacoes"
+	"github.com/luiszkm/masterCostrutora/internal/events"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus"
+	"github.com/luiszkm/masterCostrutora/internal/service/notificacoes/dto"
+	"github.com/luiszkm/masterCostrutora/pkg/auth"
+	"github.com/luiszkm/masterCostrutora/pkg/notificacao"
+)
+
+// repositorioMemoria implementa os repositórios de notificações em memória.
+type repositorioMemoria struct {
+	mu            sync.Mutex
+	notificacoes  []*notificacoes.Notificacao
+	preferencias  map[string]*notificacoes.PreferenciasNotificacao
+	destinatarios map[string][]notificacoes.Destinatario // por permissão
+}
+
+func novoRepositorioMemoria() *repositorioMemoria {
+	return &repositorioMemoria{
+		preferencias:  map[string]*notificacoes.PreferenciasNotificacao{},
+		destinatarios: map[string][]notificacoes.Destinatario{},
+	}
+}
+
+func (m *repositorioMemoria) Salvar(ctx context.Context, n *notificacoes.Notificacao) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notificacoes = append(m.notificacoes, n)
+	return nil
+}
+
+func (m *repositorioMemoria) AtualizarEntregas(ctx context.Context, n *notificacoes.Notificacao) error {
+	return nil // As notificações são guardadas por ponteiro
+}
+
+func (m *repositorioMemoria) ReservarEntregasPendentes(ctx context.Context, agora, reservaAte time.Time, limite int) ([]*notificacoes.Notificacao, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var lote []*notificacoes.Notificacao
+	for _, n := range m.notificacoes {
+		if proxima := n.ProximaEntrega(); proxima != nil && !proxima.After(agora) && len(lote) < limite {
+			lote = append(lote, n)
+		}
+	}
+	return lote, nil
+}
+
+func (m *repositorioMemoria) ListarPorUsuario(ctx context.Context, usuarioID string, filtros common.ListarFiltros) ([]*notificacoes.Notificacao, *common.PaginacaoInfo, error) {
+	var lista []*notificacoes.Notificacao
+	for _, n := range m.salvas() {
+		if n.UsuarioID == usuarioID {
+			lista = append(lista, n)
+		}
+	}
+	return lista, common.NewPaginacaoInfo(len(lista), 1, len(lista)), nil
+}
+
+func (m *repositorioMemoria) ContarNaoLidas(ctx context.Context, usuarioID string) (int, error) {
+	return 0, nil
+}
+
+func (m *repositorioMemoria) MarcarLida(ctx context.Context, usuarioID, id string, agora time.Time) (*notificacoes.Notificacao, error) {
+	return nil, postgres.ErrNaoEncontrado
+}
+
+func (m *repositorioMemoria) MarcarTodasLidas(ctx context.Context, usuarioID string, agora time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *repositorioMemoria) BuscarPreferencias(ctx context.Context, usuarioID string) (*notificacoes.PreferenciasNotificacao, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if p, ok := m.preferencias[usuarioID]; ok {
+		return p, nil
+	}
+	return nil, postgres.ErrNaoEncontrado
+}
+
+func (m *repositorioMemoria) SalvarPreferencias(ctx context.Context, p *notificacoes.PreferenciasNotificacao) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.preferencias[p.UsuarioID] = p
+	return nil
+}
+
+func (m *repositorioMemoria) ListarDestinatarios(ctx context.Context, permissao string) ([]notificacoes.Destinatario, error) {
+	return m.destinatarios[permissao], nil
+}
+
+func (m *repositorioMemoria) salvas() []*notificacoes.Notificacao {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*notificacoes.Notificacao(nil), m.notificacoes...)
+}
+
+// aguardarNotificacoes espera o processamento assíncrono do EventBus.
+func aguardarNotificacoes(t *testing.T, repo *repositorioMemoria, quantidade int) []*notificacoes.Notificacao {
+	t.Helper()
+	limite := time.Now().Add(2 * time.Second)
+	for time.Now().Before(limite) {
+		if salvas := repo.salvas(); len(salvas) >= quantidade {
+			return salvas
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("esperava %d notificação(ões), obteve %d", quantidade, len(repo.salvas()))
+	return nil
+}
+
+func TestContaReceberVencidaGeraNotificacao(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := novoRepositorioMemoria()
+	repo.destinatarios[authz.PermissaoFinanceiroLer] = []notificacoes.Destinatario{
+		{UsuarioID: "financeiro-1", Email: "financeiro1@empresa.com"},
+		{UsuarioID: "financeiro-2", Email: "financeiro2@empresa.com"},
+	}
+	// financeiro-2 silenciou contas vencidas
+	repo.preferencias["financeiro-2"] = &notificacoes.PreferenciasNotificacao{
+		UsuarioID: "financeiro-2",
+		Eventos:   []notificacoes.PreferenciaEvento{{Evento: events.ContaReceberVencida, Ativo: false}},
+	}
+
+	svc := NovoServico(repo, repo, repo, map[notificacoes.Canal]notificacao.Canal{}, logger)
+	eventBus := bus.NovoEventBus(logger)
+	svc.Registrar(eventBus)
+
+	obraID := "obra-1"
+	eventBus.Publicar(context.Background(), bus.Evento{
+		Nome: events.ContaReceberVencida,
+		Payload: events.ContaReceberVencidaPayload{
+			ContaReceberID: "conta-1",
+			ObraID:         &obraID,
+			Cliente:        "Construtora Cliente",
+			Descricao:      "Parcela 3",
+			ValorSaldo:     1500,
+			DataVencimento: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+			DiasVencidos:   5,
+		},
+	})
+
+	salvas := aguardarNotificacoes(t, repo, 1)
+	time.Sleep(50 * time.Millisecond) // garante que nenhuma notificação extra chegou
+	if len(repo.salvas()) != 1 {
+		t.Fatalf("esperava 1 notificação, obteve %d", len(repo.salvas()))
+	}
+
+	n := salvas[0]
+	if n.UsuarioID != "financeiro-1" || n.Evento != events.ContaReceberVencida {
+		t.Errorf("notificação inesperada: usuário %q, evento %q", n.UsuarioID, n.Evento)
+	}
+	if n.ReferenciaID == nil || *n.ReferenciaID != "conta-1" || n.ObraID == nil || *n.ObraID != obraID {
+		t.Errorf("referências inesperadas: %+v", n)
+	}
+	if n.Lida {
+		t.Error("notificação nova não deveria estar lida")
+	}
+}
+
+func TestAtualizarPreferenciasRecusaWebhookInterno(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := novoRepositorioMemoria()
+	svc := NovoServico(repo, repo, repo, map[notificacoes.Canal]notificacao.Canal{}, logger)
+	ctx := context.WithValue(context.Background(), auth.UserContextKey, "usuario-1")
+
+	casos := []struct {
+		url    string
+		aceita bool
+	}{
+		{"http://169.254.169.254/latest/meta-data/", false},
+		{"http://localhost:5432/", false},
+		{"http://10.0.0.5/hook", false},
+		{"https://hooks.exemplo.com/notificacoes", true},
+	}
+	for _, c := range casos {
+		t.Run(c.url, func(t *testing.T) {
+			url := c.url
+			_, err := svc.AtualizarPreferencias(ctx, dto.AtualizarPreferenciasInput{WebhookURL: &url})
+			if c.aceita && err != nil {
+				t.Fatalf("esperava aceitar, obteve %v", err)
+			}
+			if !c.aceita && !errors.Is(err, notificacoes.ErrPreferenciasInvalidas) {
+				t.Fatalf("esperava ErrPreferenciasInvalidas, obteve %v", err)
+			}
+		})
+	}
+}
+
+// canalComFalha simula um canal externo indisponível.
+type canalComFalha struct{}
+
+func (canalComFalha) Enviar(ctx context.Context, m notificacao.Mensagem) error {
+	return fmt.Errorf("gateway indisponível")
+}
+
+func TestEntregasSaoEnfileiradasEEnviadasEmSegundoPlano(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := novoRepositorioMemoria()
+	repo.destinatarios[authz.PermissaoFinanceiroLer] = []notificacoes.Destinatario{
+		{UsuarioID: "u1", Email: "u1@empresa.com"},
+	}
+	repo.preferencias["u1"] = &notificacoes.PreferenciasNotificacao{
+		UsuarioID:  "u1",
+		Telefone:   "+5511999990000",
+		WebhookURL: "https://hooks.exemplo.com/u1",
+		Eventos: []notificacoes.PreferenciaEvento{{
+			Evento: events.RecebimentoRealizado,
+			Ativo:  true,
+			Canais: []notificacoes.Canal{notificacoes.CanalEmail, notificacoes.CanalWhatsApp, notificacoes.CanalWebhook},
+		}},
+	}
+
+	email := notificacao.NovoFake("EMAIL", logger)
+	svc := NovoServico(repo, repo, repo, map[notificacoes.Canal]notificacao.Canal{
+		notificacoes.CanalEmail:    email,
+		notificacoes.CanalWhatsApp: canalComFalha{},
+		// WEBHOOK não configurado
+	}, logger)
+
+	svc.HandleRecebimentoRealizado(context.Background(), bus.Evento{
+		Nome: events.RecebimentoRealizado,
+		Payload: events.RecebimentoRealizadoPayload{
+			Cliente: "Cliente", ValorRecebido: 100, Descricao: "Parcela 1", DataRecebimento: time.Now(),
+		},
+	})
+
+	salvas := repo.salvas()
+	if len(salvas) != 1 {
+		t.Fatalf("esperava 1 notificação, obteve %d", len(salvas))
+	}
+	n := salvas[0]
+	if len(email.Enviadas()) != 0 {
+		t.Fatal("o handler do evento não deveria enviar, apenas enfileirar")
+	}
+	esperado := map[notificacoes.Canal]string{
+		notificacoes.CanalEmail:    notificacoes.StatusEntregaPendente,
+		notificacoes.CanalWhatsApp: notificacoes.StatusEntregaPendente,
+		notificacoes.CanalWebhook:  notificacoes.StatusEntregaFalha,
+	}
+	for _, e := range n.Entregas {
+		if e.Status != esperado[e.Canal] {
+			t.Errorf("antes do envio: canal %s com status %s, esperado %s", e.Canal, e.Status, esperado[e.Canal])
+		}
+	}
+
+	svc.ProcessarEntregas(context.Background())
+
+	if enviadas := email.Enviadas(); len(enviadas) != 1 || enviadas[0].Destino != "u1@empresa.com" {
+		t.Fatalf("esperava 1 e-mail para u1@empresa.com, obteve %+v", enviadas)
+	}
+	for _, e := range n.Entregas {
+		switch e.Canal {
+		case notificacoes.CanalEmail:
+			if e.Status != notificacoes.StatusEntregaEnviada || e.Tentativas != 1 {
+				t.Errorf("e-mail: status %s, tentativas %d", e.Status, e.Tentativas)
+			}
+		case notificacoes.CanalWhatsApp:
+			if e.Status != notificacoes.StatusEntregaPendente || e.Tentativas != 1 || e.ProximaTentativa == nil || !e.ProximaTentativa.After(time.Now()) {
+				t.Errorf("whatsapp deveria aguardar nova tentativa: %+v", e)
+			}
+		case notificacoes.CanalWebhook:
+			if e.Tentativas != 0 || e.Erro != ErrCanalNaoConfigurado.Error() {
+				t.Errorf("webhook não configurado não deveria ser tentado: %+v", e)
+			}
+		}
+	}
+
+	// A retentativa só acontece depois do backoff
+	svc.ProcessarEntregas(context.Background())
+	if len(email.Enviadas()) != 1 {
+		t.Error("entregas já enviadas não devem ser repetidas")
+	}
+	if proxima := n.ProximaEntrega(); proxima == nil || !proxima.After(time.Now()) {
+		t.Errorf("a notificação deveria estar agendada para o backoff, obteve %v", proxima)
+	}
+}
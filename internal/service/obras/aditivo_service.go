@@ -0,0 +1,267 @@
+package obras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+	"github.com/luiszkm/masterCostrutora/pkg/auth"
+)
+
+var (
+	ErrAditivoObraEncerrada    = errors.New("obra concluída ou cancelada não aceita aditivos")
+	ErrAjusteCronogramaAditivo = errors.New("ajuste do cronograma de recebimento inválido para o aditivo")
+)
+
+// AjustadorCronograma cria ou refaz as parcelas do cronograma de recebimento. Os
+// métodos com db.DBTX gravam na transação do chamador, que publica as parcelas após o commit.
+type AjustadorCronograma interface {
+	GerarCronograma(ctx context.Context, obraID string, input dto.GerarCronogramaInput) ([]*dto.CronogramaRecebimentoOutput, error)
+	AcrescentarParcela(ctx context.Context, dbtx db.DBTX, obra *obras.Obra, input dto.CriarCronogramaRecebimentoInput) (*obras.CronogramaRecebimento, error)
+	SalvarCronogramaDoContrato(ctx context.Context, dbtx db.DBTX, obra *obras.Obra, input dto.GerarCronogramaInput) ([]*obras.CronogramaRecebimento, error)
+	PublicarCronogramaCriado(ctx context.Context, obra *obras.Obra, cronogramas []*obras.CronogramaRecebimento)
+}
+
+// AditivoService registra os aditivos de contrato e mantém o cronograma de
+// recebimento coerente com o contrato vigente.
+type AditivoService struct {
+	obraRepo       obras.ObrasRepository
+	aditivoRepo    obras.AditivoRepository
+	cronogramaRepo obras.CronogramaRecebimentoRepository
+	cronograma     AjustadorCronograma
+	logger         *slog.Logger
+	dbpool         *pgxpool.Pool
+}
+
+func NovoAditivoService(
+	obraRepo obras.ObrasRepository,
+	aditivoRepo obras.AditivoRepository,
+	cronogramaRepo obras.CronogramaRecebimentoRepository,
+	cronograma AjustadorCronograma,
+	logger *slog.Logger,
+	dbpool *pgxpool.Pool,
+) *AditivoService {
+	return &AditivoService{
+		obraRepo:       obraRepo,
+		aditivoRepo:    aditivoRepo,
+		cronogramaRepo: cronogramaRepo,
+		cronograma:     cronograma,
+		logger:         logger.With("service", "Aditivo"),
+		dbpool:         dbpool,
+	}
+}
+
+// RegistrarAditivo grava o aditivo, aplica o novo valor e prazo na obra e ajusta
+// o cronograma de recebimento conforme pedido, tudo na mesma transação: se o
+// ajuste falhar, o contrato não é alterado.
+func (s *AditivoService) RegistrarAditivo(ctx context.Context, obraID string, input dto.RegistrarAditivoInput) (*obras.Aditivo, error) {
+	const op = "service.obras.aditivo.RegistrarAditivo"
+
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if obra.Status == obras.StatusConcluida || obra.Status == obras.StatusCancelada {
+		return nil, fmt.Errorf("%s: %w", op, ErrAditivoObraEncerrada)
+	}
+	aditivos, err := s.aditivoRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	numero := 1
+	for _, a := range aditivos {
+		numero = max(numero, a.Numero+1)
+	}
+
+	novaDataFim, err := parseDataOpcional(&input.NovaDataFim)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	dataAprovacao, err := dataOuHoje(input.DataAprovacao)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	var documentoURL *string
+	if input.DocumentoURL != "" {
+		documentoURL = &input.DocumentoURL
+	}
+
+	agora := time.Now()
+	aditivo, err := obras.NovoAditivo(uuid.NewString(), obra, numero, input.Descricao, input.ValorAcrescimo,
+		novaDataFim, dataAprovacao, documentoURL, auth.UsuarioDoContexto(ctx), agora)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	parcela, err := s.validarAjuste(ctx, obra, aditivo, input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.dbpool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao iniciar transação: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.aditivoRepo.Salvar(ctx, tx, aditivo); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	aditivo.Aplicar(obra)
+	if err := s.obraRepo.Atualizar(ctx, tx, obra); err != nil {
+		return nil, fmt.Errorf("%s: falha ao aplicar aditivo na obra: %w", op, err)
+	}
+
+	var cronogramas []*obras.CronogramaRecebimento
+	switch input.AjusteCronograma {
+	case dto.AjusteCronogramaAcrescentar:
+		var nova *obras.CronogramaRecebimento
+		if nova, err = s.cronograma.AcrescentarParcela(ctx, tx, obra, *parcela); err == nil {
+			cronogramas = append(cronogramas, nova)
+		}
+	case dto.AjusteCronogramaRegerar:
+		geracao := *input.Geracao
+		geracao.SubstituirExistente = true
+		cronogramas, err = s.cronograma.SalvarCronogramaDoContrato(ctx, tx, obra, geracao)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao ajustar o cronograma de recebimento: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: falha ao fazer commit: %w", op, err)
+	}
+	s.cronograma.PublicarCronogramaCriado(ctx, obra, cronogramas)
+
+	s.logger.InfoContext(ctx, "aditivo registrado", "obra_id", obraID, "aditivo_id", aditivo.ID, "numero", aditivo.Numero,
+		"valor_acrescimo", aditivo.ValorAcrescimo, "valor_contrato", aditivo.ValorContratoResultante)
+	return aditivo, nil
+}
+
+// ListarAditivos lista os aditivos da obra em ordem de número.
+func (s *AditivoService) ListarAditivos(ctx context.Context, obraID string) ([]*obras.Aditivo, error) {
+	const op = "service.obras.aditivo.ListarAditivos"
+
+	if _, err := s.obraRepo.BuscarPorID(ctx, obraID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	aditivos, err := s.aditivoRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return aditivos, nil
+}
+
+// ObterContrato retorna a linha de base, o contrato vigente e o histórico de aditivos.
+func (s *AditivoService) ObterContrato(ctx context.Context, obraID string) (*dto.ContratoObraDTO, error) {
+	const op = "service.obras.aditivo.ObterContrato"
+
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	aditivos, err := s.aditivoRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return montarContratoObra(obra, aditivos), nil
+}
+
+// validarAjuste confere o ajuste de cronograma pedido contra o contrato resultante.
+// Para ACRESCENTAR, devolve a parcela a ser criada.
+func (s *AditivoService) validarAjuste(ctx context.Context, obra *obras.Obra, aditivo *obras.Aditivo, input dto.RegistrarAditivoInput) (*dto.CriarCronogramaRecebimentoInput, error) {
+	switch input.AjusteCronograma {
+	case dto.AjusteCronogramaNenhum:
+		return nil, nil
+	case dto.AjusteCronogramaAcrescentar, dto.AjusteCronogramaRegerar:
+	default:
+		return nil, fmt.Errorf("%w: use ACRESCENTAR ou REGERAR", ErrAjusteCronogramaAditivo)
+	}
+
+	// A geração a partir do contrato é validada ao salvar, na transação do aditivo
+	if input.AjusteCronograma == dto.AjusteCronogramaRegerar {
+		if input.Geracao == nil {
+			return nil, fmt.Errorf("%w: informe os parâmetros de geração", ErrAjusteCronogramaAditivo)
+		}
+		return nil, nil
+	}
+
+	cronogramas, err := s.cronogramaRepo.ListarPorObraID(ctx, obra.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if aditivo.ValorAcrescimo <= 0 {
+		return nil, fmt.Errorf("%w: só acréscimos de valor geram nova parcela", ErrAjusteCronogramaAditivo)
+	}
+	vencimento, err := parseDataOpcional(&input.DataVencimento)
+	if err != nil {
+		return nil, err
+	}
+	if vencimento == nil {
+		padrao := aditivo.DataAprovacao.AddDate(0, 0, prazoVencimentoMedicao)
+		vencimento = &padrao
+	}
+	numeroEtapa := 1
+	for _, c := range cronogramas {
+		numeroEtapa = max(numeroEtapa, c.NumeroEtapa+1)
+	}
+	return &dto.CriarCronogramaRecebimentoInput{
+		ObraID:         obra.ID,
+		NumeroEtapa:    numeroEtapa,
+		DescricaoEtapa: fmt.Sprintf("Aditivo nº %d", aditivo.Numero),
+		ValorPrevisto:  arredondar(aditivo.ValorAcrescimo),
+		DataVencimento: *vencimento,
+	}, nil
+}
+
+// montarContratoObra parte da linha de base (o contrato antes do primeiro aditivo)
+// e lista a assinatura e cada aditivo em ordem.
+func montarContratoObra(obra *obras.Obra, aditivos []*obras.Aditivo) *dto.ContratoObraDTO {
+	contrato := &dto.ContratoObraDTO{
+		ValorOriginal:      obra.ValorContratoTotal,
+		DataFimOriginal:    obra.DataFim,
+		ValorAtual:         obra.ValorContratoTotal,
+		DataFimAtual:       obra.DataFim,
+		Historico:          []dto.EventoContratoDTO{},
+		PercentualRetencao: obra.PercentualRetencao,
+	}
+	if len(aditivos) > 0 {
+		contrato.ValorOriginal = aditivos[0].ValorContratoAnterior
+		contrato.DataFimOriginal = aditivos[0].DataFimAnterior
+	}
+
+	if obra.DataAssinaturaContrato != nil {
+		contrato.Historico = append(contrato.Historico, dto.EventoContratoDTO{
+			Tipo:          "ASSINATURA",
+			Data:          *obra.DataAssinaturaContrato,
+			Descricao:     "Assinatura do contrato",
+			ValorContrato: contrato.ValorOriginal,
+			DataFim:       contrato.DataFimOriginal,
+		})
+	}
+	for _, a := range aditivos {
+		contrato.TotalAditivado += a.ValorAcrescimo
+		contrato.Historico = append(contrato.Historico, dto.EventoContratoDTO{
+			Tipo:           "ADITIVO",
+			Data:           a.DataAprovacao,
+			Descricao:      fmt.Sprintf("Aditivo nº %d: %s", a.Numero, a.Descricao),
+			AditivoID:      &a.ID,
+			ValorAcrescimo: a.ValorAcrescimo,
+			ValorContrato:  a.ValorContratoResultante,
+			DataFim:        a.DataFimResultante,
+			DocumentoURL:   a.DocumentoURL,
+		})
+	}
+	contrato.TotalAditivado = arredondar(contrato.TotalAditivado)
+	if contrato.ValorOriginal > 0 {
+		contrato.PercentualAditivado = arredondar(contrato.TotalAditivado / contrato.ValorOriginal * 100)
+	}
+	return contrato
+}
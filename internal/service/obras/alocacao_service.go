@@ -0,0 +1,301 @@
+package obras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+var (
+	ErrAlocacaoObraEncerrada    = errors.New("obra concluída ou cancelada não aceita alocações")
+	ErrAlocacaoNaoEncontrada    = errors.New("alocação não encontrada nesta obra")
+	ErrFuncionarioNaoEncontrado = errors.New("funcionário não encontrado ou desligado")
+)
+
+// AlocacaoQuerier monta o histórico de alocações com os nomes da obra e do funcionário.
+type AlocacaoQuerier interface {
+	ListarHistoricoAlocacoes(ctx context.Context, obraID, funcionarioID string) ([]*dto.AlocacaoHistoricoDTO, error)
+}
+
+// AlocacaoService controla o ciclo de vida das alocações: alocar, encerrar e
+// transferir funcionários, sem deixar ninguém em duas obras no mesmo dia sem justificativa.
+type AlocacaoService struct {
+	obraRepo      obras.ObrasRepository
+	alocacaoRepo  obras.AlocacaoRepository
+	pessoalFinder PessoalFinder
+	querier       AlocacaoQuerier
+	logger        *slog.Logger
+}
+
+func NovoAlocacaoService(
+	obraRepo obras.ObrasRepository,
+	alocacaoRepo obras.AlocacaoRepository,
+	pessoalFinder PessoalFinder,
+	querier AlocacaoQuerier,
+	logger *slog.Logger,
+) *AlocacaoService {
+	return &AlocacaoService{
+		obraRepo:      obraRepo,
+		alocacaoRepo:  alocacaoRepo,
+		pessoalFinder: pessoalFinder,
+		querier:       querier,
+		logger:        logger.With("service", "Alocacao"),
+	}
+}
+
+// AlocarFuncionarios aloca os funcionários na obra. Sobreposição com outra alocação
+// na mesma obra é sempre recusada; com outra obra, só passa com MotivoSobreposicao.
+func (s *AlocacaoService) AlocarFuncionarios(ctx context.Context, obraID string, input dto.AlocarFuncionariosInput) ([]*obras.Alocacao, error) {
+	const op = "service.obras.alocacao.AlocarFuncionarios"
+
+	if _, err := s.buscarObraAberta(ctx, obraID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(input.FuncionarioIDs) == 0 {
+		return nil, fmt.Errorf("%s: %w: informe ao menos um funcionário", op, obras.ErrAlocacaoInvalida)
+	}
+	inicio, err := time.Parse("2006-01-02", input.DataInicioAlocacao)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	fim, err := parseDataOpcional(&input.DataFimAlocacao)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	motivo := strings.TrimSpace(input.MotivoSobreposicao)
+	vistos := make(map[string]bool, len(input.FuncionarioIDs))
+	alocacoesParaSalvar := make([]*obras.Alocacao, 0, len(input.FuncionarioIDs))
+	for _, funcionarioID := range input.FuncionarioIDs {
+		if vistos[funcionarioID] {
+			return nil, fmt.Errorf("%s: %w: funcionário %s repetido", op, obras.ErrAlocacaoInvalida, funcionarioID)
+		}
+		vistos[funcionarioID] = true
+
+		if _, err := s.pessoalFinder.BuscarPorID(ctx, funcionarioID); err != nil {
+			if errors.Is(err, postgres.ErrNaoEncontrado) {
+				return nil, fmt.Errorf("%s: %w: %s", op, ErrFuncionarioNaoEncontrado, funcionarioID)
+			}
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		nova, err := obras.NovaAlocacao(uuid.NewString(), obraID, funcionarioID, inicio, fim)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if err := s.verificarConflitos(ctx, nova, "", motivo); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		alocacoesParaSalvar = append(alocacoesParaSalvar, nova)
+	}
+
+	if err := s.alocacaoRepo.SalvarMuitos(ctx, alocacoesParaSalvar); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s.logger.InfoContext(ctx, "funcionários alocados com sucesso", "obra_id", obraID, "quantidade", len(alocacoesParaSalvar))
+	return alocacoesParaSalvar, nil
+}
+
+// EncerrarAlocacao define o último dia do funcionário na obra (padrão: hoje).
+func (s *AlocacaoService) EncerrarAlocacao(ctx context.Context, obraID, alocacaoID string, input dto.EncerrarAlocacaoInput) (*obras.Alocacao, error) {
+	const op = "service.obras.alocacao.EncerrarAlocacao"
+
+	alocacao, err := s.buscarAlocacaoDaObra(ctx, obraID, alocacaoID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	data := time.Now()
+	if input.DataFim != "" {
+		if data, err = time.Parse("2006-01-02", input.DataFim); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err := alocacao.Encerrar(data, input.Motivo); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.alocacaoRepo.Atualizar(ctx, alocacao); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "alocação encerrada", "obra_id", obraID, "alocacao_id", alocacaoID, "data_fim", alocacao.DataFimAlocacao)
+	return alocacao, nil
+}
+
+// TransferirAlocacao encerra a alocação na véspera da data informada e aloca o
+// funcionário na obra de destino a partir dela, mantendo o fim previsto, se houver.
+func (s *AlocacaoService) TransferirAlocacao(ctx context.Context, obraID, alocacaoID string, input dto.TransferirAlocacaoInput) (*dto.TransferenciaAlocacaoOutput, error) {
+	const op = "service.obras.alocacao.TransferirAlocacao"
+
+	if input.ObraDestinoID == "" || input.ObraDestinoID == obraID {
+		return nil, fmt.Errorf("%s: %w: informe uma obra de destino diferente da atual", op, obras.ErrAlocacaoInvalida)
+	}
+	origem, err := s.buscarAlocacaoDaObra(ctx, obraID, alocacaoID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	destino, err := s.buscarObraAberta(ctx, input.ObraDestinoID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	data := time.Now()
+	if input.Data != "" {
+		if data, err = time.Parse("2006-01-02", input.Data); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	fimPrevisto := origem.DataFimAlocacao
+
+	motivo := strings.TrimSpace(input.Motivo)
+	if motivo == "" {
+		motivo = fmt.Sprintf("Transferido para a obra %s", destino.Nome)
+	}
+	if err := origem.Encerrar(data.AddDate(0, 0, -1), motivo); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	nova, err := obras.NovaAlocacao(uuid.NewString(), destino.ID, origem.FuncionarioID, data, fimPrevisto)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	nova.TransferidaDeID = &origem.ID
+	if err := s.verificarConflitos(ctx, nova, origem.ID, input.MotivoSobreposicao); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.alocacaoRepo.Transferir(ctx, origem, nova); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "alocação transferida", "obra_origem", obraID, "obra_destino", destino.ID,
+		"funcionario_id", origem.FuncionarioID, "alocacao_id", nova.ID)
+	return &dto.TransferenciaAlocacaoOutput{Encerrada: origem, Nova: nova}, nil
+}
+
+// ListarAlocacoesDaObra retorna o histórico de alocações da obra.
+func (s *AlocacaoService) ListarAlocacoesDaObra(ctx context.Context, obraID string) ([]*dto.AlocacaoHistoricoDTO, error) {
+	const op = "service.obras.alocacao.ListarAlocacoesDaObra"
+
+	if _, err := s.obraRepo.BuscarPorID(ctx, obraID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	historico, err := s.querier.ListarHistoricoAlocacoes(ctx, obraID, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return marcarAtivas(historico), nil
+}
+
+// ListarAlocacoesDoFuncionario retorna por onde o funcionário passou, da mais recente à mais antiga.
+func (s *AlocacaoService) ListarAlocacoesDoFuncionario(ctx context.Context, funcionarioID string) ([]*dto.AlocacaoHistoricoDTO, error) {
+	const op = "service.obras.alocacao.ListarAlocacoesDoFuncionario"
+
+	historico, err := s.querier.ListarHistoricoAlocacoes(ctx, "", funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return marcarAtivas(historico), nil
+}
+
+// EncerrarAlocacoesDoFuncionario encerra na data as alocações do funcionário que
+// seguiriam depois dela e remove as que ainda nem começaram, na transação do
+// desligamento. Retorna quantas alocações foram afetadas.
+func (s *AlocacaoService) EncerrarAlocacoesDoFuncionario(ctx context.Context, dbtx db.DBTX, funcionarioID string, data time.Time, motivo string) (int, error) {
+	const op = "service.obras.alocacao.EncerrarAlocacoesDoFuncionario"
+
+	data = time.Date(data.Year(), data.Month(), data.Day(), 0, 0, 0, 0, time.UTC)
+	alocacoes, err := s.alocacaoRepo.ListarAtivasDoFuncionario(ctx, dbtx, funcionarioID, data)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	encerradas, canceladas, err := obras.EncerrarNoDesligamento(alocacoes, data, motivo)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.alocacaoRepo.AtualizarMuitas(ctx, dbtx, encerradas); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	ids := make([]string, len(canceladas))
+	for i, a := range canceladas {
+		ids[i] = a.ID
+	}
+	if err := s.alocacaoRepo.DeletarMuitas(ctx, dbtx, ids); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(encerradas)+len(canceladas) > 0 {
+		s.logger.InfoContext(ctx, "alocações do funcionário encerradas", "funcionario_id", funcionarioID,
+			"encerradas", len(encerradas), "canceladas", len(canceladas))
+	}
+	return len(encerradas) + len(canceladas), nil
+}
+
+// verificarConflitos recusa a alocação se o funcionário já estiver na mesma obra no
+// período; em outra obra, aceita se houver motivo e o registra na nova alocação.
+func (s *AlocacaoService) verificarConflitos(ctx context.Context, nova *obras.Alocacao, ignorarID, motivo string) error {
+	existentes, err := s.alocacaoRepo.ListarPorFuncionarioID(ctx, nova.FuncionarioID)
+	if err != nil {
+		return err
+	}
+	conflitos := nova.Conflitos(existentes, ignorarID)
+	if len(conflitos) == 0 {
+		return nil
+	}
+
+	motivo = strings.TrimSpace(motivo)
+	for _, c := range conflitos {
+		if c.ObraID == nova.ObraID {
+			return &obras.ErroConflitoAlocacao{Conflitos: conflitos}
+		}
+	}
+	if motivo == "" {
+		return &obras.ErroConflitoAlocacao{Conflitos: conflitos}
+	}
+	nova.MotivoSobreposicao = &motivo
+	s.logger.WarnContext(ctx, "alocação sobreposta autorizada", "funcionario_id", nova.FuncionarioID,
+		"obra_id", nova.ObraID, "conflitos", len(conflitos), "motivo", motivo)
+	return nil
+}
+
+func (s *AlocacaoService) buscarObraAberta(ctx context.Context, obraID string) (*obras.Obra, error) {
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, err
+	}
+	if obra.Status == obras.StatusConcluida || obra.Status == obras.StatusCancelada {
+		return nil, ErrAlocacaoObraEncerrada
+	}
+	return obra, nil
+}
+
+func (s *AlocacaoService) buscarAlocacaoDaObra(ctx context.Context, obraID, alocacaoID string) (*obras.Alocacao, error) {
+	alocacao, err := s.alocacaoRepo.BuscarPorID(ctx, alocacaoID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNaoEncontrado) {
+			return nil, ErrAlocacaoNaoEncontrada
+		}
+		return nil, err
+	}
+	if alocacao.ObraID != obraID {
+		return nil, ErrAlocacaoNaoEncontrada
+	}
+	return alocacao, nil
+}
+
+func marcarAtivas(historico []*dto.AlocacaoHistoricoDTO) []*dto.AlocacaoHistoricoDTO {
+	hoje := time.Now()
+	for _, h := range historico {
+		a := obras.Alocacao{DataInicioAlocacao: h.DataInicioAlocacao, DataFimAlocacao: h.DataFimAlocacao}
+		h.Ativa = a.AtivaEm(hoje)
+	}
+	return historico
+}
@@ -0,0 +1,164 @@
+package obras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// Limites do horizonte do calendário de capacidade, em semanas.
+const (
+	SemanasCapacidadePadrao = 8
+	SemanasCapacidadeMaximo = 52
+)
+
+var ErrHorizonteCapacidadeInvalido = errors.New("o horizonte deve ter entre 1 e 52 semanas")
+
+// CapacidadeQuerier fornece o efetivo disponível e a demanda planejada das obras.
+type CapacidadeQuerier interface {
+	ListarFuncionariosDisponiveis(ctx context.Context) ([]obras.FuncionarioCapacidade, error)
+	ListarDemandasNoPeriodo(ctx context.Context, inicio, fim time.Time) ([]obras.DemandaEfetivo, error)
+}
+
+// CapacidadeService mantém o efetivo planejado das etapas e monta o calendário
+// de capacidade: quem está alocado, quem está livre e onde vai faltar gente.
+type CapacidadeService struct {
+	obraRepo     obras.ObrasRepository
+	etapaRepo    obras.EtapaRepository
+	efetivoRepo  obras.EfetivoPlanejadoRepository
+	alocacaoRepo obras.AlocacaoRepository
+	querier      CapacidadeQuerier
+	logger       *slog.Logger
+}
+
+func NovoCapacidadeService(
+	obraRepo obras.ObrasRepository,
+	etapaRepo obras.EtapaRepository,
+	efetivoRepo obras.EfetivoPlanejadoRepository,
+	alocacaoRepo obras.AlocacaoRepository,
+	querier CapacidadeQuerier,
+	logger *slog.Logger,
+) *CapacidadeService {
+	return &CapacidadeService{
+		obraRepo:     obraRepo,
+		etapaRepo:    etapaRepo,
+		efetivoRepo:  efetivoRepo,
+		alocacaoRepo: alocacaoRepo,
+		querier:      querier,
+		logger:       logger.With("service", "Capacidade"),
+	}
+}
+
+// DefinirEfetivoPlanejado substitui o efetivo que cada etapa da obra precisa, por cargo.
+func (s *CapacidadeService) DefinirEfetivoPlanejado(ctx context.Context, obraID string, input dto.DefinirEfetivoPlanejadoInput) ([]*obras.EfetivoPlanejado, error) {
+	const op = "service.obras.capacidade.DefinirEfetivoPlanejado"
+
+	if _, err := s.obraRepo.BuscarPorID(ctx, obraID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	etapas, err := s.etapaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	itens := make([]*obras.EfetivoPlanejado, 0, len(input.Itens))
+	for _, item := range input.Itens {
+		if !contemEtapa(etapas, item.EtapaID) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEtapaForaDaObra)
+		}
+		itens = append(itens, &obras.EfetivoPlanejado{
+			ID:         uuid.NewString(),
+			ObraID:     obraID,
+			EtapaID:    item.EtapaID,
+			Cargo:      item.Cargo,
+			Quantidade: item.Quantidade,
+		})
+	}
+	if err := obras.ValidarEfetivoPlanejado(itens); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.efetivoRepo.Salvar(ctx, obraID, itens); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "efetivo planejado definido", "obra_id", obraID, "linhas", len(itens))
+	return itens, nil
+}
+
+// ListarEfetivoPlanejado retorna o efetivo planejado das etapas da obra.
+func (s *CapacidadeService) ListarEfetivoPlanejado(ctx context.Context, obraID string) ([]*obras.EfetivoPlanejado, error) {
+	const op = "service.obras.capacidade.ListarEfetivoPlanejado"
+
+	if _, err := s.obraRepo.BuscarPorID(ctx, obraID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	itens, err := s.efetivoRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return itens, nil
+}
+
+// ObterCapacidade monta o calendário semanal por cargo, a partir da segunda-feira
+// da data de início, cruzando funcionários ativos, alocações e o efetivo planejado
+// das etapas previstas de obras em planejamento ou em andamento.
+func (s *CapacidadeService) ObterCapacidade(ctx context.Context, filtro dto.FiltroCapacidadeInput) (*dto.CapacidadeOutput, error) {
+	const op = "service.obras.capacidade.ObterCapacidade"
+
+	inicio := time.Now()
+	if filtro.Inicio != "" {
+		data, err := time.Parse("2006-01-02", filtro.Inicio)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		inicio = data
+	}
+	semanas := SemanasCapacidadePadrao
+	if filtro.Semanas != "" {
+		n, err := strconv.Atoi(filtro.Semanas)
+		if err != nil || n < 1 || n > SemanasCapacidadeMaximo {
+			return nil, fmt.Errorf("%s: %w", op, ErrHorizonteCapacidadeInvalido)
+		}
+		semanas = n
+	}
+	inicio = obras.InicioSemana(inicio)
+	fim := inicio.AddDate(0, 0, 7*semanas-1)
+
+	funcionarios, err := s.querier.ListarFuncionariosDisponiveis(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	alocacoes, err := s.alocacaoRepo.ListarNoPeriodo(ctx, inicio, fim)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	demandas, err := s.querier.ListarDemandasNoPeriodo(ctx, inicio, fim)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	output := &dto.CapacidadeOutput{
+		Inicio:  inicio,
+		Fim:     fim,
+		Semanas: obras.CalcularCapacidade(inicio, semanas, funcionarios, alocacoes, demandas),
+	}
+	for _, semana := range output.Semanas {
+		for _, c := range semana.Cargos {
+			if c.Falta > 0 {
+				output.TotalFaltas++
+			}
+			if len(c.Sobrealocados) > 0 {
+				output.TotalSobrealocacoes++
+			}
+		}
+	}
+	return output, nil
+}
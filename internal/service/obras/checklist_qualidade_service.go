@@ -0,0 +1,367 @@
+package obras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+	"github.com/luiszkm/masterCostrutora/pkg/auth"
+)
+
+var (
+	ErrModeloChecklistNaoEncontrado  = errors.New("modelo de checklist não encontrado")
+	ErrEtapaPadraoNaoEncontrada      = errors.New("etapa padrão não encontrada")
+	ErrChecklistNaoEncontrada        = errors.New("checklist não encontrada")
+	ErrChecklistsObrigatoriasAbertas = errors.New("há checklists de qualidade obrigatórias não aprovadas na etapa")
+)
+
+// ChecklistQualidadeService mantém os modelos de FVS (ficha de verificação de
+// serviço) do catálogo, instancia as FVS nas etapas das obras e registra as inspeções.
+type ChecklistQualidadeService struct {
+	checklistRepo   obras.ChecklistQualidadeRepository
+	etapaRepo       obras.EtapaRepository
+	etapaPadraoRepo obras.EtapaPadraoRepository
+	logger          *slog.Logger
+	dbpool          *pgxpool.Pool
+}
+
+func NovoChecklistQualidadeService(
+	checklistRepo obras.ChecklistQualidadeRepository,
+	etapaRepo obras.EtapaRepository,
+	etapaPadraoRepo obras.EtapaPadraoRepository,
+	logger *slog.Logger,
+	dbpool *pgxpool.Pool,
+) *ChecklistQualidadeService {
+	return &ChecklistQualidadeService{
+		checklistRepo:   checklistRepo,
+		etapaRepo:       etapaRepo,
+		etapaPadraoRepo: etapaPadraoRepo,
+		logger:          logger.With("service", "ChecklistQualidade"),
+		dbpool:          dbpool,
+	}
+}
+
+// CriarModelo cadastra um modelo de FVS para uma etapa padrão. Vale para as etapas
+// criadas a partir de então; as existentes recebem a FVS por AplicarChecklists.
+func (s *ChecklistQualidadeService) CriarModelo(ctx context.Context, input dto.SalvarModeloChecklistInput) (*obras.ModeloChecklist, error) {
+	const op = "service.obras.checklist_qualidade.CriarModelo"
+
+	modelo, err := s.montarModelo(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	agora := time.Now()
+	modelo.ID = uuid.NewString()
+	modelo.CreatedAt = agora
+	modelo.UpdatedAt = agora
+	if err := s.checklistRepo.SalvarModelo(ctx, modelo); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "modelo de checklist criado", "modelo_id", modelo.ID, "etapa_padrao_id", modelo.EtapaPadraoID, "itens", len(modelo.Itens))
+	return modelo, nil
+}
+
+// AtualizarModelo substitui o modelo. As FVS já instanciadas nas etapas não mudam.
+func (s *ChecklistQualidadeService) AtualizarModelo(ctx context.Context, modeloID string, input dto.SalvarModeloChecklistInput) (*obras.ModeloChecklist, error) {
+	const op = "service.obras.checklist_qualidade.AtualizarModelo"
+
+	atual, err := s.BuscarModelo(ctx, modeloID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	modelo, err := s.montarModelo(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	modelo.ID = atual.ID
+	modelo.CreatedAt = atual.CreatedAt
+	modelo.UpdatedAt = time.Now()
+	if err := s.checklistRepo.AtualizarModelo(ctx, modelo); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return modelo, nil
+}
+
+func (s *ChecklistQualidadeService) BuscarModelo(ctx context.Context, modeloID string) (*obras.ModeloChecklist, error) {
+	const op = "service.obras.checklist_qualidade.BuscarModelo"
+
+	modelo, err := s.checklistRepo.BuscarModeloPorID(ctx, modeloID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNaoEncontrado) {
+			return nil, ErrModeloChecklistNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return modelo, nil
+}
+
+// ListarModelos lista os modelos de FVS, opcionalmente de uma etapa padrão.
+func (s *ChecklistQualidadeService) ListarModelos(ctx context.Context, etapaPadraoID string) ([]*obras.ModeloChecklist, error) {
+	const op = "service.obras.checklist_qualidade.ListarModelos"
+
+	modelos, err := s.checklistRepo.ListarModelos(ctx, etapaPadraoID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return modelos, nil
+}
+
+func (s *ChecklistQualidadeService) DeletarModelo(ctx context.Context, modeloID string) error {
+	const op = "service.obras.checklist_qualidade.DeletarModelo"
+
+	if err := s.checklistRepo.DeletarModelo(ctx, modeloID); err != nil {
+		if errors.Is(err, postgres.ErrNaoEncontrado) {
+			return ErrModeloChecklistNaoEncontrado
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// InstanciarChecklists copia para cada etapa nova os modelos da etapa padrão que a
+// originou, na transação em que as etapas são gravadas.
+func (s *ChecklistQualidadeService) InstanciarChecklists(ctx context.Context, dbtx db.DBTX, etapas []*obras.Etapa) error {
+	if len(etapas) == 0 {
+		return nil
+	}
+	porEtapaPadrao, err := s.modelosPorEtapaPadrao(ctx)
+	if err != nil {
+		return err
+	}
+	agora := time.Now()
+	var checklists []*obras.ChecklistEtapa
+	for _, etapa := range etapas {
+		for _, modelo := range modelosDaEtapa(porEtapaPadrao, etapa) {
+			checklists = append(checklists, modelo.Instanciar(uuid.NewString(), etapa, agora))
+		}
+	}
+	return s.checklistRepo.SalvarChecklists(ctx, dbtx, checklists)
+}
+
+// VerificarConclusao retorna ErrChecklistsObrigatoriasAbertas, com os nomes das
+// FVS, enquanto alguma FVS obrigatória da etapa não estiver aprovada.
+func (s *ChecklistQualidadeService) VerificarConclusao(ctx context.Context, etapaID string) error {
+	checklists, err := s.checklistRepo.ListarChecklistsPorEtapaID(ctx, etapaID)
+	if err != nil {
+		return err
+	}
+	pendentes := obras.ChecklistsObrigatoriasPendentes(checklists)
+	if len(pendentes) == 0 {
+		return nil
+	}
+	nomes := make([]string, len(pendentes))
+	for i, c := range pendentes {
+		nomes[i] = fmt.Sprintf("%s (%s)", c.Nome, c.Status)
+	}
+	return fmt.Errorf("%w: %s", ErrChecklistsObrigatoriasAbertas, strings.Join(nomes, ", "))
+}
+
+// ListarChecklistsObra lista as FVS de todas as etapas da obra com os totais.
+func (s *ChecklistQualidadeService) ListarChecklistsObra(ctx context.Context, obraID string) (*dto.ChecklistsObraOutput, error) {
+	const op = "service.obras.checklist_qualidade.ListarChecklistsObra"
+
+	checklists, err := s.checklistRepo.ListarChecklistsPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	out := &dto.ChecklistsObraOutput{
+		ObraID:                obraID,
+		Checklists:            checklists,
+		Total:                 len(checklists),
+		ObrigatoriasPendentes: len(obras.ChecklistsObrigatoriasPendentes(checklists)),
+	}
+	for _, c := range checklists {
+		switch c.Status {
+		case obras.StatusChecklistAprovada:
+			out.Aprovadas++
+		case obras.StatusChecklistReprovada:
+			out.Reprovadas++
+		}
+	}
+	return out, nil
+}
+
+func (s *ChecklistQualidadeService) ListarChecklistsEtapa(ctx context.Context, etapaID string) ([]*obras.ChecklistEtapa, error) {
+	const op = "service.obras.checklist_qualidade.ListarChecklistsEtapa"
+
+	if _, err := s.etapaRepo.BuscarPorID(ctx, etapaID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	checklists, err := s.checklistRepo.ListarChecklistsPorEtapaID(ctx, etapaID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return checklists, nil
+}
+
+// AplicarChecklists instancia na etapa o modelo informado ou, sem modelo, os modelos
+// da etapa padrão que a originou que a etapa ainda não tem. Serve para etapas
+// criadas antes do modelo e, com o modelo informado, para etapas fora do catálogo.
+func (s *ChecklistQualidadeService) AplicarChecklists(ctx context.Context, etapaID string, input dto.AplicarChecklistsInput) ([]*obras.ChecklistEtapa, error) {
+	const op = "service.obras.checklist_qualidade.AplicarChecklists"
+
+	etapa, err := s.etapaRepo.BuscarPorID(ctx, etapaID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	var modelos []*obras.ModeloChecklist
+	if input.ModeloID != "" {
+		modelo, err := s.BuscarModelo(ctx, input.ModeloID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		modelos = []*obras.ModeloChecklist{modelo}
+	} else {
+		porEtapaPadrao, err := s.modelosPorEtapaPadrao(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		modelos = modelosDaEtapa(porEtapaPadrao, etapa)
+	}
+
+	existentes, err := s.checklistRepo.ListarChecklistsPorEtapaID(ctx, etapaID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	aplicados := make(map[string]bool, len(existentes))
+	for _, c := range existentes {
+		if c.ModeloID != nil {
+			aplicados[*c.ModeloID] = true
+		}
+	}
+	agora := time.Now()
+	var novas []*obras.ChecklistEtapa
+	for _, modelo := range modelos {
+		if !aplicados[modelo.ID] {
+			novas = append(novas, modelo.Instanciar(uuid.NewString(), etapa, agora))
+		}
+	}
+	if err := s.checklistRepo.SalvarChecklists(ctx, s.dbpool, novas); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "checklists aplicadas na etapa", "etapa_id", etapaID, "novas", len(novas))
+	return append(existentes, novas...), nil
+}
+
+// RegistrarInspecao registra a inspeção (ou reinspeção) da FVS da obra.
+func (s *ChecklistQualidadeService) RegistrarInspecao(ctx context.Context, obraID, checklistID string, input dto.RegistrarInspecaoInput) (*obras.ChecklistEtapa, error) {
+	const op = "service.obras.checklist_qualidade.RegistrarInspecao"
+
+	checklist, err := s.buscarDaObra(ctx, obraID, checklistID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	data, err := dataOuHoje(input.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	dados := obras.DadosInspecao{
+		Data:        data,
+		Inspetor:    input.Inspetor,
+		Itens:       input.Itens,
+		Evidencias:  input.Evidencias,
+		Observacoes: input.Observacoes,
+	}
+	usuarioID := auth.UsuarioDoContexto(ctx)
+	inspecao, err := checklist.RegistrarInspecao(dados, usuarioID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.checklistRepo.AtualizarChecklist(ctx, checklist); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "inspeção de checklist registrada",
+		"obra_id", obraID, "checklist_id", checklistID, "tipo", inspecao.Tipo, "resultado", inspecao.Resultado, "usuario_id", usuarioID)
+	return checklist, nil
+}
+
+// RemoverChecklist retira da etapa uma FVS que ainda não foi inspecionada.
+func (s *ChecklistQualidadeService) RemoverChecklist(ctx context.Context, obraID, checklistID string) error {
+	const op = "service.obras.checklist_qualidade.RemoverChecklist"
+
+	checklist, err := s.buscarDaObra(ctx, obraID, checklistID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := checklist.PodeRemover(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.checklistRepo.DeletarChecklist(ctx, checklistID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// montarModelo valida o payload e confere que a etapa padrão existe no catálogo.
+func (s *ChecklistQualidadeService) montarModelo(ctx context.Context, input dto.SalvarModeloChecklistInput) (*obras.ModeloChecklist, error) {
+	modelo := &obras.ModeloChecklist{
+		EtapaPadraoID: input.EtapaPadraoID,
+		Nome:          input.Nome,
+		Descricao:     strings.TrimSpace(input.Descricao),
+		Obrigatorio:   input.Obrigatorio,
+		Itens:         input.Itens,
+	}
+	if err := modelo.Validar(); err != nil {
+		return nil, err
+	}
+	catalogo, err := s.etapaPadraoRepo.ListarTodas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range catalogo {
+		if e.ID == modelo.EtapaPadraoID {
+			return modelo, nil
+		}
+	}
+	return nil, ErrEtapaPadraoNaoEncontrada
+}
+
+// modelosPorEtapaPadrao agrupa os modelos pela etapa padrão a que pertencem.
+func (s *ChecklistQualidadeService) modelosPorEtapaPadrao(ctx context.Context) (map[string][]*obras.ModeloChecklist, error) {
+	modelos, err := s.checklistRepo.ListarModelos(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	porEtapaPadrao := make(map[string][]*obras.ModeloChecklist)
+	for _, m := range modelos {
+		porEtapaPadrao[m.EtapaPadraoID] = append(porEtapaPadrao[m.EtapaPadraoID], m)
+	}
+	return porEtapaPadrao, nil
+}
+
+// modelosDaEtapa devolve os modelos da etapa padrão que originou a etapa. O nome
+// não é usado: etapas renomeadas mantêm as FVS e etapas fora do catálogo não
+// recebem nenhuma.
+func modelosDaEtapa(porEtapaPadrao map[string][]*obras.ModeloChecklist, etapa *obras.Etapa) []*obras.ModeloChecklist {
+	if etapa.EtapaPadraoID == nil {
+		return nil
+	}
+	return porEtapaPadrao[*etapa.EtapaPadraoID]
+}
+
+// buscarDaObra busca a FVS e confere que ela pertence à obra da rota.
+func (s *ChecklistQualidadeService) buscarDaObra(ctx context.Context, obraID, checklistID string) (*obras.ChecklistEtapa, error) {
+	checklist, err := s.checklistRepo.BuscarChecklistPorID(ctx, checklistID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNaoEncontrado) {
+			return nil, ErrChecklistNaoEncontrada
+		}
+		return nil, err
+	}
+	if checklist.ObraID != obraID {
+		return nil, ErrChecklistNaoEncontrada
+	}
+	return checklist, nil
+}
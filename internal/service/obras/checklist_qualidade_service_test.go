@@ -0,0 +1,54 @@
+package obras
+
+import (
+	"testing"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+)
+
+func TestModelosDaEtapa(t *testing.T) {
+	fundacao := "ep-fundacao"
+	alvenaria := "ep-alvenaria"
+	porEtapaPadrao := map[string][]*obras.ModeloChecklist{
+		fundacao: {{ID: "fvs-armadura"}, {ID: "fvs-concretagem"}},
+	}
+
+	casos := []struct {
+		nome     string
+		etapa    *obras.Etapa
+		esperado []string
+	}{
+		{
+			nome:     "etapa criada da etapa padrão",
+			etapa:    &obras.Etapa{Nome: "Fundação", EtapaPadraoID: &fundacao},
+			esperado: []string{"fvs-armadura", "fvs-concretagem"},
+		},
+		{
+			nome:     "etapa renomeada mantém as FVS",
+			etapa:    &obras.Etapa{Nome: "Fundação bloco B", EtapaPadraoID: &fundacao},
+			esperado: []string{"fvs-armadura", "fvs-concretagem"},
+		},
+		{
+			nome:  "etapa padrão sem modelos",
+			etapa: &obras.Etapa{Nome: "Alvenaria", EtapaPadraoID: &alvenaria},
+		},
+		{
+			nome:  "mesmo nome fora do catálogo",
+			etapa: &obras.Etapa{Nome: "Fundação"},
+		},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			modelos := modelosDaEtapa(porEtapaPadrao, tc.etapa)
+			if len(modelos) != len(tc.esperado) {
+				t.Fatalf("modelosDaEtapa() = %d modelos, esperado %d", len(modelos), len(tc.esperado))
+			}
+			for i, m := range modelos {
+				if m.ID != tc.esperado[i] {
+					t.Errorf("modelo %d = %s, esperado %s", i, m.ID, tc.esperado[i])
+				}
+			}
+		})
+	}
+}
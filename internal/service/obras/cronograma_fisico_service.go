@@ -0,0 +1,205 @@
+package obras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// ErrEtapaForaDaObra é retornado quando uma dependência referencia etapa de outra obra.
+var ErrEtapaForaDaObra = errors.New("etapa não pertence à obra")
+
+// CronogramaFisicoService mantém as dependências entre etapas e a programação da obra.
+type CronogramaFisicoService struct {
+	obraRepo        obras.ObrasRepository
+	etapaRepo       obras.EtapaRepository
+	dependenciaRepo obras.DependenciaEtapaRepository
+	logger          *slog.Logger
+}
+
+func NovoCronogramaFisicoService(
+	obraRepo obras.ObrasRepository,
+	etapaRepo obras.EtapaRepository,
+	dependenciaRepo obras.DependenciaEtapaRepository,
+	logger *slog.Logger,
+) *CronogramaFisicoService {
+	return &CronogramaFisicoService{
+		obraRepo:        obraRepo,
+		etapaRepo:       etapaRepo,
+		dependenciaRepo: dependenciaRepo,
+		logger:          logger.With("service", "CronogramaFisico"),
+	}
+}
+
+// ObterCronogramaFisico calcula a programação, as folgas e o caminho crítico da obra.
+func (s *CronogramaFisicoService) ObterCronogramaFisico(ctx context.Context, obraID string) (*dto.CronogramaFisicoOutput, error) {
+	const op = "service.obras.cronograma_fisico.ObterCronogramaFisico"
+
+	cronograma, err := s.calcular(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	output := &dto.CronogramaFisicoOutput{
+		ObraID:         obraID,
+		DataInicio:     cronograma.Inicio,
+		DataFim:        cronograma.Fim,
+		DuracaoDias:    cronograma.DuracaoDias,
+		CaminhoCritico: cronograma.CaminhoCritico,
+		Etapas:         make([]dto.EtapaCronogramaFisicoItem, 0, len(cronograma.Itens)),
+	}
+	for _, item := range cronograma.Itens {
+		e := item.Etapa
+		predecessoras := item.Predecessoras
+		if predecessoras == nil {
+			predecessoras = []*obras.DependenciaEtapa{}
+		}
+		output.Etapas = append(output.Etapas, dto.EtapaCronogramaFisicoItem{
+			EtapaID:             e.ID,
+			Nome:                e.Nome,
+			Status:              e.Status,
+			DataInicio:          item.Inicio,
+			DataFim:             item.Fim,
+			DuracaoDias:         item.DuracaoDias,
+			DataInicioPrevista:  e.DataInicioPrevista,
+			DataFimPrevista:     e.DataFimPrevista,
+			DataInicioReal:      e.DataInicioReal,
+			DataFimReal:         e.DataFimReal,
+			PercentualExecutado: e.PercentualExecutado,
+			Peso:                e.Peso,
+			FolgaDias:           item.FolgaDias,
+			Critica:             item.Critica,
+			Predecessoras:       predecessoras,
+		})
+	}
+	return output, nil
+}
+
+// AdicionarDependencia cria a dependência, recusando ciclos, e reprograma a obra.
+func (s *CronogramaFisicoService) AdicionarDependencia(ctx context.Context, obraID string, input dto.AdicionarDependenciaInput) (*obras.DependenciaEtapa, error) {
+	const op = "service.obras.cronograma_fisico.AdicionarDependencia"
+
+	tipo := obras.TipoDependencia(input.Tipo)
+	if tipo == "" {
+		tipo = obras.DependenciaTerminoInicio
+	}
+	dependencia := &obras.DependenciaEtapa{
+		ID:             uuid.NewString(),
+		ObraID:         obraID,
+		EtapaID:        input.EtapaID,
+		PredecessoraID: input.PredecessoraID,
+		Tipo:           tipo,
+		LagDias:        input.LagDias,
+		CreatedAt:      time.Now(),
+	}
+	if err := dependencia.Validar(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	etapas, err := s.etapaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !contemEtapa(etapas, dependencia.EtapaID) || !contemEtapa(etapas, dependencia.PredecessoraID) {
+		return nil, fmt.Errorf("%s: %w", op, ErrEtapaForaDaObra)
+	}
+
+	dependencias, err := s.dependenciaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, d := range dependencias {
+		if d.EtapaID == dependencia.EtapaID && d.PredecessoraID == dependencia.PredecessoraID {
+			return nil, fmt.Errorf("%s: %w: dependência já cadastrada", op, obras.ErrDependenciaInvalida)
+		}
+	}
+	if err := obras.VerificarCiclos(etapas, append(dependencias, dependencia)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.dependenciaRepo.Salvar(ctx, dependencia); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := s.Reprogramar(ctx, obraID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "dependência entre etapas criada",
+		"obra_id", obraID, "etapa_id", dependencia.EtapaID, "predecessora_id", dependencia.PredecessoraID, "tipo", dependencia.Tipo)
+	return dependencia, nil
+}
+
+// RemoverDependencia exclui a dependência. As datas já reprogramadas são mantidas.
+func (s *CronogramaFisicoService) RemoverDependencia(ctx context.Context, obraID, dependenciaID string) error {
+	const op = "service.obras.cronograma_fisico.RemoverDependencia"
+
+	dependencia, err := s.dependenciaRepo.BuscarPorID(ctx, dependenciaID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if dependencia.ObraID != obraID {
+		return fmt.Errorf("%s: %w", op, ErrEtapaForaDaObra)
+	}
+	if err := s.dependenciaRepo.Deletar(ctx, dependenciaID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Reprogramar recalcula o cronograma e grava as datas programadas das etapas
+// pendentes empurradas por atrasos das predecessoras, ou as limpa quando voltam à previsão.
+func (s *CronogramaFisicoService) Reprogramar(ctx context.Context, obraID string) (int, error) {
+	const op = "service.obras.cronograma_fisico.Reprogramar"
+
+	cronograma, err := s.calcular(ctx, obraID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	alteradas := cronograma.AplicarReprogramacao()
+	for _, e := range alteradas {
+		if err := s.etapaRepo.Atualizar(ctx, e); err != nil {
+			return 0, fmt.Errorf("%s: falha ao reprogramar etapa %s: %w", op, e.ID, err)
+		}
+	}
+	if len(alteradas) > 0 {
+		s.logger.InfoContext(ctx, "cronograma físico reprogramado", "obra_id", obraID, "etapas_reprogramadas", len(alteradas))
+	}
+	return len(alteradas), nil
+}
+
+func (s *CronogramaFisicoService) calcular(ctx context.Context, obraID string) (_ *obras.CronogramaFisico, err error) {
+	ctx, span := iniciarSpanRelatorio(ctx, "cronograma_fisico", obraID)
+	defer func() {
+		span.RegistrarErro(err)
+		span.Finalizar()
+	}()
+
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, err
+	}
+	etapas, err := s.etapaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, err
+	}
+	dependencias, err := s.dependenciaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, err
+	}
+	return obras.CalcularCronogramaFisico(etapas, dependencias, obra.DataInicio, time.Now())
+}
+
+func contemEtapa(etapas []*obras.Etapa, etapaID string) bool {
+	for _, e := range etapas {
+		if e.ID == etapaID {
+			return true
+		}
+	}
+	return false
+}
@@ -8,9 +8,11 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/financeiro"
 	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
 	"github.com/luiszkm/masterCostrutora/internal/events"
 	"github.com/luiszkm/masterCostrutora/internal/platform/bus"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
 	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
 )
 
@@ -21,26 +23,35 @@ type EventPublisher interface {
 
 // CronogramaService encapsula a lógica de negócio para cronogramas de recebimento
 type CronogramaService struct {
-	cronogramaRepo obras.CronogramaRecebimentoRepository
-	obraRepo       obras.ObrasRepository
-	eventBus       EventPublisher
-	logger         *slog.Logger
-	dbpool         *pgxpool.Pool
+	cronogramaRepo   obras.CronogramaRecebimentoRepository
+	obraRepo         obras.ObrasRepository
+	etapaRepo        obras.EtapaRepository
+	medicaoRepo      obras.MedicaoRepository
+	contaReceberRepo ContaReceberObraRepository
+	eventBus         EventPublisher
+	logger           *slog.Logger
+	dbpool           *pgxpool.Pool
 }
 
 func NovoCronogramaService(
 	cronogramaRepo obras.CronogramaRecebimentoRepository,
 	obraRepo obras.ObrasRepository,
+	etapaRepo obras.EtapaRepository,
+	medicaoRepo obras.MedicaoRepository,
+	contaReceberRepo ContaReceberObraRepository,
 	eventBus EventPublisher,
 	logger *slog.Logger,
 	dbpool *pgxpool.Pool,
 ) *CronogramaService {
 	return &CronogramaService{
-		cronogramaRepo: cronogramaRepo,
-		obraRepo:       obraRepo,
-		eventBus:       eventBus,
-		logger:         logger.With("service", "CronogramaRecebimento"),
-		dbpool:         dbpool,
+		cronogramaRepo:   cronogramaRepo,
+		obraRepo:         obraRepo,
+		etapaRepo:        etapaRepo,
+		medicaoRepo:      medicaoRepo,
+		contaReceberRepo: contaReceberRepo,
+		eventBus:         eventBus,
+		logger:           logger.With("service", "CronogramaRecebimento"),
+		dbpool:           dbpool,
 	}
 }
 
@@ -54,6 +65,24 @@ func (s *CronogramaService) CriarCronograma(ctx context.Context, input dto.Criar
 		return nil, fmt.Errorf("%s: obra não encontrada: %w", op, err)
 	}
 
+	cronograma, err := s.AcrescentarParcela(ctx, s.dbpool, obra, input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.PublicarCronogramaCriado(ctx, obra, []*obras.CronogramaRecebimento{cronograma})
+
+	s.logger.InfoContext(ctx, "cronograma de recebimento criado", "cronograma_id", cronograma.ID, "obra_id", obra.ID)
+
+	return s.toOutput(cronograma), nil
+}
+
+// AcrescentarParcela valida e salva uma parcela na transação do chamador, com a
+// caução da obra retida, sem publicar o evento; quem chama publica com
+// PublicarCronogramaCriado após o commit.
+func (s *CronogramaService) AcrescentarParcela(ctx context.Context, dbtx db.DBTX, obra *obras.Obra, input dto.CriarCronogramaRecebimentoInput) (*obras.CronogramaRecebimento, error) {
+	const op = "service.obras.cronograma.AcrescentarParcela"
+
 	// Criar cronograma
 	cronograma := &obras.CronogramaRecebimento{
 		ID:             uuid.NewString(),
@@ -64,6 +93,7 @@ func (s *CronogramaService) CriarCronograma(ctx context.Context, input dto.Criar
 		DataVencimento: input.DataVencimento,
 		Status:         obras.StatusRecebimentoPendente,
 		ValorRecebido:  0,
+		ValorRetido:    financeiro.CalcularRetencao(input.ValorPrevisto, obra.PercentualRetencao),
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 	}
@@ -74,30 +104,10 @@ func (s *CronogramaService) CriarCronograma(ctx context.Context, input dto.Criar
 	}
 
 	// Salvar no banco
-	if err := s.cronogramaRepo.Salvar(ctx, s.dbpool, cronograma); err != nil {
+	if err := s.cronogramaRepo.Salvar(ctx, dbtx, cronograma); err != nil {
 		return nil, fmt.Errorf("%s: falha ao salvar cronograma: %w", op, err)
 	}
-
-	// Publicar evento
-	payload := events.CronogramaRecebimentoCriadoPayload{
-		ObraID:             cronograma.ObraID,
-		ObraNome:           obra.Nome,
-		Cliente:            obra.Cliente,
-		CronogramasIds:     []string{cronograma.ID},
-		ValorTotalPrevisto: cronograma.ValorPrevisto,
-		QuantidadeEtapas:   1,
-		PrimeiroVencimento: cronograma.DataVencimento,
-		UsuarioID:          "system", // TODO: pegar do contexto
-	}
-
-	s.eventBus.Publicar(ctx, bus.Evento{
-		Nome:    events.CronogramaRecebimentoCriado,
-		Payload: payload,
-	})
-
-	s.logger.InfoContext(ctx, "cronograma de recebimento criado", "cronograma_id", cronograma.ID, "obra_id", obra.ID)
-
-	return s.toOutput(cronograma), nil
+	return cronograma, nil
 }
 
 // CriarCronogramaEmLote cria múltiplos cronogramas de uma vez
@@ -117,26 +127,48 @@ func (s *CronogramaService) CriarCronogramaEmLote(ctx context.Context, input dto
 	}
 	defer tx.Rollback(ctx)
 
+	cronogramas, err := s.salvarLote(ctx, tx, input, obra.PercentualRetencao, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Commit da transação
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: falha ao fazer commit: %w", op, err)
+	}
+
+	s.PublicarCronogramaCriado(ctx, obra, cronogramas)
+
+	s.logger.InfoContext(ctx, "cronogramas criados em lote", "obra_id", obra.ID, "quantidade", len(cronogramas))
+
+	return s.toOutputs(cronogramas), nil
+}
+
+// salvarLote salva o lote na transação do chamador, retendo a caução da obra de
+// cada parcela. Com SubstituirExistente, os cronogramas atuais são removidos e as
+// contas a receber canceladas pela substituição são gravadas na mesma transação.
+func (s *CronogramaService) salvarLote(ctx context.Context, dbtx db.DBTX, input dto.CriarCronogramaEmLoteInput, percentualRetencao float64, contasCanceladas []*financeiro.ContaReceber) ([]*obras.CronogramaRecebimento, error) {
+	// As contas são canceladas antes de remover os cronogramas, enquanto ainda estão vinculadas a eles
+	if err := s.contaReceberRepo.AtualizarMuitas(ctx, dbtx, contasCanceladas); err != nil {
+		return nil, fmt.Errorf("falha ao cancelar contas a receber: %w", err)
+	}
+
 	// Se solicitado, remover cronogramas existentes
 	if input.SubstituirExistente {
 		cronogramasExistentes, err := s.cronogramaRepo.ListarPorObraID(ctx, input.ObraID)
 		if err != nil {
-			return nil, fmt.Errorf("%s: falha ao listar cronogramas existentes: %w", op, err)
+			return nil, fmt.Errorf("falha ao listar cronogramas existentes: %w", err)
 		}
 
 		for _, cronograma := range cronogramasExistentes {
-			if err := s.cronogramaRepo.Deletar(ctx, cronograma.ID); err != nil {
-				return nil, fmt.Errorf("%s: falha ao deletar cronograma existente: %w", op, err)
+			if err := s.cronogramaRepo.Deletar(ctx, dbtx, cronograma.ID); err != nil {
+				return nil, fmt.Errorf("falha ao deletar cronograma existente: %w", err)
 			}
 		}
 	}
 
 	// Criar cronogramas
 	var cronogramas []*obras.CronogramaRecebimento
-	var cronogramasIds []string
-	var valorTotalPrevisto float64
-	var primeiroVencimento time.Time
-
 	for i, inputCronograma := range input.Cronogramas {
 		cronograma := &obras.CronogramaRecebimento{
 			ID:             uuid.NewString(),
@@ -147,17 +179,40 @@ func (s *CronogramaService) CriarCronogramaEmLote(ctx context.Context, input dto
 			DataVencimento: inputCronograma.DataVencimento,
 			Status:         obras.StatusRecebimentoPendente,
 			ValorRecebido:  0,
+			ValorRetido:    financeiro.CalcularRetencao(inputCronograma.ValorPrevisto, percentualRetencao),
 			CreatedAt:      time.Now(),
 			UpdatedAt:      time.Now(),
 		}
 
 		// Validar
 		if err := cronograma.Validar(); err != nil {
-			return nil, fmt.Errorf("%s: dados inválidos no cronograma %d: %w", op, i+1, err)
+			return nil, fmt.Errorf("dados inválidos no cronograma %d: %w", i+1, err)
 		}
-
 		cronogramas = append(cronogramas, cronograma)
+	}
+
+	// Salvar em lote
+	if err := s.cronogramaRepo.SalvarMuitos(ctx, dbtx, cronogramas); err != nil {
+		return nil, fmt.Errorf("falha ao salvar cronogramas: %w", err)
+	}
+	return cronogramas, nil
+}
+
+// PublicarCronogramaCriado publica as parcelas criadas, para gerar as contas a
+// receber. Deve ser chamado depois do commit da transação que as salvou.
+func (s *CronogramaService) PublicarCronogramaCriado(ctx context.Context, obra *obras.Obra, cronogramas []*obras.CronogramaRecebimento) {
+	if len(cronogramas) == 0 {
+		return
+	}
+
+	var cronogramasIds []string
+	var parcelas []events.ParcelaCronogramaPayload
+	var valorTotalPrevisto float64
+	var primeiroVencimento time.Time
+
+	for i, cronograma := range cronogramas {
 		cronogramasIds = append(cronogramasIds, cronograma.ID)
+		parcelas = append(parcelas, parcelaDoCronograma(cronograma))
 		valorTotalPrevisto += cronograma.ValorPrevisto
 
 		// Definir primeiro vencimento
@@ -166,45 +221,25 @@ func (s *CronogramaService) CriarCronogramaEmLote(ctx context.Context, input dto
 		}
 	}
 
-	// Salvar em lote
-	if err := s.cronogramaRepo.SalvarMuitos(ctx, tx, cronogramas); err != nil {
-		return nil, fmt.Errorf("%s: falha ao salvar cronogramas: %w", op, err)
-	}
-
-	// Commit da transação
-	if err := tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("%s: falha ao fazer commit: %w", op, err)
-	}
-
 	// Publicar evento
 	payload := events.CronogramaRecebimentoCriadoPayload{
-		ObraID:             input.ObraID,
+		ObraID:             obra.ID,
 		ObraNome:           obra.Nome,
 		Cliente:            obra.Cliente,
+		ClienteID:          obra.ClienteID,
 		CronogramasIds:     cronogramasIds,
 		ValorTotalPrevisto: valorTotalPrevisto,
 		QuantidadeEtapas:   len(cronogramas),
 		PrimeiroVencimento: primeiroVencimento,
+		PercentualRetencao: obra.PercentualRetencao,
 		UsuarioID:          "system", // TODO: pegar do contexto
+		Parcelas:           parcelas,
 	}
 
 	s.eventBus.Publicar(ctx, bus.Evento{
 		Nome:    events.CronogramaRecebimentoCriado,
 		Payload: payload,
 	})
-
-	s.logger.InfoContext(ctx, "cronogramas criados em lote", 
-		"obra_id", obra.ID, 
-		"quantidade", len(cronogramas), 
-		"valor_total", valorTotalPrevisto)
-
-	// Converter para output
-	var outputs []*dto.CronogramaRecebimentoOutput
-	for _, cronograma := range cronogramas {
-		outputs = append(outputs, s.toOutput(cronograma))
-	}
-
-	return outputs, nil
 }
 
 // RegistrarRecebimento registra um recebimento em um cronograma
@@ -235,7 +270,7 @@ func (s *CronogramaService) RegistrarRecebimento(ctx context.Context, cronograma
 
 	// Atualizar valor recebido na obra
 	obra.ValorRecebido += input.Valor
-	if err := s.obraRepo.Atualizar(ctx, obra); err != nil {
+	if err := s.obraRepo.Atualizar(ctx, nil, obra); err != nil {
 		s.logger.WarnContext(ctx, "falha ao atualizar valor recebido na obra", "obra_id", obra.ID, "erro", err)
 	}
 
@@ -273,12 +308,7 @@ func (s *CronogramaService) ListarPorObraID(ctx context.Context, obraID string)
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var outputs []*dto.CronogramaRecebimentoOutput
-	for _, cronograma := range cronogramas {
-		outputs = append(outputs, s.toOutput(cronograma))
-	}
-
-	return outputs, nil
+	return s.toOutputs(cronogramas), nil
 }
 
 // BuscarPorID busca um cronograma por ID
@@ -363,6 +393,7 @@ func (s *CronogramaService) toOutput(cronograma *obras.CronogramaRecebimento) *d
 		Status:                 cronograma.Status,
 		DataRecebimento:        cronograma.DataRecebimento,
 		ValorRecebido:          cronograma.ValorRecebido,
+		ValorRetido:            cronograma.ValorRetido,
 		ValorSaldo:             cronograma.ValorSaldo(),
 		PercentualRecebido:     cronograma.PercentualRecebido(),
 		ObservacoesRecebimento: cronograma.ObservacoesRecebimento,
@@ -370,4 +401,23 @@ func (s *CronogramaService) toOutput(cronograma *obras.CronogramaRecebimento) *d
 		CreatedAt:              cronograma.CreatedAt,
 		UpdatedAt:              cronograma.UpdatedAt,
 	}
-}
\ No newline at end of file
+}
+
+// toOutputs converte uma lista de entidades para DTOs de output
+func (s *CronogramaService) toOutputs(cronogramas []*obras.CronogramaRecebimento) []*dto.CronogramaRecebimentoOutput {
+	var outputs []*dto.CronogramaRecebimentoOutput
+	for _, cronograma := range cronogramas {
+		outputs = append(outputs, s.toOutput(cronograma))
+	}
+	return outputs
+}
+
+// parcelaDoCronograma resume a parcela para o evento de cronograma criado.
+func parcelaDoCronograma(cronograma *obras.CronogramaRecebimento) events.ParcelaCronogramaPayload {
+	return events.ParcelaCronogramaPayload{
+		CronogramaID:   cronograma.ID,
+		Descricao:      cronograma.DescricaoEtapa,
+		Valor:          cronograma.ValorPrevisto,
+		DataVencimento: cronograma.DataVencimento,
+	}
+}
@@ -0,0 +1,407 @@
+package obras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+	"github.com/luiszkm/masterCostrutora/pkg/auth"
+	"github.com/luiszkm/masterCostrutora/pkg/pdf"
+)
+
+var (
+	ErrDiarioJaExiste         = errors.New("já existe diário de obra nesta data")
+	ErrDiarioObraCancelada    = errors.New("obra cancelada não aceita diário de obra")
+	ErrFuncionarioNaoAlocado  = errors.New("funcionário não está alocado na obra nesta data")
+	ErrPeriodoDiariosInvalido = errors.New("data inicial do período é posterior à final")
+)
+
+// DiarioObraService registra o diário de obra, sua assinatura e a exportação em PDF.
+type DiarioObraService struct {
+	obraRepo      obras.ObrasRepository
+	etapaRepo     obras.EtapaRepository
+	alocacaoRepo  obras.AlocacaoRepository
+	diarioRepo    obras.DiarioObraRepository
+	pessoalFinder PessoalFinder
+	logger        *slog.Logger
+}
+
+func NovoDiarioObraService(
+	obraRepo obras.ObrasRepository,
+	etapaRepo obras.EtapaRepository,
+	alocacaoRepo obras.AlocacaoRepository,
+	diarioRepo obras.DiarioObraRepository,
+	pessoalFinder PessoalFinder,
+	logger *slog.Logger,
+) *DiarioObraService {
+	return &DiarioObraService{
+		obraRepo:      obraRepo,
+		etapaRepo:     etapaRepo,
+		alocacaoRepo:  alocacaoRepo,
+		diarioRepo:    diarioRepo,
+		pessoalFinder: pessoalFinder,
+		logger:        logger.With("service", "DiarioObra"),
+	}
+}
+
+// CriarDiario abre o diário da obra na data informada (ou hoje), um por dia.
+func (s *DiarioObraService) CriarDiario(ctx context.Context, obraID string, input dto.DiarioObraInput) (*obras.DiarioObra, error) {
+	const op = "service.obras.diario_obra.CriarDiario"
+
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if obra.Status == obras.StatusCancelada {
+		return nil, fmt.Errorf("%s: %w", op, ErrDiarioObraCancelada)
+	}
+	data, err := dataOuHoje(input.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	agora := time.Now()
+	diario, err := obras.NovoDiarioObra(uuid.NewString(), obraID, data, auth.UsuarioDoContexto(ctx), agora)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	existe, err := s.diarioRepo.ExisteNaData(ctx, obraID, diario.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if existe {
+		return nil, fmt.Errorf("%s: %w", op, ErrDiarioJaExiste)
+	}
+
+	conteudo, err := s.montarConteudo(ctx, obraID, diario.Data, input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := diario.AtualizarConteudo(conteudo, agora); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.diarioRepo.Salvar(ctx, diario); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "diário de obra criado", "obra_id", obraID, "diario_id", diario.ID, "data", diario.Data.Format("2006-01-02"))
+	return diario, nil
+}
+
+// AtualizarDiario substitui os registros do dia enquanto o diário não foi assinado.
+func (s *DiarioObraService) AtualizarDiario(ctx context.Context, obraID, diarioID string, input dto.DiarioObraInput) (*obras.DiarioObra, error) {
+	const op = "service.obras.diario_obra.AtualizarDiario"
+
+	diario, err := s.buscarDaObra(ctx, obraID, diarioID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if diario.Assinado() {
+		return nil, fmt.Errorf("%s: %w", op, obras.ErrDiarioAssinado)
+	}
+	conteudo, err := s.montarConteudo(ctx, obraID, diario.Data, input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := diario.AtualizarConteudo(conteudo, time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.diarioRepo.Atualizar(ctx, diario); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return diario, nil
+}
+
+func (s *DiarioObraService) BuscarDiario(ctx context.Context, obraID, diarioID string) (*obras.DiarioObra, error) {
+	const op = "service.obras.diario_obra.BuscarDiario"
+
+	diario, err := s.buscarDaObra(ctx, obraID, diarioID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return diario, nil
+}
+
+// ListarDiarios lista os diários da obra no período, em ordem de data.
+func (s *DiarioObraService) ListarDiarios(ctx context.Context, obraID string, filtro dto.FiltroDiariosInput) ([]*obras.DiarioObra, error) {
+	const op = "service.obras.diario_obra.ListarDiarios"
+
+	if _, err := s.obraRepo.BuscarPorID(ctx, obraID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	inicio, fim, err := periodoDiarios(filtro)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	diarios, err := s.diarioRepo.ListarPorPeriodo(ctx, obraID, inicio, fim)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return diarios, nil
+}
+
+// AdicionarFoto anexa ao diário uma foto já enviada ao armazenamento de arquivos.
+func (s *DiarioObraService) AdicionarFoto(ctx context.Context, obraID, diarioID string, input dto.AdicionarFotoDiarioInput) (*obras.FotoDiario, error) {
+	const op = "service.obras.diario_obra.AdicionarFoto"
+
+	diario, err := s.buscarDaObra(ctx, obraID, diarioID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if input.EtapaID != nil {
+		etapas, err := s.etapaRepo.ListarPorObraID(ctx, obraID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if !contemEtapa(etapas, *input.EtapaID) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEtapaForaDaObra)
+		}
+	}
+
+	agora := time.Now()
+	foto := obras.FotoDiario{
+		ID:        uuid.NewString(),
+		URL:       strings.TrimSpace(input.URL),
+		Legenda:   strings.TrimSpace(input.Legenda),
+		EtapaID:   input.EtapaID,
+		CreatedAt: agora,
+	}
+	if err := diario.AdicionarFoto(foto, agora); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.diarioRepo.Atualizar(ctx, diario); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &foto, nil
+}
+
+func (s *DiarioObraService) RemoverFoto(ctx context.Context, obraID, diarioID, fotoID string) error {
+	const op = "service.obras.diario_obra.RemoverFoto"
+
+	diario, err := s.buscarDaObra(ctx, obraID, diarioID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := diario.RemoverFoto(fotoID, time.Now()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.diarioRepo.Atualizar(ctx, diario); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// AssinarDiario registra o aceite do engenheiro autenticado e trava o diário.
+func (s *DiarioObraService) AssinarDiario(ctx context.Context, obraID, diarioID string) (*obras.DiarioObra, error) {
+	const op = "service.obras.diario_obra.AssinarDiario"
+
+	diario, err := s.buscarDaObra(ctx, obraID, diarioID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	usuarioID := auth.UsuarioDoContexto(ctx)
+	if err := diario.Assinar(usuarioID, time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.diarioRepo.Atualizar(ctx, diario); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "diário de obra assinado", "obra_id", obraID, "diario_id", diarioID, "usuario_id", usuarioID)
+	return diario, nil
+}
+
+// ExportarPDF gera o PDF com os diários da obra no período.
+func (s *DiarioObraService) ExportarPDF(ctx context.Context, obraID string, filtro dto.FiltroDiariosInput) ([]byte, error) {
+	const op = "service.obras.diario_obra.ExportarPDF"
+
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	inicio, fim, err := periodoDiarios(filtro)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	diarios, err := s.diarioRepo.ListarPorPeriodo(ctx, obraID, inicio, fim)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	etapas, err := s.etapaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return montarPDFDiarios(obra, etapas, diarios, inicio, fim), nil
+}
+
+// montarConteudo valida efetivo e serviços contra as alocações e etapas da obra e
+// completa o nome de cada funcionário presente.
+func (s *DiarioObraService) montarConteudo(ctx context.Context, obraID string, data time.Time, input dto.DiarioObraInput) (obras.ConteudoDiario, error) {
+	conteudo := obras.ConteudoDiario{
+		Clima:               input.Clima,
+		EfetivoTerceirizado: input.EfetivoTerceirizado,
+		Equipamentos:        input.Equipamentos,
+		Servicos:            input.Servicos,
+		Ocorrencias:         input.Ocorrencias,
+		Observacoes:         input.Observacoes,
+	}
+
+	if len(input.Servicos) > 0 {
+		etapas, err := s.etapaRepo.ListarPorObraID(ctx, obraID)
+		if err != nil {
+			return conteudo, err
+		}
+		for _, servico := range input.Servicos {
+			if !contemEtapa(etapas, servico.EtapaID) {
+				return conteudo, ErrEtapaForaDaObra
+			}
+		}
+	}
+
+	if len(input.Efetivo) == 0 {
+		return conteudo, nil
+	}
+	alocacoes, err := s.alocacaoRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return conteudo, err
+	}
+	for _, p := range input.Efetivo {
+		alocacao := alocacaoNaData(alocacoes, p.FuncionarioID, data)
+		if alocacao == nil {
+			return conteudo, fmt.Errorf("%w: %s", ErrFuncionarioNaoAlocado, p.FuncionarioID)
+		}
+		funcionario, err := s.pessoalFinder.BuscarPorID(ctx, p.FuncionarioID)
+		if err != nil {
+			return conteudo, err
+		}
+		conteudo.Efetivo = append(conteudo.Efetivo, obras.PresencaDiario{
+			FuncionarioID:    p.FuncionarioID,
+			FuncionarioNome:  funcionario.Nome,
+			AlocacaoID:       alocacao.ID,
+			HorasTrabalhadas: p.HorasTrabalhadas,
+		})
+	}
+	return conteudo, nil
+}
+
+// buscarDaObra busca o diário e confere que ele pertence à obra da rota.
+func (s *DiarioObraService) buscarDaObra(ctx context.Context, obraID, diarioID string) (*obras.DiarioObra, error) {
+	diario, err := s.diarioRepo.BuscarPorID(ctx, diarioID)
+	if err != nil {
+		return nil, err
+	}
+	if diario.ObraID != obraID {
+		return nil, postgres.ErrNaoEncontrado
+	}
+	return diario, nil
+}
+
+func alocacaoNaData(alocacoes []*obras.Alocacao, funcionarioID string, data time.Time) *obras.Alocacao {
+	for _, a := range alocacoes {
+		if a.FuncionarioID != funcionarioID || dataSemHora(a.DataInicioAlocacao).After(data) {
+			continue
+		}
+		if a.DataFimAlocacao == nil || !dataSemHora(*a.DataFimAlocacao).Before(data) {
+			return a
+		}
+	}
+	return nil
+}
+
+func periodoDiarios(filtro dto.FiltroDiariosInput) (*time.Time, *time.Time, error) {
+	inicio, err := parseDataOpcional(&filtro.DataInicio)
+	if err != nil {
+		return nil, nil, err
+	}
+	fim, err := parseDataOpcional(&filtro.DataFim)
+	if err != nil {
+		return nil, nil, err
+	}
+	if inicio != nil && fim != nil && inicio.After(*fim) {
+		return nil, nil, ErrPeriodoDiariosInvalido
+	}
+	return inicio, fim, nil
+}
+
+const formatoDataBR = "02/01/2006"
+
+func montarPDFDiarios(obra *obras.Obra, etapas []*obras.Etapa, diarios []*obras.DiarioObra, inicio, fim *time.Time) []byte {
+	nomesEtapas := make(map[string]string, len(etapas))
+	for _, e := range etapas {
+		nomesEtapas[e.ID] = e.Nome
+	}
+
+	doc := pdf.NovoDocumento()
+	doc.Titulo("Diário de Obra — " + obra.Nome)
+	doc.Paragrafo(fmt.Sprintf("Cliente: %s | Endereço: %s", obra.Cliente, obra.Endereco))
+	periodo := "todo o histórico"
+	switch {
+	case inicio != nil && fim != nil:
+		periodo = inicio.Format(formatoDataBR) + " a " + fim.Format(formatoDataBR)
+	case inicio != nil:
+		periodo = "a partir de " + inicio.Format(formatoDataBR)
+	case fim != nil:
+		periodo = "até " + fim.Format(formatoDataBR)
+	}
+	doc.Paragrafo(fmt.Sprintf("Período: %s | Diários: %d", periodo, len(diarios)))
+	if len(diarios) == 0 {
+		doc.Espaco()
+		doc.Paragrafo("Nenhum diário registrado no período.")
+	}
+
+	for _, d := range diarios {
+		doc.Espaco()
+		doc.Subtitulo(d.Data.Format(formatoDataBR))
+		if d.Assinado() {
+			doc.Paragrafo(fmt.Sprintf("Assinado por %s em %s", *d.AssinadoPor, d.AssinadoEm.Format(formatoDataBR+" 15:04")))
+		} else {
+			doc.Paragrafo("Rascunho (não assinado)")
+		}
+		praticavel := "não"
+		if d.Clima.Praticavel {
+			praticavel = "sim"
+		}
+		doc.Paragrafo(fmt.Sprintf("Clima: manhã %s, tarde %s | Dia praticável: %s", d.Clima.Manha, d.Clima.Tarde, praticavel))
+
+		doc.Paragrafo(fmt.Sprintf("Efetivo: %d funcionário(s) + %d terceirizado(s)", len(d.Efetivo), d.EfetivoTerceirizado))
+		for _, p := range d.Efetivo {
+			doc.Item(fmt.Sprintf("%s — %.1fh", p.FuncionarioNome, p.HorasTrabalhadas))
+		}
+		if len(d.Equipamentos) > 0 {
+			doc.Paragrafo("Equipamentos:")
+			for _, e := range d.Equipamentos {
+				doc.Item(fmt.Sprintf("%s (%d) — %.1fh", e.Descricao, e.Quantidade, e.HorasUso))
+			}
+		}
+		if len(d.Servicos) > 0 {
+			doc.Paragrafo("Serviços executados:")
+			for _, sv := range d.Servicos {
+				doc.Item(fmt.Sprintf("%s: %s", nomesEtapas[sv.EtapaID], sv.Descricao))
+			}
+		}
+		if len(d.Ocorrencias) > 0 {
+			doc.Paragrafo("Ocorrências:")
+			for _, o := range d.Ocorrencias {
+				doc.Item(fmt.Sprintf("[%s] %s", o.Tipo, o.Descricao))
+			}
+		}
+		if len(d.Fotos) > 0 {
+			doc.Paragrafo("Fotos:")
+			for _, f := range d.Fotos {
+				doc.Item(strings.TrimSpace(f.Legenda + " " + f.URL))
+			}
+		}
+		if d.Observacoes != "" {
+			doc.Paragrafo("Observações: " + d.Observacoes)
+		}
+	}
+	return doc.Bytes()
+}
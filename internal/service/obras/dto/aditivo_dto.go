@@ -0,0 +1,51 @@
+package dto
+
+import "time"
+
+// Ajustes do cronograma de recebimento ao registrar um aditivo.
+const (
+	AjusteCronogramaNenhum      = ""
+	AjusteCronogramaAcrescentar = "ACRESCENTAR" // Nova parcela com o valor do acréscimo
+	AjusteCronogramaRegerar     = "REGERAR"     // Cronograma refeito a partir do contrato resultante
+)
+
+// RegistrarAditivoInput registra um aditivo aprovado. Datas no formato AAAA-MM-DD.
+type RegistrarAditivoInput struct {
+	Descricao      string  `json:"descricao"`
+	ValorAcrescimo float64 `json:"valorAcrescimo"` // Negativo para supressão
+	NovaDataFim    string  `json:"novaDataFim,omitempty"`
+	DataAprovacao  string  `json:"dataAprovacao,omitempty"` // Padrão: hoje
+	DocumentoURL   string  `json:"documentoUrl,omitempty"`
+
+	// AjusteCronograma indica o que fazer com o cronograma de recebimento:
+	// ACRESCENTAR usa DataVencimento (padrão: 30 dias após a aprovação) e
+	// REGERAR usa Geracao, sempre substituindo o cronograma atual.
+	AjusteCronograma string                `json:"ajusteCronograma,omitempty"`
+	DataVencimento   string                `json:"dataVencimento,omitempty"`
+	Geracao          *GerarCronogramaInput `json:"geracao,omitempty"`
+}
+
+// ContratoObraDTO resume o contrato da obra: linha de base, contrato vigente e
+// o histórico de alterações em ordem cronológica.
+type ContratoObraDTO struct {
+	ValorOriginal       float64             `json:"valorOriginal"`
+	DataFimOriginal     *time.Time          `json:"dataFimOriginal,omitempty"`
+	ValorAtual          float64             `json:"valorAtual"`
+	DataFimAtual        *time.Time          `json:"dataFimAtual,omitempty"`
+	TotalAditivado      float64             `json:"totalAditivado"`
+	PercentualAditivado float64             `json:"percentualAditivado"`
+	PercentualRetencao  float64             `json:"percentualRetencao"` // Caução retida de cada medição
+	Historico           []EventoContratoDTO `json:"historico"`
+}
+
+// EventoContratoDTO é um marco do contrato: a assinatura ou um aditivo.
+type EventoContratoDTO struct {
+	Tipo           string     `json:"tipo"` // ASSINATURA ou ADITIVO
+	Data           time.Time  `json:"data"`
+	Descricao      string     `json:"descricao"`
+	AditivoID      *string    `json:"aditivoId,omitempty"`
+	ValorAcrescimo float64    `json:"valorAcrescimo"`
+	ValorContrato  float64    `json:"valorContrato"`
+	DataFim        *time.Time `json:"dataFim,omitempty"`
+	DocumentoURL   *string    `json:"documentoUrl,omitempty"`
+}
@@ -1,8 +1,51 @@
 // file: internal/service/obras/dto/alocacao_dto.go
 package dto
 
+import (
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+)
+
 // AlocarFuncionariosInput é o DTO para alocar um ou mais funcionários a uma obra.
 type AlocarFuncionariosInput struct {
 	FuncionarioIDs     []string `json:"funcionarioIds"`
-	DataInicioAlocacao string   `json:"dataInicioAlocacao"` // Formato "YYYY-MM-DD"
+	DataInicioAlocacao string   `json:"dataInicioAlocacao"`           // Formato "YYYY-MM-DD"
+	DataFimAlocacao    string   `json:"dataFimAlocacao,omitempty"`    // Opcional; último dia alocado
+	MotivoSobreposicao string   `json:"motivoSobreposicao,omitempty"` // Obrigatório se o funcionário já estiver em outra obra no período
+}
+
+// EncerrarAlocacaoInput define o último dia da alocação (padrão: hoje).
+type EncerrarAlocacaoInput struct {
+	DataFim string `json:"dataFim,omitempty"` // Formato "YYYY-MM-DD"
+	Motivo  string `json:"motivo"`
+}
+
+// TransferirAlocacaoInput move o funcionário para outra obra a partir de uma data.
+type TransferirAlocacaoInput struct {
+	ObraDestinoID      string `json:"obraDestinoId"`
+	Data               string `json:"data,omitempty"` // Primeiro dia na obra de destino (padrão: hoje)
+	Motivo             string `json:"motivo"`
+	MotivoSobreposicao string `json:"motivoSobreposicao,omitempty"`
+}
+
+// TransferenciaAlocacaoOutput traz a alocação encerrada na origem e a criada no destino.
+type TransferenciaAlocacaoOutput struct {
+	Encerrada *obras.Alocacao `json:"encerrada"`
+	Nova      *obras.Alocacao `json:"nova"`
+}
+
+// AlocacaoHistoricoDTO é uma linha do histórico de alocações, por obra ou por funcionário.
+type AlocacaoHistoricoDTO struct {
+	ID                 string     `json:"id" db:"id"`
+	ObraID             string     `json:"obraId" db:"obra_id"`
+	ObraNome           string     `json:"obraNome" db:"obra_nome"`
+	FuncionarioID      string     `json:"funcionarioId" db:"funcionario_id"`
+	FuncionarioNome    string     `json:"funcionarioNome" db:"funcionario_nome"`
+	DataInicioAlocacao time.Time  `json:"dataInicioAlocacao" db:"data_inicio_alocacao"`
+	DataFimAlocacao    *time.Time `json:"dataFimAlocacao,omitempty" db:"data_fim_alocacao"`
+	MotivoEncerramento *string    `json:"motivoEncerramento,omitempty" db:"motivo_encerramento"`
+	MotivoSobreposicao *string    `json:"motivoSobreposicao,omitempty" db:"motivo_sobreposicao"`
+	TransferidaDeID    *string    `json:"transferidaDeId,omitempty" db:"transferida_de_id"`
+	Ativa              bool       `json:"ativa" db:"-"`
 }
@@ -0,0 +1,35 @@
+// file: internal/service/obras/dto/capacidade_dto.go
+package dto
+
+import (
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+)
+
+// DefinirEfetivoPlanejadoInput substitui o efetivo planejado das etapas da obra.
+type DefinirEfetivoPlanejadoInput struct {
+	Itens []ItemEfetivoPlanejadoInput `json:"itens"`
+}
+
+// ItemEfetivoPlanejadoInput é quantos funcionários de um cargo a etapa precisa.
+type ItemEfetivoPlanejadoInput struct {
+	EtapaID    string `json:"etapaId"`
+	Cargo      string `json:"cargo"`
+	Quantidade int    `json:"quantidade"`
+}
+
+// FiltroCapacidadeInput define o horizonte do calendário de capacidade.
+type FiltroCapacidadeInput struct {
+	Inicio  string // Formato "YYYY-MM-DD"; padrão: semana atual
+	Semanas string // Quantidade de semanas; padrão: 8
+}
+
+// CapacidadeOutput é o calendário semanal de efetivo alocado x disponível por cargo.
+type CapacidadeOutput struct {
+	Inicio              time.Time                 `json:"inicio"`
+	Fim                 time.Time                 `json:"fim"`
+	Semanas             []*obras.SemanaCapacidade `json:"semanas"`
+	TotalFaltas         int                       `json:"totalFaltas"`         // Semanas x cargos com falta de efetivo
+	TotalSobrealocacoes int                       `json:"totalSobrealocacoes"` // Semanas x cargos com funcionário sobrealocado
+}
@@ -0,0 +1,38 @@
+// file: internal/service/obras/dto/checklist_qualidade_dto.go
+package dto
+
+import "github.com/luiszkm/masterCostrutora/internal/domain/obras"
+
+// SalvarModeloChecklistInput cria ou substitui um modelo de FVS de uma etapa padrão.
+type SalvarModeloChecklistInput struct {
+	EtapaPadraoID string                `json:"etapaPadraoId"`
+	Nome          string                `json:"nome"`
+	Descricao     string                `json:"descricao"`
+	Obrigatorio   bool                  `json:"obrigatorio"`
+	Itens         []obras.ItemChecklist `json:"itens"`
+}
+
+// AplicarChecklistsInput instancia FVS em uma etapa já existente. Sem ModeloID,
+// aplica os modelos da etapa padrão que originou a etapa e ainda não estão nela.
+type AplicarChecklistsInput struct {
+	ModeloID string `json:"modeloId,omitempty"`
+}
+
+// RegistrarInspecaoInput é o resultado de uma inspeção (ou reinspeção) da FVS.
+type RegistrarInspecaoInput struct {
+	Data        string                         `json:"data"` // AAAA-MM-DD; padrão: hoje
+	Inspetor    string                         `json:"inspetor"`
+	Itens       []obras.ResultadoItemChecklist `json:"itens"`
+	Evidencias  []string                       `json:"evidencias"` // URLs de fotos ou documentos
+	Observacoes string                         `json:"observacoes"`
+}
+
+// ChecklistsObraOutput reúne as FVS de todas as etapas da obra.
+type ChecklistsObraOutput struct {
+	ObraID                string                  `json:"obraId"`
+	Checklists            []*obras.ChecklistEtapa `json:"checklists"`
+	Total                 int                     `json:"total"`
+	Aprovadas             int                     `json:"aprovadas"`
+	Reprovadas            int                     `json:"reprovadas"`
+	ObrigatoriasPendentes int                     `json:"obrigatoriasPendentes"` // Impedem concluir as etapas
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+)
+
+// AdicionarDependenciaInput liga uma etapa à sua predecessora.
+type AdicionarDependenciaInput struct {
+	EtapaID        string `json:"etapaId" validate:"required,uuid"`
+	PredecessoraID string `json:"predecessoraId" validate:"required,uuid"`
+	Tipo           string `json:"tipo"`    // "TI" (término-início, padrão) ou "II" (início-início)
+	LagDias        int    `json:"lagDias"` // Intervalo em dias; negativo antecipa a sucessora
+}
+
+// CronogramaFisicoOutput traz as etapas programadas em formato pronto para um gráfico de Gantt.
+type CronogramaFisicoOutput struct {
+	ObraID         string                      `json:"obraId"`
+	DataInicio     time.Time                   `json:"dataInicio"`
+	DataFim        time.Time                   `json:"dataFim"`
+	DuracaoDias    int                         `json:"duracaoDias"`
+	CaminhoCritico []string                    `json:"caminhoCritico"`
+	Etapas         []EtapaCronogramaFisicoItem `json:"etapas"`
+}
+
+// EtapaCronogramaFisicoItem é uma barra do Gantt: datas programadas, execução e folga.
+type EtapaCronogramaFisicoItem struct {
+	EtapaID             string                    `json:"etapaId"`
+	Nome                string                    `json:"nome"`
+	Status              obras.StatusEtapa         `json:"status"`
+	DataInicio          time.Time                 `json:"dataInicio"`
+	DataFim             time.Time                 `json:"dataFim"`
+	DuracaoDias         int                       `json:"duracaoDias"`
+	DataInicioPrevista  *time.Time                `json:"dataInicioPrevista,omitempty"`
+	DataFimPrevista     *time.Time                `json:"dataFimPrevista,omitempty"`
+	DataInicioReal      *time.Time                `json:"dataInicioReal,omitempty"`
+	DataFimReal         *time.Time                `json:"dataFimReal,omitempty"`
+	PercentualExecutado float64                   `json:"percentualExecutado"`
+	Peso                float64                   `json:"peso"`
+	FolgaDias           int                       `json:"folgaDias"`
+	Critica             bool                      `json:"critica"`
+	Predecessoras       []*obras.DependenciaEtapa `json:"predecessoras"`
+}
@@ -35,6 +35,7 @@ type CronogramaRecebimentoOutput struct {
 	Status                 string     `json:"status"`
 	DataRecebimento        *time.Time `json:"dataRecebimento,omitempty"`
 	ValorRecebido          float64    `json:"valorRecebido"`
+	ValorRetido            float64    `json:"valorRetido"` // Caução, fora do saldo da parcela
 	ValorSaldo             float64    `json:"valorSaldo"`
 	PercentualRecebido     float64    `json:"percentualRecebido"`
 	ObservacoesRecebimento *string    `json:"observacoesRecebimento,omitempty"`
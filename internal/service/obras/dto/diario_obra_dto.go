@@ -0,0 +1,35 @@
+package dto
+
+import "github.com/luiszkm/masterCostrutora/internal/domain/obras"
+
+// DiarioObraInput cria ou substitui os registros de um dia. Data (AAAA-MM-DD) só é
+// usada na criação; omitida, assume hoje.
+type DiarioObraInput struct {
+	Data                string                    `json:"data,omitempty"`
+	Clima               obras.ClimaDiario         `json:"clima"`
+	Efetivo             []PresencaDiarioInput     `json:"efetivo"`
+	EfetivoTerceirizado int                       `json:"efetivoTerceirizado"`
+	Equipamentos        []obras.EquipamentoDiario `json:"equipamentos"`
+	Servicos            []obras.ServicoDiario     `json:"servicos"`
+	Ocorrencias         []obras.OcorrenciaDiario  `json:"ocorrencias"`
+	Observacoes         string                    `json:"observacoes"`
+}
+
+// PresencaDiarioInput registra um funcionário alocado presente no dia.
+type PresencaDiarioInput struct {
+	FuncionarioID    string  `json:"funcionarioId"`
+	HorasTrabalhadas float64 `json:"horasTrabalhadas"`
+}
+
+// AdicionarFotoDiarioInput anexa uma foto já enviada ao armazenamento de arquivos.
+type AdicionarFotoDiarioInput struct {
+	URL     string  `json:"url"`
+	Legenda string  `json:"legenda"`
+	EtapaID *string `json:"etapaId,omitempty"`
+}
+
+// FiltroDiariosInput limita os diários a um período (datas AAAA-MM-DD, opcionais).
+type FiltroDiariosInput struct {
+	DataInicio string
+	DataFim    string
+}
@@ -5,7 +5,8 @@ import "time"
 // CriarNovaObraInput representa os dados necessários para criar uma obra.
 type CriarNovaObraInput struct {
 	Nome       string `json:"nome"`
-	Cliente    string `json:"cliente"`
+	ClienteID  string `json:"clienteId"`
+	Cliente    string `json:"cliente"` // Usado para localizar o cliente quando não há clienteId
 	Endereco   string `json:"endereco"`
 	DataInicio string `json:"dataInicio"` // Espera-se "YYYY-MM-DD"
 	DataFim    string `json:"dataFim"`    // Espera-se "YYYY-MM-DD"
@@ -15,10 +16,16 @@ type CriarNovaObraInput struct {
 	ValorContratoTotal     *float64   `json:"valorContratoTotal,omitempty"`
 	TipoCobranca           *string    `json:"tipoCobranca,omitempty"` // "VISTA", "PARCELADO", "ETAPAS"
 	DataAssinaturaContrato *time.Time `json:"dataAssinaturaContrato,omitempty"`
+	PercentualRetencao     *float64   `json:"percentualRetencao,omitempty"` // Caução retida de cada medição (0 a 30)
+
+	// Estrutura inicial (opcional, um ou outro): sem nenhum dos dois, as etapas saem do catálogo de etapas padrão
+	ModeloID     string `json:"modeloId,omitempty"`     // Modelo de obra (etapas, durações, dependências, orçamento e cronograma)
+	ObraOrigemID string `json:"obraOrigemId,omitempty"` // Obra existente cuja estrutura é copiada
 }
 
 type AtualizarObraInput struct {
 	Nome       string `json:"nome"`
+	ClienteID  string `json:"clienteId"`
 	Cliente    string `json:"cliente"`
 	Endereco   string `json:"endereco"`
 	DataInicio string `json:"dataInicio"` // Espera-se "YYYY-MM-DD"
@@ -30,6 +37,7 @@ type AtualizarObraInput struct {
 	ValorContratoTotal     *float64   `json:"valorContratoTotal,omitempty"`
 	TipoCobranca           *string    `json:"tipoCobranca,omitempty"`
 	DataAssinaturaContrato *time.Time `json:"dataAssinaturaContrato,omitempty"`
+	PercentualRetencao     *float64   `json:"percentualRetencao,omitempty"`
 }
 
 // AtualizarValoresContratoInput permite atualizar apenas valores financeiros
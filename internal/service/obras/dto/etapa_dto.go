@@ -3,11 +3,22 @@ package dto
 
 // AdicionarEtapaInput é o DTO para adicionar uma nova etapa a uma obra.
 type AdicionarEtapaInput struct {
-	EtapaPadraoID      string `json:"etapaPadraoId"`
-	DataInicioPrevista string `json:"dataInicioPrevista"` // Formato "YYYY-MM-DD"
-	DataFimPrevista    string `json:"dataFimPrevista"`    // Formato "YYYY-MM-DD"
+	EtapaPadraoID      string   `json:"etapaPadraoId"`
+	DataInicioPrevista string   `json:"dataInicioPrevista"` // Formato "YYYY-MM-DD"
+	DataFimPrevista    string   `json:"dataFimPrevista"`    // Formato "YYYY-MM-DD"
+	Peso               *float64 `json:"peso,omitempty"`     // Peso no progresso da obra; padrão 1
 }
 
 type AtualizarStatusEtapaInput struct {
-	Status string `json:"status"`
+	Status              string   `json:"status"`
+	Data                string   `json:"data,omitempty"`                // Data real do início/conclusão, "YYYY-MM-DD"; padrão hoje
+	PercentualExecutado *float64 `json:"percentualExecutado,omitempty"` // Obrigatório ao reabrir uma etapa concluída
+}
+
+// AtualizarProgressoEtapaInput registra a execução da etapa. Campos omitidos não são alterados.
+type AtualizarProgressoEtapaInput struct {
+	PercentualExecutado *float64 `json:"percentualExecutado,omitempty"`
+	Peso                *float64 `json:"peso,omitempty"`
+	DataInicioReal      *string  `json:"dataInicioReal,omitempty"` // Formato "YYYY-MM-DD"
+	DataFimReal         *string  `json:"dataFimReal,omitempty"`    // Formato "YYYY-MM-DD"
 }
@@ -0,0 +1,80 @@
+package dto
+
+import "time"
+
+// Tipos de lançamento realizado usados no cronograma físico-financeiro.
+const (
+	LancamentoCusto   = "CUSTO"
+	LancamentoReceita = "RECEITA"
+)
+
+// LancamentoRealizado é um valor efetivamente pago ou recebido pela obra.
+type LancamentoRealizado struct {
+	Tipo   string    `json:"tipo"`   // CUSTO ou RECEITA
+	Origem string    `json:"origem"` // CONTA_PAGAR, APONTAMENTO, CRONOGRAMA_RECEBIMENTO, CONTA_RECEBER
+	Data   time.Time `json:"data"`
+	Valor  float64   `json:"valor"`
+}
+
+// FisicoFinanceiroOutput é o cronograma físico-financeiro da obra: curva S mensal
+// de custo e receita (previsto x realizado) e os indicadores de valor agregado.
+type FisicoFinanceiroOutput struct {
+	ObraID                     string                   `json:"obraId"`
+	DataReferencia             time.Time                `json:"dataReferencia"`
+	CustoPrevistoTotal         float64                  `json:"custoPrevistoTotal"`
+	CustoPrevistoNaoProgramado float64                  `json:"custoPrevistoNaoProgramado"` // Etapas com orçamento, mas sem datas previstas
+	ReceitaPrevistaTotal       float64                  `json:"receitaPrevistaTotal"`
+	PercentualFisicoPrevisto   float64                  `json:"percentualFisicoPrevisto"` // Planejado até a data de referência
+	PercentualFisicoRealizado  float64                  `json:"percentualFisicoRealizado"`
+	Indicadores                IndicadoresValorAgregado `json:"indicadores"`
+	CurvaS                     []PontoCurvaS            `json:"curvaS"`
+	Etapas                     []EtapaFisicoFinanceiro  `json:"etapas"`
+}
+
+// IndicadoresValorAgregado segue a análise de valor agregado (EVM) na data de
+// referência, com o custo previsto total como orçamento no término (BAC), ou o valor
+// do contrato quando a obra não tem orçamento. SPI e CPI ficam nulos quando o
+// denominador é zero.
+type IndicadoresValorAgregado struct {
+	ValorPlanejado        float64  `json:"valorPlanejado"` // PV: BAC x percentual físico previsto
+	ValorAgregado         float64  `json:"valorAgregado"`  // EV: BAC x percentual físico realizado
+	CustoReal             float64  `json:"custoReal"`      // AC: custo realizado até a data
+	VariacaoPrazo         float64  `json:"variacaoPrazo"`  // SV = EV - PV
+	VariacaoCusto         float64  `json:"variacaoCusto"`  // CV = EV - AC
+	IndiceDesempenhoPrazo *float64 `json:"spi"`            // SPI = EV / PV
+	IndiceDesempenhoCusto *float64 `json:"cpi"`            // CPI = EV / AC
+	EstimativaNoTermino   *float64 `json:"eac"`            // EAC = BAC / CPI
+}
+
+// PontoCurvaS traz os valores do mês e os acumulados até o fim do mês.
+type PontoCurvaS struct {
+	Mes                       string  `json:"mes"` // Formato "YYYY-MM"
+	CustoPrevisto             float64 `json:"custoPrevisto"`
+	CustoPrevistoAcumulado    float64 `json:"custoPrevistoAcumulado"`
+	CustoRealizado            float64 `json:"custoRealizado"`
+	CustoRealizadoAcumulado   float64 `json:"custoRealizadoAcumulado"`
+	ReceitaPrevista           float64 `json:"receitaPrevista"`
+	ReceitaPrevistaAcumulada  float64 `json:"receitaPrevistaAcumulada"`
+	ReceitaRealizada          float64 `json:"receitaRealizada"`
+	ReceitaRealizadaAcumulada float64 `json:"receitaRealizadaAcumulada"`
+	PercentualFisicoPrevisto  float64 `json:"percentualFisicoPrevisto"` // Acumulado, ponderado pelo peso das etapas
+}
+
+// EtapaFisicoFinanceiro é a distribuição mensal do custo previsto de uma etapa.
+// ValorAgregado é a parte da etapa no EV: BAC x peso relativo x percentual executado.
+type EtapaFisicoFinanceiro struct {
+	EtapaID             string        `json:"etapaId"`
+	Nome                string        `json:"nome"`
+	DataInicioPrevista  *time.Time    `json:"dataInicioPrevista,omitempty"`
+	DataFimPrevista     *time.Time    `json:"dataFimPrevista,omitempty"`
+	CustoPrevisto       float64       `json:"custoPrevisto"`
+	PercentualExecutado float64       `json:"percentualExecutado"`
+	ValorAgregado       float64       `json:"valorAgregado"`
+	Meses               []ValorMensal `json:"meses"`
+}
+
+// ValorMensal é um valor atribuído a um mês ("YYYY-MM").
+type ValorMensal struct {
+	Mes   string  `json:"mes"`
+	Valor float64 `json:"valor"`
+}
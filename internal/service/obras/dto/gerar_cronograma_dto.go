@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// GerarCronogramaInput define como o cronograma de recebimento é montado a partir
+// do contrato da obra. Os campos usados dependem do tipo de cobrança:
+// VISTA gera uma parcela única na data base; PARCELADO gera a entrada e
+// NumeroParcelas mensais; ETAPAS gera a entrada e uma parcela por etapa.
+type GerarCronogramaInput struct {
+	DataBase            string                 `json:"dataBase,omitempty"` // AAAA-MM-DD; padrão: assinatura do contrato ou hoje
+	PercentualEntrada   float64                `json:"percentualEntrada"`  // PARCELADO e ETAPAS
+	NumeroParcelas      int                    `json:"numeroParcelas"`     // PARCELADO
+	DiaVencimento       int                    `json:"diaVencimento"`      // PARCELADO: 1 a 31; 0 usa o dia da data base
+	PrazoDiasEtapa      int                    `json:"prazoDiasEtapa"`     // ETAPAS: dias após o fim previsto da etapa
+	Etapas              []PercentualEtapaInput `json:"etapas,omitempty"`   // ETAPAS: vazia reparte pelo peso das etapas
+	SubstituirExistente bool                   `json:"substituirExistente"`
+}
+
+// PercentualEtapaInput é a fatia do contrato cobrada ao fim de uma etapa.
+type PercentualEtapaInput struct {
+	EtapaID        string  `json:"etapaId"`
+	Percentual     float64 `json:"percentual"`
+	DataVencimento string  `json:"dataVencimento,omitempty"` // AAAA-MM-DD; padrão: fim previsto da etapa + prazo
+}
+
+// CronogramaGeradoOutput é a prévia do cronograma montado a partir do contrato.
+type CronogramaGeradoOutput struct {
+	ObraID             string                `json:"obraId"`
+	TipoCobranca       string                `json:"tipoCobranca"`
+	ValorContratoTotal float64               `json:"valorContratoTotal"`
+	Parcelas           []ParcelaGeradaOutput `json:"parcelas"`
+}
+
+type ParcelaGeradaOutput struct {
+	NumeroEtapa    int       `json:"numeroEtapa"`
+	DescricaoEtapa string    `json:"descricaoEtapa"`
+	Percentual     float64   `json:"percentual"`
+	ValorPrevisto  float64   `json:"valorPrevisto"`
+	DataVencimento time.Time `json:"dataVencimento"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+// MedicaoInput cria ou substitui uma medição. Datas no formato AAAA-MM-DD.
+type MedicaoInput struct {
+	PeriodoInicio string             `json:"periodoInicio"`
+	PeriodoFim    string             `json:"periodoFim"`
+	Itens         []ItemMedicaoInput `json:"itens"`
+	Observacoes   string             `json:"observacoes"`
+}
+
+// ItemMedicaoInput é o avanço de uma etapa no período: informe o percentual ou a
+// quantidade medida junto com a quantidade prevista da etapa.
+type ItemMedicaoInput struct {
+	EtapaID            string   `json:"etapaId"`
+	Percentual         float64  `json:"percentual,omitempty"`
+	QuantidadeMedida   *float64 `json:"quantidadeMedida,omitempty"`
+	QuantidadePrevista *float64 `json:"quantidadePrevista,omitempty"`
+	Unidade            string   `json:"unidade,omitempty"`
+}
+
+// AprovarMedicaoInput registra o aceite do cliente. Sem DataVencimento, a parcela
+// vence no prazo padrão a partir da aprovação.
+type AprovarMedicaoInput struct {
+	ResponsavelCliente string `json:"responsavelCliente"`
+	DataVencimento     string `json:"dataVencimento,omitempty"`
+}
+
+type RejeitarMedicaoInput struct {
+	ResponsavelCliente string `json:"responsavelCliente"`
+	Motivo             string `json:"motivo"`
+}
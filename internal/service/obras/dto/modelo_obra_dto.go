@@ -0,0 +1,17 @@
+// file: internal/service/obras/dto/modelo_obra_dto.go
+package dto
+
+import "github.com/luiszkm/masterCostrutora/internal/domain/obras"
+
+// SalvarModeloObraInput cria ou substitui um modelo de obra. Com ObraOrigemID, as
+// etapas, dependências, orçamento e divisão do cronograma saem da obra informada
+// e os demais campos, exceto nome e descrição, são ignorados.
+type SalvarModeloObraInput struct {
+	Nome              string                       `json:"nome"`
+	Descricao         string                       `json:"descricao"`
+	ObraOrigemID      string                       `json:"obraOrigemId,omitempty"`
+	PercentualEntrada float64                      `json:"percentualEntrada"`
+	PrazoDiasEtapa    int                          `json:"prazoDiasEtapa"`
+	Etapas            []*obras.EtapaModelo         `json:"etapas"`
+	Orcamento         []*obras.ItemOrcamentoModelo `json:"orcamento"`
+}
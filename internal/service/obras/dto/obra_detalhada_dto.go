@@ -8,6 +8,7 @@ type ObraDetalhadaDTO struct {
 	ID           string                  `json:"id"`
 	Nome         string                  `json:"nome"`
 	Cliente      string                  `json:"cliente"`
+	ClienteID    *string                 `json:"clienteId,omitempty"`
 	Endereco     string                  `json:"endereco"`
 	DataInicio   time.Time               `json:"dataInicio"`
 	DataFim      *time.Time              `json:"dataFim,omitempty"`
@@ -18,6 +19,7 @@ type ObraDetalhadaDTO struct {
 	Fornecedores []FornecedorDTO         `json:"fornecedores"`
 	Orcamentos   []OrcamentoDTO          `json:"orcamentos"`
 	Produtos     []ProdutoDto            `json:"produtos"`
+	Contrato     *ContratoObraDTO        `json:"contrato,omitempty"`
 }
 
 // EtapaDTO representa uma etapa dentro da resposta detalhada.
@@ -0,0 +1,69 @@
+package dto
+
+import "github.com/luiszkm/masterCostrutora/internal/domain/obras"
+
+// DefinirOrcamentoAnaliticoInput substitui a linha de base de custo da obra.
+// Limites omitidos assumem 90% (alerta) e 100% (crítico).
+type DefinirOrcamentoAnaliticoInput struct {
+	PercentualAlerta  *float64                      `json:"percentualAlerta,omitempty"`
+	PercentualCritico *float64                      `json:"percentualCritico,omitempty"`
+	Itens             []ItemOrcamentoAnaliticoInput `json:"itens"`
+}
+
+type ItemOrcamentoAnaliticoInput struct {
+	EtapaID       *string `json:"etapaId,omitempty"` // Omitido: linha da obra como um todo
+	Categoria     string  `json:"categoria"`         // MATERIAL, MAO_DE_OBRA, SERVICOS ou EQUIPAMENTOS
+	ValorPrevisto float64 `json:"valorPrevisto"`
+}
+
+// CustoApurado é o custo comprometido e realizado de uma etapa/categoria.
+type CustoApurado struct {
+	EtapaID      *string `json:"etapaId"`
+	Categoria    string  `json:"categoria"`
+	Comprometido float64 `json:"comprometido"`
+	Realizado    float64 `json:"realizado"`
+}
+
+// ResumoCusto compara o previsto com o comprometido e o realizado.
+// Projetado é o maior entre comprometido e realizado; é ele que dispara os alertas.
+type ResumoCusto struct {
+	Previsto            float64                `json:"previsto"`
+	Comprometido        float64                `json:"comprometido"`
+	Realizado           float64                `json:"realizado"`
+	Projetado           float64                `json:"projetado"`
+	Variacao            float64                `json:"variacao"` // Previsto - Projetado; negativo indica estouro
+	PercentualConsumido float64                `json:"percentualConsumido"`
+	Nivel               obras.NivelAlertaCusto `json:"nivel"`
+}
+
+// LinhaControleCusto é uma linha etapa + categoria do controle de custos.
+type LinhaControleCusto struct {
+	EtapaID   *string              `json:"etapaId,omitempty"`
+	EtapaNome string               `json:"etapaNome"`
+	Categoria obras.CategoriaCusto `json:"categoria"`
+	ResumoCusto
+}
+
+type ResumoCustoCategoria struct {
+	Categoria obras.CategoriaCusto `json:"categoria"`
+	ResumoCusto
+}
+
+type ResumoCustoEtapa struct {
+	EtapaID   *string `json:"etapaId,omitempty"`
+	EtapaNome string  `json:"etapaNome"`
+	ResumoCusto
+}
+
+// ControleCustosOutput é o orçamento analítico comparado ao custo apurado da obra.
+type ControleCustosOutput struct {
+	ObraID            string                 `json:"obraId"`
+	PossuiOrcamento   bool                   `json:"possuiOrcamento"`
+	PercentualAlerta  float64                `json:"percentualAlerta"`
+	PercentualCritico float64                `json:"percentualCritico"`
+	Total             ResumoCusto            `json:"total"`
+	PorCategoria      []ResumoCustoCategoria `json:"porCategoria"`
+	PorEtapa          []ResumoCustoEtapa     `json:"porEtapa"`
+	Linhas            []LinhaControleCusto   `json:"linhas"`
+	Alertas           []LinhaControleCusto   `json:"alertas"`
+}
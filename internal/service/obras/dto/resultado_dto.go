@@ -0,0 +1,97 @@
+package dto
+
+import "time"
+
+// ObraResultadoBase traz os dados da obra usados na carteira de resultados.
+type ObraResultadoBase struct {
+	ID                 string  `json:"id"`
+	Nome               string  `json:"nome"`
+	Status             string  `json:"status"`
+	ValorContratoTotal float64 `json:"valorContratoTotal"`
+	PercentualFisico   float64 `json:"percentualFisico"`
+}
+
+// CustoResultado é o custo de uma obra em uma categoria:
+//   - comprometido: orçamentos aprovados, contas a pagar sem orçamento e apontamentos aprovados;
+//   - incorrido: contas a pagar lançadas e apontamentos aprovados;
+//   - pago: o que já saiu do caixa.
+type CustoResultado struct {
+	ObraID       string  `json:"obraId,omitempty"`
+	Categoria    string  `json:"categoria"`
+	Comprometido float64 `json:"comprometido"`
+	Incorrido    float64 `json:"incorrido"`
+	Pago         float64 `json:"pago"`
+}
+
+// FaturamentoResultado é a receita emitida (parcelas e contas a receber) e recebida da obra.
+type FaturamentoResultado struct {
+	ObraID   string  `json:"obraId"`
+	Faturado float64 `json:"faturado"`
+	Recebido float64 `json:"recebido"`
+}
+
+// FluxoCaixaMensal é o que a obra recebeu e pagou em um mês (AAAA-MM).
+type FluxoCaixaMensal struct {
+	ObraID   string  `json:"obraId"`
+	Mes      string  `json:"mes"`
+	Recebido float64 `json:"recebido"`
+	Pago     float64 `json:"pago"`
+}
+
+// ResumoResultado reúne receita, custo e margem de uma obra ou da carteira.
+type ResumoResultado struct {
+	ValorContratado           float64 `json:"valorContratado"`
+	ValorFaturado             float64 `json:"valorFaturado"`
+	ValorRecebido             float64 `json:"valorRecebido"`
+	SaldoAReceber             float64 `json:"saldoAReceber"`
+	CustoComprometido         float64 `json:"custoComprometido"`
+	CustoIncorrido            float64 `json:"custoIncorrido"`
+	CustoPago                 float64 `json:"custoPago"`
+	ReceitaReconhecida        float64 `json:"receitaReconhecida"` // Valor contratado × percentual físico executado
+	MargemBruta               float64 `json:"margemBruta"`        // Receita reconhecida - custo incorrido
+	MargemBrutaPercentual     float64 `json:"margemBrutaPercentual"`
+	CustoProjetado            float64 `json:"custoProjetado"`  // Estimativa do custo no término
+	MargemProjetada           float64 `json:"margemProjetada"` // Valor contratado - custo projetado
+	MargemProjetadaPercentual float64 `json:"margemProjetadaPercentual"`
+	SaldoCaixa                float64 `json:"saldoCaixa"`      // Recebido - pago até hoje
+	ExposicaoMaxima           float64 `json:"exposicaoMaxima"` // Maior saldo de caixa negativo acumulado
+}
+
+// PontoFluxoCaixa é um mês do fluxo de caixa da obra.
+type PontoFluxoCaixa struct {
+	Mes            string  `json:"mes"`
+	Recebido       float64 `json:"recebido"`
+	Pago           float64 `json:"pago"`
+	Saldo          float64 `json:"saldo"`
+	SaldoAcumulado float64 `json:"saldoAcumulado"`
+}
+
+// ResultadoObraOutput é o relatório de rentabilidade de uma obra.
+type ResultadoObraOutput struct {
+	ObraID             string            `json:"obraId"`
+	Nome               string            `json:"nome"`
+	Status             string            `json:"status"`
+	DataReferencia     time.Time         `json:"dataReferencia"`
+	PercentualFisico   float64           `json:"percentualFisico"`
+	CustosPorCategoria []CustoResultado  `json:"custosPorCategoria"`
+	ExposicaoCaixa     []PontoFluxoCaixa `json:"exposicaoCaixa"`
+	ResumoResultado
+}
+
+// ResultadoObraItem é uma obra na carteira de resultados.
+type ResultadoObraItem struct {
+	ObraID           string  `json:"obraId"`
+	Nome             string  `json:"nome"`
+	Status           string  `json:"status"`
+	PercentualFisico float64 `json:"percentualFisico"`
+	ResumoResultado
+}
+
+// ResultadoCarteiraOutput consolida o resultado de todas as obras.
+type ResultadoCarteiraOutput struct {
+	DataReferencia     time.Time           `json:"dataReferencia"`
+	Total              ResumoResultado     `json:"total"`
+	CustosPorCategoria []CustoResultado    `json:"custosPorCategoria"`
+	ExposicaoCaixa     []PontoFluxoCaixa   `json:"exposicaoCaixa"`
+	Obras              []ResultadoObraItem `json:"obras"`
+}
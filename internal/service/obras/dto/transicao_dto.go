@@ -0,0 +1,16 @@
+package dto
+
+import "github.com/luiszkm/masterCostrutora/internal/domain/obras"
+
+// ExecutarTransicaoInput representa o pedido de mudança de ciclo de vida da obra
+type ExecutarTransicaoInput struct {
+	Transicao string `json:"transicao" validate:"required,oneof=iniciar concluir cancelar"`
+	Motivo    string `json:"motivo,omitempty"` // Obrigatório para cancelar
+}
+
+// TransicoesObraOutput lista o status atual e as transições possíveis, com os motivos de bloqueio
+type TransicoesObraOutput struct {
+	ObraID      string                     `json:"obraId"`
+	StatusAtual obras.Status               `json:"statusAtual"`
+	Transicoes  []obras.AvaliacaoTransicao `json:"transicoes"`
+}
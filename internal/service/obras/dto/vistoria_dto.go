@@ -0,0 +1,44 @@
+// file: internal/service/obras/dto/vistoria_dto.go
+package dto
+
+import "github.com/luiszkm/masterCostrutora/internal/domain/obras"
+
+// AbrirVistoriaInput abre uma nova rodada de vistoria (ou revistoria) da obra.
+type AbrirVistoriaInput struct {
+	Data        string `json:"data"` // AAAA-MM-DD; padrão: hoje
+	Vistoriador string `json:"vistoriador"`
+	Observacoes string `json:"observacoes"`
+}
+
+// PendenciaVistoriaInput cria ou altera uma pendência da vistoria.
+type PendenciaVistoriaInput struct {
+	Descricao   string  `json:"descricao"`
+	Local       string  `json:"local"`
+	EtapaID     *string `json:"etapaId,omitempty"`
+	Responsavel string  `json:"responsavel"`
+	FotoURL     string  `json:"fotoUrl"`
+	Prazo       string  `json:"prazo"` // AAAA-MM-DD
+	Bloqueante  bool    `json:"bloqueante"`
+}
+
+// VerificarPendenciaInput registra o resultado da pendência na revistoria aberta.
+type VerificarPendenciaInput struct {
+	Aprovada   bool   `json:"aprovada"`
+	Observacao string `json:"observacao"`
+}
+
+// PendenciaVistoriaOutput é a pendência com a indicação de prazo vencido.
+type PendenciaVistoriaOutput struct {
+	*obras.PendenciaVistoria
+	Atrasada bool `json:"atrasada"`
+}
+
+// VistoriasObraOutput reúne as rodadas de vistoria e a lista de pendências da obra.
+type VistoriasObraOutput struct {
+	ObraID                string                     `json:"obraId"`
+	Vistorias             []*obras.Vistoria          `json:"vistorias"`
+	Pendencias            []*PendenciaVistoriaOutput `json:"pendencias"`
+	TotalPendencias       int                        `json:"totalPendencias"`
+	PendenciasEmAberto    int                        `json:"pendenciasEmAberto"`
+	PendenciasBloqueantes int                        `json:"pendenciasBloqueantes"` // Bloqueantes não resolvidas: impedem a conclusão
+}
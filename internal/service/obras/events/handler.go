@@ -7,15 +7,22 @@ import (
 
 	"github.com/luiszkm/masterCostrutora/internal/events"
 	"github.com/luiszkm/masterCostrutora/internal/platform/bus"
+	"github.com/luiszkm/masterCostrutora/internal/service/dashboard"
 )
 
+// InvalidadorDashboard descarta seções em cache do dashboard.
+type InvalidadorDashboard interface {
+	InvalidarSecoes(ctx context.Context, secoes ...string)
+}
+
 // ObrasEventHandler lida com eventos destinados ao contexto de Obras.
 type ObrasEventHandler struct {
-	logger *slog.Logger
+	dashboard InvalidadorDashboard
+	logger    *slog.Logger
 }
 
-func NovoObrasEventHandler(logger *slog.Logger) *ObrasEventHandler {
-	return &ObrasEventHandler{logger: logger}
+func NovoObrasEventHandler(dashboard InvalidadorDashboard, logger *slog.Logger) *ObrasEventHandler {
+	return &ObrasEventHandler{dashboard: dashboard, logger: logger}
 }
 
 // HandleOrcamentoStatusAtualizado é o método que será subscrito ao evento.
@@ -26,9 +33,10 @@ func (h *ObrasEventHandler) HandleOrcamentoStatusAtualizado(ctx context.Context,
 		return
 	}
 
-	h.logger.Info("EVENTO RECEBIDO PELO CONTEXTO DE OBRAS!", "novo_status", payload.NovoStatus, "orcamento_id", payload.OrcamentoID)
+	h.logger.InfoContext(ctx, "status de orçamento atualizado", "novo_status", payload.NovoStatus, "orcamento_id", payload.OrcamentoID)
 
-	// TODO: Lógica futura aqui.
-	// Por exemplo, poderíamos usar o payload.EtapaID para encontrar a Obra
-	// e forçar a atualização de um modelo de leitura (dashboard) em cache.
+	// O status do orçamento altera custos das obras, gastos com fornecedores e os alertas
+	if h.dashboard != nil {
+		h.dashboard.InvalidarSecoes(ctx, dashboard.SecaoObras, dashboard.SecaoFinanceiro, dashboard.SecaoFornecedores, dashboard.SecaoGeral)
+	}
 }
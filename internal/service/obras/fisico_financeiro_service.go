@@ -0,0 +1,306 @@
+package obras
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// FisicoFinanceiroQuerier fornece os custos orçados por etapa e os valores realizados da obra.
+type FisicoFinanceiroQuerier interface {
+	ListarCustoPrevistoPorEtapa(ctx context.Context, obraID string) (map[string]float64, error)
+	ListarLancamentosRealizados(ctx context.Context, obraID string) ([]dto.LancamentoRealizado, error)
+}
+
+// FisicoFinanceiroService monta o cronograma físico-financeiro e a curva S da obra.
+type FisicoFinanceiroService struct {
+	obraRepo       obras.ObrasRepository
+	etapaRepo      obras.EtapaRepository
+	cronogramaRepo obras.CronogramaRecebimentoRepository
+	querier        FisicoFinanceiroQuerier
+	logger         *slog.Logger
+}
+
+func NovoFisicoFinanceiroService(
+	obraRepo obras.ObrasRepository,
+	etapaRepo obras.EtapaRepository,
+	cronogramaRepo obras.CronogramaRecebimentoRepository,
+	querier FisicoFinanceiroQuerier,
+	logger *slog.Logger,
+) *FisicoFinanceiroService {
+	return &FisicoFinanceiroService{
+		obraRepo:       obraRepo,
+		etapaRepo:      etapaRepo,
+		cronogramaRepo: cronogramaRepo,
+		querier:        querier,
+		logger:         logger.With("service", "FisicoFinanceiro"),
+	}
+}
+
+// ObterFisicoFinanceiro distribui o custo orçado de cada etapa pelos dias previstos
+// e a receita pelo vencimento das parcelas, compara com o realizado e calcula os
+// indicadores de valor agregado na data de hoje sobre o custo orçado da obra.
+func (s *FisicoFinanceiroService) ObterFisicoFinanceiro(ctx context.Context, obraID string) (_ *dto.FisicoFinanceiroOutput, err error) {
+	const op = "service.obras.fisico_financeiro.ObterFisicoFinanceiro"
+
+	ctx, span := iniciarSpanRelatorio(ctx, "fisico_financeiro", obraID)
+	defer func() {
+		span.RegistrarErro(err)
+		span.Finalizar()
+	}()
+
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	etapas, err := s.etapaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	custos, err := s.querier.ListarCustoPrevistoPorEtapa(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	cronogramas, err := s.cronogramaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	lancamentos, err := s.querier.ListarLancamentosRealizados(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return montarFisicoFinanceiro(obraID, obra.ValorContratoTotal, etapas, custos, cronogramas, lancamentos, time.Now()), nil
+}
+
+// valoresMes acumula os valores de um mês da curva S.
+type valoresMes struct {
+	custoPrevisto, custoRealizado, receitaPrevista, receitaRealizada float64
+}
+
+func montarFisicoFinanceiro(
+	obraID string,
+	valorContrato float64,
+	etapas []*obras.Etapa,
+	custos map[string]float64,
+	cronogramas []*obras.CronogramaRecebimento,
+	lancamentos []dto.LancamentoRealizado,
+	agora time.Time,
+) *dto.FisicoFinanceiroOutput {
+	hoje := dataSemHora(agora)
+	meses := make(map[string]*valoresMes)
+	mes := func(t time.Time) *valoresMes {
+		chave := t.Format("2006-01")
+		if meses[chave] == nil {
+			meses[chave] = &valoresMes{}
+		}
+		return meses[chave]
+	}
+
+	output := &dto.FisicoFinanceiroOutput{
+		ObraID:                    obraID,
+		DataReferencia:            hoje,
+		PercentualFisicoRealizado: obras.PercentualPonderado(etapas),
+		CurvaS:                    []dto.PontoCurvaS{},
+		Etapas:                    make([]dto.EtapaFisicoFinanceiro, 0, len(etapas)),
+	}
+	ind := &output.Indicadores
+
+	var somaPesos float64
+	for _, e := range etapas {
+		somaPesos += e.Peso
+		output.CustoPrevistoTotal += custos[e.ID]
+	}
+	// Orçamento no término (BAC): o custo orçado, para que EV seja comparável ao
+	// custo real; o valor do contrato só entra quando a obra não tem orçamento.
+	bac := output.CustoPrevistoTotal
+	if bac == 0 {
+		bac = valorContrato
+	}
+
+	// Custo previsto: distribuído igualmente pelos dias corridos da etapa.
+	for _, e := range etapas {
+		custo := custos[e.ID]
+		item := dto.EtapaFisicoFinanceiro{
+			EtapaID:             e.ID,
+			Nome:                e.Nome,
+			DataInicioPrevista:  e.DataInicioPrevista,
+			DataFimPrevista:     e.DataFimPrevista,
+			CustoPrevisto:       custo,
+			PercentualExecutado: e.PercentualExecutado,
+			Meses:               []dto.ValorMensal{},
+		}
+		if somaPesos > 0 {
+			item.ValorAgregado = arredondar(bac * e.Peso / somaPesos * e.PercentualExecutado / 100)
+		}
+
+		inicio, fim, ok := periodoPrevisto(e)
+		if !ok {
+			output.CustoPrevistoNaoProgramado += custo
+			output.Etapas = append(output.Etapas, item)
+			continue
+		}
+		diario := custo / float64(diasCorridos(inicio, fim))
+		porMes := make(map[string]float64)
+		for d := inicio; !d.After(fim); d = d.AddDate(0, 0, 1) {
+			mes(d).custoPrevisto += diario
+			porMes[d.Format("2006-01")] += diario
+		}
+		for _, chave := range chavesOrdenadas(porMes) {
+			item.Meses = append(item.Meses, dto.ValorMensal{Mes: chave, Valor: arredondar(porMes[chave])})
+		}
+		output.Etapas = append(output.Etapas, item)
+	}
+
+	// Receita prevista: parcelas do cronograma no mês de vencimento.
+	for _, c := range cronogramas {
+		if c.Status == obras.StatusRecebimentoCancelado {
+			continue
+		}
+		mes(c.DataVencimento).receitaPrevista += c.ValorPrevisto
+		output.ReceitaPrevistaTotal += c.ValorPrevisto
+	}
+
+	// Realizado: pagamentos e recebimentos efetivados.
+	for _, l := range lancamentos {
+		if l.Tipo == dto.LancamentoReceita {
+			mes(l.Data).receitaRealizada += l.Valor
+			continue
+		}
+		mes(l.Data).custoRealizado += l.Valor
+		if !dataSemHora(l.Data).After(hoje) {
+			ind.CustoReal += l.Valor
+		}
+	}
+
+	var acumulado valoresMes
+	for _, chave := range mesesEntre(meses) {
+		v := meses[chave]
+		if v == nil {
+			v = &valoresMes{}
+		}
+		acumulado.custoPrevisto += v.custoPrevisto
+		acumulado.custoRealizado += v.custoRealizado
+		acumulado.receitaPrevista += v.receitaPrevista
+		acumulado.receitaRealizada += v.receitaRealizada
+
+		inicioMes, _ := time.Parse("2006-01", chave)
+		output.CurvaS = append(output.CurvaS, dto.PontoCurvaS{
+			Mes:                       chave,
+			CustoPrevisto:             arredondar(v.custoPrevisto),
+			CustoPrevistoAcumulado:    arredondar(acumulado.custoPrevisto),
+			CustoRealizado:            arredondar(v.custoRealizado),
+			CustoRealizadoAcumulado:   arredondar(acumulado.custoRealizado),
+			ReceitaPrevista:           arredondar(v.receitaPrevista),
+			ReceitaPrevistaAcumulada:  arredondar(acumulado.receitaPrevista),
+			ReceitaRealizada:          arredondar(v.receitaRealizada),
+			ReceitaRealizadaAcumulada: arredondar(acumulado.receitaRealizada),
+			PercentualFisicoPrevisto:  arredondar(percentualFisicoPrevisto(etapas, inicioMes.AddDate(0, 1, -1))),
+		})
+	}
+
+	// Valor agregado: o avanço físico planejado (pesos e datas previstas das etapas)
+	// e o realizado, aplicados ao orçamento no término.
+	output.PercentualFisicoPrevisto = percentualFisicoPrevisto(etapas, hoje)
+	ind.ValorPlanejado = bac * output.PercentualFisicoPrevisto / 100
+	ind.ValorAgregado = bac * output.PercentualFisicoRealizado / 100
+
+	if ind.ValorPlanejado > 0 {
+		spi := math.Round(ind.ValorAgregado/ind.ValorPlanejado*1000) / 1000
+		ind.IndiceDesempenhoPrazo = &spi
+	}
+	if ind.CustoReal > 0 {
+		cpi := ind.ValorAgregado / ind.CustoReal
+		if cpi > 0 {
+			eac := arredondar(bac / cpi)
+			ind.EstimativaNoTermino = &eac
+		}
+		cpi = math.Round(cpi*1000) / 1000
+		ind.IndiceDesempenhoCusto = &cpi
+	}
+	ind.ValorPlanejado = arredondar(ind.ValorPlanejado)
+	ind.ValorAgregado = arredondar(ind.ValorAgregado)
+	ind.CustoReal = arredondar(ind.CustoReal)
+	ind.VariacaoPrazo = arredondar(ind.ValorAgregado - ind.ValorPlanejado)
+	ind.VariacaoCusto = arredondar(ind.ValorAgregado - ind.CustoReal)
+	output.PercentualFisicoPrevisto = arredondar(output.PercentualFisicoPrevisto)
+	output.PercentualFisicoRealizado = arredondar(output.PercentualFisicoRealizado)
+	return output
+}
+
+// percentualFisicoPrevisto é o avanço físico planejado até a data, ponderado pelo
+// peso das etapas. Usa as mesmas etapas de obras.PercentualPonderado: as que não têm
+// datas previstas contam no total de pesos, mas não têm avanço planejado.
+func percentualFisicoPrevisto(etapas []*obras.Etapa, ate time.Time) float64 {
+	var somaPesos, somaPonderada float64
+	for _, e := range etapas {
+		somaPesos += e.Peso
+		inicio, fim, ok := periodoPrevisto(e)
+		if !ok || ate.Before(inicio) {
+			continue
+		}
+		fracao := float64(diasCorridos(inicio, ate)) / float64(diasCorridos(inicio, fim))
+		somaPonderada += e.Peso * min(fracao, 1)
+	}
+	if somaPesos == 0 {
+		return 0
+	}
+	return somaPonderada / somaPesos * 100
+}
+
+func periodoPrevisto(e *obras.Etapa) (time.Time, time.Time, bool) {
+	if e.DataInicioPrevista == nil || e.DataFimPrevista == nil || e.DataFimPrevista.Before(*e.DataInicioPrevista) {
+		return time.Time{}, time.Time{}, false
+	}
+	return dataSemHora(*e.DataInicioPrevista), dataSemHora(*e.DataFimPrevista), true
+}
+
+// diasCorridos conta os dias entre as datas, inclusive as duas pontas.
+func diasCorridos(inicio, fim time.Time) int {
+	return int(fim.Sub(inicio).Hours()/24) + 1
+}
+
+func dataSemHora(t time.Time) time.Time {
+	a, m, d := t.Date()
+	return time.Date(a, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// mesesEntre devolve todos os meses do primeiro ao último com valores, sem lacunas.
+func mesesEntre(meses map[string]*valoresMes) []string {
+	chaves := make([]string, 0, len(meses))
+	for chave := range meses {
+		chaves = append(chaves, chave)
+	}
+	if len(chaves) == 0 {
+		return chaves
+	}
+	sort.Strings(chaves)
+	primeiro, _ := time.Parse("2006-01", chaves[0])
+	ultimo, _ := time.Parse("2006-01", chaves[len(chaves)-1])
+
+	var todos []string
+	for m := primeiro; !m.After(ultimo); m = m.AddDate(0, 1, 0) {
+		todos = append(todos, m.Format("2006-01"))
+	}
+	return todos
+}
+
+// arredondar fixa os valores monetários e percentuais em duas casas decimais.
+func arredondar(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func chavesOrdenadas(valores map[string]float64) []string {
+	chaves := make([]string, 0, len(valores))
+	for chave := range valores {
+		chaves = append(chaves, chave)
+	}
+	sort.Strings(chaves)
+	return chaves
+}
@@ -0,0 +1,114 @@
+package obras
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+func TestMontarFisicoFinanceiroValorAgregado(t *testing.T) {
+	data := func(m time.Month, d int) *time.Time { t := time.Date(2025, m, d, 0, 0, 0, 0, time.UTC); return &t }
+	// Hoje é o 10º dia da fundação (1 a 20/03); a alvenaria (21/03 a 09/04) não começou
+	// e a cobertura, vinda do catálogo, ainda não tem datas.
+	hoje := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	etapas := func(executadoFundacao float64) []*obras.Etapa {
+		return []*obras.Etapa{
+			{ID: "fundacao", Peso: 1, DataInicioPrevista: data(3, 1), DataFimPrevista: data(3, 20), PercentualExecutado: executadoFundacao},
+			{ID: "alvenaria", Peso: 3, DataInicioPrevista: data(3, 21), DataFimPrevista: data(4, 9)},
+			{ID: "cobertura", Peso: 4},
+		}
+	}
+	ptr := func(v float64) *float64 { return &v }
+	// O custo orçado (40.000) é o BAC; o valor do contrato inclui a margem.
+	custos := map[string]float64{"fundacao": 10000, "alvenaria": 30000}
+	pago := []dto.LancamentoRealizado{{Tipo: dto.LancamentoCusto, Data: *data(3, 5), Valor: 2500}}
+
+	casos := []struct {
+		nome                    string
+		valorContrato           float64
+		custos                  map[string]float64
+		etapas                  []*obras.Etapa
+		lancamentos             []dto.LancamentoRealizado
+		previsto, realizado     float64
+		pv, ev, custoReal       float64
+		spi, cpi, eac           *float64
+		evFundacao, evAlvenaria float64
+	}{
+		{
+			nome:          "no prazo",
+			valorContrato: 400000,
+			custos:        custos,
+			etapas:        etapas(50),
+			previsto:      6.25, realizado: 6.25,
+			pv: 2500, ev: 2500,
+			spi:        ptr(1),
+			evFundacao: 2500,
+		},
+		{
+			nome:          "atrasada e com custo real",
+			valorContrato: 400000,
+			custos:        custos,
+			etapas:        etapas(25),
+			lancamentos:   pago,
+			previsto:      6.25, realizado: 3.13,
+			pv: 2500, ev: 1250, custoReal: 2500,
+			spi: ptr(0.5), cpi: ptr(0.5), eac: ptr(80000),
+			evFundacao: 1250,
+		},
+		{
+			nome:          "sem orçamento usa o valor do contrato",
+			valorContrato: 400000,
+			etapas:        etapas(50),
+			previsto:      6.25, realizado: 6.25,
+			pv: 25000, ev: 25000,
+			spi:        ptr(1),
+			evFundacao: 25000,
+		},
+		{
+			nome:     "sem orçamento nem valor de contrato",
+			etapas:   etapas(50),
+			previsto: 6.25, realizado: 6.25,
+		},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			out := montarFisicoFinanceiro("obra", tc.valorContrato, tc.etapas, tc.custos, nil, tc.lancamentos, hoje)
+			ind := out.Indicadores
+
+			if out.PercentualFisicoPrevisto != tc.previsto || out.PercentualFisicoRealizado != tc.realizado {
+				t.Errorf("físico previsto/realizado = %v/%v, esperado %v/%v",
+					out.PercentualFisicoPrevisto, out.PercentualFisicoRealizado, tc.previsto, tc.realizado)
+			}
+			if ind.ValorPlanejado != tc.pv || ind.ValorAgregado != tc.ev || ind.CustoReal != tc.custoReal {
+				t.Errorf("PV/EV/AC = %v/%v/%v, esperado %v/%v/%v",
+					ind.ValorPlanejado, ind.ValorAgregado, ind.CustoReal, tc.pv, tc.ev, tc.custoReal)
+			}
+			for nome, par := range map[string][2]*float64{
+				"spi": {ind.IndiceDesempenhoPrazo, tc.spi},
+				"cpi": {ind.IndiceDesempenhoCusto, tc.cpi},
+				"eac": {ind.EstimativaNoTermino, tc.eac},
+			} {
+				got, esperado := par[0], par[1]
+				if (got == nil) != (esperado == nil) || (got != nil && *got != *esperado) {
+					t.Errorf("%s = %v, esperado %v", nome, valorOuNil(got), valorOuNil(esperado))
+				}
+			}
+			if got := out.Etapas[0].ValorAgregado; got != tc.evFundacao {
+				t.Errorf("EV da fundação = %v, esperado %v", got, tc.evFundacao)
+			}
+			if got := out.Etapas[1].ValorAgregado; got != tc.evAlvenaria {
+				t.Errorf("EV da alvenaria = %v, esperado %v", got, tc.evAlvenaria)
+			}
+		})
+	}
+}
+
+func valorOuNil(v *float64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
@@ -0,0 +1,316 @@
+package obras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/financeiro"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+var (
+	ErrTipoCobrancaNaoDefinido   = errors.New("tipo de cobrança da obra não definido")
+	ErrParametrosCronograma      = errors.New("parâmetros de geração do cronograma inválidos")
+	ErrCronogramaExistente       = errors.New("a obra já tem cronograma de recebimento; use substituirExistente")
+	ErrCronogramaComRecebimentos = errors.New("o cronograma atual já tem recebimentos e não pode ser substituído")
+	ErrCronogramaComMedicoes     = errors.New("a obra já tem medições faturadas; o cronograma não pode ser gerado a partir do contrato")
+)
+
+// PreverCronograma monta o cronograma de recebimento a partir do contrato da obra, sem salvar.
+func (s *CronogramaService) PreverCronograma(ctx context.Context, obraID string, input dto.GerarCronogramaInput) (*dto.CronogramaGeradoOutput, error) {
+	const op = "service.obras.cronograma.PreverCronograma"
+
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	previa, err := s.preverCronograma(ctx, obra, input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return previa, nil
+}
+
+// preverCronograma reparte o contrato da obra informada, que pode trazer valores ainda não salvos.
+func (s *CronogramaService) preverCronograma(ctx context.Context, obra *obras.Obra, input dto.GerarCronogramaInput) (*dto.CronogramaGeradoOutput, error) {
+	etapas, err := s.etapaRepo.ListarPorObraID(ctx, obra.ID)
+	if err != nil {
+		return nil, err
+	}
+	parcelas, err := montarParcelasContrato(obra, etapas, input, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CronogramaGeradoOutput{
+		ObraID:             obra.ID,
+		TipoCobranca:       obra.TipoCobranca,
+		ValorContratoTotal: obra.ValorContratoTotal,
+		Parcelas:           parcelas,
+	}, nil
+}
+
+// GerarCronograma monta e salva o cronograma a partir do contrato, em uma transação.
+func (s *CronogramaService) GerarCronograma(ctx context.Context, obraID string, input dto.GerarCronogramaInput) ([]*dto.CronogramaRecebimentoOutput, error) {
+	const op = "service.obras.cronograma.GerarCronograma"
+
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.dbpool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao iniciar transação: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	cronogramas, err := s.SalvarCronogramaDoContrato(ctx, tx, obra, input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: falha ao fazer commit: %w", op, err)
+	}
+
+	s.PublicarCronogramaCriado(ctx, obra, cronogramas)
+	return s.toOutputs(cronogramas), nil
+}
+
+// SalvarCronogramaDoContrato monta o cronograma a partir do contrato da obra
+// informada e o salva na transação do chamador, sem publicar o evento. Com
+// SubstituirExistente, as parcelas atuais são removidas e as contas a receber em
+// aberto geradas por elas são canceladas na mesma transação; parcelas com
+// recebimento impedem a substituição.
+// Obras já faturadas por medição não recebem cronograma do contrato.
+func (s *CronogramaService) SalvarCronogramaDoContrato(ctx context.Context, dbtx db.DBTX, obra *obras.Obra, input dto.GerarCronogramaInput) ([]*obras.CronogramaRecebimento, error) {
+	const op = "service.obras.cronograma.SalvarCronogramaDoContrato"
+	obraID := obra.ID
+
+	previa, err := s.preverCronograma(ctx, obra, input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	medicoes, err := s.medicaoRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if temMedicaoFaturada(medicoes) {
+		return nil, fmt.Errorf("%s: %w", op, ErrCronogramaComMedicoes)
+	}
+	existentes, err := s.cronogramaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	substituidos := make(map[string]bool, len(existentes))
+	for _, c := range existentes {
+		if !input.SubstituirExistente {
+			return nil, fmt.Errorf("%s: %w", op, ErrCronogramaExistente)
+		}
+		if c.ValorRecebido > 0 {
+			return nil, fmt.Errorf("%s: %w", op, ErrCronogramaComRecebimentos)
+		}
+		substituidos[c.ID] = true
+	}
+	// As contas são listadas antes da substituição: ao remover o cronograma, o
+	// vínculo da conta com ele deixa de existir.
+	contas, err := s.contaReceberRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	motivo := "cronograma de recebimento substituído"
+	var canceladas []*financeiro.ContaReceber
+	for _, c := range contas {
+		if c.CronogramaRecebimentoID == nil || !substituidos[*c.CronogramaRecebimentoID] || !c.EstaEmAberto() {
+			continue
+		}
+		if err := c.Cancelar(&motivo); err != nil {
+			return nil, fmt.Errorf("%s: conta a receber %s: %w", op, c.ID, err)
+		}
+		canceladas = append(canceladas, c)
+	}
+
+	lote := dto.CriarCronogramaEmLoteInput{
+		ObraID:              obraID,
+		SubstituirExistente: input.SubstituirExistente,
+	}
+	for _, p := range previa.Parcelas {
+		lote.Cronogramas = append(lote.Cronogramas, dto.CriarCronogramaRecebimentoInput{
+			ObraID:         obraID,
+			NumeroEtapa:    p.NumeroEtapa,
+			DescricaoEtapa: p.DescricaoEtapa,
+			ValorPrevisto:  p.ValorPrevisto,
+			DataVencimento: p.DataVencimento,
+		})
+	}
+	cronogramas, err := s.salvarLote(ctx, dbtx, lote, obra.PercentualRetencao, canceladas)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "cronograma de recebimento gerado a partir do contrato",
+		"obra_id", obraID, "parcelas", len(cronogramas), "substituidas", len(substituidos), "contas_canceladas", len(canceladas))
+	return cronogramas, nil
+}
+
+// fatiaContrato é uma parte do contrato, em percentual, com seu vencimento.
+type fatiaContrato struct {
+	descricao  string
+	percentual float64
+	vencimento time.Time
+}
+
+// montarParcelasContrato reparte o valor do contrato conforme o tipo de cobrança.
+// Os valores são arredondados em centavos e a diferença de arredondamento fica na última parcela.
+func montarParcelasContrato(obra *obras.Obra, etapas []*obras.Etapa, input dto.GerarCronogramaInput, hoje time.Time) ([]dto.ParcelaGeradaOutput, error) {
+	if obra.ValorContratoTotal <= 0 {
+		return nil, ErrObraSemValorContrato
+	}
+	if input.PercentualEntrada < 0 || input.PercentualEntrada >= 100 {
+		return nil, fmt.Errorf("%w: percentual de entrada deve estar entre 0 e 100", ErrParametrosCronograma)
+	}
+	if input.DiaVencimento < 0 || input.DiaVencimento > 31 {
+		return nil, fmt.Errorf("%w: dia de vencimento deve estar entre 1 e 31", ErrParametrosCronograma)
+	}
+	if input.PrazoDiasEtapa < 0 {
+		return nil, fmt.Errorf("%w: prazo após a etapa não pode ser negativo", ErrParametrosCronograma)
+	}
+
+	base := dataSemHora(hoje)
+	if obra.DataAssinaturaContrato != nil {
+		base = dataSemHora(*obra.DataAssinaturaContrato)
+	}
+	if data, err := parseDataOpcional(&input.DataBase); err != nil {
+		return nil, err
+	} else if data != nil {
+		base = *data
+	}
+
+	var fatias []fatiaContrato
+	if input.PercentualEntrada > 0 && obra.TipoCobranca != obras.TipoCobrancaVista {
+		fatias = append(fatias, fatiaContrato{"Entrada", input.PercentualEntrada, base})
+	}
+	restante := 100 - input.PercentualEntrada
+
+	switch obra.TipoCobranca {
+	case obras.TipoCobrancaVista:
+		fatias = append(fatias, fatiaContrato{"Pagamento à vista", 100, base})
+	case obras.TipoCobrancaParcelado:
+		if input.NumeroParcelas < 1 {
+			return nil, fmt.Errorf("%w: informe o número de parcelas", ErrParametrosCronograma)
+		}
+		for i := 1; i <= input.NumeroParcelas; i++ {
+			fatias = append(fatias, fatiaContrato{
+				descricao:  fmt.Sprintf("Parcela %d/%d", i, input.NumeroParcelas),
+				percentual: restante / float64(input.NumeroParcelas),
+				vencimento: vencimentoMensal(base, i, input.DiaVencimento),
+			})
+		}
+	case obras.TipoCobrancaEtapas:
+		porEtapa, err := fatiasPorEtapa(etapas, input, restante, base)
+		if err != nil {
+			return nil, err
+		}
+		fatias = append(fatias, porEtapa...)
+	default:
+		return nil, ErrTipoCobrancaNaoDefinido
+	}
+
+	parcelas := make([]dto.ParcelaGeradaOutput, len(fatias))
+	var acumulado float64
+	for i, f := range fatias {
+		valor := arredondar(obra.ValorContratoTotal * f.percentual / 100)
+		if i == len(fatias)-1 {
+			valor = arredondar(obra.ValorContratoTotal - acumulado)
+		}
+		if valor <= 0 {
+			return nil, fmt.Errorf("%w: a parcela '%s' ficaria sem valor", ErrParametrosCronograma, f.descricao)
+		}
+		acumulado += valor
+		parcelas[i] = dto.ParcelaGeradaOutput{
+			NumeroEtapa:    i + 1,
+			DescricaoEtapa: f.descricao,
+			Percentual:     arredondar(f.percentual),
+			ValorPrevisto:  valor,
+			DataVencimento: f.vencimento,
+		}
+	}
+	return parcelas, nil
+}
+
+// fatiasPorEtapa cobra cada etapa ao fim programado dela (o previsto, se não foi
+// reprogramada), mais o prazo informado.
+// Sem percentuais informados, o restante do contrato é repartido pelo peso das etapas.
+func fatiasPorEtapa(etapas []*obras.Etapa, input dto.GerarCronogramaInput, restante float64, base time.Time) ([]fatiaContrato, error) {
+	vencimentoEtapa := func(e *obras.Etapa) time.Time {
+		fim := e.DataFimPrevista
+		if e.DataFimProgramada != nil {
+			fim = e.DataFimProgramada
+		}
+		if fim == nil {
+			return base
+		}
+		return dataSemHora(*fim).AddDate(0, 0, input.PrazoDiasEtapa)
+	}
+
+	var fatias []fatiaContrato
+	if len(input.Etapas) == 0 {
+		if len(etapas) == 0 {
+			return nil, fmt.Errorf("%w: a obra não tem etapas", ErrParametrosCronograma)
+		}
+		var somaPesos float64
+		for _, e := range etapas {
+			somaPesos += e.Peso
+		}
+		for _, e := range etapas {
+			fatias = append(fatias, fatiaContrato{"Etapa: " + e.Nome, restante * e.Peso / somaPesos, vencimentoEtapa(e)})
+		}
+		return fatias, nil
+	}
+
+	porID := make(map[string]*obras.Etapa, len(etapas))
+	for _, e := range etapas {
+		porID[e.ID] = e
+	}
+	soma := 0.0
+	for _, in := range input.Etapas {
+		etapa, ok := porID[in.EtapaID]
+		if !ok {
+			return nil, ErrEtapaForaDaObra
+		}
+		if in.Percentual <= 0 {
+			return nil, fmt.Errorf("%w: percentual da etapa '%s' deve ser positivo", ErrParametrosCronograma, etapa.Nome)
+		}
+		vencimento := vencimentoEtapa(etapa)
+		data, err := parseDataOpcional(&in.DataVencimento)
+		if err != nil {
+			return nil, err
+		}
+		if data != nil {
+			vencimento = *data
+		}
+		soma += in.Percentual
+		fatias = append(fatias, fatiaContrato{"Etapa: " + etapa.Nome, in.Percentual, vencimento})
+	}
+	if math.Abs(soma-restante) > 0.01 {
+		return nil, fmt.Errorf("%w: entrada e etapas somam %.2f%%, e não 100%%", ErrParametrosCronograma, soma+100-restante)
+	}
+	return fatias, nil
+}
+
+// vencimentoMensal devolve o vencimento meses depois da data base, no dia
+// informado (ou no dia da data base). Meses mais curtos vencem no último dia.
+func vencimentoMensal(base time.Time, meses, dia int) time.Time {
+	if dia == 0 {
+		dia = base.Day()
+	}
+	primeiro := time.Date(base.Year(), base.Month()+time.Month(meses), 1, 0, 0, 0, 0, time.UTC)
+	ultimo := primeiro.AddDate(0, 1, -1).Day()
+	return primeiro.AddDate(0, 0, min(dia, ultimo)-1)
+}
@@ -0,0 +1,202 @@
+package obras
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+func TestMontarParcelasContrato(t *testing.T) {
+	data := func(a int, m time.Month, d int) time.Time { return time.Date(a, m, d, 0, 0, 0, 0, time.UTC) }
+	ptr := func(t time.Time) *time.Time { return &t }
+	hoje := data(2025, 1, 10)
+	assinatura := data(2025, 1, 31)
+
+	etapas := []*obras.Etapa{
+		{ID: "fundacao", Nome: "Fundação", Peso: 1, DataFimPrevista: ptr(data(2025, 3, 10))},
+		{ID: "estrutura", Nome: "Estrutura", Peso: 2, DataFimPrevista: ptr(data(2025, 5, 20))},
+	}
+
+	type parcela struct {
+		valor      float64
+		vencimento time.Time
+	}
+
+	casos := []struct {
+		nome     string
+		obra     obras.Obra
+		input    dto.GerarCronogramaInput
+		esperado []parcela
+		erro     error
+	}{
+		{
+			nome:     "à vista vence na assinatura",
+			obra:     obras.Obra{TipoCobranca: obras.TipoCobrancaVista, ValorContratoTotal: 150000, DataAssinaturaContrato: &assinatura},
+			input:    dto.GerarCronogramaInput{PercentualEntrada: 20},
+			esperado: []parcela{{150000, assinatura}},
+		},
+		{
+			nome:     "sem assinatura usa a data de hoje",
+			obra:     obras.Obra{TipoCobranca: obras.TipoCobrancaVista, ValorContratoTotal: 1000},
+			esperado: []parcela{{1000, hoje}},
+		},
+		{
+			nome:     "data base informada prevalece sobre a assinatura",
+			obra:     obras.Obra{TipoCobranca: obras.TipoCobrancaVista, ValorContratoTotal: 1000, DataAssinaturaContrato: &assinatura},
+			input:    dto.GerarCronogramaInput{DataBase: "2025-02-15"},
+			esperado: []parcela{{1000, data(2025, 2, 15)}},
+		},
+		{
+			nome:  "resto do arredondamento fica na última parcela e fim de mês é respeitado",
+			obra:  obras.Obra{TipoCobranca: obras.TipoCobrancaParcelado, ValorContratoTotal: 1000, DataAssinaturaContrato: &assinatura},
+			input: dto.GerarCronogramaInput{NumeroParcelas: 3},
+			esperado: []parcela{
+				{333.33, data(2025, 2, 28)},
+				{333.33, data(2025, 3, 31)},
+				{333.34, data(2025, 4, 30)},
+			},
+		},
+		{
+			nome:  "entrada e parcelas no dia informado",
+			obra:  obras.Obra{TipoCobranca: obras.TipoCobrancaParcelado, ValorContratoTotal: 1000, DataAssinaturaContrato: &assinatura},
+			input: dto.GerarCronogramaInput{PercentualEntrada: 10, NumeroParcelas: 2, DiaVencimento: 5},
+			esperado: []parcela{
+				{100, assinatura},
+				{450, data(2025, 2, 5)},
+				{450, data(2025, 3, 5)},
+			},
+		},
+		{
+			nome:  "etapas repartidas pelo peso, vencendo após o fim previsto",
+			obra:  obras.Obra{TipoCobranca: obras.TipoCobrancaEtapas, ValorContratoTotal: 1000, DataAssinaturaContrato: &assinatura},
+			input: dto.GerarCronogramaInput{PrazoDiasEtapa: 15},
+			esperado: []parcela{
+				{333.33, data(2025, 3, 25)},
+				{666.67, data(2025, 6, 4)},
+			},
+		},
+		{
+			nome: "etapas com percentuais e vencimento informados",
+			obra: obras.Obra{TipoCobranca: obras.TipoCobrancaEtapas, ValorContratoTotal: 2000, DataAssinaturaContrato: &assinatura},
+			input: dto.GerarCronogramaInput{PercentualEntrada: 25, Etapas: []dto.PercentualEtapaInput{
+				{EtapaID: "estrutura", Percentual: 50, DataVencimento: "2025-06-01"},
+				{EtapaID: "fundacao", Percentual: 25},
+			}},
+			esperado: []parcela{
+				{500, assinatura},
+				{1000, data(2025, 6, 1)},
+				{500, data(2025, 3, 10)},
+			},
+		},
+		{
+			nome: "percentuais das etapas não fecham 100%",
+			obra: obras.Obra{TipoCobranca: obras.TipoCobrancaEtapas, ValorContratoTotal: 2000},
+			input: dto.GerarCronogramaInput{Etapas: []dto.PercentualEtapaInput{
+				{EtapaID: "estrutura", Percentual: 50},
+			}},
+			erro: ErrParametrosCronograma,
+		},
+		{
+			nome: "etapa de outra obra",
+			obra: obras.Obra{TipoCobranca: obras.TipoCobrancaEtapas, ValorContratoTotal: 2000},
+			input: dto.GerarCronogramaInput{Etapas: []dto.PercentualEtapaInput{
+				{EtapaID: "outra", Percentual: 100},
+			}},
+			erro: ErrEtapaForaDaObra,
+		},
+		{
+			nome:  "parcelado sem número de parcelas",
+			obra:  obras.Obra{TipoCobranca: obras.TipoCobrancaParcelado, ValorContratoTotal: 1000},
+			input: dto.GerarCronogramaInput{},
+			erro:  ErrParametrosCronograma,
+		},
+		{
+			nome:  "entrada de 100%",
+			obra:  obras.Obra{TipoCobranca: obras.TipoCobrancaParcelado, ValorContratoTotal: 1000},
+			input: dto.GerarCronogramaInput{PercentualEntrada: 100, NumeroParcelas: 1},
+			erro:  ErrParametrosCronograma,
+		},
+		{
+			nome:  "parcelas que ficariam sem valor",
+			obra:  obras.Obra{TipoCobranca: obras.TipoCobrancaParcelado, ValorContratoTotal: 0.02},
+			input: dto.GerarCronogramaInput{NumeroParcelas: 3},
+			erro:  ErrParametrosCronograma,
+		},
+		{
+			nome: "contrato sem valor",
+			obra: obras.Obra{TipoCobranca: obras.TipoCobrancaVista},
+			erro: ErrObraSemValorContrato,
+		},
+		{
+			nome: "tipo de cobrança não definido",
+			obra: obras.Obra{ValorContratoTotal: 1000},
+			erro: ErrTipoCobrancaNaoDefinido,
+		},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			parcelas, err := montarParcelasContrato(&tc.obra, etapas, tc.input, hoje)
+			if tc.erro != nil {
+				if !errors.Is(err, tc.erro) {
+					t.Fatalf("erro = %v, esperado %v", err, tc.erro)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if len(parcelas) != len(tc.esperado) {
+				t.Fatalf("parcelas = %d, esperado %d", len(parcelas), len(tc.esperado))
+			}
+			var soma float64
+			for i, p := range parcelas {
+				esp := tc.esperado[i]
+				if p.NumeroEtapa != i+1 {
+					t.Errorf("parcela %d: número = %d", i+1, p.NumeroEtapa)
+				}
+				if p.ValorPrevisto != esp.valor {
+					t.Errorf("parcela %d: valor = %.2f, esperado %.2f", i+1, p.ValorPrevisto, esp.valor)
+				}
+				if !p.DataVencimento.Equal(esp.vencimento) {
+					t.Errorf("parcela %d: vencimento = %s, esperado %s", i+1, p.DataVencimento.Format("2006-01-02"), esp.vencimento.Format("2006-01-02"))
+				}
+				soma += p.ValorPrevisto
+			}
+			if arredondar(soma) != tc.obra.ValorContratoTotal {
+				t.Errorf("soma das parcelas = %.2f, esperado %.2f", soma, tc.obra.ValorContratoTotal)
+			}
+		})
+	}
+}
+
+func TestVencimentoMensal(t *testing.T) {
+	data := func(a int, m time.Month, d int) time.Time { return time.Date(a, m, d, 0, 0, 0, 0, time.UTC) }
+
+	casos := []struct {
+		nome     string
+		base     time.Time
+		meses    int
+		dia      int
+		esperado time.Time
+	}{
+		{"dia da data base", data(2025, 1, 15), 1, 0, data(2025, 2, 15)},
+		{"dia 31 em fevereiro", data(2025, 1, 31), 1, 0, data(2025, 2, 28)},
+		{"dia 31 em fevereiro bissexto", data(2024, 1, 31), 1, 0, data(2024, 2, 29)},
+		{"volta ao dia 31 depois de fevereiro", data(2025, 1, 31), 2, 0, data(2025, 3, 31)},
+		{"dia informado maior que o mês", data(2025, 3, 10), 1, 31, data(2025, 4, 30)},
+		{"dia informado", data(2025, 3, 10), 1, 5, data(2025, 4, 5)},
+		{"virada de ano", data(2025, 11, 10), 2, 5, data(2026, 1, 5)},
+		{"doze meses", data(2025, 1, 15), 12, 0, data(2026, 1, 15)},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			if got := vencimentoMensal(tc.base, tc.meses, tc.dia); !got.Equal(tc.esperado) {
+				t.Errorf("vencimentoMensal() = %s, esperado %s", got.Format("2006-01-02"), tc.esperado.Format("2006-01-02"))
+			}
+		})
+	}
+}
@@ -0,0 +1,444 @@
+package obras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/financeiro"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/events"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+	"github.com/luiszkm/masterCostrutora/pkg/auth"
+)
+
+// prazoVencimentoMedicao é o prazo, em dias após a aprovação, da parcela gerada
+// por uma medição quando o vencimento não é informado.
+const prazoVencimentoMedicao = 30
+
+var (
+	ErrObraNaoCobradaPorEtapas   = errors.New("medições só se aplicam a obras com cobrança por etapas")
+	ErrObraSemValorContrato      = errors.New("obra sem valor de contrato definido")
+	ErrMedicaoObraCancelada      = errors.New("obra cancelada não aceita medições")
+	ErrMedicaoEmAberto           = errors.New("a obra já tem uma medição não aprovada")
+	ErrPeriodoMedicaoSobreposto  = errors.New("período da medição se sobrepõe a uma medição aprovada")
+	ErrPeriodoMedicaoObrigatorio = errors.New("informe o início e o fim do período medido")
+	ErrMedicaoComCronogramaObra  = errors.New("a obra já tem cronograma de recebimento do contrato; medições não podem gerar parcelas")
+)
+
+// MedicaoService registra as medições das obras cobradas por etapa e, na
+// aprovação do cliente, gera a parcela correspondente do cronograma de
+// recebimento. A conta a receber é criada pelo módulo financeiro a partir do
+// evento de cronograma criado.
+type MedicaoService struct {
+	obraRepo       obras.ObrasRepository
+	etapaRepo      obras.EtapaRepository
+	medicaoRepo    obras.MedicaoRepository
+	cronogramaRepo obras.CronogramaRecebimentoRepository
+	eventBus       EventPublisher
+	dbpool         *pgxpool.Pool
+	logger         *slog.Logger
+}
+
+func NovoMedicaoService(
+	obraRepo obras.ObrasRepository,
+	etapaRepo obras.EtapaRepository,
+	medicaoRepo obras.MedicaoRepository,
+	cronogramaRepo obras.CronogramaRecebimentoRepository,
+	eventBus EventPublisher,
+	dbpool *pgxpool.Pool,
+	logger *slog.Logger,
+) *MedicaoService {
+	return &MedicaoService{
+		obraRepo:       obraRepo,
+		etapaRepo:      etapaRepo,
+		medicaoRepo:    medicaoRepo,
+		cronogramaRepo: cronogramaRepo,
+		eventBus:       eventBus,
+		dbpool:         dbpool,
+		logger:         logger.With("service", "Medicao"),
+	}
+}
+
+// CriarMedicao abre a próxima medição da obra. Só pode haver uma medição não
+// aprovada por vez, para que o acumulado de cada etapa siga a ordem das medições.
+func (s *MedicaoService) CriarMedicao(ctx context.Context, obraID string, input dto.MedicaoInput) (*obras.Medicao, error) {
+	const op = "service.obras.medicao.CriarMedicao"
+
+	obra, err := s.buscarObraMedivel(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	medicoes, err := s.medicaoRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	numero := 1
+	for _, m := range medicoes {
+		if !m.Aprovada() {
+			return nil, fmt.Errorf("%s: %w", op, ErrMedicaoEmAberto)
+		}
+		numero = max(numero, m.Numero+1)
+	}
+	cronogramas, err := s.cronogramaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if temParcelaDeContrato(cronogramas, medicoes) {
+		return nil, fmt.Errorf("%s: %w", op, ErrMedicaoComCronogramaObra)
+	}
+
+	inicio, fim, err := periodoMedicao(input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	agora := time.Now()
+	medicao, err := obras.NovaMedicao(uuid.NewString(), obraID, numero, inicio, fim, auth.UsuarioDoContexto(ctx), agora)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.preencher(ctx, obra, medicao, medicoes, input, agora); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.medicaoRepo.Salvar(ctx, medicao); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "medição criada", "obra_id", obraID, "medicao_id", medicao.ID, "numero", medicao.Numero, "valor", medicao.ValorTotal)
+	return medicao, nil
+}
+
+// AtualizarMedicao substitui período e itens de uma medição ainda não aprovada.
+// Medições enviadas ou rejeitadas voltam para rascunho.
+func (s *MedicaoService) AtualizarMedicao(ctx context.Context, obraID, medicaoID string, input dto.MedicaoInput) (*obras.Medicao, error) {
+	const op = "service.obras.medicao.AtualizarMedicao"
+
+	medicao, err := s.buscarDaObra(ctx, obraID, medicaoID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if medicao.Aprovada() {
+		return nil, fmt.Errorf("%s: %w", op, obras.ErrMedicaoAprovada)
+	}
+	obra, err := s.buscarObraMedivel(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	medicoes, err := s.medicaoRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.preencher(ctx, obra, medicao, medicoes, input, time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.medicaoRepo.Atualizar(ctx, s.dbpool, medicao); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return medicao, nil
+}
+
+func (s *MedicaoService) BuscarMedicao(ctx context.Context, obraID, medicaoID string) (*obras.Medicao, error) {
+	const op = "service.obras.medicao.BuscarMedicao"
+
+	medicao, err := s.buscarDaObra(ctx, obraID, medicaoID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return medicao, nil
+}
+
+// ListarMedicoes lista as medições da obra em ordem de número.
+func (s *MedicaoService) ListarMedicoes(ctx context.Context, obraID string) ([]*obras.Medicao, error) {
+	const op = "service.obras.medicao.ListarMedicoes"
+
+	if _, err := s.obraRepo.BuscarPorID(ctx, obraID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	medicoes, err := s.medicaoRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return medicoes, nil
+}
+
+// ExcluirMedicao descarta uma medição que ainda não foi aprovada.
+func (s *MedicaoService) ExcluirMedicao(ctx context.Context, obraID, medicaoID string) error {
+	const op = "service.obras.medicao.ExcluirMedicao"
+
+	medicao, err := s.buscarDaObra(ctx, obraID, medicaoID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if medicao.Aprovada() {
+		return fmt.Errorf("%s: %w", op, obras.ErrMedicaoAprovada)
+	}
+	if err := s.medicaoRepo.Deletar(ctx, medicaoID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// EnviarMedicao submete a medição à aprovação do cliente.
+func (s *MedicaoService) EnviarMedicao(ctx context.Context, obraID, medicaoID string) (*obras.Medicao, error) {
+	const op = "service.obras.medicao.EnviarMedicao"
+
+	medicao, err := s.buscarDaObra(ctx, obraID, medicaoID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := medicao.Enviar(time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.medicaoRepo.Atualizar(ctx, s.dbpool, medicao); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "medição enviada ao cliente", "obra_id", obraID, "medicao_id", medicaoID, "valor", medicao.ValorTotal)
+	return medicao, nil
+}
+
+// AprovarMedicao registra o aceite do cliente e gera, na mesma transação, a
+// parcela do cronograma de recebimento com o valor medido. Obras com cronograma
+// gerado a partir do contrato não são faturadas por medição, para que a mesma
+// etapa não seja cobrada duas vezes.
+func (s *MedicaoService) AprovarMedicao(ctx context.Context, obraID, medicaoID string, input dto.AprovarMedicaoInput) (*obras.Medicao, error) {
+	const op = "service.obras.medicao.AprovarMedicao"
+
+	medicao, err := s.buscarDaObra(ctx, obraID, medicaoID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	obra, err := s.buscarObraMedivel(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	agora := time.Now()
+	vencimento, err := parseDataOpcional(&input.DataVencimento)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if vencimento == nil {
+		padrao := dataSemHora(agora).AddDate(0, 0, prazoVencimentoMedicao)
+		vencimento = &padrao
+	}
+	cronogramas, err := s.cronogramaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	medicoes, err := s.medicaoRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if temParcelaDeContrato(cronogramas, medicoes) {
+		return nil, fmt.Errorf("%s: %w", op, ErrMedicaoComCronogramaObra)
+	}
+	numeroEtapa := 1
+	for _, c := range cronogramas {
+		numeroEtapa = max(numeroEtapa, c.NumeroEtapa+1)
+	}
+
+	cronograma := &obras.CronogramaRecebimento{
+		ID:          uuid.NewString(),
+		ObraID:      obraID,
+		NumeroEtapa: numeroEtapa,
+		DescricaoEtapa: fmt.Sprintf("Medição nº %d (%s a %s)", medicao.Numero,
+			medicao.PeriodoInicio.Format(formatoDataBR), medicao.PeriodoFim.Format(formatoDataBR)),
+		ValorPrevisto:  medicao.ValorTotal,
+		ValorRetido:    financeiro.CalcularRetencao(medicao.ValorTotal, obra.PercentualRetencao),
+		DataVencimento: *vencimento,
+		Status:         obras.StatusRecebimentoPendente,
+		CreatedAt:      agora,
+		UpdatedAt:      agora,
+	}
+	if err := medicao.Aprovar(input.ResponsavelCliente, cronograma.ID, agora); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := cronograma.Validar(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %v", op, obras.ErrMedicaoInvalida, err)
+	}
+
+	tx, err := s.dbpool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao iniciar transação: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.cronogramaRepo.Salvar(ctx, tx, cronograma); err != nil {
+		return nil, fmt.Errorf("%s: falha ao salvar cronograma: %w", op, err)
+	}
+	if err := s.medicaoRepo.Atualizar(ctx, tx, medicao); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: falha ao fazer commit: %w", op, err)
+	}
+
+	s.eventBus.Publicar(ctx, bus.Evento{
+		Nome: events.CronogramaRecebimentoCriado,
+		Payload: events.CronogramaRecebimentoCriadoPayload{
+			ObraID:             obraID,
+			ObraNome:           obra.Nome,
+			Cliente:            obra.Cliente,
+			ClienteID:          obra.ClienteID,
+			CronogramasIds:     []string{cronograma.ID},
+			ValorTotalPrevisto: cronograma.ValorPrevisto,
+			QuantidadeEtapas:   1,
+			PrimeiroVencimento: cronograma.DataVencimento,
+			PercentualRetencao: obra.PercentualRetencao,
+			UsuarioID:          auth.UsuarioDoContexto(ctx),
+			Parcelas:           []events.ParcelaCronogramaPayload{parcelaDoCronograma(cronograma)},
+		},
+	})
+
+	s.logger.InfoContext(ctx, "medição aprovada pelo cliente", "obra_id", obraID, "medicao_id", medicaoID,
+		"cronograma_id", cronograma.ID, "valor", medicao.ValorTotal)
+	return medicao, nil
+}
+
+// RejeitarMedicao registra a recusa do cliente; a medição pode ser corrigida e reenviada.
+func (s *MedicaoService) RejeitarMedicao(ctx context.Context, obraID, medicaoID string, input dto.RejeitarMedicaoInput) (*obras.Medicao, error) {
+	const op = "service.obras.medicao.RejeitarMedicao"
+
+	medicao, err := s.buscarDaObra(ctx, obraID, medicaoID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := medicao.Rejeitar(input.ResponsavelCliente, input.Motivo, time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.medicaoRepo.Atualizar(ctx, s.dbpool, medicao); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "medição rejeitada pelo cliente", "obra_id", obraID, "medicao_id", medicaoID)
+	return medicao, nil
+}
+
+// buscarObraMedivel confere que a obra é cobrada por etapas, tem valor de contrato e não foi cancelada.
+func (s *MedicaoService) buscarObraMedivel(ctx context.Context, obraID string) (*obras.Obra, error) {
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case obra.Status == obras.StatusCancelada:
+		return nil, ErrMedicaoObraCancelada
+	case obra.TipoCobranca != obras.TipoCobrancaEtapas:
+		return nil, ErrObraNaoCobradaPorEtapas
+	case obra.ValorContratoTotal <= 0:
+		return nil, ErrObraSemValorContrato
+	}
+	return obra, nil
+}
+
+// preencher valida o período contra as medições aprovadas e valoriza os itens
+// sobre a parte do contrato de cada etapa, descontando o já medido.
+func (s *MedicaoService) preencher(ctx context.Context, obra *obras.Obra, medicao *obras.Medicao, medicoes []*obras.Medicao, input dto.MedicaoInput, agora time.Time) error {
+	inicio, fim, err := periodoMedicao(input)
+	if err != nil {
+		return err
+	}
+	anteriores := make(map[string]float64)
+	for _, m := range medicoes {
+		if m.ID == medicao.ID || !m.Aprovada() {
+			continue
+		}
+		if !inicio.After(m.PeriodoFim) {
+			return ErrPeriodoMedicaoSobreposto
+		}
+		for _, item := range m.Itens {
+			anteriores[item.EtapaID] += item.PercentualPeriodo
+		}
+	}
+
+	etapas, err := s.etapaRepo.ListarPorObraID(ctx, obra.ID)
+	if err != nil {
+		return err
+	}
+	nomes := make(map[string]string, len(etapas))
+	for _, e := range etapas {
+		nomes[e.ID] = e.Nome
+	}
+	valores := obras.ValorContratoPorEtapa(obra.ValorContratoTotal, etapas)
+
+	itens := make([]obras.ItemMedicao, 0, len(input.Itens))
+	for _, in := range input.Itens {
+		nome, ok := nomes[in.EtapaID]
+		if !ok {
+			return ErrEtapaForaDaObra
+		}
+		itens = append(itens, obras.ItemMedicao{
+			EtapaID:            in.EtapaID,
+			EtapaNome:          nome,
+			Unidade:            in.Unidade,
+			QuantidadePrevista: in.QuantidadePrevista,
+			QuantidadeMedida:   in.QuantidadeMedida,
+			PercentualPeriodo:  in.Percentual,
+			PercentualAnterior: arredondar(anteriores[in.EtapaID]),
+			ValorEtapa:         arredondar(valores[in.EtapaID]),
+		})
+	}
+	return medicao.Atualizar(inicio, fim, itens, input.Observacoes, agora)
+}
+
+// temParcelaDeContrato informa se o cronograma da obra tem parcelas que não
+// foram geradas por medições aprovadas, ou seja, parcelas do contrato. Parcelas
+// canceladas não contam.
+func temParcelaDeContrato(cronogramas []*obras.CronogramaRecebimento, medicoes []*obras.Medicao) bool {
+	deMedicao := make(map[string]bool, len(medicoes))
+	for _, m := range medicoes {
+		if m.CronogramaRecebimentoID != nil {
+			deMedicao[*m.CronogramaRecebimentoID] = true
+		}
+	}
+	for _, c := range cronogramas {
+		if c.Status != obras.StatusRecebimentoCancelado && !deMedicao[c.ID] {
+			return true
+		}
+	}
+	return false
+}
+
+// temMedicaoFaturada informa se alguma medição da obra já foi aprovada e virou parcela.
+func temMedicaoFaturada(medicoes []*obras.Medicao) bool {
+	for _, m := range medicoes {
+		if m.Aprovada() {
+			return true
+		}
+	}
+	return false
+}
+
+// buscarDaObra busca a medição e confere que ela pertence à obra da rota.
+func (s *MedicaoService) buscarDaObra(ctx context.Context, obraID, medicaoID string) (*obras.Medicao, error) {
+	medicao, err := s.medicaoRepo.BuscarPorID(ctx, medicaoID)
+	if err != nil {
+		return nil, err
+	}
+	if medicao.ObraID != obraID {
+		return nil, postgres.ErrNaoEncontrado
+	}
+	return medicao, nil
+}
+
+func periodoMedicao(input dto.MedicaoInput) (time.Time, time.Time, error) {
+	inicio, err := parseDataOpcional(&input.PeriodoInicio)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	fim, err := parseDataOpcional(&input.PeriodoFim)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if inicio == nil || fim == nil {
+		return time.Time{}, time.Time{}, ErrPeriodoMedicaoObrigatorio
+	}
+	return *inicio, *fim, nil
+}
@@ -0,0 +1,82 @@
+package obras
+
+import (
+	"testing"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+)
+
+func TestTemParcelaDeContrato(t *testing.T) {
+	cronogramaMedicao := "cr-medicao"
+	aprovada := &obras.Medicao{ID: "m1", Status: obras.StatusMedicaoAprovada, CronogramaRecebimentoID: &cronogramaMedicao}
+
+	casos := []struct {
+		nome        string
+		cronogramas []*obras.CronogramaRecebimento
+		medicoes    []*obras.Medicao
+		esperado    bool
+	}{
+		{
+			nome: "obra sem cronograma",
+		},
+		{
+			nome:        "só parcelas de medição",
+			cronogramas: []*obras.CronogramaRecebimento{{ID: cronogramaMedicao}},
+			medicoes:    []*obras.Medicao{aprovada},
+		},
+		{
+			nome:        "parcela do contrato sem medições",
+			cronogramas: []*obras.CronogramaRecebimento{{ID: "cr-contrato"}},
+			esperado:    true,
+		},
+		{
+			nome:        "parcela do contrato junto de parcela de medição",
+			cronogramas: []*obras.CronogramaRecebimento{{ID: cronogramaMedicao}, {ID: "cr-contrato"}},
+			medicoes:    []*obras.Medicao{aprovada},
+			esperado:    true,
+		},
+		{
+			nome:        "parcela do contrato cancelada",
+			cronogramas: []*obras.CronogramaRecebimento{{ID: "cr-contrato", Status: obras.StatusRecebimentoCancelado}},
+		},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			if got := temParcelaDeContrato(tc.cronogramas, tc.medicoes); got != tc.esperado {
+				t.Errorf("temParcelaDeContrato() = %v, esperado %v", got, tc.esperado)
+			}
+		})
+	}
+}
+
+func TestTemMedicaoFaturada(t *testing.T) {
+	casos := []struct {
+		nome     string
+		medicoes []*obras.Medicao
+		esperado bool
+	}{
+		{nome: "sem medições"},
+		{
+			nome: "medições em aberto",
+			medicoes: []*obras.Medicao{
+				{Status: obras.StatusMedicaoRascunho},
+				{Status: obras.StatusMedicaoAguardandoAprovacao},
+				{Status: obras.StatusMedicaoRejeitada},
+			},
+		},
+		{
+			nome:     "medição aprovada",
+			medicoes: []*obras.Medicao{{Status: obras.StatusMedicaoRascunho}, {Status: obras.StatusMedicaoAprovada}},
+			esperado: true,
+		},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			if got := temMedicaoFaturada(tc.medicoes); got != tc.esperado {
+				t.Errorf("temMedicaoFaturada() = %v, esperado %v", got, tc.esperado)
+			}
+		})
+	}
+}
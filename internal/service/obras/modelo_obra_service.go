@@ -0,0 +1,272 @@
+package obras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+var (
+	ErrModeloObraNaoEncontrado = errors.New("modelo de obra não encontrado")
+	ErrModeloObraNomeDuplicado = errors.New("já existe um modelo de obra com este nome")
+	ErrObraOrigemNaoEncontrada = errors.New("obra de origem não encontrada")
+	ErrOrigemEstruturaAmbigua  = errors.New("informe o modelo ou a obra de origem, não os dois")
+)
+
+// ModeloObraService mantém os modelos de obra e monta a estrutura inicial de uma
+// obra nova a partir de um modelo ou de uma obra existente.
+type ModeloObraService struct {
+	modeloRepo      obras.ModeloObraRepository
+	obraRepo        obras.ObrasRepository
+	etapaRepo       obras.EtapaRepository
+	dependenciaRepo obras.DependenciaEtapaRepository
+	orcamentoRepo   obras.OrcamentoAnaliticoRepository
+	cronogramaRepo  obras.CronogramaRecebimentoRepository
+	etapaPadraoRepo obras.EtapaPadraoRepository
+	logger          *slog.Logger
+}
+
+func NovoModeloObraService(
+	modeloRepo obras.ModeloObraRepository,
+	obraRepo obras.ObrasRepository,
+	etapaRepo obras.EtapaRepository,
+	dependenciaRepo obras.DependenciaEtapaRepository,
+	orcamentoRepo obras.OrcamentoAnaliticoRepository,
+	cronogramaRepo obras.CronogramaRecebimentoRepository,
+	etapaPadraoRepo obras.EtapaPadraoRepository,
+	logger *slog.Logger,
+) *ModeloObraService {
+	return &ModeloObraService{
+		modeloRepo:      modeloRepo,
+		obraRepo:        obraRepo,
+		etapaRepo:       etapaRepo,
+		dependenciaRepo: dependenciaRepo,
+		orcamentoRepo:   orcamentoRepo,
+		cronogramaRepo:  cronogramaRepo,
+		etapaPadraoRepo: etapaPadraoRepo,
+		logger:          logger.With("service", "ModeloObra"),
+	}
+}
+
+// CriarModelo cadastra um modelo informado por completo ou copiado de uma obra existente.
+func (s *ModeloObraService) CriarModelo(ctx context.Context, input dto.SalvarModeloObraInput) (*obras.ModeloObra, error) {
+	const op = "service.obras.modelo.CriarModelo"
+
+	modelo, err := s.montarModelo(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.verificarNome(ctx, modelo); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	agora := time.Now()
+	modelo.ID = uuid.NewString()
+	modelo.CreatedAt = agora
+	modelo.UpdatedAt = agora
+	if err := s.modeloRepo.Salvar(ctx, modelo); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "modelo de obra criado", "modelo_id", modelo.ID, "obra_origem_id", input.ObraOrigemID, "etapas", len(modelo.Etapas))
+	return modelo, nil
+}
+
+// AtualizarModelo substitui o conteúdo do modelo. Obras já criadas não mudam.
+func (s *ModeloObraService) AtualizarModelo(ctx context.Context, modeloID string, input dto.SalvarModeloObraInput) (*obras.ModeloObra, error) {
+	const op = "service.obras.modelo.AtualizarModelo"
+
+	atual, err := s.BuscarModelo(ctx, modeloID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	modelo, err := s.montarModelo(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	modelo.ID = atual.ID
+	if err := s.verificarNome(ctx, modelo); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	modelo.CreatedAt = atual.CreatedAt
+	modelo.UpdatedAt = time.Now()
+	if err := s.modeloRepo.Atualizar(ctx, modelo); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return modelo, nil
+}
+
+func (s *ModeloObraService) BuscarModelo(ctx context.Context, modeloID string) (*obras.ModeloObra, error) {
+	const op = "service.obras.modelo.BuscarModelo"
+
+	modelo, err := s.modeloRepo.BuscarPorID(ctx, modeloID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNaoEncontrado) {
+			return nil, ErrModeloObraNaoEncontrado
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return modelo, nil
+}
+
+func (s *ModeloObraService) ListarModelos(ctx context.Context) ([]*obras.ModeloObra, error) {
+	const op = "service.obras.modelo.ListarModelos"
+
+	modelos, err := s.modeloRepo.ListarTodos(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return modelos, nil
+}
+
+func (s *ModeloObraService) DeletarModelo(ctx context.Context, modeloID string) error {
+	const op = "service.obras.modelo.DeletarModelo"
+
+	if err := s.modeloRepo.Deletar(ctx, modeloID); err != nil {
+		if errors.Is(err, postgres.ErrNaoEncontrado) {
+			return ErrModeloObraNaoEncontrado
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// PlanejarObra aplica à obra nova o modelo ou a estrutura da obra de origem.
+// Retorna nil quando nenhum dos dois é informado.
+func (s *ModeloObraService) PlanejarObra(ctx context.Context, obra *obras.Obra, modeloID, obraOrigemID string) (*obras.PlanoObra, error) {
+	const op = "service.obras.modelo.PlanejarObra"
+
+	var modelo *obras.ModeloObra
+	var err error
+	switch {
+	case modeloID != "" && obraOrigemID != "":
+		return nil, ErrOrigemEstruturaAmbigua
+	case modeloID != "":
+		modelo, err = s.BuscarModelo(ctx, modeloID)
+	case obraOrigemID != "":
+		modelo, err = s.modeloDaObra(ctx, obraOrigemID)
+		if err == nil {
+			modelo.Nome = "obra " + obraOrigemID
+			err = modelo.Validar()
+		}
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Etapas padrão removidas depois de salvo o modelo deixam de ser referenciadas
+	catalogo, err := s.idsDoCatalogo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, e := range modelo.Etapas {
+		if e.EtapaPadraoID != "" && !catalogo[e.EtapaPadraoID] {
+			e.EtapaPadraoID = ""
+		}
+	}
+
+	plano, err := modelo.Instanciar(obra, time.Now(), uuid.NewString)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return plano, nil
+}
+
+// montarModelo monta e valida o modelo a partir do payload ou da obra de origem.
+func (s *ModeloObraService) montarModelo(ctx context.Context, input dto.SalvarModeloObraInput) (*obras.ModeloObra, error) {
+	modelo := &obras.ModeloObra{
+		PercentualEntrada: input.PercentualEntrada,
+		PrazoDiasEtapa:    input.PrazoDiasEtapa,
+		Etapas:            input.Etapas,
+		Orcamento:         input.Orcamento,
+	}
+	if input.ObraOrigemID != "" {
+		var err error
+		if modelo, err = s.modeloDaObra(ctx, input.ObraOrigemID); err != nil {
+			return nil, err
+		}
+	}
+	modelo.Nome = input.Nome
+	modelo.Descricao = input.Descricao
+	if modelo.Orcamento == nil {
+		modelo.Orcamento = []*obras.ItemOrcamentoModelo{}
+	}
+	if err := modelo.Validar(); err != nil {
+		return nil, err
+	}
+	catalogo, err := s.idsDoCatalogo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range modelo.Etapas {
+		if e.EtapaPadraoID != "" && !catalogo[e.EtapaPadraoID] {
+			return nil, fmt.Errorf("%w: etapa padrão da etapa '%s' não está no catálogo", obras.ErrModeloObraInvalido, e.Nome)
+		}
+	}
+	return modelo, nil
+}
+
+// idsDoCatalogo lista os IDs das etapas padrão cadastradas.
+func (s *ModeloObraService) idsDoCatalogo(ctx context.Context) (map[string]bool, error) {
+	catalogo, err := s.etapaPadraoRepo.ListarTodas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool, len(catalogo))
+	for _, e := range catalogo {
+		ids[e.ID] = true
+	}
+	return ids, nil
+}
+
+// modeloDaObra lê etapas, dependências, orçamento e cronograma da obra e monta o modelo.
+func (s *ModeloObraService) modeloDaObra(ctx context.Context, obraID string) (*obras.ModeloObra, error) {
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNaoEncontrado) {
+			return nil, ErrObraOrigemNaoEncontrada
+		}
+		return nil, err
+	}
+	etapas, err := s.etapaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, err
+	}
+	dependencias, err := s.dependenciaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, err
+	}
+	orcamento, err := s.orcamentoRepo.BuscarPorObraID(ctx, obraID)
+	if err != nil && !errors.Is(err, postgres.ErrNaoEncontrado) {
+		return nil, err
+	}
+	cronograma, err := s.cronogramaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, err
+	}
+	return obras.ModeloAPartirDaObra(obra, etapas, dependencias, orcamento, cronograma), nil
+}
+
+func (s *ModeloObraService) verificarNome(ctx context.Context, modelo *obras.ModeloObra) error {
+	existente, err := s.modeloRepo.BuscarPorNome(ctx, modelo.Nome)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNaoEncontrado) {
+			return nil
+		}
+		return err
+	}
+	if existente.ID != modelo.ID {
+		return ErrModeloObraNomeDuplicado
+	}
+	return nil
+}
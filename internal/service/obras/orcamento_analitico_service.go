@@ -0,0 +1,245 @@
+package obras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+// CustosObraQuerier apura o custo comprometido e realizado da obra por etapa e categoria.
+type CustosObraQuerier interface {
+	ListarCustosApurados(ctx context.Context, obraID string) ([]dto.CustoApurado, error)
+}
+
+// OrcamentoAnaliticoService mantém a linha de base de custo e o controle previsto x realizado.
+type OrcamentoAnaliticoService struct {
+	obraRepo      obras.ObrasRepository
+	etapaRepo     obras.EtapaRepository
+	orcamentoRepo obras.OrcamentoAnaliticoRepository
+	querier       CustosObraQuerier
+	logger        *slog.Logger
+}
+
+func NovoOrcamentoAnaliticoService(
+	obraRepo obras.ObrasRepository,
+	etapaRepo obras.EtapaRepository,
+	orcamentoRepo obras.OrcamentoAnaliticoRepository,
+	querier CustosObraQuerier,
+	logger *slog.Logger,
+) *OrcamentoAnaliticoService {
+	return &OrcamentoAnaliticoService{
+		obraRepo:      obraRepo,
+		etapaRepo:     etapaRepo,
+		orcamentoRepo: orcamentoRepo,
+		querier:       querier,
+		logger:        logger.With("service", "OrcamentoAnalitico"),
+	}
+}
+
+// DefinirOrcamentoAnalitico substitui a linha de base de custo da obra.
+func (s *OrcamentoAnaliticoService) DefinirOrcamentoAnalitico(ctx context.Context, obraID string, input dto.DefinirOrcamentoAnaliticoInput) (*obras.OrcamentoAnalitico, error) {
+	const op = "service.obras.orcamento_analitico.DefinirOrcamentoAnalitico"
+
+	if _, err := s.obraRepo.BuscarPorID(ctx, obraID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	etapas, err := s.etapaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	orcamento := &obras.OrcamentoAnalitico{
+		ObraID:            obraID,
+		PercentualAlerta:  obras.PercentualAlertaPadrao,
+		PercentualCritico: obras.PercentualCriticoPadrao,
+		Itens:             make([]*obras.ItemOrcamentoAnalitico, 0, len(input.Itens)),
+		UpdatedAt:         time.Now(),
+	}
+	if input.PercentualAlerta != nil {
+		orcamento.PercentualAlerta = *input.PercentualAlerta
+	}
+	if input.PercentualCritico != nil {
+		orcamento.PercentualCritico = *input.PercentualCritico
+	}
+	for _, item := range input.Itens {
+		if item.EtapaID != nil && !contemEtapa(etapas, *item.EtapaID) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEtapaForaDaObra)
+		}
+		orcamento.Itens = append(orcamento.Itens, &obras.ItemOrcamentoAnalitico{
+			ID:            uuid.NewString(),
+			EtapaID:       item.EtapaID,
+			Categoria:     obras.CategoriaCusto(item.Categoria),
+			ValorPrevisto: item.ValorPrevisto,
+		})
+	}
+	if err := orcamento.Validar(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.orcamentoRepo.Salvar(ctx, orcamento); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "orçamento analítico definido", "obra_id", obraID, "linhas", len(orcamento.Itens), "valor_previsto", orcamento.ValorPrevistoTotal())
+	return orcamento, nil
+}
+
+// ObterControleCustos compara o orçamento analítico com o custo apurado e aponta as
+// linhas que passaram dos limites de alerta. Sem orçamento definido, todo custo
+// apurado aparece como não previsto.
+func (s *OrcamentoAnaliticoService) ObterControleCustos(ctx context.Context, obraID string) (*dto.ControleCustosOutput, error) {
+	const op = "service.obras.orcamento_analitico.ObterControleCustos"
+
+	if _, err := s.obraRepo.BuscarPorID(ctx, obraID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	possuiOrcamento := true
+	orcamento, err := s.orcamentoRepo.BuscarPorObraID(ctx, obraID)
+	if errors.Is(err, postgres.ErrNaoEncontrado) {
+		possuiOrcamento = false
+		orcamento = &obras.OrcamentoAnalitico{
+			ObraID:            obraID,
+			PercentualAlerta:  obras.PercentualAlertaPadrao,
+			PercentualCritico: obras.PercentualCriticoPadrao,
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	etapas, err := s.etapaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	custos, err := s.querier.ListarCustosApurados(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	output := montarControleCustos(orcamento, etapas, custos)
+	output.PossuiOrcamento = possuiOrcamento
+	return output, nil
+}
+
+func montarControleCustos(orcamento *obras.OrcamentoAnalitico, etapas []*obras.Etapa, custos []dto.CustoApurado) *dto.ControleCustosOutput {
+	nomes := make(map[string]string, len(etapas))
+	ordemEtapa := make(map[string]int, len(etapas))
+	for i, e := range etapas {
+		nomes[e.ID] = e.Nome
+		ordemEtapa[e.ID] = i
+	}
+	nomeEtapa := func(etapaID *string) string {
+		if etapaID == nil {
+			return "Obra (geral)"
+		}
+		return nomes[*etapaID]
+	}
+
+	linhas := make(map[string]*dto.LinhaControleCusto)
+	linha := func(etapaID *string, categoria obras.CategoriaCusto) *dto.LinhaControleCusto {
+		chave := obras.ChaveLinhaCusto(etapaID, categoria)
+		if linhas[chave] == nil {
+			linhas[chave] = &dto.LinhaControleCusto{EtapaID: etapaID, EtapaNome: nomeEtapa(etapaID), Categoria: categoria}
+		}
+		return linhas[chave]
+	}
+	for _, item := range orcamento.Itens {
+		linha(item.EtapaID, item.Categoria).Previsto += item.ValorPrevisto
+	}
+	for _, c := range custos {
+		l := linha(c.EtapaID, obras.CategoriaCusto(c.Categoria))
+		l.Comprometido += c.Comprometido
+		l.Realizado += c.Realizado
+	}
+
+	ordenadas := make([]*dto.LinhaControleCusto, 0, len(linhas))
+	for _, l := range linhas {
+		ordenadas = append(ordenadas, l)
+	}
+	sort.Slice(ordenadas, func(i, j int) bool {
+		a, b := ordenadas[i], ordenadas[j]
+		if (a.EtapaID == nil) != (b.EtapaID == nil) {
+			return a.EtapaID == nil
+		}
+		if a.EtapaID != nil && *a.EtapaID != *b.EtapaID {
+			return ordemEtapa[*a.EtapaID] < ordemEtapa[*b.EtapaID]
+		}
+		return a.Categoria < b.Categoria
+	})
+
+	output := &dto.ControleCustosOutput{
+		ObraID:            orcamento.ObraID,
+		PercentualAlerta:  orcamento.PercentualAlerta,
+		PercentualCritico: orcamento.PercentualCritico,
+		PorCategoria:      []dto.ResumoCustoCategoria{},
+		PorEtapa:          []dto.ResumoCustoEtapa{},
+		Linhas:            make([]dto.LinhaControleCusto, 0, len(ordenadas)),
+		Alertas:           []dto.LinhaControleCusto{},
+	}
+	porCategoria := make(map[obras.CategoriaCusto]*dto.ResumoCustoCategoria)
+	porEtapa := make(map[string]*dto.ResumoCustoEtapa)
+	var ordemCategorias []obras.CategoriaCusto
+	var ordemEtapas []string
+
+	for _, l := range ordenadas {
+		completarResumo(&l.ResumoCusto, orcamento)
+		output.Linhas = append(output.Linhas, *l)
+		if l.Nivel != obras.NivelAlertaOK {
+			output.Alertas = append(output.Alertas, *l)
+		}
+		somarResumo(&output.Total, l.ResumoCusto)
+
+		if porCategoria[l.Categoria] == nil {
+			porCategoria[l.Categoria] = &dto.ResumoCustoCategoria{Categoria: l.Categoria}
+			ordemCategorias = append(ordemCategorias, l.Categoria)
+		}
+		somarResumo(&porCategoria[l.Categoria].ResumoCusto, l.ResumoCusto)
+
+		chaveEtapa := obras.ChaveLinhaCusto(l.EtapaID, "")
+		if porEtapa[chaveEtapa] == nil {
+			porEtapa[chaveEtapa] = &dto.ResumoCustoEtapa{EtapaID: l.EtapaID, EtapaNome: l.EtapaNome}
+			ordemEtapas = append(ordemEtapas, chaveEtapa)
+		}
+		somarResumo(&porEtapa[chaveEtapa].ResumoCusto, l.ResumoCusto)
+	}
+
+	completarResumo(&output.Total, orcamento)
+	sort.Slice(ordemCategorias, func(i, j int) bool { return ordemCategorias[i] < ordemCategorias[j] })
+	for _, c := range ordemCategorias {
+		completarResumo(&porCategoria[c].ResumoCusto, orcamento)
+		output.PorCategoria = append(output.PorCategoria, *porCategoria[c])
+	}
+	for _, chave := range ordemEtapas {
+		completarResumo(&porEtapa[chave].ResumoCusto, orcamento)
+		output.PorEtapa = append(output.PorEtapa, *porEtapa[chave])
+	}
+	return output
+}
+
+func somarResumo(destino *dto.ResumoCusto, r dto.ResumoCusto) {
+	destino.Previsto += r.Previsto
+	destino.Comprometido += r.Comprometido
+	destino.Realizado += r.Realizado
+}
+
+// completarResumo calcula projetado, variação, percentual consumido e nível de alerta.
+func completarResumo(r *dto.ResumoCusto, orcamento *obras.OrcamentoAnalitico) {
+	r.Previsto = arredondar(r.Previsto)
+	r.Comprometido = arredondar(r.Comprometido)
+	r.Realizado = arredondar(r.Realizado)
+	r.Projetado = max(r.Comprometido, r.Realizado)
+	r.Variacao = arredondar(r.Previsto - r.Projetado)
+	r.PercentualConsumido = 0
+	if r.Previsto > 0 {
+		r.PercentualConsumido = arredondar(r.Projetado / r.Previsto * 100)
+	}
+	r.Nivel = orcamento.NivelAlerta(r.Previsto, r.Projetado)
+}
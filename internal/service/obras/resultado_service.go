@@ -0,0 +1,320 @@
+package obras
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+	"github.com/luiszkm/masterCostrutora/pkg/tracing"
+)
+
+// ResultadoQuerier apura receitas, custos e fluxo de caixa de uma ou mais obras.
+type ResultadoQuerier interface {
+	ListarObrasResultado(ctx context.Context, status string) ([]dto.ObraResultadoBase, error)
+	ListarCustosResultado(ctx context.Context, obraIDs []string) ([]dto.CustoResultado, error)
+	ListarFaturamentoResultado(ctx context.Context, obraIDs []string) ([]dto.FaturamentoResultado, error)
+	ListarFluxoCaixaMensal(ctx context.Context, obraIDs []string) ([]dto.FluxoCaixaMensal, error)
+}
+
+// categoriasResultado fixa a ordem das categorias de custo no relatório.
+var categoriasResultado = []obras.CategoriaCusto{
+	obras.CategoriaCustoMaterial,
+	obras.CategoriaCustoMaoDeObra,
+	obras.CategoriaCustoServicos,
+	obras.CategoriaCustoEquipamentos,
+	obras.CategoriaCustoOutros,
+}
+
+// ResultadoService calcula a rentabilidade das obras e da carteira.
+type ResultadoService struct {
+	obraRepo  obras.ObrasRepository
+	etapaRepo obras.EtapaRepository
+	querier   ResultadoQuerier
+	logger    *slog.Logger
+}
+
+func NovoResultadoService(
+	obraRepo obras.ObrasRepository,
+	etapaRepo obras.EtapaRepository,
+	querier ResultadoQuerier,
+	logger *slog.Logger,
+) *ResultadoService {
+	return &ResultadoService{
+		obraRepo:  obraRepo,
+		etapaRepo: etapaRepo,
+		querier:   querier,
+		logger:    logger.With("service", "Resultado"),
+	}
+}
+
+// dadosResultado agrupa o que foi apurado para uma obra.
+type dadosResultado struct {
+	custos      []dto.CustoResultado
+	faturamento dto.FaturamentoResultado
+	fluxo       []dto.FluxoCaixaMensal
+}
+
+// iniciarSpanRelatorio abre o span interno de um relatório pesado, para separar
+// o tempo de cálculo do tempo das queries no trace da requisição.
+func iniciarSpanRelatorio(ctx context.Context, relatorio, obraID string) (context.Context, *tracing.Span) {
+	ctx, span := tracing.Iniciar(ctx, "relatorio "+relatorio, tracing.TipoInterno)
+	if obraID != "" {
+		span.DefinirAtributo("obra.id", obraID)
+	}
+	return ctx, span
+}
+
+// ObterResultadoObra monta o relatório de rentabilidade de uma obra, com custos por
+// categoria, margens atual e projetada e a exposição de caixa mês a mês.
+func (s *ResultadoService) ObterResultadoObra(ctx context.Context, obraID string) (_ *dto.ResultadoObraOutput, err error) {
+	const op = "service.obras.resultado.ObterResultadoObra"
+
+	ctx, span := iniciarSpanRelatorio(ctx, "resultado_obra", obraID)
+	defer func() {
+		span.RegistrarErro(err)
+		span.Finalizar()
+	}()
+
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	etapas, err := s.etapaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	dados, err := s.apurar(ctx, []string{obraID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	percentualFisico := obras.PercentualPonderado(etapas)
+	d := dados[obraID]
+	resumo := montarResumoResultado(obra.ValorContratoTotal, percentualFisico, d)
+	return &dto.ResultadoObraOutput{
+		ObraID:             obra.ID,
+		Nome:               obra.Nome,
+		Status:             string(obra.Status),
+		DataReferencia:     dataSemHora(time.Now()),
+		PercentualFisico:   arredondar(percentualFisico),
+		CustosPorCategoria: custosPorCategoria(d.custos),
+		ExposicaoCaixa:     montarFluxoCaixa(d.fluxo, &resumo),
+		ResumoResultado:    arredondarResumo(resumo),
+	}, nil
+}
+
+// ObterResultadoCarteira consolida o resultado das obras, opcionalmente filtradas por status.
+func (s *ResultadoService) ObterResultadoCarteira(ctx context.Context, status string) (_ *dto.ResultadoCarteiraOutput, err error) {
+	const op = "service.obras.resultado.ObterResultadoCarteira"
+
+	ctx, span := iniciarSpanRelatorio(ctx, "resultado_carteira", "")
+	span.DefinirAtributo("obra.status", status)
+	defer func() {
+		span.RegistrarErro(err)
+		span.Finalizar()
+	}()
+
+	bases, err := s.querier.ListarObrasResultado(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	ids := make([]string, 0, len(bases))
+	for _, b := range bases {
+		ids = append(ids, b.ID)
+	}
+	dados, err := s.apurar(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	output := &dto.ResultadoCarteiraOutput{
+		DataReferencia: dataSemHora(time.Now()),
+		Obras:          make([]dto.ResultadoObraItem, 0, len(bases)),
+	}
+	var todos dadosResultado
+	total := &output.Total
+	for _, b := range bases {
+		d := dados[b.ID]
+		resumo := montarResumoResultado(b.ValorContratoTotal, b.PercentualFisico, d)
+		montarFluxoCaixa(d.fluxo, &resumo)
+
+		total.ValorContratado += resumo.ValorContratado
+		total.ValorFaturado += resumo.ValorFaturado
+		total.ValorRecebido += resumo.ValorRecebido
+		total.SaldoAReceber += resumo.SaldoAReceber
+		total.CustoComprometido += resumo.CustoComprometido
+		total.CustoIncorrido += resumo.CustoIncorrido
+		total.CustoPago += resumo.CustoPago
+		total.ReceitaReconhecida += resumo.ReceitaReconhecida
+		total.CustoProjetado += resumo.CustoProjetado
+		todos.custos = append(todos.custos, d.custos...)
+		todos.fluxo = append(todos.fluxo, d.fluxo...)
+
+		output.Obras = append(output.Obras, dto.ResultadoObraItem{
+			ObraID:           b.ID,
+			Nome:             b.Nome,
+			Status:           b.Status,
+			PercentualFisico: arredondar(b.PercentualFisico),
+			ResumoResultado:  arredondarResumo(resumo),
+		})
+	}
+	calcularMargens(total)
+	output.CustosPorCategoria = custosPorCategoria(todos.custos)
+	output.ExposicaoCaixa = montarFluxoCaixa(todos.fluxo, total)
+	output.Total = arredondarResumo(*total)
+	return output, nil
+}
+
+// apurar busca custos, faturamento e fluxo de caixa das obras e separa por obra.
+func (s *ResultadoService) apurar(ctx context.Context, obraIDs []string) (map[string]*dadosResultado, error) {
+	dados := make(map[string]*dadosResultado, len(obraIDs))
+	for _, id := range obraIDs {
+		dados[id] = &dadosResultado{faturamento: dto.FaturamentoResultado{ObraID: id}}
+	}
+	if len(obraIDs) == 0 {
+		return dados, nil
+	}
+
+	custos, err := s.querier.ListarCustosResultado(ctx, obraIDs)
+	if err != nil {
+		return nil, err
+	}
+	faturamento, err := s.querier.ListarFaturamentoResultado(ctx, obraIDs)
+	if err != nil {
+		return nil, err
+	}
+	fluxo, err := s.querier.ListarFluxoCaixaMensal(ctx, obraIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range custos {
+		if d := dados[c.ObraID]; d != nil {
+			d.custos = append(d.custos, c)
+		}
+	}
+	for _, f := range faturamento {
+		if d := dados[f.ObraID]; d != nil {
+			d.faturamento = f
+		}
+	}
+	for _, f := range fluxo {
+		if d := dados[f.ObraID]; d != nil {
+			d.fluxo = append(d.fluxo, f)
+		}
+	}
+	return dados, nil
+}
+
+// montarResumoResultado calcula receitas, custos e margens de uma obra. A receita é
+// reconhecida pelo avanço físico; o custo projetado extrapola o incorrido pelo mesmo
+// avanço e nunca fica abaixo do que já foi comprometido.
+func montarResumoResultado(valorContratado, percentualFisico float64, d *dadosResultado) dto.ResumoResultado {
+	r := dto.ResumoResultado{
+		ValorContratado:    valorContratado,
+		ValorFaturado:      d.faturamento.Faturado,
+		ValorRecebido:      d.faturamento.Recebido,
+		SaldoAReceber:      max(valorContratado-d.faturamento.Recebido, 0),
+		ReceitaReconhecida: valorContratado * percentualFisico / 100,
+	}
+	for _, c := range d.custos {
+		r.CustoComprometido += c.Comprometido
+		r.CustoIncorrido += c.Incorrido
+		r.CustoPago += c.Pago
+	}
+
+	r.CustoProjetado = max(r.CustoComprometido, r.CustoIncorrido)
+	if percentualFisico > 0 {
+		r.CustoProjetado = max(r.CustoProjetado, r.CustoIncorrido*100/percentualFisico)
+	}
+	calcularMargens(&r)
+	return r
+}
+
+func calcularMargens(r *dto.ResumoResultado) {
+	r.MargemBruta = r.ReceitaReconhecida - r.CustoIncorrido
+	r.MargemBrutaPercentual = 0
+	if r.ReceitaReconhecida > 0 {
+		r.MargemBrutaPercentual = r.MargemBruta / r.ReceitaReconhecida * 100
+	}
+	r.MargemProjetada = r.ValorContratado - r.CustoProjetado
+	r.MargemProjetadaPercentual = 0
+	if r.ValorContratado > 0 {
+		r.MargemProjetadaPercentual = r.MargemProjetada / r.ValorContratado * 100
+	}
+}
+
+// custosPorCategoria soma os custos por categoria, sempre em todas as categorias.
+func custosPorCategoria(custos []dto.CustoResultado) []dto.CustoResultado {
+	porCategoria := make(map[string]*dto.CustoResultado, len(categoriasResultado))
+	resultado := make([]dto.CustoResultado, len(categoriasResultado))
+	for i, categoria := range categoriasResultado {
+		resultado[i].Categoria = string(categoria)
+		porCategoria[string(categoria)] = &resultado[i]
+	}
+	for _, c := range custos {
+		destino := porCategoria[c.Categoria]
+		if destino == nil {
+			continue
+		}
+		destino.Comprometido += c.Comprometido
+		destino.Incorrido += c.Incorrido
+		destino.Pago += c.Pago
+	}
+	for i := range resultado {
+		resultado[i].Comprometido = arredondar(resultado[i].Comprometido)
+		resultado[i].Incorrido = arredondar(resultado[i].Incorrido)
+		resultado[i].Pago = arredondar(resultado[i].Pago)
+	}
+	return resultado
+}
+
+// montarFluxoCaixa acumula o saldo de caixa mês a mês, sem lacunas, e registra no
+// resumo o saldo atual e a maior exposição (saldo acumulado mais negativo).
+func montarFluxoCaixa(fluxo []dto.FluxoCaixaMensal, r *dto.ResumoResultado) []dto.PontoFluxoCaixa {
+	meses := make(map[string]*valoresMes)
+	for _, f := range fluxo {
+		if meses[f.Mes] == nil {
+			meses[f.Mes] = &valoresMes{}
+		}
+		meses[f.Mes].receitaRealizada += f.Recebido
+		meses[f.Mes].custoRealizado += f.Pago
+	}
+
+	pontos := []dto.PontoFluxoCaixa{}
+	var acumulado, menor float64
+	for _, chave := range mesesEntre(meses) {
+		v := meses[chave]
+		if v == nil {
+			v = &valoresMes{}
+		}
+		saldo := v.receitaRealizada - v.custoRealizado
+		acumulado += saldo
+		menor = min(menor, acumulado)
+		pontos = append(pontos, dto.PontoFluxoCaixa{
+			Mes:            chave,
+			Recebido:       arredondar(v.receitaRealizada),
+			Pago:           arredondar(v.custoRealizado),
+			Saldo:          arredondar(saldo),
+			SaldoAcumulado: arredondar(acumulado),
+		})
+	}
+	r.SaldoCaixa = acumulado
+	r.ExposicaoMaxima = max(-menor, 0)
+	return pontos
+}
+
+func arredondarResumo(r dto.ResumoResultado) dto.ResumoResultado {
+	for _, v := range []*float64{
+		&r.ValorContratado, &r.ValorFaturado, &r.ValorRecebido, &r.SaldoAReceber,
+		&r.CustoComprometido, &r.CustoIncorrido, &r.CustoPago, &r.ReceitaReconhecida,
+		&r.MargemBruta, &r.MargemBrutaPercentual, &r.CustoProjetado, &r.MargemProjetada,
+		&r.MargemProjetadaPercentual, &r.SaldoCaixa, &r.ExposicaoMaxima,
+	} {
+		*v = arredondar(*v)
+	}
+	return r
+}
@@ -0,0 +1,54 @@
+package obras
+
+import (
+	"testing"
+
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+)
+
+func TestCustosPorCategoria(t *testing.T) {
+	custos := []dto.CustoResultado{
+		{Categoria: "MATERIAL", Comprometido: 100, Incorrido: 80, Pago: 50},
+		{Categoria: "MATERIAL", Comprometido: 0.25, Incorrido: 0.25},
+		{Categoria: "EQUIPAMENTOS", Incorrido: 300, Pago: 300},
+		{Categoria: "OUTROS", Comprometido: 40, Incorrido: 40, Pago: 10},
+		{Categoria: "DESCONHECIDA", Comprometido: 999},
+	}
+
+	porCategoria := make(map[string]dto.CustoResultado)
+	var ordem []string
+	for _, c := range custosPorCategoria(custos) {
+		porCategoria[c.Categoria] = c
+		ordem = append(ordem, c.Categoria)
+	}
+
+	esperadaOrdem := []string{"MATERIAL", "MAO_DE_OBRA", "SERVICOS", "EQUIPAMENTOS", "OUTROS"}
+	if len(ordem) != len(esperadaOrdem) {
+		t.Fatalf("categorias = %v, esperado %v", ordem, esperadaOrdem)
+	}
+	for i := range ordem {
+		if ordem[i] != esperadaOrdem[i] {
+			t.Fatalf("categorias = %v, esperado %v", ordem, esperadaOrdem)
+		}
+	}
+
+	casos := []struct {
+		categoria                     string
+		comprometido, incorrido, pago float64
+	}{
+		{"MATERIAL", 100.25, 80.25, 50},
+		{"MAO_DE_OBRA", 0, 0, 0},
+		{"SERVICOS", 0, 0, 0},
+		{"EQUIPAMENTOS", 0, 300, 300},
+		{"OUTROS", 40, 40, 10},
+	}
+	for _, tc := range casos {
+		t.Run(tc.categoria, func(t *testing.T) {
+			c := porCategoria[tc.categoria]
+			if c.Comprometido != tc.comprometido || c.Incorrido != tc.incorrido || c.Pago != tc.pago {
+				t.Errorf("%s = {%v %v %v}, esperado {%v %v %v}", tc.categoria,
+					c.Comprometido, c.Incorrido, c.Pago, tc.comprometido, tc.incorrido, tc.pago)
+			}
+		})
+	}
+}
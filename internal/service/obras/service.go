@@ -3,19 +3,25 @@ package obras
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/google/uuid" // Usaremos UUID para os IDs.
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/luiszkm/masterCostrutora/internal/domain/clientes"
 	"github.com/luiszkm/masterCostrutora/internal/domain/common" // Importa o pacote de filtros e paginação
 	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
 	"github.com/luiszkm/masterCostrutora/internal/domain/pessoal"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
 	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto" // Importa o pacote de DTO
 	// Importa o pacote de DTO
 )
 
+// ErrStatusSomentePorTransicao é retornado quando AtualizarObra tenta mudar o status diretamente.
+var ErrStatusSomentePorTransicao = errors.New("o status da obra só pode ser alterado pelas transições (/obras/{obraId}/transicoes)")
+
 type ObrasQuerier interface {
 	BuscarDashboardPorID(ctx context.Context, id string) (*dto.ObraDashboard, error)
 	ListarObras(ctx context.Context, filtros common.ListarFiltros) ([]*dto.ObraListItemDTO, *common.PaginacaoInfo, error)
@@ -25,30 +31,73 @@ type PessoalFinder interface {
 	BuscarPorID(ctx context.Context, funcionarioID string) (*pessoal.Funcionario, error)
 }
 
+// ClienteFinder localiza o cliente cadastrado que contrata a obra.
+type ClienteFinder interface {
+	ResolverCliente(ctx context.Context, clienteID, nome string) (*clientes.Cliente, error)
+}
+
+// PlanejadorObra monta a estrutura inicial da obra a partir de um modelo ou de outra obra.
+type PlanejadorObra interface {
+	PlanejarObra(ctx context.Context, obra *obras.Obra, modeloID, obraOrigemID string) (*obras.PlanoObra, error)
+}
+
+// QualidadeEtapas instancia as FVS nas etapas novas e diz se as FVS obrigatórias
+// ainda impedem concluir a etapa.
+type QualidadeEtapas interface {
+	InstanciarChecklists(ctx context.Context, dbtx db.DBTX, etapas []*obras.Etapa) error
+	VerificarConclusao(ctx context.Context, etapaID string) error
+}
+
+// ReprogramadorCronograma empurra as etapas sucessoras quando uma etapa atrasa.
+type ReprogramadorCronograma interface {
+	Reprogramar(ctx context.Context, obraID string) (int, error)
+}
+
+// VerificadorExclusao confere as pendências que impedem mandar a obra para a lixeira.
+type VerificadorExclusao interface {
+	VerificarExclusao(ctx context.Context, obra *obras.Obra) error
+}
+
 // Service encapsula a lógica de negócio para o contexto de Obras.
 type Service struct {
 	obraRepo        obras.ObrasRepository
 	etapaRepo       obras.EtapaRepository
-	alocacaoRepo    obras.AlocacaoRepository
 	etapaPadraoRepo obras.EtapaPadraoRepository
-	pessoalFinder   PessoalFinder
+	aditivoRepo     obras.AditivoRepository
+	dependenciaRepo obras.DependenciaEtapaRepository
+	orcamentoRepo   obras.OrcamentoAnaliticoRepository
+	clienteFinder   ClienteFinder
+	planejador      PlanejadorObra
+	recebimentos    AjustadorCronograma
 	obrasQuerier    ObrasQuerier
+	cronograma      ReprogramadorCronograma
+	qualidade       QualidadeEtapas
+	exclusao        VerificadorExclusao
 	logger          *slog.Logger
 	dbpool          *pgxpool.Pool // NOVO
 
 }
 
 func NovoServico(obraRepo obras.ObrasRepository, etapaRepo obras.EtapaRepository,
-	etapaPadraoRepo obras.EtapaPadraoRepository,
-	alocacaoRepo obras.AlocacaoRepository, pessoalFinder PessoalFinder, obrasQuerier ObrasQuerier,
-	logger *slog.Logger, dbpool *pgxpool.Pool) *Service {
+	etapaPadraoRepo obras.EtapaPadraoRepository, aditivoRepo obras.AditivoRepository,
+	dependenciaRepo obras.DependenciaEtapaRepository, orcamentoRepo obras.OrcamentoAnaliticoRepository,
+	clienteFinder ClienteFinder, planejador PlanejadorObra, recebimentos AjustadorCronograma,
+	obrasQuerier ObrasQuerier, cronograma ReprogramadorCronograma, qualidade QualidadeEtapas,
+	exclusao VerificadorExclusao, logger *slog.Logger, dbpool *pgxpool.Pool) *Service {
 	return &Service{
-		alocacaoRepo:    alocacaoRepo,
-		pessoalFinder:   pessoalFinder,
+		clienteFinder:   clienteFinder,
+		planejador:      planejador,
+		recebimentos:    recebimentos,
+		dependenciaRepo: dependenciaRepo,
+		orcamentoRepo:   orcamentoRepo,
 		obraRepo:        obraRepo,
 		etapaRepo:       etapaRepo,
 		etapaPadraoRepo: etapaPadraoRepo,
+		aditivoRepo:     aditivoRepo,
 		obrasQuerier:    obrasQuerier,
+		cronograma:      cronograma,
+		qualidade:       qualidade,
+		exclusao:        exclusao,
 		logger:          logger,
 		dbpool:          dbpool, // NOVO
 	}
@@ -69,16 +118,16 @@ func (s *Service) ListarObras(ctx context.Context, filtros common.ListarFiltros)
 	}, nil
 }
 
-// CriarNovaObra é o caso de uso para registrar uma nova construção.
+// CriarNovaObra é o caso de uso para registrar uma nova construção. A estrutura
+// inicial vem do modelo escolhido, de uma obra existente ou, sem nenhum dos dois,
+// do catálogo de etapas padrão. Todas as etapas começam pendentes.
 func (s *Service) CriarNovaObra(ctx context.Context, input dto.CriarNovaObraInput) (*obras.Obra, error) {
 	const op = "service.obras.CriarNovaObra"
 
-	// Inicia a transação
-	tx, err := s.dbpool.Begin(ctx)
+	cliente, err := s.clienteFinder.ResolverCliente(ctx, input.ClienteID, input.Cliente)
 	if err != nil {
-		return nil, fmt.Errorf("%s: falha ao iniciar transação: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	defer tx.Rollback(ctx) // Garante o rollback em caso de erro
 
 	// 1. Cria a entidade Obra principal
 	dataInicio, err := time.Parse("2006-01-02", input.DataInicio)
@@ -114,7 +163,8 @@ func (s *Service) CriarNovaObra(ctx context.Context, input dto.CriarNovaObraInpu
 	novaObra := &obras.Obra{
 		ID:                     uuid.NewString(),
 		Nome:                   input.Nome,
-		Cliente:                input.Cliente,
+		Cliente:                cliente.Nome,
+		ClienteID:              &cliente.ID,
 		Endereco:               input.Endereco,
 		DataInicio:             dataInicio,
 		DataFim:                dataFim,
@@ -125,45 +175,111 @@ func (s *Service) CriarNovaObra(ctx context.Context, input dto.CriarNovaObraInpu
 		TipoCobranca:           tipoCobranca,
 		DataAssinaturaContrato: input.DataAssinaturaContrato,
 	}
-	if err := s.obraRepo.Salvar(ctx, tx, novaObra); err != nil {
-		return nil, fmt.Errorf("%s: falha ao salvar nova obra: %w", op, err)
+	if input.PercentualRetencao != nil {
+		if err := novaObra.DefinirRetencao(*input.PercentualRetencao); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
 	}
 
-	// 2. Busca todas as etapas padrão do catálogo
-	etapasPadrao, err := s.etapaPadraoRepo.ListarTodas(ctx)
+	// 2. Monta as etapas: do modelo / obra de origem ou do catálogo de etapas padrão
+	plano, err := s.planejador.PlanejarObra(ctx, novaObra, input.ModeloID, input.ObraOrigemID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: falha ao buscar catálogo de etapas: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-
-	// 3. Cria uma instância de cada etapa padrão para a nova obra
-	for _, etapaPadrao := range etapasPadrao {
-		// Regra de negócio: A primeira etapa ("Fundações") começa em planejamento, as outras pendentes.
-		status := obras.StatusEtapaPendente
-		if etapaPadrao.Nome == "Fundações" {
-			status = obras.StatusEtapaEmAndamento // Corrigido para "Em Andamento" para ser mais realista
+	if plano == nil {
+		if plano, err = s.planoDoCatalogo(ctx, novaObra); err != nil {
+			return nil, fmt.Errorf("%s: falha ao buscar catálogo de etapas: %w", op, err)
 		}
+	}
+	cronograma, err := s.cronogramaDoPlano(novaObra, plano)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-		novaEtapa := &obras.Etapa{
-			ID:                 uuid.NewString(),
-			ObraID:             novaObra.ID,
-			Nome:               etapaPadrao.Nome,
-			DataInicioPrevista: &time.Time{}, // Data padrão, pode ser ajustada depois
-			DataFimPrevista:    &time.Time{}, // Previsão de 1 mês
-			Status:             status,
-		}
+	// 3. Grava obra, etapas, dependências e orçamento na mesma transação
+	tx, err := s.dbpool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao iniciar transação: %w", op, err)
+	}
+	defer tx.Rollback(ctx) // Garante o rollback em caso de erro
+
+	if err := s.obraRepo.Salvar(ctx, tx, novaObra); err != nil {
+		return nil, fmt.Errorf("%s: falha ao salvar nova obra: %w", op, err)
+	}
+	for _, novaEtapa := range plano.Etapas {
 		if err := s.etapaRepo.Salvar(ctx, tx, novaEtapa); err != nil {
 			return nil, fmt.Errorf("%s: falha ao salvar etapa '%s': %w", op, novaEtapa.Nome, err)
 		}
 	}
+	if err := s.qualidade.InstanciarChecklists(ctx, tx, plano.Etapas); err != nil {
+		return nil, fmt.Errorf("%s: falha ao instanciar checklists de qualidade: %w", op, err)
+	}
+	if err := s.dependenciaRepo.SalvarMuitos(ctx, tx, plano.Dependencias); err != nil {
+		return nil, fmt.Errorf("%s: falha ao salvar dependências: %w", op, err)
+	}
+	if plano.Orcamento != nil {
+		if err := s.orcamentoRepo.Criar(ctx, tx, plano.Orcamento); err != nil {
+			return nil, fmt.Errorf("%s: falha ao salvar orçamento analítico: %w", op, err)
+		}
+	}
 
 	// Se tudo deu certo, comita a transação
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("%s: falha ao comitar transação: %w", op, err)
 	}
 
-	s.logger.InfoContext(ctx, "nova obra criada com etapas padrão", "obra_id", novaObra.ID, "etapas_criadas", len(etapasPadrao))
+	// 4. O cronograma de recebimento passa pelo serviço do cronograma, que publica
+	// os eventos das parcelas; se falhar, a obra fica criada e ele pode ser gerado depois.
+	if cronograma != nil {
+		if _, err := s.recebimentos.GerarCronograma(ctx, novaObra.ID, *cronograma); err != nil {
+			s.logger.WarnContext(ctx, "falha ao gerar o cronograma de recebimento do modelo", "obra_id", novaObra.ID, "erro", err)
+		}
+	}
+
+	s.logger.InfoContext(ctx, "nova obra criada", "obra_id", novaObra.ID, "modelo_id", input.ModeloID,
+		"obra_origem_id", input.ObraOrigemID, "etapas_criadas", len(plano.Etapas), "dependencias", len(plano.Dependencias))
 	return novaObra, nil
 }
+
+// planoDoCatalogo cria uma etapa pendente, sem datas previstas, para cada etapa padrão do catálogo.
+func (s *Service) planoDoCatalogo(ctx context.Context, obra *obras.Obra) (*obras.PlanoObra, error) {
+	etapasPadrao, err := s.etapaPadraoRepo.ListarTodas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plano := &obras.PlanoObra{Etapas: make([]*obras.Etapa, 0, len(etapasPadrao))}
+	for _, etapaPadrao := range etapasPadrao {
+		plano.Etapas = append(plano.Etapas, &obras.Etapa{
+			ID:            uuid.NewString(),
+			ObraID:        obra.ID,
+			Nome:          etapaPadrao.Nome,
+			EtapaPadraoID: &etapaPadrao.ID,
+			Status:        obras.StatusEtapaPendente,
+			Peso:          obras.PesoEtapaPadrao,
+		})
+	}
+	return plano, nil
+}
+
+// cronogramaDoPlano monta a geração do cronograma de recebimento quando a obra é
+// cobrada por etapas e o plano divide o contrato entre elas. Os parâmetros são
+// validados antes de gravar a obra.
+func (s *Service) cronogramaDoPlano(obra *obras.Obra, plano *obras.PlanoObra) (*dto.GerarCronogramaInput, error) {
+	if obra.TipoCobranca != obras.TipoCobrancaEtapas || obra.ValorContratoTotal <= 0 || len(plano.PercentuaisCronograma) == 0 {
+		return nil, nil
+	}
+	input := &dto.GerarCronogramaInput{
+		PercentualEntrada: plano.PercentualEntrada,
+		PrazoDiasEtapa:    plano.PrazoDiasEtapa,
+	}
+	for _, p := range plano.PercentuaisCronograma {
+		input.Etapas = append(input.Etapas, dto.PercentualEtapaInput{EtapaID: p.EtapaID, Percentual: p.Percentual})
+	}
+	if _, err := montarParcelasContrato(obra, plano.Etapas, *input, time.Now()); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
 func (s *Service) ListarEtapasPadrao(ctx context.Context) ([]*obras.EtapaPadrao, error) {
 	return s.etapaPadraoRepo.ListarTodas(ctx)
 }
@@ -269,15 +385,28 @@ func (s *Service) AdicionarEtapa(ctx context.Context, obraID string, input dto.A
 		ID:                 uuid.NewString(),
 		ObraID:             obraID,
 		Nome:               etapaPadrao.Nome, // O nome vem do catálogo
+		EtapaPadraoID:      &etapaPadrao.ID,
 		DataInicioPrevista: &inicio,
 		DataFimPrevista:    &fim,
 		Status:             obras.StatusEtapaPendente, // Status inicial padrão
+		Peso:               obras.PesoEtapaPadrao,
+	}
+	if input.Peso != nil {
+		if err := novaEtapa.DefinirPeso(*input.Peso); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
 	}
 
-	// 5. Salva a nova etapa no banco de dados.
+	// 5. Salva a nova etapa no banco de dados, com as FVS da etapa padrão.
 	if err := s.etapaRepo.Salvar(ctx, tx, novaEtapa); err != nil {
 		return nil, fmt.Errorf("%s: falha ao salvar nova etapa na obra: %w", op, err)
 	}
+	if err := s.qualidade.InstanciarChecklists(ctx, tx, []*obras.Etapa{novaEtapa}); err != nil {
+		return nil, fmt.Errorf("%s: falha ao instanciar checklists de qualidade: %w", op, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: falha ao comitar transação: %w", op, err)
+	}
 
 	s.logger.InfoContext(ctx, "etapa adicionada à obra com sucesso", "etapa_id", novaEtapa.ID, "obra_id", obraID, "nome_etapa", novaEtapa.Nome)
 	return novaEtapa, nil
@@ -294,9 +423,23 @@ func (s *Service) AtualizarStatusEtapa(ctx context.Context, etapaID string, inpu
 	}
 
 	// 2. Aplicar a lógica de negócio/validação
-	// TODO: Adicionar validações de transição de status.
-	// Por exemplo, uma etapa não pode ir de "Concluída" de volta para "Pendente".
-	etapa.Status = obras.StatusEtapa(input.Status)
+	data, err := dataOuHoje(input.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	statusAnterior := etapa.Status
+	novoStatus := obras.StatusEtapa(input.Status)
+	if statusAnterior == obras.StatusEtapaConcluida && novoStatus == obras.StatusEtapaEmAndamento && input.PercentualExecutado != nil {
+		err = etapa.Reabrir(*input.PercentualExecutado)
+	} else {
+		err = etapa.AlterarStatus(novoStatus, data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.verificarConclusao(ctx, etapa, statusAnterior); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
 	// 3. Salvar a etapa atualizada
 	if err := s.etapaRepo.Atualizar(ctx, etapa); err != nil {
@@ -304,52 +447,118 @@ func (s *Service) AtualizarStatusEtapa(ctx context.Context, etapaID string, inpu
 	}
 
 	s.logger.InfoContext(ctx, "status da etapa atualizado", "etapa_id", etapa.ID, "novo_status", etapa.Status)
+	s.reprogramarCronograma(ctx, etapa.ObraID)
 	return etapa, nil
 }
-func (s *Service) AlocarFuncionarios(ctx context.Context, obraID string, input dto.AlocarFuncionariosInput) ([]*obras.Alocacao, error) {
-	const op = "service.obras.AlocarFuncionarios"
 
-	// --- Lógica de Negócio e Validação ---
-	// 1. Valida a obra uma única vez.
-	_, err := s.obrasQuerier.BuscarDashboardPorID(ctx, obraID)
+// AtualizarProgressoEtapa registra o percentual executado, o peso e as datas reais da etapa.
+func (s *Service) AtualizarProgressoEtapa(ctx context.Context, etapaID string, input dto.AtualizarProgressoEtapaInput) (*obras.Etapa, error) {
+	const op = "service.obras.AtualizarProgressoEtapa"
+
+	etapa, err := s.etapaRepo.BuscarPorID(ctx, etapaID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: obra não encontrada: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	// 2. Valida a data de início uma única vez.
-	inicio, err := time.Parse("2006-01-02", input.DataInicioAlocacao)
+	inicioReal, err := parseDataOpcional(input.DataInicioReal)
 	if err != nil {
-		return nil, fmt.Errorf("%s: formato de data inválido: %w", op, err)
+		return nil, fmt.Errorf("%s: formato de data de início real inválido: %w", op, err)
+	}
+	fimReal, err := parseDataOpcional(input.DataFimReal)
+	if err != nil {
+		return nil, fmt.Errorf("%s: formato de data de fim real inválido: %w", op, err)
 	}
 
-	var alocacoesParaSalvar []*obras.Alocacao
-	// 3. Itera sobre cada funcionário para validar e criar a alocação.
-	for _, funcionarioID := range input.FuncionarioIDs {
-		// Verifica se o funcionário existe.
-		_, err = s.pessoalFinder.BuscarPorID(ctx, funcionarioID)
-		if err != nil {
-			return nil, fmt.Errorf("%s: funcionário com ID %s não encontrado: %w", op, funcionarioID, err)
+	if input.Peso != nil {
+		if err := etapa.DefinirPeso(*input.Peso); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
 		}
-
-		novaAlocacao := &obras.Alocacao{
-			ID:                 uuid.NewString(),
-			ObraID:             obraID,
-			FuncionarioID:      funcionarioID,
-			DataInicioAlocacao: inicio,
+	}
+	statusAnterior := etapa.Status
+	if input.PercentualExecutado != nil {
+		// A data do evento (início ou conclusão automáticos) é a informada ou hoje.
+		data := time.Now()
+		if fimReal != nil {
+			data = *fimReal
+		} else if inicioReal != nil {
+			data = *inicioReal
 		}
-		alocacoesParaSalvar = append(alocacoesParaSalvar, novaAlocacao)
+		if err := etapa.RegistrarProgresso(*input.PercentualExecutado, data); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err := etapa.DefinirDatasReais(inicioReal, fimReal); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.verificarConclusao(ctx, etapa, statusAnterior); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	// 4. Salva todas as alocações de uma vez.
-	if err := s.alocacaoRepo.SalvarMuitos(ctx, alocacoesParaSalvar); err != nil {
+	if err := s.etapaRepo.Atualizar(ctx, etapa); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	s.logger.InfoContext(ctx, "funcionários alocados com sucesso", "obra_id", obraID, "quantidade", len(alocacoesParaSalvar))
-	return alocacoesParaSalvar, nil
+
+	s.logger.InfoContext(ctx, "progresso da etapa atualizado", "etapa_id", etapa.ID, "percentual_executado", etapa.PercentualExecutado, "status", etapa.Status)
+	s.reprogramarCronograma(ctx, etapa.ObraID)
+	return etapa, nil
+}
+
+// verificarConclusao impede que a etapa passe a Concluída com FVS obrigatória não aprovada.
+func (s *Service) verificarConclusao(ctx context.Context, etapa *obras.Etapa, statusAnterior obras.StatusEtapa) error {
+	if etapa.Status != obras.StatusEtapaConcluida || statusAnterior == obras.StatusEtapaConcluida {
+		return nil
+	}
+	return s.qualidade.VerificarConclusao(ctx, etapa.ID)
+}
+
+// reprogramarCronograma propaga a mudança da etapa às sucessoras. A etapa já foi
+// salva, então uma falha aqui é só registrada; a próxima alteração reprograma de novo.
+func (s *Service) reprogramarCronograma(ctx context.Context, obraID string) {
+	if _, err := s.cronograma.Reprogramar(ctx, obraID); err != nil {
+		s.logger.WarnContext(ctx, "falha ao reprogramar cronograma físico", "obra_id", obraID, "erro", err)
+	}
+}
+
+// dataOuHoje interpreta uma data opcional no formato "YYYY-MM-DD"; vazia significa hoje.
+func dataOuHoje(valor string) (time.Time, error) {
+	data, err := parseDataOpcional(&valor)
+	if err != nil || data == nil {
+		return time.Now(), err
+	}
+	return *data, nil
+}
+
+// mesmoDia compara duas datas opcionais ignorando o horário.
+func mesmoDia(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return dataSemHora(*a).Equal(dataSemHora(*b))
+}
+
+func parseDataOpcional(valor *string) (*time.Time, error) {
+	if valor == nil || *valor == "" {
+		return nil, nil
+	}
+	data, err := time.Parse("2006-01-02", *valor)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
 }
 func (s *Service) DeletarObra(ctx context.Context, id string) error {
 	const op = "service.obras.DeletarObra"
-	// TODO: Adicionar lógica de negócio aqui. Ex: não se pode deletar uma obra com pagamentos pendentes.
+
+	// Obras em andamento ou concluídas têm movimentação financeira; precisam ser canceladas antes,
+	// e parcelas ou contas ainda em aberto impedem a exclusão
+	obra, err := s.obraRepo.BuscarPorID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.exclusao.VerificarExclusao(ctx, obra); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	if err := s.obraRepo.Deletar(ctx, id); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -358,14 +567,36 @@ func (s *Service) DeletarObra(ctx context.Context, id string) error {
 }
 
 func (s *Service) BuscarDetalhesPorID(ctx context.Context, obraID string) (*dto.ObraDetalhadaDTO, error) {
-	return s.obrasQuerier.BuscarDetalhesPorID(ctx, obraID)
+	const op = "service.obras.BuscarDetalhesPorID"
+
+	detalhes, err := s.obrasQuerier.BuscarDetalhesPorID(ctx, obraID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Linha do tempo do contrato: assinatura e aditivos
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	aditivos, err := s.aditivoRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	detalhes.Contrato = montarContratoObra(obra, aditivos)
+	return detalhes, nil
 }
 
 func (s *Service) AtualizarObra(ctx context.Context, obraID string, input dto.AtualizarObraInput) (*obras.Obra, error) {
 	const op = "service.obras.AtualizarObra"
 
-	if input.Nome == "" || input.Cliente == "" || input.Endereco == "" {
-		return nil, fmt.Errorf("%s: nome, cliente e endereço são obrigatórios", op)
+	if input.Nome == "" || input.Endereco == "" {
+		return nil, fmt.Errorf("%s: nome e endereço são obrigatórios", op)
+	}
+
+	cliente, err := s.clienteFinder.ResolverCliente(ctx, input.ClienteID, input.Cliente)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	// Buscar obra existente primeiro para preservar campos não atualizados
@@ -374,6 +605,11 @@ func (s *Service) AtualizarObra(ctx context.Context, obraID string, input dto.At
 		return nil, fmt.Errorf("%s: obra não encontrada: %w", op, err)
 	}
 
+	// O status só muda pelas transições de ciclo de vida, que validam as pré-condições
+	if input.Status != "" && obras.Status(input.Status) != obraExistente.Status {
+		return nil, fmt.Errorf("%s: %w", op, ErrStatusSomentePorTransicao)
+	}
+
 	dataInicio, err := time.Parse("2006-01-02", input.DataInicio)
 	if err != nil {
 		return nil, fmt.Errorf("%s: formato de data de início inválido: %w", op, err)
@@ -387,17 +623,19 @@ func (s *Service) AtualizarObra(ctx context.Context, obraID string, input dto.At
 	obraAtualizada := &obras.Obra{
 		ID:                     obraID,
 		Nome:                   input.Nome,
-		Cliente:                input.Cliente,
+		Cliente:                cliente.Nome,
+		ClienteID:              &cliente.ID,
 		Endereco:               input.Endereco,
 		DataInicio:             dataInicio,
 		DataFim:                &dataFim,
-		Status:                 obras.Status(input.Status),
+		Status:                 obraExistente.Status,
 		Descricao:              &input.Descricao,
 		// Preservar campos financeiros existentes
 		ValorContratoTotal:     obraExistente.ValorContratoTotal,
 		ValorRecebido:          obraExistente.ValorRecebido,
 		TipoCobranca:           obraExistente.TipoCobranca,
 		DataAssinaturaContrato: obraExistente.DataAssinaturaContrato,
+		PercentualRetencao:     obraExistente.PercentualRetencao,
 	}
 
 	// Atualizar campos financeiros se fornecidos no input
@@ -415,8 +653,25 @@ func (s *Service) AtualizarObra(ctx context.Context, obraID string, input dto.At
 	if input.DataAssinaturaContrato != nil {
 		obraAtualizada.DataAssinaturaContrato = input.DataAssinaturaContrato
 	}
+	if input.PercentualRetencao != nil {
+		if err := obraAtualizada.DefinirRetencao(*input.PercentualRetencao); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	// Depois do primeiro aditivo, valor e prazo só mudam por novo aditivo, para
+	// não perder a linha de base nem o histórico do contrato
+	if obraAtualizada.ValorContratoTotal != obraExistente.ValorContratoTotal || !mesmoDia(obraAtualizada.DataFim, obraExistente.DataFim) {
+		aditivos, err := s.aditivoRepo.ListarPorObraID(ctx, obraID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if len(aditivos) > 0 {
+			return nil, fmt.Errorf("%s: %w", op, obras.ErrContratoComAditivo)
+		}
+	}
 
-	if err := s.obraRepo.Atualizar(ctx, obraAtualizada); err != nil {
+	if err := s.obraRepo.Atualizar(ctx, nil, obraAtualizada); err != nil {
 		return nil, fmt.Errorf("%s: falha ao atualizar obra: %w", op, err)
 	}
 
@@ -0,0 +1,247 @@
+package obras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/financeiro"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/events"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+	"github.com/luiszkm/masterCostrutora/pkg/auth"
+)
+
+// ErrMotivoObrigatorio é retornado ao cancelar uma obra sem informar o motivo.
+var ErrMotivoObrigatorio = errors.New("motivo é obrigatório para cancelar a obra")
+
+// ContaReceberObraRepository é a parte do repositório de contas a receber usada nas transições.
+type ContaReceberObraRepository interface {
+	ListarPorObraID(ctx context.Context, obraID string) ([]*financeiro.ContaReceber, error)
+	Atualizar(ctx context.Context, conta *financeiro.ContaReceber) error
+	AtualizarMuitas(ctx context.Context, dbtx db.DBTX, contas []*financeiro.ContaReceber) error
+}
+
+// ContaPagarObraRepository é a parte do repositório de contas a pagar usada nas transições.
+type ContaPagarObraRepository interface {
+	ListarPorObraID(ctx context.Context, obraID string) ([]*financeiro.ContaPagar, error)
+	Atualizar(ctx context.Context, conta *financeiro.ContaPagar) error
+}
+
+// TransicaoService controla o ciclo de vida da obra (iniciar, concluir, cancelar).
+type TransicaoService struct {
+	obraRepo         obras.ObrasRepository
+	etapaRepo        obras.EtapaRepository
+	cronogramaRepo   obras.CronogramaRecebimentoRepository
+	contaReceberRepo ContaReceberObraRepository
+	contaPagarRepo   ContaPagarObraRepository
+	vistoriaRepo     obras.VistoriaRepository
+	eventBus         EventPublisher
+	logger           *slog.Logger
+}
+
+func NovoTransicaoService(
+	obraRepo obras.ObrasRepository,
+	etapaRepo obras.EtapaRepository,
+	cronogramaRepo obras.CronogramaRecebimentoRepository,
+	contaReceberRepo ContaReceberObraRepository,
+	contaPagarRepo ContaPagarObraRepository,
+	vistoriaRepo obras.VistoriaRepository,
+	eventBus EventPublisher,
+	logger *slog.Logger,
+) *TransicaoService {
+	return &TransicaoService{
+		obraRepo:         obraRepo,
+		etapaRepo:        etapaRepo,
+		cronogramaRepo:   cronogramaRepo,
+		contaReceberRepo: contaReceberRepo,
+		contaPagarRepo:   contaPagarRepo,
+		vistoriaRepo:     vistoriaRepo,
+		eventBus:         eventBus,
+		logger:           logger.With("service", "TransicaoObra"),
+	}
+}
+
+// pendenciasObra agrega o que está vinculado à obra e ainda não foi encerrado.
+type pendenciasObra struct {
+	etapas        []*obras.Etapa
+	cronogramas   []*obras.CronogramaRecebimento
+	contasReceber []*financeiro.ContaReceber
+	contasPagar   []*financeiro.ContaPagar
+	bloqueantes   int // Pendências de vistoria bloqueantes não resolvidas
+}
+
+func (p *pendenciasObra) situacao() obras.SituacaoObra {
+	return obras.SituacaoObra{
+		Etapas:                    p.etapas,
+		ParcelasCronogramaAbertas: len(p.cronogramas),
+		ContasReceberAbertas:      len(p.contasReceber),
+		ContasPagarAbertas:        len(p.contasPagar),
+		PendenciasBloqueantes:     p.bloqueantes,
+	}
+}
+
+// ListarTransicoes retorna as transições da obra com os motivos que impedem cada uma.
+func (s *TransicaoService) ListarTransicoes(ctx context.Context, obraID string) (*dto.TransicoesObraOutput, error) {
+	const op = "service.obras.transicao.ListarTransicoes"
+
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	pendencias, err := s.carregarPendencias(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &dto.TransicoesObraOutput{
+		ObraID:      obra.ID,
+		StatusAtual: obra.Status,
+		Transicoes:  obra.AvaliarTransicoes(pendencias.situacao()),
+	}, nil
+}
+
+// ExecutarTransicao aplica a transição pedida. Cancelar também cancela as parcelas
+// do cronograma e as contas ainda abertas da obra.
+func (s *TransicaoService) ExecutarTransicao(ctx context.Context, obraID string, input dto.ExecutarTransicaoInput) (*dto.TransicoesObraOutput, error) {
+	const op = "service.obras.transicao.ExecutarTransicao"
+
+	transicao := obras.Transicao(input.Transicao)
+	if transicao == obras.TransicaoCancelar && input.Motivo == "" {
+		return nil, fmt.Errorf("%s: %w", op, ErrMotivoObrigatorio)
+	}
+
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	pendencias, err := s.carregarPendencias(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	statusAnterior := obra.Status
+	if err := obra.AplicarTransicao(transicao, pendencias.situacao()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	payload := events.ObraStatusAlteradoPayload{
+		ObraID:         obra.ID,
+		ObraNome:       obra.Nome,
+		Transicao:      string(transicao),
+		StatusAnterior: string(statusAnterior),
+		NovoStatus:     string(obra.Status),
+		Motivo:         input.Motivo,
+		UsuarioID:      auth.UsuarioDoContexto(ctx),
+		DataAlteracao:  time.Now(),
+	}
+
+	// O cancelamento encerra as pendências antes de mudar o status: se falhar no meio,
+	// a obra continua cancelável e a operação pode ser repetida.
+	if transicao == obras.TransicaoCancelar {
+		if err := s.cancelarPendencias(ctx, pendencias, "Obra cancelada: "+input.Motivo); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		payload.CronogramasCancelados = len(pendencias.cronogramas)
+		payload.ContasReceberCanceladas = len(pendencias.contasReceber)
+		payload.ContasPagarCanceladas = len(pendencias.contasPagar)
+		pendencias = &pendenciasObra{etapas: pendencias.etapas, bloqueantes: pendencias.bloqueantes}
+	}
+
+	if err := s.obraRepo.Atualizar(ctx, nil, obra); err != nil {
+		return nil, fmt.Errorf("%s: falha ao atualizar obra: %w", op, err)
+	}
+
+	s.eventBus.Publicar(ctx, bus.Evento{Nome: events.ObraStatusAlterado, Payload: payload})
+	s.logger.InfoContext(ctx, "status da obra alterado",
+		"obra_id", obra.ID, "transicao", transicao, "status_anterior", statusAnterior, "novo_status", obra.Status)
+
+	return &dto.TransicoesObraOutput{
+		ObraID:      obra.ID,
+		StatusAtual: obra.Status,
+		Transicoes:  obra.AvaliarTransicoes(pendencias.situacao()),
+	}, nil
+}
+
+// VerificarExclusao confere se a obra pode ir para a lixeira: além do status,
+// não pode haver parcelas do cronograma nem contas a pagar ou a receber em aberto.
+func (s *TransicaoService) VerificarExclusao(ctx context.Context, obra *obras.Obra) error {
+	pendencias, err := s.carregarPendencias(ctx, obra.ID)
+	if err != nil {
+		return err
+	}
+	return obra.PodeSerExcluida(pendencias.situacao())
+}
+
+func (s *TransicaoService) carregarPendencias(ctx context.Context, obraID string) (*pendenciasObra, error) {
+	etapas, err := s.etapaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar etapas: %w", err)
+	}
+	cronogramas, err := s.cronogramaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar cronograma de recebimento: %w", err)
+	}
+	contasReceber, err := s.contaReceberRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar contas a receber: %w", err)
+	}
+	contasPagar, err := s.contaPagarRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar contas a pagar: %w", err)
+	}
+	pendenciasVistoria, err := s.vistoriaRepo.ListarPendenciasPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar pendências da vistoria: %w", err)
+	}
+
+	p := &pendenciasObra{etapas: etapas, bloqueantes: obras.ContarBloqueantesEmAberto(pendenciasVistoria)}
+	for _, c := range cronogramas {
+		if c.EstaEmAberto() {
+			p.cronogramas = append(p.cronogramas, c)
+		}
+	}
+	for _, c := range contasReceber {
+		if c.EstaEmAberto() {
+			p.contasReceber = append(p.contasReceber, c)
+		}
+	}
+	for _, c := range contasPagar {
+		if c.EstaEmAberto() {
+			p.contasPagar = append(p.contasPagar, c)
+		}
+	}
+	return p, nil
+}
+
+func (s *TransicaoService) cancelarPendencias(ctx context.Context, p *pendenciasObra, motivo string) error {
+	for _, c := range p.cronogramas {
+		if err := c.Cancelar(); err != nil {
+			return fmt.Errorf("cronograma %s: %w", c.ID, err)
+		}
+		if err := s.cronogramaRepo.Atualizar(ctx, c); err != nil {
+			return fmt.Errorf("falha ao cancelar cronograma %s: %w", c.ID, err)
+		}
+	}
+	for _, c := range p.contasReceber {
+		if err := c.Cancelar(&motivo); err != nil {
+			return fmt.Errorf("conta a receber %s: %w", c.ID, err)
+		}
+		if err := s.contaReceberRepo.Atualizar(ctx, c); err != nil {
+			return fmt.Errorf("falha ao cancelar conta a receber %s: %w", c.ID, err)
+		}
+	}
+	for _, c := range p.contasPagar {
+		if err := c.Cancelar(&motivo); err != nil {
+			return fmt.Errorf("conta a pagar %s: %w", c.ID, err)
+		}
+		if err := s.contaPagarRepo.Atualizar(ctx, c); err != nil {
+			return fmt.Errorf("falha ao cancelar conta a pagar %s: %w", c.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,406 @@
+package obras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/service/obras/dto"
+	"github.com/luiszkm/masterCostrutora/pkg/auth"
+	"github.com/luiszkm/masterCostrutora/pkg/pdf"
+)
+
+var (
+	ErrVistoriaObraStatus           = errors.New("vistoria só pode ser registrada em obra em andamento")
+	ErrVistoriaEmAberto             = errors.New("já existe uma vistoria aberta nesta obra")
+	ErrVistoriaNaoEncontrada        = errors.New("vistoria não encontrada")
+	ErrSemVistoriaAberta            = errors.New("não há vistoria aberta nesta obra")
+	ErrPendenciaNaoEncontrada       = errors.New("pendência de vistoria não encontrada")
+	ErrPendenciaJaVerificada        = errors.New("pendência já verificada não pode ser removida")
+	ErrPendenciasBloqueantesAbertas = errors.New("há pendências bloqueantes da vistoria não resolvidas")
+	ErrTermoSemVistoria             = errors.New("a obra ainda não passou por vistoria")
+)
+
+// VistoriaService conduz a vistoria final da obra: rodadas de vistoria e revistoria,
+// a lista de pendências e o termo de entrega.
+type VistoriaService struct {
+	obraRepo     obras.ObrasRepository
+	etapaRepo    obras.EtapaRepository
+	vistoriaRepo obras.VistoriaRepository
+	logger       *slog.Logger
+}
+
+func NovoVistoriaService(
+	obraRepo obras.ObrasRepository,
+	etapaRepo obras.EtapaRepository,
+	vistoriaRepo obras.VistoriaRepository,
+	logger *slog.Logger,
+) *VistoriaService {
+	return &VistoriaService{
+		obraRepo:     obraRepo,
+		etapaRepo:    etapaRepo,
+		vistoriaRepo: vistoriaRepo,
+		logger:       logger.With("service", "Vistoria"),
+	}
+}
+
+// AbrirVistoria inicia a próxima rodada de vistoria. Só uma rodada fica aberta por vez.
+func (s *VistoriaService) AbrirVistoria(ctx context.Context, obraID string, input dto.AbrirVistoriaInput) (*obras.Vistoria, error) {
+	const op = "service.obras.vistoria.AbrirVistoria"
+
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if obra.Status != obras.StatusEmAndamento {
+		return nil, fmt.Errorf("%s: %w", op, ErrVistoriaObraStatus)
+	}
+	vistorias, err := s.vistoriaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if vistoriaAberta(vistorias) != nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrVistoriaEmAberto)
+	}
+	data, err := dataOuHoje(input.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	vistoria, err := obras.NovaVistoria(uuid.NewString(), obraID, len(vistorias)+1, data, input.Vistoriador, input.Observacoes, auth.UsuarioDoContexto(ctx), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.vistoriaRepo.Salvar(ctx, vistoria); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "vistoria aberta", "obra_id", obraID, "vistoria_id", vistoria.ID, "numero", vistoria.Numero)
+	return vistoria, nil
+}
+
+// EncerrarVistoria fecha a rodada; as pendências não resolvidas seguem para a próxima.
+func (s *VistoriaService) EncerrarVistoria(ctx context.Context, obraID, vistoriaID string) (*obras.Vistoria, error) {
+	const op = "service.obras.vistoria.EncerrarVistoria"
+
+	vistoria, err := s.buscarVistoria(ctx, obraID, vistoriaID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := vistoria.Encerrar(time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.vistoriaRepo.Atualizar(ctx, vistoria); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "vistoria encerrada", "obra_id", obraID, "vistoria_id", vistoriaID)
+	return vistoria, nil
+}
+
+// ListarVistorias retorna as rodadas e as pendências da obra com os totais em aberto.
+func (s *VistoriaService) ListarVistorias(ctx context.Context, obraID string) (*dto.VistoriasObraOutput, error) {
+	const op = "service.obras.vistoria.ListarVistorias"
+
+	if _, err := s.obraRepo.BuscarPorID(ctx, obraID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	vistorias, err := s.vistoriaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	pendencias, err := s.vistoriaRepo.ListarPendenciasPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	hoje := time.Now()
+	out := &dto.VistoriasObraOutput{
+		ObraID:                obraID,
+		Vistorias:             vistorias,
+		Pendencias:            make([]*dto.PendenciaVistoriaOutput, 0, len(pendencias)),
+		TotalPendencias:       len(pendencias),
+		PendenciasBloqueantes: obras.ContarBloqueantesEmAberto(pendencias),
+	}
+	for _, p := range pendencias {
+		if p.Status != obras.StatusPendenciaResolvida {
+			out.PendenciasEmAberto++
+		}
+		out.Pendencias = append(out.Pendencias, &dto.PendenciaVistoriaOutput{PendenciaVistoria: p, Atrasada: p.Atrasada(hoje)})
+	}
+	return out, nil
+}
+
+// AdicionarPendencia aponta uma pendência na vistoria aberta da obra.
+func (s *VistoriaService) AdicionarPendencia(ctx context.Context, obraID string, input dto.PendenciaVistoriaInput) (*obras.PendenciaVistoria, error) {
+	const op = "service.obras.vistoria.AdicionarPendencia"
+
+	vistorias, err := s.vistoriaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	vistoria := vistoriaAberta(vistorias)
+	if vistoria == nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrSemVistoriaAberta)
+	}
+	dados, err := s.dadosPendencia(ctx, obraID, input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pendencia, err := obras.NovaPendencia(uuid.NewString(), vistoria, dados, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.vistoriaRepo.SalvarPendencia(ctx, pendencia); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return pendencia, nil
+}
+
+// AtualizarPendencia altera os dados de uma pendência ainda não resolvida.
+func (s *VistoriaService) AtualizarPendencia(ctx context.Context, obraID, pendenciaID string, input dto.PendenciaVistoriaInput) (*obras.PendenciaVistoria, error) {
+	const op = "service.obras.vistoria.AtualizarPendencia"
+
+	pendencia, err := s.buscarPendencia(ctx, obraID, pendenciaID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	dados, err := s.dadosPendencia(ctx, obraID, input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := pendencia.AtualizarDados(dados, time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.vistoriaRepo.AtualizarPendencia(ctx, pendencia); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return pendencia, nil
+}
+
+// MarcarCorrigida registra a correção informada pelo responsável; a pendência
+// aguarda a revistoria.
+func (s *VistoriaService) MarcarCorrigida(ctx context.Context, obraID, pendenciaID string) (*obras.PendenciaVistoria, error) {
+	const op = "service.obras.vistoria.MarcarCorrigida"
+
+	pendencia, err := s.buscarPendencia(ctx, obraID, pendenciaID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := pendencia.MarcarCorrigida(time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.vistoriaRepo.AtualizarPendencia(ctx, pendencia); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return pendencia, nil
+}
+
+// VerificarPendencia registra, na revistoria, se a pendência foi aprovada ou reprovada.
+func (s *VistoriaService) VerificarPendencia(ctx context.Context, obraID, vistoriaID, pendenciaID string, input dto.VerificarPendenciaInput) (*obras.PendenciaVistoria, error) {
+	const op = "service.obras.vistoria.VerificarPendencia"
+
+	vistoria, err := s.buscarVistoria(ctx, obraID, vistoriaID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	pendencia, err := s.buscarPendencia(ctx, obraID, pendenciaID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	usuarioID := auth.UsuarioDoContexto(ctx)
+	if err := pendencia.Verificar(vistoria, input.Aprovada, input.Observacao, usuarioID, time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.vistoriaRepo.AtualizarPendencia(ctx, pendencia); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "pendência verificada",
+		"obra_id", obraID, "pendencia_id", pendenciaID, "vistoria_id", vistoriaID, "aprovada", input.Aprovada, "usuario_id", usuarioID)
+	return pendencia, nil
+}
+
+// RemoverPendencia apaga uma pendência apontada por engano, enquanto a vistoria
+// em que foi apontada continua aberta.
+func (s *VistoriaService) RemoverPendencia(ctx context.Context, obraID, pendenciaID string) error {
+	const op = "service.obras.vistoria.RemoverPendencia"
+
+	pendencia, err := s.buscarPendencia(ctx, obraID, pendenciaID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	vistoria, err := s.vistoriaRepo.BuscarPorID(ctx, pendencia.VistoriaID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !vistoria.Aberta() {
+		return fmt.Errorf("%s: %w", op, obras.ErrVistoriaEncerrada)
+	}
+	if len(pendencia.Verificacoes) > 0 {
+		return fmt.Errorf("%s: %w", op, ErrPendenciaJaVerificada)
+	}
+	if err := s.vistoriaRepo.DeletarPendencia(ctx, pendenciaID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// GerarTermoEntrega gera o termo de entrega em PDF para assinatura do cliente.
+// Não é emitido enquanto houver pendência bloqueante não resolvida; as demais
+// pendências em aberto constam como ressalvas.
+func (s *VistoriaService) GerarTermoEntrega(ctx context.Context, obraID string) ([]byte, error) {
+	const op = "service.obras.vistoria.GerarTermoEntrega"
+
+	obra, err := s.obraRepo.BuscarPorID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	vistorias, err := s.vistoriaRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(vistorias) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrTermoSemVistoria)
+	}
+	pendencias, err := s.vistoriaRepo.ListarPendenciasPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if obras.ContarBloqueantesEmAberto(pendencias) > 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrPendenciasBloqueantesAbertas)
+	}
+
+	return montarPDFTermoEntrega(obra, vistorias, pendencias, time.Now()), nil
+}
+
+// dadosPendencia converte o payload e confere que a etapa informada é da obra.
+func (s *VistoriaService) dadosPendencia(ctx context.Context, obraID string, input dto.PendenciaVistoriaInput) (obras.DadosPendencia, error) {
+	dados := obras.DadosPendencia{
+		Descricao:   input.Descricao,
+		Local:       input.Local,
+		Responsavel: input.Responsavel,
+		FotoURL:     input.FotoURL,
+		Bloqueante:  input.Bloqueante,
+	}
+	prazo, err := parseDataOpcional(&input.Prazo)
+	if err != nil {
+		return dados, err
+	}
+	dados.Prazo = prazo
+
+	if input.EtapaID != nil && *input.EtapaID != "" {
+		etapas, err := s.etapaRepo.ListarPorObraID(ctx, obraID)
+		if err != nil {
+			return dados, err
+		}
+		if !contemEtapa(etapas, *input.EtapaID) {
+			return dados, ErrEtapaForaDaObra
+		}
+		dados.EtapaID = input.EtapaID
+	}
+	return dados, nil
+}
+
+// buscarVistoria busca a vistoria e confere que ela pertence à obra da rota.
+func (s *VistoriaService) buscarVistoria(ctx context.Context, obraID, vistoriaID string) (*obras.Vistoria, error) {
+	vistoria, err := s.vistoriaRepo.BuscarPorID(ctx, vistoriaID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNaoEncontrado) {
+			return nil, ErrVistoriaNaoEncontrada
+		}
+		return nil, err
+	}
+	if vistoria.ObraID != obraID {
+		return nil, ErrVistoriaNaoEncontrada
+	}
+	return vistoria, nil
+}
+
+// buscarPendencia busca a pendência e confere que ela pertence à obra da rota.
+func (s *VistoriaService) buscarPendencia(ctx context.Context, obraID, pendenciaID string) (*obras.PendenciaVistoria, error) {
+	pendencia, err := s.vistoriaRepo.BuscarPendenciaPorID(ctx, pendenciaID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNaoEncontrado) {
+			return nil, ErrPendenciaNaoEncontrada
+		}
+		return nil, err
+	}
+	if pendencia.ObraID != obraID {
+		return nil, ErrPendenciaNaoEncontrada
+	}
+	return pendencia, nil
+}
+
+func vistoriaAberta(vistorias []*obras.Vistoria) *obras.Vistoria {
+	for _, v := range vistorias {
+		if v.Aberta() {
+			return v
+		}
+	}
+	return nil
+}
+
+func montarPDFTermoEntrega(obra *obras.Obra, vistorias []*obras.Vistoria, pendencias []*obras.PendenciaVistoria, agora time.Time) []byte {
+	var resolvidas, ressalvas []*obras.PendenciaVistoria
+	for _, p := range pendencias {
+		if p.Status == obras.StatusPendenciaResolvida {
+			resolvidas = append(resolvidas, p)
+		} else {
+			ressalvas = append(ressalvas, p)
+		}
+	}
+
+	doc := pdf.NovoDocumento()
+	doc.Titulo("Termo de Entrega — " + obra.Nome)
+	doc.Paragrafo(fmt.Sprintf("Cliente: %s | Endereço: %s", obra.Cliente, obra.Endereco))
+	doc.Paragrafo(fmt.Sprintf("Emitido em %s", agora.Format(formatoDataBR)))
+	doc.Espaco()
+	doc.Paragrafo("Declaramos que a obra acima foi vistoriada em conjunto com o cliente e é entregue " +
+		"nas condições descritas neste termo.")
+
+	doc.Espaco()
+	doc.Subtitulo("Vistorias realizadas")
+	for _, v := range vistorias {
+		doc.Item(fmt.Sprintf("%dª vistoria em %s — %s", v.Numero, v.Data.Format(formatoDataBR), v.Vistoriador))
+	}
+
+	doc.Espaco()
+	doc.Subtitulo(fmt.Sprintf("Pendências resolvidas (%d)", len(resolvidas)))
+	if len(resolvidas) == 0 {
+		doc.Paragrafo("Nenhuma pendência foi apontada nas vistorias.")
+	}
+	for _, p := range resolvidas {
+		doc.Item(fmt.Sprintf("%s: %s — resolvida em %s", p.Local, p.Descricao, p.ResolvidaEm.Format(formatoDataBR)))
+	}
+
+	if len(ressalvas) > 0 {
+		doc.Espaco()
+		doc.Subtitulo(fmt.Sprintf("Ressalvas (%d)", len(ressalvas)))
+		doc.Paragrafo("Pendências não bloqueantes que a construtora se compromete a corrigir após a entrega:")
+		for _, p := range ressalvas {
+			texto := fmt.Sprintf("%s: %s", p.Local, p.Descricao)
+			if p.Prazo != nil {
+				texto += " — prazo " + p.Prazo.Format(formatoDataBR)
+			}
+			doc.Item(texto)
+		}
+	}
+
+	doc.Espaco()
+	doc.Espaco()
+	doc.Paragrafo("Local e data: ______________________________, ____/____/________")
+	doc.Espaco()
+	doc.Paragrafo("______________________________________________")
+	doc.Paragrafo("Cliente: " + obra.Cliente)
+	doc.Espaco()
+	doc.Paragrafo("______________________________________________")
+	doc.Paragrafo("Responsável pela construtora")
+	return doc.Bytes()
+}
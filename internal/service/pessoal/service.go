@@ -3,7 +3,6 @@ package pessoal
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -14,13 +13,10 @@ import (
 	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
 	"github.com/luiszkm/masterCostrutora/internal/domain/pessoal"
 	"github.com/luiszkm/masterCostrutora/internal/platform/bus"
+	"github.com/luiszkm/masterCostrutora/internal/platform/bus/db"
 	"github.com/luiszkm/masterCostrutora/internal/service/pessoal/dto"
 )
 
-var (
-	ErrFuncionarioAlocado = errors.New("não é possível excluir um funcionário que está alocado em uma obra ativa")
-)
-
 type EventPublisher interface {
 	Publicar(ctx context.Context, evento bus.Evento)
 }
@@ -34,7 +30,7 @@ type PessoalQuerier interface {
 type Service struct {
 	repo            pessoal.FuncionarioRepository
 	apontamentoRepo pessoal.ApontamentoRepository // NOVA DEPENDÊNCIA
-	alocacaoFinder  AlocacaoFinder
+	alocacoes       AlocacaoEncerrador
 	obraFinder      ObraFinder     // NOVA DEPENDÊNCIA
 	querier         PessoalQuerier // NOVA DEPENDÊNCIA
 	logger          *slog.Logger
@@ -48,7 +44,7 @@ type Service struct {
 func NovoServico(
 	repo pessoal.FuncionarioRepository,
 	apontamentoRepo pessoal.ApontamentoRepository,
-	alocacaoFinder AlocacaoFinder,
+	alocacoes AlocacaoEncerrador,
 	obraFinder ObraFinder,
 	eventBus EventPublisher,
 	querier PessoalQuerier,
@@ -58,7 +54,7 @@ func NovoServico(
 	return &Service{
 		repo:            repo,
 		apontamentoRepo: apontamentoRepo,
-		alocacaoFinder:  alocacaoFinder,
+		alocacoes:       alocacoes,
 		obraFinder:      obraFinder,
 		eventBus:        eventBus,
 		querier:         querier,
@@ -67,8 +63,10 @@ func NovoServico(
 	}
 }
 
-type AlocacaoFinder interface {
-	ExistemAlocacoesAtivasParaFuncionario(ctx context.Context, funcionarioID string) (bool, error)
+// AlocacaoEncerrador encerra as alocações em obras quando o funcionário é
+// desligado, na mesma transação do desligamento.
+type AlocacaoEncerrador interface {
+	EncerrarAlocacoesDoFuncionario(ctx context.Context, dbtx db.DBTX, funcionarioID string, data time.Time, motivo string) (int, error)
 }
 
 func (s *Service) CadastrarFuncionario(ctx context.Context, nome, cpf, cargo, departamento, telefone, ChavePix string, diaria float64) (*pessoal.Funcionario, error) {
@@ -97,18 +95,22 @@ func (s *Service) CadastrarFuncionario(ctx context.Context, nome, cpf, cargo, de
 func (s *Service) DeletarFuncionario(ctx context.Context, id string) error {
 	const op = "service.pessoal.DeletarFuncionario"
 
-	// Regra de Negócio: Verificar se o funcionário tem alocações ativas.
-	alocado, err := s.alocacaoFinder.ExistemAlocacoesAtivasParaFuncionario(ctx, id)
+	tx, err := s.dbpool.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("%s: falha ao verificar alocações: %w", op, err)
-	}
-	if alocado {
-		return ErrFuncionarioAlocado
+		return fmt.Errorf("%s: falha ao iniciar transação: %w", op, err)
 	}
+	defer tx.Rollback(ctx)
 
-	if err := s.repo.Deletar(ctx, id); err != nil {
+	if err := s.repo.Deletar(ctx, tx, id); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+	// Regra de Negócio: o desligamento encerra hoje as alocações em obras.
+	if _, err := s.alocacoes.EncerrarAlocacoesDoFuncionario(ctx, tx, id, time.Now(), "Funcionário desligado"); err != nil {
+		return fmt.Errorf("%s: falha ao encerrar alocações: %w", op, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: falha ao comitar transação: %w", op, err)
+	}
 	s.logger.InfoContext(ctx, "funcionário excluído (soft delete)", "funcionario_id", id)
 	return nil
 }
@@ -184,10 +186,29 @@ func (s *Service) AtualizarFuncionario(ctx context.Context, id string, input dto
 
 	funcionario.UpdatedAt = time.Now()
 
-	// 3. Persiste o objeto funcionário completo e atualizado.
-	if err := s.repo.Atualizar(ctx, funcionario); err != nil {
+	// 3. Persiste o funcionário e, se ele foi desligado, encerra as alocações na
+	// data do desligamento, na mesma transação.
+	tx, err := s.dbpool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao iniciar transação: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.repo.Atualizar(ctx, tx, funcionario); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	if funcionario.DesligamentoData != nil {
+		motivo := funcionario.MotivoDesligamento
+		if motivo == "" {
+			motivo = "Funcionário desligado"
+		}
+		if _, err := s.alocacoes.EncerrarAlocacoesDoFuncionario(ctx, tx, id, *funcionario.DesligamentoData, motivo); err != nil {
+			return nil, fmt.Errorf("%s: falha ao encerrar alocações: %w", op, err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: falha ao comitar transação: %w", op, err)
+	}
 
 	s.logger.InfoContext(ctx, "funcionário atualizado com sucesso", "funcionario_id", id)
 	return funcionario, nil
@@ -0,0 +1,94 @@
+package dto
+
+import "time"
+
+// CriarLinkInput representa o input para gerar um link de compartilhamento da obra
+type CriarLinkInput struct {
+	Descricao    string `json:"descricao"`              // Para quem o link foi enviado
+	ValidadeDias int    `json:"validadeDias,omitempty"` // Padrão de 30 dias, máximo de 180
+}
+
+// LinkOutput representa um link de compartilhamento com o token a ser enviado ao cliente
+type LinkOutput struct {
+	ID             string     `json:"id"`
+	ObraID         string     `json:"obraId"`
+	Descricao      string     `json:"descricao"`
+	Token          string     `json:"token"`
+	URL            string     `json:"url,omitempty"` // Preenchida quando PORTAL_URL_BASE está configurada
+	CriadoPor      string     `json:"criadoPor"`
+	ExpiraEm       time.Time  `json:"expiraEm"`
+	RevogadoEm     *time.Time `json:"revogadoEm,omitempty"`
+	Ativo          bool       `json:"ativo"`
+	UltimoAcessoEm *time.Time `json:"ultimoAcessoEm,omitempty"`
+	TotalAcessos   int        `json:"totalAcessos"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// PortalObraDTO é a visão restrita da obra exibida ao cliente. Não inclui custos,
+// fornecedores nem dados de funcionários.
+type PortalObraDTO struct {
+	Obra         ObraPortalDTO      `json:"obra"`
+	Etapas       []EtapaPortalDTO   `json:"etapas"`
+	Fotos        []FotoPortalDTO    `json:"fotos"`
+	Recebimentos []ParcelaPortalDTO `json:"recebimentos"`
+	Resumo       ResumoPortalDTO    `json:"resumo"`
+	LinkExpiraEm time.Time          `json:"linkExpiraEm"`
+	GeradoEm     time.Time          `json:"geradoEm"`
+}
+
+type ObraPortalDTO struct {
+	Nome                string     `json:"nome"`
+	Cliente             string     `json:"cliente"`
+	Endereco            string     `json:"endereco"`
+	Status              string     `json:"status"`
+	DataInicio          time.Time  `json:"dataInicio"`
+	DataFimPrevista     *time.Time `json:"dataFimPrevista,omitempty"`
+	PercentualConcluido float64    `json:"percentualConcluido"` // Média do executado ponderada pelo peso das etapas
+}
+
+type EtapaPortalDTO struct {
+	Nome                string     `json:"nome"`
+	Status              string     `json:"status"`
+	DataInicioPrevista  *time.Time `json:"dataInicioPrevista,omitempty"`
+	DataFimPrevista     *time.Time `json:"dataFimPrevista,omitempty"`
+	DataFimReal         *time.Time `json:"dataFimReal,omitempty"`
+	PercentualExecutado float64    `json:"percentualExecutado"`
+}
+
+// FotoPortalDTO é uma foto de um diário de obra já assinado
+type FotoPortalDTO struct {
+	URL     string    `json:"url"`
+	Legenda string    `json:"legenda"`
+	Data    time.Time `json:"data"`
+	Etapa   string    `json:"etapa,omitempty"`
+}
+
+// ParcelaPortalDTO é uma parcela do cronograma de recebimento
+type ParcelaPortalDTO struct {
+	Numero         int                 `json:"numero"`
+	Descricao      string              `json:"descricao"`
+	ValorPrevisto  float64             `json:"valorPrevisto"`
+	ValorPago      float64             `json:"valorPago"`
+	ValorRetido    float64             `json:"valorRetido"` // Caução, cobrada na entrega da obra e não na parcela
+	ValorEmAberto  float64             `json:"valorEmAberto"`
+	DataVencimento time.Time           `json:"dataVencimento"`
+	DataPagamento  *time.Time          `json:"dataPagamento,omitempty"`
+	Situacao       string              `json:"situacao"`            // PAGO, PAGO_PARCIAL, EM_ABERTO, VENCIDO, CANCELADO
+	Pagamento      *PagamentoPortalDTO `json:"pagamento,omitempty"` // Apenas para parcelas em aberto
+}
+
+// PagamentoPortalDTO reúne as formas de pagamento de uma parcela em aberto
+type PagamentoPortalDTO struct {
+	PixCopiaECola  *string `json:"pixCopiaECola,omitempty"`
+	ChavePix       *string `json:"chavePix,omitempty"`
+	Beneficiario   *string `json:"beneficiario,omitempty"`
+	LinhaDigitavel *string `json:"linhaDigitavel,omitempty"`
+	URLBoleto      *string `json:"urlBoleto,omitempty"`
+}
+
+type ResumoPortalDTO struct {
+	ValorContrato float64 `json:"valorContrato"`
+	TotalPago     float64 `json:"totalPago"`
+	TotalEmAberto float64 `json:"totalEmAberto"`
+	TotalVencido  float64 `json:"totalVencido"`
+}
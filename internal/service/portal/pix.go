@@ -0,0 +1,93 @@
+// file: internal/service/portal/pix.go
+package portal
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// DadosPix são os dados de recebimento por PIX da construtora. Sem chave, o portal
+// não exibe PIX.
+type DadosPix struct {
+	Chave        string
+	Beneficiario string
+	Cidade       string
+}
+
+// pixCopiaECola monta o BR Code estático (padrão EMV do Banco Central) com o valor
+// da parcela. O txid identifica a parcela na conciliação do extrato bancário.
+func pixCopiaECola(dados DadosPix, valor float64, txid string) string {
+	conta := campoEMV("00", "BR.GOV.BCB.PIX") + campoEMV("01", dados.Chave)
+
+	var b strings.Builder
+	b.WriteString(campoEMV("00", "01"))
+	b.WriteString(campoEMV("26", conta))
+	b.WriteString(campoEMV("52", "0000"))
+	b.WriteString(campoEMV("53", "986")) // BRL
+	if valor > 0 {
+		b.WriteString(campoEMV("54", fmt.Sprintf("%.2f", valor)))
+	}
+	b.WriteString(campoEMV("58", "BR"))
+	b.WriteString(campoEMV("59", textoEMV(dados.Beneficiario, 25)))
+	b.WriteString(campoEMV("60", textoEMV(dados.Cidade, 15)))
+	b.WriteString(campoEMV("62", campoEMV("05", txidEMV(txid))))
+	b.WriteString("6304")
+	return b.String() + fmt.Sprintf("%04X", crc16(b.String()))
+}
+
+func campoEMV(id, valor string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(valor), valor)
+}
+
+// textoEMV remove acentos e limita o tamanho, já que o BR Code aceita só ASCII.
+func textoEMV(s string, max int) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.TrimSpace(s)) {
+		if unicode.Is(unicode.Mn, r) || r > unicode.MaxASCII {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	t := strings.ToUpper(b.String())
+	if len(t) > max {
+		t = t[:max]
+	}
+	return t
+}
+
+// txidEMV mantém só letras e números, com no máximo 25 caracteres.
+func txidEMV(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	t := b.String()
+	if len(t) > 25 {
+		t = t[:25]
+	}
+	if t == "" {
+		return "***"
+	}
+	return t
+}
+
+// crc16 é o CRC16-CCITT (polinômio 0x1021, valor inicial 0xFFFF) exigido pelo BR Code.
+func crc16(s string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,371 @@
+// file: internal/service/portal/service.go
+package portal
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luiszkm/masterCostrutora/internal/domain/financeiro"
+	"github.com/luiszkm/masterCostrutora/internal/domain/obras"
+	"github.com/luiszkm/masterCostrutora/internal/domain/portal"
+	"github.com/luiszkm/masterCostrutora/internal/infrastructure/repository/postgres"
+	"github.com/luiszkm/masterCostrutora/internal/service/portal/dto"
+	"github.com/luiszkm/masterCostrutora/pkg/auth"
+)
+
+// maxFotosPortal limita a galeria às fotos mais recentes.
+const maxFotosPortal = 200
+
+type ObraFinder interface {
+	BuscarPorID(ctx context.Context, id string) (*obras.Obra, error)
+}
+type EtapaFinder interface {
+	ListarPorObraID(ctx context.Context, obraID string) ([]*obras.Etapa, error)
+}
+type DiarioFinder interface {
+	ListarPorPeriodo(ctx context.Context, obraID string, inicio, fim *time.Time) ([]*obras.DiarioObra, error)
+}
+type CronogramaFinder interface {
+	ListarPorObraID(ctx context.Context, obraID string) ([]*obras.CronogramaRecebimento, error)
+}
+type ContaReceberFinder interface {
+	ListarPorObraID(ctx context.Context, obraID string) ([]*financeiro.ContaReceber, error)
+}
+
+// Service gerencia os links de compartilhamento e monta a visão da obra exibida ao cliente.
+type Service struct {
+	linkRepo         portal.LinkRepository
+	obraFinder       ObraFinder
+	etapaFinder      EtapaFinder
+	diarioFinder     DiarioFinder
+	cronogramaFinder CronogramaFinder
+	contaFinder      ContaReceberFinder
+	segredo          []byte
+	urlBase          string
+	pix              DadosPix
+	logger           *slog.Logger
+}
+
+func NovoServico(
+	linkRepo portal.LinkRepository,
+	obraFinder ObraFinder,
+	etapaFinder EtapaFinder,
+	diarioFinder DiarioFinder,
+	cronogramaFinder CronogramaFinder,
+	contaFinder ContaReceberFinder,
+	segredo []byte,
+	urlBase string, // Endereço do portal no frontend; o token é anexado ao final
+	pix DadosPix,
+	logger *slog.Logger,
+) *Service {
+	return &Service{
+		linkRepo:         linkRepo,
+		obraFinder:       obraFinder,
+		etapaFinder:      etapaFinder,
+		diarioFinder:     diarioFinder,
+		cronogramaFinder: cronogramaFinder,
+		contaFinder:      contaFinder,
+		segredo:          segredo,
+		urlBase:          urlBase,
+		pix:              pix,
+		logger:           logger.With("service", "Portal"),
+	}
+}
+
+// CriarLink gera um link de compartilhamento somente leitura para a obra.
+func (s *Service) CriarLink(ctx context.Context, obraID string, input dto.CriarLinkInput) (*dto.LinkOutput, error) {
+	const op = "service.portal.CriarLink"
+
+	if _, err := s.obraFinder.BuscarPorID(ctx, obraID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	validade := time.Duration(input.ValidadeDias) * 24 * time.Hour
+	link, err := portal.NovoLink(uuid.NewString(), obraID, strings.TrimSpace(input.Descricao), auth.UsuarioDoContexto(ctx), validade, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.linkRepo.Salvar(ctx, link); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logger.InfoContext(ctx, "link do portal criado", "obraId", obraID, "linkId", link.ID, "expiraEm", link.ExpiraEm)
+	return s.toLinkOutput(link, time.Now()), nil
+}
+
+func (s *Service) ListarLinks(ctx context.Context, obraID string) ([]*dto.LinkOutput, error) {
+	const op = "service.portal.ListarLinks"
+
+	links, err := s.linkRepo.ListarPorObraID(ctx, obraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	agora := time.Now()
+	saida := make([]*dto.LinkOutput, 0, len(links))
+	for _, l := range links {
+		saida = append(saida, s.toLinkOutput(l, agora))
+	}
+	return saida, nil
+}
+
+// RevogarLink encerra o acesso do link antes da expiração.
+func (s *Service) RevogarLink(ctx context.Context, obraID, linkID string) error {
+	const op = "service.portal.RevogarLink"
+
+	link, err := s.linkRepo.BuscarPorID(ctx, linkID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if link.ObraID != obraID {
+		return fmt.Errorf("%s: %w", op, postgres.ErrNaoEncontrado)
+	}
+	agora := time.Now()
+	if err := link.Revogar(agora); err != nil {
+		return err
+	}
+	if err := s.linkRepo.Revogar(ctx, linkID, agora); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	s.logger.InfoContext(ctx, "link do portal revogado", "obraId", obraID, "linkId", linkID)
+	return nil
+}
+
+// ObterVisao valida o token do link e monta a visão restrita da obra.
+func (s *Service) ObterVisao(ctx context.Context, token string) (*dto.PortalObraDTO, error) {
+	const op = "service.portal.ObterVisao"
+
+	agora := time.Now()
+	linkID, expiraEm, err := validarToken(s.segredo, token, agora)
+	if err != nil {
+		return nil, err
+	}
+	link, err := s.linkRepo.BuscarPorID(ctx, linkID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	// A expiração do token precisa ser a do registro, senão o token não foi emitido para este link
+	if !link.ExpiraEm.Equal(expiraEm) {
+		return nil, portal.ErrLinkInvalido
+	}
+	if err := link.VerificarAcesso(agora); err != nil {
+		return nil, err
+	}
+
+	obra, err := s.obraFinder.BuscarPorID(ctx, link.ObraID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	etapas, err := s.etapaFinder.ListarPorObraID(ctx, obra.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao listar etapas: %w", op, err)
+	}
+	diarios, err := s.diarioFinder.ListarPorPeriodo(ctx, obra.ID, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao listar diários: %w", op, err)
+	}
+	cronograma, err := s.cronogramaFinder.ListarPorObraID(ctx, obra.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao listar cronograma de recebimento: %w", op, err)
+	}
+	contas, err := s.contaFinder.ListarPorObraID(ctx, obra.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: falha ao listar contas a receber: %w", op, err)
+	}
+
+	if err := s.linkRepo.RegistrarAcesso(ctx, link.ID, agora); err != nil {
+		s.logger.WarnContext(ctx, "falha ao registrar acesso ao portal", "linkId", link.ID, "erro", err)
+	}
+
+	visao := montarVisao(obra, etapas, diarios, cronograma, contas, s.pix, agora)
+	visao.LinkExpiraEm = link.ExpiraEm
+	return visao, nil
+}
+
+func (s *Service) toLinkOutput(l *portal.LinkCompartilhamento, agora time.Time) *dto.LinkOutput {
+	token := assinarToken(s.segredo, l.ID, l.ExpiraEm)
+	saida := &dto.LinkOutput{
+		ID:             l.ID,
+		ObraID:         l.ObraID,
+		Descricao:      l.Descricao,
+		Token:          token,
+		CriadoPor:      l.CriadoPor,
+		ExpiraEm:       l.ExpiraEm,
+		RevogadoEm:     l.RevogadoEm,
+		Ativo:          l.VerificarAcesso(agora) == nil,
+		UltimoAcessoEm: l.UltimoAcessoEm,
+		TotalAcessos:   l.TotalAcessos,
+		CreatedAt:      l.CreatedAt,
+	}
+	if s.urlBase != "" {
+		saida.URL = strings.TrimRight(s.urlBase, "/") + "/" + token
+	}
+	return saida
+}
+
+// montarVisao monta o que o cliente pode ver: progresso das etapas, fotos dos diários
+// assinados e as parcelas a pagar. Custos, fornecedores e equipe ficam de fora.
+func montarVisao(obra *obras.Obra, etapas []*obras.Etapa, diarios []*obras.DiarioObra,
+	cronograma []*obras.CronogramaRecebimento, contas []*financeiro.ContaReceber, pix DadosPix, agora time.Time) *dto.PortalObraDTO {
+
+	visao := &dto.PortalObraDTO{
+		Obra: dto.ObraPortalDTO{
+			Nome:            obra.Nome,
+			Cliente:         obra.Cliente,
+			Endereco:        obra.Endereco,
+			Status:          string(obra.Status),
+			DataInicio:      obra.DataInicio,
+			DataFimPrevista: obra.DataFim,
+		},
+		Etapas:       make([]dto.EtapaPortalDTO, 0, len(etapas)),
+		Fotos:        make([]dto.FotoPortalDTO, 0),
+		Recebimentos: make([]dto.ParcelaPortalDTO, 0, len(cronograma)),
+		Resumo:       dto.ResumoPortalDTO{ValorContrato: obra.ValorContratoTotal},
+		GeradoEm:     agora,
+	}
+
+	nomesEtapas := make(map[string]string, len(etapas))
+	var somaPesos, somaExecutado float64
+	for _, e := range etapas {
+		nomesEtapas[e.ID] = e.Nome
+		somaPesos += e.Peso
+		somaExecutado += e.Peso * e.PercentualExecutado
+		visao.Etapas = append(visao.Etapas, dto.EtapaPortalDTO{
+			Nome:                e.Nome,
+			Status:              string(e.Status),
+			DataInicioPrevista:  e.DataInicioPrevista,
+			DataFimPrevista:     e.DataFimPrevista,
+			DataFimReal:         e.DataFimReal,
+			PercentualExecutado: e.PercentualExecutado,
+		})
+	}
+	if somaPesos > 0 {
+		visao.Obra.PercentualConcluido = somaExecutado / somaPesos
+	}
+
+	// Só diários assinados: o rascunho ainda pode mudar e não foi revisado pelo engenheiro
+	for i := len(diarios) - 1; i >= 0 && len(visao.Fotos) < maxFotosPortal; i-- {
+		d := diarios[i]
+		if !d.Assinado() {
+			continue
+		}
+		for _, f := range d.Fotos {
+			foto := dto.FotoPortalDTO{URL: f.URL, Legenda: f.Legenda, Data: d.Data}
+			if f.EtapaID != nil {
+				foto.Etapa = nomesEtapas[*f.EtapaID]
+			}
+			visao.Fotos = append(visao.Fotos, foto)
+		}
+	}
+	if len(visao.Fotos) > maxFotosPortal {
+		visao.Fotos = visao.Fotos[:maxFotosPortal]
+	}
+
+	contaPorParcela := make(map[string]*financeiro.ContaReceber, len(contas))
+	avulsas := make([]*financeiro.ContaReceber, 0)
+	for _, c := range contas {
+		if c.CronogramaRecebimentoID != nil {
+			contaPorParcela[*c.CronogramaRecebimentoID] = c
+		} else {
+			avulsas = append(avulsas, c)
+		}
+	}
+
+	for _, p := range cronograma {
+		conta := contaPorParcela[p.ID]
+		parcela := dto.ParcelaPortalDTO{
+			Numero:         p.NumeroEtapa,
+			Descricao:      p.DescricaoEtapa,
+			ValorPrevisto:  p.ValorPrevisto,
+			ValorPago:      p.ValorRecebido,
+			ValorRetido:    p.ValorRetido,
+			ValorEmAberto:  p.ValorSaldo(),
+			DataVencimento: p.DataVencimento,
+			DataPagamento:  p.DataRecebimento,
+		}
+		parcela.Situacao = situacaoParcela(p.Status, parcela.ValorEmAberto, p.DataVencimento, agora)
+		txid := p.ID
+		if conta != nil {
+			txid = conta.ID
+		}
+		parcela.Pagamento = dadosPagamento(parcela, conta, pix, txid)
+		visao.Recebimentos = append(visao.Recebimentos, parcela)
+	}
+
+	// Contas lançadas fora do cronograma (ex.: serviços extras) também são cobradas do cliente
+	sort.Slice(avulsas, func(i, j int) bool { return avulsas[i].DataVencimento.Before(avulsas[j].DataVencimento) })
+	for _, c := range avulsas {
+		parcela := dto.ParcelaPortalDTO{
+			Numero:         len(visao.Recebimentos) + 1,
+			Descricao:      c.Descricao,
+			ValorPrevisto:  c.ValorOriginal + c.ValorRetido, // Bruto, como nas parcelas do cronograma
+			ValorPago:      c.ValorRecebido,
+			ValorRetido:    c.ValorRetido,
+			ValorEmAberto:  c.ValorSaldo(),
+			DataVencimento: c.DataVencimento,
+			DataPagamento:  c.DataRecebimento,
+		}
+		parcela.Situacao = situacaoParcela(c.Status, parcela.ValorEmAberto, c.DataVencimento, agora)
+		parcela.Pagamento = dadosPagamento(parcela, c, pix, c.ID)
+		visao.Recebimentos = append(visao.Recebimentos, parcela)
+	}
+
+	for _, p := range visao.Recebimentos {
+		if p.Situacao == "CANCELADO" {
+			continue
+		}
+		visao.Resumo.TotalPago += p.ValorPago
+		visao.Resumo.TotalEmAberto += p.ValorEmAberto
+		if p.Situacao == "VENCIDO" {
+			visao.Resumo.TotalVencido += p.ValorEmAberto
+		}
+	}
+	return visao
+}
+
+// situacaoParcela traduz o status interno para o cliente. Os status de cronograma e
+// de conta a receber usam os mesmos valores.
+func situacaoParcela(status string, emAberto float64, vencimento, agora time.Time) string {
+	switch {
+	case status == obras.StatusRecebimentoCancelado:
+		return "CANCELADO"
+	case status == obras.StatusRecebimentoRecebido || emAberto <= 0:
+		return "PAGO"
+	case dataSemHora(vencimento).Before(dataSemHora(agora)):
+		return "VENCIDO"
+	case status == obras.StatusRecebimentoParcial:
+		return "PAGO_PARCIAL"
+	default:
+		return "EM_ABERTO"
+	}
+}
+
+// dadosPagamento devolve PIX e boleto para parcelas ainda a pagar.
+func dadosPagamento(parcela dto.ParcelaPortalDTO, conta *financeiro.ContaReceber, pix DadosPix, txid string) *dto.PagamentoPortalDTO {
+	if parcela.Situacao == "PAGO" || parcela.Situacao == "CANCELADO" {
+		return nil
+	}
+	pagamento := &dto.PagamentoPortalDTO{}
+	if pix.Chave != "" {
+		copiaECola := pixCopiaECola(pix, parcela.ValorEmAberto, txid)
+		pagamento.PixCopiaECola = &copiaECola
+		pagamento.ChavePix = &pix.Chave
+		pagamento.Beneficiario = &pix.Beneficiario
+	}
+	if conta != nil {
+		pagamento.LinhaDigitavel = conta.LinhaDigitavel
+		pagamento.URLBoleto = conta.URLBoleto
+	}
+	if pagamento.PixCopiaECola == nil && pagamento.LinhaDigitavel == nil && pagamento.URLBoleto == nil {
+		return nil
+	}
+	return pagamento
+}
+
+func dataSemHora(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
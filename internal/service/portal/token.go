@@ -0,0 +1,49 @@
+// file: internal/service/portal/token.go
+package portal
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luiszkm/masterCostrutora/internal/domain/portal"
+)
+
+// O token do link tem o formato <linkId>.<expiração unix>.<assinatura>, em que a
+// assinatura é um HMAC-SHA256 do ID e da expiração. Assim um token adulterado ou
+// vencido é recusado antes de qualquer consulta ao banco.
+
+func assinarToken(segredo []byte, linkID string, expiraEm time.Time) string {
+	corpo := linkID + "." + strconv.FormatInt(expiraEm.Unix(), 10)
+	return corpo + "." + assinatura(segredo, corpo)
+}
+
+// validarToken confere a assinatura e a expiração e devolve o ID do link.
+func validarToken(segredo []byte, token string, agora time.Time) (string, time.Time, error) {
+	partes := strings.Split(token, ".")
+	if len(partes) != 3 {
+		return "", time.Time{}, portal.ErrLinkInvalido
+	}
+	corpo := partes[0] + "." + partes[1]
+	if !hmac.Equal([]byte(partes[2]), []byte(assinatura(segredo, corpo))) {
+		return "", time.Time{}, portal.ErrLinkInvalido
+	}
+	exp, err := strconv.ParseInt(partes[1], 10, 64)
+	if err != nil {
+		return "", time.Time{}, portal.ErrLinkInvalido
+	}
+	expiraEm := time.Unix(exp, 0)
+	if !agora.Before(expiraEm) {
+		return "", time.Time{}, portal.ErrLinkExpirado
+	}
+	return partes[0], expiraEm, nil
+}
+
+func assinatura(segredo []byte, corpo string) string {
+	mac := hmac.New(sha256.New, segredo)
+	mac.Write([]byte("portal:" + corpo))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -8,6 +8,14 @@ import (
 
 const PermissoesContextKey = contextKey("permissoes")
 
+// UsuarioDoContexto retorna o usuário autenticado da requisição, ou "system".
+func UsuarioDoContexto(ctx context.Context) string {
+	if id, ok := ctx.Value(UserContextKey).(string); ok && id != "" {
+		return id
+	}
+	return "system"
+}
+
 func (s *JWTService) AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var tokenStr string
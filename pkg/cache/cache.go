@@ -0,0 +1,17 @@
+// file: pkg/cache/cache.go
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Backend é o armazenamento chave/valor usado pelos caches da aplicação.
+// Valores são opacos (normalmente JSON) e expiram após o TTL informado.
+type Backend interface {
+	// Obter retorna o valor e true quando a chave existe e não expirou.
+	Obter(ctx context.Context, chave string) ([]byte, bool, error)
+	Definir(ctx context.Context, chave string, valor []byte, ttl time.Duration) error
+	// RemoverPorPrefixo apaga todas as chaves iniciadas por prefixo.
+	RemoverPorPrefixo(ctx context.Context, prefixo string) error
+}
@@ -0,0 +1,64 @@
+// file: pkg/cache/memoria.go
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// limiteItensMemoria dispara a remoção de itens expirados quando ultrapassado.
+const limiteItensMemoria = 10000
+
+type itemMemoria struct {
+	valor    []byte
+	expiraEm time.Time
+}
+
+// Memoria é um Backend local ao processo. Adequado para uma única instância da API;
+// com várias réplicas use Redis para que as invalidações alcancem todas.
+type Memoria struct {
+	mu    sync.RWMutex
+	itens map[string]itemMemoria
+}
+
+func NovaMemoria() *Memoria {
+	return &Memoria{itens: make(map[string]itemMemoria)}
+}
+
+func (m *Memoria) Obter(_ context.Context, chave string) ([]byte, bool, error) {
+	m.mu.RLock()
+	item, ok := m.itens[chave]
+	m.mu.RUnlock()
+	if !ok || time.Now().After(item.expiraEm) {
+		return nil, false, nil
+	}
+	return item.valor, true, nil
+}
+
+func (m *Memoria) Definir(_ context.Context, chave string, valor []byte, ttl time.Duration) error {
+	agora := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.itens) >= limiteItensMemoria {
+		for k, item := range m.itens {
+			if agora.After(item.expiraEm) {
+				delete(m.itens, k)
+			}
+		}
+	}
+	m.itens[chave] = itemMemoria{valor: valor, expiraEm: agora.Add(ttl)}
+	return nil
+}
+
+func (m *Memoria) RemoverPorPrefixo(_ context.Context, prefixo string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k := range m.itens {
+		if strings.HasPrefix(k, prefixo) {
+			delete(m.itens, k)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+// file: pkg/cache/redis.go
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tamanhoPoolRedis = 8
+	timeoutRedis     = 2 * time.Second
+)
+
+// Redis é um Backend que fala o protocolo RESP2, compatível com Redis, KeyDB,
+// Valkey e Dragonfly. Usa apenas GET, SET PX, SCAN e DEL.
+type Redis struct {
+	endereco string
+	senha    string
+	db       int
+	pool     chan *conexaoRedis
+}
+
+type conexaoRedis struct {
+	net.Conn
+	leitor *bufio.Reader
+}
+
+// erroRedis é uma resposta de erro do servidor; a conexão continua utilizável.
+type erroRedis string
+
+func (e erroRedis) Error() string { return "redis: " + string(e) }
+
+// NovoRedis interpreta uma URL no formato redis://[:senha@]host:porta[/db].
+func NovoRedis(rawURL string) (*Redis, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "redis" || u.Host == "" {
+		return nil, fmt.Errorf("cache: URL do Redis inválida: %q", rawURL)
+	}
+	r := &Redis{endereco: u.Host, pool: make(chan *conexaoRedis, tamanhoPoolRedis)}
+	if !strings.Contains(u.Host, ":") {
+		r.endereco = u.Host + ":6379"
+	}
+	if u.User != nil {
+		r.senha, _ = u.User.Password()
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		if r.db, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("cache: banco do Redis inválido: %q", db)
+		}
+	}
+	return r, nil
+}
+
+func (r *Redis) Obter(ctx context.Context, chave string) ([]byte, bool, error) {
+	res, err := r.comando(ctx, "GET", chave)
+	if err != nil {
+		return nil, false, err
+	}
+	if res == nil {
+		return nil, false, nil
+	}
+	valor, ok := res.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: resposta inesperada para GET: %T", res)
+	}
+	return valor, true, nil
+}
+
+func (r *Redis) Definir(ctx context.Context, chave string, valor []byte, ttl time.Duration) error {
+	_, err := r.comando(ctx, "SET", chave, string(valor), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+func (r *Redis) RemoverPorPrefixo(ctx context.Context, prefixo string) error {
+	cursor := "0"
+	for {
+		res, err := r.comando(ctx, "SCAN", cursor, "MATCH", escaparPadraoRedis(prefixo)+"*", "COUNT", "200")
+		if err != nil {
+			return err
+		}
+		partes, ok := res.([]any)
+		if !ok || len(partes) != 2 {
+			return fmt.Errorf("redis: resposta inesperada para SCAN")
+		}
+		proximo, _ := partes[0].([]byte)
+		chaves, _ := partes[1].([]any)
+		if len(chaves) > 0 {
+			args := make([]string, 0, len(chaves)+1)
+			args = append(args, "DEL")
+			for _, c := range chaves {
+				if b, ok := c.([]byte); ok {
+					args = append(args, string(b))
+				}
+			}
+			if _, err := r.comando(ctx, args...); err != nil {
+				return err
+			}
+		}
+		cursor = string(proximo)
+		if cursor == "0" || cursor == "" {
+			return nil
+		}
+	}
+}
+
+func (r *Redis) comando(ctx context.Context, args ...string) (any, error) {
+	conn, err := r.obterConexao(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prazo := time.Now().Add(timeoutRedis)
+	if d, ok := ctx.Deadline(); ok && d.Before(prazo) {
+		prazo = d
+	}
+	_ = conn.SetDeadline(prazo)
+
+	res, err := executarRESP(conn, args)
+	var erroServidor erroRedis
+	if err != nil && !errors.As(err, &erroServidor) {
+		conn.Close()
+		return nil, err
+	}
+	r.devolverConexao(conn)
+	return res, err
+}
+
+func (r *Redis) obterConexao(ctx context.Context) (*conexaoRedis, error) {
+	select {
+	case c := <-r.pool:
+		return c, nil
+	default:
+	}
+
+	d := net.Dialer{Timeout: timeoutRedis}
+	nc, err := d.DialContext(ctx, "tcp", r.endereco)
+	if err != nil {
+		return nil, fmt.Errorf("redis: conexão: %w", err)
+	}
+	c := &conexaoRedis{Conn: nc, leitor: bufio.NewReader(nc)}
+	_ = c.SetDeadline(time.Now().Add(timeoutRedis))
+	if r.senha != "" {
+		if _, err := executarRESP(c, []string{"AUTH", r.senha}); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	if r.db != 0 {
+		if _, err := executarRESP(c, []string{"SELECT", strconv.Itoa(r.db)}); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (r *Redis) devolverConexao(c *conexaoRedis) {
+	select {
+	case r.pool <- c:
+	default:
+		c.Close()
+	}
+}
+
+func executarRESP(c *conexaoRedis, args []string) (any, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(a), a)
+	}
+	if _, err := io.WriteString(c, b.String()); err != nil {
+		return nil, err
+	}
+	return lerRESP(c.leitor)
+}
+
+func lerRESP(r *bufio.Reader) (any, error) {
+	linha, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(linha) < 3 {
+		return nil, fmt.Errorf("redis: resposta malformada")
+	}
+	tipo, conteudo := linha[0], strings.TrimSuffix(linha[1:], "\r\n")
+
+	switch tipo {
+	case '+':
+		return conteudo, nil
+	case '-':
+		return nil, erroRedis(conteudo)
+	case ':':
+		return strconv.ParseInt(conteudo, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(conteudo)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(conteudo)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		// Um erro do servidor dentro do array (ex.: EXEC) não interrompe a leitura:
+		// os demais elementos precisam ser consumidos para a conexão voltar ao pool
+		// alinhada com a próxima resposta.
+		itens := make([]any, n)
+		var erroServidor error
+		for i := range itens {
+			item, err := lerRESP(r)
+			var errRedis erroRedis
+			switch {
+			case errors.As(err, &errRedis):
+				if erroServidor == nil {
+					erroServidor = err
+				}
+			case err != nil:
+				return nil, err
+			}
+			itens[i] = item
+		}
+		if erroServidor != nil {
+			return nil, erroServidor
+		}
+		return itens, nil
+	default:
+		return nil, fmt.Errorf("redis: tipo de resposta desconhecido %q", tipo)
+	}
+}
+
+// escaparPadraoRedis escapa os caracteres especiais do glob usado por SCAN MATCH.
+func escaparPadraoRedis(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+	return r.Replace(s)
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLerRESP(t *testing.T) {
+	casos := []struct {
+		nome         string
+		entrada      string
+		esperado     any
+		erroServidor bool
+		erro         bool
+	}{
+		{nome: "simple string", entrada: "+OK\r\n", esperado: "OK"},
+		{nome: "inteiro", entrada: ":42\r\n", esperado: int64(42)},
+		{nome: "bulk string", entrada: "$5\r\nvalor\r\n", esperado: []byte("valor")},
+		{nome: "bulk nulo", entrada: "$-1\r\n", esperado: nil},
+		{nome: "array nulo", entrada: "*-1\r\n", esperado: nil},
+		{
+			nome:     "array aninhado",
+			entrada:  "*2\r\n$1\r\n0\r\n*2\r\n$1\r\na\r\n$1\r\nb\r\n",
+			esperado: []any{[]byte("0"), []any{[]byte("a"), []byte("b")}},
+		},
+		{nome: "erro do servidor", entrada: "-ERR falhou\r\n", erroServidor: true},
+		{
+			nome:         "erro do servidor dentro do array",
+			entrada:      "*3\r\n+OK\r\n-WRONGTYPE tipo errado\r\n:1\r\n",
+			erroServidor: true,
+		},
+		{
+			nome:         "erro do servidor em array aninhado",
+			entrada:      "*2\r\n*2\r\n-ERR a\r\n-ERR b\r\n$2\r\nok\r\n",
+			erroServidor: true,
+		},
+		{nome: "tipo desconhecido", entrada: "?x\r\n", erro: true},
+		{nome: "linha malformada", entrada: "+\n", erro: true},
+		{nome: "bulk truncado", entrada: "$10\r\ncurto\r\n", erro: true},
+		{nome: "array truncado", entrada: "*2\r\n+OK\r\n", erro: true},
+	}
+
+	// Depois de cada resposta vem outra; se a leitura parar no meio, ela sai desalinhada.
+	const proxima = "+PROXIMA\r\n"
+
+	for _, tc := range casos {
+		t.Run(tc.nome, func(t *testing.T) {
+			entrada := tc.entrada
+			if !tc.erro {
+				entrada += proxima
+			}
+			r := bufio.NewReader(strings.NewReader(entrada))
+			got, err := lerRESP(r)
+
+			var errRedis erroRedis
+			switch {
+			case tc.erroServidor:
+				if !errors.As(err, &errRedis) {
+					t.Fatalf("esperado erro do servidor, recebido %v", err)
+				}
+			case tc.erro:
+				if err == nil || errors.As(err, &errRedis) {
+					t.Fatalf("esperado erro de protocolo, recebido %v", err)
+				}
+				return
+			default:
+				if err != nil {
+					t.Fatalf("erro inesperado: %v", err)
+				}
+				if !reflect.DeepEqual(got, tc.esperado) {
+					t.Fatalf("lerRESP() = %#v, esperado %#v", got, tc.esperado)
+				}
+			}
+
+			seguinte, err := lerRESP(r)
+			if err != nil || seguinte != "PROXIMA" {
+				t.Fatalf("leitura desalinhada: próxima resposta = %#v, erro %v", seguinte, err)
+			}
+			if _, err := r.ReadByte(); err != io.EOF {
+				t.Fatalf("sobraram bytes no leitor")
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// file: pkg/metrics/eventbus.go
+package metrics
+
+import "time"
+
+// EventBusMetrics implementa o observador do EventBus (bus.Observador).
+type EventBusMetrics struct {
+	publicados *CounterVec
+	tratados   *CounterVec
+	falhas     *CounterVec
+	duracao    *HistogramVec
+}
+
+func NovoEventBusMetrics(r *Registro) *EventBusMetrics {
+	return &EventBusMetrics{
+		publicados: r.NovoCounterVec("eventbus_events_published_total",
+			"Eventos publicados no EventBus.", "event"),
+		tratados: r.NovoCounterVec("eventbus_events_handled_total",
+			"Execuções de handlers concluídas.", "event"),
+		falhas: r.NovoCounterVec("eventbus_events_failed_total",
+			"Execuções de handlers que falharam (panic).", "event"),
+		duracao: r.NovoHistogramVec("eventbus_handler_duration_seconds",
+			"Duração da execução dos handlers por evento.", nil, "event"),
+	}
+}
+
+func (m *EventBusMetrics) EventoPublicado(nome string) {
+	m.publicados.Inc(nome)
+}
+
+func (m *EventBusMetrics) EventoProcessado(nome string, duracao time.Duration, falhou bool) {
+	m.duracao.Observe(duracao.Seconds(), nome)
+	if falhou {
+		m.falhas.Inc(nome)
+		return
+	}
+	m.tratados.Inc(nome)
+}
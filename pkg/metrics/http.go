@@ -0,0 +1,51 @@
+// file: pkg/metrics/http.go
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// HTTPMetrics mede latência e status das requisições agrupando pelo padrão de rota
+// do chi (ex.: /obras/{obraId}), evitando uma série por ID.
+type HTTPMetrics struct {
+	duracao     *HistogramVec
+	requisicoes *CounterVec
+}
+
+func NovoHTTPMetrics(r *Registro) *HTTPMetrics {
+	return &HTTPMetrics{
+		duracao: r.NovoHistogramVec("http_request_duration_seconds",
+			"Latência das requisições HTTP por rota.", nil, "method", "route"),
+		requisicoes: r.NovoCounterVec("http_requests_total",
+			"Total de requisições HTTP por rota e status.", "method", "route", "status"),
+	}
+}
+
+// Middleware deve ser registrado no router raiz para que o padrão de rota esteja completo ao final.
+func (m *HTTPMetrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inicio := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		rota := "desconhecida"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			if p := rctx.RoutePattern(); p != "" {
+				rota = p
+			}
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		m.duracao.Observe(time.Since(inicio).Seconds(), r.Method, rota)
+		m.requisicoes.Inc(r.Method, rota, strconv.Itoa(status))
+	})
+}
@@ -0,0 +1,254 @@
+// file: pkg/metrics/metrics.go
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// BucketsPadrao são os limites (em segundos) usados nos histogramas de latência.
+var BucketsPadrao = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// coletor é qualquer métrica capaz de se escrever no formato texto do Prometheus.
+type coletor interface {
+	escrever(w *bufio.Writer)
+}
+
+// Registro agrupa as métricas expostas em /metrics.
+type Registro struct {
+	mu        sync.RWMutex
+	coletores []coletor
+}
+
+func NovoRegistro() *Registro {
+	return &Registro{}
+}
+
+func (r *Registro) registrar(c coletor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.coletores = append(r.coletores, c)
+}
+
+// Handler expõe as métricas no formato de texto do Prometheus (versão 0.0.4).
+func (r *Registro) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		bw := bufio.NewWriter(w)
+		r.mu.RLock()
+		for _, c := range r.coletores {
+			c.escrever(bw)
+		}
+		r.mu.RUnlock()
+		_ = bw.Flush()
+	})
+}
+
+// --- Counter ---
+
+// CounterVec é um contador monotônico particionado por labels.
+type CounterVec struct {
+	nome, ajuda string
+	labels      []string
+	mu          sync.Mutex
+	valores     map[string]*serieCounter
+}
+
+type serieCounter struct {
+	labels []string
+	valor  float64
+}
+
+// NovoCounterVec cria e registra um contador.
+func (r *Registro) NovoCounterVec(nome, ajuda string, labels ...string) *CounterVec {
+	c := &CounterVec{nome: nome, ajuda: ajuda, labels: labels, valores: make(map[string]*serieCounter)}
+	r.registrar(c)
+	return c
+}
+
+// Inc incrementa em 1 a série identificada pelos valores dos labels.
+func (c *CounterVec) Inc(valoresLabels ...string) {
+	c.Add(1, valoresLabels...)
+}
+
+// Add soma v (não negativo) à série identificada pelos valores dos labels.
+func (c *CounterVec) Add(v float64, valoresLabels ...string) {
+	if v < 0 {
+		return
+	}
+	chave := strings.Join(valoresLabels, "\xff")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.valores[chave]
+	if !ok {
+		s = &serieCounter{labels: append([]string(nil), valoresLabels...)}
+		c.valores[chave] = s
+	}
+	s.valor += v
+}
+
+func (c *CounterVec) escrever(w *bufio.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	escreverCabecalho(w, c.nome, c.ajuda, "counter")
+	for _, chave := range chavesOrdenadas(c.valores) {
+		s := c.valores[chave]
+		fmt.Fprintf(w, "%s%s %s\n", c.nome, formatarLabels(c.labels, s.labels), formatarValor(s.valor))
+	}
+}
+
+// --- Histogram ---
+
+// HistogramVec acumula observações em buckets cumulativos, particionado por labels.
+type HistogramVec struct {
+	nome, ajuda string
+	labels      []string
+	buckets     []float64
+	mu          sync.Mutex
+	series      map[string]*serieHistogram
+}
+
+type serieHistogram struct {
+	labels     []string
+	contagens  []uint64
+	soma       float64
+	quantidade uint64
+}
+
+// NovoHistogramVec cria e registra um histograma. Se buckets for nil, usa BucketsPadrao.
+func (r *Registro) NovoHistogramVec(nome, ajuda string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = BucketsPadrao
+	}
+	b := append([]float64(nil), buckets...)
+	sort.Float64s(b)
+	h := &HistogramVec{nome: nome, ajuda: ajuda, labels: labels, buckets: b, series: make(map[string]*serieHistogram)}
+	r.registrar(h)
+	return h
+}
+
+// Observe registra um valor (em segundos, para latências) na série indicada.
+func (h *HistogramVec) Observe(v float64, valoresLabels ...string) {
+	chave := strings.Join(valoresLabels, "\xff")
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.series[chave]
+	if !ok {
+		s = &serieHistogram{labels: append([]string(nil), valoresLabels...), contagens: make([]uint64, len(h.buckets))}
+		h.series[chave] = s
+	}
+	for i, limite := range h.buckets {
+		if v <= limite {
+			s.contagens[i]++
+		}
+	}
+	s.soma += v
+	s.quantidade++
+}
+
+func (h *HistogramVec) escrever(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	escreverCabecalho(w, h.nome, h.ajuda, "histogram")
+	nomesLe := append(append([]string(nil), h.labels...), "le")
+	for _, chave := range chavesOrdenadas(h.series) {
+		s := h.series[chave]
+		for i, limite := range h.buckets {
+			valores := append(append([]string(nil), s.labels...), formatarValor(limite))
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.nome, formatarLabels(nomesLe, valores), s.contagens[i])
+		}
+		valores := append(append([]string(nil), s.labels...), "+Inf")
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.nome, formatarLabels(nomesLe, valores), s.quantidade)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.nome, formatarLabels(h.labels, s.labels), formatarValor(s.soma))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.nome, formatarLabels(h.labels, s.labels), s.quantidade)
+	}
+}
+
+// --- Gauge calculado na coleta ---
+
+// Amostra é um valor pontual com os valores de seus labels.
+type Amostra struct {
+	Labels []string
+	Valor  float64
+}
+
+type gaugeFunc struct {
+	nome, ajuda string
+	tipo        string
+	labels      []string
+	fn          func() []Amostra
+}
+
+// NovoGaugeFunc registra um gauge cujo valor é lido no momento da coleta.
+func (r *Registro) NovoGaugeFunc(nome, ajuda string, fn func() []Amostra, labels ...string) {
+	r.registrar(&gaugeFunc{nome: nome, ajuda: ajuda, tipo: "gauge", labels: labels, fn: fn})
+}
+
+// NovoCounterFunc registra um contador mantido por outro componente (ex.: estatísticas do pgxpool).
+func (r *Registro) NovoCounterFunc(nome, ajuda string, fn func() []Amostra, labels ...string) {
+	r.registrar(&gaugeFunc{nome: nome, ajuda: ajuda, tipo: "counter", labels: labels, fn: fn})
+}
+
+func (g *gaugeFunc) escrever(w *bufio.Writer) {
+	escreverCabecalho(w, g.nome, g.ajuda, g.tipo)
+	for _, a := range g.fn() {
+		fmt.Fprintf(w, "%s%s %s\n", g.nome, formatarLabels(g.labels, a.Labels), formatarValor(a.Valor))
+	}
+}
+
+// --- Formatação ---
+
+var escapeValor = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+var escapeAjuda = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escreverCabecalho(w *bufio.Writer, nome, ajuda, tipo string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", nome, escapeAjuda.Replace(ajuda), nome, tipo)
+}
+
+func formatarLabels(nomes, valores []string) string {
+	if len(nomes) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, nome := range nomes {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		v := ""
+		if i < len(valores) {
+			v = valores[i]
+		}
+		sb.WriteString(nome)
+		sb.WriteString(`="`)
+		sb.WriteString(escapeValor.Replace(v))
+		sb.WriteByte('"')
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+func formatarValor(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func chavesOrdenadas[T any](m map[string]T) []string {
+	chaves := make([]string, 0, len(m))
+	for k := range m {
+		chaves = append(chaves, k)
+	}
+	sort.Strings(chaves)
+	return chaves
+}
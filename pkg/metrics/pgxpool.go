@@ -0,0 +1,37 @@
+// file: pkg/metrics/pgxpool.go
+package metrics
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+// RegistrarPgxPool expõe as estatísticas do pool de conexões do PostgreSQL.
+func RegistrarPgxPool(r *Registro, pool *pgxpool.Pool) {
+	gauge := func(nome, ajuda string, valor func(s *pgxpool.Stat) float64) {
+		r.NovoGaugeFunc(nome, ajuda, func() []Amostra {
+			return []Amostra{{Valor: valor(pool.Stat())}}
+		})
+	}
+	counter := func(nome, ajuda string, valor func(s *pgxpool.Stat) float64) {
+		r.NovoCounterFunc(nome, ajuda, func() []Amostra {
+			return []Amostra{{Valor: valor(pool.Stat())}}
+		})
+	}
+
+	gauge("pgxpool_total_conns", "Conexões abertas no pool.",
+		func(s *pgxpool.Stat) float64 { return float64(s.TotalConns()) })
+	gauge("pgxpool_acquired_conns", "Conexões em uso.",
+		func(s *pgxpool.Stat) float64 { return float64(s.AcquiredConns()) })
+	gauge("pgxpool_idle_conns", "Conexões ociosas.",
+		func(s *pgxpool.Stat) float64 { return float64(s.IdleConns()) })
+	gauge("pgxpool_constructing_conns", "Conexões sendo estabelecidas.",
+		func(s *pgxpool.Stat) float64 { return float64(s.ConstructingConns()) })
+	gauge("pgxpool_max_conns", "Tamanho máximo do pool.",
+		func(s *pgxpool.Stat) float64 { return float64(s.MaxConns()) })
+	counter("pgxpool_acquire_total", "Total de aquisições de conexão.",
+		func(s *pgxpool.Stat) float64 { return float64(s.AcquireCount()) })
+	counter("pgxpool_acquire_duration_seconds_total", "Tempo total gasto aguardando conexões.",
+		func(s *pgxpool.Stat) float64 { return s.AcquireDuration().Seconds() })
+	counter("pgxpool_empty_acquire_total", "Aquisições que precisaram esperar por pool vazio.",
+		func(s *pgxpool.Stat) float64 { return float64(s.EmptyAcquireCount()) })
+	counter("pgxpool_canceled_acquire_total", "Aquisições canceladas pelo contexto.",
+		func(s *pgxpool.Stat) float64 { return float64(s.CanceledAcquireCount()) })
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/luiszkm/masterCostrutora/pkg/auth"
 	"github.com/luiszkm/masterCostrutora/pkg/logging"
+	"github.com/luiszkm/masterCostrutora/pkg/tracing"
 )
 
 // contextKey é o tipo para chaves do contexto
@@ -52,8 +53,11 @@ func (w *responseWriter) Write(data []byte) (int, error) {
 func RequestLogger(logger *logging.AppLogger, jwtService *auth.JWTService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Gerar Request ID único
+			// Gerar Request ID único; com tracing ativo, reutiliza o trace-id para correlação
 			requestID := uuid.New().String()
+			if sc := tracing.SpanContextDoContexto(r.Context()); sc.Valido() {
+				requestID = sc.TraceID.String()
+			}
 
 			// Adicionar informações ao contexto
 			ctx := context.WithValue(r.Context(), RequestIDKey, requestID)
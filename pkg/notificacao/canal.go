@@ -0,0 +1,24 @@
+// file: pkg/notificacao/canal.go
+package notificacao
+
+import (
+	"context"
+	"time"
+)
+
+// timeoutEnvio limita cada tentativa de entrega em um canal externo.
+const timeoutEnvio = 10 * time.Second
+
+// Mensagem é o conteúdo entregue por um canal. Destino depende do canal:
+// endereço de e-mail, telefone em formato internacional ou URL do webhook.
+type Mensagem struct {
+	Destino string
+	Assunto string
+	Texto   string
+	Dados   any // Enviado como JSON pelos canais que aceitam dados estruturados
+}
+
+// Canal entrega mensagens fora da aplicação (e-mail, WhatsApp/SMS, webhook).
+type Canal interface {
+	Enviar(ctx context.Context, m Mensagem) error
+}
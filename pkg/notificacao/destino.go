@@ -0,0 +1,91 @@
+// file: pkg/notificacao/destino.go
+package notificacao
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrDestinoBloqueado indica um destino na rede interna (loopback, privada,
+// link-local, metadados da nuvem), que não pode receber mensagens de URLs
+// informadas pelos usuários.
+var ErrDestinoBloqueado = errors.New("notificacao: destino em rede interna não permitido")
+
+// redesBloqueadas complementa os intervalos reconhecidos por netip.Addr.
+var redesBloqueadas = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "Esta rede"
+	netip.MustParsePrefix("100.64.0.0/10"), // CGNAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // Atribuições de protocolo IETF
+	netip.MustParsePrefix("198.18.0.0/15"), // Testes de desempenho
+	netip.MustParsePrefix("240.0.0.0/4"),   // Reservado, inclui broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, pode embutir endereços IPv4 internos
+}
+
+// EnderecoBloqueado informa se o IP pertence a uma rede interna ou reservada.
+// 169.254.169.254 (metadados da nuvem) é link-local e também é bloqueado.
+func EnderecoBloqueado(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+	for _, rede := range redesBloqueadas {
+		if rede.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidarDestinoPublico recusa URLs cujo host é um IP interno ou localhost. Nomes
+// que resolvem para a rede interna são barrados na conexão, pelo cliente de ClientePublico.
+func ValidarDestinoPublico(rawURL string) error {
+	if err := validarURL(rawURL); err != nil {
+		return err
+	}
+	u, _ := url.Parse(rawURL)
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrDestinoBloqueado
+	}
+	if ip, err := netip.ParseAddr(host); err == nil && EnderecoBloqueado(ip) {
+		return ErrDestinoBloqueado
+	}
+	return nil
+}
+
+// ClientePublico retorna um cliente HTTP que confere o IP resolvido no momento da
+// conexão, inclusive após redirecionamentos, e não usa proxy do ambiente. Serve
+// para qualquer envio a URLs informadas por usuários ou administradores.
+func ClientePublico() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeoutEnvio,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrDestinoBloqueado, address)
+			}
+			ip, err := netip.ParseAddr(host)
+			if err != nil || EnderecoBloqueado(ip) {
+				return fmt.Errorf("%w: %s", ErrDestinoBloqueado, host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeoutEnvio,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConns:        20,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package notificacao
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+)
+
+func TestEnderecoBloqueado(t *testing.T) {
+	casos := []struct {
+		ip        string
+		bloqueado bool
+	}{
+		{"127.0.0.1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.10", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true}, // Metadados da nuvem
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"255.255.255.255", true},
+		{"::1", true},
+		{"fd00:ec2::254", true},
+		{"fe80::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"64:ff9b::a00:1", true},
+		{"8.8.8.8", false},
+		{"200.160.2.3", false},
+		{"2001:4860:4860::8888", false},
+	}
+	for _, c := range casos {
+		t.Run(c.ip, func(t *testing.T) {
+			if got := EnderecoBloqueado(netip.MustParseAddr(c.ip)); got != c.bloqueado {
+				t.Errorf("EnderecoBloqueado(%s) = %v, esperado %v", c.ip, got, c.bloqueado)
+			}
+		})
+	}
+}
+
+func TestValidarDestinoPublico(t *testing.T) {
+	casos := []struct {
+		url       string
+		bloqueado bool
+		invalida  bool
+	}{
+		{url: "https://hooks.slack.com/services/x", bloqueado: false},
+		{url: "http://localhost:8080/hook", bloqueado: true},
+		{url: "http://api.localhost/hook", bloqueado: true},
+		{url: "http://127.0.0.1/hook", bloqueado: true},
+		{url: "http://[::1]:9000/", bloqueado: true},
+		{url: "http://169.254.169.254/latest/meta-data/", bloqueado: true},
+		{url: "ftp://exemplo.com/arquivo", invalida: true},
+		{url: "/relativa", invalida: true},
+	}
+	for _, c := range casos {
+		t.Run(c.url, func(t *testing.T) {
+			err := ValidarDestinoPublico(c.url)
+			switch {
+			case c.bloqueado && !errors.Is(err, ErrDestinoBloqueado):
+				t.Errorf("esperava ErrDestinoBloqueado, obteve %v", err)
+			case c.invalida && (err == nil || errors.Is(err, ErrDestinoBloqueado)):
+				t.Errorf("esperava URL inválida, obteve %v", err)
+			case !c.bloqueado && !c.invalida && err != nil:
+				t.Errorf("esperava URL aceita, obteve %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookRecusaRedeInternaNaConexao(t *testing.T) {
+	var chamadas atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		chamadas.Add(1)
+	}))
+	defer srv.Close()
+
+	// O cliente é usado diretamente para simular um nome público que resolve para 127.0.0.1
+	err := postarJSON(context.Background(), ClientePublico(), srv.URL, "", map[string]string{"text": "oi"})
+	if !errors.Is(err, ErrDestinoBloqueado) {
+		t.Fatalf("esperava ErrDestinoBloqueado, obteve %v", err)
+	}
+	if chamadas.Load() != 0 {
+		t.Fatal("o servidor interno não deveria ter recebido a requisição")
+	}
+}
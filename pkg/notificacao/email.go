@@ -0,0 +1,71 @@
+// file: pkg/notificacao/email.go
+package notificacao
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Email entrega mensagens por SMTP com STARTTLS quando o servidor oferece.
+type Email struct {
+	endereco  string
+	auth      smtp.Auth
+	remetente string
+}
+
+// NovoEmail interpreta uma URL no formato smtp://[usuario:senha@]host:porta.
+func NovoEmail(rawURL, remetente string) (*Email, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "smtp" || u.Host == "" {
+		return nil, fmt.Errorf("notificacao: URL do SMTP inválida: %q", rawURL)
+	}
+	if remetente == "" {
+		return nil, fmt.Errorf("notificacao: remetente do e-mail é obrigatório")
+	}
+	e := &Email{endereco: u.Host, remetente: remetente}
+	if !strings.Contains(u.Host, ":") {
+		e.endereco = u.Host + ":587"
+	}
+	if u.User != nil {
+		senha, _ := u.User.Password()
+		host, _, _ := net.SplitHostPort(e.endereco)
+		e.auth = smtp.PlainAuth("", u.User.Username(), senha, host)
+	}
+	return e, nil
+}
+
+func (e *Email) Enviar(ctx context.Context, m Mensagem) error {
+	corpo := strings.Join([]string{
+		"From: " + e.remetente,
+		"To: " + m.Destino,
+		"Subject: " + mime.QEncoding.Encode("utf-8", m.Assunto),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"",
+		m.Texto,
+	}, "\r\n")
+
+	// net/smtp não aceita contexto; o envio roda à parte para respeitar o cancelamento.
+	ctx, cancel := context.WithTimeout(ctx, timeoutEnvio)
+	defer cancel()
+	erro := make(chan error, 1)
+	go func() {
+		erro <- smtp.SendMail(e.endereco, e.auth, e.remetente, []string{m.Destino}, []byte(corpo))
+	}()
+	select {
+	case err := <-erro:
+		if err != nil {
+			return fmt.Errorf("notificacao: falha no envio de e-mail: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("notificacao: envio de e-mail interrompido: %w", ctx.Err())
+	}
+}
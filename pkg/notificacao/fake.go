@@ -0,0 +1,42 @@
+// file: pkg/notificacao/fake.go
+package notificacao
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// limiteFake é o número de mensagens guardadas por um Fake; as mais antigas são descartadas.
+const limiteFake = 100
+
+// Fake é um Canal local para desenvolvimento e testes: não envia nada, apenas
+// registra a mensagem no log e a guarda em memória.
+type Fake struct {
+	logger   *slog.Logger
+	mu       sync.Mutex
+	enviadas []Mensagem
+}
+
+func NovoFake(nome string, logger *slog.Logger) *Fake {
+	return &Fake{logger: logger.With("component", "CanalFake", "canal", nome)}
+}
+
+func (f *Fake) Enviar(ctx context.Context, m Mensagem) error {
+	f.logger.InfoContext(ctx, "notificação entregue (fake)", "destino", m.Destino, "assunto", m.Assunto)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.enviadas = append(f.enviadas, m)
+	if len(f.enviadas) > limiteFake {
+		f.enviadas = f.enviadas[len(f.enviadas)-limiteFake:]
+	}
+	return nil
+}
+
+// Enviadas retorna uma cópia das mensagens recebidas, da mais antiga para a mais recente.
+func (f *Fake) Enviadas() []Mensagem {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Mensagem(nil), f.enviadas...)
+}
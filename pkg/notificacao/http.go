@@ -0,0 +1,92 @@
+// file: pkg/notificacao/http.go
+package notificacao
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Gateway entrega mensagens de texto por um gateway HTTP de WhatsApp/SMS. O corpo
+// enviado é {"destino", "mensagem"}; o token, quando informado, vai como Bearer.
+type Gateway struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NovoGateway(rawURL, token string) (*Gateway, error) {
+	if err := validarURL(rawURL); err != nil {
+		return nil, fmt.Errorf("notificacao: URL do gateway inválida: %q", rawURL)
+	}
+	return &Gateway{url: rawURL, token: token, client: &http.Client{Timeout: timeoutEnvio}}, nil
+}
+
+func (g *Gateway) Enviar(ctx context.Context, m Mensagem) error {
+	corpo := map[string]string{"destino": m.Destino, "mensagem": m.Assunto + "\n" + m.Texto}
+	return postarJSON(ctx, g.client, g.url, g.token, corpo)
+}
+
+// Webhook entrega a mensagem como JSON para a URL do destinatário. O campo "text"
+// segue o formato dos webhooks de entrada de ferramentas de chat. Como a URL é
+// informada pelo usuário, destinos na rede interna são recusados.
+type Webhook struct {
+	client *http.Client
+}
+
+func NovoWebhook() *Webhook {
+	return &Webhook{client: ClientePublico()}
+}
+
+func (w *Webhook) Enviar(ctx context.Context, m Mensagem) error {
+	if err := ValidarDestinoPublico(m.Destino); err != nil {
+		if errors.Is(err, ErrDestinoBloqueado) {
+			return err
+		}
+		return fmt.Errorf("notificacao: URL do webhook inválida: %q", m.Destino)
+	}
+	corpo := map[string]any{"text": m.Assunto + "\n" + m.Texto, "dados": m.Dados}
+	return postarJSON(ctx, w.client, m.Destino, "", corpo)
+}
+
+// validarURL verifica se a URL é absoluta e usa http ou https.
+func validarURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("url deve ser absoluta e usar http ou https")
+	}
+	return nil
+}
+
+func postarJSON(ctx context.Context, client *http.Client, destino, token string, corpo any) error {
+	payload, err := json.Marshal(corpo)
+	if err != nil {
+		return fmt.Errorf("notificacao: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, destino, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("notificacao: requisição inválida: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "MasterConstrutora-Notificacoes/1.0")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notificacao: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notificacao: destino respondeu HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+// Package pdf gera documentos PDF simples, só de texto, sem dependências externas.
+// Usa as fontes padrão Helvetica (regular e negrito) em páginas A4, com quebra de
+// linha e de página automáticas e numeração no rodapé.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	larguraPagina = 595.0 // A4 em pontos
+	alturaPagina  = 842.0
+	margem        = 50.0
+	larguraUtil   = larguraPagina - 2*margem
+
+	// larguraMediaCaractere é a largura média de um caractere Helvetica em
+	// proporção ao tamanho da fonte, usada para estimar a quebra de linha.
+	larguraMediaCaractere = 0.52
+)
+
+type fonte string
+
+const (
+	fonteRegular fonte = "F1"
+	fonteNegrito fonte = "F2"
+)
+
+type linha struct {
+	texto   string
+	fonte   fonte
+	tamanho float64
+	x, y    float64
+}
+
+// Documento acumula o texto página a página. O conteúdo só é serializado em Bytes.
+type Documento struct {
+	paginas [][]linha
+	y       float64
+}
+
+func NovoDocumento() *Documento {
+	d := &Documento{}
+	d.NovaPagina()
+	return d
+}
+
+// NovaPagina força o início de uma nova página.
+func (d *Documento) NovaPagina() {
+	d.paginas = append(d.paginas, nil)
+	d.y = alturaPagina - margem
+}
+
+// Titulo escreve um título em negrito, 14pt.
+func (d *Documento) Titulo(texto string) {
+	d.escrever(texto, fonteNegrito, 14, 0)
+}
+
+// Subtitulo escreve um subtítulo em negrito, 11pt.
+func (d *Documento) Subtitulo(texto string) {
+	d.escrever(texto, fonteNegrito, 11, 0)
+}
+
+// Paragrafo escreve texto corrido, 10pt, quebrando as linhas na largura da página.
+func (d *Documento) Paragrafo(texto string) {
+	d.escrever(texto, fonteRegular, 10, 0)
+}
+
+// Item escreve um item de lista com marcador e recuo.
+func (d *Documento) Item(texto string) {
+	d.escrever("- "+texto, fonteRegular, 10, 12)
+}
+
+// Espaco deixa uma linha em branco.
+func (d *Documento) Espaco() {
+	d.y -= 8
+}
+
+func (d *Documento) escrever(texto string, f fonte, tamanho, recuo float64) {
+	altura := tamanho * 1.4
+	maxCaracteres := int((larguraUtil - recuo) / (tamanho * larguraMediaCaractere))
+	for _, paragrafo := range strings.Split(texto, "\n") {
+		for _, l := range quebrarLinhas(paragrafo, maxCaracteres) {
+			if d.y-altura < margem+20 {
+				d.NovaPagina()
+			}
+			d.y -= altura
+			pagina := len(d.paginas) - 1
+			d.paginas[pagina] = append(d.paginas[pagina], linha{texto: l, fonte: f, tamanho: tamanho, x: margem + recuo, y: d.y})
+		}
+	}
+}
+
+// quebrarLinhas divide o texto em linhas de até limite caracteres, cortando nas
+// palavras; palavras maiores que a linha são cortadas no meio.
+func quebrarLinhas(texto string, limite int) []string {
+	palavras := strings.Fields(texto)
+	if len(palavras) == 0 {
+		return []string{""}
+	}
+	var linhas []string
+	atual := ""
+	for _, p := range palavras {
+		for len([]rune(p)) > limite {
+			if atual != "" {
+				linhas = append(linhas, atual)
+				atual = ""
+			}
+			r := []rune(p)
+			linhas = append(linhas, string(r[:limite]))
+			p = string(r[limite:])
+		}
+		switch {
+		case atual == "":
+			atual = p
+		case len([]rune(atual))+1+len([]rune(p)) <= limite:
+			atual += " " + p
+		default:
+			linhas = append(linhas, atual)
+			atual = p
+		}
+	}
+	return append(linhas, atual)
+}
+
+// Bytes serializa o documento em PDF 1.4.
+func (d *Documento) Bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+	objeto := func(conteudo string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), conteudo)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1: catálogo, 2: árvore de páginas, 3 e 4: fontes; depois, página e conteúdo de cada página.
+	total := len(d.paginas)
+	kids := make([]string, total)
+	for i := range d.paginas {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	objeto("<< /Type /Catalog /Pages 2 0 R >>")
+	objeto(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), total))
+	objeto("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	objeto("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, linhas := range d.paginas {
+		var conteudo bytes.Buffer
+		rodape := linha{texto: fmt.Sprintf("Página %d de %d", i+1, total), fonte: fonteRegular, tamanho: 8, x: margem, y: margem - 20}
+		for _, l := range append(linhas, rodape) {
+			fmt.Fprintf(&conteudo, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", l.fonte, l.tamanho, l.x, l.y, codificar(l.texto))
+		}
+		objeto(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			larguraPagina, alturaPagina, 6+2*i))
+		objeto(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", conteudo.Len(), conteudo.String()))
+	}
+
+	inicioXref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, o := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", o)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, inicioXref)
+	return buf.Bytes()
+}
+
+// especiaisWinAnsi mapeia os caracteres comuns fora do Latin-1 para a codificação WinAnsi.
+var especiaisWinAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// codificar converte o texto para WinAnsi e escapa os caracteres especiais de strings PDF.
+func codificar(texto string) string {
+	var b strings.Builder
+	for _, r := range texto {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x80:
+			b.WriteRune(r)
+		case r >= 0xA0 && r <= 0xFF:
+			b.WriteByte(byte(r))
+		default:
+			if c, ok := especiaisWinAnsi[r]; ok {
+				b.WriteByte(c)
+			} else {
+				b.WriteByte('?')
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,31 @@
+// file: pkg/retentativa/retentativa.go
+package retentativa
+
+import "time"
+
+const (
+	base   = 30 * time.Second
+	limite = time.Hour
+)
+
+// Backoff calcula o intervalo até a próxima tentativa: 30s, 1m, 2m, 4m... limitado a 1h.
+func Backoff(tentativas int) time.Duration {
+	if tentativas < 1 {
+		return base
+	}
+	d := base << (tentativas - 1)
+	if d <= 0 || d > limite {
+		return limite
+	}
+	return d
+}
+
+// Proxima retorna quando repetir um envio que já falhou `tentativas` vezes, ou nil
+// quando as tentativas chegaram a maxTentativas e a falha é definitiva.
+func Proxima(tentativas, maxTentativas int, agora time.Time) *time.Time {
+	if tentativas >= maxTentativas {
+		return nil
+	}
+	proxima := agora.Add(Backoff(tentativas))
+	return &proxima
+}
@@ -0,0 +1,59 @@
+package retentativa
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	casos := []struct {
+		tentativas int
+		esperado   time.Duration
+	}{
+		{-1, 30 * time.Second},
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{4, 4 * time.Minute},
+		{7, 32 * time.Minute},
+		{8, time.Hour},
+		{40, time.Hour},
+		{100, time.Hour},
+	}
+	for _, c := range casos {
+		if got := Backoff(c.tentativas); got != c.esperado {
+			t.Errorf("Backoff(%d) = %v, esperado %v", c.tentativas, got, c.esperado)
+		}
+	}
+}
+
+func TestProxima(t *testing.T) {
+	agora := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	casos := []struct {
+		nome       string
+		tentativas int
+		esperado   *time.Duration
+	}{
+		{nome: "primeira falha", tentativas: 1, esperado: ptr(30 * time.Second)},
+		{nome: "penúltima", tentativas: 4, esperado: ptr(4 * time.Minute)},
+		{nome: "esgotadas", tentativas: 5},
+		{nome: "além do limite", tentativas: 6},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			got := Proxima(c.tentativas, 5, agora)
+			if c.esperado == nil {
+				if got != nil {
+					t.Fatalf("Proxima = %v, esperado nil", got)
+				}
+				return
+			}
+			if got == nil || !got.Equal(agora.Add(*c.esperado)) {
+				t.Fatalf("Proxima = %v, esperado %v", got, agora.Add(*c.esperado))
+			}
+		})
+	}
+}
+
+func ptr(d time.Duration) *time.Duration { return &d }
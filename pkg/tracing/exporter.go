@@ -0,0 +1,140 @@
+// file: pkg/tracing/exporter.go
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Exportador recebe lotes de spans finalizados. Implementações OTLP (HTTP/gRPC)
+// convertem DadosSpan para ResourceSpans; o nome do serviço vai em service.name.
+type Exportador interface {
+	Exportar(ctx context.Context, spans []*DadosSpan) error
+	Encerrar(ctx context.Context) error
+}
+
+// ExportadorNulo descarta os spans. É o padrão quando o tracing não está configurado.
+type ExportadorNulo struct{}
+
+func (ExportadorNulo) Exportar(context.Context, []*DadosSpan) error { return nil }
+func (ExportadorNulo) Encerrar(context.Context) error               { return nil }
+
+// ExportadorStdout escreve um span por linha em JSON, útil em desenvolvimento.
+type ExportadorStdout struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NovoExportadorStdout(w io.Writer) *ExportadorStdout {
+	return &ExportadorStdout{enc: json.NewEncoder(w)}
+}
+
+func (e *ExportadorStdout) Exportar(_ context.Context, spans []*DadosSpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, s := range spans {
+		if err := e.enc.Encode(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *ExportadorStdout) Encerrar(context.Context) error { return nil }
+
+// processadorEmLote agrupa spans e os exporta fora do caminho da requisição.
+type processadorEmLote struct {
+	exportador Exportador
+	fila       chan *DadosSpan
+	encerrado  chan struct{} // sinaliza o fim da aceitação de spans
+	concluido  chan struct{} // fechado quando a fila foi drenada e exportada
+	once       sync.Once
+}
+
+const (
+	tamanhoFilaSpans    = 2048
+	tamanhoLoteSpans    = 256
+	intervaloExportacao = 2 * time.Second
+)
+
+func novoProcessadorEmLote(exportador Exportador) *processadorEmLote {
+	p := &processadorEmLote{
+		exportador: exportador,
+		fila:       make(chan *DadosSpan, tamanhoFilaSpans),
+		encerrado:  make(chan struct{}),
+		concluido:  make(chan struct{}),
+	}
+	if _, nulo := exportador.(ExportadorNulo); nulo {
+		close(p.encerrado)
+		close(p.concluido)
+		return p
+	}
+	go p.executar()
+	return p
+}
+
+func (p *processadorEmLote) aoFinalizar(s *DadosSpan) {
+	select {
+	case <-p.encerrado:
+	case p.fila <- s:
+	default:
+		// Fila cheia: descarta em vez de bloquear a aplicação.
+	}
+}
+
+func (p *processadorEmLote) executar() {
+	defer close(p.concluido)
+	ticker := time.NewTicker(intervaloExportacao)
+	defer ticker.Stop()
+	lote := make([]*DadosSpan, 0, tamanhoLoteSpans)
+
+	exportar := func() {
+		if len(lote) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		_ = p.exportador.Exportar(ctx, lote)
+		cancel()
+		lote = make([]*DadosSpan, 0, tamanhoLoteSpans)
+	}
+
+	for {
+		select {
+		case s := <-p.fila:
+			lote = append(lote, s)
+			if len(lote) >= tamanhoLoteSpans {
+				exportar()
+			}
+		case <-ticker.C:
+			exportar()
+		case <-p.encerrado:
+			for {
+				select {
+				case s := <-p.fila:
+					lote = append(lote, s)
+				default:
+					exportar()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (p *processadorEmLote) encerrar(ctx context.Context) error {
+	p.once.Do(func() {
+		select {
+		case <-p.encerrado:
+		default:
+			close(p.encerrado)
+		}
+	})
+	select {
+	case <-ctx.Done():
+	case <-p.concluido:
+	}
+	return p.exportador.Encerrar(ctx)
+}
@@ -0,0 +1,50 @@
+// file: pkg/tracing/http.go
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// HeaderTraceID devolve ao cliente o trace-id da requisição, para correlação em suporte.
+const HeaderTraceID = "X-Trace-ID"
+
+// Middleware cria o span de servidor de cada requisição, continuando o trace
+// recebido em traceparent quando houver.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if sc, err := ParseTraceparent(r.Header.Get(HeaderTraceparent)); err == nil {
+			ctx = ComSpanContextRemoto(ctx, sc)
+		}
+
+		ctx, span := Iniciar(ctx, r.Method, TipoServidor)
+		defer span.Finalizar()
+
+		w.Header().Set(HeaderTraceparent, FormatarTraceparent(span.SpanContext()))
+		w.Header().Set(HeaderTraceID, span.SpanContext().TraceID.String())
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		rota := r.URL.Path
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			rota = rctx.RoutePattern()
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		span.DefinirNome(r.Method + " " + rota)
+		span.DefinirAtributo("http.request.method", r.Method)
+		span.DefinirAtributo("http.route", rota)
+		span.DefinirAtributo("url.path", r.URL.Path)
+		span.DefinirAtributo("http.response.status_code", status)
+		if status >= http.StatusInternalServerError {
+			span.DefinirStatus(StatusErro, http.StatusText(status))
+		}
+	})
+}
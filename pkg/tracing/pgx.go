@@ -0,0 +1,51 @@
+// file: pkg/tracing/pgx.go
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// PgxTracer implementa pgx.QueryTracer, criando um span de cliente por query.
+// Basta atribuí-lo a pgxpool.Config.ConnConfig.Tracer para cobrir todos os repositórios.
+type PgxTracer struct{}
+
+type chaveSpanQuery struct{}
+
+func (PgxTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, span := Iniciar(ctx, "db "+operacaoSQL(data.SQL), TipoCliente)
+	span.DefinirAtributo("db.system", "postgresql")
+	span.DefinirAtributo("db.statement", compactarSQL(data.SQL))
+	return context.WithValue(ctx, chaveSpanQuery{}, span)
+}
+
+func (PgxTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span, ok := ctx.Value(chaveSpanQuery{}).(*Span)
+	if !ok {
+		return
+	}
+	if data.Err != nil && data.Err != pgx.ErrNoRows {
+		span.RegistrarErro(data.Err)
+	}
+	span.DefinirAtributo("db.rows_affected", data.CommandTag.RowsAffected())
+	span.Finalizar()
+}
+
+func operacaoSQL(sql string) string {
+	campos := strings.Fields(sql)
+	if len(campos) == 0 {
+		return "query"
+	}
+	return strings.ToUpper(campos[0])
+}
+
+func compactarSQL(sql string) string {
+	s := strings.Join(strings.Fields(sql), " ")
+	const limite = 1000
+	if len(s) > limite {
+		return s[:limite] + "..."
+	}
+	return s
+}
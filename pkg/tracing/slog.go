@@ -0,0 +1,35 @@
+// file: pkg/tracing/slog.go
+package tracing
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogHandler adiciona trace_id e span_id aos registros emitidos com um contexto
+// (InfoContext, ErrorContext...), ligando logs de handlers assíncronos à requisição de origem.
+type LogHandler struct {
+	slog.Handler
+}
+
+func NovoLogHandler(h slog.Handler) *LogHandler {
+	return &LogHandler{Handler: h}
+}
+
+func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if sc := SpanContextDoContexto(ctx); sc.Valido() {
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID.String()),
+			slog.String("span_id", sc.SpanID.String()),
+		)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LogHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,53 @@
+// file: pkg/tracing/traceparent.go
+package tracing
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// HeaderTraceparent é o cabeçalho definido pelo W3C Trace Context.
+const HeaderTraceparent = "traceparent"
+
+// ParseTraceparent interpreta um cabeçalho no formato "00-<trace-id>-<span-id>-<flags>".
+func ParseTraceparent(valor string) (SpanContext, error) {
+	partes := strings.Split(strings.TrimSpace(valor), "-")
+	if len(partes) < 4 {
+		return SpanContext{}, fmt.Errorf("traceparent inválido: %q", valor)
+	}
+	versao, trace, span, flags := partes[0], partes[1], partes[2], partes[3]
+	if len(versao) != 2 || versao == "ff" || (versao == "00" && len(partes) != 4) {
+		return SpanContext{}, fmt.Errorf("versão de traceparent não suportada: %q", versao)
+	}
+	if len(trace) != 32 || len(span) != 16 || len(flags) != 2 {
+		return SpanContext{}, fmt.Errorf("traceparent inválido: %q", valor)
+	}
+
+	var sc SpanContext
+	if _, err := hex.Decode(sc.TraceID[:], []byte(trace)); err != nil {
+		return SpanContext{}, fmt.Errorf("trace-id inválido: %w", err)
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(span)); err != nil {
+		return SpanContext{}, fmt.Errorf("parent-id inválido: %w", err)
+	}
+	var f [1]byte
+	if _, err := hex.Decode(f[:], []byte(flags)); err != nil {
+		return SpanContext{}, fmt.Errorf("trace-flags inválido: %w", err)
+	}
+	if !sc.Valido() {
+		return SpanContext{}, fmt.Errorf("traceparent com IDs zerados: %q", valor)
+	}
+	sc.Amostrado = f[0]&0x01 == 0x01
+	sc.Remoto = true
+	return sc, nil
+}
+
+// FormatarTraceparent gera o cabeçalho traceparent para o SpanContext.
+func FormatarTraceparent(sc SpanContext) string {
+	flags := "00"
+	if sc.Amostrado {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
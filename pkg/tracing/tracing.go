@@ -0,0 +1,256 @@
+// file: pkg/tracing/tracing.go
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TipoSpan segue os valores de SpanKind do OTLP.
+type TipoSpan int
+
+const (
+	TipoInterno    TipoSpan = 1
+	TipoServidor   TipoSpan = 2
+	TipoCliente    TipoSpan = 3
+	TipoProdutor   TipoSpan = 4
+	TipoConsumidor TipoSpan = 5
+)
+
+// Códigos de status seguem o StatusCode do OTLP.
+const (
+	StatusNaoDefinido = 0
+	StatusOk          = 1
+	StatusErro        = 2
+)
+
+type (
+	TraceID [16]byte
+	SpanID  [8]byte
+)
+
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+func (s SpanID) String() string  { return hex.EncodeToString(s[:]) }
+func (t TraceID) Valido() bool   { return t != TraceID{} }
+func (s SpanID) Valido() bool    { return s != SpanID{} }
+
+// SpanContext é a parte de um span propagada entre processos (traceparent).
+type SpanContext struct {
+	TraceID   TraceID
+	SpanID    SpanID
+	Amostrado bool
+	Remoto    bool
+}
+
+func (sc SpanContext) Valido() bool { return sc.TraceID.Valido() && sc.SpanID.Valido() }
+
+// DadosSpan é o span finalizado entregue ao exportador. Os campos espelham o
+// modelo de Span do OTLP para que um exportador OTLP possa convertê-los diretamente.
+type DadosSpan struct {
+	Servico           string         `json:"serviceName"`
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Nome              string         `json:"name"`
+	Tipo              TipoSpan       `json:"kind"`
+	StartTimeUnixNano int64          `json:"startTimeUnixNano,string"`
+	EndTimeUnixNano   int64          `json:"endTimeUnixNano,string"`
+	Atributos         map[string]any `json:"attributes,omitempty"`
+	StatusCodigo      int            `json:"statusCode"`
+	StatusMensagem    string         `json:"statusMessage,omitempty"`
+}
+
+// Span representa uma operação em andamento. Métodos são seguros para uso concorrente
+// e não fazem nada quando o span não é amostrado.
+type Span struct {
+	tracer     *Tracer
+	sc         SpanContext
+	pai        SpanID
+	nome       string
+	tipo       TipoSpan
+	inicio     time.Time
+	mu         sync.Mutex
+	atributos  map[string]any
+	status     int
+	mensagem   string
+	finalizado bool
+}
+
+// SpanContext retorna o contexto propagável do span.
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// DefinirNome altera o nome do span (ex.: quando o padrão de rota só é conhecido ao final).
+func (s *Span) DefinirNome(nome string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.nome = nome
+	s.mu.Unlock()
+}
+
+// DefinirAtributo adiciona um atributo ao span.
+func (s *Span) DefinirAtributo(chave string, valor any) {
+	if s == nil || !s.sc.Amostrado {
+		return
+	}
+	s.mu.Lock()
+	if s.atributos == nil {
+		s.atributos = make(map[string]any)
+	}
+	s.atributos[chave] = valor
+	s.mu.Unlock()
+}
+
+// RegistrarErro marca o span como erro.
+func (s *Span) RegistrarErro(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	s.status = StatusErro
+	s.mensagem = err.Error()
+	s.mu.Unlock()
+}
+
+// DefinirStatus define explicitamente o status do span (StatusOk ou StatusErro).
+func (s *Span) DefinirStatus(codigo int, mensagem string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.status = codigo
+	s.mensagem = mensagem
+	s.mu.Unlock()
+}
+
+// Finalizar encerra o span e o envia ao exportador. Chamadas repetidas são ignoradas.
+func (s *Span) Finalizar() {
+	if s == nil {
+		return
+	}
+	fim := time.Now()
+	s.mu.Lock()
+	if s.finalizado {
+		s.mu.Unlock()
+		return
+	}
+	s.finalizado = true
+	dados := &DadosSpan{
+		Servico:           s.tracer.servico,
+		TraceID:           s.sc.TraceID.String(),
+		SpanID:            s.sc.SpanID.String(),
+		Nome:              s.nome,
+		Tipo:              s.tipo,
+		StartTimeUnixNano: s.inicio.UnixNano(),
+		EndTimeUnixNano:   fim.UnixNano(),
+		Atributos:         s.atributos,
+		StatusCodigo:      s.status,
+		StatusMensagem:    s.mensagem,
+	}
+	if s.pai.Valido() {
+		dados.ParentSpanID = s.pai.String()
+	}
+	s.mu.Unlock()
+
+	if s.sc.Amostrado {
+		s.tracer.processador.aoFinalizar(dados)
+	}
+}
+
+// Tracer cria spans e os entrega ao processador configurado.
+type Tracer struct {
+	servico     string
+	processador *processadorEmLote
+}
+
+// NovoTracer cria um tracer que exporta em lote pelo exportador informado.
+func NovoTracer(servico string, exportador Exportador) *Tracer {
+	return &Tracer{servico: servico, processador: novoProcessadorEmLote(exportador)}
+}
+
+// Encerrar envia os spans pendentes e fecha o exportador.
+func (t *Tracer) Encerrar(ctx context.Context) error {
+	return t.processador.encerrar(ctx)
+}
+
+// Iniciar cria um span filho do span presente em ctx (ou raiz, se não houver).
+func (t *Tracer) Iniciar(ctx context.Context, nome string, tipo TipoSpan) (context.Context, *Span) {
+	pai := SpanContextDoContexto(ctx)
+
+	s := &Span{tracer: t, nome: nome, tipo: tipo, inicio: time.Now()}
+	if pai.Valido() {
+		s.sc.TraceID = pai.TraceID
+		s.sc.Amostrado = pai.Amostrado
+		s.pai = pai.SpanID
+	} else {
+		s.sc.TraceID = novoTraceID()
+		s.sc.Amostrado = true
+	}
+	s.sc.SpanID = novoSpanID()
+
+	return context.WithValue(ctx, chaveSpan{}, s), s
+}
+
+// --- Contexto ---
+
+type chaveSpan struct{}
+type chaveSpanRemoto struct{}
+
+// SpanDoContexto retorna o span ativo, ou nil.
+func SpanDoContexto(ctx context.Context) *Span {
+	s, _ := ctx.Value(chaveSpan{}).(*Span)
+	return s
+}
+
+// SpanContextDoContexto retorna o SpanContext ativo, local ou recebido via traceparent.
+func SpanContextDoContexto(ctx context.Context) SpanContext {
+	if s := SpanDoContexto(ctx); s != nil {
+		return s.sc
+	}
+	sc, _ := ctx.Value(chaveSpanRemoto{}).(SpanContext)
+	return sc
+}
+
+// ComSpanContextRemoto associa ao contexto um SpanContext recebido de outro processo.
+func ComSpanContextRemoto(ctx context.Context, sc SpanContext) context.Context {
+	sc.Remoto = true
+	return context.WithValue(ctx, chaveSpanRemoto{}, sc)
+}
+
+// --- Tracer global ---
+
+var tracerPadrao atomic.Pointer[Tracer]
+
+func init() {
+	tracerPadrao.Store(NovoTracer("", ExportadorNulo{}))
+}
+
+// DefinirTracerPadrao troca o tracer usado por Iniciar.
+func DefinirTracerPadrao(t *Tracer) {
+	tracerPadrao.Store(t)
+}
+
+// Iniciar cria um span usando o tracer padrão.
+func Iniciar(ctx context.Context, nome string, tipo TipoSpan) (context.Context, *Span) {
+	return tracerPadrao.Load().Iniciar(ctx, nome, tipo)
+}
+
+func novoTraceID() (id TraceID) {
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func novoSpanID() (id SpanID) {
+	_, _ = rand.Read(id[:])
+	return id
+}